@@ -5,6 +5,7 @@ package action
 
 import (
 	"github.com/juju/errors"
+	"github.com/juju/names"
 	"gopkg.in/juju/charm.v5"
 
 	"github.com/juju/juju/api/base"
@@ -76,13 +77,30 @@ func (c *Client) ListCompleted(arg params.Entities) (params.ActionsByReceivers,
 	return results, err
 }
 
-// Cancel attempts to cancel a queued up Action from running.
-func (c *Client) Cancel(arg params.Actions) (params.ActionResults, error) {
+// Cancel takes a list of ActionTags and attempts to cancel each of
+// those queued up Actions from running.
+func (c *Client) Cancel(arg params.Entities) (params.ActionResults, error) {
 	results := params.ActionResults{}
 	err := c.facade.FacadeCall("Cancel", arg, &results)
 	return results, err
 }
 
+// Abort takes a list of ActionTags and attempts to stop each of those
+// Actions, whether they are queued up or already running.
+func (c *Client) Abort(arg params.Entities) (params.ActionResults, error) {
+	results := params.ActionResults{}
+	err := c.facade.FacadeCall("Abort", arg, &results)
+	return results, err
+}
+
+// Output returns the output produced so far by each of the given
+// Actions, identified by ActionTag.
+func (c *Client) Output(arg params.OutputQueries) (params.OutputResults, error) {
+	results := params.OutputResults{}
+	err := c.facade.FacadeCall("Output", arg, &results)
+	return results, err
+}
+
 // servicesCharmActions is a batched query for the charm.Actions for a slice
 // of services by Entity.
 func (c *Client) servicesCharmActions(arg params.Entities) (params.ServicesCharmActionsResults, error) {
@@ -112,3 +130,61 @@ func (c *Client) ServiceCharmActions(arg params.Entity) (*charm.Actions, error)
 	}
 	return result.Actions, nil
 }
+
+// servicesUnits is a batched query for the tags of the alive units of
+// a slice of services by Entity.
+func (c *Client) servicesUnits(arg params.Entities) (params.StringsResults, error) {
+	results := params.StringsResults{}
+	err := c.facade.FacadeCall("ServicesUnits", arg, &results)
+	return results, err
+}
+
+// ServiceUnits is a single query which uses ServicesUnits to get the
+// tags of the alive units of a single Service by tag, in unit number
+// order.
+func (c *Client) ServiceUnits(arg params.Entity) ([]names.UnitTag, error) {
+	tags := params.Entities{Entities: []params.Entity{{Tag: arg.Tag}}}
+	results, err := c.servicesUnits(tags)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("%d results, expected 1", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	unitTags := make([]names.UnitTag, len(result.Result))
+	for i, tag := range result.Result {
+		unitTag, err := names.ParseUnitTag(tag)
+		if err != nil {
+			return nil, err
+		}
+		unitTags[i] = unitTag
+	}
+	return unitTags, nil
+}
+
+// AddSchedules adds schedules on which Actions are queued up
+// repeatedly.
+func (c *Client) AddSchedules(arg params.ActionSchedules) (params.ErrorResults, error) {
+	results := params.ErrorResults{}
+	err := c.facade.FacadeCall("AddSchedules", arg, &results)
+	return results, err
+}
+
+// ListSchedules returns all of the schedules in the environment, with
+// the most recent runs of each.
+func (c *Client) ListSchedules() ([]params.ActionSchedule, error) {
+	results := params.ActionSchedules{}
+	err := c.facade.FacadeCall("ListSchedules", nil, &results)
+	return results.Schedules, err
+}
+
+// RemoveSchedules removes the schedules with the given names.
+func (c *Client) RemoveSchedules(arg params.ActionScheduleNames) (params.ErrorResults, error) {
+	results := params.ErrorResults{}
+	err := c.facade.FacadeCall("RemoveSchedules", arg, &results)
+	return results, err
+}
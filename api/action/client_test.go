@@ -114,6 +114,73 @@ func (s *actionSuite) TestServiceCharmActions(c *gc.C) {
 	}
 }
 
+func (s *actionSuite) TestServiceUnits(c *gc.C) {
+	tests := []struct {
+		description    string
+		patchResults   []params.StringsResult
+		patchErr       string
+		expectedErr    string
+		expectedResult []names.UnitTag
+	}{{
+		description: "some other error",
+		patchResults: []params.StringsResult{{
+			Error: &params.Error{Message: "something bad"},
+		}},
+		expectedErr: "something bad",
+	}, {
+		description:  "more than one result",
+		patchResults: []params.StringsResult{{}, {}},
+		expectedErr:  "2 results, expected 1",
+	}, {
+		description: "error on facade call",
+		patchErr:    "something went wrong",
+		expectedErr: "something went wrong",
+	}, {
+		description: "invalid unit tag",
+		patchResults: []params.StringsResult{{
+			Result: []string{"service-foo"},
+		}},
+		expectedErr: `"service-foo" is not a valid unit tag`,
+	}, {
+		description: "normal result",
+		patchResults: []params.StringsResult{{
+			Result: []string{"unit-foo-0", "unit-foo-2"},
+		}},
+		expectedResult: []names.UnitTag{
+			names.NewUnitTag("foo/0"),
+			names.NewUnitTag("foo/2"),
+		},
+	}}
+
+	for i, t := range tests {
+		func() {
+			c.Logf("test %d: %s", i, t.description)
+			cleanup := action.PatchClientFacadeCall(s.client,
+				func(req string, paramsIn interface{}, resp interface{}) error {
+					c.Assert(req, gc.Equals, "ServicesUnits")
+					c.Assert(paramsIn, jc.DeepEquals, params.Entities{
+						Entities: []params.Entity{{Tag: "service-foo"}},
+					})
+					result := resp.(*params.StringsResults)
+					result.Results = t.patchResults
+					if t.patchErr != "" {
+						return errors.New(t.patchErr)
+					}
+					return nil
+				},
+			)
+			defer cleanup()
+			result, err := s.client.ServiceUnits(params.Entity{Tag: names.NewServiceTag("foo").String()})
+			if t.expectedErr != "" {
+				c.Check(err, gc.ErrorMatches, t.expectedErr)
+			} else {
+				c.Check(err, jc.ErrorIsNil)
+				c.Check(result, jc.DeepEquals, t.expectedResult)
+			}
+		}()
+	}
+}
+
 // replace "ServicesCharmActions" facade call with required results and error
 // if desired
 func patchServiceCharmActions(c *gc.C, apiCli *action.Client, patchResults []params.ServiceCharmActionsResult, err string) func() {
@@ -0,0 +1,75 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/auditlog"
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type auditLogMockSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&auditLogMockSuite{})
+
+func (s *auditLogMockSuite) TestEntries(c *gc.C) {
+	since := time.Date(2015, 6, 1, 0, 0, 0, 0, time.UTC)
+	filter := params.AuditLogFilter{
+		User:   "bob",
+		Facade: "Client",
+		Since:  &since,
+	}
+	expected := []params.AuditLogEntry{{
+		Timestamp: since.Add(time.Hour),
+		User:      "user-bob@local",
+		Facade:    "Client",
+		Method:    "ServiceDeploy",
+	}}
+	called := false
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, response interface{},
+		) error {
+			called = true
+			c.Check(objType, gc.Equals, "AuditLog")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "Entries")
+			c.Check(a, jc.DeepEquals, filter)
+
+			result, ok := response.(*params.AuditLogResults)
+			c.Assert(ok, jc.IsTrue)
+			result.Entries = expected
+			return nil
+		})
+	client := auditlog.NewClient(apiCaller)
+	entries, err := client.Entries(filter)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(entries, jc.DeepEquals, expected)
+}
+
+func (s *auditLogMockSuite) TestEntriesError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, response interface{},
+		) error {
+			return errors.New("boom")
+		})
+	client := auditlog.NewClient(apiCaller)
+	_, err := client.Entries(params.AuditLogFilter{})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -127,6 +127,15 @@ type UnitStatus struct {
 	PublicAddress string
 	Charm         string
 	Subordinates  map[string]UnitStatus
+	HealthChecks  map[string]HealthCheckStatus
+}
+
+// HealthCheckStatus holds the outcome of one of the health checks
+// declared by a unit's charm.
+type HealthCheckStatus struct {
+	Healthy bool
+	Message string
+	Since   *time.Time
 }
 
 // RelationStatus holds status info about a relation.
@@ -196,6 +205,26 @@ func (c *Client) UnitStatusHistory(kind params.HistoryKind, unitName string, siz
 	return &results, nil
 }
 
+// StatusHistory retrieves the past statuses of <kind:combined|agent|workload>
+// for the unit, machine or service with the given tag that match the
+// filter, oldest first.
+func (c *Client) StatusHistory(kind params.HistoryKind, tag names.Tag, filter params.StatusHistoryFilter) (*params.StatusHistoryResult, error) {
+	var result params.StatusHistoryResult
+	args := params.StatusHistoryArgs{
+		Kind:   kind,
+		Tag:    tag.String(),
+		Filter: filter,
+	}
+	err := c.facade.FacadeCall("StatusHistory", args, &result)
+	if err != nil {
+		if params.IsCodeNotImplemented(err) {
+			return &params.StatusHistoryResult{}, errors.NotImplementedf("StatusHistory")
+		}
+		return &params.StatusHistoryResult{}, errors.Trace(err)
+	}
+	return &result, nil
+}
+
 // LegacyMachineStatus holds just the instance-id of a machine.
 type LegacyMachineStatus struct {
 	InstanceId string // Not type instance.Id just to match original api.
@@ -245,6 +274,16 @@ func (c *Client) Resolved(unit string, retry bool) error {
 	return c.facade.FacadeCall("Resolved", p, nil)
 }
 
+// CancelHookRetry cancels the automatic retry of a unit's failed hook,
+// leaving the unit in an error state until it is resolved.
+func (c *Client) CancelHookRetry(unit string) error {
+	p := params.Resolved{
+		UnitName:    unit,
+		CancelRetry: true,
+	}
+	return c.facade.FacadeCall("Resolved", p, nil)
+}
+
 // RetryProvisioning updates the provisioning status of a machine allowing the
 // provisioner to retry.
 func (c *Client) RetryProvisioning(machines ...names.MachineTag) ([]params.ErrorResult, error) {
@@ -364,9 +403,10 @@ func (c *Client) ForceDestroyMachines(machines ...string) error {
 }
 
 // ServiceExpose changes the juju-managed firewall to expose any ports that
-// were also explicitly marked by units as open.
-func (c *Client) ServiceExpose(service string) error {
-	params := params.ServiceExpose{ServiceName: service}
+// were also explicitly marked by units as open. If source CIDRs are
+// given, the ports are only opened to those networks.
+func (c *Client) ServiceExpose(service string, sourceCIDRs ...string) error {
+	params := params.ServiceExpose{ServiceName: service, SourceCIDRs: sourceCIDRs}
 	return c.facade.FacadeCall("ServiceExpose", params, nil)
 }
 
@@ -377,6 +417,28 @@ func (c *Client) ServiceUnexpose(service string) error {
 	return c.facade.FacadeCall("ServiceUnexpose", params, nil)
 }
 
+// ServiceGetHookRetryPolicy returns the policy by which the failed hooks
+// of a service's units are retried automatically.
+func (c *Client) ServiceGetHookRetryPolicy(service string) (*params.ServiceHookRetryPolicyResults, error) {
+	var results params.ServiceHookRetryPolicyResults
+	params := params.ServiceGet{ServiceName: service}
+	if err := c.facade.FacadeCall("ServiceGetHookRetryPolicy", params, &results); err != nil {
+		return nil, err
+	}
+	return &results, nil
+}
+
+// ServiceSetHookRetryPolicy sets the policy by which the failed hooks of
+// a service's units are retried automatically. A nil policy reverts the
+// service to the environment's policy.
+func (c *Client) ServiceSetHookRetryPolicy(service string, policy *params.HookRetryPolicy) error {
+	p := params.ServiceSetHookRetryPolicy{
+		ServiceName: service,
+		Policy:      policy,
+	}
+	return c.facade.FacadeCall("ServiceSetHookRetryPolicy", p, nil)
+}
+
 // ServiceDeployWithNetworks works exactly like ServiceDeploy, but
 // allows the specification of requested networks that must be present
 // on the machines where the service is deployed. Another way to specify
@@ -549,12 +611,22 @@ func (c *Client) EnvironmentUUID() string {
 
 // ShareEnvironment allows the given users access to the environment.
 func (c *Client) ShareEnvironment(users ...names.UserTag) error {
+	return c.ShareEnvironmentWithAccess("", users...)
+}
+
+// ShareEnvironmentWithAccess allows the given users the given level of
+// access ("read", "write" or "admin") to the environment. Users who
+// already have access to the environment have their access changed to
+// the given level. If access is empty, new users are given write access
+// and existing users are left unchanged.
+func (c *Client) ShareEnvironmentWithAccess(access string, users ...names.UserTag) error {
 	var args params.ModifyEnvironUsers
 	for _, user := range users {
 		if &user != nil {
 			args.Changes = append(args.Changes, params.ModifyEnvironUser{
 				UserTag: user.String(),
 				Action:  params.AddEnvUser,
+				Access:  access,
 			})
 		}
 	}
@@ -675,6 +747,18 @@ func (c *Client) EnvironmentSet(config map[string]interface{}) error {
 	return c.facade.FacadeCall("EnvironmentSet", args, nil)
 }
 
+// EnvironmentUpdateCredentials replaces the provider credentials of
+// the environment with the given ones, once the provider has accepted
+// them. The result reports which state server workers picked up the
+// new credentials, and the machines whose provisioning, which failed
+// because the provider rejected the old credentials, is retried.
+func (c *Client) EnvironmentUpdateCredentials(credentials map[string]interface{}) (params.UpdateCredentialsResult, error) {
+	args := params.EnvironmentSet{Config: credentials}
+	var result params.UpdateCredentialsResult
+	err := c.facade.FacadeCall("EnvironmentUpdateCredentials", args, &result)
+	return result, err
+}
+
 // EnvironmentUnset sets the given key-value pairs in the environment.
 func (c *Client) EnvironmentUnset(keys ...string) error {
 	args := params.EnvironmentUnset{Keys: keys}
@@ -726,6 +810,26 @@ func (c *Client) Run(run params.RunParams) ([]params.RunResult, error) {
 	return results.Results, err
 }
 
+// RunOutput returns the output produced so far by commands that were
+// run on units with the given output id. For each unit named in after,
+// only the chunks that follow the given sequence number are returned.
+func (c *Client) RunOutput(outputId string, after map[string]int) ([]params.OutputChunk, error) {
+	args := params.OutputQueries{
+		Queries: []params.OutputQuery{{Id: outputId, After: after}},
+	}
+	var results params.OutputResults
+	if err := c.facade.FacadeCall("RunOutput", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results.Results[0].Chunks, nil
+}
+
 // DestroyEnvironment puts the environment into a "dying" state,
 // and removes all non-manager machine instances. DestroyEnvironment
 // will fail if there are any manually-provisioned non-manager machines
@@ -259,6 +259,36 @@ func (s *clientSuite) TestShareEnvironmentExistingUser(c *gc.C) {
 	c.Assert(c.GetTestLog(), jc.Contains, logMsg)
 }
 
+func (s *clientSuite) TestShareEnvironmentWithAccess(c *gc.C) {
+	client := s.APIState.Client()
+	user := names.NewUserTag("foo@bar")
+	var called bool
+	cleanup := api.PatchClientFacadeCall(client,
+		func(request string, paramsIn interface{}, response interface{}) error {
+			called = true
+			c.Assert(request, gc.Equals, "ShareEnvironment")
+			c.Assert(paramsIn, jc.DeepEquals, params.ModifyEnvironUsers{
+				Changes: []params.ModifyEnvironUser{{
+					UserTag: user.String(),
+					Action:  params.AddEnvUser,
+					Access:  "read",
+				}},
+			})
+			if result, ok := response.(*params.ErrorResults); ok {
+				*result = params.ErrorResults{Results: []params.ErrorResult{{}}}
+			} else {
+				c.Fatalf("wrong output structure")
+			}
+			return nil
+		},
+	)
+	defer cleanup()
+
+	err := client.ShareEnvironmentWithAccess("read", user)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
 func (s *clientSuite) TestDestroyEnvironment(c *gc.C) {
 	client := s.APIState.Client()
 	var called bool
@@ -579,6 +609,18 @@ func (s *clientSuite) TestEnvironmentSet(c *gc.C) {
 	c.Assert(env["other-name"], gc.Equals, true)
 }
 
+func (s *clientSuite) TestEnvironmentUpdateCredentials(c *gc.C) {
+	client := s.APIState.Client()
+	result, err := client.EnvironmentUpdateCredentials(map[string]interface{}{
+		"secret": "new secret",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.RetriedMachines, gc.HasLen, 0)
+	env, err := client.EnvironmentGet()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(env["secret"], gc.Equals, "new secret")
+}
+
 func (s *clientSuite) TestEnvironmentUnset(c *gc.C) {
 	client := s.APIState.Client()
 	err := client.EnvironmentSet(map[string]interface{}{
@@ -0,0 +1,32 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import (
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// CredentialsLoadedSetter provides common client-side API functions
+// to call into apiserver.common.CredentialsLoadedSetter.
+type CredentialsLoadedSetter struct {
+	facade base.FacadeCaller
+}
+
+// NewCredentialsLoadedSetter creates a CredentialsLoadedSetter on the
+// specified facade, and uses this name when calling through the caller.
+func NewCredentialsLoadedSetter(facade base.FacadeCaller) *CredentialsLoadedSetter {
+	return &CredentialsLoadedSetter{facade}
+}
+
+// SetCredentialsLoaded records that the named worker has opened the
+// environment with the provider credentials that have the given
+// fingerprint.
+func (s *CredentialsLoadedSetter) SetCredentialsLoaded(worker, fingerprint string) error {
+	args := params.CredentialsLoaded{
+		Worker:      worker,
+		Fingerprint: fingerprint,
+	}
+	return s.facade.FacadeCall("SetCredentialsLoaded", args, nil)
+}
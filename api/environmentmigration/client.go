@@ -0,0 +1,41 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package environmentmigration
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client allows access to the environment migration API end point.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the environment
+// migration API.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "EnvironmentMigration")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// Export returns the serialized description of the current
+// environment's model.
+func (c *Client) Export() ([]byte, error) {
+	var result params.SerializedEnvironment
+	if err := c.facade.FacadeCall("Export", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Bytes, nil
+}
+
+// Import recreates the model in the serialized description in the
+// current environment, which must not yet have any services.
+func (c *Client) Import(data []byte) error {
+	args := params.SerializedEnvironment{Bytes: data}
+	return errors.Trace(c.facade.FacadeCall("Import", args, nil))
+}
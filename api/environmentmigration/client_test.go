@@ -0,0 +1,82 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package environmentmigration_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/environmentmigration"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type migrationMockSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&migrationMockSuite{})
+
+func (s *migrationMockSuite) TestExport(c *gc.C) {
+	called := false
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, response interface{},
+		) error {
+			called = true
+			c.Check(objType, gc.Equals, "EnvironmentMigration")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "Export")
+			c.Check(a, gc.IsNil)
+			result, ok := response.(*params.SerializedEnvironment)
+			c.Assert(ok, jc.IsTrue)
+			result.Bytes = []byte("version: 1\n")
+			return nil
+		})
+	client := environmentmigration.NewClient(apiCaller)
+	data, err := client.Export()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(string(data), gc.Equals, "version: 1\n")
+}
+
+func (s *migrationMockSuite) TestImport(c *gc.C) {
+	called := false
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, response interface{},
+		) error {
+			called = true
+			c.Check(objType, gc.Equals, "EnvironmentMigration")
+			c.Check(request, gc.Equals, "Import")
+			c.Check(a, jc.DeepEquals, params.SerializedEnvironment{
+				Bytes: []byte("version: 1\n"),
+			})
+			return nil
+		})
+	client := environmentmigration.NewClient(apiCaller)
+	err := client.Import([]byte("version: 1\n"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *migrationMockSuite) TestImportError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, response interface{},
+		) error {
+			return errors.New("boom")
+		})
+	client := environmentmigration.NewClient(apiCaller)
+	err := client.Import(nil)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -15,6 +15,7 @@ var facadeVersions = map[string]int{
 	"Agent":                        1,
 	"AllWatcher":                   0,
 	"Annotations":                  1,
+	"AuditLog":                     1,
 	"Backups":                      0,
 	"Block":                        1,
 	"Charms":                       1,
@@ -25,6 +26,7 @@ var facadeVersions = map[string]int{
 	"DiskManager":                  1,
 	"Environment":                  0,
 	"EnvironmentManager":           1,
+	"EnvironmentMigration":         1,
 	"FilesystemAttachmentsWatcher": 1,
 	"Firewaller":                   1,
 	"HighAvailability":             1,
@@ -32,6 +34,7 @@ var facadeVersions = map[string]int{
 	"InstancePoller":               1,
 	"KeyManager":                   0,
 	"KeyUpdater":                   0,
+	"LeadershipAdmin":              1,
 	"LeadershipService":            1,
 	"Logger":                       0,
 	"MachineManager":               1,
@@ -39,6 +42,7 @@ var facadeVersions = map[string]int{
 	"MetricsManager":               0,
 	"Networker":                    0,
 	"NotifyWatcher":                0,
+	"Offers":                       1,
 	"Pinger":                       0,
 	"Provisioner":                  1,
 	"Reboot":                       1,
@@ -46,6 +50,7 @@ var facadeVersions = map[string]int{
 	"Resumer":                      1,
 	"Rsyslog":                      0,
 	"Service":                      1,
+	"Spaces":                       1,
 	"Storage":                      1,
 	"StorageProvisioner":           1,
 	"StringsWatcher":               0,
@@ -19,14 +19,16 @@ const firewallerFacade = "Firewaller"
 type State struct {
 	facade base.FacadeCaller
 	*common.EnvironWatcher
+	*common.CredentialsLoadedSetter
 }
 
 // NewState creates a new client-side Firewaller API facade.
 func NewState(caller base.APICaller) *State {
 	facadeCaller := base.NewFacadeCaller(caller, firewallerFacade)
 	return &State{
-		facade:         facadeCaller,
-		EnvironWatcher: common.NewEnvironWatcher(facadeCaller),
+		facade:                  facadeCaller,
+		EnvironWatcher:          common.NewEnvironWatcher(facadeCaller),
+		CredentialsLoadedSetter: common.NewCredentialsLoadedSetter(facadeCaller),
 	}
 }
 
@@ -18,6 +18,7 @@ const instancePollerFacade = "InstancePoller"
 // API provides access to the InstancePoller API facade.
 type API struct {
 	*common.EnvironWatcher
+	*common.CredentialsLoadedSetter
 
 	facade base.FacadeCaller
 }
@@ -29,8 +30,9 @@ func NewAPI(caller base.APICaller) *API {
 	}
 	facadeCaller := base.NewFacadeCaller(caller, instancePollerFacade)
 	return &API{
-		EnvironWatcher: common.NewEnvironWatcher(facadeCaller),
-		facade:         facadeCaller,
+		EnvironWatcher:          common.NewEnvironWatcher(facadeCaller),
+		CredentialsLoadedSetter: common.NewCredentialsLoadedSetter(facadeCaller),
+		facade:                  facadeCaller,
 	}
 }
 
@@ -187,3 +187,17 @@ func (m *Machine) SetProviderAddresses(addrs ...network.Address) error {
 	}
 	return result.OneError()
 }
+
+// MarkReclaimed records that the machine's instance has been reclaimed
+// by the cloud provider, so that a replacement is started for it.
+func (m *Machine) MarkReclaimed() error {
+	var result params.ErrorResults
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: m.tag.String()},
+	}}
+	err := m.facade.FacadeCall("MarkReclaimed", args, &result)
+	if err != nil {
+		return err
+	}
+	return result.OneError()
+}
@@ -111,6 +111,12 @@ var machineErrorTests = []struct {
 		return m.SetProviderAddresses()
 	},
 	resultsRef: params.ErrorResults{},
+}, {
+	method: "MarkReclaimed",
+	wrapper: func(m *instancepoller.Machine) error {
+		return m.MarkReclaimed()
+	},
+	resultsRef: params.ErrorResults{},
 }}
 
 func (s *MachineSuite) TestClientError(c *gc.C) {
@@ -266,6 +272,18 @@ func (s *MachineSuite) TestSetProviderAddressesSuccess(c *gc.C) {
 	c.Check(called, gc.Equals, 1)
 }
 
+func (s *MachineSuite) TestMarkReclaimedSuccess(c *gc.C) {
+	var called int
+	results := params.ErrorResults{
+		Results: []params.ErrorResult{{Error: nil}},
+	}
+	apiCaller := successAPICaller(c, "MarkReclaimed", entitiesArgs, results, &called)
+	machine := instancepoller.NewMachine(apiCaller, s.tag, params.Alive)
+	err := machine.MarkReclaimed()
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(called, gc.Equals, 1)
+}
+
 func (s *MachineSuite) CheckClientError(c *gc.C, wf methodWrapper) {
 	var called int
 	apiCaller := clientErrorAPICaller(c, "", nil, &called)
@@ -0,0 +1,63 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package leadership
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// AdminClient provides access to the LeadershipAdmin API, which is used
+// to see which units are the leaders of their services, and to move
+// leadership between units.
+type AdminClient struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewAdminClient returns a new LeadershipAdmin client.
+func NewAdminClient(st base.APICallCloser) *AdminClient {
+	frontend, backend := base.NewClientFacade(st, "LeadershipAdmin")
+	return &AdminClient{ClientFacade: frontend, facade: backend}
+}
+
+// Leaders returns the leader of each of the named services, and when
+// its lease expires. If no services are named, the leaders of all of the
+// services in the environment are returned.
+func (c *AdminClient) Leaders(serviceNames ...string) ([]params.ServiceLeadershipResult, error) {
+	args := params.Entities{Entities: make([]params.Entity, len(serviceNames))}
+	for i, serviceName := range serviceNames {
+		if !names.IsValidService(serviceName) {
+			return nil, errors.NotValidf("service name %q", serviceName)
+		}
+		args.Entities[i].Tag = names.NewServiceTag(serviceName).String()
+	}
+	var results params.ServiceLeadershipResults
+	if err := c.facade.FacadeCall("Leaders", args, &results); err != nil {
+		return nil, err
+	}
+	if len(serviceNames) > 0 && len(results.Results) != len(serviceNames) {
+		return nil, errors.Errorf("expected %d results, got %d", len(serviceNames), len(results.Results))
+	}
+	return results.Results, nil
+}
+
+// TransferLeadership makes the named unit the leader of its service, in
+// place of the current leader.
+func (c *AdminClient) TransferLeadership(unitName string) error {
+	if !names.IsValidUnit(unitName) {
+		return errors.NotValidf("unit name %q", unitName)
+	}
+	args := params.Entities{Entities: []params.Entity{{
+		Tag: names.NewUnitTag(unitName).String(),
+	}}}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("TransferLeadership", args, &results); err != nil {
+		return err
+	}
+	return results.OneError()
+}
@@ -0,0 +1,81 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package leadership_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/leadership"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type adminSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&adminSuite{})
+
+func (s *adminSuite) TestLeaders(c *gc.C) {
+	expiry := time.Date(2015, time.June, 1, 12, 0, 0, 0, time.UTC)
+	var callCount int
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "LeadershipAdmin")
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "Leaders")
+		c.Check(arg, jc.DeepEquals, params.Entities{Entities: []params.Entity{{Tag: "service-mysql"}}})
+		c.Assert(result, gc.FitsTypeOf, &params.ServiceLeadershipResults{})
+		*(result.(*params.ServiceLeadershipResults)) = params.ServiceLeadershipResults{
+			Results: []params.ServiceLeadershipResult{{
+				ServiceTag: "service-mysql",
+				UnitTag:    "unit-mysql-0",
+				Expiry:     expiry,
+			}},
+		}
+		callCount++
+		return nil
+	})
+
+	client := leadership.NewAdminClient(apiCaller)
+	results, err := client.Leaders("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(callCount, gc.Equals, 1)
+	c.Check(results, jc.DeepEquals, []params.ServiceLeadershipResult{{
+		ServiceTag: "service-mysql",
+		UnitTag:    "unit-mysql-0",
+		Expiry:     expiry,
+	}})
+}
+
+func (s *adminSuite) TestLeadersInvalidService(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected call")
+		return nil
+	})
+	_, err := leadership.NewAdminClient(apiCaller).Leaders("mysql/0")
+	c.Assert(err, gc.ErrorMatches, `service name "mysql/0" not valid`)
+}
+
+func (s *adminSuite) TestTransferLeadership(c *gc.C) {
+	var callCount int
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "LeadershipAdmin")
+		c.Check(request, gc.Equals, "TransferLeadership")
+		c.Check(arg, jc.DeepEquals, params.Entities{Entities: []params.Entity{{Tag: "unit-mysql-1"}}})
+		c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{Error: &params.Error{Message: "boom"}}},
+		}
+		callCount++
+		return nil
+	})
+
+	err := leadership.NewAdminClient(apiCaller).TransferLeadership("mysql/1")
+	c.Assert(err, gc.ErrorMatches, "boom")
+	c.Check(callCount, gc.Equals, 1)
+}
@@ -0,0 +1,72 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package offers provides access to the Offers API, which is used to
+// offer services to the other environments on the state server, and to
+// consume the services offered by them.
+package offers
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+const offersFacade = "Offers"
+
+// Client allows access to the offers API end point.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the offers API.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, offersFacade)
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// AddOffer offers the given endpoints of the named service under the
+// given offer name.
+func (c *Client) AddOffer(name, serviceName string, endpoints []string) error {
+	args := params.Offers{
+		Offers: []params.Offer{{
+			Name:        name,
+			ServiceName: serviceName,
+			Endpoints:   endpoints,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("AddOffers", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// ListOffers returns all the offers made by the environment.
+func (c *Client) ListOffers() ([]params.Offer, error) {
+	var results params.ListOffersResults
+	if err := c.facade.FacadeCall("ListOffers", nil, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results.Offers, nil
+}
+
+// Consume adds a remote service with the given name for the named offer
+// of the environment with the given name or UUID. The offer name is
+// used as the service name if it is empty.
+func (c *Client) Consume(environment, offerName, serviceName string) error {
+	args := params.ConsumeOffers{
+		Offers: []params.ConsumeOffer{{
+			Environment: environment,
+			OfferName:   offerName,
+			ServiceName: serviceName,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("Consume", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
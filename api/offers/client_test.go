@@ -0,0 +1,85 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package offers_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/offers"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type offersSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&offersSuite{})
+
+func (s *offersSuite) TestAddOffer(c *gc.C) {
+	var callCount int
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "Offers")
+		c.Check(request, gc.Equals, "AddOffers")
+		c.Check(arg, jc.DeepEquals, params.Offers{
+			Offers: []params.Offer{{
+				Name:        "db",
+				ServiceName: "mysql",
+				Endpoints:   []string{"server"},
+			}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{}},
+		}
+		callCount++
+		return nil
+	})
+
+	err := offers.NewClient(apiCaller).AddOffer("db", "mysql", []string{"server"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(callCount, gc.Equals, 1)
+}
+
+func (s *offersSuite) TestListOffers(c *gc.C) {
+	expected := []params.Offer{{
+		Name:        "db",
+		ServiceName: "mysql",
+		Endpoints:   []string{"server"},
+	}}
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "Offers")
+		c.Check(request, gc.Equals, "ListOffers")
+		c.Assert(result, gc.FitsTypeOf, &params.ListOffersResults{})
+		*(result.(*params.ListOffersResults)) = params.ListOffersResults{Offers: expected}
+		return nil
+	})
+
+	results, err := offers.NewClient(apiCaller).ListOffers()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, expected)
+}
+
+func (s *offersSuite) TestConsumeError(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "Offers")
+		c.Check(request, gc.Equals, "Consume")
+		c.Check(arg, jc.DeepEquals, params.ConsumeOffers{
+			Offers: []params.ConsumeOffer{{
+				Environment: "shared",
+				OfferName:   "db",
+				ServiceName: "shared-db",
+			}},
+		})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{Error: &params.Error{Message: "boom"}}},
+		}
+		return nil
+	})
+
+	err := offers.NewClient(apiCaller).Consume("shared", "db", "shared-db")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -19,6 +19,7 @@ import (
 // State provides access to the Machiner API facade.
 type State struct {
 	*common.EnvironWatcher
+	*common.CredentialsLoadedSetter
 	*common.APIAddresser
 
 	facade base.FacadeCaller
@@ -30,9 +31,10 @@ const provisionerFacade = "Provisioner"
 func NewState(caller base.APICaller) *State {
 	facadeCaller := base.NewFacadeCaller(caller, provisionerFacade)
 	return &State{
-		EnvironWatcher: common.NewEnvironWatcher(facadeCaller),
-		APIAddresser:   common.NewAPIAddresser(facadeCaller),
-		facade:         facadeCaller}
+		EnvironWatcher:          common.NewEnvironWatcher(facadeCaller),
+		CredentialsLoadedSetter: common.NewCredentialsLoadedSetter(facadeCaller),
+		APIAddresser:            common.NewAPIAddresser(facadeCaller),
+		facade:                  facadeCaller}
 }
 
 // machineLife requests the lifecycle of the given machine from the server.
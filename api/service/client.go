@@ -58,17 +58,19 @@ func (c *Client) ServiceDeploy(
 	toMachineSpec string,
 	networks []string,
 	storage map[string]storage.Constraints,
+	bindings map[string]string,
 ) error {
 	args := params.ServicesDeploy{
 		Services: []params.ServiceDeploy{{
-			ServiceName:   serviceName,
-			CharmUrl:      charmURL,
-			NumUnits:      numUnits,
-			ConfigYAML:    configYAML,
-			Constraints:   cons,
-			ToMachineSpec: toMachineSpec,
-			Networks:      networks,
-			Storage:       storage,
+			ServiceName:      serviceName,
+			CharmUrl:         charmURL,
+			NumUnits:         numUnits,
+			ConfigYAML:       configYAML,
+			Constraints:      cons,
+			ToMachineSpec:    toMachineSpec,
+			Networks:         networks,
+			Storage:          storage,
+			EndpointBindings: bindings,
 		}},
 	}
 	var results params.ErrorResults
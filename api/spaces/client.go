@@ -0,0 +1,59 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package spaces provides access to the Spaces API, which is used to
+// manage network spaces.
+package spaces
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+const spacesFacade = "Spaces"
+
+// Client allows access to the spaces API end point.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the spaces API.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, spacesFacade)
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// CreateSpace creates a space with the given name, made up of the given
+// subnets.
+func (c *Client) CreateSpace(name string, subnets []params.Subnet) error {
+	return c.call("CreateSpaces", name, subnets)
+}
+
+// AddSubnets adds the given subnets to the named space.
+func (c *Client) AddSubnets(name string, subnets []params.Subnet) error {
+	return c.call("AddSubnets", name, subnets)
+}
+
+func (c *Client) call(method, name string, subnets []params.Subnet) error {
+	args := params.SpacesSubnets{
+		Spaces: []params.SpaceSubnets{{Name: name, Subnets: subnets}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall(method, args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// ListSpaces returns all the spaces in the environment, with their
+// subnets.
+func (c *Client) ListSpaces() ([]params.SpaceSubnets, error) {
+	var results params.ListSpacesResults
+	if err := c.facade.FacadeCall("ListSpaces", nil, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results.Spaces, nil
+}
@@ -0,0 +1,75 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package spaces_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/spaces"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type spacesSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&spacesSuite{})
+
+func (s *spacesSuite) TestCreateSpace(c *gc.C) {
+	var callCount int
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "Spaces")
+		c.Check(request, gc.Equals, "CreateSpaces")
+		c.Check(arg, jc.DeepEquals, params.SpacesSubnets{
+			Spaces: []params.SpaceSubnets{{
+				Name:    "dmz",
+				Subnets: []params.Subnet{{CIDR: "10.0.0.0/24"}},
+			}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{}},
+		}
+		callCount++
+		return nil
+	})
+
+	err := spaces.NewClient(apiCaller).CreateSpace("dmz", []params.Subnet{{CIDR: "10.0.0.0/24"}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(callCount, gc.Equals, 1)
+}
+
+func (s *spacesSuite) TestAddSubnetsError(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(request, gc.Equals, "AddSubnets")
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{Error: &params.Error{Message: "boom"}}},
+		}
+		return nil
+	})
+
+	err := spaces.NewClient(apiCaller).AddSubnets("dmz", []params.Subnet{{CIDR: "10.0.0.0/24"}})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *spacesSuite) TestListSpaces(c *gc.C) {
+	expected := []params.SpaceSubnets{{
+		Name:    "dmz",
+		Subnets: []params.Subnet{{CIDR: "10.0.0.0/24", ProviderId: "subnet-0"}},
+	}}
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "Spaces")
+		c.Check(request, gc.Equals, "ListSpaces")
+		c.Assert(result, gc.FitsTypeOf, &params.ListSpacesResults{})
+		*(result.(*params.ListSpacesResults)) = params.ListSpacesResults{Spaces: expected}
+		return nil
+	})
+
+	results, err := spaces.NewClient(apiCaller).ListSpaces()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, expected)
+}
@@ -112,3 +112,65 @@ func (c *Client) AddToUnit(storages []params.StorageAddParams) ([]params.ErrorRe
 	}
 	return out.Results, nil
 }
+
+// CreateVolumeSnapshots requests snapshots of the volumes with the
+// specified IDs.
+func (c *Client) CreateVolumeSnapshots(volumes []string) ([]params.VolumeSnapshotDetailsResult, error) {
+	entities := make([]params.Entity, len(volumes))
+	for i, one := range volumes {
+		entities[i] = params.Entity{Tag: names.NewVolumeTag(one).String()}
+	}
+	out := params.VolumeSnapshotDetailsResults{}
+	if err := c.facade.FacadeCall("CreateVolumeSnapshots", params.Entities{Entities: entities}, &out); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return out.Results, nil
+}
+
+// ListVolumeSnapshots lists the snapshots of the volumes with the
+// specified IDs. If no volumes are provided, the snapshots of all
+// volumes are returned.
+func (c *Client) ListVolumeSnapshots(volumes []string) ([]params.VolumeSnapshotDetailsResult, error) {
+	tags := make([]string, len(volumes))
+	for i, one := range volumes {
+		tags[i] = names.NewVolumeTag(one).String()
+	}
+	args := params.VolumeSnapshotFilter{Volumes: tags}
+	out := params.VolumeSnapshotDetailsResults{}
+	if err := c.facade.FacadeCall("ListVolumeSnapshots", args, &out); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return out.Results, nil
+}
+
+// DestroyVolumeSnapshots requests that the volume snapshots with the
+// specified IDs be destroyed.
+func (c *Client) DestroyVolumeSnapshots(ids []string) ([]params.ErrorResult, error) {
+	out := params.ErrorResults{}
+	if err := c.facade.FacadeCall("DestroyVolumeSnapshots", params.VolumeSnapshotIds{Ids: ids}, &out); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return out.Results, nil
+}
+
+// RestoreVolumeSnapshots creates volumes from the specified snapshots,
+// attached to the specified machines.
+func (c *Client) RestoreVolumeSnapshots(restores []params.VolumeSnapshotRestore) ([]params.StringResult, error) {
+	out := params.StringResults{}
+	in := params.VolumeSnapshotRestores{Restores: restores}
+	if err := c.facade.FacadeCall("RestoreVolumeSnapshots", in, &out); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return out.Results, nil
+}
+
+// ResizeVolumes requests that the specified volumes be grown to the
+// specified sizes, in MiB.
+func (c *Client) ResizeVolumes(sizes []params.VolumeSize) ([]params.ErrorResult, error) {
+	out := params.ErrorResults{}
+	in := params.VolumeSizes{Sizes: sizes}
+	if err := c.facade.FacadeCall("ResizeVolumes", in, &out); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return out.Results, nil
+}
@@ -474,3 +474,183 @@ func (s *storageMockSuite) TestAddToUnitFacadeCallError(c *gc.C) {
 	c.Assert(errors.Cause(err), gc.ErrorMatches, msg)
 	c.Assert(found, gc.HasLen, 0)
 }
+
+func (s *storageMockSuite) TestCreateVolumeSnapshots(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			called = true
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "CreateVolumeSnapshots")
+
+			c.Assert(a, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{"volume-0-1"}, {"volume-2"}},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.VolumeSnapshotDetailsResults{})
+			results := result.(*params.VolumeSnapshotDetailsResults)
+			results.Results = []params.VolumeSnapshotDetailsResult{
+				{Result: params.VolumeSnapshotDetails{Id: "0/0", VolumeTag: "volume-0-1"}},
+				{Error: common.ServerError(errors.NotFoundf("volume 2"))},
+			}
+			return nil
+		})
+	storageClient := storage.NewClient(apiCaller)
+	found, err := storageClient.CreateVolumeSnapshots([]string{"0/1", "2"})
+	c.Assert(called, jc.IsTrue)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found, gc.HasLen, 2)
+	c.Assert(found[0].Result.Id, gc.Equals, "0/0")
+	c.Assert(found[1].Error, gc.ErrorMatches, "volume 2 not found")
+}
+
+func (s *storageMockSuite) TestListVolumeSnapshots(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			called = true
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "ListVolumeSnapshots")
+
+			c.Assert(a, jc.DeepEquals, params.VolumeSnapshotFilter{
+				Volumes: []string{"volume-0-1"},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.VolumeSnapshotDetailsResults{})
+			results := result.(*params.VolumeSnapshotDetailsResults)
+			results.Results = []params.VolumeSnapshotDetailsResult{
+				{Result: params.VolumeSnapshotDetails{Id: "0/0", VolumeTag: "volume-0-1"}},
+			}
+			return nil
+		})
+	storageClient := storage.NewClient(apiCaller)
+	found, err := storageClient.ListVolumeSnapshots([]string{"0/1"})
+	c.Assert(called, jc.IsTrue)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found, gc.HasLen, 1)
+	c.Assert(found[0].Result.Id, gc.Equals, "0/0")
+}
+
+func (s *storageMockSuite) TestDestroyVolumeSnapshots(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			called = true
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "DestroyVolumeSnapshots")
+
+			c.Assert(a, jc.DeepEquals, params.VolumeSnapshotIds{Ids: []string{"0/0", "1"}})
+			c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+			results := result.(*params.ErrorResults)
+			results.Results = []params.ErrorResult{
+				{},
+				{common.ServerError(errors.NotFoundf(`volume snapshot "1"`))},
+			}
+			return nil
+		})
+	storageClient := storage.NewClient(apiCaller)
+	found, err := storageClient.DestroyVolumeSnapshots([]string{"0/0", "1"})
+	c.Assert(called, jc.IsTrue)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found, gc.HasLen, 2)
+	c.Assert(found[0].Error, gc.IsNil)
+	c.Assert(found[1].Error, gc.ErrorMatches, `volume snapshot "1" not found`)
+}
+
+func (s *storageMockSuite) TestRestoreVolumeSnapshots(c *gc.C) {
+	var called bool
+	restores := []params.VolumeSnapshotRestore{{Id: "0/0", MachineTag: "machine-0"}}
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			called = true
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "RestoreVolumeSnapshots")
+
+			c.Assert(a, jc.DeepEquals, params.VolumeSnapshotRestores{Restores: restores})
+			c.Assert(result, gc.FitsTypeOf, &params.StringResults{})
+			results := result.(*params.StringResults)
+			results.Results = []params.StringResult{{Result: "volume-0-2"}}
+			return nil
+		})
+	storageClient := storage.NewClient(apiCaller)
+	found, err := storageClient.RestoreVolumeSnapshots(restores)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found, jc.DeepEquals, []params.StringResult{{Result: "volume-0-2"}})
+}
+
+func (s *storageMockSuite) TestVolumeSnapshotsFacadeCallError(c *gc.C) {
+	msg := "facade failure"
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(id, gc.Equals, "")
+			return errors.New(msg)
+		})
+	storageClient := storage.NewClient(apiCaller)
+	_, err := storageClient.CreateVolumeSnapshots([]string{"0"})
+	c.Assert(errors.Cause(err), gc.ErrorMatches, msg)
+	_, err = storageClient.ListVolumeSnapshots(nil)
+	c.Assert(errors.Cause(err), gc.ErrorMatches, msg)
+	_, err = storageClient.DestroyVolumeSnapshots([]string{"0"})
+	c.Assert(errors.Cause(err), gc.ErrorMatches, msg)
+	_, err = storageClient.RestoreVolumeSnapshots(nil)
+	c.Assert(errors.Cause(err), gc.ErrorMatches, msg)
+}
+
+func (s *storageMockSuite) TestResizeVolumes(c *gc.C) {
+	sizes := []params.VolumeSize{
+		{VolumeTag: "volume-0-1", Size: 2048},
+		{VolumeTag: "volume-2", Size: 512},
+	}
+	var called bool
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			called = true
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "ResizeVolumes")
+
+			c.Assert(a, jc.DeepEquals, params.VolumeSizes{Sizes: sizes})
+			c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+			results := result.(*params.ErrorResults)
+			results.Results = []params.ErrorResult{
+				{},
+				{common.ServerError(errors.New("cannot shrink volume from 1024MiB to 512MiB"))},
+			}
+			return nil
+		})
+	storageClient := storage.NewClient(apiCaller)
+	found, err := storageClient.ResizeVolumes(sizes)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found, gc.HasLen, 2)
+	c.Assert(found[0].Error, gc.IsNil)
+	c.Assert(found[1].Error, gc.ErrorMatches, "cannot shrink volume from 1024MiB to 512MiB")
+}
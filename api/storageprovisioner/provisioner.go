@@ -21,6 +21,7 @@ type State struct {
 	scope  names.Tag
 
 	*common.EnvironWatcher
+	*common.CredentialsLoadedSetter
 }
 
 // NewState creates a new client-side StorageProvisioner facade.
@@ -36,6 +37,7 @@ func NewState(caller base.APICaller, scope names.Tag) *State {
 		facadeCaller,
 		scope,
 		common.NewEnvironWatcher(facadeCaller),
+		common.NewCredentialsLoadedSetter(facadeCaller),
 	}
 }
 
@@ -93,6 +95,18 @@ func (st *State) WatchFilesystems() (watcher.StringsWatcher, error) {
 	return st.watchStorageEntities("WatchFilesystems")
 }
 
+// WatchVolumeSnapshots watches for lifecycle changes to volume snapshots
+// scoped to the entity with the tag passed to NewState.
+func (st *State) WatchVolumeSnapshots() (watcher.StringsWatcher, error) {
+	return st.watchStorageEntities("WatchVolumeSnapshots")
+}
+
+// WatchVolumeResizes watches for requests to resize volumes scoped to
+// the entity with the tag passed to NewState.
+func (st *State) WatchVolumeResizes() (watcher.StringsWatcher, error) {
+	return st.watchStorageEntities("WatchVolumeResizes")
+}
+
 func (st *State) watchStorageEntities(method string) (watcher.StringsWatcher, error) {
 	var results params.StringsWatchResults
 	args := params.Entities{
@@ -269,6 +283,26 @@ func (st *State) FilesystemParams(tags []names.FilesystemTag) ([]params.Filesyst
 	return results.Results, nil
 }
 
+// FilesystemDestroyParams returns the parameters for destroying the
+// filesystems with the specified tags.
+func (st *State) FilesystemDestroyParams(tags []names.FilesystemTag) ([]params.FilesystemDestroyParamsResult, error) {
+	args := params.Entities{
+		Entities: make([]params.Entity, len(tags)),
+	}
+	for i, tag := range tags {
+		args.Entities[i].Tag = tag.String()
+	}
+	var results params.FilesystemDestroyParamsResults
+	err := st.facade.FacadeCall("FilesystemDestroyParams", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(tags) {
+		panic(errors.Errorf("expected %d result(s), got %d", len(tags), len(results.Results)))
+	}
+	return results.Results, nil
+}
+
 // VolumeAttachmentParams returns the parameters for creating the volume
 // attachments with the specified tags.
 func (st *State) VolumeAttachmentParams(ids []params.MachineStorageId) ([]params.VolumeAttachmentParamsResult, error) {
@@ -455,3 +489,110 @@ func (st *State) InstanceIds(tags []names.MachineTag) ([]params.StringResult, er
 	}
 	return results.Results, nil
 }
+
+// VolumeSnapshots returns details of the volume snapshots with the
+// specified IDs.
+func (st *State) VolumeSnapshots(ids []string) ([]params.VolumeSnapshotResult, error) {
+	args := params.VolumeSnapshotIds{ids}
+	var results params.VolumeSnapshotResults
+	err := st.facade.FacadeCall("VolumeSnapshots", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(ids) {
+		panic(errors.Errorf("expected %d result(s), got %d", len(ids), len(results.Results)))
+	}
+	return results.Results, nil
+}
+
+// VolumeSnapshotParams returns the parameters for taking the volume
+// snapshots with the specified IDs.
+func (st *State) VolumeSnapshotParams(ids []string) ([]params.VolumeSnapshotParamsResult, error) {
+	args := params.VolumeSnapshotIds{ids}
+	var results params.VolumeSnapshotParamsResults
+	err := st.facade.FacadeCall("VolumeSnapshotParams", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(ids) {
+		panic(errors.Errorf("expected %d result(s), got %d", len(ids), len(results.Results)))
+	}
+	return results.Results, nil
+}
+
+// SetVolumeSnapshotInfo records the details of newly taken volume
+// snapshots.
+func (st *State) SetVolumeSnapshotInfo(snapshots []params.VolumeSnapshot) ([]params.ErrorResult, error) {
+	args := params.VolumeSnapshots{Snapshots: snapshots}
+	var results params.ErrorResults
+	err := st.facade.FacadeCall("SetVolumeSnapshotInfo", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(snapshots) {
+		panic(errors.Errorf("expected %d result(s), got %d", len(snapshots), len(results.Results)))
+	}
+	return results.Results, nil
+}
+
+// VolumeSnapshotLife requests the life cycle of the volume snapshots
+// with the specified IDs.
+func (st *State) VolumeSnapshotLife(ids []string) ([]params.LifeResult, error) {
+	var results params.LifeResults
+	args := params.VolumeSnapshotIds{ids}
+	if err := st.facade.FacadeCall("VolumeSnapshotLife", args, &results); err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(ids) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(ids), len(results.Results))
+	}
+	return results.Results, nil
+}
+
+// RemoveVolumeSnapshots removes the volume snapshots with the specified
+// IDs from state.
+func (st *State) RemoveVolumeSnapshots(ids []string) ([]params.ErrorResult, error) {
+	var results params.ErrorResults
+	args := params.VolumeSnapshotIds{ids}
+	if err := st.facade.FacadeCall("RemoveVolumeSnapshots", args, &results); err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(ids) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(ids), len(results.Results))
+	}
+	return results.Results, nil
+}
+
+// VolumeResizeParams returns the parameters for resizing the volumes
+// with the specified tags.
+func (st *State) VolumeResizeParams(tags []names.VolumeTag) ([]params.VolumeResizeParamsResult, error) {
+	args := params.Entities{
+		Entities: make([]params.Entity, len(tags)),
+	}
+	for i, tag := range tags {
+		args.Entities[i].Tag = tag.String()
+	}
+	var results params.VolumeResizeParamsResults
+	err := st.facade.FacadeCall("VolumeResizeParams", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(tags) {
+		panic(errors.Errorf("expected %d result(s), got %d", len(tags), len(results.Results)))
+	}
+	return results.Results, nil
+}
+
+// SetVolumesResized records the new sizes of resized volumes.
+func (st *State) SetVolumesResized(sizes []params.VolumeSize) ([]params.ErrorResult, error) {
+	args := params.VolumeSizes{Sizes: sizes}
+	var results params.ErrorResults
+	err := st.facade.FacadeCall("SetVolumesResized", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(sizes) {
+		panic(errors.Errorf("expected %d result(s), got %d", len(sizes), len(results.Results)))
+	}
+	return results.Results, nil
+}
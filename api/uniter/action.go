@@ -3,10 +3,15 @@
 
 package uniter
 
+import (
+	"time"
+)
+
 // Action represents a single instance of an Action call, by name and params.
 type Action struct {
-	name   string
-	params map[string]interface{}
+	name    string
+	params  map[string]interface{}
+	timeout time.Duration
 }
 
 // NewAction makes a new Action with specified name and params map.
@@ -14,6 +19,12 @@ func NewAction(name string, params map[string]interface{}) (*Action, error) {
 	return &Action{name: name, params: params}, nil
 }
 
+// NewActionWithTimeout makes a new Action with specified name, params
+// map and timeout.
+func NewActionWithTimeout(name string, params map[string]interface{}, timeout time.Duration) (*Action, error) {
+	return &Action{name: name, params: params, timeout: timeout}, nil
+}
+
 // Name retrieves the name of the Action.
 func (a *Action) Name() string {
 	return a.name
@@ -23,3 +34,9 @@ func (a *Action) Name() string {
 func (a *Action) Params() map[string]interface{} {
 	return a.params
 }
+
+// Timeout retrieves the time the Action may run for before it is
+// stopped, or zero if it may run indefinitely.
+func (a *Action) Timeout() time.Duration {
+	return a.timeout
+}
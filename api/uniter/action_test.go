@@ -4,6 +4,8 @@
 package uniter_test
 
 import (
+	"time"
+
 	"github.com/juju/names"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -11,6 +13,7 @@ import (
 	"github.com/juju/juju/api/uniter"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
 )
 
 type actionSuite struct {
@@ -122,3 +125,66 @@ func (s *actionSuite) TestActionFail(c *gc.C) {
 	c.Assert(res, gc.DeepEquals, map[string]interface{}{})
 	c.Assert(completed[0].Name(), gc.Equals, "fakeaction")
 }
+
+func (s *actionSuite) TestActionAbort(c *gc.C) {
+	action, err := s.uniterSuite.wordpressUnit.AddActionWithTimeout("fakeaction", nil, time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+
+	retrievedAction, err := s.uniter.Action(action.ActionTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(retrievedAction.Timeout(), gc.Equals, time.Minute)
+
+	err = s.uniter.ActionBegin(action.ActionTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	w, err := s.uniter.WatchActionAbort(action.ActionTag())
+	c.Assert(err, jc.ErrorIsNil)
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.BackingState, w)
+	wc.AssertOneChange()
+
+	aborting, err := s.uniter.ActionAborting(action.ActionTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(aborting, jc.IsFalse)
+
+	_, err = action.Abort()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	aborting, err = s.uniter.ActionAborting(action.ActionTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(aborting, jc.IsTrue)
+
+	partial := map[string]interface{}{"progress": "half"}
+	err = s.uniter.ActionFinish(action.ActionTag(), params.ActionAborted, partial, "action aborted")
+	c.Assert(err, jc.ErrorIsNil)
+
+	completed, err := s.uniterSuite.wordpressUnit.CompletedActions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(completed, gc.HasLen, 1)
+	c.Assert(completed[0].Status(), gc.Equals, state.ActionAborted)
+	res, errstr := completed[0].Results()
+	c.Assert(errstr, gc.Equals, "action aborted")
+	c.Assert(res, gc.DeepEquals, partial)
+}
+
+func (s *actionSuite) TestAddOutput(c *gc.C) {
+	action, err := s.uniterSuite.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	now := time.Date(2015, 6, 1, 2, 0, 0, 0, time.UTC)
+	err = s.uniter.AddOutput(action.Tag().String(), []params.OutputChunk{
+		{Seq: 1, Stream: "stdout", Data: "hello\n", Time: now},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	output, err := s.BackingState.Output(action.Id(), nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(output, jc.DeepEquals, []state.OutputChunk{{
+		Unit:   s.uniterSuite.wordpressUnit.Name(),
+		Seq:    1,
+		Stream: "stdout",
+		Data:   "hello\n",
+		Time:   now,
+	}})
+}
@@ -412,6 +412,71 @@ func (u *Unit) PrivateAddress() (string, error) {
 	return result.Result, nil
 }
 
+// BindingAddress returns the address the unit should advertise on the
+// given endpoint: its address in the network space the endpoint is
+// bound to, or its private address if the endpoint is not bound.
+func (u *Unit) BindingAddress(endpoint string) (string, error) {
+	if u.st.facade.BestAPIVersion() < 2 {
+		return "", errors.NotImplementedf("BindingAddress() (need V2+)")
+	}
+	var results params.StringResults
+	args := params.UnitEndpoints{
+		Endpoints: []params.UnitEndpoint{{Tag: u.tag.String(), Endpoint: endpoint}},
+	}
+	err := u.st.facade.FacadeCall("BindingAddresses", args, &results)
+	if err != nil {
+		return "", err
+	}
+	if len(results.Results) != 1 {
+		return "", fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return "", result.Error
+	}
+	return result.Result, nil
+}
+
+// SetHealthChecks records the results of the health checks declared by
+// the unit's charm.
+func (u *Unit) SetHealthChecks(checks []params.HealthCheckResult) error {
+	if u.st.facade.BestAPIVersion() < 2 {
+		return errors.NotImplementedf("SetHealthChecks() (need V2+)")
+	}
+	var results params.ErrorResults
+	args := params.SetHealthChecks{
+		Units: []params.UnitHealthChecks{{Tag: u.tag.String(), Checks: checks}},
+	}
+	err := u.st.facade.FacadeCall("SetHealthChecks", args, &results)
+	if err != nil {
+		return err
+	}
+	return results.OneError()
+}
+
+// HookRetryPolicy returns the policy by which the unit's failed hooks
+// are retried automatically.
+func (u *Unit) HookRetryPolicy() (params.HookRetryPolicy, error) {
+	if u.st.facade.BestAPIVersion() < 2 {
+		return params.HookRetryPolicy{}, errors.NotImplementedf("HookRetryPolicy() (need V2+)")
+	}
+	var results params.HookRetryPolicyResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	if err := u.st.facade.FacadeCall("HookRetryPolicies", args, &results); err != nil {
+		return params.HookRetryPolicy{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return params.HookRetryPolicy{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return params.HookRetryPolicy{}, errors.Trace(result.Error)
+	}
+	return result.Result, nil
+}
+
 // AvailabilityZone returns the availability zone of the unit.
 func (u *Unit) AvailabilityZone() (string, error) {
 	var results params.StringResults
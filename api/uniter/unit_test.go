@@ -19,6 +19,7 @@ import (
 	"github.com/juju/juju/api/uniter"
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
@@ -342,6 +343,67 @@ func (s *unitSuite) TestPrivateAddress(c *gc.C) {
 	c.Assert(address, gc.Equals, "1.2.3.4")
 }
 
+func (s *unitSuite) TestBindingAddress(c *gc.C) {
+	_, err := s.State.AddSubnet(state.SubnetInfo{CIDR: "10.0.0.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSpace("db", []string{"10.0.0.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.wordpressService.SetEndpointBindings(map[string]string{"db": "db"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.apiUnit.BindingAddress("db")
+	c.Assert(err, gc.ErrorMatches, `address of unit "wordpress/0" in space "db" not found`)
+
+	err = s.wordpressMachine.SetProviderAddresses(
+		network.NewScopedAddress("1.2.3.4", network.ScopeCloudLocal),
+		network.NewScopedAddress("10.0.0.4", network.ScopeCloudLocal),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	address, err := s.apiUnit.BindingAddress("db")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(address, gc.Equals, "10.0.0.4")
+
+	address, err = s.apiUnit.BindingAddress("url")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(address, gc.Equals, "1.2.3.4")
+}
+
+func (s *unitSuite) TestSetHealthChecks(c *gc.C) {
+	since := time.Date(2015, 7, 1, 12, 0, 0, 0, time.UTC)
+	err := s.apiUnit.SetHealthChecks([]params.HealthCheckResult{{
+		Name:    "http",
+		Healthy: true,
+		Since:   since,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.wordpressUnit.HealthChecks()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []state.HealthCheckResult{{
+		Name:    "http",
+		Healthy: true,
+		Since:   since,
+	}})
+}
+
+func (s *unitSuite) TestHookRetryPolicy(c *gc.C) {
+	err := s.wordpressService.SetHookRetryPolicy(&config.HookRetryPolicy{
+		Attempts: 3,
+		Delay:    time.Minute,
+		MaxDelay: time.Hour,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	policy, err := s.apiUnit.HookRetryPolicy()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(policy, gc.Equals, params.HookRetryPolicy{
+		Attempts: 3,
+		Delay:    time.Minute,
+		MaxDelay: time.Hour,
+	})
+}
+
 func (s *unitSuite) TestAvailabilityZone(c *gc.C) {
 	uniter.PatchUnitResponse(s, s.apiUnit, "AvailabilityZone",
 		func(result interface{}) error {
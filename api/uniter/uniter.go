@@ -221,8 +221,9 @@ func (st *State) Action(tag names.ActionTag) (*Action, error) {
 		return nil, err
 	}
 	return &Action{
-		name:   result.Action.Action.Name,
-		params: result.Action.Action.Parameters,
+		name:    result.Action.Action.Name,
+		params:  result.Action.Action.Parameters,
+		timeout: result.Action.Action.Timeout,
 	}, nil
 }
 
@@ -279,6 +280,73 @@ func (st *State) ActionFinish(tag names.ActionTag, status string, results map[st
 	return nil
 }
 
+// WatchActionAbort returns a watcher that notifies when the running
+// action with the given tag is asked to stop.
+func (st *State) WatchActionAbort(tag names.ActionTag) (watcher.NotifyWatcher, error) {
+	if st.facade.BestAPIVersion() < 2 {
+		return nil, errors.NotImplementedf("WatchActionAborts() (need V2+)")
+	}
+	var results params.NotifyWatchResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: tag.String()}},
+	}
+	err := st.facade.FacadeCall("WatchActionAborts", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != 1 {
+		return nil, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	w := watcher.NewNotifyWatcher(st.facade.RawAPICaller(), result)
+	return w, nil
+}
+
+// ActionAborting reports whether the running action with the given
+// tag has been asked to stop.
+func (st *State) ActionAborting(tag names.ActionTag) (bool, error) {
+	if st.facade.BestAPIVersion() < 2 {
+		return false, errors.NotImplementedf("ActionsAborting() (need V2+)")
+	}
+	var results params.BoolResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: tag.String()}},
+	}
+	err := st.facade.FacadeCall("ActionsAborting", args, &results)
+	if err != nil {
+		return false, err
+	}
+	if len(results.Results) != 1 {
+		return false, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.Result, nil
+}
+
+// AddOutput records chunks of output produced by the unit. The output
+// id is the tag of the running action that produced the output, or the
+// output id given when commands were run on the unit.
+func (st *State) AddOutput(outputId string, chunks []params.OutputChunk) error {
+	if st.facade.BestAPIVersion() < 2 {
+		return errors.NotImplementedf("AddOutput() (need V2+)")
+	}
+	var results params.ErrorResults
+	args := params.Outputs{
+		Outputs: []params.Output{{Id: outputId, Chunks: chunks}},
+	}
+	err := st.facade.FacadeCall("AddOutput", args, &results)
+	if err != nil {
+		return err
+	}
+	return results.OneError()
+}
+
 // RelationById returns the existing relation with the given id.
 func (st *State) RelationById(id int) (*Relation, error) {
 	var results params.RelationResults
@@ -6,6 +6,7 @@ package usermanager
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -166,3 +167,42 @@ func (c *Client) SetPassword(username, password string) error {
 	}
 	return results.OneError()
 }
+
+// CreateToken creates an API token for the logged in user that expires
+// after the given duration and grants at most the given level of access
+// ("read", "write" or "admin") to an environment. If access is empty,
+// the token grants the same access as the user has. The returned
+// credential may be used in place of the user's password to log in.
+func (c *Client) CreateToken(expiresIn time.Duration, access string) (params.CreateTokenResult, error) {
+	var result params.CreateTokenResult
+	args := params.CreateToken{
+		ExpiresIn: expiresIn,
+		Access:    access,
+	}
+	err := c.facade.FacadeCall("CreateToken", args, &result)
+	if err != nil {
+		return params.CreateTokenResult{}, errors.Trace(err)
+	}
+	return result, nil
+}
+
+// ListTokens returns the API tokens of the logged in user.
+func (c *Client) ListTokens() ([]params.UserToken, error) {
+	var result params.UserTokenResults
+	err := c.facade.FacadeCall("ListTokens", nil, &result)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Tokens, nil
+}
+
+// RevokeToken revokes the logged in user's API token with the given ID.
+func (c *Client) RevokeToken(id string) error {
+	args := params.RevokeTokens{IDs: []string{id}}
+	var results params.ErrorResults
+	err := c.facade.FacadeCall("RevokeTokens", args, &results)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
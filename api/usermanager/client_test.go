@@ -4,6 +4,8 @@
 package usermanager_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -177,3 +179,26 @@ func (s *usermanagerSuite) TestSetUserPasswordBadName(c *gc.C) {
 	err := s.usermanager.SetPassword("not@home", "new-password")
 	c.Assert(err, gc.ErrorMatches, `"not@home" is not a valid username`)
 }
+
+func (s *usermanagerSuite) TestCreateListAndRevokeToken(c *gc.C) {
+	result, err := s.usermanager.CreateToken(time.Hour, "read")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Access, gc.Equals, "read")
+	c.Assert(result.Credential, gc.Not(gc.Equals), "")
+
+	tokens, err := s.usermanager.ListTokens()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tokens, gc.HasLen, 1)
+	c.Assert(tokens[0].ID, gc.Equals, result.ID)
+
+	err = s.usermanager.RevokeToken(result.ID)
+	c.Assert(err, jc.ErrorIsNil)
+	tokens, err = s.usermanager.ListTokens()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tokens, gc.HasLen, 0)
+}
+
+func (s *usermanagerSuite) TestRevokeMissingToken(c *gc.C) {
+	err := s.usermanager.RevokeToken("missing")
+	c.Assert(err, gc.ErrorMatches, `cannot revoke token: token "missing" for user "admin" not found`)
+}
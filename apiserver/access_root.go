@@ -0,0 +1,89 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/rpc"
+	"github.com/juju/juju/rpc/rpcreflect"
+	"github.com/juju/juju/state"
+)
+
+// accessRoot restricts API calls to those permitted by an environment
+// user's level of access.
+type accessRoot struct {
+	rpc.MethodFinder
+	access state.Access
+}
+
+// newAccessRoot returns a new accessRoot that permits the calls
+// allowed by the given access level.
+func newAccessRoot(finder rpc.MethodFinder, access state.Access) *accessRoot {
+	return &accessRoot{
+		MethodFinder: finder,
+		access:       access,
+	}
+}
+
+// adminCalls holds the methods, keyed by facade name, that may only be
+// called by users with admin access to the environment.
+var adminCalls = map[string]set.Strings{
+	"Client": set.NewStrings(
+		"DestroyEnvironment",
+		"ShareEnvironment",
+	),
+	"EnvironmentMigration": set.NewStrings(
+		"Export",
+		"Import",
+	),
+	"LeadershipAdmin": set.NewStrings(
+		"TransferLeadership",
+	),
+}
+
+// selfServiceCalls holds the methods, keyed by facade name, that manage
+// the credentials of the calling user and so leave the environment
+// unchanged. The facades check that users only manage their own
+// credentials unless otherwise permitted.
+var selfServiceCalls = map[string]set.Strings{
+	"UserManager": set.NewStrings(
+		"CreateToken",
+		"RevokeTokens",
+		"SetPassword",
+	),
+}
+
+// requiredAccess returns the level of access a user needs to call the
+// given facade method.
+func requiredAccess(facadeName, methodName string) state.Access {
+	if isCallReadOnly(facadeName, methodName) {
+		return state.ReadAccess
+	}
+	if methods, ok := selfServiceCalls[facadeName]; ok && methods.Contains(methodName) {
+		return state.ReadAccess
+	}
+	if methods, ok := adminCalls[facadeName]; ok && methods.Contains(methodName) {
+		return state.AdminAccess
+	}
+	return state.WriteAccess
+}
+
+// FindMethod returns a permission error for calls that need more
+// access to the environment than the user has.
+func (r *accessRoot) FindMethod(rootName string, version int, methodName string) (rpcreflect.MethodCaller, error) {
+	caller, err := r.MethodFinder.FindMethod(rootName, version, methodName)
+	if err != nil {
+		return nil, err
+	}
+	if !r.access.Includes(requiredAccess(rootName, methodName)) {
+		return nil, errors.Annotatef(common.ErrPerm,
+			"%s access to the environment does not permit %s.%s",
+			r.access, rootName, methodName,
+		)
+	}
+	return caller, nil
+}
@@ -0,0 +1,74 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver"
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing"
+)
+
+type accessRootSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&accessRootSuite{})
+
+func (s *accessRootSuite) assertAllowed(c *gc.C, access state.Access, facade, method string) {
+	root := apiserver.TestingAccessRoot(&fakeFinder{}, access)
+	caller, err := root.FindMethod(facade, 0, method)
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(caller, gc.NotNil)
+}
+
+func (s *accessRootSuite) assertDenied(c *gc.C, access state.Access, facade, method string) {
+	root := apiserver.TestingAccessRoot(&fakeFinder{}, access)
+	caller, err := root.FindMethod(facade, 0, method)
+	c.Check(err, gc.ErrorMatches, string(access)+" access to the environment does not permit "+facade+"."+method+": permission denied")
+	c.Check(common.ServerError(err).Code, gc.Equals, params.CodeUnauthorized)
+	c.Check(caller, gc.IsNil)
+}
+
+func (s *accessRootSuite) TestReadAccess(c *gc.C) {
+	s.assertAllowed(c, state.ReadAccess, "Client", "FullStatus")
+	s.assertAllowed(c, state.ReadAccess, "Client", "WatchAll")
+	s.assertAllowed(c, state.ReadAccess, "AllWatcher", "Next")
+	s.assertAllowed(c, state.ReadAccess, "NotifyWatcher", "Stop")
+	s.assertAllowed(c, state.ReadAccess, "Pinger", "Ping")
+	s.assertAllowed(c, state.ReadAccess, "UserManager", "SetPassword")
+	s.assertAllowed(c, state.ReadAccess, "UserManager", "CreateToken")
+	s.assertDenied(c, state.ReadAccess, "Client", "ServiceDeploy")
+	s.assertDenied(c, state.ReadAccess, "Client", "ServiceSet")
+	s.assertDenied(c, state.ReadAccess, "Client", "DestroyMachines")
+	s.assertDenied(c, state.ReadAccess, "Client", "DestroyEnvironment")
+}
+
+func (s *accessRootSuite) TestWriteAccess(c *gc.C) {
+	s.assertAllowed(c, state.WriteAccess, "Client", "FullStatus")
+	s.assertAllowed(c, state.WriteAccess, "Client", "ServiceDeploy")
+	s.assertAllowed(c, state.WriteAccess, "Client", "ServiceSet")
+	s.assertDenied(c, state.WriteAccess, "Client", "ShareEnvironment")
+	s.assertDenied(c, state.WriteAccess, "Client", "DestroyEnvironment")
+	s.assertAllowed(c, state.WriteAccess, "LeadershipAdmin", "Leaders")
+	s.assertDenied(c, state.WriteAccess, "LeadershipAdmin", "TransferLeadership")
+}
+
+func (s *accessRootSuite) TestAdminAccess(c *gc.C) {
+	s.assertAllowed(c, state.AdminAccess, "Client", "FullStatus")
+	s.assertAllowed(c, state.AdminAccess, "Client", "ServiceDeploy")
+	s.assertAllowed(c, state.AdminAccess, "Client", "ShareEnvironment")
+	s.assertAllowed(c, state.AdminAccess, "Client", "DestroyEnvironment")
+	s.assertAllowed(c, state.AdminAccess, "LeadershipAdmin", "TransferLeadership")
+}
+
+func (s *accessRootSuite) TestFindMethodError(c *gc.C) {
+	root := apiserver.TestingAccessRoot(&fakeFinder{findErr: common.ErrBadId}, state.AdminAccess)
+	_, err := root.FindMethod("Client", 0, "FullStatus")
+	c.Assert(err, gc.Equals, common.ErrBadId)
+}
@@ -4,6 +4,10 @@
 package action
 
 import (
+	"sort"
+	"strconv"
+	"strings"
+
 	"github.com/juju/loggo"
 	"github.com/juju/names"
 
@@ -97,7 +101,7 @@ func (a *ActionAPI) Enqueue(arg params.Actions) (params.ActionResults, error) {
 			currentResult.Error = common.ServerError(err)
 			continue
 		}
-		enqueued, err := receiver.AddAction(action.Name, action.Parameters)
+		enqueued, err := receiver.AddActionWithTimeout(action.Name, action.Parameters, action.Timeout)
 		if err != nil {
 			currentResult.Error = common.ServerError(err)
 			continue
@@ -172,6 +176,68 @@ func (a *ActionAPI) Cancel(arg params.Entities) (params.ActionResults, error) {
 	return response, nil
 }
 
+// Abort stops Actions. Enqueued Actions are cancelled before they
+// run; running Actions are stopped by their receivers and recorded as
+// aborted.
+func (a *ActionAPI) Abort(arg params.Entities) (params.ActionResults, error) {
+	response := params.ActionResults{Results: make([]params.ActionResult, len(arg.Entities))}
+	for i, entity := range arg.Entities {
+		currentResult := &response.Results[i]
+		actionTag, err := names.ParseActionTag(entity.Tag)
+		if err != nil {
+			currentResult.Error = common.ServerError(common.ErrBadId)
+			continue
+		}
+		action, err := a.state.ActionByTag(actionTag)
+		if err != nil {
+			currentResult.Error = common.ServerError(err)
+			continue
+		}
+		result, err := action.Abort()
+		if err != nil {
+			currentResult.Error = common.ServerError(err)
+			continue
+		}
+		receiverTag, err := names.ActionReceiverTag(result.Receiver())
+		if err != nil {
+			currentResult.Error = common.ServerError(err)
+			continue
+		}
+
+		response.Results[i] = makeActionResult(receiverTag, result)
+	}
+	return response, nil
+}
+
+// Output returns the output produced so far by each of the given
+// Actions. The id of each query is the tag of an Action.
+func (a *ActionAPI) Output(arg params.OutputQueries) (params.OutputResults, error) {
+	response := params.OutputResults{Results: make([]params.OutputResult, len(arg.Queries))}
+	for i, query := range arg.Queries {
+		currentResult := &response.Results[i]
+		actionTag, err := names.ParseActionTag(query.Id)
+		if err != nil {
+			currentResult.Error = common.ServerError(common.ErrBadId)
+			continue
+		}
+		action, err := a.state.ActionByTag(actionTag)
+		if err != nil {
+			currentResult.Error = common.ServerError(err)
+			continue
+		}
+		chunks, err := a.state.Output(action.Id(), query.After)
+		if err != nil {
+			currentResult.Error = common.ServerError(err)
+			continue
+		}
+		receiver := action.Receiver()
+		currentResult.Chunks = common.OutputChunksFromState(chunks, func(unitName string) bool {
+			return unitName == receiver
+		})
+	}
+	return response, nil
+}
+
 // ServicesCharmActions returns a slice of charm Actions for a slice of
 // services.
 func (a *ActionAPI) ServicesCharmActions(args params.Entities) (params.ServicesCharmActionsResults, error) {
@@ -199,6 +265,55 @@ func (a *ActionAPI) ServicesCharmActions(args params.Entities) (params.ServicesC
 	return result, nil
 }
 
+// ServicesUnits returns the tags of the alive units of each of the
+// given services, in unit number order, so that clients can run
+// actions across a whole service.
+func (a *ActionAPI) ServicesUnits(args params.Entities) (params.StringsResults, error) {
+	result := params.StringsResults{Results: make([]params.StringsResult, len(args.Entities))}
+	for i, entity := range args.Entities {
+		currentResult := &result.Results[i]
+		svcTag, err := names.ParseServiceTag(entity.Tag)
+		if err != nil {
+			currentResult.Error = common.ServerError(common.ErrBadId)
+			continue
+		}
+		svc, err := a.state.Service(svcTag.Id())
+		if err != nil {
+			currentResult.Error = common.ServerError(err)
+			continue
+		}
+		units, err := svc.AllUnits()
+		if err != nil {
+			currentResult.Error = common.ServerError(err)
+			continue
+		}
+		sort.Sort(unitsByNumber(units))
+		unitTags := []string{}
+		for _, unit := range units {
+			if unit.Life() != state.Alive {
+				continue
+			}
+			unitTags = append(unitTags, unit.Tag().String())
+		}
+		currentResult.Result = unitTags
+	}
+	return result, nil
+}
+
+type unitsByNumber []*state.Unit
+
+func (u unitsByNumber) Len() int      { return len(u) }
+func (u unitsByNumber) Swap(i, j int) { u[i], u[j] = u[j], u[i] }
+func (u unitsByNumber) Less(i, j int) bool {
+	return unitNumber(u[i].Name()) < unitNumber(u[j].Name())
+}
+
+// unitNumber returns the number of the named unit.
+func unitNumber(unitName string) int {
+	number, _ := strconv.Atoi(unitName[strings.LastIndex(unitName, "/")+1:])
+	return number
+}
+
 // internalList takes a list of Entities representing ActionReceivers
 // and returns all of the Actions the extractorFn can get out of the
 // ActionReceiver.
@@ -313,6 +428,7 @@ func makeActionResult(actionReceiverTag names.Tag, action *state.Action) params.
 			Tag:        action.ActionTag().String(),
 			Name:       action.Name(),
 			Parameters: action.Parameters(),
+			Timeout:    action.Timeout(),
 		},
 		Status:    string(action.Status()),
 		Message:   message,
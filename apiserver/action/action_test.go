@@ -6,6 +6,7 @@ package action_test
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/juju/names"
 	jc "github.com/juju/testing/checkers"
@@ -574,6 +575,140 @@ func (s *actionSuite) TestCancel(c *gc.C) {
 	c.Assert(myActions[1].Status, gc.Equals, params.ActionCancelled)
 }
 
+func (s *actionSuite) TestAbort(c *gc.C) {
+	results, err := s.action.Enqueue(params.Actions{
+		Actions: []params.Action{{
+			Receiver: s.wordpressUnit.Tag().String(),
+			Name:     "fakeaction",
+			Timeout:  time.Minute,
+		}, {
+			Receiver: s.wordpressUnit.Tag().String(),
+			Name:     "fakeaction",
+		}, {
+			Receiver: s.wordpressUnit.Tag().String(),
+			Name:     "fakeaction",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	for _, res := range results.Results {
+		c.Assert(res.Error, gc.IsNil)
+	}
+	c.Assert(results.Results[0].Action.Timeout, gc.Equals, time.Minute)
+
+	// Start the second Action and finish the third.
+	runningTag, err := names.ParseActionTag(results.Results[1].Action.Tag)
+	c.Assert(err, jc.ErrorIsNil)
+	running, err := s.State.ActionByTag(runningTag)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = running.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	finishedTag, err := names.ParseActionTag(results.Results[2].Action.Tag)
+	c.Assert(err, jc.ErrorIsNil)
+	finished, err := s.State.ActionByTag(finishedTag)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = finished.Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+
+	aborted, err := s.action.Abort(params.Entities{
+		Entities: []params.Entity{
+			{Tag: results.Results[0].Action.Tag},
+			{Tag: results.Results[1].Action.Tag},
+			{Tag: results.Results[2].Action.Tag},
+			{Tag: s.wordpressUnit.Tag().String()},
+		}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(aborted.Results, gc.HasLen, 4)
+	c.Assert(aborted.Results[0].Error, gc.IsNil)
+	c.Assert(aborted.Results[0].Status, gc.Equals, params.ActionCancelled)
+	c.Assert(aborted.Results[1].Error, gc.IsNil)
+	c.Assert(aborted.Results[1].Status, gc.Equals, params.ActionRunning)
+	c.Assert(aborted.Results[2].Error, gc.ErrorMatches, `cannot abort action ".*": action ".*" has already finished`)
+	c.Assert(aborted.Results[3].Error, gc.ErrorMatches, "id not found")
+
+	running, err = s.State.ActionByTag(runningTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(running.Aborting(), jc.IsTrue)
+}
+
+func (s *actionSuite) TestOutput(c *gc.C) {
+	action, err := s.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	now := time.Date(2015, 6, 1, 2, 0, 0, 0, time.UTC)
+	chunk := func(seq int, data string) state.OutputChunk {
+		return state.OutputChunk{Seq: seq, Stream: state.OutputStdout, Data: data, Time: now}
+	}
+	err = s.State.AddOutput(action.Id(), s.wordpressUnit.UnitTag(), []state.OutputChunk{
+		chunk(1, "one\n"),
+		chunk(2, "two\n"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	// Output recorded by any other unit under the action's id is ignored.
+	err = s.State.AddOutput(action.Id(), s.mysqlUnit.UnitTag(), []state.OutputChunk{
+		chunk(1, "bogus\n"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	tag := action.Tag().String()
+	results, err := s.action.Output(params.OutputQueries{
+		Queries: []params.OutputQuery{
+			{Id: tag},
+			{Id: tag, After: map[string]int{s.wordpressUnit.Name(): 1}},
+			{Id: s.wordpressUnit.Tag().String()},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0], jc.DeepEquals, params.OutputResult{
+		Chunks: []params.OutputChunk{
+			{Unit: s.wordpressUnit.Name(), Seq: 1, Stream: "stdout", Data: "one\n", Time: now},
+			{Unit: s.wordpressUnit.Name(), Seq: 2, Stream: "stdout", Data: "two\n", Time: now},
+		},
+	})
+	c.Assert(results.Results[1], jc.DeepEquals, params.OutputResult{
+		Chunks: []params.OutputChunk{
+			{Unit: s.wordpressUnit.Name(), Seq: 2, Stream: "stdout", Data: "two\n", Time: now},
+		},
+	})
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, "id not found")
+}
+
+func (s *actionSuite) TestServicesUnits(c *gc.C) {
+	dead, err := s.wordpress.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = dead.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	alive, err := s.wordpress.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.action.ServicesUnits(params.Entities{
+		Entities: []params.Entity{
+			{Tag: s.wordpress.Tag().String()},
+			{Tag: s.mysql.Tag().String()},
+			{Tag: s.dummy.Tag().String()},
+			{Tag: names.NewServiceTag("nonsuch").String()},
+			{Tag: s.mysqlUnit.Tag().String()},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.StringsResults{
+		Results: []params.StringsResult{
+			{Result: []string{s.wordpressUnit.Tag().String(), alive.Tag().String()}},
+			{Result: []string{s.mysqlUnit.Tag().String()}},
+			{Result: []string{}},
+			{Error: &params.Error{
+				Message: `service "nonsuch" not found`,
+				Code:    params.CodeNotFound,
+			}},
+			{Error: &params.Error{
+				Message: common.ErrBadId.Error(),
+				Code:    params.CodeNotFound,
+			}},
+		},
+	})
+}
+
 func (s *actionSuite) TestServicesCharmActions(c *gc.C) {
 	actionSchemas := map[string]map[string]interface{}{
 		"snapshot": {
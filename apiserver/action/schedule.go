@@ -0,0 +1,81 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"github.com/juju/names"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// AddSchedules adds schedules on which Actions are queued up
+// repeatedly.
+func (a *ActionAPI) AddSchedules(arg params.ActionSchedules) (params.ErrorResults, error) {
+	response := params.ErrorResults{Results: make([]params.ErrorResult, len(arg.Schedules))}
+	for i, schedule := range arg.Schedules {
+		_, err := a.state.AddActionSchedule(state.ActionScheduleArgs{
+			Name:       schedule.Name,
+			Receiver:   schedule.Receiver,
+			ActionName: schedule.ActionName,
+			Parameters: schedule.Parameters,
+			Start:      schedule.Start,
+			Interval:   schedule.Interval,
+		})
+		response.Results[i].Error = common.ServerError(err)
+	}
+	return response, nil
+}
+
+// ListSchedules returns all of the schedules in the environment, with
+// the most recent runs of each.
+func (a *ActionAPI) ListSchedules() (params.ActionSchedules, error) {
+	schedules, err := a.state.AllActionSchedules()
+	if err != nil {
+		return params.ActionSchedules{}, common.ServerError(err)
+	}
+	response := params.ActionSchedules{Schedules: make([]params.ActionSchedule, len(schedules))}
+	for i, schedule := range schedules {
+		response.Schedules[i] = makeActionSchedule(schedule)
+	}
+	return response, nil
+}
+
+// RemoveSchedules removes the schedules with the given names. Actions
+// that have already been queued up by the schedules are not affected.
+func (a *ActionAPI) RemoveSchedules(arg params.ActionScheduleNames) (params.ErrorResults, error) {
+	response := params.ErrorResults{Results: make([]params.ErrorResult, len(arg.Names))}
+	for i, name := range arg.Names {
+		err := a.state.RemoveActionSchedule(name)
+		response.Results[i].Error = common.ServerError(err)
+	}
+	return response, nil
+}
+
+// makeActionSchedule converts a *state.ActionSchedule to a
+// params.ActionSchedule.
+func makeActionSchedule(schedule *state.ActionSchedule) params.ActionSchedule {
+	result := params.ActionSchedule{
+		Name:       schedule.Name(),
+		Receiver:   schedule.Receiver(),
+		ActionName: schedule.ActionName(),
+		Parameters: schedule.Parameters(),
+		Start:      schedule.Start(),
+		Interval:   schedule.Interval(),
+		NextRun:    schedule.NextRun(),
+	}
+	for _, run := range schedule.Runs() {
+		resultRun := params.ActionScheduleRun{
+			Due:     run.Due,
+			Skipped: run.Skipped,
+			Message: run.Message,
+		}
+		if run.ActionId != "" {
+			resultRun.Action = names.NewActionTag(run.ActionId).String()
+		}
+		result.Runs = append(result.Runs, resultRun)
+	}
+	return result
+}
@@ -0,0 +1,76 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+func (s *actionSuite) TestSchedules(c *gc.C) {
+	start := time.Date(2015, 6, 1, 2, 0, 0, 0, time.UTC)
+	results, err := s.action.AddSchedules(params.ActionSchedules{
+		Schedules: []params.ActionSchedule{{
+			Name:       "nightly",
+			Receiver:   "wordpress/leader",
+			ActionName: "fakeaction",
+			Start:      start,
+			Interval:   24 * time.Hour,
+		}, {
+			Name:       "hourly",
+			Receiver:   s.mysqlUnit.Name(),
+			ActionName: "fakeaction",
+			Start:      start,
+			Interval:   time.Hour,
+		}, {
+			Name:       "broken",
+			Receiver:   "wordpress/leader",
+			ActionName: "nonsuch",
+			Start:      start,
+			Interval:   time.Hour,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.IsNil)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `cannot add action schedule "broken": action "nonsuch" not defined on service "wordpress"`)
+
+	schedule, err := s.State.ActionSchedule("nightly")
+	c.Assert(err, jc.ErrorIsNil)
+	action, err := s.State.EnqueueAction(s.wordpressUnit.Tag(), "fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = schedule.RecordRun(state.ActionScheduleRun{Due: start, ActionId: action.Id()}, start)
+	c.Assert(err, jc.ErrorIsNil)
+
+	list, err := s.action.ListSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(list.Schedules, gc.HasLen, 2)
+	c.Assert(list.Schedules[0].Name, gc.Equals, "hourly")
+	c.Assert(list.Schedules[0].Receiver, gc.Equals, s.mysqlUnit.Name())
+	c.Assert(list.Schedules[0].Runs, gc.HasLen, 0)
+	c.Assert(list.Schedules[1].Name, gc.Equals, "nightly")
+	c.Assert(list.Schedules[1].Interval, gc.Equals, 24*time.Hour)
+	c.Assert(list.Schedules[1].NextRun.Equal(start.Add(24*time.Hour)), jc.IsTrue)
+	c.Assert(list.Schedules[1].Runs, gc.HasLen, 1)
+	c.Assert(list.Schedules[1].Runs[0].Action, gc.Equals, action.Tag().String())
+
+	removed, err := s.action.RemoveSchedules(params.ActionScheduleNames{
+		Names: []string{"hourly", "nonsuch"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(removed.Results, gc.HasLen, 2)
+	c.Assert(removed.Results[0].Error, gc.IsNil)
+	c.Assert(removed.Results[1].Error, gc.ErrorMatches, `action schedule "nonsuch" not found`)
+
+	list, err = s.action.ListSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(list.Schedules, gc.HasLen, 1)
+	c.Assert(list.Schedules[0].Name, gc.Equals, "nightly")
+}
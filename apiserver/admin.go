@@ -118,6 +118,42 @@ func (a *admin) doLogin(req params.LoginRequest, loginVersion int) (params.Login
 	}
 	a.root.entity = entity
 
+	// If the user logged in with an API token, record its use and
+	// restrict the session to what the token permits.
+	var token *state.UserToken
+	if isUser {
+		if token, err = a.useToken(req, entity.Tag(), loginVersion); err != nil {
+			return fail, errors.Trace(err)
+		}
+		if token != nil {
+			authedApi = newTokenRoot(authedApi)
+		}
+	}
+
+	if isUser && !serverOnlyLogin {
+		// Restrict the calls users may make to those permitted by
+		// their level of access to the environment, and by the token
+		// they logged in with, if any.
+		envUser, err := a.root.state.EnvironmentUser(entity.Tag().(names.UserTag))
+		if err != nil {
+			return fail, errors.Trace(err)
+		}
+		access := envUser.Access()
+		if token != nil && !token.Access().Includes(access) {
+			access = token.Access()
+		}
+		authedApi = newAccessRoot(authedApi, access)
+	}
+
+	// Record any calls made by users that may change the environment.
+	if isUser {
+		var tokenID string
+		if token != nil {
+			tokenID = token.ID()
+		}
+		authedApi = newAuditingRoot(authedApi, a.root.state, entity.Tag(), tokenID)
+	}
+
 	if a.reqNotifier != nil {
 		a.reqNotifier.login(entity.Tag().String())
 	}
@@ -184,6 +220,34 @@ func (a *admin) doLogin(req params.LoginRequest, loginVersion int) (params.Login
 	return loginResult, nil
 }
 
+// useToken returns the API token that the user logged in with, or nil
+// if they logged in with a password. Each use of a token is recorded in
+// the audit log against the token's ID.
+func (a *admin) useToken(req params.LoginRequest, user names.Tag, loginVersion int) (*state.UserToken, error) {
+	id, _, ok := state.ParseTokenCredential(req.Credentials)
+	if !ok {
+		return nil, nil
+	}
+	token, err := a.root.state.UserToken(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := token.UpdateLastUsed(); err != nil {
+		logger.Warningf("cannot update last use of token %q: %v", id, err)
+	}
+	err = a.root.state.AddAuditEntry(state.AuditEntry{
+		User:    user.String(),
+		TokenID: id,
+		Facade:  "Admin",
+		Version: loginVersion,
+		Method:  "Login",
+	})
+	if err != nil {
+		logger.Errorf("cannot record login by %s with token %q: %v", user, id, err)
+	}
+	return token, nil
+}
+
 // checkCredsOfStateServerMachine checks the special case of a state server
 // machine creating an API connection for a different environment so it can
 // run API workers for that environment to do things like provisioning
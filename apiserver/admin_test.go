@@ -956,3 +956,80 @@ func (s *loginSuite) TestLoginUpdatesLastLoginAndConnection(c *gc.C) {
 	c.Assert(envUser.LastConnection(), gc.NotNil)
 	c.Assert(envUser.LastConnection().After(startTime), jc.IsTrue)
 }
+
+func (s *loginSuite) TestReadOnlyUserCannotChangeEnvironment(c *gc.C) {
+	info, cleanup := s.setupServerWithValidator(c, nil)
+	defer cleanup()
+
+	user := s.Factory.MakeUser(c, &factory.UserParams{Password: "password", NoEnvUser: true})
+	s.Factory.MakeEnvUser(c, &factory.EnvUserParams{
+		User:   user.UserTag().Username(),
+		Access: state.ReadAccess,
+	})
+	s.Factory.MakeService(c, &factory.ServiceParams{Name: "wordpress"})
+
+	info.Tag = user.UserTag()
+	info.Password = "password"
+	st, err := api.Open(info, fastDialOpts)
+	c.Assert(err, jc.ErrorIsNil)
+	defer st.Close()
+
+	client := st.Client()
+	_, err = client.Status(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = client.ServiceExpose("wordpress")
+	c.Assert(err, gc.ErrorMatches, `read access to the environment does not permit Client.ServiceExpose: permission denied`)
+	c.Assert(params.IsCodeUnauthorized(err), jc.IsTrue)
+}
+
+func (s *loginSuite) TestLoginWithToken(c *gc.C) {
+	info, cleanup := s.setupServerWithValidator(c, nil)
+	defer cleanup()
+
+	user := s.Factory.MakeUser(c, &factory.UserParams{Password: "password"})
+	token, credential, err := user.CreateToken(time.Hour, state.ReadAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	s.Factory.MakeService(c, &factory.ServiceParams{Name: "wordpress"})
+
+	info.Tag = user.UserTag()
+	info.Password = credential
+	st, err := api.Open(info, fastDialOpts)
+	c.Assert(err, jc.ErrorIsNil)
+	defer st.Close()
+
+	// The token restricts the user, who has write access, to reading
+	// the environment.
+	client := st.Client()
+	_, err = client.Status(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = client.ServiceExpose("wordpress")
+	c.Assert(err, gc.ErrorMatches, `read access to the environment does not permit Client.ServiceExpose: permission denied`)
+
+	token, err = s.State.UserToken(token.ID())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(token.LastUsed(), gc.NotNil)
+
+	entries, err := s.State.AuditEntries(state.AuditFilter{User: user.Tag().String()})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, gc.HasLen, 1)
+	c.Assert(entries[0].Facade, gc.Equals, "Admin")
+	c.Assert(entries[0].Method, gc.Equals, "Login")
+	c.Assert(entries[0].TokenID, gc.Equals, token.ID())
+}
+
+func (s *loginSuite) TestLoginWithRevokedToken(c *gc.C) {
+	info, cleanup := s.setupServerWithValidator(c, nil)
+	defer cleanup()
+
+	user := s.Factory.MakeUser(c, &factory.UserParams{Password: "password"})
+	token, credential, err := user.CreateToken(time.Hour, state.AdminAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	err = user.RevokeToken(token.ID())
+	c.Assert(err, jc.ErrorIsNil)
+
+	info.Tag = user.UserTag()
+	info.Password = credential
+	_, err = api.Open(info, fastDialOpts)
+	c.Assert(err, gc.ErrorMatches, "invalid entity name or password")
+}
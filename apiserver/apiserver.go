@@ -233,8 +233,9 @@ type requestNotifier struct {
 	id    int64
 	start time.Time
 
-	mu   sync.Mutex
-	tag_ string
+	mu       sync.Mutex
+	tag_     string
+	loggedIn bool
 }
 
 var globalCounter int64
@@ -250,6 +251,10 @@ func newRequestNotifier() *requestNotifier {
 func (n *requestNotifier) login(tag string) {
 	n.mu.Lock()
 	n.tag_ = tag
+	if !n.loggedIn {
+		n.loggedIn = true
+		apiLogins.With().Inc()
+	}
 	n.mu.Unlock()
 }
 
@@ -261,6 +266,9 @@ func (n *requestNotifier) tag() (tag string) {
 }
 
 func (n *requestNotifier) ServerRequest(hdr *rpc.Header, body interface{}) {
+	if logger.EffectiveLogLevel() > loggo.DEBUG {
+		return
+	}
 	if hdr.Request.Type == "Pinger" && hdr.Request.Action == "Ping" {
 		return
 	}
@@ -275,6 +283,15 @@ func (n *requestNotifier) ServerRequest(hdr *rpc.Header, body interface{}) {
 }
 
 func (n *requestNotifier) ServerReply(req rpc.Request, hdr *rpc.Header, body interface{}, timeSpent time.Duration) {
+	// Calls to unknown facades and methods are not recorded, so
+	// that clients cannot add arbitrary labels to the metrics.
+	if hdr.ErrorCode != params.CodeNotImplemented {
+		apiRequests.With(req.Type, req.Action).Inc()
+		apiRequestDuration.With(req.Type, req.Action).Observe(timeSpent.Seconds())
+	}
+	if logger.EffectiveLogLevel() > loggo.DEBUG {
+		return
+	}
 	if req.Type == "Pinger" && req.Action == "Ping" {
 		return
 	}
@@ -289,10 +306,17 @@ func (n *requestNotifier) ServerReply(req rpc.Request, hdr *rpc.Header, body int
 }
 
 func (n *requestNotifier) join(req *http.Request) {
+	apiConnections.With().Inc()
 	logger.Infof("[%X] API connection from %s", n.id, req.RemoteAddr)
 }
 
 func (n *requestNotifier) leave() {
+	apiConnections.With().Dec()
+	n.mu.Lock()
+	if n.loggedIn {
+		apiLogins.With().Dec()
+	}
+	n.mu.Unlock()
 	logger.Infof("[%X] %s API connection terminated after %v", n.id, n.tag(), time.Since(n.start))
 }
 
@@ -396,6 +420,12 @@ func (srv *Server) run(lis net.Listener) {
 			httpHandler{ssState: srv.state},
 		}},
 	)
+	handleAll(mux, "/metrics",
+		&metricsHandler{httpHandler{
+			ssState:            srv.state,
+			stateServerEnvOnly: true,
+		}},
+	)
 	handleAll(mux, "/", http.HandlerFunc(srv.apiHandler))
 	// The error from http.Serve is not interesting.
 	http.Serve(lis, mux)
@@ -436,13 +466,9 @@ func (srv *Server) serveConn(wsConn *websocket.Conn, reqNotifier *requestNotifie
 	if loggo.GetLogger("juju.rpc.jsoncodec").EffectiveLogLevel() <= loggo.TRACE {
 		codec.SetLogging(true)
 	}
-	var notifier rpc.RequestNotifier
-	if logger.EffectiveLogLevel() <= loggo.DEBUG {
-		// Incur request monitoring overhead only if we
-		// know we'll need it.
-		notifier = reqNotifier
-	}
-	conn := rpc.NewConn(codec, notifier)
+	// The notifier is always installed, as it records the request
+	// metrics; it only logs requests at debug level or below.
+	conn := rpc.NewConn(codec, reqNotifier)
 
 	var h *apiHandler
 	st, _, err := validateEnvironUUID(validateArgs{st: srv.state, envUUID: envUUID})
@@ -0,0 +1,194 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/audit"
+	"github.com/juju/juju/rpc"
+	"github.com/juju/juju/rpc/rpcreflect"
+	"github.com/juju/juju/state"
+)
+
+// auditingRoot records every call that may change the environment
+// in the environment's audit log.
+type auditingRoot struct {
+	rpc.MethodFinder
+	recorder auditRecorder
+	user     names.Tag
+	tokenID  string
+}
+
+// auditRecorder records audit entries. It is implemented by
+// *state.State.
+type auditRecorder interface {
+	AddAuditEntry(entry state.AuditEntry) error
+}
+
+// newAuditingRoot returns a new auditingRoot that records the calls
+// made by the given user. If the user logged in with an API token,
+// tokenID holds the token's ID and the calls are recorded against it.
+func newAuditingRoot(finder rpc.MethodFinder, recorder auditRecorder, user names.Tag, tokenID string) *auditingRoot {
+	return &auditingRoot{
+		MethodFinder: finder,
+		recorder:     recorder,
+		user:         user,
+		tokenID:      tokenID,
+	}
+}
+
+// FindMethod returns a caller that records the call in the audit log
+// once it completes, unless the call is known to be read-only. Calls
+// that the user is not permitted to make are recorded straight away, as
+// they never reach a caller; their arguments are not known yet.
+func (r *auditingRoot) FindMethod(rootName string, version int, methodName string) (rpcreflect.MethodCaller, error) {
+	caller, err := r.MethodFinder.FindMethod(rootName, version, methodName)
+	if errors.Cause(err) == common.ErrPerm {
+		entry := r.newEntry(rootName, version, methodName)
+		entry.Error = err.Error()
+		r.record(entry)
+		return nil, err
+	} else if err != nil {
+		return nil, err
+	}
+	if isCallReadOnly(rootName, methodName) {
+		return caller, nil
+	}
+	return &auditingCaller{
+		MethodCaller: caller,
+		root:         r,
+		facade:       rootName,
+		version:      version,
+		method:       methodName,
+	}, nil
+}
+
+// auditingCaller wraps a MethodCaller, recording each call made
+// through it.
+type auditingCaller struct {
+	rpcreflect.MethodCaller
+	root    *auditingRoot
+	facade  string
+	version int
+	method  string
+}
+
+// Call implements rpcreflect.MethodCaller.
+func (c *auditingCaller) Call(objId string, arg reflect.Value) (reflect.Value, error) {
+	result, err := c.MethodCaller.Call(objId, arg)
+	entry := c.root.newEntry(c.facade, c.version, c.method)
+	entry.Args = redactedJSON(arg)
+	if err != nil {
+		entry.Error = err.Error()
+	} else {
+		entry.Result = redactedJSON(result)
+	}
+	c.root.record(entry)
+	return result, err
+}
+
+// newEntry returns an audit entry for a call to the given facade
+// method made now by the root's user.
+func (r *auditingRoot) newEntry(facade string, version int, method string) state.AuditEntry {
+	return state.AuditEntry{
+		Timestamp: time.Now(),
+		User:      r.user.String(),
+		TokenID:   r.tokenID,
+		Facade:    facade,
+		Version:   version,
+		Method:    method,
+	}
+}
+
+// record writes the given entry to the audit log. Failures are logged
+// rather than failing the call being recorded.
+func (r *auditingRoot) record(entry state.AuditEntry) {
+	audit.Audit(auditTagger{r.user}, "%s(%d).%s", entry.Facade, entry.Version, entry.Method)
+	if err := r.recorder.AddAuditEntry(entry); err != nil {
+		logger.Errorf("cannot record %s(%d).%s call by %s: %v", entry.Facade, entry.Version, entry.Method, r.user, err)
+	}
+}
+
+// auditTagger adapts a names.Tag to the audit.Tagger interface.
+type auditTagger struct {
+	tag names.Tag
+}
+
+// Tag implements audit.Tagger.
+func (t auditTagger) Tag() string {
+	return t.tag.String()
+}
+
+// redactedFields holds substrings of field names whose values must
+// never be written to the audit log.
+var redactedFields = []string{
+	"password",
+	"secret",
+	"credential",
+	"private-key",
+	"privatekey",
+	"access-key",
+	"token",
+	"nonce",
+}
+
+const redactedValue = "<redacted>"
+
+// redactedJSON returns the JSON serialisation of v with the values of
+// any secret fields redacted. It returns an empty string if v is not
+// valid or cannot be serialised.
+func redactedJSON(v reflect.Value) string {
+	if !v.IsValid() || !v.CanInterface() {
+		return ""
+	}
+	data, err := json.Marshal(v.Interface())
+	if err != nil {
+		return ""
+	}
+	var generic interface{}
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return ""
+	}
+	data, err = json.Marshal(redact(generic))
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+func redact(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if isRedactedField(key) && value != nil {
+				v[key] = redactedValue
+				continue
+			}
+			v[key] = redact(value)
+		}
+	case []interface{}:
+		for i, value := range v {
+			v[i] = redact(value)
+		}
+	}
+	return v
+}
+
+func isRedactedField(name string) bool {
+	name = strings.ToLower(name)
+	for _, field := range redactedFields {
+		if strings.Contains(name, field) {
+			return true
+		}
+	}
+	return false
+}
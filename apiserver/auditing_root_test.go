@@ -0,0 +1,199 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver_test
+
+import (
+	"reflect"
+
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver"
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/rpc"
+	"github.com/juju/juju/rpc/rpcreflect"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing"
+)
+
+type auditingRootSuite struct {
+	testing.BaseSuite
+
+	finder   *fakeFinder
+	recorder *fakeAuditRecorder
+}
+
+var _ = gc.Suite(&auditingRootSuite{})
+
+func (s *auditingRootSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.finder = &fakeFinder{}
+	s.recorder = &fakeAuditRecorder{}
+}
+
+func (s *auditingRootSuite) root() rpc.MethodFinder {
+	return apiserver.TestingAuditingRoot(s.finder, s.recorder, names.NewUserTag("bob"), "")
+}
+
+func (s *auditingRootSuite) TestMutatingCallRecorded(c *gc.C) {
+	s.finder.result = params.ErrorResult{}
+	caller, err := s.root().FindMethod("Client", 0, "ServiceExpose")
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.ServiceExpose{ServiceName: "wordpress"}
+	_, err = caller.Call("", reflect.ValueOf(args))
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.recorder.entries, gc.HasLen, 1)
+	entry := s.recorder.entries[0]
+	c.Check(entry.User, gc.Equals, "user-bob@local")
+	c.Check(entry.TokenID, gc.Equals, "")
+	c.Check(entry.Facade, gc.Equals, "Client")
+	c.Check(entry.Version, gc.Equals, 0)
+	c.Check(entry.Method, gc.Equals, "ServiceExpose")
+	c.Check(entry.Args, gc.Equals, `{"ServiceName":"wordpress"}`)
+	c.Check(entry.Result, gc.Equals, `{"Error":null}`)
+	c.Check(entry.Error, gc.Equals, "")
+	c.Check(entry.Timestamp.IsZero(), jc.IsFalse)
+}
+
+func (s *auditingRootSuite) TestTokenRecorded(c *gc.C) {
+	root := apiserver.TestingAuditingRoot(s.finder, s.recorder, names.NewUserTag("bob"), "f00d")
+	caller, err := root.FindMethod("Client", 0, "ServiceExpose")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = caller.Call("", reflect.ValueOf(params.ServiceExpose{ServiceName: "wordpress"}))
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.recorder.entries, gc.HasLen, 1)
+	c.Check(s.recorder.entries[0].TokenID, gc.Equals, "f00d")
+}
+
+func (s *auditingRootSuite) TestFailedCallRecorded(c *gc.C) {
+	s.finder.err = errors.New("boom")
+	caller, err := s.root().FindMethod("Client", 0, "ServiceDestroy")
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = caller.Call("", reflect.ValueOf(params.ServiceDestroy{ServiceName: "mysql"}))
+	c.Assert(err, gc.ErrorMatches, "boom")
+
+	c.Assert(s.recorder.entries, gc.HasLen, 1)
+	c.Check(s.recorder.entries[0].Error, gc.Equals, "boom")
+	c.Check(s.recorder.entries[0].Result, gc.Equals, "")
+}
+
+func (s *auditingRootSuite) TestReadOnlyCallNotRecorded(c *gc.C) {
+	for _, call := range []struct {
+		facade string
+		method string
+	}{
+		{"Client", "FullStatus"},
+		{"Client", "EnvironmentGet"},
+		{"AllWatcher", "Next"},
+		{"Pinger", "Ping"},
+	} {
+		caller, err := s.root().FindMethod(call.facade, 0, call.method)
+		c.Assert(err, jc.ErrorIsNil)
+		_, err = caller.Call("", reflect.Value{})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	c.Assert(s.recorder.entries, gc.HasLen, 0)
+}
+
+func (s *auditingRootSuite) TestRecordFailureDoesNotFailCall(c *gc.C) {
+	s.recorder.err = errors.New("mongo is sad")
+	caller, err := s.root().FindMethod("Client", 0, "ServiceExpose")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = caller.Call("", reflect.ValueOf(params.ServiceExpose{}))
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *auditingRootSuite) TestFindMethodError(c *gc.C) {
+	s.finder.findErr = errors.New("no such method")
+	caller, err := s.root().FindMethod("Client", 0, "ServiceExpose")
+	c.Assert(err, gc.ErrorMatches, "no such method")
+	c.Assert(caller, gc.IsNil)
+	c.Assert(s.recorder.entries, gc.HasLen, 0)
+}
+
+func (s *auditingRootSuite) TestPermissionDeniedRecorded(c *gc.C) {
+	s.finder.findErr = errors.Annotate(common.ErrPerm, "read access to the environment does not permit Client.ServiceExpose")
+	caller, err := s.root().FindMethod("Client", 0, "ServiceExpose")
+	c.Assert(errors.Cause(err), gc.Equals, common.ErrPerm)
+	c.Assert(caller, gc.IsNil)
+
+	c.Assert(s.recorder.entries, gc.HasLen, 1)
+	entry := s.recorder.entries[0]
+	c.Check(entry.User, gc.Equals, "user-bob@local")
+	c.Check(entry.Facade, gc.Equals, "Client")
+	c.Check(entry.Method, gc.Equals, "ServiceExpose")
+	c.Check(entry.Args, gc.Equals, "")
+	c.Check(entry.Error, gc.Equals, err.Error())
+}
+
+func (s *auditingRootSuite) TestRedactedJSON(c *gc.C) {
+	args := params.EnvironmentSet{
+		Config: map[string]interface{}{
+			"admin-secret":   "sekrit",
+			"secret-key":     "abc",
+			"access-key":     "def",
+			"default-series": "trusty",
+		},
+	}
+	c.Assert(apiserver.RedactedJSON(args), gc.Equals,
+		`{"Config":{"access-key":"<redacted>","admin-secret":"<redacted>","default-series":"trusty","secret-key":"<redacted>"}}`)
+
+	passwords := params.EntityPasswords{
+		Changes: []params.EntityPassword{{Tag: "user-bob", Password: "hunter2"}},
+	}
+	c.Assert(apiserver.RedactedJSON(passwords), gc.Equals,
+		`{"Changes":[{"Password":"<redacted>","Tag":"user-bob"}]}`)
+}
+
+type fakeFinder struct {
+	findErr error
+	result  interface{}
+	err     error
+}
+
+func (f *fakeFinder) FindMethod(rootName string, version int, methodName string) (rpcreflect.MethodCaller, error) {
+	if f.findErr != nil {
+		return nil, f.findErr
+	}
+	return &fakeCaller{f}, nil
+}
+
+type fakeCaller struct {
+	finder *fakeFinder
+}
+
+func (c *fakeCaller) ParamsType() reflect.Type {
+	return nil
+}
+
+func (c *fakeCaller) ResultType() reflect.Type {
+	return nil
+}
+
+func (c *fakeCaller) Call(objId string, arg reflect.Value) (reflect.Value, error) {
+	if c.finder.err != nil {
+		return reflect.Value{}, c.finder.err
+	}
+	if c.finder.result == nil {
+		return reflect.Value{}, nil
+	}
+	return reflect.ValueOf(c.finder.result), nil
+}
+
+type fakeAuditRecorder struct {
+	entries []state.AuditEntry
+	err     error
+}
+
+func (r *fakeAuditRecorder) AddAuditEntry(entry state.AuditEntry) error {
+	r.entries = append(r.entries, entry)
+	return r.err
+}
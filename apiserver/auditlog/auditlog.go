@@ -0,0 +1,124 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// The auditlog package defines an API end point for querying the
+// record of API calls made against an environment.
+package auditlog
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/names"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+var logger = loggo.GetLogger("juju.apiserver.auditlog")
+
+func init() {
+	common.RegisterStandardFacade("AuditLog", 1, NewAPI)
+}
+
+// AuditLog defines the methods on the audit log API end point.
+type AuditLog interface {
+	// Entries returns the audited calls made against the environment
+	// that match the given filter.
+	Entries(args params.AuditLogFilter) (params.AuditLogResults, error)
+}
+
+// API implements AuditLog and is the concrete implementation of the
+// api end point.
+type API struct {
+	state      *state.State
+	authorizer common.Authorizer
+}
+
+var _ AuditLog = (*API)(nil)
+
+// NewAPI returns a new audit log API facade.
+func NewAPI(
+	st *state.State,
+	resources *common.Resources,
+	authorizer common.Authorizer,
+) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		state:      st,
+		authorizer: authorizer,
+	}, nil
+}
+
+// checkCanRead returns an error unless the authenticated user is the
+// owner of the environment or of the state server environment.
+func (api *API) checkCanRead() error {
+	apiUser, ok := api.authorizer.GetAuthTag().(names.UserTag)
+	if !ok {
+		return common.ErrPerm
+	}
+	env, err := api.state.Environment()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if apiUser == env.Owner() {
+		return nil
+	}
+	serverEnv, err := api.state.StateServerEnvironment()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if apiUser == serverEnv.Owner() {
+		return nil
+	}
+	logger.Debugf("%s may not read the audit log of environment %s", apiUser, env.UUID())
+	return common.ErrPerm
+}
+
+// Entries implements AuditLog.Entries.
+func (api *API) Entries(args params.AuditLogFilter) (params.AuditLogResults, error) {
+	var result params.AuditLogResults
+	if err := api.checkCanRead(); err != nil {
+		return result, errors.Trace(err)
+	}
+	filter := state.AuditFilter{
+		Facade: args.Facade,
+		Limit:  args.Limit,
+	}
+	if args.User != "" {
+		if !names.IsValidUser(args.User) {
+			return result, errors.NotValidf("user name %q", args.User)
+		}
+		filter.User = names.NewUserTag(args.User).String()
+	}
+	if args.Since != nil {
+		filter.Since = *args.Since
+	}
+	if args.Until != nil {
+		filter.Until = *args.Until
+	}
+	entries, err := api.state.AuditEntries(filter)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.EnvUUID = api.state.EnvironUUID()
+	result.Entries = make([]params.AuditLogEntry, len(entries))
+	for i, entry := range entries {
+		result.Entries[i] = params.AuditLogEntry{
+			Timestamp: entry.Timestamp.In(time.UTC),
+			User:      entry.User,
+			TokenID:   entry.TokenID,
+			Facade:    entry.Facade,
+			Version:   entry.Version,
+			Method:    entry.Method,
+			Args:      entry.Args,
+			Result:    entry.Result,
+			Error:     entry.Error,
+		}
+	}
+	return result, nil
+}
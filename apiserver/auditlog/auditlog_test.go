@@ -0,0 +1,118 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog_test
+
+import (
+	"time"
+
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/auditlog"
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/state"
+)
+
+type auditLogSuite struct {
+	jujutesting.JujuConnSuite
+}
+
+var _ = gc.Suite(&auditLogSuite{})
+
+var t0 = time.Date(2015, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func (s *auditLogSuite) newAPI(c *gc.C, tag names.Tag) (*auditlog.API, error) {
+	auth := apiservertesting.FakeAuthorizer{Tag: tag}
+	return auditlog.NewAPI(s.State, common.NewResources(), auth)
+}
+
+func (s *auditLogSuite) addEntries(c *gc.C) {
+	for i, entry := range []state.AuditEntry{{
+		User:   s.AdminUserTag(c).String(),
+		Facade: "Client",
+		Method: "ServiceDeploy",
+	}, {
+		User:   names.NewUserTag("bob").String(),
+		Facade: "Client",
+		Method: "ServiceExpose",
+		Error:  "boom",
+	}, {
+		User:   s.AdminUserTag(c).String(),
+		Facade: "Block",
+		Method: "SwitchBlockOn",
+	}} {
+		entry.Timestamp = t0.Add(time.Duration(i) * time.Minute)
+		err := s.State.AddAuditEntry(entry)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+}
+
+func (s *auditLogSuite) TestNewAPIRefusesNonClient(c *gc.C) {
+	_, err := s.newAPI(c, names.NewMachineTag("0"))
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *auditLogSuite) TestEntriesAll(c *gc.C) {
+	s.addEntries(c)
+	api, err := s.newAPI(c, s.AdminUserTag(c))
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := api.Entries(params.AuditLogFilter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.EnvUUID, gc.Equals, s.State.EnvironUUID())
+	c.Assert(result.Entries, jc.DeepEquals, []params.AuditLogEntry{{
+		Timestamp: t0,
+		User:      s.AdminUserTag(c).String(),
+		Facade:    "Client",
+		Method:    "ServiceDeploy",
+	}, {
+		Timestamp: t0.Add(time.Minute),
+		User:      "user-bob@local",
+		Facade:    "Client",
+		Method:    "ServiceExpose",
+		Error:     "boom",
+	}, {
+		Timestamp: t0.Add(2 * time.Minute),
+		User:      s.AdminUserTag(c).String(),
+		Facade:    "Block",
+		Method:    "SwitchBlockOn",
+	}})
+}
+
+func (s *auditLogSuite) TestEntriesFiltered(c *gc.C) {
+	s.addEntries(c)
+	api, err := s.newAPI(c, s.AdminUserTag(c))
+	c.Assert(err, jc.ErrorIsNil)
+
+	since := t0.Add(30 * time.Second)
+	until := t0.Add(3 * time.Minute)
+	result, err := api.Entries(params.AuditLogFilter{
+		User:   "bob",
+		Facade: "Client",
+		Since:  &since,
+		Until:  &until,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Entries, gc.HasLen, 1)
+	c.Assert(result.Entries[0].Method, gc.Equals, "ServiceExpose")
+}
+
+func (s *auditLogSuite) TestEntriesInvalidUser(c *gc.C) {
+	api, err := s.newAPI(c, s.AdminUserTag(c))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = api.Entries(params.AuditLogFilter{User: "not/valid"})
+	c.Assert(err, gc.ErrorMatches, `user name "not/valid" not valid`)
+}
+
+func (s *auditLogSuite) TestEntriesDeniedToNonOwner(c *gc.C) {
+	user := s.Factory.MakeUser(c, nil)
+	api, err := s.newAPI(c, user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = api.Entries(params.AuditLogFilter{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
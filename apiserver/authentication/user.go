@@ -16,10 +16,17 @@ type UserAuthenticator struct {
 var _ EntityAuthenticator = (*UserAuthenticator)(nil)
 
 // Authenticate authenticates the provided entity and returns an error on authentication failure.
+// Users may authenticate with either their password or an API token.
 func (u *UserAuthenticator) Authenticate(entity state.Entity, password, nonce string) error {
-	if _, ok := entity.(*state.User); ok {
-		return u.AgentAuthenticator.Authenticate(entity, password, nonce)
+	user, ok := entity.(*state.User)
+	if !ok {
+		return common.ErrBadRequest
 	}
-
-	return common.ErrBadRequest
+	if id, secret, ok := state.ParseTokenCredential(password); ok {
+		if !user.TokenValid(id, secret) {
+			return common.ErrBadCreds
+		}
+		return nil
+	}
+	return u.AgentAuthenticator.Authenticate(entity, password, nonce)
 }
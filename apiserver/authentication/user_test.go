@@ -4,6 +4,8 @@
 package authentication_test
 
 import (
+	"time"
+
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
 	gc "gopkg.in/check.v1"
@@ -102,3 +104,31 @@ func (s *userAuthenticatorSuite) TestInvalidRelationLogin(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "invalid request")
 
 }
+
+func (s *userAuthenticatorSuite) TestUserLoginWithToken(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{
+		Name:     "bobbrown",
+		Password: "password",
+	})
+	_, credential, err := user.CreateToken(time.Hour, state.ReadAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	authenticator := &authentication.UserAuthenticator{}
+	err = authenticator.Authenticate(user, credential, "")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *userAuthenticatorSuite) TestUserLoginWithRevokedToken(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{
+		Name:     "bobbrown",
+		Password: "password",
+	})
+	token, credential, err := user.CreateToken(time.Hour, state.ReadAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	err = user.RevokeToken(token.ID())
+	c.Assert(err, jc.ErrorIsNil)
+
+	authenticator := &authentication.UserAuthenticator{}
+	err = authenticator.Authenticate(user, credential, "")
+	c.Assert(err, gc.ErrorMatches, "invalid entity name or password")
+}
@@ -5,11 +5,14 @@ package client
 
 import (
 	"fmt"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/names"
+	"github.com/juju/utils"
 	"gopkg.in/juju/charm.v5"
 
 	"github.com/juju/juju/api"
@@ -17,6 +20,7 @@ import (
 	"github.com/juju/juju/apiserver/highavailability"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/apiserver/service"
+	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/manual"
 	"github.com/juju/juju/instance"
@@ -158,10 +162,16 @@ func (c *Client) Resolved(p params.Resolved) error {
 	if err := c.check.ChangeAllowed(); err != nil {
 		return errors.Trace(err)
 	}
+	if p.Retry && p.CancelRetry {
+		return errors.New("cannot both retry and cancel the retry of a failed hook")
+	}
 	unit, err := c.api.state.Unit(p.UnitName)
 	if err != nil {
 		return err
 	}
+	if p.CancelRetry {
+		return unit.CancelHookRetry()
+	}
 	return unit.Resolve(p.Retry)
 }
 
@@ -232,7 +242,21 @@ func (c *Client) ServiceExpose(args params.ServiceExpose) error {
 	if err != nil {
 		return err
 	}
-	return svc.SetExposed()
+	if len(args.SourceCIDRs) == 0 {
+		return svc.SetExposed()
+	}
+	envcfg, err := c.api.state.EnvironConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	env, err := environs.New(envcfg)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, ok := environs.SupportsIngressFirewall(env); !ok {
+		return errors.NotSupportedf("exposing services to source CIDRs on provider %q", envcfg.Type())
+	}
+	return svc.SetExposedTo(args.SourceCIDRs)
 }
 
 // ServiceUnexpose changes the juju-managed firewall to unexpose any ports that
@@ -249,6 +273,26 @@ func (c *Client) ServiceUnexpose(args params.ServiceUnexpose) error {
 	return svc.ClearExposed()
 }
 
+// ServiceSetHookRetryPolicy sets the policy by which the failed hooks of
+// a service's units are retried automatically.
+func (c *Client) ServiceSetHookRetryPolicy(args params.ServiceSetHookRetryPolicy) error {
+	if err := c.check.ChangeAllowed(); err != nil {
+		return errors.Trace(err)
+	}
+	svc, err := c.api.state.Service(args.ServiceName)
+	if err != nil {
+		return err
+	}
+	if args.Policy == nil {
+		return svc.SetHookRetryPolicy(nil)
+	}
+	return svc.SetHookRetryPolicy(&config.HookRetryPolicy{
+		Attempts: args.Policy.Attempts,
+		Delay:    args.Policy.Delay,
+		MaxDelay: args.Policy.MaxDelay,
+	})
+}
+
 // ServiceDeploy fetches the charm from the charm store and deploys it.
 // AddCharm or AddLocalCharm should be called to add the charm
 // before calling ServiceDeploy, although for backward compatibility
@@ -767,6 +811,10 @@ func (c *Client) ShareEnvironment(args params.ModifyEnvironUsers) (result params
 		return result, errors.Errorf("api connection is not through a user")
 	}
 
+	if err := c.checkAccess(state.AdminAccess); err != nil {
+		return result, errors.Trace(err)
+	}
+
 	result = params.ErrorResults{
 		Results: make([]params.ErrorResult, len(args.Changes)),
 	}
@@ -783,7 +831,7 @@ func (c *Client) ShareEnvironment(args params.ModifyEnvironUsers) (result params
 		}
 		switch arg.Action {
 		case params.AddEnvUser:
-			_, err := c.api.state.AddEnvironmentUser(user, createdBy, "")
+			err := c.shareEnvironment(user, createdBy, arg.Access)
 			if err != nil {
 				err = errors.Annotate(err, "could not share environment")
 				result.Results[i].Error = common.ServerError(err)
@@ -801,6 +849,45 @@ func (c *Client) ShareEnvironment(args params.ModifyEnvironUsers) (result params
 	return result, nil
 }
 
+// shareEnvironment gives the user the requested access to the
+// environment, defaulting to write access. If the user already has
+// access to the environment and a level was requested, their access
+// is changed to that level.
+func (c *Client) shareEnvironment(user, createdBy names.UserTag, access string) error {
+	level := state.WriteAccess
+	if access != "" {
+		level = state.Access(access)
+	}
+	_, err := c.api.state.AddEnvironmentUserWithAccess(user, createdBy, "", level)
+	if !errors.IsAlreadyExists(err) || access == "" {
+		return err
+	}
+	envUser, err := c.api.state.EnvironmentUser(user)
+	if err != nil {
+		return err
+	}
+	return envUser.SetAccess(level)
+}
+
+// checkAccess returns a permission error if the authenticated user does
+// not have at least the required level of access to the environment.
+func (c *Client) checkAccess(required state.Access) error {
+	user, ok := c.api.auth.GetAuthTag().(names.UserTag)
+	if !ok {
+		return common.ErrPerm
+	}
+	envUser, err := c.api.state.EnvironmentUser(user)
+	if errors.IsNotFound(err) {
+		return common.ErrPerm
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if !envUser.Access().Includes(required) {
+		return common.ErrPerm
+	}
+	return nil
+}
+
 // EnvUserInfo returns information on all users in the environment.
 func (c *Client) EnvUserInfo() (params.EnvUserInfoResults, error) {
 	var results params.EnvUserInfoResults
@@ -821,6 +908,7 @@ func (c *Client) EnvUserInfo() (params.EnvUserInfoResults, error) {
 				CreatedBy:      user.CreatedBy(),
 				DateCreated:    user.DateCreated(),
 				LastConnection: user.LastConnection(),
+				Access:         string(user.Access()),
 			},
 		})
 	}
@@ -927,6 +1015,168 @@ func (c *Client) EnvironmentSet(args params.EnvironmentSet) error {
 	return c.api.state.UpdateEnvironConfig(attrs, nil, checkAgentVersion)
 }
 
+// credentialsLoadedAttempt defines how long EnvironmentUpdateCredentials
+// waits for the state server workers to pick up new credentials.
+var credentialsLoadedAttempt = utils.AttemptStrategy{
+	Total: 10 * time.Second,
+	Delay: 500 * time.Millisecond,
+}
+
+// EnvironmentUpdateCredentials implements the server-side part of the
+// update-credentials CLI command. Only the provider's credential
+// attributes may be given, and the new credentials are checked against
+// the provider before they are applied. Machines that failed to start
+// because the provider rejected the old credentials are then retried,
+// and the call waits for a while for the state server workers to
+// report that they have picked up the new credentials.
+func (c *Client) EnvironmentUpdateCredentials(args params.EnvironmentSet) (params.UpdateCredentialsResult, error) {
+	var result params.UpdateCredentialsResult
+	if err := c.check.ChangeAllowed(); err != nil {
+		return result, errors.Trace(err)
+	}
+	if len(args.Config) == 0 {
+		return result, errors.New("no credentials specified")
+	}
+	oldConfig, err := c.api.state.EnvironConfig()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	provider, err := environs.Provider(oldConfig.Type())
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	// The provider's secret attributes are the credentials it
+	// authenticates with.
+	credentials, err := provider.SecretAttrs(oldConfig)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	for key := range args.Config {
+		if _, ok := credentials[key]; !ok {
+			return result, errors.Errorf("%q is not a credential of the %q provider", key, oldConfig.Type())
+		}
+	}
+
+	newConfig, err := oldConfig.Apply(args.Config)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	newConfig, err = provider.Validate(newConfig, oldConfig)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	env, err := environs.New(newConfig)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	if _, err := env.AllInstances(); err != nil && err != environs.ErrNoInstances {
+		return result, errors.Annotate(err, "cannot verify credentials")
+	}
+
+	// Only apply the new credentials if the ones they replace have not
+	// been changed while they were being verified.
+	oldAttrs := oldConfig.AllAttrs()
+	checkCredentials := func(updateAttrs map[string]interface{}, removeAttrs []string, cfg *config.Config) error {
+		attrs := cfg.AllAttrs()
+		for key := range credentials {
+			if attrs[key] != oldAttrs[key] {
+				return errors.New("credentials changed while being verified")
+			}
+		}
+		return nil
+	}
+	if err := c.api.state.UpdateEnvironConfig(args.Config, nil, checkCredentials); err != nil {
+		return result, errors.Trace(err)
+	}
+	result.RetriedMachines, err = c.retryInvalidCredentialMachines()
+	if err != nil {
+		return result, errors.Annotate(err, "credentials updated, but cannot retry provisioning")
+	}
+	result.Workers, result.PendingWorkers, err = c.waitForCredentialsLoaded()
+	if err != nil {
+		return result, errors.Annotate(err, "credentials updated, but cannot check workers")
+	}
+	return result, nil
+}
+
+// waitForCredentialsLoaded waits for every worker that has recorded the
+// credentials it uses to pick up those now in the environment config.
+// It returns the workers that did so and those that did not before the
+// attempt ran out.
+func (c *Client) waitForCredentialsLoaded() (loaded, pending []string, err error) {
+	cfg, err := c.api.state.EnvironConfig()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	fingerprint, err := environs.CredentialsFingerprint(cfg)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	for a := credentialsLoadedAttempt.Start(); a.Next(); {
+		workers, err := c.api.state.CredentialsLoaded()
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		loaded, pending = nil, nil
+		for worker, workerFingerprint := range workers {
+			if workerFingerprint == fingerprint {
+				loaded = append(loaded, worker)
+			} else {
+				pending = append(pending, worker)
+			}
+		}
+		if len(pending) == 0 {
+			break
+		}
+	}
+	sort.Strings(loaded)
+	sort.Strings(pending)
+	return loaded, pending, nil
+}
+
+// retryInvalidCredentialMachines marks the provisioning errors of the
+// machines that the provisioner could not start because the provider
+// rejected its credentials as transient, so that they are retried, and
+// returns the ids of those machines.
+func (c *Client) retryInvalidCredentialMachines() ([]string, error) {
+	machines, err := c.api.state.AllMachines()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var ids []string
+	var entityStatus []params.EntityStatus
+	for _, m := range machines {
+		statusInfo, err := m.Status()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if statusInfo.Status != state.StatusError {
+			continue
+		}
+		if invalid, _ := statusInfo.Data["credentials-invalid"].(bool); !invalid {
+			continue
+		}
+		ids = append(ids, m.Id())
+		entityStatus = append(entityStatus, params.EntityStatus{
+			Tag:  m.Tag().String(),
+			Data: map[string]interface{}{"transient": true},
+		})
+	}
+	if len(entityStatus) == 0 {
+		return nil, nil
+	}
+	results, err := c.api.statusSetter.UpdateStatus(params.SetStatus{
+		Entities: entityStatus,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := results.Combine(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return ids, nil
+}
+
 // EnvironmentUnset implements the server-side part of the
 // set-environment CLI command.
 func (c *Client) EnvironmentUnset(args params.EnvironmentUnset) error {
@@ -9,10 +9,12 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/names"
 	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v5"
 	"gopkg.in/juju/charm.v5/charmrepo"
@@ -26,6 +28,7 @@ import (
 	"github.com/juju/juju/apiserver/testing"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/manual"
 	toolstesting "github.com/juju/juju/environs/tools/testing"
@@ -143,6 +146,7 @@ func (s *serverSuite) TestEnvUsersInfo(c *gc.C) {
 					CreatedBy:      owner.UserName(),
 					DateCreated:    owner.DateCreated(),
 					LastConnection: owner.LastConnection(),
+					Access:         "admin",
 				},
 			}, {
 				Result: &params.EnvUserInfo{
@@ -151,6 +155,7 @@ func (s *serverSuite) TestEnvUsersInfo(c *gc.C) {
 					CreatedBy:      owner.UserName(),
 					DateCreated:    localUser1.DateCreated(),
 					LastConnection: localUser1.LastConnection(),
+					Access:         "write",
 				},
 			}, {
 				Result: &params.EnvUserInfo{
@@ -159,6 +164,7 @@ func (s *serverSuite) TestEnvUsersInfo(c *gc.C) {
 					CreatedBy:      owner.UserName(),
 					DateCreated:    localUser2.DateCreated(),
 					LastConnection: localUser2.LastConnection(),
+					Access:         "write",
 				},
 			}, {
 				Result: &params.EnvUserInfo{
@@ -167,6 +173,7 @@ func (s *serverSuite) TestEnvUsersInfo(c *gc.C) {
 					CreatedBy:      owner.UserName(),
 					DateCreated:    remoteUser1.DateCreated(),
 					LastConnection: remoteUser1.LastConnection(),
+					Access:         "write",
 				},
 			}, {
 				Result: &params.EnvUserInfo{
@@ -175,6 +182,7 @@ func (s *serverSuite) TestEnvUsersInfo(c *gc.C) {
 					CreatedBy:      owner.UserName(),
 					DateCreated:    remoteUser2.DateCreated(),
 					LastConnection: remoteUser2.LastConnection(),
+					Access:         "write",
 				},
 			}},
 	}
@@ -320,6 +328,90 @@ func (s *serverSuite) TestShareEnvironmentAddUserTwice(c *gc.C) {
 	c.Assert(envUser.UserName(), gc.Equals, user.UserTag().Username())
 }
 
+func (s *serverSuite) TestShareEnvironmentWithAccess(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{Name: "foobar", NoEnvUser: true})
+	args := params.ModifyEnvironUsers{
+		Changes: []params.ModifyEnvironUser{{
+			UserTag: user.Tag().String(),
+			Action:  params.AddEnvUser,
+			Access:  "read",
+		}}}
+
+	result, err := s.client.ShareEnvironment(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), gc.IsNil)
+
+	envUser, err := s.State.EnvironmentUser(user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(envUser.Access(), gc.Equals, state.ReadAccess)
+}
+
+func (s *serverSuite) TestShareEnvironmentChangesAccess(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{Name: "foobar"})
+	args := params.ModifyEnvironUsers{
+		Changes: []params.ModifyEnvironUser{{
+			UserTag: user.Tag().String(),
+			Action:  params.AddEnvUser,
+			Access:  "admin",
+		}}}
+
+	result, err := s.client.ShareEnvironment(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), gc.IsNil)
+
+	envUser, err := s.State.EnvironmentUser(user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(envUser.Access(), gc.Equals, state.AdminAccess)
+}
+
+func (s *serverSuite) TestShareEnvironmentInvalidAccess(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{Name: "foobar", NoEnvUser: true})
+	args := params.ModifyEnvironUsers{
+		Changes: []params.ModifyEnvironUser{{
+			UserTag: user.Tag().String(),
+			Action:  params.AddEnvUser,
+			Access:  "superuser",
+		}}}
+
+	result, err := s.client.ShareEnvironment(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), gc.ErrorMatches, `could not share environment: access level "superuser" not valid`)
+}
+
+func (s *serverSuite) TestShareEnvironmentRequiresAdminAccess(c *gc.C) {
+	writer := s.Factory.MakeUser(c, &factory.UserParams{Name: "writer"})
+	auth := testing.FakeAuthorizer{Tag: writer.UserTag()}
+	writerClient, err := client.NewClient(s.State, common.NewResources(), auth)
+	c.Assert(err, jc.ErrorIsNil)
+
+	user := s.Factory.MakeUser(c, &factory.UserParams{Name: "foobar", NoEnvUser: true})
+	args := params.ModifyEnvironUsers{
+		Changes: []params.ModifyEnvironUser{{
+			UserTag: user.Tag().String(),
+			Action:  params.AddEnvUser,
+		}}}
+
+	_, err = writerClient.ShareEnvironment(args)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+
+	_, err = s.State.EnvironmentUser(user.UserTag())
+	c.Assert(errors.IsNotFound(err), jc.IsTrue)
+}
+
+func (s *serverSuite) TestDestroyEnvironmentRequiresAdminAccess(c *gc.C) {
+	writer := s.Factory.MakeUser(c, &factory.UserParams{Name: "writer"})
+	auth := testing.FakeAuthorizer{Tag: writer.UserTag()}
+	writerClient, err := client.NewClient(s.State, common.NewResources(), auth)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = writerClient.DestroyEnvironment()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+
+	env, err := s.State.Environment()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(env.Life(), gc.Equals, state.Alive)
+}
+
 func (s *serverSuite) TestShareEnvironmentInvalidTags(c *gc.C) {
 	for _, testParam := range []struct {
 		tag      string
@@ -1185,6 +1277,19 @@ func (s *clientSuite) TestClientServiceExpose(c *gc.C) {
 	}
 }
 
+func (s *clientSuite) TestClientServiceExposeToCIDRs(c *gc.C) {
+	s.AddTestingService(c, "dummy-service", s.AddTestingCharm(c, "dummy"))
+	err := s.APIState.Client().ServiceExpose("dummy-service", "10.0.0.0/8", "192.168.1.0/24")
+	c.Assert(err, jc.ErrorIsNil)
+	service, err := s.State.Service("dummy-service")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(service.IsExposed(), jc.IsTrue)
+	c.Assert(service.ExposedCIDRs(), jc.DeepEquals, []string{"10.0.0.0/8", "192.168.1.0/24"})
+
+	err = s.APIState.Client().ServiceExpose("dummy-service", "10.0.0.1")
+	c.Assert(err, gc.ErrorMatches, `cannot expose service "dummy-service": CIDR "10.0.0.1" not valid`)
+}
+
 func (s *clientSuite) setupServiceExpose(c *gc.C) {
 	charm := s.AddTestingCharm(c, "dummy")
 	serviceNames := []string{"dummy-service", "exposed-service"}
@@ -1483,6 +1588,50 @@ func (s *clientSuite) TestClientUnitResolvedRetry(c *gc.C) {
 	s.testClientUnitResolved(c, true, state.ResolvedRetryHooks)
 }
 
+func (s *clientSuite) TestClientCancelHookRetry(c *gc.C) {
+	u := s.setupResolved(c)
+	err := s.APIState.Client().CancelHookRetry("wordpress/0")
+	c.Assert(err, jc.ErrorIsNil)
+	err = u.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(u.Resolved(), gc.Equals, state.ResolvedCancelRetry)
+}
+
+func (s *clientSuite) TestClientServiceHookRetryPolicy(c *gc.C) {
+	s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	results, err := s.APIState.Client().ServiceGetHookRetryPolicy("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, &params.ServiceHookRetryPolicyResults{
+		Service: "wordpress",
+		Policy: params.HookRetryPolicy{
+			Attempts: config.DefaultHookRetryAttempts,
+			Delay:    config.DefaultHookRetryDelay,
+			MaxDelay: config.DefaultHookRetryMaxDelay,
+		},
+		Inherited: true,
+	})
+
+	policy := params.HookRetryPolicy{
+		Attempts: 4,
+		Delay:    time.Minute,
+		MaxDelay: time.Hour,
+	}
+	err = s.APIState.Client().ServiceSetHookRetryPolicy("wordpress", &policy)
+	c.Assert(err, jc.ErrorIsNil)
+	results, err = s.APIState.Client().ServiceGetHookRetryPolicy("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, &params.ServiceHookRetryPolicyResults{
+		Service: "wordpress",
+		Policy:  policy,
+	})
+
+	err = s.APIState.Client().ServiceSetHookRetryPolicy("wordpress", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	results, err = s.APIState.Client().ServiceGetHookRetryPolicy("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Inherited, jc.IsTrue)
+}
+
 func (s *clientSuite) setupResolved(c *gc.C) *state.Unit {
 	s.setUpScenario(c)
 	u, err := s.State.Unit("wordpress/0")
@@ -2739,6 +2888,111 @@ func (s *serverSuite) TestClientEnvironmentSetCannotChangeAgentVersion(c *gc.C)
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *serverSuite) TestClientEnvironmentUpdateCredentials(c *gc.C) {
+	args := params.EnvironmentSet{
+		Config: map[string]interface{}{"secret": "new secret"},
+	}
+	result, err := s.client.EnvironmentUpdateCredentials(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.RetriedMachines, gc.HasLen, 0)
+	s.assertEnvValue(c, "secret", "new secret")
+}
+
+func (s *serverSuite) TestClientEnvironmentUpdateCredentialsRetriesMachines(c *gc.C) {
+	invalid, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = invalid.SetStatus(state.StatusError, "credentials invalid: authentication failed", map[string]interface{}{
+		"credentials-invalid": true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	failed, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = failed.SetStatus(state.StatusError, "error", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.EnvironmentSet{
+		Config: map[string]interface{}{"secret": "new secret"},
+	}
+	result, err := s.client.EnvironmentUpdateCredentials(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.RetriedMachines, gc.DeepEquals, []string{invalid.Id()})
+
+	statusInfo, err := invalid.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(statusInfo.Status, gc.Equals, state.StatusError)
+	c.Assert(statusInfo.Data["transient"], jc.IsTrue)
+	statusInfo, err = failed.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(statusInfo.Data["transient"], gc.IsNil)
+}
+
+func (s *serverSuite) TestClientEnvironmentUpdateCredentialsReportsWorkers(c *gc.C) {
+	s.PatchValue(client.CredentialsLoadedAttempt, utils.AttemptStrategy{
+		Total: coretesting.ShortWait,
+		Delay: coretesting.ShortWait / 10,
+	})
+	oldConfig, err := s.State.EnvironConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	oldFingerprint, err := environs.CredentialsFingerprint(oldConfig)
+	c.Assert(err, jc.ErrorIsNil)
+	newConfig, err := oldConfig.Apply(map[string]interface{}{"secret": "new secret"})
+	c.Assert(err, jc.ErrorIsNil)
+	newFingerprint, err := environs.CredentialsFingerprint(newConfig)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetCredentialsLoaded("firewaller", newFingerprint)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetCredentialsLoaded("instancepoller", oldFingerprint)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.EnvironmentSet{
+		Config: map[string]interface{}{"secret": "new secret"},
+	}
+	result, err := s.client.EnvironmentUpdateCredentials(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Workers, jc.DeepEquals, []string{"firewaller"})
+	c.Assert(result.PendingWorkers, jc.DeepEquals, []string{"instancepoller"})
+}
+
+func (s *serverSuite) TestClientEnvironmentUpdateCredentialsNone(c *gc.C) {
+	_, err := s.client.EnvironmentUpdateCredentials(params.EnvironmentSet{})
+	c.Assert(err, gc.ErrorMatches, "no credentials specified")
+}
+
+func (s *serverSuite) TestClientEnvironmentUpdateCredentialsNotCredential(c *gc.C) {
+	args := params.EnvironmentSet{
+		Config: map[string]interface{}{
+			"secret":   "new secret",
+			"some-key": "value",
+		},
+	}
+	_, err := s.client.EnvironmentUpdateCredentials(args)
+	c.Assert(err, gc.ErrorMatches, `"some-key" is not a credential of the "dummy" provider`)
+	s.assertEnvValue(c, "secret", "pork")
+	s.assertEnvValueMissing(c, "some-key")
+}
+
+func (s *serverSuite) TestClientEnvironmentUpdateCredentialsVerifyFails(c *gc.C) {
+	err := s.State.UpdateEnvironConfig(map[string]interface{}{"broken": "AllInstances"}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.EnvironmentSet{
+		Config: map[string]interface{}{"secret": "new secret"},
+	}
+	_, err = s.client.EnvironmentUpdateCredentials(args)
+	c.Assert(err, gc.ErrorMatches, "cannot verify credentials: dummy.AllInstances is broken")
+	s.assertEnvValue(c, "secret", "pork")
+}
+
+func (s *serverSuite) TestBlockChangesClientEnvironmentUpdateCredentials(c *gc.C) {
+	s.BlockAllChanges(c, "TestBlockChangesClientEnvironmentUpdateCredentials")
+	args := params.EnvironmentSet{
+		Config: map[string]interface{}{"secret": "new secret"},
+	}
+	_, err := s.client.EnvironmentUpdateCredentials(args)
+	s.AssertBlocked(c, err, "TestBlockChangesClientEnvironmentUpdateCredentials")
+	s.assertEnvValue(c, "secret", "pork")
+}
+
 func (s *serverSuite) TestClientEnvironmentUnset(c *gc.C) {
 	err := s.State.UpdateEnvironConfig(map[string]interface{}{"abc": 123}, nil, nil)
 	c.Assert(err, jc.ErrorIsNil)
@@ -14,6 +14,9 @@ import (
 // DestroyEnvironment destroys all services and non-manager machine
 // instances in the environment.
 func (c *Client) DestroyEnvironment() (err error) {
+	if err = c.checkAccess(state.AdminAccess); err != nil {
+		return errors.Trace(err)
+	}
 	if err = c.check.DestroyAllowed(); err != nil {
 		return errors.Trace(err)
 	}
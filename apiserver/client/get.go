@@ -70,3 +70,25 @@ func (c *Client) ServiceGetCharmURL(args params.ServiceGet) (params.StringResult
 	charmURL, _ := service.CharmURL()
 	return params.StringResult{Result: charmURL.String()}, nil
 }
+
+// ServiceGetHookRetryPolicy returns the policy by which the failed hooks
+// of a service's units are retried automatically.
+func (c *Client) ServiceGetHookRetryPolicy(args params.ServiceGet) (params.ServiceHookRetryPolicyResults, error) {
+	service, err := c.api.state.Service(args.ServiceName)
+	if err != nil {
+		return params.ServiceHookRetryPolicyResults{}, err
+	}
+	policy, err := service.HookRetryPolicy()
+	if err != nil {
+		return params.ServiceHookRetryPolicyResults{}, err
+	}
+	return params.ServiceHookRetryPolicyResults{
+		Service: args.ServiceName,
+		Policy: params.HookRetryPolicy{
+			Attempts: policy.Attempts,
+			Delay:    policy.Delay,
+			MaxDelay: policy.MaxDelay,
+		},
+		Inherited: !service.HasHookRetryPolicy(),
+	}, nil
+}
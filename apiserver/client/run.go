@@ -87,6 +87,9 @@ func (c *Client) Run(run params.RunParams) (results params.RunResults, err error
 	if err := c.check.ChangeAllowed(); err != nil {
 		return params.RunResults{}, errors.Trace(err)
 	}
+	if run.OutputId != "" && !utils.IsValidUUIDString(run.OutputId) {
+		return results, errors.NotValidf("output id %q", run.OutputId)
+	}
 	units, err := getAllUnitNames(c.api.state, run.Units, run.Services)
 	if err != nil {
 		return results, err
@@ -106,6 +109,9 @@ func (c *Client) Run(run params.RunParams) (results params.RunResults, err error
 			return results, err
 		}
 		command := fmt.Sprintf("juju-run %s %s", unit.Name(), quotedCommands)
+		if run.OutputId != "" {
+			command = fmt.Sprintf("juju-run --output-id %s %s %s", run.OutputId, unit.Name(), quotedCommands)
+		}
 		execParam := remoteParamsForMachine(machine, command, run.Timeout)
 		execParam.UnitId = unit.Name()
 		params = append(params, execParam)
@@ -122,6 +128,25 @@ func (c *Client) Run(run params.RunParams) (results params.RunResults, err error
 	return ParallelExecute(c.getDataDir(), params), nil
 }
 
+// RunOutput returns the output produced so far by commands that were
+// run on units with the given output ids.
+func (c *Client) RunOutput(args params.OutputQueries) (params.OutputResults, error) {
+	results := params.OutputResults{Results: make([]params.OutputResult, len(args.Queries))}
+	for i, query := range args.Queries {
+		if !utils.IsValidUUIDString(query.Id) {
+			results.Results[i].Error = common.ServerError(errors.NotValidf("output id %q", query.Id))
+			continue
+		}
+		chunks, err := c.api.state.Output(query.Id, query.After)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Chunks = common.OutputChunksFromState(chunks, nil)
+	}
+	return results, nil
+}
+
 // RunOnAllMachines attempts to run the specified command on all the machines.
 func (c *Client) RunOnAllMachines(run params.RunParams) (params.RunResults, error) {
 	if err := c.check.ChangeAllowed(); err != nil {
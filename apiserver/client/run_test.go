@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/juju/names"
 	gitjujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils/exec"
@@ -317,6 +318,62 @@ func (s *runSuite) TestRunMachineAndService(c *gc.C) {
 	c.Assert(results, jc.DeepEquals, expectedResults)
 }
 
+func (s *runSuite) TestRunWithOutputId(c *gc.C) {
+	charm := s.AddTestingCharm(c, "dummy")
+	owner := s.Factory.MakeUser(c, nil).Tag()
+	magic, err := s.State.AddService("magic", owner.String(), charm, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.addUnit(c, magic)
+
+	s.mockSSH(c, echoInput)
+
+	client := s.APIState.Client()
+	outputId := "c8a71ff5-4e8c-4ed7-8c45-ad5f3c8f9b4d"
+	results, err := client.Run(
+		params.RunParams{
+			Commands: "hostname",
+			Timeout:  testing.LongWait,
+			Services: []string{"magic"},
+			OutputId: outputId,
+		})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(string(results[0].Stdout), gc.Matches, "juju-run --output-id "+outputId+" magic/0 'hostname'\r?\n")
+
+	_, err = client.Run(
+		params.RunParams{
+			Commands: "hostname",
+			Timeout:  testing.LongWait,
+			Services: []string{"magic"},
+			OutputId: "foo",
+		})
+	c.Assert(err, gc.ErrorMatches, `output id "foo" not valid`)
+}
+
+func (s *runSuite) TestRunOutput(c *gc.C) {
+	outputId := "c8a71ff5-4e8c-4ed7-8c45-ad5f3c8f9b4d"
+	now := time.Date(2015, 6, 1, 2, 0, 0, 0, time.UTC)
+	for _, unitName := range []string{"magic/0", "magic/1"} {
+		err := s.State.AddOutput(outputId, names.NewUnitTag(unitName), []state.OutputChunk{
+			{Seq: 1, Stream: state.OutputStdout, Data: "one\n", Time: now},
+			{Seq: 2, Stream: state.OutputStderr, Data: "two\n", Time: now},
+		})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	client := s.APIState.Client()
+	chunks, err := client.RunOutput(outputId, map[string]int{"magic/0": 1})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(chunks, jc.DeepEquals, []params.OutputChunk{
+		{Unit: "magic/0", Seq: 2, Stream: "stderr", Data: "two\n", Time: now},
+		{Unit: "magic/1", Seq: 1, Stream: "stdout", Data: "one\n", Time: now},
+		{Unit: "magic/1", Seq: 2, Stream: "stderr", Data: "two\n", Time: now},
+	})
+
+	_, err = client.RunOutput("foo", nil)
+	c.Assert(err, gc.ErrorMatches, `output id "foo" not valid`)
+}
+
 func (s *runSuite) TestBlockRunMachineAndService(c *gc.C) {
 	// Make three machines.
 	s.addMachineWithAddress(c, "10.3.2.1")
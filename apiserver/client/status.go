@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/juju/errors"
+	"github.com/juju/names"
 	"github.com/juju/utils/set"
 	"gopkg.in/juju/charm.v5"
 	"gopkg.in/juju/charm.v5/hooks"
@@ -62,7 +63,7 @@ func (c *Client) UnitStatusHistory(args params.StatusHistory) (api.UnitStatusHis
 	}
 	statuses := api.UnitStatusHistory{}
 	if args.Kind == params.KindCombined || args.Kind == params.KindWorkload {
-		unitStatuses, err := unit.StatusHistory(size)
+		unitStatuses, err := unit.StatusHistory(state.StatusHistoryFilter{Size: size})
 		if err != nil {
 			return api.UnitStatusHistory{}, errors.Trace(err)
 		}
@@ -81,7 +82,7 @@ func (c *Client) UnitStatusHistory(args params.StatusHistory) (api.UnitStatusHis
 		if !ok {
 			return api.UnitStatusHistory{}, errors.Errorf("cannot obtain agent for %q", args.Name)
 		}
-		agentStatuses, err := agent.StatusHistory(size)
+		agentStatuses, err := agent.StatusHistory(state.StatusHistoryFilter{Size: size})
 		if err != nil {
 			return api.UnitStatusHistory{}, errors.Trace(err)
 		}
@@ -106,6 +107,134 @@ func (c *Client) UnitStatusHistory(args params.StatusHistory) (api.UnitStatusHis
 	return statuses, nil
 }
 
+// statusHistorySource is implemented by the entities whose current
+// and past statuses may be queried.
+type statusHistorySource interface {
+	state.StatusGetter
+	state.StatusHistoryGetter
+}
+
+// StatusHistory returns the past statuses of a unit, machine or service
+// that match the given filter, oldest first. The entity's current
+// status is included if it matches the filter. Machines only have
+// agent statuses, and services only have workload statuses.
+func (c *Client) StatusHistory(args params.StatusHistoryArgs) (params.StatusHistoryResult, error) {
+	var noResult params.StatusHistoryResult
+	tag, err := names.ParseTag(args.Tag)
+	if err != nil {
+		return noResult, errors.Trace(err)
+	}
+	kind := args.Kind
+	if kind == "" {
+		kind = params.KindCombined
+	}
+	switch kind {
+	case params.KindCombined, params.KindAgent, params.KindWorkload:
+	default:
+		return noResult, errors.NotValidf("status history kind %q", kind)
+	}
+
+	sources := make(map[params.HistoryKind]statusHistorySource)
+	switch tag := tag.(type) {
+	case names.UnitTag:
+		unit, err := c.api.state.Unit(tag.Id())
+		if err != nil {
+			return noResult, errors.Trace(err)
+		}
+		if kind != params.KindAgent {
+			sources[params.KindWorkload] = unit
+		}
+		if kind != params.KindWorkload {
+			agent, ok := unit.Agent().(*state.UnitAgent)
+			if !ok {
+				return noResult, errors.Errorf("cannot obtain agent for %q", tag.Id())
+			}
+			sources[params.KindAgent] = agent
+		}
+	case names.MachineTag:
+		if kind == params.KindWorkload {
+			return noResult, errors.NotSupportedf("workload status history for machines")
+		}
+		machine, err := c.api.state.Machine(tag.Id())
+		if err != nil {
+			return noResult, errors.Trace(err)
+		}
+		sources[params.KindAgent] = machine
+	case names.ServiceTag:
+		if kind == params.KindAgent {
+			return noResult, errors.NotSupportedf("agent status history for services")
+		}
+		service, err := c.api.state.Service(tag.Id())
+		if err != nil {
+			return noResult, errors.Trace(err)
+		}
+		sources[params.KindWorkload] = service
+	default:
+		return noResult, errors.NotSupportedf("status history for %s", names.ReadableString(tag))
+	}
+
+	filter := state.StatusHistoryFilter{
+		Size:   args.Filter.Size,
+		Since:  args.Filter.Since,
+		Until:  args.Filter.Until,
+		Status: state.Status(args.Filter.Status),
+	}
+	result := params.StatusHistoryResult{
+		Statuses: []params.StatusHistoryEntry{},
+	}
+	for kind, source := range sources {
+		history, err := source.StatusHistory(filter)
+		if err != nil {
+			return noResult, errors.Trace(err)
+		}
+		current, err := source.Status()
+		if err != nil {
+			return noResult, errors.Trace(err)
+		}
+		if filter.Since != nil && current.Since != nil && current.Since.Before(*filter.Since) {
+			// The current status was already in effect at the start
+			// of the range, so none of the history falls within it.
+			history = nil
+		}
+		if filter.MatchesCurrent(current) {
+			history = append([]state.StatusInfo{current}, history...)
+		}
+		// History is returned newest first.
+		for i := len(history) - 1; i >= 0; i-- {
+			info := history[i]
+			result.Statuses = append(result.Statuses, params.StatusHistoryEntry{
+				Kind:   kind,
+				Status: params.Status(info.Status),
+				Info:   info.Message,
+				Data:   info.Data,
+				Since:  info.Since,
+			})
+		}
+	}
+	sort.Stable(statusHistoryEntriesByTime(result.Statuses))
+	if filter.Size > 0 && len(result.Statuses) > filter.Size {
+		result.Statuses = result.Statuses[len(result.Statuses)-filter.Size:]
+	}
+	return result, nil
+}
+
+type statusHistoryEntriesByTime []params.StatusHistoryEntry
+
+func (s statusHistoryEntriesByTime) Len() int {
+	return len(s)
+}
+
+func (s statusHistoryEntriesByTime) Swap(i, j int) {
+	s[i], s[j] = s[j], s[i]
+}
+
+func (s statusHistoryEntriesByTime) Less(i, j int) bool {
+	if s[i].Since == nil || s[j].Since == nil {
+		return s[j].Since != nil
+	}
+	return s[i].Since.Before(*s[j].Since)
+}
+
 // FullStatus gives the information needed for juju status over the api
 func (c *Client) FullStatus(args params.StatusParams) (api.Status, error) {
 	cfg, err := c.api.state.EnvironConfig()
@@ -626,6 +755,7 @@ func (context *statusContext) processUnit(unit *state.Unit, serviceCharm string)
 		result.Charm = curl.String()
 	}
 	processUnitAndAgentStatus(unit, &result)
+	result.HealthChecks = processUnitHealthChecks(unit)
 
 	if subUnits := unit.SubordinateNames(); len(subUnits) > 0 {
 		result.Subordinates = make(map[string]api.UnitStatus)
@@ -640,6 +770,29 @@ func (context *statusContext) processUnit(unit *state.Unit, serviceCharm string)
 	return result
 }
 
+// processUnitHealthChecks returns the results of the health checks
+// declared by the unit's charm, keyed by check name.
+func processUnitHealthChecks(unit *state.Unit) map[string]api.HealthCheckStatus {
+	checks, err := unit.HealthChecks()
+	if err != nil {
+		logger.Warningf("cannot get health checks of unit %q: %v", unit.Name(), err)
+		return nil
+	}
+	if len(checks) == 0 {
+		return nil
+	}
+	result := make(map[string]api.HealthCheckStatus)
+	for _, check := range checks {
+		since := check.Since
+		result[check.Name] = api.HealthCheckStatus{
+			Healthy: check.Healthy,
+			Message: check.Message,
+			Since:   &since,
+		}
+	}
+	return result
+}
+
 func (context *statusContext) unitByName(name string) *state.Unit {
 	serviceName := strings.Split(name, "/")[0]
 	return context.units[serviceName][name]
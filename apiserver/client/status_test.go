@@ -4,10 +4,14 @@
 package client_test
 
 import (
+	"time"
+
+	"github.com/juju/names"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/apiserver/client"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/testing/factory"
@@ -61,6 +65,133 @@ func (s *statusSuite) TestLegacyStatus(c *gc.C) {
 	c.Check(resultMachine.InstanceId, gc.Equals, instanceId)
 }
 
+func (s *statusSuite) TestStatusHistoryMachine(c *gc.C) {
+	machine := s.addMachine(c)
+	err := machine.SetStatus(state.StatusStarted, "", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.SetStatus(state.StatusError, "boom", map[string]interface{}{"foo": "bar"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.SetStatus(state.StatusStarted, "", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	client := s.APIState.Client()
+	result, err := client.StatusHistory(params.KindCombined, machine.Tag(), params.StatusHistoryFilter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Statuses, gc.HasLen, 4)
+	var statuses []params.Status
+	for _, entry := range result.Statuses {
+		c.Check(entry.Kind, gc.Equals, params.KindAgent)
+		statuses = append(statuses, entry.Status)
+	}
+	c.Assert(statuses, jc.DeepEquals, []params.Status{
+		params.StatusPending,
+		params.StatusStarted,
+		params.StatusError,
+		params.StatusStarted,
+	})
+	c.Assert(result.Statuses[2].Info, gc.Equals, "boom")
+	c.Assert(result.Statuses[2].Data, jc.DeepEquals, map[string]interface{}{"foo": "bar"})
+
+	result, err = client.StatusHistory(params.KindAgent, machine.Tag(), params.StatusHistoryFilter{
+		Status: params.StatusError,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Statuses, gc.HasLen, 1)
+	c.Assert(result.Statuses[0].Info, gc.Equals, "boom")
+
+	result, err = client.StatusHistory(params.KindCombined, machine.Tag(), params.StatusHistoryFilter{Size: 2})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Statuses, gc.HasLen, 2)
+	c.Assert(result.Statuses[0].Status, gc.Equals, params.StatusError)
+	c.Assert(result.Statuses[1].Status, gc.Equals, params.StatusStarted)
+}
+
+func (s *statusSuite) TestStatusHistoryTimeRange(c *gc.C) {
+	machine := s.addMachine(c)
+	err := machine.SetStatus(state.StatusStarted, "", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	client := s.APIState.Client()
+	future := time.Now().Add(time.Hour)
+	result, err := client.StatusHistory(params.KindAgent, machine.Tag(), params.StatusHistoryFilter{
+		Since: &future,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	// Only the current status is still in effect.
+	c.Assert(result.Statuses, gc.HasLen, 1)
+	c.Assert(result.Statuses[0].Status, gc.Equals, params.StatusStarted)
+
+	result, err = client.StatusHistory(params.KindAgent, machine.Tag(), params.StatusHistoryFilter{
+		Since:  &future,
+		Status: params.StatusPending,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Statuses, gc.HasLen, 0)
+
+	past := time.Now().Add(-time.Hour)
+	result, err = client.StatusHistory(params.KindAgent, machine.Tag(), params.StatusHistoryFilter{
+		Until: &past,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Statuses, gc.HasLen, 0)
+
+	result, err = client.StatusHistory(params.KindAgent, machine.Tag(), params.StatusHistoryFilter{
+		Since: &past,
+		Until: &future,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Statuses, gc.HasLen, 2)
+}
+
+func (s *statusSuite) TestStatusHistoryService(c *gc.C) {
+	f := factory.NewFactory(s.State)
+	service := f.MakeService(c, nil)
+	err := service.SetStatus(state.StatusActive, "ready", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	client := s.APIState.Client()
+	result, err := client.StatusHistory(params.KindWorkload, service.Tag(), params.StatusHistoryFilter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Statuses, gc.Not(gc.HasLen), 0)
+	last := result.Statuses[len(result.Statuses)-1]
+	c.Assert(last.Kind, gc.Equals, params.KindWorkload)
+	c.Assert(last.Status, gc.Equals, params.StatusActive)
+	c.Assert(last.Info, gc.Equals, "ready")
+
+	_, err = client.StatusHistory(params.KindAgent, service.Tag(), params.StatusHistoryFilter{})
+	c.Assert(err, gc.ErrorMatches, "agent status history for services not supported")
+}
+
+func (s *statusSuite) TestStatusHistoryUnit(c *gc.C) {
+	f := factory.NewFactory(s.State)
+	unit := f.MakeUnit(c, nil)
+	err := unit.SetStatus(state.StatusActive, "ready", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	client := s.APIState.Client()
+	result, err := client.StatusHistory(params.KindCombined, unit.Tag(), params.StatusHistoryFilter{})
+	c.Assert(err, jc.ErrorIsNil)
+	kinds := make(map[params.HistoryKind]bool)
+	for _, entry := range result.Statuses {
+		kinds[entry.Kind] = true
+	}
+	c.Assert(kinds, jc.DeepEquals, map[params.HistoryKind]bool{
+		params.KindAgent:    true,
+		params.KindWorkload: true,
+	})
+
+	result, err = client.StatusHistory(params.KindWorkload, unit.Tag(), params.StatusHistoryFilter{Size: 1})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Statuses, gc.HasLen, 1)
+	c.Assert(result.Statuses[0].Status, gc.Equals, params.StatusActive)
+}
+
+func (s *statusSuite) TestStatusHistoryUnsupportedEntity(c *gc.C) {
+	client := s.APIState.Client()
+	_, err := client.StatusHistory(params.KindCombined, names.NewUserTag("admin"), params.StatusHistoryFilter{})
+	c.Assert(err, gc.ErrorMatches, `status history for user admin not supported`)
+}
+
 var _ = gc.Suite(&statusUnitTestSuite{})
 
 type statusUnitTestSuite struct {
@@ -0,0 +1,35 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import (
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// CredentialsLoadedSetter implements a common SetCredentialsLoaded
+// method for use by the facades of state server workers that open the
+// environment with the provider credentials.
+type CredentialsLoadedSetter struct {
+	st         state.CredentialsLoadedSetter
+	authorizer Authorizer
+}
+
+// NewCredentialsLoadedSetter returns a new CredentialsLoadedSetter.
+func NewCredentialsLoadedSetter(st state.CredentialsLoadedSetter, authorizer Authorizer) *CredentialsLoadedSetter {
+	return &CredentialsLoadedSetter{
+		st:         st,
+		authorizer: authorizer,
+	}
+}
+
+// SetCredentialsLoaded records that the calling worker has opened the
+// environment with the provider credentials that have the given
+// fingerprint. Only environment managers may call it.
+func (s *CredentialsLoadedSetter) SetCredentialsLoaded(args params.CredentialsLoaded) error {
+	if !s.authorizer.AuthEnvironManager() {
+		return ErrPerm
+	}
+	return s.st.SetCredentialsLoaded(args.Worker, args.Fingerprint)
+}
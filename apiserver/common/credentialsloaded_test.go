@@ -0,0 +1,62 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+)
+
+type credentialsLoadedSetterSuite struct{}
+
+var _ = gc.Suite(&credentialsLoadedSetterSuite{})
+
+type fakeCredentialsLoadedSetter struct {
+	loaded map[string]string
+	err    error
+}
+
+func (f *fakeCredentialsLoadedSetter) SetCredentialsLoaded(worker, fingerprint string) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.loaded[worker] = fingerprint
+	return nil
+}
+
+func (*credentialsLoadedSetterSuite) TestSetCredentialsLoaded(c *gc.C) {
+	st := &fakeCredentialsLoadedSetter{loaded: make(map[string]string)}
+	authorizer := apiservertesting.FakeAuthorizer{
+		Tag:            names.NewMachineTag("0"),
+		EnvironManager: true,
+	}
+	s := common.NewCredentialsLoadedSetter(st, authorizer)
+	err := s.SetCredentialsLoaded(params.CredentialsLoaded{
+		Worker:      "firewaller",
+		Fingerprint: "abc",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(st.loaded, jc.DeepEquals, map[string]string{"firewaller": "abc"})
+
+	st.err = errors.New("pow")
+	err = s.SetCredentialsLoaded(params.CredentialsLoaded{Worker: "firewaller"})
+	c.Assert(err, gc.ErrorMatches, "pow")
+}
+
+func (*credentialsLoadedSetterSuite) TestSetCredentialsLoadedNotEnvironManager(c *gc.C) {
+	st := &fakeCredentialsLoadedSetter{loaded: make(map[string]string)}
+	authorizer := apiservertesting.FakeAuthorizer{
+		Tag: names.NewMachineTag("1"),
+	}
+	s := common.NewCredentialsLoadedSetter(st, authorizer)
+	err := s.SetCredentialsLoaded(params.CredentialsLoaded{Worker: "firewaller"})
+	c.Assert(err, gc.Equals, common.ErrPerm)
+	c.Assert(st.loaded, gc.HasLen, 0)
+}
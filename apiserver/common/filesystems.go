@@ -70,6 +70,34 @@ func FilesystemParams(
 	return result, nil
 }
 
+// FilesystemDestroyParams returns the parameters for destroying the
+// given filesystem. The filesystem must have been provisioned.
+func FilesystemDestroyParams(
+	f state.Filesystem,
+	poolManager poolmanager.PoolManager,
+) (params.FilesystemDestroyParams, error) {
+	filesystemInfo, err := f.Info()
+	if err != nil {
+		return params.FilesystemDestroyParams{}, errors.Trace(err)
+	}
+	providerType, _, err := StoragePoolConfig(filesystemInfo.Pool, poolManager)
+	if err != nil {
+		return params.FilesystemDestroyParams{}, errors.Trace(err)
+	}
+	result := params.FilesystemDestroyParams{
+		FilesystemTag: f.Tag().String(),
+		FilesystemId:  filesystemInfo.FilesystemId,
+		Provider:      string(providerType),
+	}
+	volumeTag, err := f.Volume()
+	if err == nil {
+		result.VolumeTag = volumeTag.String()
+	} else if err != state.ErrNoBackingVolume {
+		return params.FilesystemDestroyParams{}, errors.Trace(err)
+	}
+	return result, nil
+}
+
 // FilesystemsToState converts a slice of params.Filesystem to a mapping
 // of filesystem tags to state.FilesystemInfo.
 func FilesystemsToState(in []params.Filesystem) (map[names.FilesystemTag]state.FilesystemInfo, error) {
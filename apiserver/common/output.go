@@ -0,0 +1,29 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import (
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// OutputChunksFromState translates state.OutputChunks to
+// params.OutputChunks, keeping only those produced by units for which
+// include returns true, or all of them if include is nil.
+func OutputChunksFromState(chunks []state.OutputChunk, include func(unitName string) bool) []params.OutputChunk {
+	result := make([]params.OutputChunk, 0, len(chunks))
+	for _, chunk := range chunks {
+		if include != nil && !include(chunk.Unit) {
+			continue
+		}
+		result = append(result, params.OutputChunk{
+			Unit:   chunk.Unit,
+			Seq:    chunk.Seq,
+			Stream: chunk.Stream,
+			Data:   chunk.Data,
+			Time:   chunk.Time,
+		})
+	}
+	return result
+}
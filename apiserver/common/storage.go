@@ -49,6 +49,10 @@ type StorageInterface interface {
 	// WatchVolumeAttachment watches for changes to the volume attachment
 	// corresponding to the identfified machien and volume.
 	WatchVolumeAttachment(names.MachineTag, names.VolumeTag) state.NotifyWatcher
+
+	// WatchVolume watches for changes to the specified volume, such
+	// as the volume being resized.
+	WatchVolume(names.VolumeTag) state.NotifyWatcher
 }
 
 // StorageAttachmentInfo returns the StorageAttachmentInfo for the specified
@@ -104,6 +108,7 @@ func volumeStorageAttachmentInfo(
 	return &storage.StorageAttachmentInfo{
 		storage.StorageKindBlock,
 		devicePath,
+		volumeInfo.Size,
 	}, nil
 }
 
@@ -128,12 +133,14 @@ func filesystemStorageAttachmentInfo(
 	return &storage.StorageAttachmentInfo{
 		storage.StorageKindFilesystem,
 		filesystemAttachmentInfo.MountPoint,
+		0, // size is only reported for block storage
 	}, nil
 }
 
 // WatchStorageAttachment returns a state.NotifyWatcher that reacts to changes
 // to the VolumeAttachmentInfo or FilesystemAttachmentInfo corresponding to the tags
-// specified.
+// specified. For block storage, the watcher also reacts to the volume being
+// resized.
 func WatchStorageAttachment(
 	st StorageInterface,
 	storageTag names.StorageTag,
@@ -144,25 +151,30 @@ func WatchStorageAttachment(
 	if err != nil {
 		return nil, errors.Annotate(err, "getting storage instance")
 	}
-	var w state.NotifyWatcher
+	var watchers []state.NotifyWatcher
 	switch storageInstance.Kind() {
 	case state.StorageKindBlock:
 		volume, err := st.StorageInstanceVolume(storageTag)
 		if err != nil {
 			return nil, errors.Annotate(err, "getting storage volume")
 		}
-		w = st.WatchVolumeAttachment(machineTag, volume.VolumeTag())
+		watchers = append(watchers,
+			st.WatchVolumeAttachment(machineTag, volume.VolumeTag()),
+			st.WatchVolume(volume.VolumeTag()),
+		)
 	case state.StorageKindFilesystem:
 		filesystem, err := st.StorageInstanceFilesystem(storageTag)
 		if err != nil {
 			return nil, errors.Annotate(err, "getting storage filesystem")
 		}
-		w = st.WatchFilesystemAttachment(machineTag, filesystem.FilesystemTag())
+		watchers = append(watchers,
+			st.WatchFilesystemAttachment(machineTag, filesystem.FilesystemTag()),
+		)
 	default:
 		return nil, errors.Errorf("invalid storage kind %v", storageInstance.Kind())
 	}
-	w2 := st.WatchStorageAttachment(storageTag, unitTag)
-	return newMultiNotifyWatcher(w, w2), nil
+	watchers = append(watchers, st.WatchStorageAttachment(storageTag, unitTag))
+	return newMultiNotifyWatcher(watchers...), nil
 }
 
 var errNoDevicePath = errors.New("cannot determine device path: no serial or persistent device name")
@@ -59,6 +59,7 @@ func VolumeParams(
 		cfg.Attrs(),
 		volumeTags,
 		nil, // attachment params set by the caller
+		stateVolumeParams.Snapshot,
 	}, nil
 }
 
@@ -132,6 +133,89 @@ func VolumeFromState(v state.Volume) (params.Volume, error) {
 	}, nil
 }
 
+// VolumeResizeParams returns the parameters for resizing the given
+// volume to the given size, in MiB.
+func VolumeResizeParams(
+	v state.Volume,
+	size uint64,
+	poolManager poolmanager.PoolManager,
+) (params.VolumeResizeParams, error) {
+	volumeInfo, err := v.Info()
+	if err != nil {
+		return params.VolumeResizeParams{}, errors.Trace(err)
+	}
+	providerType, _, err := StoragePoolConfig(volumeInfo.Pool, poolManager)
+	if err != nil {
+		return params.VolumeResizeParams{}, errors.Trace(err)
+	}
+	return params.VolumeResizeParams{
+		VolumeTag: v.VolumeTag().String(),
+		VolumeId:  volumeInfo.VolumeId,
+		Provider:  string(providerType),
+		Size:      size,
+	}, nil
+}
+
+// VolumeSnapshotParams returns the parameters for the given snapshot
+// of the given volume. Unlike volumes, the parameters are returned for
+// snapshots that have already been taken, as they identify the provider
+// responsible for destroying the snapshot.
+func VolumeSnapshotParams(
+	s state.VolumeSnapshot,
+	v state.Volume,
+	environConfig *config.Config,
+	poolManager poolmanager.PoolManager,
+) (params.VolumeSnapshotParams, error) {
+	volumeInfo, err := v.Info()
+	if err != nil {
+		return params.VolumeSnapshotParams{}, errors.Trace(err)
+	}
+	snapshotTags, err := storageTags(nil, environConfig)
+	if err != nil {
+		return params.VolumeSnapshotParams{}, errors.Annotate(err, "computing storage tags")
+	}
+	providerType, _, err := StoragePoolConfig(s.Pool(), poolManager)
+	if err != nil {
+		return params.VolumeSnapshotParams{}, errors.Trace(err)
+	}
+	return params.VolumeSnapshotParams{
+		Id:        s.Id(),
+		VolumeTag: v.VolumeTag().String(),
+		VolumeId:  volumeInfo.VolumeId,
+		Provider:  string(providerType),
+		Tags:      snapshotTags,
+	}, nil
+}
+
+// VolumeSnapshotFromState converts a state.VolumeSnapshot to
+// params.VolumeSnapshot.
+func VolumeSnapshotFromState(s state.VolumeSnapshot) (params.VolumeSnapshot, error) {
+	info, err := s.Info()
+	if err != nil {
+		return params.VolumeSnapshot{}, errors.Trace(err)
+	}
+	return params.VolumeSnapshot{
+		s.Id(),
+		s.Volume().String(),
+		params.VolumeSnapshotInfo{
+			info.SnapshotId,
+			info.Size,
+		},
+	}, nil
+}
+
+// VolumeSnapshotToState converts a params.VolumeSnapshot to
+// state.VolumeSnapshotInfo and a volume snapshot ID.
+func VolumeSnapshotToState(s params.VolumeSnapshot) (string, state.VolumeSnapshotInfo, error) {
+	if !state.IsValidVolumeSnapshot(s.Id) {
+		return "", state.VolumeSnapshotInfo{}, errors.NotValidf("volume snapshot ID %q", s.Id)
+	}
+	return s.Id, state.VolumeSnapshotInfo{
+		s.Info.SnapshotId,
+		s.Info.Size,
+	}, nil
+}
+
 // VolumeAttachmentFromState converts a state.VolumeAttachment to params.VolumeAttachment.
 func VolumeAttachmentFromState(v state.VolumeAttachment) (params.VolumeAttachment, error) {
 	info, err := v.Info()
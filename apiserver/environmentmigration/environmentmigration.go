@@ -0,0 +1,73 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// The environmentmigration package defines an API end point for
+// exporting an environment's model to a portable description, and for
+// importing such a description into an empty environment.
+package environmentmigration
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/description"
+	"github.com/juju/juju/state"
+)
+
+func init() {
+	common.RegisterStandardFacade("EnvironmentMigration", 1, NewAPI)
+}
+
+// EnvironmentMigration defines the methods on the environment migration
+// API end point.
+type EnvironmentMigration interface {
+	// Export returns the serialized description of the environment.
+	Export() (params.SerializedEnvironment, error)
+
+	// Import recreates the described model in the environment.
+	Import(args params.SerializedEnvironment) error
+}
+
+// API implements EnvironmentMigration and is the concrete
+// implementation of the api end point.
+type API struct {
+	state *state.State
+}
+
+var _ EnvironmentMigration = (*API)(nil)
+
+// NewAPI returns a new environment migration API facade.
+func NewAPI(
+	st *state.State,
+	resources *common.Resources,
+	authorizer common.Authorizer,
+) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{state: st}, nil
+}
+
+// Export implements EnvironmentMigration.Export.
+func (api *API) Export() (params.SerializedEnvironment, error) {
+	var result params.SerializedEnvironment
+	env, err := api.state.Export()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Bytes, err = description.Serialize(env)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	return result, nil
+}
+
+// Import implements EnvironmentMigration.Import.
+func (api *API) Import(args params.SerializedEnvironment) error {
+	env, err := description.Deserialize(args.Bytes)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(api.state.Import(env))
+}
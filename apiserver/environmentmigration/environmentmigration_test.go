@@ -0,0 +1,87 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package environmentmigration_test
+
+import (
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/environmentmigration"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/description"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type migrationSuite struct {
+	jujutesting.JujuConnSuite
+}
+
+var _ = gc.Suite(&migrationSuite{})
+
+func (s *migrationSuite) newAPI(c *gc.C, st *state.State, tag names.Tag) (*environmentmigration.API, error) {
+	auth := apiservertesting.FakeAuthorizer{Tag: tag}
+	return environmentmigration.NewAPI(st, common.NewResources(), auth)
+}
+
+func (s *migrationSuite) TestNewAPIRefusesNonClient(c *gc.C) {
+	_, err := s.newAPI(c, s.State, names.NewMachineTag("0"))
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *migrationSuite) makeService(c *gc.C) {
+	s.Factory.MakeService(c, &factory.ServiceParams{
+		Charm:   s.Factory.MakeCharm(c, &factory.CharmParams{URL: "cs:quantal/mysql-1"}),
+		Creator: s.AdminUserTag(c),
+	})
+}
+
+func (s *migrationSuite) TestExport(c *gc.C) {
+	s.makeService(c)
+	api, err := s.newAPI(c, s.State, s.AdminUserTag(c))
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := api.Export()
+	c.Assert(err, jc.ErrorIsNil)
+	env, err := description.Deserialize(result.Bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(env.Services, gc.HasLen, 1)
+	c.Assert(env.Services[0].Name, gc.Equals, "mysql")
+}
+
+func (s *migrationSuite) TestImport(c *gc.C) {
+	s.makeService(c)
+	data, err := description.Serialize(s.export(c, s.State))
+	c.Assert(err, jc.ErrorIsNil)
+
+	st := s.Factory.MakeEnvironment(c, nil)
+	defer st.Close()
+	f := factory.NewFactory(st)
+	f.MakeCharm(c, &factory.CharmParams{URL: "cs:quantal/mysql-1"})
+	api, err := s.newAPI(c, st, s.AdminUserTag(c))
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = api.Import(params.SerializedEnvironment{Bytes: data})
+	c.Assert(err, jc.ErrorIsNil)
+	service, err := st.Service("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(service.Name(), gc.Equals, "mysql")
+}
+
+func (s *migrationSuite) TestImportInvalid(c *gc.C) {
+	api, err := s.newAPI(c, s.State, s.AdminUserTag(c))
+	c.Assert(err, jc.ErrorIsNil)
+	err = api.Import(params.SerializedEnvironment{Bytes: []byte("version: 99\n")})
+	c.Assert(err, gc.ErrorMatches, "environment description version 99 not supported")
+}
+
+func (s *migrationSuite) export(c *gc.C, st *state.State) *description.Environment {
+	env, err := st.Export()
+	c.Assert(err, jc.ErrorIsNil)
+	return env
+}
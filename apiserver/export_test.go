@@ -179,3 +179,25 @@ func (logLine *logLine) LogLineAgentTag() string {
 func (logLine *logLine) LogLineAgentName() string {
 	return logLine.agentName
 }
+
+// TestingAuditingRoot returns an auditingRoot wrapping the given
+// finder, recording calls made by the given user with the recorder.
+func TestingAuditingRoot(finder rpc.MethodFinder, recorder auditRecorder, user names.Tag, tokenID string) rpc.MethodFinder {
+	return newAuditingRoot(finder, recorder, user, tokenID)
+}
+
+// TestingTokenRoot returns a tokenRoot wrapping the given finder.
+func TestingTokenRoot(finder rpc.MethodFinder) rpc.MethodFinder {
+	return newTokenRoot(finder)
+}
+
+// RedactedJSON exposes redactedJSON for testing.
+func RedactedJSON(v interface{}) string {
+	return redactedJSON(reflect.ValueOf(v))
+}
+
+// TestingAccessRoot returns an accessRoot wrapping the given finder
+// that permits the calls allowed by the given access level.
+func TestingAccessRoot(finder rpc.MethodFinder, access state.Access) rpc.MethodFinder {
+	return newAccessRoot(finder, access)
+}
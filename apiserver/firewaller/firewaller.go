@@ -23,6 +23,7 @@ func init() {
 type FirewallerAPI struct {
 	*common.LifeGetter
 	*common.EnvironWatcher
+	*common.CredentialsLoadedSetter
 	*common.AgentEntityWatcher
 	*common.UnitsWatcher
 	*common.EnvironMachinesWatcher
@@ -67,6 +68,11 @@ func NewFirewallerAPI(
 		resources,
 		authorizer,
 	)
+	// SetCredentialsLoaded() is allowed for environment managers only.
+	credentialsLoadedSetter := common.NewCredentialsLoadedSetter(
+		st,
+		authorizer,
+	)
 	// Watch() is supported for services only.
 	entityWatcher := common.NewAgentEntityWatcher(
 		st,
@@ -91,19 +97,20 @@ func NewFirewallerAPI(
 	)
 
 	return &FirewallerAPI{
-		LifeGetter:             lifeGetter,
-		EnvironWatcher:         environWatcher,
-		AgentEntityWatcher:     entityWatcher,
-		UnitsWatcher:           unitsWatcher,
-		EnvironMachinesWatcher: machinesWatcher,
-		InstanceIdGetter:       instanceIdGetter,
-		st:                     st,
-		resources:              resources,
-		authorizer:             authorizer,
-		accessUnit:             accessUnit,
-		accessService:          accessService,
-		accessMachine:          accessMachine,
-		accessEnviron:          accessEnviron,
+		LifeGetter:              lifeGetter,
+		EnvironWatcher:          environWatcher,
+		CredentialsLoadedSetter: credentialsLoadedSetter,
+		AgentEntityWatcher:      entityWatcher,
+		UnitsWatcher:            unitsWatcher,
+		EnvironMachinesWatcher:  machinesWatcher,
+		InstanceIdGetter:        instanceIdGetter,
+		st:                      st,
+		resources:               resources,
+		authorizer:              authorizer,
+		accessUnit:              accessUnit,
+		accessService:           accessService,
+		accessMachine:           accessMachine,
+		accessEnviron:           accessEnviron,
 	}, nil
 }
 
@@ -263,6 +270,31 @@ func (f *FirewallerAPI) GetExposed(args params.Entities) (params.BoolResults, er
 	return result, nil
 }
 
+// GetExposedCIDRs returns the source CIDRs each given service is
+// exposed to. An empty result means any address.
+func (f *FirewallerAPI) GetExposedCIDRs(args params.Entities) (params.StringsResults, error) {
+	result := params.StringsResults{
+		Results: make([]params.StringsResult, len(args.Entities)),
+	}
+	canAccess, err := f.accessService()
+	if err != nil {
+		return params.StringsResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseServiceTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		service, err := f.getService(canAccess, tag)
+		if err == nil {
+			result.Results[i].Result = service.ExposedCIDRs()
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
 // GetAssignedMachine returns the assigned machine tag (if any) for
 // each given unit.
 func (f *FirewallerAPI) GetAssignedMachine(args params.Entities) (params.StringResults, error) {
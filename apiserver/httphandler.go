@@ -113,6 +113,30 @@ func (h *httpStateWrapper) authenticateUser(r *http.Request) error {
 	}
 }
 
+// authenticateAdmin authenticates a user with admin access to the
+// environment. The user may log in with their password or with any of
+// their API tokens, whatever the access the token grants.
+func (h *httpStateWrapper) authenticateAdmin(r *http.Request) error {
+	tag, err := h.authenticate(r)
+	if err != nil {
+		return err
+	}
+	userTag, ok := tag.(names.UserTag)
+	if !ok {
+		return common.ErrBadCreds
+	}
+	envUser, err := h.state.EnvironmentUser(userTag)
+	if errors.IsNotFound(err) {
+		return common.ErrPerm
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if envUser.Access() != state.AdminAccess {
+		return common.ErrPerm
+	}
+	return nil
+}
+
 func (h *httpStateWrapper) authenticateAgent(r *http.Request) (names.Tag, error) {
 	tag, err := h.authenticate(r)
 	if err != nil {
@@ -24,6 +24,7 @@ var logger = loggo.GetLogger("juju.apiserver.instancepoller")
 type InstancePollerAPI struct {
 	*common.LifeGetter
 	*common.EnvironWatcher
+	*common.CredentialsLoadedSetter
 	*common.EnvironMachinesWatcher
 	*common.InstanceIdGetter
 	*common.StatusGetter
@@ -61,6 +62,11 @@ func NewInstancePollerAPI(
 		resources,
 		authorizer,
 	)
+	// SetCredentialsLoaded() is allowed for environment managers only.
+	credentialsLoadedSetter := common.NewCredentialsLoadedSetter(
+		st,
+		authorizer,
+	)
 	// WatchEnvironMachines() is allowed with unrestricted access.
 	machinesWatcher := common.NewEnvironMachinesWatcher(
 		sti,
@@ -79,15 +85,16 @@ func NewInstancePollerAPI(
 	)
 
 	return &InstancePollerAPI{
-		LifeGetter:             lifeGetter,
-		EnvironWatcher:         environWatcher,
-		EnvironMachinesWatcher: machinesWatcher,
-		InstanceIdGetter:       instanceIdGetter,
-		StatusGetter:           statusGetter,
-		st:                     sti,
-		resources:              resources,
-		authorizer:             authorizer,
-		accessMachine:          accessMachine,
+		LifeGetter:              lifeGetter,
+		EnvironWatcher:          environWatcher,
+		CredentialsLoadedSetter: credentialsLoadedSetter,
+		EnvironMachinesWatcher:  machinesWatcher,
+		InstanceIdGetter:        instanceIdGetter,
+		StatusGetter:            statusGetter,
+		st:                      sti,
+		resources:               resources,
+		authorizer:              authorizer,
+		accessMachine:           accessMachine,
 	}, nil
 }
 
@@ -194,6 +201,27 @@ func (a *InstancePollerAPI) SetInstanceStatus(args params.SetInstancesStatus) (p
 	return result, nil
 }
 
+// MarkReclaimed records that the instance of each given entity has
+// been reclaimed by the provider, so that the provisioner will start a
+// replacement. Only machine tags are accepted.
+func (a *InstancePollerAPI) MarkReclaimed(args params.Entities) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	canAccess, err := a.accessMachine()
+	if err != nil {
+		return result, err
+	}
+	for i, arg := range args.Entities {
+		machine, err := a.getOneMachine(arg.Tag, canAccess)
+		if err == nil {
+			err = machine.MarkReclaimed()
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
 // AreManuallyProvisioned returns whether each given entity is
 // manually provisioned or not. Only machine tags are accepted.
 func (a *InstancePollerAPI) AreManuallyProvisioned(args params.Entities) (params.BoolResults, error) {
@@ -644,6 +644,45 @@ func (s *InstancePollerSuite) TestSetInstanceStatusFailure(c *gc.C) {
 	s.st.CheckFindEntityCall(c, 3, "3")
 }
 
+func (s *InstancePollerSuite) TestMarkReclaimedSuccess(c *gc.C) {
+	s.st.SetMachineInfo(c, machineInfo{id: "1", instanceId: "i-foo"})
+	s.st.SetMachineInfo(c, machineInfo{id: "2", instanceId: "i-bar"})
+
+	result, err := s.api.MarkReclaimed(s.mixedEntities)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, s.mixedErrorResults)
+
+	s.st.CheckFindEntityCall(c, 0, "1")
+	s.st.CheckCall(c, 1, "MarkReclaimed")
+	s.st.CheckFindEntityCall(c, 2, "2")
+	s.st.CheckCall(c, 3, "MarkReclaimed")
+	s.st.CheckFindEntityCall(c, 4, "42")
+}
+
+func (s *InstancePollerSuite) TestMarkReclaimedFailure(c *gc.C) {
+	s.st.SetErrors(
+		errors.New("pow!"),                   // m1 := FindEntity("1")
+		nil,                                  // m2 := FindEntity("2")
+		errors.New("FAIL"),                   // m2.MarkReclaimed()
+		errors.NotProvisionedf("machine 42"), // FindEntity("3") (ensure wrapping is preserved)
+	)
+	s.st.SetMachineInfo(c, machineInfo{id: "1", instanceId: "i-foo"})
+	s.st.SetMachineInfo(c, machineInfo{id: "2", instanceId: "i-bar"})
+
+	result, err := s.api.MarkReclaimed(params.Entities{Entities: []params.Entity{
+		{Tag: "machine-1"},
+		{Tag: "machine-2"},
+		{Tag: "machine-3"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, s.machineErrorResults)
+
+	s.st.CheckFindEntityCall(c, 0, "1")
+	s.st.CheckFindEntityCall(c, 1, "2")
+	s.st.CheckCall(c, 2, "MarkReclaimed")
+	s.st.CheckFindEntityCall(c, 3, "3")
+}
+
 func (s *InstancePollerSuite) TestAreManuallyProvisionedSuccess(c *gc.C) {
 	s.st.SetMachineInfo(c, machineInfo{id: "1", isManual: true})
 	s.st.SetMachineInfo(c, machineInfo{id: "2", isManual: false})
@@ -288,6 +288,19 @@ func (m *mockMachine) SetInstanceStatus(status string) error {
 	return nil
 }
 
+// MarkReclaimed implements StateMachine.
+func (m *mockMachine) MarkReclaimed() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.MethodCall(m, "MarkReclaimed")
+	if err := m.NextErr(); err != nil {
+		return err
+	}
+	m.instanceId = ""
+	return nil
+}
+
 // Life implements StateMachine.
 func (m *mockMachine) Life() state.Life {
 	m.mu.Lock()
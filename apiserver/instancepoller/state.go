@@ -18,6 +18,7 @@ type StateMachine interface {
 	SetProviderAddresses(...network.Address) error
 	InstanceStatus() (string, error)
 	SetInstanceStatus(status string) error
+	MarkReclaimed() error
 	String() string
 	Refresh() error
 	Life() state.Life
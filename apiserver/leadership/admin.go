@@ -0,0 +1,188 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package leadership
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+const (
+	// AdminFacadeName is the name of the API used by clients to
+	// inspect and transfer leadership.
+	AdminFacadeName = "LeadershipAdmin"
+
+	// TransferLeaseDuration is the duration of the lease given to a
+	// unit that leadership is transferred to. The unit renews the lease
+	// itself once it notices that it is the leader.
+	TransferLeaseDuration = time.Minute
+)
+
+func init() {
+	common.RegisterStandardFacade(
+		AdminFacadeName,
+		1,
+		NewLeadershipAdminFn(leaderMgr),
+	)
+}
+
+// AdminManager is the part of the leadership manager used by the
+// LeadershipAdmin API.
+type AdminManager interface {
+	// ServiceLeaderLease returns the leader of the given service and
+	// the time at which its lease expires.
+	ServiceLeaderLease(serviceId string) (string, time.Time, error)
+
+	// TransferLeadership makes the given unit the leader of the given
+	// service in place of the current leader.
+	TransferLeadership(serviceId, unitId string, duration time.Duration) error
+}
+
+// adminState is the part of state used by the LeadershipAdmin API.
+type adminState interface {
+	ServiceNames() ([]string, error)
+	UnitAlive(name string) (bool, error)
+}
+
+type adminStateShim struct {
+	*state.State
+}
+
+// ServiceNames returns the names of all the services in the environment.
+func (s adminStateShim) ServiceNames() ([]string, error) {
+	services, err := s.State.AllServices()
+	if err != nil {
+		return nil, err
+	}
+	serviceNames := make([]string, len(services))
+	for i, service := range services {
+		serviceNames[i] = service.Name()
+	}
+	return serviceNames, nil
+}
+
+// UnitAlive returns whether the named unit is alive. It returns a
+// NotFound error if the unit does not exist.
+func (s adminStateShim) UnitAlive(name string) (bool, error) {
+	unit, err := s.State.Unit(name)
+	if err != nil {
+		return false, err
+	}
+	return unit.Life() == state.Alive, nil
+}
+
+var getAdminState = func(st *state.State) adminState {
+	return adminStateShim{st}
+}
+
+// NewLeadershipAdminFn returns a function which can construct a
+// LeadershipAdmin API when passed a state, resources, and authorizer.
+func NewLeadershipAdminFn(
+	leadershipMgr AdminManager,
+) func(*state.State, *common.Resources, common.Authorizer) (*LeadershipAdmin, error) {
+	return func(
+		st *state.State,
+		resources *common.Resources,
+		authorizer common.Authorizer,
+	) (*LeadershipAdmin, error) {
+		if !authorizer.AuthClient() {
+			return nil, common.ErrPerm
+		}
+		return &LeadershipAdmin{
+			st:      getAdminState(st),
+			manager: leadershipMgr,
+		}, nil
+	}
+}
+
+// LeadershipAdmin is the API used by clients to see which units are
+// the leaders of their services, and to move leadership between units.
+type LeadershipAdmin struct {
+	st      adminState
+	manager AdminManager
+}
+
+// Leaders returns the leader of each of the given services, and when
+// its lease expires. If no services are given, the leaders of all of
+// the services in the environment are returned.
+func (a *LeadershipAdmin) Leaders(args params.Entities) (params.ServiceLeadershipResults, error) {
+	var serviceTags []names.ServiceTag
+	var results []params.ServiceLeadershipResult
+	if len(args.Entities) == 0 {
+		serviceNames, err := a.st.ServiceNames()
+		if err != nil {
+			return params.ServiceLeadershipResults{}, common.ServerError(err)
+		}
+		for _, serviceName := range serviceNames {
+			serviceTags = append(serviceTags, names.NewServiceTag(serviceName))
+		}
+		results = make([]params.ServiceLeadershipResult, len(serviceTags))
+	} else {
+		results = make([]params.ServiceLeadershipResult, len(args.Entities))
+		serviceTags = make([]names.ServiceTag, len(args.Entities))
+		for i, entity := range args.Entities {
+			tag, err := names.ParseServiceTag(entity.Tag)
+			if err != nil {
+				results[i].Error = common.ServerError(common.ErrPerm)
+				continue
+			}
+			serviceTags[i] = tag
+		}
+	}
+	for i, tag := range serviceTags {
+		result := &results[i]
+		if result.Error != nil {
+			continue
+		}
+		result.ServiceTag = tag.String()
+		leader, expiry, err := a.manager.ServiceLeaderLease(tag.Id())
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			result.Error = common.ServerError(err)
+			continue
+		}
+		result.UnitTag = names.NewUnitTag(leader).String()
+		result.Expiry = expiry
+	}
+	return params.ServiceLeadershipResults{Results: results}, nil
+}
+
+// TransferLeadership makes each of the given units the leader of its
+// service, in place of the current leader.
+func (a *LeadershipAdmin) TransferLeadership(args params.Entities) (params.ErrorResults, error) {
+	results := make([]params.ErrorResult, len(args.Entities))
+	for i, entity := range args.Entities {
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		if err := a.transferLeadership(tag); err != nil {
+			results[i].Error = common.ServerError(err)
+		}
+	}
+	return params.ErrorResults{Results: results}, nil
+}
+
+func (a *LeadershipAdmin) transferLeadership(tag names.UnitTag) error {
+	alive, err := a.st.UnitAlive(tag.Id())
+	if err != nil {
+		return err
+	}
+	if !alive {
+		return errors.Errorf("unit %q is not alive", tag.Id())
+	}
+	serviceName, err := names.UnitService(tag.Id())
+	if err != nil {
+		return err
+	}
+	return a.manager.TransferLeadership(serviceName, tag.Id(), TransferLeaseDuration)
+}
@@ -0,0 +1,132 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package leadership
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+type adminSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&adminSuite{})
+
+type stubAdminState struct {
+	serviceNames []string
+	units        map[string]bool
+}
+
+func (s *stubAdminState) ServiceNames() ([]string, error) {
+	return s.serviceNames, nil
+}
+
+func (s *stubAdminState) UnitAlive(name string) (bool, error) {
+	alive, ok := s.units[name]
+	if !ok {
+		return false, errors.NotFoundf("unit %q", name)
+	}
+	return alive, nil
+}
+
+type stubAdminManager struct {
+	leaders     map[string]string
+	expiry      time.Time
+	transferred []string
+}
+
+func (m *stubAdminManager) ServiceLeaderLease(serviceId string) (string, time.Time, error) {
+	leader, ok := m.leaders[serviceId]
+	if !ok {
+		return "", time.Time{}, errors.NotFoundf("leader of service %q", serviceId)
+	}
+	return leader, m.expiry, nil
+}
+
+func (m *stubAdminManager) TransferLeadership(serviceId, unitId string, duration time.Duration) error {
+	if duration != TransferLeaseDuration {
+		return errors.Errorf("unexpected duration %v", duration)
+	}
+	m.transferred = append(m.transferred, serviceId+" "+unitId)
+	return nil
+}
+
+func (s *adminSuite) newAdmin() (*LeadershipAdmin, *stubAdminManager) {
+	manager := &stubAdminManager{
+		leaders: map[string]string{"mysql": "mysql/1"},
+		expiry:  time.Date(2015, time.June, 1, 12, 0, 0, 0, time.UTC),
+	}
+	st := &stubAdminState{
+		serviceNames: []string{"mysql", "wordpress"},
+		units:        map[string]bool{"mysql/0": true, "mysql/1": true, "mysql/2": false},
+	}
+	return &LeadershipAdmin{st: st, manager: manager}, manager
+}
+
+func (s *adminSuite) TestLeadersAll(c *gc.C) {
+	admin, manager := s.newAdmin()
+	results, err := admin.Leaders(params.Entities{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ServiceLeadershipResult{{
+		ServiceTag: "service-mysql",
+		UnitTag:    "unit-mysql-1",
+		Expiry:     manager.expiry,
+	}, {
+		ServiceTag: "service-wordpress",
+	}})
+}
+
+func (s *adminSuite) TestLeaders(c *gc.C) {
+	admin, manager := s.newAdmin()
+	results, err := admin.Leaders(params.Entities{Entities: []params.Entity{
+		{Tag: "service-mysql"},
+		{Tag: "unit-mysql-0"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ServiceLeadershipResult{{
+		ServiceTag: "service-mysql",
+		UnitTag:    "unit-mysql-1",
+		Expiry:     manager.expiry,
+	}, {
+		Error: &params.Error{Message: "permission denied", Code: params.CodeUnauthorized},
+	}})
+}
+
+func (s *adminSuite) TestTransferLeadership(c *gc.C) {
+	admin, manager := s.newAdmin()
+	results, err := admin.TransferLeadership(params.Entities{Entities: []params.Entity{
+		{Tag: names.NewUnitTag("mysql/0").String()},
+		{Tag: names.NewUnitTag("mysql/2").String()},
+		{Tag: names.NewUnitTag("mysql/3").String()},
+		{Tag: "service-mysql"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{
+		{},
+		{Error: &params.Error{Message: `unit "mysql/2" is not alive`}},
+		{Error: &params.Error{Message: `unit "mysql/3" not found`, Code: params.CodeNotFound}},
+		{Error: &params.Error{Message: "permission denied", Code: params.CodeUnauthorized}},
+	})
+	c.Check(manager.transferred, jc.DeepEquals, []string{"mysql mysql/0"})
+}
+
+func (s *adminSuite) TestNewLeadershipAdminRequiresClient(c *gc.C) {
+	authorizer := &clientAuthorizer{}
+	_, err := NewLeadershipAdminFn(&stubAdminManager{})(nil, nil, authorizer)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+type clientAuthorizer struct {
+	stubAuthorizer
+}
+
+func (*clientAuthorizer) AuthClient() bool { return false }
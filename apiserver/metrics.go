@@ -0,0 +1,87 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	apihttp "github.com/juju/juju/apiserver/http"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/monitoring"
+)
+
+var (
+	apiRequests = monitoring.NewCounterVec(
+		"juju_apiserver_requests_total",
+		"Number of API requests served, by facade and method.",
+		"facade", "method",
+	)
+	apiRequestDuration = monitoring.NewHistogramVec(
+		"juju_apiserver_request_duration_seconds",
+		"Time taken to serve API requests, by facade and method.",
+		nil,
+		"facade", "method",
+	)
+	apiConnections = monitoring.NewGaugeVec(
+		"juju_apiserver_connections",
+		"Number of open API connections.",
+	)
+	apiLogins = monitoring.NewGaugeVec(
+		"juju_apiserver_logins",
+		"Number of open API connections that have logged in.",
+	)
+)
+
+func init() {
+	monitoring.MustRegister(apiRequests, apiRequestDuration, apiConnections, apiLogins)
+}
+
+// metricsHandler serves the operational metrics of the API server, and
+// of the agent it runs in, in the Prometheus text format. Only users
+// with admin access to the state server environment may read them;
+// monitoring systems should log in with a read-only API token created
+// by such a user.
+type metricsHandler struct {
+	httpHandler
+}
+
+func (h *metricsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	stateWrapper, err := h.validateEnvironUUID(r)
+	if err != nil {
+		h.sendError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	defer stateWrapper.cleanup()
+
+	if err := stateWrapper.authenticateAdmin(r); err != nil {
+		h.authError(w, h)
+		return
+	}
+
+	switch r.Method {
+	case "GET":
+		w.Header().Set("Content-Type", monitoring.ContentType)
+		w.WriteHeader(http.StatusOK)
+		if err := monitoring.DefaultRegistry.Write(w); err != nil {
+			logger.Errorf("cannot write metrics: %v", err)
+		}
+	default:
+		h.sendError(w, http.StatusMethodNotAllowed, fmt.Sprintf("unsupported method: %q", r.Method))
+	}
+}
+
+// sendError sends a JSON-encoded error response.
+func (h *metricsHandler) sendError(w http.ResponseWriter, statusCode int, message string) {
+	logger.Debugf("sending error: %v %v", statusCode, message)
+	body, err := json.Marshal(&params.Error{Message: message})
+	if err != nil {
+		logger.Errorf("failed to send error: %v", err)
+		return
+	}
+	w.Header().Set("Content-Type", apihttp.CTypeJSON)
+	w.WriteHeader(statusCode)
+	w.Write(body)
+}
@@ -0,0 +1,123 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apihttp "github.com/juju/juju/apiserver/http"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/monitoring"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type metricsSuite struct {
+	userAuthHttpSuite
+}
+
+var _ = gc.Suite(&metricsSuite{})
+
+func (s *metricsSuite) metricsURL(c *gc.C) string {
+	uri := s.baseURL(c)
+	uri.Path = "/metrics"
+	return uri.String()
+}
+
+// makeAdmin makes a new user with admin access to the environment,
+// and returns the user.
+func (s *metricsSuite) makeAdmin(c *gc.C) *state.User {
+	user := s.Factory.MakeUser(c, &factory.UserParams{
+		Password:  s.password,
+		NoEnvUser: true,
+	})
+	s.Factory.MakeEnvUser(c, &factory.EnvUserParams{
+		User:   user.Name(),
+		Access: state.AdminAccess,
+	})
+	return user
+}
+
+func (s *metricsSuite) assertMetrics(c *gc.C, resp *http.Response) string {
+	c.Assert(resp.StatusCode, gc.Equals, http.StatusOK)
+	c.Assert(resp.Header.Get("Content-Type"), gc.Equals, monitoring.ContentType)
+	body, err := ioutil.ReadAll(resp.Body)
+	c.Assert(err, jc.ErrorIsNil)
+	return string(body)
+}
+
+func (s *metricsSuite) assertErrorResponse(c *gc.C, resp *http.Response, statusCode int, msg string) {
+	c.Check(resp.StatusCode, gc.Equals, statusCode)
+	c.Check(resp.Header.Get("Content-Type"), gc.Equals, apihttp.CTypeJSON)
+	body, err := ioutil.ReadAll(resp.Body)
+	c.Assert(err, jc.ErrorIsNil)
+	var failure params.Error
+	err = json.Unmarshal(body, &failure)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(&failure, gc.ErrorMatches, msg)
+}
+
+func (s *metricsSuite) TestRequiresAuth(c *gc.C) {
+	resp, err := s.sendRequest(c, "", "", "GET", s.metricsURL(c), "", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertErrorResponse(c, resp, http.StatusUnauthorized, "unauthorized")
+}
+
+func (s *metricsSuite) TestRequiresAdmin(c *gc.C) {
+	resp, err := s.authRequest(c, "GET", s.metricsURL(c), "", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertErrorResponse(c, resp, http.StatusUnauthorized, "unauthorized")
+}
+
+func (s *metricsSuite) TestInvalidMethod(c *gc.C) {
+	user := s.makeAdmin(c)
+	resp, err := s.sendRequest(c, user.Tag().String(), s.password, "POST", s.metricsURL(c), "", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertErrorResponse(c, resp, http.StatusMethodNotAllowed, `unsupported method: "POST"`)
+}
+
+func (s *metricsSuite) TestAdmin(c *gc.C) {
+	user := s.makeAdmin(c)
+	resp, err := s.sendRequest(c, user.Tag().String(), s.password, "GET", s.metricsURL(c), "", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	body := s.assertMetrics(c, resp)
+
+	// The suite's API connection has logged in.
+	c.Assert(body, jc.Contains, "# TYPE juju_apiserver_requests_total counter\n")
+	c.Assert(body, jc.Contains, `juju_apiserver_requests_total{facade="Admin",method="Login"} `)
+	c.Assert(body, jc.Contains, `juju_apiserver_request_duration_seconds_count{facade="Admin",method="Login"} `)
+	c.Assert(body, jc.Contains, "# TYPE juju_apiserver_connections gauge\n")
+	c.Assert(body, jc.Contains, "# TYPE juju_apiserver_logins gauge\n")
+	c.Assert(body, jc.Contains, "# TYPE juju_state_allwatcher_subscribers gauge\n")
+	c.Assert(body, jc.Contains, "# TYPE juju_state_txn_retries_total counter\n")
+	c.Assert(body, jc.Contains, "# TYPE juju_state_txn_aborts_total counter\n")
+}
+
+func (s *metricsSuite) TestReadOnlyToken(c *gc.C) {
+	user := s.makeAdmin(c)
+	token, secret, err := user.CreateToken(time.Hour, state.ReadAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	credential := state.TokenCredential(token.ID(), secret)
+
+	resp, err := s.sendRequest(c, user.Tag().String(), credential, "GET", s.metricsURL(c), "", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertMetrics(c, resp)
+}
+
+func (s *metricsSuite) TestRequestsNotRecordedForUnknownFacades(c *gc.C) {
+	err := s.APIState.APICall("NoSuchFacade", 1, "", "Method", nil, nil)
+	c.Assert(err, gc.ErrorMatches, `unknown object type "NoSuchFacade"`)
+
+	user := s.makeAdmin(c)
+	resp, err := s.sendRequest(c, user.Tag().String(), s.password, "GET", s.metricsURL(c), "", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	body := s.assertMetrics(c, resp)
+	c.Assert(body, gc.Not(jc.Contains), "NoSuchFacade")
+}
@@ -0,0 +1,149 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package offers implements the API used by clients to offer services
+// to the other environments on the state server, and to consume the
+// services offered by them.
+package offers
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/names"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+var logger = loggo.GetLogger("juju.apiserver.offers")
+
+func init() {
+	common.RegisterStandardFacade("Offers", 1, NewAPI)
+}
+
+// API implements the Offers API.
+type API struct {
+	st         *state.State
+	authorizer common.Authorizer
+}
+
+// NewAPI returns a new Offers API facade.
+func NewAPI(st *state.State, resources *common.Resources, authorizer common.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{st: st, authorizer: authorizer}, nil
+}
+
+// AddOffers offers the given endpoints of the given services to the
+// other environments on the state server.
+func (api *API) AddOffers(args params.Offers) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Offers)),
+	}
+	for i, offer := range args.Offers {
+		_, err := api.st.AddOffer(offer.Name, offer.ServiceName, offer.Endpoints)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// ListOffers returns all the offers made by the environment.
+func (api *API) ListOffers() (params.ListOffersResults, error) {
+	offers, err := api.st.AllOffers()
+	if err != nil {
+		return params.ListOffersResults{}, common.ServerError(err)
+	}
+	results := params.ListOffersResults{
+		Offers: make([]params.Offer, len(offers)),
+	}
+	for i, offer := range offers {
+		results.Offers[i] = params.Offer{
+			Name:        offer.Name(),
+			ServiceName: offer.ServiceName(),
+			Endpoints:   offer.Endpoints(),
+		}
+	}
+	return results, nil
+}
+
+// Consume adds a remote service for each of the given offers, which
+// may be related to as if it were a local service.
+func (api *API) Consume(args params.ConsumeOffers) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Offers)),
+	}
+	for i, offer := range args.Offers {
+		err := api.consume(offer)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (api *API) consume(arg params.ConsumeOffer) error {
+	user, ok := api.authorizer.GetAuthTag().(names.UserTag)
+	if !ok {
+		return common.ErrPerm
+	}
+	env, err := api.findEnvironment(user, arg.Environment)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	offerSt, err := api.st.ForEnviron(env.EnvironTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer offerSt.Close()
+
+	offer, err := offerSt.Offer(arg.OfferName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	service, err := offerSt.Service(offer.ServiceName())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ch, _, err := service.Charm()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	serviceName := arg.ServiceName
+	if serviceName == "" {
+		serviceName = offer.Name()
+	}
+	logger.Infof("consuming offer %q of environment %q as service %q", offer, env.Name(), serviceName)
+	_, err = api.st.AddRemoteService(serviceName, user.String(), ch, state.RemoteServiceInfo{
+		EnvUUID:     env.UUID(),
+		ServiceName: service.Name(),
+		OfferName:   offer.Name(),
+		Endpoints:   offer.Endpoints(),
+	})
+	return errors.Trace(err)
+}
+
+// findEnvironment returns the environment, accessible to the user, with
+// the given name or UUID.
+func (api *API) findEnvironment(user names.UserTag, nameOrUUID string) (*state.Environment, error) {
+	envs, err := api.st.EnvironmentsForUser(user)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var found *state.Environment
+	for _, env := range envs {
+		if env.UUID() == nameOrUUID {
+			return env, nil
+		}
+		if env.Name() != nameOrUUID {
+			continue
+		}
+		if found != nil {
+			return nil, errors.Errorf("environment name %q is ambiguous", nameOrUUID)
+		}
+		found = env
+	}
+	if found == nil {
+		return nil, errors.NotFoundf("environment %q", nameOrUUID)
+	}
+	return found, nil
+}
@@ -0,0 +1,124 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package offers_test
+
+import (
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/offers"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type offersSuite struct {
+	jujutesting.JujuConnSuite
+	api *offers.API
+}
+
+var _ = gc.Suite(&offersSuite{})
+
+func (s *offersSuite) SetUpTest(c *gc.C) {
+	s.JujuConnSuite.SetUpTest(c)
+
+	var err error
+	auth := apiservertesting.FakeAuthorizer{
+		Tag: s.AdminUserTag(c),
+	}
+	s.api, err = offers.NewAPI(s.State, common.NewResources(), auth)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *offersSuite) TestNewAPIRequiresClient(c *gc.C) {
+	auth := apiservertesting.FakeAuthorizer{
+		Tag: names.NewMachineTag("0"),
+	}
+	_, err := offers.NewAPI(s.State, common.NewResources(), auth)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *offersSuite) TestAddAndListOffers(c *gc.C) {
+	s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+
+	results, err := s.api.AddOffers(params.Offers{
+		Offers: []params.Offer{{
+			Name:        "db",
+			ServiceName: "mysql",
+			Endpoints:   []string{"server"},
+		}, {
+			Name:        "blog",
+			ServiceName: "wordpress",
+			Endpoints:   []string{"url"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `cannot add offer "blog": service "wordpress" not found`)
+	c.Assert(results.Results[1].Error, jc.Satisfies, params.IsCodeNotFound)
+
+	list, err := s.api.ListOffers()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(list, jc.DeepEquals, params.ListOffersResults{
+		Offers: []params.Offer{{
+			Name:        "db",
+			ServiceName: "mysql",
+			Endpoints:   []string{"server"},
+		}},
+	})
+}
+
+func (s *offersSuite) TestConsume(c *gc.C) {
+	otherState := s.Factory.MakeEnvironment(c, &factory.EnvParams{Name: "shared"})
+	defer otherState.Close()
+	otherFactory := factory.NewFactory(otherState)
+	otherFactory.MakeService(c, &factory.ServiceParams{
+		Charm:   otherFactory.MakeCharm(c, &factory.CharmParams{Name: "mysql"}),
+		Creator: s.AdminUserTag(c),
+	})
+	_, err := otherState.AddOffer("db", "mysql", []string{"server"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.api.Consume(params.ConsumeOffers{
+		Offers: []params.ConsumeOffer{{
+			Environment: "shared",
+			OfferName:   "db",
+		}, {
+			Environment: otherState.EnvironUUID(),
+			OfferName:   "db",
+			ServiceName: "shared-db",
+		}, {
+			Environment: "shared",
+			OfferName:   "nonsense",
+		}, {
+			Environment: "nonsense",
+			OfferName:   "db",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 4)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.IsNil)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `offer "nonsense" not found`)
+	c.Assert(results.Results[3].Error, gc.ErrorMatches, `environment "nonsense" not found`)
+
+	expected := state.RemoteServiceInfo{
+		EnvUUID:     otherState.EnvironUUID(),
+		ServiceName: "mysql",
+		OfferName:   "db",
+		Endpoints:   []string{"server"},
+	}
+	for _, name := range []string{"db", "shared-db"} {
+		service, err := s.State.Service(name)
+		c.Assert(err, jc.ErrorIsNil)
+		info, ok := service.RemoteInfo()
+		c.Assert(ok, jc.IsTrue)
+		c.Assert(info, jc.DeepEquals, expected)
+	}
+}
@@ -30,6 +30,22 @@ const (
 	// ActionRunning is the status of an Action that has been started but
 	// not completed yet.
 	ActionRunning string = "running"
+
+	// ActionAborted is the status of an Action that was stopped by
+	// request while it was running.
+	ActionAborted string = "aborted"
+
+	// ActionTimedOut is the status of an Action that was stopped
+	// because it ran for longer than its timeout.
+	ActionTimedOut string = "timed-out"
+)
+
+const (
+	// OutputStdout identifies output that was written to stdout.
+	OutputStdout string = "stdout"
+
+	// OutputStderr identifies output that was written to stderr.
+	OutputStderr string = "stderr"
 )
 
 // Actions is a slice of Action for bulk requests.
@@ -43,6 +59,7 @@ type Action struct {
 	Receiver   string                 `json:"receiver"`
 	Name       string                 `json:"name"`
 	Parameters map[string]interface{} `json:"parameters,omitempty"`
+	Timeout    time.Duration          `json:"timeout,omitempty"`
 }
 
 // ActionResults is a slice of ActionResult for bulk requests.
@@ -117,3 +134,81 @@ type ServiceCharmActionsResult struct {
 	Actions    *charm.Actions `json:"actions,omitempty"`
 	Error      *Error         `json:"error,omitempty"`
 }
+
+// ActionSchedules holds a slice of ActionSchedule for bulk requests.
+type ActionSchedules struct {
+	Schedules []ActionSchedule `json:"schedules,omitempty"`
+}
+
+// ActionSchedule describes an Action that is queued up repeatedly, at
+// a fixed interval.
+type ActionSchedule struct {
+	Name       string                 `json:"name"`
+	Receiver   string                 `json:"receiver"`
+	ActionName string                 `json:"action-name"`
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+	Start      time.Time              `json:"start"`
+	Interval   time.Duration          `json:"interval"`
+	NextRun    time.Time              `json:"next-run,omitempty"`
+	Runs       []ActionScheduleRun    `json:"runs,omitempty"`
+}
+
+// ActionScheduleRun describes an occasion on which a scheduled Action
+// was due.
+type ActionScheduleRun struct {
+	Due     time.Time `json:"due"`
+	Action  string    `json:"action,omitempty"`
+	Skipped bool      `json:"skipped,omitempty"`
+	Message string    `json:"message,omitempty"`
+}
+
+// ActionScheduleNames holds the names of ActionSchedules.
+type ActionScheduleNames struct {
+	Names []string `json:"names"`
+}
+
+// OutputChunk holds a piece of the output of an Action, or of commands
+// run on a unit, as it was produced.
+type OutputChunk struct {
+	Unit   string    `json:"unit,omitempty"`
+	Seq    int       `json:"seq"`
+	Stream string    `json:"stream"`
+	Data   string    `json:"data"`
+	Time   time.Time `json:"time"`
+}
+
+// Output holds chunks of the output with the given id, which is the tag
+// of an Action, or the output id given when running commands on a unit.
+type Output struct {
+	Id     string        `json:"id"`
+	Chunks []OutputChunk `json:"chunks"`
+}
+
+// Outputs holds a slice of Output for bulk requests.
+type Outputs struct {
+	Outputs []Output `json:"outputs,omitempty"`
+}
+
+// OutputQuery requests the chunks of the output with the given id. For
+// each unit named in After, only the chunks that follow the given
+// sequence number are requested.
+type OutputQuery struct {
+	Id    string         `json:"id"`
+	After map[string]int `json:"after,omitempty"`
+}
+
+// OutputQueries holds a slice of OutputQuery for bulk requests.
+type OutputQueries struct {
+	Queries []OutputQuery `json:"queries,omitempty"`
+}
+
+// OutputResult holds the chunks requested by an OutputQuery.
+type OutputResult struct {
+	Chunks []OutputChunk `json:"chunks,omitempty"`
+	Error  *Error        `json:"error,omitempty"`
+}
+
+// OutputResults holds a slice of OutputResult for bulk requests.
+type OutputResults struct {
+	Results []OutputResult `json:"results,omitempty"`
+}
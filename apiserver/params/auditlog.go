@@ -0,0 +1,46 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import (
+	"time"
+)
+
+// AuditLogFilter holds the parameters for querying the audit log.
+// Zero-valued fields do not restrict the result.
+type AuditLogFilter struct {
+	// User holds the name of the user whose calls to return.
+	User string `json:"user,omitempty"`
+
+	// Facade holds the name of the facade whose calls to return.
+	Facade string `json:"facade,omitempty"`
+
+	// Since and Until restrict the calls returned to those completed
+	// in the range [Since, Until).
+	Since *time.Time `json:"since,omitempty"`
+	Until *time.Time `json:"until,omitempty"`
+
+	// Limit restricts the result to the most recent Limit calls.
+	Limit int `json:"limit,omitempty"`
+}
+
+// AuditLogEntry describes a single audited API call.
+type AuditLogEntry struct {
+	Timestamp time.Time `json:"timestamp"`
+	User      string    `json:"user"`
+	TokenID   string    `json:"token-id,omitempty"`
+	Facade    string    `json:"facade"`
+	Version   int       `json:"version"`
+	Method    string    `json:"method"`
+	Args      string    `json:"args,omitempty"`
+	Result    string    `json:"result,omitempty"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// AuditLogResults holds the result of an API call to query the
+// audit log.
+type AuditLogResults struct {
+	EnvUUID string          `json:"env-uuid"`
+	Entries []AuditLogEntry `json:"entries,omitempty"`
+}
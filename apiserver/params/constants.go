@@ -29,4 +29,8 @@ const (
 	ResolvedNone       ResolvedMode = ""
 	ResolvedRetryHooks ResolvedMode = "retry-hooks"
 	ResolvedNoHooks    ResolvedMode = "no-hooks"
+
+	// ResolvedCancelRetry cancels the automatic retry of a failed
+	// hook.
+	ResolvedCancelRetry ResolvedMode = "cancel-retry"
 )
@@ -27,6 +27,32 @@ type EnvironmentUnset struct {
 	Keys []string
 }
 
+// UpdateCredentialsResult holds the result of an
+// EnvironmentUpdateCredentials client API call.
+type UpdateCredentialsResult struct {
+	// Workers holds the names of the state server workers that have
+	// opened the environment with the new credentials.
+	Workers []string
+
+	// PendingWorkers holds the names of the state server workers that
+	// had not yet opened the environment with the new credentials when
+	// the call returned.
+	PendingWorkers []string
+
+	// RetriedMachines holds the ids of the machines whose
+	// provisioning, which failed because the provider rejected the
+	// old credentials, is retried with the new ones.
+	RetriedMachines []string
+}
+
+// CredentialsLoaded records that a state server worker has opened the
+// environment with the provider credentials that have the given
+// fingerprint.
+type CredentialsLoaded struct {
+	Worker      string
+	Fingerprint string
+}
+
 // ModifyEnvironUsers holds the parameters for making Client ShareEnvironment calls.
 type ModifyEnvironUsers struct {
 	Changes []ModifyEnvironUser
@@ -45,6 +71,9 @@ const (
 type ModifyEnvironUser struct {
 	UserTag string        `json:"user-tag"`
 	Action  EnvironAction `json:"action"`
+	// Access holds the level of access to grant the user when the
+	// action is AddEnvUser. If empty, write access is granted.
+	Access string `json:"access,omitempty"`
 }
 
 // SetEnvironAgentVersion contains the arguments for
@@ -60,6 +89,7 @@ type EnvUserInfo struct {
 	CreatedBy      string     `json:"createdby"`
 	DateCreated    time.Time  `json:"datecreated"`
 	LastConnection *time.Time `json:"lastconnection"`
+	Access         string     `json:"access"`
 }
 
 // EnvUserInfoResult holds the result of an EnvUserInfo call.
@@ -72,3 +102,9 @@ type EnvUserInfoResult struct {
 type EnvUserInfoResults struct {
 	Results []EnvUserInfoResult `json:"results"`
 }
+
+// SerializedEnvironment holds the YAML description of an environment's
+// model, as defined by the description package.
+type SerializedEnvironment struct {
+	Bytes []byte `json:"bytes"`
+}
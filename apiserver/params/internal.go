@@ -371,6 +371,38 @@ type StatusHistory struct {
 	Name string
 }
 
+// StatusHistoryFilter holds the criteria used to select the entries
+// returned by a status history query. Zero values select all entries.
+type StatusHistoryFilter struct {
+	Size   int        `json:"size,omitempty"`
+	Since  *time.Time `json:"since,omitempty"`
+	Until  *time.Time `json:"until,omitempty"`
+	Status Status     `json:"status,omitempty"`
+}
+
+// StatusHistoryArgs holds the parameters of a status history query
+// for a unit, machine or service.
+type StatusHistoryArgs struct {
+	Kind   HistoryKind         `json:"kind"`
+	Tag    string              `json:"tag"`
+	Filter StatusHistoryFilter `json:"filter"`
+}
+
+// StatusHistoryEntry holds a past or current status of an entity.
+type StatusHistoryEntry struct {
+	Kind   HistoryKind            `json:"kind"`
+	Status Status                 `json:"status"`
+	Info   string                 `json:"info"`
+	Data   map[string]interface{} `json:"data,omitempty"`
+	Since  *time.Time             `json:"since"`
+}
+
+// StatusHistoryResult holds the entries returned by a status history
+// query, oldest first.
+type StatusHistoryResult struct {
+	Statuses []StatusHistoryEntry `json:"statuses"`
+}
+
 // StatusResult holds an entity status, extra information, or an
 // error.
 type StatusResult struct {
@@ -534,12 +566,15 @@ type CharmsResponse struct {
 // RunParams is used to provide the parameters to the Run method.
 // Commands and Timeout are expected to have values, and one or more
 // values should be in the Machines, Services, or Units slices.
+// If OutputId is set, the output of the commands run on units is
+// recorded under that id as it is produced.
 type RunParams struct {
 	Commands string
 	Timeout  time.Duration
 	Machines []string
 	Services []string
 	Units    []string
+	OutputId string `json:",omitempty"`
 }
 
 // RunResult contains the result from an individual run call on a machine.
@@ -572,6 +607,11 @@ type ProvisioningInfo struct {
 	Jobs        []multiwatcher.MachineJob
 	Volumes     []VolumeParams
 	Tags        map[string]string
+
+	// SubnetsToZones maps the provider ids of the subnets in the
+	// spaces that the machine's units' endpoints are bound to, to
+	// the availability zones those subnets are in.
+	SubnetsToZones map[string][]string `json:",omitempty"`
 }
 
 // ProvisioningInfoResult holds machine provisioning info or an error.
@@ -633,3 +673,44 @@ type MeterStatusResult struct {
 type MeterStatusResults struct {
 	Results []MeterStatusResult
 }
+
+// HealthCheckResult holds the outcome of one of the health checks
+// declared by a unit's charm.
+type HealthCheckResult struct {
+	Name    string
+	Healthy bool
+	Message string
+	Since   time.Time
+}
+
+// UnitHealthChecks holds the results of the health checks of a unit.
+type UnitHealthChecks struct {
+	Tag    string
+	Checks []HealthCheckResult
+}
+
+// SetHealthChecks holds the parameters for making a SetHealthChecks
+// call.
+type SetHealthChecks struct {
+	Units []UnitHealthChecks
+}
+
+// HookRetryPolicy describes how a unit's failed hooks are retried
+// automatically.
+type HookRetryPolicy struct {
+	Attempts int
+	Delay    time.Duration
+	MaxDelay time.Duration
+}
+
+// HookRetryPolicyResult holds a hook retry policy or an error.
+type HookRetryPolicyResult struct {
+	Error  *Error
+	Result HookRetryPolicy
+}
+
+// HookRetryPolicyResults holds the bulk operation result of an API call
+// that returns hook retry policies or errors.
+type HookRetryPolicyResults struct {
+	Results []HookRetryPolicyResult
+}
@@ -3,6 +3,8 @@
 
 package params
 
+import "time"
+
 // ClaimLeadershipBulkParams is a collection of parameters for making
 // a bulk leadership claim.
 type ClaimLeadershipBulkParams struct {
@@ -84,3 +86,25 @@ type MergeLeadershipSettingsParam struct {
 	// Settings are the Leadership settings you wish to merge in.
 	Settings Settings
 }
+
+// ServiceLeadershipResults holds the leaders of a number of services.
+type ServiceLeadershipResults struct {
+	Results []ServiceLeadershipResult
+}
+
+// ServiceLeadershipResult holds the leader of a service, and the time
+// at which the leader's lease expires.
+type ServiceLeadershipResult struct {
+	// ServiceTag is the service whose leader is given.
+	ServiceTag string
+
+	// UnitTag is the unit that is the leader of the service. It is
+	// empty if the service has no leader.
+	UnitTag string `json:",omitempty"`
+
+	// Expiry is the time at which the leader's lease expires, unless
+	// it is renewed first.
+	Expiry time.Time
+
+	Error *Error `json:",omitempty"`
+}
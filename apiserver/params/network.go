@@ -431,3 +431,47 @@ type APIHostPortsResult struct {
 func (r APIHostPortsResult) NetworkHostsPorts() [][]network.HostPort {
 	return NetworkHostsPorts(r.Servers)
 }
+
+// Subnet describes a subnet of a space.
+type Subnet struct {
+	// CIDR of the subnet, in 123.45.67.89/24 format.
+	CIDR string `json:"CIDR"`
+
+	// ProviderId is the provider-specific id of the subnet. It may be
+	// empty.
+	ProviderId string `json:"ProviderId,omitempty"`
+
+	// AvailabilityZone is the availability zone that the subnet is
+	// in. It may be empty.
+	AvailabilityZone string `json:"AvailabilityZone,omitempty"`
+}
+
+// SpaceSubnets holds the name of a space, and subnets that are in it
+// or are to be added to it.
+type SpaceSubnets struct {
+	Name    string   `json:"Name"`
+	Subnets []Subnet `json:"Subnets"`
+}
+
+// SpacesSubnets holds the arguments of the Spaces.CreateSpaces and
+// Spaces.AddSubnets API calls.
+type SpacesSubnets struct {
+	Spaces []SpaceSubnets `json:"Spaces"`
+}
+
+// ListSpacesResults holds the result of the Spaces.ListSpaces API call.
+type ListSpacesResults struct {
+	Spaces []SpaceSubnets `json:"Spaces"`
+}
+
+// UnitEndpoint identifies an endpoint of a unit's service.
+type UnitEndpoint struct {
+	Tag      string `json:"Tag"`
+	Endpoint string `json:"Endpoint"`
+}
+
+// UnitEndpoints holds the arguments of the Uniter.BindingAddresses
+// API call.
+type UnitEndpoints struct {
+	Endpoints []UnitEndpoint `json:"Endpoints"`
+}
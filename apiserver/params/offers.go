@@ -0,0 +1,42 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+// Offer describes the endpoints of a service offered to the other
+// environments on the state server under a name.
+type Offer struct {
+	Name        string   `json:"Name"`
+	ServiceName string   `json:"ServiceName"`
+	Endpoints   []string `json:"Endpoints"`
+}
+
+// Offers holds the arguments of the Offers.AddOffers API call.
+type Offers struct {
+	Offers []Offer `json:"Offers"`
+}
+
+// ListOffersResults holds the result of the Offers.ListOffers API call.
+type ListOffersResults struct {
+	Offers []Offer `json:"Offers"`
+}
+
+// ConsumeOffer identifies an offer to consume, and names the remote
+// service that is added for it.
+type ConsumeOffer struct {
+	// Environment is the name or UUID of the environment making the
+	// offer.
+	Environment string `json:"Environment"`
+
+	// OfferName is the name of the offer.
+	OfferName string `json:"OfferName"`
+
+	// ServiceName is the name of the remote service to add. The offer
+	// name is used if it is empty.
+	ServiceName string `json:"ServiceName,omitempty"`
+}
+
+// ConsumeOffers holds the arguments of the Offers.Consume API call.
+type ConsumeOffers struct {
+	Offers []ConsumeOffer `json:"Offers"`
+}
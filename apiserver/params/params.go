@@ -202,6 +202,10 @@ type ServiceDeploy struct {
 	ToMachineSpec string
 	Networks      []string
 	Storage       map[string]storage.Constraints
+
+	// EndpointBindings maps the names of the service's endpoints to
+	// the names of the spaces they are bound to.
+	EndpointBindings map[string]string `json:",omitempty"`
 }
 
 // ServiceUpdate holds the parameters for making the ServiceUpdate call.
@@ -225,6 +229,11 @@ type ServiceSetCharm struct {
 // ServiceExpose holds the parameters for making the ServiceExpose call.
 type ServiceExpose struct {
 	ServiceName string
+
+	// SourceCIDRs restricts access to the service's open ports to
+	// the given source CIDRs. If empty the ports are open to any
+	// address.
+	SourceCIDRs []string `json:",omitempty"`
 }
 
 // ServiceSet holds the parameters for a ServiceSet
@@ -264,6 +273,25 @@ type ServiceGetResults struct {
 	Constraints constraints.Value
 }
 
+// ServiceSetHookRetryPolicy holds the parameters for making the
+// ServiceSetHookRetryPolicy call. A nil Policy reverts the service to
+// the environment's policy.
+type ServiceSetHookRetryPolicy struct {
+	ServiceName string
+	Policy      *HookRetryPolicy
+}
+
+// ServiceHookRetryPolicyResults holds the results of the
+// ServiceGetHookRetryPolicy call.
+type ServiceHookRetryPolicyResults struct {
+	Service string
+	Policy  HookRetryPolicy
+
+	// Inherited reports whether the policy is the environment's,
+	// rather than one set on the service.
+	Inherited bool
+}
+
 // ServiceCharmRelations holds parameters for making the ServiceCharmRelations call.
 type ServiceCharmRelations struct {
 	ServiceName string
@@ -314,6 +342,11 @@ type PrivateAddressResults struct {
 type Resolved struct {
 	UnitName string
 	Retry    bool
+
+	// CancelRetry cancels the automatic retry of the unit's failed
+	// hook, rather than resolving the error. It may not be combined
+	// with Retry.
+	CancelRetry bool
 }
 
 // ResolvedResults holds results of the Resolved call.
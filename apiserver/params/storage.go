@@ -3,7 +3,11 @@
 
 package params
 
-import "github.com/juju/juju/storage"
+import (
+	"time"
+
+	"github.com/juju/juju/storage"
+)
 
 // MachineBlockDevices holds a machine tag and the block devices present
 // on that machine.
@@ -94,6 +98,8 @@ type StorageAttachment struct {
 	Kind     StorageKind
 	Location string
 	Life     Life
+	// Size is the size of the volume backing block storage in MiB.
+	Size uint64 `json:",omitempty"`
 }
 
 // StorageAttachmentId identifies a storage attachment by the tags of the
@@ -208,6 +214,9 @@ type VolumeParams struct {
 	Attributes map[string]interface{}  `json:"attributes,omitempty"`
 	Tags       map[string]string       `json:"tags,omitempty"`
 	Attachment *VolumeAttachmentParams `json:"attachment,omitempty"`
+	// Snapshot is the provider ID of the snapshot to create the
+	// volume from, if any.
+	Snapshot string `json:"snapshot,omitempty"`
 }
 
 // VolumeAttachmentParams holds the parameters for creating a volume
@@ -280,6 +289,95 @@ type VolumeAttachmentParamsResults struct {
 	Results []VolumeAttachmentParamsResult `json:"results,omitempty"`
 }
 
+// VolumeSize holds the size of a volume, in MiB.
+type VolumeSize struct {
+	VolumeTag string `json:"volumetag"`
+	Size      uint64 `json:"size"`
+}
+
+// VolumeSizes holds the sizes of a set of volumes.
+type VolumeSizes struct {
+	Sizes []VolumeSize `json:"sizes"`
+}
+
+// VolumeResizeParams holds the parameters for resizing a volume.
+type VolumeResizeParams struct {
+	VolumeTag string `json:"volumetag"`
+	VolumeId  string `json:"volumeid"`
+	Provider  string `json:"provider"`
+	// Size is the requested size of the volume in MiB.
+	Size uint64 `json:"size"`
+}
+
+// VolumeResizeParamsResult holds the parameters for resizing a volume.
+type VolumeResizeParamsResult struct {
+	Result VolumeResizeParams `json:"result"`
+	Error  *Error             `json:"error,omitempty"`
+}
+
+// VolumeResizeParamsResults holds the parameters for resizing multiple
+// volumes.
+type VolumeResizeParamsResults struct {
+	Results []VolumeResizeParamsResult `json:"results,omitempty"`
+}
+
+// VolumeSnapshotIds holds a set of volume snapshot IDs.
+type VolumeSnapshotIds struct {
+	Ids []string `json:"ids"`
+}
+
+// VolumeSnapshot identifies and describes a volume snapshot.
+type VolumeSnapshot struct {
+	Id        string             `json:"id"`
+	VolumeTag string             `json:"volumetag"`
+	Info      VolumeSnapshotInfo `json:"info"`
+}
+
+// VolumeSnapshotInfo describes a volume snapshot.
+type VolumeSnapshotInfo struct {
+	SnapshotId string `json:"snapshotid"`
+	// Size is the size of the snapshot in MiB.
+	Size uint64 `json:"size"`
+}
+
+// VolumeSnapshots describes a set of volume snapshots.
+type VolumeSnapshots struct {
+	Snapshots []VolumeSnapshot `json:"snapshots"`
+}
+
+// VolumeSnapshotResult holds information about a volume snapshot.
+type VolumeSnapshotResult struct {
+	Result VolumeSnapshot `json:"result"`
+	Error  *Error         `json:"error,omitempty"`
+}
+
+// VolumeSnapshotResults holds information about multiple volume snapshots.
+type VolumeSnapshotResults struct {
+	Results []VolumeSnapshotResult `json:"results,omitempty"`
+}
+
+// VolumeSnapshotParams holds the parameters for taking a volume snapshot.
+type VolumeSnapshotParams struct {
+	Id        string            `json:"id"`
+	VolumeTag string            `json:"volumetag"`
+	VolumeId  string            `json:"volumeid"`
+	Provider  string            `json:"provider"`
+	Tags      map[string]string `json:"tags,omitempty"`
+}
+
+// VolumeSnapshotParamsResult holds provisioning parameters for a volume
+// snapshot.
+type VolumeSnapshotParamsResult struct {
+	Result VolumeSnapshotParams `json:"result"`
+	Error  *Error               `json:"error,omitempty"`
+}
+
+// VolumeSnapshotParamsResults holds provisioning parameters for multiple
+// volume snapshots.
+type VolumeSnapshotParamsResults struct {
+	Results []VolumeSnapshotParamsResult `json:"results,omitempty"`
+}
+
 // Filesystem identifies and describes a storage filesystem in the environment.
 type Filesystem struct {
 	FilesystemTag string         `json:"filesystemtag"`
@@ -373,6 +471,27 @@ type FilesystemParamsResults struct {
 	Results []FilesystemParamsResult `json:"results,omitempty"`
 }
 
+// FilesystemDestroyParams holds the parameters for destroying a filesystem.
+type FilesystemDestroyParams struct {
+	FilesystemTag string `json:"filesystemtag"`
+	FilesystemId  string `json:"filesystemid"`
+	VolumeTag     string `json:"volumetag,omitempty"`
+	Provider      string `json:"provider"`
+}
+
+// FilesystemDestroyParamsResult holds the parameters for destroying a
+// filesystem.
+type FilesystemDestroyParamsResult struct {
+	Result FilesystemDestroyParams `json:"result"`
+	Error  *Error                  `json:"error,omitempty"`
+}
+
+// FilesystemDestroyParamsResults holds the parameters for destroying
+// multiple filesystems.
+type FilesystemDestroyParamsResults struct {
+	Results []FilesystemDestroyParamsResult `json:"results,omitempty"`
+}
+
 // FilesystemAttachmentParamsResults holds provisioning parameters for a filesystem
 // attachment.
 type FilesystemAttachmentParamsResult struct {
@@ -409,6 +528,9 @@ type StorageDetails struct {
 
 	// Persistent indicates whether the storage is persistent or not.
 	Persistent bool `json:"persistent"`
+
+	// Snapshots holds the snapshots of block storage's volume.
+	Snapshots []VolumeSnapshotDetails `json:"snapshots,omitempty"`
 }
 
 // StorageDetailsResult holds information about a storage instance
@@ -523,6 +645,60 @@ type VolumeItemsResult struct {
 	Results []VolumeItem `json:"results,omitempty"`
 }
 
+// VolumeSnapshotDetails describes a volume snapshot for the purpose
+// of snapshot CLI commands.
+type VolumeSnapshotDetails struct {
+	// Id is the Juju-assigned ID of the snapshot.
+	Id string `json:"id"`
+
+	// VolumeTag is the tag of the snapshotted volume.
+	VolumeTag string `json:"volumetag"`
+
+	// Status indicates snapshot status, e.g. pending, available, destroying.
+	Status string `json:"status"`
+
+	// Created is the time that the snapshot was requested.
+	Created time.Time `json:"created"`
+
+	// SnapshotId is the provider-supplied ID for the snapshot.
+	SnapshotId string `json:"snapshotid,omitempty"`
+
+	// Size is the size of the snapshot in MiB.
+	Size uint64 `json:"size,omitempty"`
+}
+
+// VolumeSnapshotDetailsResult holds details of a volume snapshot,
+// or an error.
+type VolumeSnapshotDetailsResult struct {
+	Result VolumeSnapshotDetails `json:"result"`
+	Error  *Error                `json:"error,omitempty"`
+}
+
+// VolumeSnapshotDetailsResults holds details of multiple volume snapshots.
+type VolumeSnapshotDetailsResults struct {
+	Results []VolumeSnapshotDetailsResult `json:"results,omitempty"`
+}
+
+// VolumeSnapshotFilter holds a filter for the volume snapshot list
+// API call.
+type VolumeSnapshotFilter struct {
+	// Volumes are volume tags to filter on.
+	Volumes []string `json:"volumes,omitempty"`
+}
+
+// VolumeSnapshotRestore holds the parameters for creating a volume
+// from a snapshot, attached to a machine.
+type VolumeSnapshotRestore struct {
+	Id         string `json:"id"`
+	MachineTag string `json:"machinetag"`
+}
+
+// VolumeSnapshotRestores holds parameters for creating volumes from
+// snapshots.
+type VolumeSnapshotRestores struct {
+	Restores []VolumeSnapshotRestore `json:"restores"`
+}
+
 // StorageConstraints contains constraints for storage instance.
 type StorageConstraints struct {
 	// Pool is the name of the storage pool from which to provision the
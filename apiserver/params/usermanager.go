@@ -57,3 +57,40 @@ type AddUserResult struct {
 	Tag   string `json:"tag,omitempty"`
 	Error *Error `json:"error,omitempty"`
 }
+
+// CreateToken holds the parameters for creating an API token for the
+// logged in user.
+type CreateToken struct {
+	// ExpiresIn holds how long the token remains valid for.
+	ExpiresIn time.Duration `json:"expires-in"`
+
+	// Access holds the most access the token grants to an environment.
+	// If empty, the token grants the same access as the user has.
+	Access string `json:"access,omitempty"`
+}
+
+// CreateTokenResult holds a newly created API token along with the
+// credential to log in with, which cannot be retrieved later.
+type CreateTokenResult struct {
+	UserToken
+	Credential string `json:"credential"`
+}
+
+// UserToken holds information about an API token.
+type UserToken struct {
+	ID       string     `json:"id"`
+	Access   string     `json:"access"`
+	Created  time.Time  `json:"created"`
+	Expires  time.Time  `json:"expires"`
+	LastUsed *time.Time `json:"last-used,omitempty"`
+}
+
+// UserTokenResults holds the API tokens of the logged in user.
+type UserTokenResults struct {
+	Tokens []UserToken `json:"tokens"`
+}
+
+// RevokeTokens holds the IDs of API tokens to revoke.
+type RevokeTokens struct {
+	IDs []string `json:"ids"`
+}
@@ -48,6 +48,7 @@ type ProvisionerAPI struct {
 	*common.StateAddresser
 	*common.APIAddresser
 	*common.EnvironWatcher
+	*common.CredentialsLoadedSetter
 	*common.EnvironMachinesWatcher
 	*common.InstanceIdGetter
 	*common.ToolsFinder
@@ -98,22 +99,23 @@ func NewProvisionerAPI(st *state.State, resources *common.Resources, authorizer
 	}
 	urlGetter := common.NewToolsURLGetter(env.UUID(), st)
 	return &ProvisionerAPI{
-		Remover:                common.NewRemover(st, false, getAuthFunc),
-		StatusSetter:           common.NewStatusSetter(st, getAuthFunc),
-		StatusGetter:           common.NewStatusGetter(st, getAuthFunc),
-		DeadEnsurer:            common.NewDeadEnsurer(st, getAuthFunc),
-		PasswordChanger:        common.NewPasswordChanger(st, getAuthFunc),
-		LifeGetter:             common.NewLifeGetter(st, getAuthFunc),
-		StateAddresser:         common.NewStateAddresser(st),
-		APIAddresser:           common.NewAPIAddresser(st, resources),
-		EnvironWatcher:         common.NewEnvironWatcher(st, resources, authorizer),
-		EnvironMachinesWatcher: common.NewEnvironMachinesWatcher(st, resources, authorizer),
-		InstanceIdGetter:       common.NewInstanceIdGetter(st, getAuthFunc),
-		ToolsFinder:            common.NewToolsFinder(st, st, urlGetter),
-		st:                     st,
-		resources:              resources,
-		authorizer:             authorizer,
-		getAuthFunc:            getAuthFunc,
+		Remover:                 common.NewRemover(st, false, getAuthFunc),
+		StatusSetter:            common.NewStatusSetter(st, getAuthFunc),
+		StatusGetter:            common.NewStatusGetter(st, getAuthFunc),
+		DeadEnsurer:             common.NewDeadEnsurer(st, getAuthFunc),
+		PasswordChanger:         common.NewPasswordChanger(st, getAuthFunc),
+		LifeGetter:              common.NewLifeGetter(st, getAuthFunc),
+		StateAddresser:          common.NewStateAddresser(st),
+		APIAddresser:            common.NewAPIAddresser(st, resources),
+		EnvironWatcher:          common.NewEnvironWatcher(st, resources, authorizer),
+		CredentialsLoadedSetter: common.NewCredentialsLoadedSetter(st, authorizer),
+		EnvironMachinesWatcher:  common.NewEnvironMachinesWatcher(st, resources, authorizer),
+		InstanceIdGetter:        common.NewInstanceIdGetter(st, getAuthFunc),
+		ToolsFinder:             common.NewToolsFinder(st, st, urlGetter),
+		st:                      st,
+		resources:               resources,
+		authorizer:              authorizer,
+		getAuthFunc:             getAuthFunc,
 	}, nil
 }
 
@@ -418,14 +420,19 @@ func (p *ProvisionerAPI) getProvisioningInfo(m *state.Machine) (*params.Provisio
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	subnetsToZones, err := p.machineSubnetsToZones(m)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	return &params.ProvisioningInfo{
-		Constraints: cons,
-		Series:      m.Series(),
-		Placement:   m.Placement(),
-		Networks:    networks,
-		Jobs:        jobs,
-		Volumes:     volumes,
-		Tags:        tags,
+		Constraints:    cons,
+		Series:         m.Series(),
+		Placement:      m.Placement(),
+		Networks:       networks,
+		Jobs:           jobs,
+		Volumes:        volumes,
+		Tags:           tags,
+		SubnetsToZones: subnetsToZones,
 	}, nil
 }
 
@@ -1283,6 +1290,65 @@ func (p *ProvisionerAPI) createOrFetchStateSubnet(subnetInfo network.SubnetInfo)
 	return subnet, nil
 }
 
+// machineSubnetsToZones returns the availability zones of the subnets,
+// keyed on their provider ids, in the space that the endpoints of the
+// services of the machine's units are bound to. Subnets unknown to the
+// provider are omitted. A machine is started in a single subnet, so its
+// units must not be bound to more than one space.
+func (p *ProvisionerAPI) machineSubnetsToZones(m *state.Machine) (map[string][]string, error) {
+	units, err := m.Units()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	spaceNames := set.NewStrings()
+	for _, unit := range units {
+		service, err := unit.Service()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, spaceName := range service.EndpointBindings() {
+			spaceNames.Add(spaceName)
+		}
+	}
+	switch spaceNames.Size() {
+	case 0:
+		return nil, nil
+	case 1:
+	default:
+		return nil, errors.NotSupportedf(
+			"units of machine %q bound to more than one space (%s)",
+			m.Id(), strings.Join(spaceNames.SortedValues(), ", "),
+		)
+	}
+	spaceName := spaceNames.Values()[0]
+	space, err := p.st.Space(spaceName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	subnets, err := space.Subnets()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var subnetsToZones map[string][]string
+	for _, subnet := range subnets {
+		if subnet.ProviderId() == "" {
+			logger.Warningf("ignoring subnet %q in space %q: no provider id", subnet.CIDR(), spaceName)
+			continue
+		}
+		if subnetsToZones == nil {
+			subnetsToZones = make(map[string][]string)
+		}
+		var zones []string
+		if zone := subnet.AvailabilityZone(); zone != "" {
+			zones = []string{zone}
+		} else {
+			logger.Warningf("subnet %q in space %q has no availability zone", subnet.ProviderId(), spaceName)
+		}
+		subnetsToZones[subnet.ProviderId()] = zones
+	}
+	return subnetsToZones, nil
+}
+
 // machineTags returns machine-specific tags to set on the instance.
 func (p *ProvisionerAPI) machineTags(m *state.Machine, jobs []multiwatcher.MachineJob) (map[string]string, error) {
 	// Names of all units deployed to the machine.
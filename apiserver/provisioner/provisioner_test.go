@@ -837,6 +837,69 @@ func (s *withoutStateServerSuite) TestProvisioningInfo(c *gc.C) {
 	c.Assert(result, jc.DeepEquals, expected)
 }
 
+func (s *withoutStateServerSuite) TestProvisioningInfoWithEndpointBindings(c *gc.C) {
+	_, err := s.State.AddSubnet(state.SubnetInfo{
+		CIDR:             "10.0.0.0/24",
+		ProviderId:       "subnet-0",
+		AvailabilityZone: "zone0",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSubnet(state.SubnetInfo{CIDR: "10.0.1.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSpace("db", []string{"10.0.0.0/24", "10.0.1.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	svc := s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	err = svc.SetEndpointBindings(map[string]string{"db": "db"})
+	c.Assert(err, jc.ErrorIsNil)
+	unit, err := svc.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(s.machines[1])
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: s.machines[0].Tag().String()},
+		{Tag: s.machines[1].Tag().String()},
+	}}
+	result, err := s.provisioner.ProvisioningInfo(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[0].Result.SubnetsToZones, gc.IsNil)
+	c.Assert(result.Results[1].Error, gc.IsNil)
+	// The subnet without a provider id is left out.
+	c.Assert(result.Results[1].Result.SubnetsToZones, jc.DeepEquals, map[string][]string{
+		"subnet-0": {"zone0"},
+	})
+}
+
+func (s *withoutStateServerSuite) TestProvisioningInfoWithEndpointBindingsInSeveralSpaces(c *gc.C) {
+	for _, name := range []string{"db", "public"} {
+		_, err := s.State.AddSpace(name, nil)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	for _, binding := range []struct{ service, charm, endpoint, space string }{
+		{"wordpress", "wordpress", "db", "db"},
+		{"mysql", "mysql", "server", "public"},
+	} {
+		svc := s.AddTestingService(c, binding.service, s.AddTestingCharm(c, binding.charm))
+		err := svc.SetEndpointBindings(map[string]string{binding.endpoint: binding.space})
+		c.Assert(err, jc.ErrorIsNil)
+		unit, err := svc.AddUnit()
+		c.Assert(err, jc.ErrorIsNil)
+		err = unit.AssignToMachine(s.machines[1])
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: s.machines[1].Tag().String()},
+	}}
+	result, err := s.provisioner.ProvisioningInfo(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.ErrorMatches, `units of machine "1" bound to more than one space \(db, public\) not supported`)
+}
+
 func (s *withoutStateServerSuite) TestStorageProviderFallbackToType(c *gc.C) {
 	registry.RegisterProvider("dynamic", &storagedummy.StorageProvider{IsDynamic: true})
 	defer registry.RegisterProvider("dynamic", nil)
@@ -0,0 +1,102 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"github.com/juju/utils/set"
+)
+
+// readOnlyFacades are facades whose every method leaves the
+// environment unchanged.
+var readOnlyFacades = set.NewStrings(
+	"AllWatcher",
+	"FilesystemAttachmentsWatcher",
+	"NotifyWatcher",
+	"Pinger",
+	"RelationUnitsWatcher",
+	"StringsWatcher",
+	"VolumeAttachmentsWatcher",
+)
+
+// readOnlyCalls holds the methods, keyed by facade name, that clients
+// may call without changing the environment.
+var readOnlyCalls = map[string]set.Strings{
+	"Action": set.NewStrings(
+		"Actions",
+		"FindActionTagsByPrefix",
+		"ListAll",
+		"ListPending",
+		"ListRunning",
+		"ListCompleted",
+		"ListSchedules",
+		"Output",
+		"ServicesCharmActions",
+		"ServicesUnits",
+	),
+	"Annotations": set.NewStrings(
+		"Get",
+	),
+	"AuditLog": set.NewStrings(
+		"Entries",
+	),
+	"Block": set.NewStrings(
+		"List",
+	),
+	"Client": set.NewStrings(
+		"AgentVersion",
+		"APIHostPorts",
+		"CharmInfo",
+		"EnvironmentGet",
+		"EnvironmentInfo",
+		"EnvUserInfo",
+		"FindTools",
+		"FullStatus",
+		"GetAnnotations",
+		"GetEnvironmentConstraints",
+		"GetServiceConstraints",
+		"PrivateAddress",
+		"PublicAddress",
+		"RunOutput",
+		"ServiceCharmRelations",
+		"ServiceGet",
+		"ServiceGetCharmURL",
+		"ServiceGetHookRetryPolicy",
+		"Status",
+		"StatusHistory",
+		"UnitStatusHistory",
+		"WatchAll",
+	),
+	"KeyManager": set.NewStrings(
+		"ListKeys",
+	),
+	"LeadershipAdmin": set.NewStrings(
+		"Leaders",
+	),
+	"Offers": set.NewStrings(
+		"ListOffers",
+	),
+	"Spaces": set.NewStrings(
+		"ListSpaces",
+	),
+	"Storage": set.NewStrings(
+		"List",
+		"ListPools",
+		"ListVolumes",
+		"Show",
+	),
+	"UserManager": set.NewStrings(
+		"ListTokens",
+		"UserInfo",
+	),
+}
+
+// isCallReadOnly returns whether the given facade method leaves the
+// environment unchanged.
+func isCallReadOnly(facadeName, methodName string) bool {
+	if readOnlyFacades.Contains(facadeName) {
+		return true
+	}
+	methods, ok := readOnlyCalls[facadeName]
+	return ok && methods.Contains(methodName)
+}
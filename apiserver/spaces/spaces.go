@@ -0,0 +1,125 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package spaces implements the API used by clients to manage network
+// spaces, which are named groups of subnets.
+package spaces
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+var logger = loggo.GetLogger("juju.apiserver.spaces")
+
+func init() {
+	common.RegisterStandardFacade("Spaces", 1, NewAPI)
+}
+
+// API implements the Spaces API.
+type API struct {
+	st         *state.State
+	authorizer common.Authorizer
+}
+
+// NewAPI returns a new Spaces API facade.
+func NewAPI(st *state.State, resources *common.Resources, authorizer common.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{st: st, authorizer: authorizer}, nil
+}
+
+// CreateSpaces creates the given spaces, each made up of the given
+// subnets. Subnets that are not yet known are added.
+func (api *API) CreateSpaces(args params.SpacesSubnets) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Spaces)),
+	}
+	for i, space := range args.Spaces {
+		cidrs, err := api.ensureSubnets(space.Subnets)
+		if err == nil {
+			_, err = api.st.AddSpace(space.Name, cidrs)
+		}
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// AddSubnets adds the given subnets to the given existing spaces.
+// Subnets that are not yet known are added.
+func (api *API) AddSubnets(args params.SpacesSubnets) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Spaces)),
+	}
+	for i, spaceSubnets := range args.Spaces {
+		space, err := api.st.Space(spaceSubnets.Name)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		cidrs, err := api.ensureSubnets(spaceSubnets.Subnets)
+		if err == nil {
+			err = space.AddSubnets(cidrs)
+		}
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// ListSpaces returns all the spaces in the environment, with their
+// subnets.
+func (api *API) ListSpaces() (params.ListSpacesResults, error) {
+	spaces, err := api.st.AllSpaces()
+	if err != nil {
+		return params.ListSpacesResults{}, common.ServerError(err)
+	}
+	results := params.ListSpacesResults{
+		Spaces: make([]params.SpaceSubnets, len(spaces)),
+	}
+	for i, space := range spaces {
+		subnets, err := space.Subnets()
+		if err != nil {
+			return params.ListSpacesResults{}, common.ServerError(err)
+		}
+		result := params.SpaceSubnets{
+			Name:    space.Name(),
+			Subnets: make([]params.Subnet, len(subnets)),
+		}
+		for j, subnet := range subnets {
+			result.Subnets[j] = params.Subnet{
+				CIDR:             subnet.CIDR(),
+				ProviderId:       subnet.ProviderId(),
+				AvailabilityZone: subnet.AvailabilityZone(),
+			}
+		}
+		results.Spaces[i] = result
+	}
+	return results, nil
+}
+
+// ensureSubnets adds those of the given subnets that are not yet known,
+// and returns the CIDRs of all of them.
+func (api *API) ensureSubnets(subnets []params.Subnet) ([]string, error) {
+	cidrs := make([]string, len(subnets))
+	for i, subnet := range subnets {
+		_, err := api.st.Subnet(subnet.CIDR)
+		if errors.IsNotFound(err) {
+			logger.Infof("adding subnet %q", subnet.CIDR)
+			_, err = api.st.AddSubnet(state.SubnetInfo{
+				CIDR:             subnet.CIDR,
+				ProviderId:       subnet.ProviderId,
+				AvailabilityZone: subnet.AvailabilityZone,
+			})
+		}
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		cidrs[i] = subnet.CIDR
+	}
+	return cidrs, nil
+}
@@ -0,0 +1,115 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package spaces_test
+
+import (
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/apiserver/spaces"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/state"
+)
+
+type spacesSuite struct {
+	jujutesting.JujuConnSuite
+	api *spaces.API
+}
+
+var _ = gc.Suite(&spacesSuite{})
+
+func (s *spacesSuite) SetUpTest(c *gc.C) {
+	s.JujuConnSuite.SetUpTest(c)
+
+	var err error
+	auth := apiservertesting.FakeAuthorizer{
+		Tag: s.AdminUserTag(c),
+	}
+	s.api, err = spaces.NewAPI(s.State, common.NewResources(), auth)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *spacesSuite) TestNewAPIRequiresClient(c *gc.C) {
+	auth := apiservertesting.FakeAuthorizer{
+		Tag: names.NewMachineTag("0"),
+	}
+	_, err := spaces.NewAPI(s.State, common.NewResources(), auth)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *spacesSuite) TestCreateSpaces(c *gc.C) {
+	_, err := s.State.AddSubnet(state.SubnetInfo{CIDR: "10.0.0.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.api.CreateSpaces(params.SpacesSubnets{
+		Spaces: []params.SpaceSubnets{{
+			Name: "dmz",
+			Subnets: []params.Subnet{
+				{CIDR: "10.0.0.0/24"},
+				{CIDR: "10.0.1.0/24", ProviderId: "subnet-1", AvailabilityZone: "zone-a"},
+			},
+		}, {
+			Name: "Bad Name",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `cannot add space "Bad Name": space name "Bad Name" not valid`)
+
+	subnet, err := s.State.Subnet("10.0.1.0/24")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(subnet.ProviderId(), gc.Equals, "subnet-1")
+	c.Assert(subnet.AvailabilityZone(), gc.Equals, "zone-a")
+	c.Assert(subnet.SpaceName(), gc.Equals, "dmz")
+}
+
+func (s *spacesSuite) TestAddSubnets(c *gc.C) {
+	_, err := s.State.AddSpace("dmz", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.api.AddSubnets(params.SpacesSubnets{
+		Spaces: []params.SpaceSubnets{{
+			Name:    "dmz",
+			Subnets: []params.Subnet{{CIDR: "10.0.0.0/24"}},
+		}, {
+			Name:    "public",
+			Subnets: []params.Subnet{{CIDR: "10.0.1.0/24"}},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `space "public" not found`)
+	c.Assert(results.Results[1].Error, jc.Satisfies, params.IsCodeNotFound)
+
+	subnet, err := s.State.Subnet("10.0.0.0/24")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(subnet.SpaceName(), gc.Equals, "dmz")
+}
+
+func (s *spacesSuite) TestListSpaces(c *gc.C) {
+	_, err := s.State.AddSubnet(state.SubnetInfo{CIDR: "10.0.0.0/24", ProviderId: "subnet-0"})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSpace("public", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSpace("dmz", []string{"10.0.0.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.api.ListSpaces()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ListSpacesResults{
+		Spaces: []params.SpaceSubnets{{
+			Name:    "dmz",
+			Subnets: []params.Subnet{{CIDR: "10.0.0.0/24", ProviderId: "subnet-0"}},
+		}, {
+			Name:    "public",
+			Subnets: []params.Subnet{},
+		}},
+	})
+}
@@ -5,6 +5,7 @@ package storage_test
 
 import (
 	stdtesting "testing"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/names"
@@ -48,6 +49,8 @@ type baseStorageSuite struct {
 	pools       map[string]*jujustorage.Config
 
 	blocks map[state.BlockType]state.Block
+
+	snapshot *mockVolumeSnapshot
 }
 
 func (s *baseStorageSuite) SetUpTest(c *gc.C) {
@@ -82,6 +85,12 @@ const (
 	allVolumesCall                          = "allVolumes"
 	addStorageForUnitCall                   = "addStorageForUnit"
 	getBlockForTypeCall                     = "getBlockForType"
+	addVolumeSnapshotCall                   = "addVolumeSnapshot"
+	volumeSnapshotsCall                     = "volumeSnapshots"
+	allVolumeSnapshotsCall                  = "allVolumeSnapshots"
+	destroyVolumeSnapshotCall               = "destroyVolumeSnapshot"
+	addVolumeFromSnapshotCall               = "addVolumeFromSnapshot"
+	resizeVolumeCall                        = "resizeVolume"
 )
 
 func (s *baseStorageSuite) constructState(c *gc.C) *mockState {
@@ -107,6 +116,13 @@ func (s *baseStorageSuite) constructState(c *gc.C) *mockState {
 		MachineTag: s.machineTag,
 	}
 
+	s.snapshot = &mockVolumeSnapshot{
+		id:      "66/0",
+		volume:  s.volumeTag,
+		life:    state.Alive,
+		created: time.Date(2015, 8, 1, 12, 0, 0, 0, time.UTC),
+	}
+
 	s.blocks = make(map[state.BlockType]state.Block)
 	return &mockState{
 		allStorageInstances: func() ([]state.StorageInstance, error) {
@@ -173,6 +189,41 @@ func (s *baseStorageSuite) constructState(c *gc.C) *mockState {
 			val, found := s.blocks[t]
 			return val, found, nil
 		},
+		addVolumeSnapshot: func(tag names.VolumeTag) (state.VolumeSnapshot, error) {
+			s.calls = append(s.calls, addVolumeSnapshotCall)
+			c.Assert(tag, gc.DeepEquals, s.volumeTag)
+			return s.snapshot, nil
+		},
+		volumeSnapshots: func(tag names.VolumeTag) ([]state.VolumeSnapshot, error) {
+			s.calls = append(s.calls, volumeSnapshotsCall)
+			c.Assert(tag, gc.DeepEquals, s.volumeTag)
+			return []state.VolumeSnapshot{s.snapshot}, nil
+		},
+		allVolumeSnapshots: func() ([]state.VolumeSnapshot, error) {
+			s.calls = append(s.calls, allVolumeSnapshotsCall)
+			return []state.VolumeSnapshot{s.snapshot}, nil
+		},
+		destroyVolumeSnapshot: func(id string) error {
+			s.calls = append(s.calls, destroyVolumeSnapshotCall)
+			if id != s.snapshot.id {
+				return errors.NotFoundf("volume snapshot %q", id)
+			}
+			return nil
+		},
+		addVolumeFromSnapshot: func(id string, machine names.MachineTag) (names.VolumeTag, error) {
+			s.calls = append(s.calls, addVolumeFromSnapshotCall)
+			c.Assert(id, gc.Equals, s.snapshot.id)
+			c.Assert(machine, gc.DeepEquals, s.machineTag)
+			return names.NewVolumeTag("66/1"), nil
+		},
+		resizeVolume: func(tag names.VolumeTag, size uint64) error {
+			s.calls = append(s.calls, resizeVolumeCall)
+			c.Assert(tag, gc.DeepEquals, s.volumeTag)
+			if size < 1024 {
+				return errors.New("cannot shrink volume from 1024MiB to 512MiB")
+			}
+			return nil
+		},
 	}
 }
 
@@ -261,6 +312,7 @@ type mockState struct {
 	watchStorageAttachment              func(names.StorageTag, names.UnitTag) state.NotifyWatcher
 	watchFilesystemAttachment           func(names.MachineTag, names.FilesystemTag) state.NotifyWatcher
 	watchVolumeAttachment               func(names.MachineTag, names.VolumeTag) state.NotifyWatcher
+	watchVolume                         func(names.VolumeTag) state.NotifyWatcher
 	envName                             string
 	volume                              func(tag names.VolumeTag) (state.Volume, error)
 	machineVolumeAttachments            func(machine names.MachineTag) ([]state.VolumeAttachment, error)
@@ -268,6 +320,12 @@ type mockState struct {
 	allVolumes                          func() ([]state.Volume, error)
 	addStorageForUnit                   func(u names.UnitTag, name string, cons state.StorageConstraints) error
 	getBlockForType                     func(t state.BlockType) (state.Block, bool, error)
+	addVolumeSnapshot                   func(tag names.VolumeTag) (state.VolumeSnapshot, error)
+	volumeSnapshots                     func(tag names.VolumeTag) ([]state.VolumeSnapshot, error)
+	allVolumeSnapshots                  func() ([]state.VolumeSnapshot, error)
+	destroyVolumeSnapshot               func(id string) error
+	addVolumeFromSnapshot               func(id string, machine names.MachineTag) (names.VolumeTag, error)
+	resizeVolume                        func(tag names.VolumeTag, size uint64) error
 }
 
 func (st *mockState) StorageInstance(s names.StorageTag) (state.StorageInstance, error) {
@@ -314,6 +372,10 @@ func (st *mockState) WatchVolumeAttachment(mtag names.MachineTag, v names.Volume
 	return st.watchVolumeAttachment(mtag, v)
 }
 
+func (st *mockState) WatchVolume(v names.VolumeTag) state.NotifyWatcher {
+	return st.watchVolume(v)
+}
+
 func (st *mockState) EnvName() (string, error) {
 	return st.envName, nil
 }
@@ -342,6 +404,30 @@ func (st *mockState) GetBlockForType(t state.BlockType) (state.Block, bool, erro
 	return st.getBlockForType(t)
 }
 
+func (st *mockState) AddVolumeSnapshot(tag names.VolumeTag) (state.VolumeSnapshot, error) {
+	return st.addVolumeSnapshot(tag)
+}
+
+func (st *mockState) VolumeSnapshots(tag names.VolumeTag) ([]state.VolumeSnapshot, error) {
+	return st.volumeSnapshots(tag)
+}
+
+func (st *mockState) AllVolumeSnapshots() ([]state.VolumeSnapshot, error) {
+	return st.allVolumeSnapshots()
+}
+
+func (st *mockState) DestroyVolumeSnapshot(id string) error {
+	return st.destroyVolumeSnapshot(id)
+}
+
+func (st *mockState) AddVolumeFromSnapshot(id string, machine names.MachineTag) (names.VolumeTag, error) {
+	return st.addVolumeFromSnapshot(id, machine)
+}
+
+func (st *mockState) ResizeVolume(tag names.VolumeTag, size uint64) error {
+	return st.resizeVolume(tag, size)
+}
+
 type mockNotifyWatcher struct {
 	state.NotifyWatcher
 	changes chan struct{}
@@ -467,6 +553,41 @@ func (va *mockVolumeAttachment) Params() (state.VolumeAttachmentParams, bool) {
 	panic("not implemented for test")
 }
 
+type mockVolumeSnapshot struct {
+	id      string
+	volume  names.VolumeTag
+	life    state.Life
+	created time.Time
+	info    *state.VolumeSnapshotInfo
+}
+
+func (s *mockVolumeSnapshot) Id() string {
+	return s.id
+}
+
+func (s *mockVolumeSnapshot) Volume() names.VolumeTag {
+	return s.volume
+}
+
+func (s *mockVolumeSnapshot) Pool() string {
+	return "loop"
+}
+
+func (s *mockVolumeSnapshot) Life() state.Life {
+	return s.life
+}
+
+func (s *mockVolumeSnapshot) Created() time.Time {
+	return s.created
+}
+
+func (s *mockVolumeSnapshot) Info() (state.VolumeSnapshotInfo, error) {
+	if s.info == nil {
+		return state.VolumeSnapshotInfo{}, errors.NotProvisionedf("volume snapshot %q", s.id)
+	}
+	return *s.info, nil
+}
+
 type mockBlock struct {
 	t   state.BlockType
 	msg string
@@ -0,0 +1,41 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+type volumeResizeSuite struct {
+	baseStorageSuite
+}
+
+var _ = gc.Suite(&volumeResizeSuite{})
+
+func (s *volumeResizeSuite) TestResizeVolumes(c *gc.C) {
+	results, err := s.api.ResizeVolumes(params.VolumeSizes{
+		Sizes: []params.VolumeSize{
+			{VolumeTag: "volume-22", Size: 2048},
+			{VolumeTag: "volume-22", Size: 512},
+			{VolumeTag: "machine-0", Size: 2048},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, "cannot shrink volume from 1024MiB to 512MiB")
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `"machine-0" is not a valid volume tag`)
+	s.assertCalls(c, []string{getBlockForTypeCall, resizeVolumeCall, resizeVolumeCall})
+}
+
+func (s *volumeResizeSuite) TestResizeVolumesBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestResizeVolumesBlocked")
+	_, err := s.api.ResizeVolumes(params.VolumeSizes{
+		Sizes: []params.VolumeSize{{VolumeTag: "volume-22", Size: 2048}},
+	})
+	s.assertBlocked(c, err, "TestResizeVolumesBlocked")
+}
@@ -0,0 +1,142 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+type volumeSnapshotSuite struct {
+	baseStorageSuite
+}
+
+var _ = gc.Suite(&volumeSnapshotSuite{})
+
+func (s *volumeSnapshotSuite) expectedSnapshot(status string) params.VolumeSnapshotDetails {
+	return params.VolumeSnapshotDetails{
+		Id:        "66/0",
+		VolumeTag: "volume-22",
+		Status:    status,
+		Created:   time.Date(2015, 8, 1, 12, 0, 0, 0, time.UTC),
+	}
+}
+
+func (s *volumeSnapshotSuite) TestCreateVolumeSnapshots(c *gc.C) {
+	results, err := s.api.CreateVolumeSnapshots(params.Entities{
+		Entities: []params.Entity{{"volume-22"}, {"machine-0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0], jc.DeepEquals, params.VolumeSnapshotDetailsResult{
+		Result: s.expectedSnapshot("pending"),
+	})
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `"machine-0" is not a valid volume tag`)
+	s.assertCalls(c, []string{getBlockForTypeCall, addVolumeSnapshotCall})
+}
+
+func (s *volumeSnapshotSuite) TestCreateVolumeSnapshotsBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestCreateVolumeSnapshotsBlocked")
+	_, err := s.api.CreateVolumeSnapshots(params.Entities{
+		Entities: []params.Entity{{"volume-22"}},
+	})
+	s.assertBlocked(c, err, "TestCreateVolumeSnapshotsBlocked")
+}
+
+func (s *volumeSnapshotSuite) TestListVolumeSnapshots(c *gc.C) {
+	s.snapshot.info = &state.VolumeSnapshotInfo{SnapshotId: "snap-1", Size: 1024}
+	expected := s.expectedSnapshot("available")
+	expected.SnapshotId = "snap-1"
+	expected.Size = 1024
+
+	results, err := s.api.ListVolumeSnapshots(params.VolumeSnapshotFilter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.VolumeSnapshotDetailsResult{{Result: expected}})
+	s.assertCalls(c, []string{allVolumeSnapshotsCall})
+}
+
+func (s *volumeSnapshotSuite) TestListVolumeSnapshotsFilter(c *gc.C) {
+	s.snapshot.life = state.Dying
+	results, err := s.api.ListVolumeSnapshots(params.VolumeSnapshotFilter{
+		Volumes: []string{"volume-22"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.VolumeSnapshotDetailsResult{{
+		Result: s.expectedSnapshot("destroying"),
+	}})
+	s.assertCalls(c, []string{volumeSnapshotsCall})
+}
+
+func (s *volumeSnapshotSuite) TestListVolumeSnapshotsInvalidFilter(c *gc.C) {
+	_, err := s.api.ListVolumeSnapshots(params.VolumeSnapshotFilter{
+		Volumes: []string{"unit-mysql-0"},
+	})
+	c.Assert(err, gc.ErrorMatches, `"unit-mysql-0" is not a valid volume tag`)
+}
+
+func (s *volumeSnapshotSuite) TestDestroyVolumeSnapshots(c *gc.C) {
+	results, err := s.api.DestroyVolumeSnapshots(params.VolumeSnapshotIds{
+		Ids: []string{"66/0", "42"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, jc.Satisfies, params.IsCodeNotFound)
+	s.assertCalls(c, []string{getBlockForTypeCall, destroyVolumeSnapshotCall, destroyVolumeSnapshotCall})
+}
+
+func (s *volumeSnapshotSuite) TestDestroyVolumeSnapshotsBlocked(c *gc.C) {
+	s.blockRemoveObject(c, "TestDestroyVolumeSnapshotsBlocked")
+	_, err := s.api.DestroyVolumeSnapshots(params.VolumeSnapshotIds{
+		Ids: []string{"66/0"},
+	})
+	s.assertBlocked(c, err, "TestDestroyVolumeSnapshotsBlocked")
+}
+
+func (s *volumeSnapshotSuite) TestRestoreVolumeSnapshots(c *gc.C) {
+	results, err := s.api.RestoreVolumeSnapshots(params.VolumeSnapshotRestores{
+		Restores: []params.VolumeSnapshotRestore{
+			{Id: "66/0", MachineTag: "machine-66"},
+			{Id: "66/0", MachineTag: "volume-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0], jc.DeepEquals, params.StringResult{Result: "volume-66-1"})
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `"volume-0" is not a valid machine tag`)
+	s.assertCalls(c, []string{getBlockForTypeCall, addVolumeFromSnapshotCall})
+}
+
+func (s *volumeSnapshotSuite) TestRestoreVolumeSnapshotsBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestRestoreVolumeSnapshotsBlocked")
+	_, err := s.api.RestoreVolumeSnapshots(params.VolumeSnapshotRestores{
+		Restores: []params.VolumeSnapshotRestore{{Id: "66/0", MachineTag: "machine-66"}},
+	})
+	s.assertBlocked(c, err, "TestRestoreVolumeSnapshotsBlocked")
+}
+
+func (s *volumeSnapshotSuite) TestShowBlockStorageSnapshots(c *gc.C) {
+	s.storageInstance.kind = state.StorageKindBlock
+	found, err := s.api.Show(params.Entities{Entities: []params.Entity{{s.storageTag.String()}}})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertCalls(c, []string{
+		storageInstanceCall,
+		storageInstanceVolumeCall,
+		storageInstanceAttachmentsCall,
+		unitAssignedMachineCall,
+		storageInstanceCall,
+		storageInstanceVolumeCall,
+		storageInstanceVolumeCall,
+		volumeSnapshotsCall,
+	})
+	c.Assert(found.Results, gc.HasLen, 1)
+	c.Assert(found.Results[0].Result.Snapshots, jc.DeepEquals, []params.VolumeSnapshotDetails{
+		s.expectedSnapshot("pending"),
+	})
+}
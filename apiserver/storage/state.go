@@ -44,6 +44,9 @@ type storageAccess interface {
 	// WatchVolumeAttachment is required for storage functionality.
 	WatchVolumeAttachment(names.MachineTag, names.VolumeTag) state.NotifyWatcher
 
+	// WatchVolume is required for storage functionality.
+	WatchVolume(names.VolumeTag) state.NotifyWatcher
+
 	// EnvName is required for pool functionality.
 	EnvName() (string, error)
 
@@ -64,6 +67,24 @@ type storageAccess interface {
 
 	// GetBlockForType is required to block operations.
 	GetBlockForType(t state.BlockType) (state.Block, bool, error)
+
+	// AddVolumeSnapshot is required for volume snapshot functionality.
+	AddVolumeSnapshot(tag names.VolumeTag) (state.VolumeSnapshot, error)
+
+	// VolumeSnapshots is required for volume snapshot functionality.
+	VolumeSnapshots(tag names.VolumeTag) ([]state.VolumeSnapshot, error)
+
+	// AllVolumeSnapshots is required for volume snapshot functionality.
+	AllVolumeSnapshots() ([]state.VolumeSnapshot, error)
+
+	// DestroyVolumeSnapshot is required for volume snapshot functionality.
+	DestroyVolumeSnapshot(id string) error
+
+	// AddVolumeFromSnapshot is required for volume snapshot functionality.
+	AddVolumeFromSnapshot(id string, machine names.MachineTag) (names.VolumeTag, error)
+
+	// ResizeVolume is required for volume resize functionality.
+	ResizeVolume(tag names.VolumeTag, size uint64) error
 }
 
 var getState = func(st *state.State) storageAccess {
@@ -79,6 +79,9 @@ func (api *API) Show(entities params.Entities) (params.StorageDetailsResults, er
 		}
 		if found {
 			results := api.createStorageDetailsResult(storageTag, instance)
+			if instance.Kind == params.StorageKindBlock {
+				api.addVolumeSnapshots(storageTag, results)
+			}
 			all = append(all, results...)
 		}
 	}
@@ -116,6 +119,31 @@ func (api *API) List() (params.StorageInfosResult, error) {
 	return params.StorageInfosResult{Results: infos}, nil
 }
 
+// addVolumeSnapshots adds details of the snapshots of the volume
+// backing the specified storage instance to each of the results.
+func (api *API) addVolumeSnapshots(storageTag names.StorageTag, results []params.StorageDetailsResult) {
+	var snapshots []params.VolumeSnapshotDetails
+	volume, err := api.storage.StorageInstanceVolume(storageTag)
+	if err == nil {
+		var stateSnapshots []state.VolumeSnapshot
+		stateSnapshots, err = api.storage.VolumeSnapshots(volume.VolumeTag())
+		for _, s := range stateSnapshots {
+			snapshots = append(snapshots, createParamsVolumeSnapshot(s))
+		}
+	}
+	for i := range results {
+		if err != nil {
+			if results[i].Error == nil {
+				results[i].Error = common.ServerError(errors.Annotatef(
+					err, "getting snapshots for storage %v", storageTag.Id(),
+				))
+			}
+			continue
+		}
+		results[i].Result.Snapshots = snapshots
+	}
+}
+
 func (api *API) createStorageDetailsResult(
 	storageTag names.StorageTag,
 	instance params.StorageDetails,
@@ -623,3 +651,157 @@ func (a *API) AddToUnit(args params.StoragesAddParams) (params.ErrorResults, err
 	}
 	return params.ErrorResults{Results: result}, nil
 }
+
+// CreateVolumeSnapshots requests snapshots of the volumes with the
+// specified tags. The snapshots are taken asynchronously by the storage
+// provisioner; until then they are reported with the "pending" status.
+// A "CHANGE" block can block this operation.
+func (a *API) CreateVolumeSnapshots(args params.Entities) (params.VolumeSnapshotDetailsResults, error) {
+	blockChecker := common.NewBlockChecker(a.storage)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.VolumeSnapshotDetailsResults{}, errors.Trace(err)
+	}
+	results := make([]params.VolumeSnapshotDetailsResult, len(args.Entities))
+	one := func(arg params.Entity) (params.VolumeSnapshotDetails, error) {
+		volumeTag, err := names.ParseVolumeTag(arg.Tag)
+		if err != nil {
+			return params.VolumeSnapshotDetails{}, errors.Trace(err)
+		}
+		snapshot, err := a.storage.AddVolumeSnapshot(volumeTag)
+		if err != nil {
+			return params.VolumeSnapshotDetails{}, errors.Trace(err)
+		}
+		return createParamsVolumeSnapshot(snapshot), nil
+	}
+	for i, arg := range args.Entities {
+		snapshot, err := one(arg)
+		if err != nil {
+			results[i].Error = common.ServerError(err)
+			continue
+		}
+		results[i].Result = snapshot
+	}
+	return params.VolumeSnapshotDetailsResults{Results: results}, nil
+}
+
+// ListVolumeSnapshots returns the snapshots of the volumes with the
+// tags in the filter, or of all volumes if the filter is empty.
+func (a *API) ListVolumeSnapshots(filter params.VolumeSnapshotFilter) (params.VolumeSnapshotDetailsResults, error) {
+	var all []state.VolumeSnapshot
+	if len(filter.Volumes) == 0 {
+		snapshots, err := a.storage.AllVolumeSnapshots()
+		if err != nil {
+			return params.VolumeSnapshotDetailsResults{}, common.ServerError(err)
+		}
+		all = snapshots
+	}
+	for _, tag := range filter.Volumes {
+		volumeTag, err := names.ParseVolumeTag(tag)
+		if err != nil {
+			return params.VolumeSnapshotDetailsResults{}, errors.Trace(err)
+		}
+		snapshots, err := a.storage.VolumeSnapshots(volumeTag)
+		if err != nil {
+			return params.VolumeSnapshotDetailsResults{}, common.ServerError(err)
+		}
+		all = append(all, snapshots...)
+	}
+	results := make([]params.VolumeSnapshotDetailsResult, len(all))
+	for i, snapshot := range all {
+		results[i].Result = createParamsVolumeSnapshot(snapshot)
+	}
+	return params.VolumeSnapshotDetailsResults{Results: results}, nil
+}
+
+// DestroyVolumeSnapshots requests that the volume snapshots with the
+// specified IDs be destroyed. The snapshots are destroyed asynchronously
+// by the storage provisioner.
+// A "REMOVE" block can block this operation.
+func (a *API) DestroyVolumeSnapshots(args params.VolumeSnapshotIds) (params.ErrorResults, error) {
+	blockChecker := common.NewBlockChecker(a.storage)
+	if err := blockChecker.RemoveAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := make([]params.ErrorResult, len(args.Ids))
+	for i, id := range args.Ids {
+		err := a.storage.DestroyVolumeSnapshot(id)
+		results[i].Error = common.ServerError(err)
+	}
+	return params.ErrorResults{Results: results}, nil
+}
+
+// RestoreVolumeSnapshots creates new volumes from the volume snapshots
+// with the specified IDs, attached to the specified machines, returning
+// the tags of the new volumes.
+// A "CHANGE" block can block this operation.
+func (a *API) RestoreVolumeSnapshots(args params.VolumeSnapshotRestores) (params.StringResults, error) {
+	blockChecker := common.NewBlockChecker(a.storage)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.StringResults{}, errors.Trace(err)
+	}
+	results := make([]params.StringResult, len(args.Restores))
+	one := func(arg params.VolumeSnapshotRestore) (string, error) {
+		machineTag, err := names.ParseMachineTag(arg.MachineTag)
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		volumeTag, err := a.storage.AddVolumeFromSnapshot(arg.Id, machineTag)
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		return volumeTag.String(), nil
+	}
+	for i, arg := range args.Restores {
+		volumeTag, err := one(arg)
+		if err != nil {
+			results[i].Error = common.ServerError(err)
+			continue
+		}
+		results[i].Result = volumeTag
+	}
+	return params.StringResults{Results: results}, nil
+}
+
+// ResizeVolumes requests that the volumes with the specified tags be
+// grown to the specified sizes, in MiB. The volumes are resized
+// asynchronously by the storage provisioner; volumes may not be shrunk.
+// A "CHANGE" block can block this operation.
+func (a *API) ResizeVolumes(args params.VolumeSizes) (params.ErrorResults, error) {
+	blockChecker := common.NewBlockChecker(a.storage)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := make([]params.ErrorResult, len(args.Sizes))
+	one := func(arg params.VolumeSize) error {
+		volumeTag, err := names.ParseVolumeTag(arg.VolumeTag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		return a.storage.ResizeVolume(volumeTag, arg.Size)
+	}
+	for i, arg := range args.Sizes {
+		err := one(arg)
+		results[i].Error = common.ServerError(err)
+	}
+	return params.ErrorResults{Results: results}, nil
+}
+
+func createParamsVolumeSnapshot(s state.VolumeSnapshot) params.VolumeSnapshotDetails {
+	result := params.VolumeSnapshotDetails{
+		Id:        s.Id(),
+		VolumeTag: s.Volume().String(),
+		Status:    "pending",
+		Created:   s.Created(),
+	}
+	if s.Life() != state.Alive {
+		result.Status = "destroying"
+	}
+	if info, err := s.Info(); err == nil {
+		result.SnapshotId = info.SnapshotId
+		result.Size = info.Size
+		if result.Status == "pending" {
+			result.Status = "available"
+		}
+	}
+	return result
+}
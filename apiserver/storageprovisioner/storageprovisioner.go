@@ -26,7 +26,9 @@ func init() {
 type StorageProvisionerAPI struct {
 	*common.LifeGetter
 	*common.DeadEnsurer
+	*common.Remover
 	*common.EnvironWatcher
+	*common.CredentialsLoadedSetter
 	*common.InstanceIdGetter
 
 	st                       provisionerState
@@ -38,6 +40,7 @@ type StorageProvisionerAPI struct {
 	getMachineAuthFunc       common.GetAuthFunc
 	getBlockDevicesAuthFunc  common.GetAuthFunc
 	getAttachmentAuthFunc    func() (func(names.MachineTag, names.Tag) bool, error)
+	getSnapshotAuthFunc      func() (func(string) bool, error)
 }
 
 var getState = func(st *state.State) provisionerState {
@@ -157,13 +160,29 @@ func NewStorageProvisionerAPI(st *state.State, resources *common.Resources, auth
 			return false
 		}, nil
 	}
+	getSnapshotAuthFunc := func() (func(string) bool, error) {
+		// Volume snapshots share the scope of the volume they
+		// were taken of.
+		return func(id string) bool {
+			if !state.IsValidVolumeSnapshot(id) {
+				return false
+			}
+			machineTag, ok := state.VolumeSnapshotMachine(id)
+			if ok {
+				return canAccessStorageMachine(machineTag, false)
+			}
+			return authorizer.AuthEnvironManager()
+		}, nil
+	}
 	stateInterface := getState(st)
 	settings := getSettingsManager(st)
 	return &StorageProvisionerAPI{
-		LifeGetter:       common.NewLifeGetter(stateInterface, lifeAuthFunc),
-		DeadEnsurer:      common.NewDeadEnsurer(stateInterface, getStorageEntityAuthFunc),
-		EnvironWatcher:   common.NewEnvironWatcher(stateInterface, resources, authorizer),
-		InstanceIdGetter: common.NewInstanceIdGetter(st, getMachineAuthFunc),
+		LifeGetter:              common.NewLifeGetter(stateInterface, lifeAuthFunc),
+		DeadEnsurer:             common.NewDeadEnsurer(stateInterface, getStorageEntityAuthFunc),
+		Remover:                 common.NewRemover(stateInterface, false, getStorageEntityAuthFunc),
+		EnvironWatcher:          common.NewEnvironWatcher(stateInterface, resources, authorizer),
+		CredentialsLoadedSetter: common.NewCredentialsLoadedSetter(st, authorizer),
+		InstanceIdGetter:        common.NewInstanceIdGetter(st, getMachineAuthFunc),
 
 		st:                       stateInterface,
 		settings:                 settings,
@@ -174,6 +193,7 @@ func NewStorageProvisionerAPI(st *state.State, resources *common.Resources, auth
 		getAttachmentAuthFunc:    getAttachmentAuthFunc,
 		getMachineAuthFunc:       getMachineAuthFunc,
 		getBlockDevicesAuthFunc:  getBlockDevicesAuthFunc,
+		getSnapshotAuthFunc:      getSnapshotAuthFunc,
 	}, nil
 }
 
@@ -306,6 +326,18 @@ func (s *StorageProvisionerAPI) watchStorageEntities(
 	return results, nil
 }
 
+// WatchVolumeSnapshots watches for changes to volume snapshots scoped
+// to the entity with the tag passed to NewState.
+func (s *StorageProvisionerAPI) WatchVolumeSnapshots(args params.Entities) (params.StringsWatchResults, error) {
+	return s.watchStorageEntities(args, s.st.WatchEnvironVolumeSnapshots, s.st.WatchMachineVolumeSnapshots)
+}
+
+// WatchVolumeResizes watches for requests to resize volumes scoped to
+// the entity with the tag passed to NewState.
+func (s *StorageProvisionerAPI) WatchVolumeResizes(args params.Entities) (params.StringsWatchResults, error) {
+	return s.watchStorageEntities(args, s.st.WatchEnvironVolumeResizes, s.st.WatchMachineVolumeResizes)
+}
+
 // WatchVolumeAttachments watches for changes to volume attachments scoped to
 // the entity with the tag passed to NewState.
 func (s *StorageProvisionerAPI) WatchVolumeAttachments(args params.Entities) (params.MachineStorageIdsWatchResults, error) {
@@ -673,6 +705,46 @@ func (s *StorageProvisionerAPI) FilesystemParams(args params.Entities) (params.F
 	return results, nil
 }
 
+// FilesystemDestroyParams returns the parameters for destroying the
+// filesystems with the specified tags. Unlike FilesystemParams, the
+// parameters are only returned for filesystems that have already been
+// provisioned, as they identify the provider responsible for destroying
+// the filesystem.
+func (s *StorageProvisionerAPI) FilesystemDestroyParams(args params.Entities) (params.FilesystemDestroyParamsResults, error) {
+	canAccess, err := s.getStorageEntityAuthFunc()
+	if err != nil {
+		return params.FilesystemDestroyParamsResults{}, err
+	}
+	results := params.FilesystemDestroyParamsResults{
+		Results: make([]params.FilesystemDestroyParamsResult, len(args.Entities)),
+	}
+	poolManager := poolmanager.New(s.settings)
+	one := func(arg params.Entity) (params.FilesystemDestroyParams, error) {
+		tag, err := names.ParseFilesystemTag(arg.Tag)
+		if err != nil || !canAccess(tag) {
+			return params.FilesystemDestroyParams{}, common.ErrPerm
+		}
+		filesystem, err := s.st.Filesystem(tag)
+		if errors.IsNotFound(err) {
+			return params.FilesystemDestroyParams{}, common.ErrPerm
+		} else if err != nil {
+			return params.FilesystemDestroyParams{}, err
+		}
+		return common.FilesystemDestroyParams(filesystem, poolManager)
+	}
+	for i, arg := range args.Entities {
+		var result params.FilesystemDestroyParamsResult
+		destroyParams, err := one(arg)
+		if err != nil {
+			result.Error = common.ServerError(err)
+		} else {
+			result.Result = destroyParams
+		}
+		results.Results[i] = result
+	}
+	return results, nil
+}
+
 // VolumeAttachmentParams returns the parameters for creating the volume
 // attachments with the specified IDs.
 func (s *StorageProvisionerAPI) VolumeAttachmentParams(
@@ -961,6 +1033,20 @@ func (s *StorageProvisionerAPI) SetFilesystemInfo(args params.Filesystems) (para
 		} else if !canAccessFilesystem(filesystemTag) {
 			return common.ErrPerm
 		}
+		filesystem, err := s.st.Filesystem(filesystemTag)
+		if errors.IsNotFound(err) {
+			return common.ErrPerm
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		if oldInfo, err := filesystem.Info(); err == nil {
+			// The storage provisioner does not know the pool of a
+			// provisioned filesystem, so keep it when the
+			// filesystem's info is refreshed.
+			filesystemInfo.Pool = oldInfo.Pool
+		} else if !errors.IsNotProvisioned(err) {
+			return errors.Trace(err)
+		}
 		err = s.st.SetFilesystemInfo(filesystemTag, filesystemInfo)
 		if errors.IsNotFound(err) {
 			return common.ErrPerm
@@ -1086,3 +1172,281 @@ func (s *StorageProvisionerAPI) AttachmentLife(args params.MachineStorageIds) (p
 	}
 	return results, nil
 }
+
+// RemoveAttachment removes the specified machine storage attachments
+// from state. The attachments must be Dying; removing the last attachment
+// of a Dying filesystem makes the filesystem Dead. Removing volume
+// attachments is not yet supported.
+func (s *StorageProvisionerAPI) RemoveAttachment(args params.MachineStorageIds) (params.ErrorResults, error) {
+	canAccess, err := s.getAttachmentAuthFunc()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Ids)),
+	}
+	one := func(arg params.MachineStorageId) error {
+		machineTag, err := names.ParseMachineTag(arg.MachineTag)
+		if err != nil {
+			return err
+		}
+		attachmentTag, err := names.ParseTag(arg.AttachmentTag)
+		if err != nil {
+			return err
+		}
+		if !canAccess(machineTag, attachmentTag) {
+			return common.ErrPerm
+		}
+		switch attachmentTag := attachmentTag.(type) {
+		case names.FilesystemTag:
+			return s.st.RemoveFilesystemAttachment(machineTag, attachmentTag)
+		}
+		return errors.NotSupportedf("removing %s attachment", attachmentTag.Kind())
+	}
+	for i, arg := range args.Ids {
+		err := one(arg)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// VolumeSnapshotLife returns the lifecycle state of each specified
+// volume snapshot.
+func (s *StorageProvisionerAPI) VolumeSnapshotLife(args params.VolumeSnapshotIds) (params.LifeResults, error) {
+	canAccess, err := s.getSnapshotAuthFunc()
+	if err != nil {
+		return params.LifeResults{}, err
+	}
+	results := params.LifeResults{
+		Results: make([]params.LifeResult, len(args.Ids)),
+	}
+	one := func(id string) (params.Life, error) {
+		if !canAccess(id) {
+			return "", common.ErrPerm
+		}
+		// NotFound errors are passed through, so that the storage
+		// provisioner can tell when a snapshot has been removed.
+		snapshot, err := s.st.VolumeSnapshot(id)
+		if err != nil {
+			return "", err
+		}
+		return params.Life(snapshot.Life().String()), nil
+	}
+	for i, id := range args.Ids {
+		life, err := one(id)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+		} else {
+			results.Results[i].Life = life
+		}
+	}
+	return results, nil
+}
+
+// VolumeSnapshots returns details of the volume snapshots with the
+// specified IDs.
+func (s *StorageProvisionerAPI) VolumeSnapshots(args params.VolumeSnapshotIds) (params.VolumeSnapshotResults, error) {
+	canAccess, err := s.getSnapshotAuthFunc()
+	if err != nil {
+		return params.VolumeSnapshotResults{}, common.ServerError(common.ErrPerm)
+	}
+	results := params.VolumeSnapshotResults{
+		Results: make([]params.VolumeSnapshotResult, len(args.Ids)),
+	}
+	one := func(id string) (params.VolumeSnapshot, error) {
+		snapshot, err := s.oneVolumeSnapshot(id, canAccess)
+		if err != nil {
+			return params.VolumeSnapshot{}, err
+		}
+		return common.VolumeSnapshotFromState(snapshot)
+	}
+	for i, id := range args.Ids {
+		var result params.VolumeSnapshotResult
+		snapshot, err := one(id)
+		if err != nil {
+			result.Error = common.ServerError(err)
+		} else {
+			result.Result = snapshot
+		}
+		results.Results[i] = result
+	}
+	return results, nil
+}
+
+// VolumeSnapshotParams returns the parameters for taking, or destroying,
+// the volume snapshots with the specified IDs.
+func (s *StorageProvisionerAPI) VolumeSnapshotParams(args params.VolumeSnapshotIds) (params.VolumeSnapshotParamsResults, error) {
+	canAccess, err := s.getSnapshotAuthFunc()
+	if err != nil {
+		return params.VolumeSnapshotParamsResults{}, err
+	}
+	envConfig, err := s.st.EnvironConfig()
+	if err != nil {
+		return params.VolumeSnapshotParamsResults{}, err
+	}
+	results := params.VolumeSnapshotParamsResults{
+		Results: make([]params.VolumeSnapshotParamsResult, len(args.Ids)),
+	}
+	poolManager := poolmanager.New(s.settings)
+	one := func(id string) (params.VolumeSnapshotParams, error) {
+		snapshot, err := s.oneVolumeSnapshot(id, canAccess)
+		if err != nil {
+			return params.VolumeSnapshotParams{}, err
+		}
+		volume, err := s.st.Volume(snapshot.Volume())
+		if err != nil {
+			return params.VolumeSnapshotParams{}, err
+		}
+		return common.VolumeSnapshotParams(snapshot, volume, envConfig, poolManager)
+	}
+	for i, id := range args.Ids {
+		var result params.VolumeSnapshotParamsResult
+		snapshotParams, err := one(id)
+		if err != nil {
+			result.Error = common.ServerError(err)
+		} else {
+			result.Result = snapshotParams
+		}
+		results.Results[i] = result
+	}
+	return results, nil
+}
+
+// SetVolumeSnapshotInfo records the details of newly taken volume
+// snapshots.
+func (s *StorageProvisionerAPI) SetVolumeSnapshotInfo(args params.VolumeSnapshots) (params.ErrorResults, error) {
+	canAccess, err := s.getSnapshotAuthFunc()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Snapshots)),
+	}
+	one := func(arg params.VolumeSnapshot) error {
+		id, info, err := common.VolumeSnapshotToState(arg)
+		if err != nil {
+			return errors.Trace(err)
+		} else if !canAccess(id) {
+			return common.ErrPerm
+		}
+		err = s.st.SetVolumeSnapshotInfo(id, info)
+		if errors.IsNotFound(err) {
+			return common.ErrPerm
+		}
+		return errors.Trace(err)
+	}
+	for i, arg := range args.Snapshots {
+		err := one(arg)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// RemoveVolumeSnapshots removes the volume snapshots with the specified
+// IDs from state. The snapshots must not be Alive.
+func (s *StorageProvisionerAPI) RemoveVolumeSnapshots(args params.VolumeSnapshotIds) (params.ErrorResults, error) {
+	canAccess, err := s.getSnapshotAuthFunc()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Ids)),
+	}
+	one := func(id string) error {
+		if !canAccess(id) {
+			return common.ErrPerm
+		}
+		return s.st.RemoveVolumeSnapshot(id)
+	}
+	for i, id := range args.Ids {
+		err := one(id)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (s *StorageProvisionerAPI) oneVolumeSnapshot(
+	id string, canAccess func(string) bool,
+) (state.VolumeSnapshot, error) {
+	if !canAccess(id) {
+		return nil, common.ErrPerm
+	}
+	snapshot, err := s.st.VolumeSnapshot(id)
+	if errors.IsNotFound(err) {
+		return nil, common.ErrPerm
+	} else if err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// VolumeResizeParams returns the parameters for resizing the volumes
+// with the specified tags. If there is no pending request to resize a
+// volume, a NotFound error is returned for it.
+func (s *StorageProvisionerAPI) VolumeResizeParams(args params.Entities) (params.VolumeResizeParamsResults, error) {
+	canAccess, err := s.getStorageEntityAuthFunc()
+	if err != nil {
+		return params.VolumeResizeParamsResults{}, err
+	}
+	results := params.VolumeResizeParamsResults{
+		Results: make([]params.VolumeResizeParamsResult, len(args.Entities)),
+	}
+	poolManager := poolmanager.New(s.settings)
+	one := func(arg params.Entity) (params.VolumeResizeParams, error) {
+		tag, err := names.ParseVolumeTag(arg.Tag)
+		if err != nil || !canAccess(tag) {
+			return params.VolumeResizeParams{}, common.ErrPerm
+		}
+		volume, err := s.st.Volume(tag)
+		if errors.IsNotFound(err) {
+			return params.VolumeResizeParams{}, common.ErrPerm
+		} else if err != nil {
+			return params.VolumeResizeParams{}, err
+		}
+		// NotFound errors are passed through, so that the storage
+		// provisioner can tell when a resize has been completed.
+		size, err := s.st.PendingVolumeResize(tag)
+		if err != nil {
+			return params.VolumeResizeParams{}, err
+		}
+		return common.VolumeResizeParams(volume, size, poolManager)
+	}
+	for i, arg := range args.Entities {
+		var result params.VolumeResizeParamsResult
+		resizeParams, err := one(arg)
+		if err != nil {
+			result.Error = common.ServerError(err)
+		} else {
+			result.Result = resizeParams
+		}
+		results.Results[i] = result
+	}
+	return results, nil
+}
+
+// SetVolumesResized records the new sizes of resized volumes.
+func (s *StorageProvisionerAPI) SetVolumesResized(args params.VolumeSizes) (params.ErrorResults, error) {
+	canAccess, err := s.getStorageEntityAuthFunc()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Sizes)),
+	}
+	one := func(arg params.VolumeSize) error {
+		tag, err := names.ParseVolumeTag(arg.VolumeTag)
+		if err != nil || !canAccess(tag) {
+			return common.ErrPerm
+		}
+		err = s.st.SetVolumeResized(tag, arg.Size)
+		if errors.IsNotFound(err) {
+			return common.ErrPerm
+		}
+		return errors.Trace(err)
+	}
+	for i, arg := range args.Sizes {
+		err := one(arg)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
@@ -426,6 +426,30 @@ func (s *provisionerSuite) TestFilesystemParams(c *gc.C) {
 	})
 }
 
+func (s *provisionerSuite) TestFilesystemDestroyParams(c *gc.C) {
+	s.setupFilesystems(c)
+	results, err := s.api.FilesystemDestroyParams(params.Entities{
+		Entities: []params.Entity{{"filesystem-0-0"}, {"filesystem-1"}, {"filesystem-2"}, {"filesystem-42"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.FilesystemDestroyParamsResults{
+		Results: []params.FilesystemDestroyParamsResult{
+			{Result: params.FilesystemDestroyParams{
+				FilesystemTag: "filesystem-0-0",
+				FilesystemId:  "abc",
+				Provider:      "machinescoped",
+			}},
+			{Error: &params.Error{`filesystem "1" not provisioned`, params.CodeNotProvisioned}},
+			{Result: params.FilesystemDestroyParams{
+				FilesystemTag: "filesystem-2",
+				FilesystemId:  "def",
+				Provider:      "environscoped",
+			}},
+			{Error: &params.Error{"permission denied", "unauthorized access"}},
+		},
+	})
+}
+
 func (s *provisionerSuite) TestVolumeAttachmentParams(c *gc.C) {
 	s.setupVolumes(c)
 	s.authorizer.EnvironManager = true
@@ -608,6 +632,37 @@ func (s *provisionerSuite) TestSetVolumeAttachmentInfo(c *gc.C) {
 	})
 }
 
+func (s *provisionerSuite) TestSetFilesystemInfoProvisioned(c *gc.C) {
+	s.setupFilesystems(c)
+	s.authorizer.EnvironManager = true
+
+	// Filesystem "2" is already provisioned; its size
+	// may be refreshed without knowing its pool.
+	results, err := s.api.SetFilesystemInfo(params.Filesystems{
+		Filesystems: []params.Filesystem{{
+			FilesystemTag: "filesystem-2",
+			Info: params.FilesystemInfo{
+				FilesystemId: "def",
+				Size:         8192,
+			},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{{}},
+	})
+
+	filesystem, err := s.State.Filesystem(names.NewFilesystemTag("2"))
+	c.Assert(err, jc.ErrorIsNil)
+	info, err := filesystem.Info()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info, jc.DeepEquals, state.FilesystemInfo{
+		FilesystemId: "def",
+		Size:         8192,
+		Pool:         "environscoped",
+	})
+}
+
 func (s *provisionerSuite) TestSetFilesystemAttachmentInfo(c *gc.C) {
 	s.setupFilesystems(c)
 	s.authorizer.EnvironManager = true
@@ -1025,6 +1080,90 @@ func (s *provisionerSuite) TestEnsureDead(c *gc.C) {
 	})
 }
 
+func (s *provisionerSuite) TestRemoveAttachment(c *gc.C) {
+	s.setupFilesystems(c)
+	err := s.State.DestroyFilesystem(names.NewFilesystemTag("0/0"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.api.RemoveAttachment(params.MachineStorageIds{
+		Ids: []params.MachineStorageId{{
+			MachineTag:    "machine-0",
+			AttachmentTag: "filesystem-0-0",
+		}, {
+			MachineTag:    "machine-0",
+			AttachmentTag: "filesystem-1",
+		}, {
+			MachineTag:    "machine-0",
+			AttachmentTag: "volume-1",
+		}, {
+			MachineTag:    "machine-2",
+			AttachmentTag: "filesystem-3",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: `cannot remove attachment of filesystem "1" from machine "0": filesystem attachment is not dying`}},
+			{Error: common.ServerError(errors.NotSupportedf("removing volume attachment"))},
+			{Error: &params.Error{Message: `cannot remove attachment of filesystem "3" from machine "2": filesystem attachment is not dying`}},
+		},
+	})
+
+	// Removing the last attachment of the Dying filesystem made it Dead.
+	_, err = s.State.FilesystemAttachment(names.NewMachineTag("0"), names.NewFilesystemTag("0/0"))
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	filesystem, err := s.State.Filesystem(names.NewFilesystemTag("0/0"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(filesystem.Life(), gc.Equals, state.Dead)
+}
+
+func (s *provisionerSuite) TestEnsureDeadFilesystems(c *gc.C) {
+	s.setupFilesystems(c)
+	err := s.State.DestroyFilesystem(names.NewFilesystemTag("0/0"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{{"filesystem-0-0"}, {"filesystem-1"}, {"filesystem-42"}}}
+	result, err := s.api.EnsureDead(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{Error: &params.Error{Message: `cannot ensure filesystem "0/0" dead: filesystem is still attached`}},
+			{Error: &params.Error{Message: `cannot ensure filesystem "1" dead: filesystem is alive`}},
+			{Error: common.ServerError(errors.NotFoundf(`filesystem "42"`))},
+		},
+	})
+	filesystem, err := s.State.Filesystem(names.NewFilesystemTag("0/0"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(filesystem.Life(), gc.Equals, state.Dying)
+}
+
+func (s *provisionerSuite) TestRemoveFilesystems(c *gc.C) {
+	s.setupFilesystems(c)
+	err := s.State.DestroyFilesystem(names.NewFilesystemTag("0/0"))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.api.RemoveAttachment(params.MachineStorageIds{
+		Ids: []params.MachineStorageId{{
+			MachineTag:    "machine-0",
+			AttachmentTag: "filesystem-0-0",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{{"filesystem-0-0"}, {"filesystem-1"}, {"filesystem-42"}}}
+	result, err := s.api.Remove(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: `cannot remove entity "filesystem-1": still alive`}},
+			{Error: common.ServerError(errors.NotFoundf(`filesystem "42"`))},
+		},
+	})
+	_, err = s.State.Filesystem(names.NewFilesystemTag("0/0"))
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
 func (s *provisionerSuite) TestWatchForEnvironConfigChanges(c *gc.C) {
 	result, err := s.api.WatchForEnvironConfigChanges()
 	c.Assert(err, jc.ErrorIsNil)
@@ -1056,6 +1195,224 @@ func (s *provisionerSuite) TestEnvironConfig(c *gc.C) {
 	c.Assert(result.Config, jc.DeepEquals, params.EnvironConfig(stateEnvironConfig.AllAttrs()))
 }
 
+func (s *provisionerSuite) setupVolumeSnapshots(c *gc.C) {
+	s.setupVolumes(c)
+	_, err := s.State.AddVolumeSnapshot(names.NewVolumeTag("0/0"))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddVolumeSnapshot(names.NewVolumeTag("2"))
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *provisionerSuite) TestWatchVolumeSnapshots(c *gc.C) {
+	s.setupVolumeSnapshots(c)
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+
+	args := params.Entities{Entities: []params.Entity{
+		{"machine-0"},
+		{s.State.EnvironTag().String()},
+		{"machine-42"}},
+	}
+	result, err := s.api.WatchVolumeSnapshots(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.StringsWatchResults{
+		Results: []params.StringsWatchResult{
+			{StringsWatcherId: "1", Changes: []string{"0/0"}},
+			{StringsWatcherId: "2", Changes: []string{"1"}},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+	c.Assert(s.resources.Count(), gc.Equals, 2)
+	defer statetesting.AssertStop(c, s.resources.Get("1"))
+	defer statetesting.AssertStop(c, s.resources.Get("2"))
+}
+
+func (s *provisionerSuite) TestVolumeSnapshotParams(c *gc.C) {
+	s.setupVolumeSnapshots(c)
+	results, err := s.api.VolumeSnapshotParams(params.VolumeSnapshotIds{
+		Ids: []string{"0/0", "1", "42", "foo"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	snapshotTags := map[string]string{
+		tags.JujuEnv: testing.EnvironmentTag.Id(),
+	}
+	c.Assert(results, jc.DeepEquals, params.VolumeSnapshotParamsResults{
+		Results: []params.VolumeSnapshotParamsResult{
+			{Result: params.VolumeSnapshotParams{
+				Id:        "0/0",
+				VolumeTag: "volume-0-0",
+				VolumeId:  "abc",
+				Provider:  "machinescoped",
+				Tags:      snapshotTags,
+			}},
+			{Result: params.VolumeSnapshotParams{
+				Id:        "1",
+				VolumeTag: "volume-2",
+				VolumeId:  "def",
+				Provider:  "environscoped",
+				Tags:      snapshotTags,
+			}},
+			{Error: &params.Error{"permission denied", "unauthorized access"}},
+			{Error: &params.Error{"permission denied", "unauthorized access"}},
+		},
+	})
+}
+
+func (s *provisionerSuite) TestSetVolumeSnapshotInfo(c *gc.C) {
+	s.setupVolumeSnapshots(c)
+	results, err := s.api.SetVolumeSnapshotInfo(params.VolumeSnapshots{
+		Snapshots: []params.VolumeSnapshot{{
+			Id:        "0/0",
+			VolumeTag: "volume-0-0",
+			Info:      params.VolumeSnapshotInfo{SnapshotId: "snap-abc", Size: 1024},
+		}, {
+			Id:   "42",
+			Info: params.VolumeSnapshotInfo{SnapshotId: "snap-def"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{"permission denied", "unauthorized access"}},
+		},
+	})
+
+	snapshots, err := s.api.VolumeSnapshots(params.VolumeSnapshotIds{
+		Ids: []string{"0/0", "1"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshots, jc.DeepEquals, params.VolumeSnapshotResults{
+		Results: []params.VolumeSnapshotResult{
+			{Result: params.VolumeSnapshot{
+				Id:        "0/0",
+				VolumeTag: "volume-0-0",
+				Info:      params.VolumeSnapshotInfo{SnapshotId: "snap-abc", Size: 1024},
+			}},
+			{Error: &params.Error{`volume snapshot "1" not provisioned`, params.CodeNotProvisioned}},
+		},
+	})
+}
+
+func (s *provisionerSuite) TestVolumeSnapshotLifeAndRemove(c *gc.C) {
+	s.setupVolumeSnapshots(c)
+	err := s.State.DestroyVolumeSnapshot("1")
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.VolumeSnapshotIds{Ids: []string{"0/0", "1", "42"}}
+	lifeResults, err := s.api.VolumeSnapshotLife(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(lifeResults, jc.DeepEquals, params.LifeResults{
+		Results: []params.LifeResult{
+			{Life: params.Alive},
+			{Life: params.Dying},
+			{Error: &params.Error{`volume snapshot "42" not found`, params.CodeNotFound}},
+		},
+	})
+
+	removeResults, err := s.api.RemoveVolumeSnapshots(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(removeResults, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{Error: &params.Error{Message: `cannot remove volume snapshot "0/0": volume snapshot is alive`}},
+			{},
+			{},
+		},
+	})
+	_, err = s.State.VolumeSnapshot("1")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *provisionerSuite) TestVolumeSnapshotsOtherMachine(c *gc.C) {
+	s.setupVolumeSnapshots(c)
+	s.authorizer.Tag = names.NewMachineTag("1")
+	s.authorizer.EnvironManager = false
+	results, err := s.api.VolumeSnapshotLife(params.VolumeSnapshotIds{
+		Ids: []string{"0/0", "1"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.LifeResults{
+		Results: []params.LifeResult{
+			{Error: &params.Error{"permission denied", "unauthorized access"}},
+			{Error: &params.Error{"permission denied", "unauthorized access"}},
+		},
+	})
+}
+
+func (s *provisionerSuite) setupVolumeResizes(c *gc.C) {
+	s.setupVolumes(c)
+	err := s.State.ResizeVolume(names.NewVolumeTag("0/0"), 2048)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.ResizeVolume(names.NewVolumeTag("2"), 8192)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *provisionerSuite) TestWatchVolumeResizes(c *gc.C) {
+	s.setupVolumeResizes(c)
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+
+	args := params.Entities{Entities: []params.Entity{
+		{"machine-0"},
+		{s.State.EnvironTag().String()},
+		{"machine-42"}},
+	}
+	result, err := s.api.WatchVolumeResizes(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.StringsWatchResults{
+		Results: []params.StringsWatchResult{
+			{StringsWatcherId: "1", Changes: []string{"0/0"}},
+			{StringsWatcherId: "2", Changes: []string{"2"}},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+	c.Assert(s.resources.Count(), gc.Equals, 2)
+	defer statetesting.AssertStop(c, s.resources.Get("1"))
+	defer statetesting.AssertStop(c, s.resources.Get("2"))
+}
+
+func (s *provisionerSuite) TestVolumeResizeParams(c *gc.C) {
+	s.setupVolumeResizes(c)
+	err := s.State.SetVolumeResized(names.NewVolumeTag("2"), 8192)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.api.VolumeResizeParams(params.Entities{
+		Entities: []params.Entity{{"volume-0-0"}, {"volume-2"}, {"volume-42"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.VolumeResizeParamsResults{
+		Results: []params.VolumeResizeParamsResult{
+			{Result: params.VolumeResizeParams{
+				VolumeTag: "volume-0-0",
+				VolumeId:  "abc",
+				Provider:  "machinescoped",
+				Size:      2048,
+			}},
+			{Error: &params.Error{`pending resize of volume "2" not found`, params.CodeNotFound}},
+			{Error: &params.Error{"permission denied", "unauthorized access"}},
+		},
+	})
+}
+
+func (s *provisionerSuite) TestSetVolumesResized(c *gc.C) {
+	s.setupVolumeResizes(c)
+	results, err := s.api.SetVolumesResized(params.VolumeSizes{
+		Sizes: []params.VolumeSize{
+			{VolumeTag: "volume-0-0", Size: 2048},
+			{VolumeTag: "volume-2", Size: 1024},
+			{VolumeTag: "volume-42", Size: 1024},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: `cannot set size of volume "2": cannot shrink volume from 4096MiB to 1024MiB`}},
+			{Error: &params.Error{"permission denied", "unauthorized access"}},
+		},
+	})
+	_, err = s.State.PendingVolumeResize(names.NewVolumeTag("0/0"))
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
 type byMachineAndEntity []params.MachineStorageId
 
 func (b byMachineAndEntity) Len() int {
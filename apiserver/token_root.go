@@ -0,0 +1,46 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/rpc"
+	"github.com/juju/juju/rpc/rpcreflect"
+)
+
+// tokenRoot restricts the calls that may be made by a user who logged
+// in with an API token. Such users may not manage the user's password or
+// API tokens, so that a token cannot be used to outlive its own expiry.
+type tokenRoot struct {
+	rpc.MethodFinder
+}
+
+// newTokenRoot returns a new tokenRoot.
+func newTokenRoot(finder rpc.MethodFinder) *tokenRoot {
+	return &tokenRoot{finder}
+}
+
+// credentialMethods holds the UserManager methods that manage user
+// credentials.
+var credentialMethods = set.NewStrings(
+	"CreateToken",
+	"RevokeTokens",
+	"SetPassword",
+)
+
+// FindMethod returns a permission error for calls that manage user
+// credentials.
+func (r *tokenRoot) FindMethod(rootName string, version int, methodName string) (rpcreflect.MethodCaller, error) {
+	caller, err := r.MethodFinder.FindMethod(rootName, version, methodName)
+	if err != nil {
+		return nil, err
+	}
+	if rootName == "UserManager" && credentialMethods.Contains(methodName) {
+		return nil, errors.Annotatef(common.ErrPerm, "cannot call %s.%s when logged in with a token", rootName, methodName)
+	}
+	return caller, nil
+}
@@ -0,0 +1,39 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver"
+	"github.com/juju/juju/testing"
+)
+
+type tokenRootSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&tokenRootSuite{})
+
+func (s *tokenRootSuite) TestAllowed(c *gc.C) {
+	root := apiserver.TestingTokenRoot(&fakeFinder{})
+	for _, method := range []string{"ListTokens", "UserInfo"} {
+		caller, err := root.FindMethod("UserManager", 0, method)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(caller, gc.NotNil)
+	}
+	caller, err := root.FindMethod("Client", 0, "ServiceDeploy")
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(caller, gc.NotNil)
+}
+
+func (s *tokenRootSuite) TestCredentialManagementDenied(c *gc.C) {
+	root := apiserver.TestingTokenRoot(&fakeFinder{})
+	for _, method := range []string{"CreateToken", "RevokeTokens", "SetPassword"} {
+		caller, err := root.FindMethod("UserManager", 0, method)
+		c.Check(err, gc.ErrorMatches, "cannot call UserManager."+method+" when logged in with a token: permission denied")
+		c.Check(caller, gc.IsNil)
+	}
+}
@@ -824,6 +824,7 @@ func (u *uniterBaseAPI) Actions(args params.Entities) (params.ActionsQueryResult
 		results.Results[i].Action.Action = &params.Action{
 			Name:       action.Name(),
 			Parameters: action.Parameters(),
+			Timeout:    action.Timeout(),
 		}
 	}
 
@@ -904,6 +905,10 @@ func paramsActionExecutionResultsToStateActionResults(arg params.ActionExecution
 		status = state.ActionFailed
 	case params.ActionPending:
 		status = state.ActionPending
+	case params.ActionAborted:
+		status = state.ActionAborted
+	case params.ActionTimedOut:
+		status = state.ActionTimedOut
 	default:
 		return state.ActionResults{}, errors.Errorf("unrecognized action status '%s'", arg.Status)
 	}
@@ -7,12 +7,16 @@
 package uniter
 
 import (
+	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/names"
+	"github.com/juju/utils"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
 )
 
 var logger = loggo.GetLogger("juju.apiserver.uniter")
@@ -61,6 +65,141 @@ func (u *UniterAPIV2) AddMetricBatches(args params.MetricBatchParams) (params.Er
 	return result, nil
 }
 
+// WatchActionAborts returns a NotifyWatcher for each of the given
+// running Actions, which notifies when the Action is asked to stop.
+func (u *UniterAPIV2) WatchActionAborts(args params.Entities) (params.NotifyWatchResults, error) {
+	nothing := params.NotifyWatchResults{}
+
+	actionFn, err := u.authAndActionFromTagFn()
+	if err != nil {
+		return nothing, err
+	}
+
+	results := params.NotifyWatchResults{
+		Results: make([]params.NotifyWatchResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		action, err := actionFn(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		watch := action.Watch()
+		// Consume the initial event. Technically, API
+		// calls to Watch 'transmit' the initial event
+		// in the Watch response. But NotifyWatchers
+		// have no state to transmit.
+		if _, ok := <-watch.Changes(); ok {
+			results.Results[i].NotifyWatcherId = u.resources.Register(watch)
+		} else {
+			results.Results[i].Error = common.ServerError(watcher.EnsureErr(watch))
+		}
+	}
+	return results, nil
+}
+
+// ActionsAborting reports, for each of the given Actions, whether it
+// has been asked to stop while it is running.
+func (u *UniterAPIV2) ActionsAborting(args params.Entities) (params.BoolResults, error) {
+	nothing := params.BoolResults{}
+
+	actionFn, err := u.authAndActionFromTagFn()
+	if err != nil {
+		return nothing, err
+	}
+
+	results := params.BoolResults{
+		Results: make([]params.BoolResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		action, err := actionFn(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = action.Aborting()
+	}
+	return results, nil
+}
+
+// AddOutput records chunks of the output of running Actions, and of
+// commands run on the unit, as it is produced. The id of each Output
+// is either the tag of an Action that is running on the unit, or the
+// output id that was given when the commands were run.
+func (u *UniterAPIV2) AddOutput(args params.Outputs) (params.ErrorResults, error) {
+	nothing := params.ErrorResults{}
+
+	actionFn, err := u.authAndActionFromTagFn()
+	if err != nil {
+		return nothing, err
+	}
+	unitTag, ok := u.auth.GetAuthTag().(names.UnitTag)
+	if !ok {
+		return nothing, common.ErrPerm
+	}
+
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Outputs)),
+	}
+	for i, output := range args.Outputs {
+		outputId := output.Id
+		if _, err := names.ParseActionTag(output.Id); err == nil {
+			action, err := actionFn(output.Id)
+			if err != nil {
+				results.Results[i].Error = common.ServerError(err)
+				continue
+			}
+			outputId = action.Id()
+		} else if !utils.IsValidUUIDString(output.Id) {
+			results.Results[i].Error = common.ServerError(errors.NotValidf("output id %q", output.Id))
+			continue
+		}
+		chunks := make([]state.OutputChunk, len(output.Chunks))
+		for j, chunk := range output.Chunks {
+			chunks[j] = state.OutputChunk{
+				Seq:    chunk.Seq,
+				Stream: chunk.Stream,
+				Data:   chunk.Data,
+				Time:   chunk.Time,
+			}
+		}
+		err := u.st.AddOutput(outputId, unitTag, chunks)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// BindingAddresses returns, for each given unit endpoint, the address
+// the unit should advertise on that endpoint. That is the unit's address
+// in the space the endpoint is bound to, or its private address if the
+// endpoint is not bound.
+func (u *UniterAPIV2) BindingAddresses(args params.UnitEndpoints) (params.StringResults, error) {
+	result := params.StringResults{
+		Results: make([]params.StringResult, len(args.Endpoints)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.StringResults{}, err
+	}
+	for i, endpoint := range args.Endpoints {
+		tag, err := names.ParseUnitTag(endpoint.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		err = common.ErrPerm
+		if canAccess(tag) {
+			var unit *state.Unit
+			unit, err = u.getUnit(tag)
+			if err == nil {
+				result.Results[i].Result, err = unit.BindingAddress(endpoint.Endpoint)
+			}
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
 // NewUniterAPIV2 creates a new instance of the Uniter API, version 2.
 func NewUniterAPIV2(st *state.State, resources *common.Resources, authorizer common.Authorizer) (*UniterAPIV2, error) {
 	baseAPI, err := NewUniterAPIV1(st, resources, authorizer)
@@ -76,3 +215,87 @@ func NewUniterAPIV2(st *state.State, resources *common.Resources, authorizer com
 		StorageAPI:  *storageAPI,
 	}, nil
 }
+
+// HookRetryPolicies returns the policies by which the failed hooks of
+// the given units are retried automatically.
+func (u *UniterAPIV2) HookRetryPolicies(args params.Entities) (params.HookRetryPolicyResults, error) {
+	result := params.HookRetryPolicyResults{
+		Results: make([]params.HookRetryPolicyResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.HookRetryPolicyResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		if !canAccess(tag) {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		policy, err := u.hookRetryPolicy(tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result.Results[i].Result = params.HookRetryPolicy{
+			Attempts: policy.Attempts,
+			Delay:    policy.Delay,
+			MaxDelay: policy.MaxDelay,
+		}
+	}
+	return result, nil
+}
+
+func (u *UniterAPIV2) hookRetryPolicy(tag names.UnitTag) (config.HookRetryPolicy, error) {
+	unit, err := u.getUnit(tag)
+	if err != nil {
+		return config.HookRetryPolicy{}, err
+	}
+	service, err := unit.Service()
+	if err != nil {
+		return config.HookRetryPolicy{}, err
+	}
+	return service.HookRetryPolicy()
+}
+
+// SetHealthChecks records the results of the health checks declared by
+// the charms of the given units.
+func (u *UniterAPIV2) SetHealthChecks(args params.SetHealthChecks) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Units)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	for i, arg := range args.Units {
+		tag, err := names.ParseUnitTag(arg.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		err = common.ErrPerm
+		if canAccess(tag) {
+			var unit *state.Unit
+			unit, err = u.getUnit(tag)
+			if err == nil {
+				checks := make([]state.HealthCheckResult, len(arg.Checks))
+				for j, check := range arg.Checks {
+					checks[j] = state.HealthCheckResult{
+						Name:    check.Name,
+						Healthy: check.Healthy,
+						Message: check.Message,
+						Since:   check.Since,
+					}
+				}
+				err = unit.SetHealthChecks(checks)
+			}
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
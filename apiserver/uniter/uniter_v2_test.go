@@ -15,7 +15,10 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/apiserver/uniter"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
 	"github.com/juju/juju/testing/factory"
 )
 
@@ -274,3 +277,197 @@ func (s *unitMetricBatchesSuite) TestAddMetricsBatchDiffTag(c *gc.C) {
 		c.Assert(err, jc.Satisfies, errors.IsNotFound)
 	}
 }
+
+func (s *uniterV2Suite) TestWatchActionAborts(c *gc.C) {
+	action, err := s.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	action, err = action.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	otherAction, err := s.mysqlUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: action.Tag().String()},
+		{Tag: otherAction.Tag().String()},
+	}}
+	result, err := s.uniter.WatchActionAborts(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.NotifyWatchResults{
+		Results: []params.NotifyWatchResult{
+			{NotifyWatcherId: "1"},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	// Verify the resource was registered and stop when done
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	resource := s.resources.Get("1")
+	defer statetesting.AssertStop(c, resource)
+
+	// Check that the Watch has consumed the initial event, and
+	// notifies when the action is aborted.
+	wc := statetesting.NewNotifyWatcherC(c, s.State, resource.(state.NotifyWatcher))
+	wc.AssertNoChange()
+
+	aborting, err := s.uniter.ActionsAborting(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(aborting, gc.DeepEquals, params.BoolResults{
+		Results: []params.BoolResult{
+			{Result: false},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	_, err = action.Abort()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	aborting, err = s.uniter.ActionsAborting(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(aborting.Results[0], gc.DeepEquals, params.BoolResult{Result: true})
+}
+
+func (s *uniterV2Suite) TestAddOutput(c *gc.C) {
+	action, err := s.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	otherAction, err := s.mysqlUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	now := time.Date(2015, 6, 1, 2, 0, 0, 0, time.UTC)
+	chunks := []params.OutputChunk{{
+		Seq:    1,
+		Stream: "stdout",
+		Data:   "hello\n",
+		Time:   now,
+	}}
+	runOutputId := "c8a71ff5-4e8c-4ed7-8c45-ad5f3c8f9b4d"
+	result, err := s.uniter.AddOutput(params.Outputs{Outputs: []params.Output{
+		{Id: action.Tag().String(), Chunks: chunks},
+		{Id: otherAction.Tag().String(), Chunks: chunks},
+		{Id: runOutputId, Chunks: chunks},
+		{Id: "foo", Chunks: chunks},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: apiservertesting.ErrUnauthorized},
+			{},
+			{Error: &params.Error{Message: `output id "foo" not valid`}},
+		},
+	})
+
+	expected := []state.OutputChunk{{
+		Unit:   s.wordpressUnit.Name(),
+		Seq:    1,
+		Stream: "stdout",
+		Data:   "hello\n",
+		Time:   now,
+	}}
+	output, err := s.State.Output(action.Id(), nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(output, jc.DeepEquals, expected)
+	output, err = s.State.Output(runOutputId, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(output, jc.DeepEquals, expected)
+	output, err = s.State.Output(otherAction.Id(), nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(output, gc.HasLen, 0)
+}
+
+func (s *uniterV2Suite) TestBindingAddresses(c *gc.C) {
+	_, err := s.State.AddSubnet(state.SubnetInfo{CIDR: "10.0.0.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSpace("db", []string{"10.0.0.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.wordpress.SetEndpointBindings(map[string]string{"db": "db"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine0.SetProviderAddresses(
+		network.NewScopedAddress("1.2.3.4", network.ScopeCloudLocal),
+		network.NewScopedAddress("10.0.0.4", network.ScopeCloudLocal),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.uniter.BindingAddresses(params.UnitEndpoints{
+		Endpoints: []params.UnitEndpoint{
+			{Tag: "unit-wordpress-0", Endpoint: "db"},
+			{Tag: "unit-wordpress-0", Endpoint: "url"},
+			{Tag: "unit-wordpress-0", Endpoint: "nonsense"},
+			{Tag: "unit-mysql-0", Endpoint: "server"},
+			{Tag: "service-wordpress", Endpoint: "db"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.StringResults{
+		Results: []params.StringResult{
+			{Result: "10.0.0.4"},
+			{Result: "1.2.3.4"},
+			{Error: &params.Error{
+				Code:    params.CodeNotFound,
+				Message: `endpoint "nonsense" of service "wordpress" not found`,
+			}},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+}
+
+func (s *uniterV2Suite) TestSetHealthChecks(c *gc.C) {
+	since := time.Date(2015, 7, 1, 12, 0, 0, 0, time.UTC)
+	checks := []params.HealthCheckResult{{
+		Name:    "http",
+		Message: "connection refused",
+		Since:   since,
+	}}
+	result, err := s.uniter.SetHealthChecks(params.SetHealthChecks{
+		Units: []params.UnitHealthChecks{
+			{Tag: "unit-wordpress-0", Checks: checks},
+			{Tag: "unit-mysql-0", Checks: checks},
+			{Tag: "service-wordpress", Checks: checks},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{nil},
+			{apiservertesting.ErrUnauthorized},
+			{apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	results, err := s.wordpressUnit.HealthChecks()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []state.HealthCheckResult{{
+		Name:    "http",
+		Message: "connection refused",
+		Since:   since,
+	}})
+	results, err = s.mysqlUnit.HealthChecks()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 0)
+}
+
+func (s *uniterV2Suite) TestHookRetryPolicies(c *gc.C) {
+	err := s.wordpress.SetHookRetryPolicy(&config.HookRetryPolicy{
+		Attempts: 3,
+		Delay:    time.Minute,
+		MaxDelay: time.Hour,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.uniter.HookRetryPolicies(params.Entities{Entities: []params.Entity{
+		{Tag: "unit-wordpress-0"},
+		{Tag: "unit-mysql-0"},
+		{Tag: "service-wordpress"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.HookRetryPolicyResults{
+		Results: []params.HookRetryPolicyResult{
+			{Result: params.HookRetryPolicy{Attempts: 3, Delay: time.Minute, MaxDelay: time.Hour}},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+}
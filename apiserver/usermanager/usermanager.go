@@ -22,8 +22,11 @@ func init() {
 // UserManager defines the methods on the usermanager API end point.
 type UserManager interface {
 	AddUser(args params.AddUsers) (params.AddUserResults, error)
+	CreateToken(args params.CreateToken) (params.CreateTokenResult, error)
 	DisableUser(args params.Entities) (params.ErrorResults, error)
 	EnableUser(args params.Entities) (params.ErrorResults, error)
+	ListTokens() (params.UserTokenResults, error)
+	RevokeTokens(args params.RevokeTokens) (params.ErrorResults, error)
 	SetPassword(args params.EntityPasswords) (params.ErrorResults, error)
 	UserInfo(args params.UserInfoRequest) (params.UserInfoResults, error)
 }
@@ -248,6 +251,95 @@ func (api *UserManagerAPI) SetPassword(args params.EntityPasswords) (params.Erro
 	return result, nil
 }
 
+// CreateToken creates an API token for the logged in user.
+func (api *UserManagerAPI) CreateToken(args params.CreateToken) (params.CreateTokenResult, error) {
+	var result params.CreateTokenResult
+	if err := api.check.ChangeAllowed(); err != nil {
+		return result, errors.Trace(err)
+	}
+	user, err := api.getLoggedInLocalUser()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	access := state.AdminAccess
+	if args.Access != "" {
+		access = state.Access(args.Access)
+	}
+	token, credential, err := user.CreateToken(args.ExpiresIn, access)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	logger.Infof("created token %q for user %q", token.ID(), user.Name())
+	result.UserToken = userTokenToParams(token)
+	result.Credential = credential
+	return result, nil
+}
+
+// ListTokens returns the API tokens of the logged in user.
+func (api *UserManagerAPI) ListTokens() (params.UserTokenResults, error) {
+	var result params.UserTokenResults
+	user, err := api.getLoggedInLocalUser()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	tokens, err := user.Tokens()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Tokens = make([]params.UserToken, len(tokens))
+	for i, token := range tokens {
+		result.Tokens[i] = userTokenToParams(token)
+	}
+	return result, nil
+}
+
+// RevokeTokens revokes API tokens of the logged in user.
+func (api *UserManagerAPI) RevokeTokens(args params.RevokeTokens) (params.ErrorResults, error) {
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.IDs)),
+	}
+	if len(args.IDs) == 0 {
+		return result, nil
+	}
+	user, err := api.getLoggedInLocalUser()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	for i, id := range args.IDs {
+		if err := user.RevokeToken(id); err != nil {
+			result.Results[i].Error = common.ServerError(err)
+		}
+	}
+	return result, nil
+}
+
+func userTokenToParams(token *state.UserToken) params.UserToken {
+	return params.UserToken{
+		ID:       token.ID(),
+		Access:   string(token.Access()),
+		Created:  token.Created(),
+		Expires:  token.Expires(),
+		LastUsed: token.LastUsed(),
+	}
+}
+
+// getLoggedInLocalUser returns the logged in user, who must be a local
+// user, as only local users may have API tokens.
+func (api *UserManagerAPI) getLoggedInLocalUser() (*state.User, error) {
+	tag, err := api.getLoggedInUser()
+	if err != nil {
+		return nil, common.ErrPerm
+	}
+	user, err := api.state.User(tag)
+	if err != nil {
+		return nil, errors.Wrap(err, common.ErrPerm)
+	}
+	return user, nil
+}
+
 func (api *UserManagerAPI) getLoggedInUser() (names.UserTag, error) {
 	switch tag := api.authorizer.GetAuthTag().(type) {
 	case names.UserTag:
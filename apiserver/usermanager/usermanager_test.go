@@ -4,6 +4,8 @@
 package usermanager_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/names"
 	jc "github.com/juju/testing/checkers"
@@ -14,6 +16,7 @@ import (
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/apiserver/usermanager"
 	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/state"
 	"github.com/juju/juju/testing/factory"
 )
 
@@ -481,3 +484,91 @@ func (s *userManagerSuite) TestSetPasswordForOther(c *gc.C) {
 
 	c.Assert(barb.PasswordValid("new-password"), jc.IsFalse)
 }
+
+func (s *userManagerSuite) TestCreateToken(c *gc.C) {
+	alex := s.Factory.MakeUser(c, &factory.UserParams{Name: "alex"})
+	usermanager, err := usermanager.NewUserManagerAPI(
+		s.State, nil, apiservertesting.FakeAuthorizer{Tag: alex.Tag()})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := usermanager.CreateToken(params.CreateToken{
+		ExpiresIn: 24 * time.Hour,
+		Access:    "read",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Access, gc.Equals, "read")
+	c.Assert(result.Expires.Sub(result.Created), gc.Equals, 24*time.Hour)
+	c.Assert(result.LastUsed, gc.IsNil)
+
+	id, secret, ok := state.ParseTokenCredential(result.Credential)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(id, gc.Equals, result.ID)
+	c.Assert(alex.TokenValid(id, secret), jc.IsTrue)
+}
+
+func (s *userManagerSuite) TestCreateTokenDefaultsToUserAccess(c *gc.C) {
+	result, err := s.usermanager.CreateToken(params.CreateToken{ExpiresIn: time.Hour})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Access, gc.Equals, "admin")
+}
+
+func (s *userManagerSuite) TestCreateTokenInvalid(c *gc.C) {
+	_, err := s.usermanager.CreateToken(params.CreateToken{ExpiresIn: -time.Hour})
+	c.Assert(err, gc.ErrorMatches, "token expiry -1h0m0s not valid")
+	_, err = s.usermanager.CreateToken(params.CreateToken{ExpiresIn: time.Hour, Access: "root"})
+	c.Assert(err, gc.ErrorMatches, `access level "root" not valid`)
+}
+
+func (s *userManagerSuite) TestCreateTokenRemoteUser(c *gc.C) {
+	usermanager, err := usermanager.NewUserManagerAPI(
+		s.State, nil, apiservertesting.FakeAuthorizer{Tag: names.NewUserTag("bob@remote")})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = usermanager.CreateToken(params.CreateToken{ExpiresIn: time.Hour})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *userManagerSuite) TestBlockCreateToken(c *gc.C) {
+	s.BlockAllChanges(c, "TestBlockCreateToken")
+	_, err := s.usermanager.CreateToken(params.CreateToken{ExpiresIn: time.Hour})
+	s.AssertBlocked(c, err, "TestBlockCreateToken")
+}
+
+func (s *userManagerSuite) TestListAndRevokeTokens(c *gc.C) {
+	alex := s.Factory.MakeUser(c, &factory.UserParams{Name: "alex"})
+	token, _, err := alex.CreateToken(time.Hour, state.WriteAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	admin, err := s.State.User(s.AdminUserTag(c))
+	c.Assert(err, jc.ErrorIsNil)
+	adminToken, _, err := admin.CreateToken(time.Hour, state.ReadAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	usermanager, err := usermanager.NewUserManagerAPI(
+		s.State, nil, apiservertesting.FakeAuthorizer{Tag: alex.Tag()})
+	c.Assert(err, jc.ErrorIsNil)
+
+	tokens, err := usermanager.ListTokens()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tokens, jc.DeepEquals, params.UserTokenResults{
+		Tokens: []params.UserToken{{
+			ID:      token.ID(),
+			Access:  "write",
+			Created: token.Created(),
+			Expires: token.Expires(),
+		}},
+	})
+
+	results, err := usermanager.RevokeTokens(params.RevokeTokens{
+		IDs: []string{token.ID(), adminToken.ID()},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `cannot revoke token: token ".*" for user "alex" not found`)
+
+	tokens, err = usermanager.ListTokens()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tokens.Tokens, gc.HasLen, 0)
+
+	_, err = s.State.UserToken(adminToken.ID())
+	c.Assert(err, jc.ErrorIsNil)
+}
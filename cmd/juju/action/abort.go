@@ -0,0 +1,77 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// AbortCommand stops pending or running Actions by ID.
+type AbortCommand struct {
+	ActionCommandBase
+	out          cmd.Output
+	requestedIds []string
+}
+
+const abortDoc = `
+Abort the actions with the given IDs.  Partial IDs may also be used, as long
+as each matches exactly one action.  Pending actions are cancelled before they
+run.  Running actions are stopped by the unit running them, and recorded as
+aborted along with any output they produced before they were stopped.
+`
+
+// Set up the output.
+func (c *AbortCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "smart", cmd.DefaultFormatters)
+}
+
+func (c *AbortCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "abort",
+		Args:    "<action ID> [<action ID>...]",
+		Purpose: "abort pending or running actions",
+		Doc:     abortDoc,
+	}
+}
+
+// Init checks that at least one action ID was given.
+func (c *AbortCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no action ID specified")
+	}
+	c.requestedIds = args
+	return nil
+}
+
+// Run resolves the given IDs and issues the API call to abort the
+// matching Actions.
+func (c *AbortCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	entities := []params.Entity{}
+	for _, requestedId := range c.requestedIds {
+		tag, err := getActionTagByPrefix(api, requestedId)
+		if err != nil {
+			return err
+		}
+		entities = append(entities, params.Entity{Tag: tag.String()})
+	}
+
+	results, err := api.Abort(params.Entities{Entities: entities})
+	if err != nil {
+		return err
+	}
+	if len(results.Results) != len(entities) {
+		return errors.Errorf("expected %d results, got %d", len(entities), len(results.Results))
+	}
+	return c.out.Write(ctx, resultsToMap(results.Results))
+}
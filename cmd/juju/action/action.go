@@ -8,6 +8,7 @@ import (
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/names"
 	"gopkg.in/juju/charm.v5"
 
 	"github.com/juju/juju/api/action"
@@ -32,9 +33,12 @@ func NewSuperCommand() cmd.Command {
 			UsagePrefix: "juju",
 			Purpose:     actionPurpose,
 		})
+	actionCmd.Register(envcmd.Wrap(&AbortCommand{}))
+	actionCmd.Register(envcmd.Wrap(&CancelCommand{}))
 	actionCmd.Register(envcmd.Wrap(&DefinedCommand{}))
 	actionCmd.Register(envcmd.Wrap(&DoCommand{}))
 	actionCmd.Register(envcmd.Wrap(&FetchCommand{}))
+	actionCmd.Register(newScheduleSuperCommand())
 	actionCmd.Register(envcmd.Wrap(&StatusCommand{}))
 	return actionCmd
 }
@@ -64,13 +68,33 @@ type APIClient interface {
 	// Entities.
 	ListCompleted(params.Entities) (params.ActionsByReceivers, error)
 
-	// Cancel attempts to cancel a queued up Action from running.
-	Cancel(params.Actions) (params.ActionResults, error)
+	// Cancel takes a list of ActionTags and attempts to cancel each of
+	// those queued up Actions from running.
+	Cancel(params.Entities) (params.ActionResults, error)
+
+	// Abort takes a list of ActionTags and attempts to stop each of
+	// those Actions, whether they are queued up or already running.
+	Abort(params.Entities) (params.ActionResults, error)
+
+	// AddSchedules adds schedules on which Actions are queued up
+	// repeatedly.
+	AddSchedules(params.ActionSchedules) (params.ErrorResults, error)
+
+	// ListSchedules returns all of the action schedules in the
+	// environment, with their most recent runs.
+	ListSchedules() ([]params.ActionSchedule, error)
+
+	// RemoveSchedules removes the action schedules with the given names.
+	RemoveSchedules(params.ActionScheduleNames) (params.ErrorResults, error)
 
 	// ServiceCharmActions is a single query which uses ServicesCharmActions to
 	// get the charm.Actions for a single Service by tag.
 	ServiceCharmActions(params.Entity) (*charm.Actions, error)
 
+	// ServiceUnits returns the tags of the alive units of a single
+	// Service by tag, in unit number order.
+	ServiceUnits(params.Entity) ([]names.UnitTag, error)
+
 	// Actions fetches actions by tag.  These Actions can be used to get
 	// the ActionReceiver if necessary.
 	Actions(params.Entities) (params.ActionResults, error)
@@ -78,6 +102,10 @@ type APIClient interface {
 	// FindActionTagsByPrefix takes a list of string prefixes and finds
 	// corresponding ActionTags that match that prefix.
 	FindActionTagsByPrefix(params.FindTags) (params.FindTagsResults, error)
+
+	// Output returns the chunks of output written by Actions, after
+	// the chunks already seen for each unit.
+	Output(params.OutputQueries) (params.OutputResults, error)
 }
 
 // ActionCommandBase is the base type for action sub-commands.
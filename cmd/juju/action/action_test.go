@@ -44,10 +44,13 @@ func (s *ActionCommandSuite) TestHelp(c *gc.C) {
 
 func (s *ActionCommandSuite) checkHelpSubCommands(c *gc.C, ctx *cmd.Context) {
 	var expectedSubCommmands = [][]string{
+		{"abort", "abort pending or running actions"},
+		{"cancel", "cancel pending actions"},
 		{"defined", "show actions defined for a service"},
 		{"do", "queue an action for execution"},
 		{"fetch", "show results of an action by ID"},
 		{"help", "show help on a command or other topic"},
+		{"schedule", "manage scheduled actions"},
 		{"status", "show results of all actions filtered by optional ID prefix"},
 	}
 
@@ -0,0 +1,77 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// CancelCommand cancels pending Actions by ID.
+type CancelCommand struct {
+	ActionCommandBase
+	out          cmd.Output
+	requestedIds []string
+}
+
+const cancelDoc = `
+Cancel the actions with the given IDs, so that they will not be run.  Partial
+IDs may also be used, as long as each matches exactly one action.  Only
+pending actions can be cancelled; actions that are already running or have
+completed are reported with their current status.
+`
+
+// Set up the output.
+func (c *CancelCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "smart", cmd.DefaultFormatters)
+}
+
+func (c *CancelCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "cancel",
+		Args:    "<action ID> [<action ID>...]",
+		Purpose: "cancel pending actions",
+		Doc:     cancelDoc,
+	}
+}
+
+// Init checks that at least one action ID was given.
+func (c *CancelCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no action ID specified")
+	}
+	c.requestedIds = args
+	return nil
+}
+
+// Run resolves the given IDs and issues the API call to cancel the
+// matching Actions.
+func (c *CancelCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	entities := []params.Entity{}
+	for _, requestedId := range c.requestedIds {
+		tag, err := getActionTagByPrefix(api, requestedId)
+		if err != nil {
+			return err
+		}
+		entities = append(entities, params.Entity{Tag: tag.String()})
+	}
+
+	results, err := api.Cancel(params.Entities{Entities: entities})
+	if err != nil {
+		return err
+	}
+	if len(results.Results) != len(entities) {
+		return errors.Errorf("expected %d results, got %d", len(entities), len(results.Results))
+	}
+	return c.out.Write(ctx, resultsToMap(results.Results))
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -19,22 +20,43 @@ import (
 
 var keyRule = regexp.MustCompile("^[a-z0-9](?:[a-z0-9-]*[a-z0-9])?$")
 
-// DoCommand enqueues an Action for running on the given unit with given
-// params
+// DoCommand enqueues an Action for running on the given unit, or on
+// every unit of the given service, with given params
 type DoCommand struct {
 	ActionCommandBase
-	unitTag      names.UnitTag
-	actionName   string
-	paramsYAML   cmd.FileVar
-	parseStrings bool
-	out          cmd.Output
-	args         [][]string
+	unitTag       names.UnitTag
+	serviceTag    names.ServiceTag
+	actionName    string
+	paramsYAML    cmd.FileVar
+	parseStrings  bool
+	batchSize     int
+	wait          bool
+	stopOnFailure bool
+	timeout       time.Duration
+	out           cmd.Output
+	args          [][]string
 }
 
+// waitInterval is the time between checks on the progress of Actions
+// that are being waited for.
+var waitInterval = 2 * time.Second
+
 const doDoc = `
 Queue an Action for execution on a given unit, with a given set of params.
 Displays the ID of the Action for use with 'juju kill', 'juju status', etc.
 
+If a service is given instead of a unit, the Action is queued on every unit
+of the service.  With --batch-size, the units are taken in batches of that
+size, and each batch is only started once every Action in the previous batch
+has finished.  With --stop-on-failure, no further batches are started once
+an Action in a batch has failed, been aborted or timed out.  With --wait, the command also waits for
+the final batch, or for the Action on the given unit, to finish.  The ID,
+status and results of the Action on each unit are displayed together.
+
+An Action that runs for longer than the duration given with --timeout, such
+as 10m, is stopped and recorded as timed out.  Without --timeout, the
+default timeout for the Action in the charm's actions.yaml is used, if any.
+
 Params are validated according to the charm for the unit's service.  The 
 valid params can be seen using "juju action defined <service> --schema".
 Params may be in a yaml file which is passed with the --params flag, or they
@@ -97,6 +119,15 @@ $ juju action do sleeper/0 pause time=1000
 $ juju action do sleeper/0 pause --string-args time=1000
 ...
 The value for the "time" param will be the string literal "1000".
+
+$ juju action do mysql backup --batch-size 5 --stop-on-failure --wait
+mysql/0:
+  id: <ID>
+  status: completed
+  results:
+    ...
+mysql/1:
+...
 `
 
 // actionNameRule describes the format an action name must match to be valid.
@@ -107,58 +138,106 @@ func (c *DoCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.out.AddFlags(f, "smart", cmd.DefaultFormatters)
 	f.Var(&c.paramsYAML, "params", "path to yaml-formatted params file")
 	f.BoolVar(&c.parseStrings, "string-args", false, "use raw string values of CLI args")
+	f.IntVar(&c.batchSize, "batch-size", 0, "number of units of a service to run the action on at once (0 for all)")
+	f.BoolVar(&c.wait, "wait", false, "wait for the actions to finish and display their results")
+	f.BoolVar(&c.stopOnFailure, "stop-on-failure", false, "do not start further batches once an action has failed")
+	f.DurationVar(&c.timeout, "timeout", 0, "stop the action if it runs for longer than this (0 for the charm's default)")
 }
 
 func (c *DoCommand) Info() *cmd.Info {
 	return &cmd.Info{
 		Name:    "do",
-		Args:    "<unit> <action name> [key.key.key...=value]",
+		Args:    "<unit>|<service> <action name> [key.key.key...=value]",
 		Purpose: "queue an action for execution",
 		Doc:     doDoc,
 	}
 }
 
-// Init gets the unit tag, and checks for other correct args.
+// Init gets the unit or service tag, and checks for other correct args.
 func (c *DoCommand) Init(args []string) error {
 	switch len(args) {
 	case 0:
-		return errors.New("no unit specified")
+		return errors.New("no unit or service specified")
 	case 1:
 		return errors.New("no action specified")
-	default:
-		// Grab and verify the unit and action names.
-		unitName := args[0]
-		if !names.IsValidUnit(unitName) {
-			return errors.Errorf("invalid unit name %q", unitName)
+	}
+
+	// Grab and verify the receiver and action names.
+	receiverName := args[0]
+	switch {
+	case names.IsValidUnit(receiverName):
+		c.unitTag = names.NewUnitTag(receiverName)
+		if c.batchSize != 0 || c.stopOnFailure {
+			return errors.New("--batch-size and --stop-on-failure can only be used with a service")
 		}
-		actionName := args[1]
-		if valid := actionNameRule.MatchString(actionName); !valid {
-			return fmt.Errorf("invalid action name %q", actionName)
+	case names.IsValidService(receiverName):
+		c.serviceTag = names.NewServiceTag(receiverName)
+		if c.batchSize < 0 {
+			return errors.Errorf("invalid batch size %d", c.batchSize)
 		}
-		c.unitTag = names.NewUnitTag(unitName)
-		c.actionName = actionName
-		if len(args) == 2 {
-			return nil
+	default:
+		return errors.Errorf("invalid unit or service name %q", receiverName)
+	}
+	if c.timeout < 0 {
+		return errors.Errorf("invalid timeout %v", c.timeout)
+	}
+	actionName := args[1]
+	if valid := actionNameRule.MatchString(actionName); !valid {
+		return fmt.Errorf("invalid action name %q", actionName)
+	}
+	c.actionName = actionName
+	if len(args) == 2 {
+		return nil
+	}
+	// Parse CLI key-value args if they exist.
+	var err error
+	c.args, err = parseKeyValueArgs(args[2:])
+	return err
+}
+
+// parseKeyValueArgs parses args of the form key.key.key...=value into
+// slices of the form [key, key, key, ..., value].
+func parseKeyValueArgs(args []string) ([][]string, error) {
+	result := make([][]string, 0)
+	for _, arg := range args {
+		thisArg := strings.SplitN(arg, "=", 2)
+		if len(thisArg) != 2 {
+			return nil, fmt.Errorf("argument %q must be of the form key...=value", arg)
 		}
-		// Parse CLI key-value args if they exist.
-		c.args = make([][]string, 0)
-		for _, arg := range args[2:] {
-			thisArg := strings.SplitN(arg, "=", 2)
-			if len(thisArg) != 2 {
-				return fmt.Errorf("argument %q must be of the form key...=value", arg)
+		keySlice := strings.Split(thisArg[0], ".")
+		// check each key for validity
+		for _, key := range keySlice {
+			if valid := keyRule.MatchString(key); !valid {
+				return nil, fmt.Errorf("key %q must start and end with lowercase alphanumeric, and contain only lowercase alphanumeric and hyphens", key)
 			}
-			keySlice := strings.Split(thisArg[0], ".")
-			// check each key for validity
-			for _, key := range keySlice {
-				if valid := keyRule.MatchString(key); !valid {
-					return fmt.Errorf("key %q must start and end with lowercase alphanumeric, and contain only lowercase alphanumeric and hyphens", key)
-				}
+		}
+		// result={..., [key, key, key, key, value]}
+		result = append(result, append(keySlice, thisArg[1]))
+	}
+	return result, nil
+}
+
+// addKeyValueArgs sets the params given by args, as parsed by
+// parseKeyValueArgs, in actionParams. Values are parsed as YAML unless
+// parseStrings is set.
+func addKeyValueArgs(actionParams map[string]interface{}, args [][]string, parseStrings bool) error {
+	// If we had explicit args {..., [key, key, key, key, value], ...}
+	// then iterate and set params ..., key.key.key.key=value, ...
+	for _, argSlice := range args {
+		valueIndex := len(argSlice) - 1
+		keys := argSlice[:valueIndex]
+		value := argSlice[valueIndex]
+		cleansedValue := interface{}(value)
+		if !parseStrings {
+			err := yaml.Unmarshal([]byte(value), &cleansedValue)
+			if err != nil {
+				return err
 			}
-			// c.args={..., [key, key, key, key, value]}
-			c.args = append(c.args, append(keySlice, thisArg[1]))
 		}
-		return nil
+		// Insert the value in the map.
+		addValueToMap(keys, cleansedValue, actionParams)
 	}
+	return nil
 }
 
 func (c *DoCommand) Run(ctx *cmd.Context) error {
@@ -194,21 +273,8 @@ func (c *DoCommand) Run(ctx *cmd.Context) error {
 		actionParams = betterParams
 	}
 
-	// If we had explicit args {..., [key, key, key, key, value], ...}
-	// then iterate and set params ..., key.key.key.key=value, ...
-	for _, argSlice := range c.args {
-		valueIndex := len(argSlice) - 1
-		keys := argSlice[:valueIndex]
-		value := argSlice[valueIndex]
-		cleansedValue := interface{}(value)
-		if !c.parseStrings {
-			err := yaml.Unmarshal([]byte(value), &cleansedValue)
-			if err != nil {
-				return err
-			}
-		}
-		// Insert the value in the map.
-		addValueToMap(keys, cleansedValue, actionParams)
+	if err := addKeyValueArgs(actionParams, c.args, c.parseStrings); err != nil {
+		return err
 	}
 
 	conformantParams, err := conform(actionParams)
@@ -221,11 +287,16 @@ func (c *DoCommand) Run(ctx *cmd.Context) error {
 		return errors.Errorf("params must be a map, got %T", typedConformantParams)
 	}
 
+	if c.serviceTag.Id() != "" || c.wait {
+		return c.runBatches(ctx, api, actionParams)
+	}
+
 	actionParam := params.Actions{
 		Actions: []params.Action{{
 			Receiver:   c.unitTag.String(),
 			Name:       c.actionName,
 			Parameters: actionParams,
+			Timeout:    c.timeout,
 		}},
 	}
 
@@ -255,3 +326,168 @@ func (c *DoCommand) Run(ctx *cmd.Context) error {
 	output := map[string]string{"Action queued with id": tag.Id()}
 	return c.out.Write(ctx, output)
 }
+
+// runBatches enqueues the Action on the given unit, or on the units of
+// the given service in batches, waiting for each batch to finish before
+// starting the next, and displays the combined results.
+func (c *DoCommand) runBatches(ctx *cmd.Context, api APIClient, actionParams map[string]interface{}) error {
+	var receivers []names.UnitTag
+	if c.serviceTag.Id() != "" {
+		unitTags, err := api.ServiceUnits(params.Entity{Tag: c.serviceTag.String()})
+		if err != nil {
+			return err
+		}
+		if len(unitTags) == 0 {
+			return errors.Errorf("service %q has no units", c.serviceTag.Id())
+		}
+		receivers = unitTags
+	} else {
+		receivers = []names.UnitTag{c.unitTag}
+	}
+
+	batchSize := c.batchSize
+	if batchSize == 0 || batchSize > len(receivers) {
+		batchSize = len(receivers)
+	}
+	var (
+		results []params.ActionResult
+		failure error
+	)
+	for start := 0; start < len(receivers); start += batchSize {
+		end := start + batchSize
+		if end > len(receivers) {
+			end = len(receivers)
+		}
+		batch := params.Actions{}
+		for _, receiver := range receivers[start:end] {
+			batch.Actions = append(batch.Actions, params.Action{
+				Receiver:   receiver.String(),
+				Name:       c.actionName,
+				Parameters: actionParams,
+				Timeout:    c.timeout,
+			})
+		}
+		enqueued, err := api.Enqueue(batch)
+		if err != nil {
+			return err
+		}
+		if len(enqueued.Results) != len(batch.Actions) {
+			return errors.New("illegal number of results returned")
+		}
+		batchResults := enqueued.Results
+		for i := range batchResults {
+			if batchResults[i].Action == nil {
+				batchResults[i].Action = &batch.Actions[i]
+			}
+		}
+
+		// The next batch may only start once this one has finished.
+		last := end == len(receivers)
+		if !last || c.wait {
+			if batchResults, err = waitForActions(api, batchResults); err != nil {
+				return err
+			}
+		}
+		results = append(results, batchResults...)
+		if c.stopOnFailure && anyActionFailed(batchResults) {
+			failure = errors.Errorf("action failed in batch %d; not run on %d remaining unit(s)", start/batchSize+1, len(receivers)-end)
+			break
+		}
+	}
+
+	if err := c.out.Write(ctx, resultsByUnit(results)); err != nil {
+		return err
+	}
+	return failure
+}
+
+// waitForActions polls the given API until none of the Actions in the
+// given results is pending or running, and returns their final results.
+func waitForActions(api APIClient, results []params.ActionResult) ([]params.ActionResult, error) {
+	results = append([]params.ActionResult(nil), results...)
+	for {
+		var (
+			entities []params.Entity
+			indices  []int
+		)
+		for i, result := range results {
+			if result.Error != nil {
+				continue
+			}
+			switch result.Status {
+			case "", params.ActionPending, params.ActionRunning:
+				entities = append(entities, params.Entity{Tag: result.Action.Tag})
+				indices = append(indices, i)
+			}
+		}
+		if len(entities) == 0 {
+			return results, nil
+		}
+
+		actions, err := api.Actions(params.Entities{Entities: entities})
+		if err != nil {
+			return nil, err
+		}
+		if len(actions.Results) != len(entities) {
+			return nil, errors.Errorf("expected %d results, got %d", len(entities), len(actions.Results))
+		}
+		waiting := false
+		for j, result := range actions.Results {
+			i := indices[j]
+			if result.Error != nil {
+				results[i].Error = result.Error
+				continue
+			}
+			if result.Action == nil {
+				result.Action = results[i].Action
+			}
+			results[i] = result
+			switch result.Status {
+			case params.ActionPending, params.ActionRunning:
+				waiting = true
+			}
+		}
+		if !waiting {
+			return results, nil
+		}
+		<-time.After(waitInterval)
+	}
+}
+
+// anyActionFailed reports whether any of the given results records a
+// failure to enqueue or run its Action.
+func anyActionFailed(results []params.ActionResult) bool {
+	for _, result := range results {
+		if result.Error != nil {
+			return true
+		}
+		switch result.Status {
+		case params.ActionFailed, params.ActionCancelled, params.ActionAborted, params.ActionTimedOut:
+			return true
+		}
+	}
+	return false
+}
+
+// resultsByUnit arranges the given results in a map keyed by the name
+// of the unit each Action was queued on, for cmd.Output to write in an
+// easy-to-read format.
+func resultsByUnit(results []params.ActionResult) map[string]interface{} {
+	response := make(map[string]interface{})
+	for _, result := range results {
+		unitName := result.Action.Receiver
+		if tag, err := names.ParseUnitTag(unitName); err == nil {
+			unitName = tag.Id()
+		}
+		if result.Error != nil {
+			response[unitName] = map[string]interface{}{"error": result.Error.Error()}
+			continue
+		}
+		item := formatActionResult(result)
+		if tag, err := names.ParseActionTag(result.Action.Tag); err == nil {
+			item["id"] = tag.Id()
+		}
+		response[unitName] = item
+	}
+	return response
+}
@@ -6,7 +6,9 @@ package action_test
 import (
 	"bytes"
 	"errors"
+	"fmt"
 	"strings"
+	"time"
 	"unicode/utf8"
 
 	"github.com/juju/names"
@@ -65,7 +67,10 @@ func (s *DoSuite) TestInit(c *gc.C) {
 		should               string
 		args                 []string
 		expectUnit           names.UnitTag
+		expectService        names.ServiceTag
 		expectAction         string
+		expectBatchSize      int
+		expectTimeout        time.Duration
 		expectParamsYamlPath string
 		expectParseStrings   bool
 		expectKVArgs         [][]string
@@ -74,7 +79,7 @@ func (s *DoSuite) TestInit(c *gc.C) {
 	}{{
 		should:      "fail with missing args",
 		args:        []string{},
-		expectError: "no unit specified",
+		expectError: "no unit or service specified",
 	}, {
 		should:      "fail with no action specified",
 		args:        []string{validUnitId},
@@ -82,7 +87,35 @@ func (s *DoSuite) TestInit(c *gc.C) {
 	}, {
 		should:      "fail with invalid unit tag",
 		args:        []string{invalidUnitId, "valid-action-name"},
-		expectError: "invalid unit name \"something-strange-\"",
+		expectError: "invalid unit or service name \"something-strange-\"",
+	}, {
+		should:      "fail with batch size for a unit",
+		args:        []string{validUnitId, "valid-action-name", "--batch-size", "2"},
+		expectError: "--batch-size and --stop-on-failure can only be used with a service",
+	}, {
+		should:      "fail with stop on failure for a unit",
+		args:        []string{validUnitId, "valid-action-name", "--stop-on-failure"},
+		expectError: "--batch-size and --stop-on-failure can only be used with a service",
+	}, {
+		should:      "fail with negative batch size",
+		args:        []string{validServiceId, "valid-action-name", "--batch-size", "-1"},
+		expectError: "invalid batch size -1",
+	}, {
+		should:          "init properly with a service",
+		args:            []string{validServiceId, "valid-action-name", "--batch-size", "3"},
+		expectService:   names.NewServiceTag(validServiceId),
+		expectAction:    "valid-action-name",
+		expectBatchSize: 3,
+	}, {
+		should:      "fail with negative timeout",
+		args:        []string{validUnitId, "valid-action-name", "--timeout", "-1m"},
+		expectError: "invalid timeout -1m0s",
+	}, {
+		should:        "init properly with a timeout",
+		args:          []string{validUnitId, "valid-action-name", "--timeout", "10m"},
+		expectUnit:    names.NewUnitTag(validUnitId),
+		expectAction:  "valid-action-name",
+		expectTimeout: 10 * time.Minute,
 	}, {
 		should:      "fail with invalid action name",
 		args:        []string{validUnitId, "BadName"},
@@ -172,6 +205,9 @@ func (s *DoSuite) TestInit(c *gc.C) {
 		err := testing.InitCommand(s.subcommand, t.args)
 		if t.expectError == "" {
 			c.Check(s.subcommand.UnitTag(), gc.Equals, t.expectUnit)
+			c.Check(s.subcommand.ServiceTag(), gc.Equals, t.expectService)
+			c.Check(s.subcommand.BatchSize(), gc.Equals, t.expectBatchSize)
+			c.Check(s.subcommand.Timeout(), gc.Equals, t.expectTimeout)
 			c.Check(s.subcommand.ActionName(), gc.Equals, t.expectAction)
 			c.Check(s.subcommand.ParamsYAMLPath(), gc.Equals, t.expectParamsYamlPath)
 			c.Check(s.subcommand.KeyValueDoArgs(), jc.DeepEquals, t.expectKVArgs)
@@ -398,3 +434,147 @@ func (s *DoSuite) TestRun(c *gc.C) {
 		}()
 	}
 }
+
+// fakeBatchAPIClient enqueues Actions that finish, with the status
+// given for their unit, as soon as they are looked up.
+type fakeBatchAPIClient struct {
+	*fakeAPIClient
+	batches  []params.Actions
+	statuses map[string]string
+	tags     map[string]string
+}
+
+func newFakeBatchAPIClient(unitIds []string, statuses map[string]string) *fakeBatchAPIClient {
+	client := &fakeBatchAPIClient{
+		fakeAPIClient: &fakeAPIClient{},
+		statuses:      make(map[string]string),
+		tags:          make(map[string]string),
+	}
+	for _, id := range unitIds {
+		client.serviceUnits = append(client.serviceUnits, names.NewUnitTag(id))
+	}
+	for id, status := range statuses {
+		client.statuses[names.NewUnitTag(id).String()] = status
+	}
+	return client
+}
+
+func (c *fakeBatchAPIClient) Enqueue(args params.Actions) (params.ActionResults, error) {
+	c.batches = append(c.batches, args)
+	results := params.ActionResults{}
+	for _, a := range args.Actions {
+		tag := fmt.Sprintf("action-f47ac10b-58cc-4372-a567-0e02b2c3d4%02d", len(c.tags))
+		c.tags[tag] = a.Receiver
+		a.Tag = tag
+		enqueued := a
+		results.Results = append(results.Results, params.ActionResult{
+			Action: &enqueued,
+			Status: params.ActionPending,
+		})
+	}
+	return results, nil
+}
+
+func (c *fakeBatchAPIClient) Actions(args params.Entities) (params.ActionResults, error) {
+	results := params.ActionResults{}
+	for _, entity := range args.Entities {
+		receiver := c.tags[entity.Tag]
+		status, ok := c.statuses[receiver]
+		if !ok {
+			status = params.ActionCompleted
+		}
+		results.Results = append(results.Results, params.ActionResult{
+			Action: &params.Action{Tag: entity.Tag, Receiver: receiver, Name: "some-action"},
+			Status: status,
+		})
+	}
+	return results, nil
+}
+
+func (s *DoSuite) runBatches(c *gc.C, client *fakeBatchAPIClient, args ...string) (map[string]map[string]interface{}, error) {
+	s.PatchValue(action.NewActionAPIClient, func(*action.ActionCommandBase) (action.APIClient, error) {
+		return client, nil
+	})
+	s.PatchValue(action.WaitInterval, time.Duration(0))
+	ctx, err := testing.RunCommand(c, &action.DoCommand{}, append(args, "--format", "yaml")...)
+	output := make(map[string]map[string]interface{})
+	if yamlErr := yaml.Unmarshal(ctx.Stdout.(*bytes.Buffer).Bytes(), &output); yamlErr != nil {
+		c.Fatalf("cannot parse output: %v", yamlErr)
+	}
+	return output, err
+}
+
+func (s *DoSuite) batchSizes(client *fakeBatchAPIClient) []int {
+	var sizes []int
+	for _, batch := range client.batches {
+		sizes = append(sizes, len(batch.Actions))
+	}
+	return sizes
+}
+
+func (s *DoSuite) TestRunServiceInBatches(c *gc.C) {
+	client := newFakeBatchAPIClient([]string{"mysql/0", "mysql/1", "mysql/2"}, nil)
+	output, err := s.runBatches(c, client, validServiceId, "some-action", "--batch-size", "2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.batchSizes(client), jc.DeepEquals, []int{2, 1})
+	c.Assert(client.batches[0].Actions[0], jc.DeepEquals, params.Action{
+		Receiver:   "unit-mysql-0",
+		Name:       "some-action",
+		Parameters: map[string]interface{}{},
+	})
+	c.Assert(output, gc.HasLen, 3)
+	// The final batch is not waited for.
+	c.Assert(output["mysql/0"]["status"], gc.Equals, params.ActionCompleted)
+	c.Assert(output["mysql/1"]["status"], gc.Equals, params.ActionCompleted)
+	c.Assert(output["mysql/2"]["status"], gc.Equals, params.ActionPending)
+	c.Assert(output["mysql/2"]["id"], gc.Equals, "f47ac10b-58cc-4372-a567-0e02b2c3d402")
+}
+
+func (s *DoSuite) TestRunServiceWait(c *gc.C) {
+	client := newFakeBatchAPIClient([]string{"mysql/0", "mysql/1"}, map[string]string{
+		"mysql/1": params.ActionFailed,
+	})
+	output, err := s.runBatches(c, client, validServiceId, "some-action", "--wait")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.batchSizes(client), jc.DeepEquals, []int{2})
+	c.Assert(output["mysql/0"]["status"], gc.Equals, params.ActionCompleted)
+	c.Assert(output["mysql/1"]["status"], gc.Equals, params.ActionFailed)
+}
+
+func (s *DoSuite) TestRunServiceStopOnFailure(c *gc.C) {
+	client := newFakeBatchAPIClient([]string{"mysql/0", "mysql/1", "mysql/2"}, map[string]string{
+		"mysql/0": params.ActionFailed,
+	})
+	output, err := s.runBatches(c, client, validServiceId, "some-action", "--batch-size", "1", "--stop-on-failure")
+	c.Assert(err, gc.ErrorMatches, `action failed in batch 1; not run on 2 remaining unit\(s\)`)
+	c.Assert(s.batchSizes(client), jc.DeepEquals, []int{1})
+	c.Assert(output, gc.HasLen, 1)
+	c.Assert(output["mysql/0"]["status"], gc.Equals, params.ActionFailed)
+}
+
+func (s *DoSuite) TestRunServiceContinuesAfterFailure(c *gc.C) {
+	client := newFakeBatchAPIClient([]string{"mysql/0", "mysql/1"}, map[string]string{
+		"mysql/0": params.ActionFailed,
+	})
+	output, err := s.runBatches(c, client, validServiceId, "some-action", "--batch-size", "1", "--wait")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.batchSizes(client), jc.DeepEquals, []int{1, 1})
+	c.Assert(output["mysql/0"]["status"], gc.Equals, params.ActionFailed)
+	c.Assert(output["mysql/1"]["status"], gc.Equals, params.ActionCompleted)
+}
+
+func (s *DoSuite) TestRunServiceNoUnits(c *gc.C) {
+	client := newFakeBatchAPIClient(nil, nil)
+	_, err := s.runBatches(c, client, validServiceId, "some-action")
+	c.Assert(err, gc.ErrorMatches, `service "mysql" has no units`)
+	c.Assert(client.batches, gc.HasLen, 0)
+}
+
+func (s *DoSuite) TestRunUnitWait(c *gc.C) {
+	client := newFakeBatchAPIClient(nil, nil)
+	output, err := s.runBatches(c, client, validUnitId, "some-action", "--wait")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.batchSizes(client), jc.DeepEquals, []int{1})
+	c.Assert(output, gc.HasLen, 1)
+	c.Assert(output["mysql/0"]["status"], gc.Equals, params.ActionCompleted)
+}
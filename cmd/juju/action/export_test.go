@@ -4,6 +4,8 @@
 package action
 
 import (
+	"time"
+
 	"github.com/juju/names"
 
 	"github.com/juju/juju/apiserver/params"
@@ -11,6 +13,8 @@ import (
 
 var (
 	NewActionAPIClient = &newAPIClient
+	WaitInterval       = &waitInterval
+	Now                = &now
 )
 
 func (c *DefinedCommand) ServiceTag() names.ServiceTag {
@@ -25,6 +29,18 @@ func (c *DoCommand) UnitTag() names.UnitTag {
 	return c.unitTag
 }
 
+func (c *DoCommand) ServiceTag() names.ServiceTag {
+	return c.serviceTag
+}
+
+func (c *DoCommand) BatchSize() int {
+	return c.batchSize
+}
+
+func (c *DoCommand) Timeout() time.Duration {
+	return c.timeout
+}
+
 func (c *DoCommand) ActionName() string {
 	return c.actionName
 }
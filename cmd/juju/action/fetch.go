@@ -4,6 +4,7 @@
 package action
 
 import (
+	"io"
 	"regexp"
 	"time"
 
@@ -21,6 +22,7 @@ type FetchCommand struct {
 	requestedId string
 	fullSchema  bool
 	wait        string
+	follow      bool
 }
 
 const fetchDoc = `
@@ -32,12 +34,18 @@ to wait indefinitely.  If units are left off, seconds are assumed.
 The default behavior without --wait is to immediately check and return; if
 the results are "pending" then only the available information will be
 displayed.  This is also the behavior when any negative time is given.
+
+To see the output of the action while it is running, use the --follow flag.
+The action's stdout and stderr are written as they are produced, until the
+action is completed or failed, and then its results are displayed.  The
+--follow flag cannot be used with --wait.
 `
 
 // Set up the output.
 func (c *FetchCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.out.AddFlags(f, "smart", cmd.DefaultFormatters)
 	f.StringVar(&c.wait, "wait", "-1s", "wait for results")
+	f.BoolVar(&c.follow, "follow", false, "write the action's output as it is produced, then show its results")
 }
 
 func (c *FetchCommand) Info() *cmd.Info {
@@ -56,6 +64,9 @@ func (c *FetchCommand) Init(args []string) error {
 		return errors.New("no action ID specified")
 	case 1:
 		c.requestedId = args[0]
+		if c.follow && c.wait != "-1s" {
+			return errors.New("cannot use --follow with --wait")
+		}
 		return nil
 	default:
 		return cmd.CheckEmpty(args[1:])
@@ -64,6 +75,10 @@ func (c *FetchCommand) Init(args []string) error {
 
 // Run issues the API call to get Actions by ID.
 func (c *FetchCommand) Run(ctx *cmd.Context) error {
+	if c.follow {
+		return c.runFollow(ctx)
+	}
+
 	// Check whether units were left off our time string.
 	r := regexp.MustCompile("[a-zA-Z]")
 	matches := r.FindStringSubmatch(c.wait[len(c.wait)-1:])
@@ -107,6 +122,68 @@ func (c *FetchCommand) Run(ctx *cmd.Context) error {
 	return c.out.Write(ctx, formatActionResult(result))
 }
 
+// runFollow writes the output of the Action as it is produced, and then
+// its result once it is no longer running or pending.
+func (c *FetchCommand) runFollow(ctx *cmd.Context) error {
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	actionTag, err := getActionTagByPrefix(api, c.requestedId)
+	if err != nil {
+		return err
+	}
+	after := make(map[string]int)
+	for {
+		result, err := fetchResult(api, c.requestedId)
+		if err != nil {
+			return err
+		}
+		// The output is fetched after the status, so that all of the
+		// output of a finished Action is written before its result.
+		if err := writeOutput(ctx, api, actionTag.String(), after); err != nil {
+			return err
+		}
+		switch result.Status {
+		case params.ActionRunning, params.ActionPending:
+		default:
+			return c.out.Write(ctx, formatActionResult(result))
+		}
+		<-time.After(waitInterval)
+	}
+}
+
+// writeOutput writes the chunks of the output with the given id that
+// follow those recorded in after to the context's stdout or stderr, and
+// records the last chunk written for each unit in after.
+func writeOutput(ctx *cmd.Context, api APIClient, outputId string, after map[string]int) error {
+	results, err := api.Output(params.OutputQueries{
+		Queries: []params.OutputQuery{{Id: outputId, After: after}},
+	})
+	if err != nil {
+		return err
+	}
+	if len(results.Results) != 1 {
+		return errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if results.Results[0].Error != nil {
+		return results.Results[0].Error
+	}
+	for _, chunk := range results.Results[0].Chunks {
+		var w io.Writer = ctx.Stdout
+		if chunk.Stream == params.OutputStderr {
+			w = ctx.Stderr
+		}
+		if _, err := io.WriteString(w, chunk.Data); err != nil {
+			return err
+		}
+		after[chunk.Unit] = chunk.Seq
+	}
+	return nil
+}
+
 // timerLoop loops indefinitely to query the given API, until "wait" times
 // out, using the "tick" timer to delay the API queries.  It writes the
 // result to the given output.
@@ -45,6 +45,10 @@ func (s *FetchSuite) TestInit(c *gc.C) {
 		should:      "fail with multiple args",
 		args:        []string{"12345", "54321"},
 		expectError: `unrecognized args: \["54321"\]`,
+	}, {
+		should:      "fail with --follow and --wait",
+		args:        []string{"12345", "--follow", "--wait", "5s"},
+		expectError: "cannot use --follow with --wait",
 	}}
 
 	for i, t := range tests {
@@ -287,6 +291,39 @@ timing:
 	}
 }
 
+func (s *FetchSuite) TestRunFollow(c *gc.C) {
+	client := makeFakeClient(
+		0,
+		10*time.Second,
+		tagsForIdPrefix(validActionId, validActionTagString),
+		[]params.ActionResult{{
+			Status:    "completed",
+			Enqueued:  time.Date(2015, time.February, 14, 8, 13, 0, 0, time.UTC),
+			Completed: time.Date(2015, time.February, 14, 8, 15, 30, 0, time.UTC),
+		}},
+		"",
+	)
+	client.outputChunks = []params.OutputChunk{
+		{Unit: "mysql/0", Seq: 1, Stream: params.OutputStdout, Data: "backing up\n"},
+		{Unit: "mysql/0", Seq: 2, Stream: params.OutputStderr, Data: "disk nearly full\n"},
+		{Unit: "mysql/0", Seq: 3, Stream: params.OutputStdout, Data: "done\n"},
+	}
+	unpatch := s.BaseActionSuite.patchAPIClient(client)
+	defer unpatch()
+
+	ctx, err := testing.RunCommand(c, &action.FetchCommand{}, validActionId, "--follow")
+	c.Assert(err, gc.IsNil)
+	c.Check(testing.Stdout(ctx), gc.Equals, `
+backing up
+done
+status: completed
+timing:
+  completed: 2015-02-14 08:15:30 +0000 UTC
+  enqueued: 2015-02-14 08:13:00 +0000 UTC
+`[1:])
+	c.Check(testing.Stderr(ctx), gc.Equals, "disk nearly full\n")
+}
+
 func testRunHelper(c *gc.C, s *FetchSuite, client *fakeAPIClient, expectedErr, expectedOutput, wait, query string) {
 	unpatch := s.BaseActionSuite.patchAPIClient(client)
 	defer unpatch()
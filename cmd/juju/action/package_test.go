@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/juju/cmd"
+	"github.com/juju/names"
 	jujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -136,6 +137,14 @@ type fakeAPIClient struct {
 	actionsByReceivers []params.ActionsByReceiver
 	actionTagMatches   params.FindTagsResults
 	charmActions       *charm.Actions
+	serviceUnits       []names.UnitTag
+	cancelled          params.Entities
+	aborted            params.Entities
+	addedSchedules     params.ActionSchedules
+	schedules          []params.ActionSchedule
+	removedSchedules   params.ActionScheduleNames
+	errorResults       []params.ErrorResult
+	outputChunks       []params.OutputChunk
 	apiErr             error
 }
 
@@ -174,16 +183,42 @@ func (c *fakeAPIClient) ListCompleted(args params.Entities) (params.ActionsByRec
 	}, c.apiErr
 }
 
-func (c *fakeAPIClient) Cancel(args params.Actions) (params.ActionResults, error) {
+func (c *fakeAPIClient) Cancel(args params.Entities) (params.ActionResults, error) {
+	c.cancelled = args
 	return params.ActionResults{
 		Results: c.actionResults,
 	}, c.apiErr
 }
 
+func (c *fakeAPIClient) Abort(args params.Entities) (params.ActionResults, error) {
+	c.aborted = args
+	return params.ActionResults{
+		Results: c.actionResults,
+	}, c.apiErr
+}
+
+func (c *fakeAPIClient) AddSchedules(args params.ActionSchedules) (params.ErrorResults, error) {
+	c.addedSchedules = args
+	return params.ErrorResults{Results: c.errorResults}, c.apiErr
+}
+
+func (c *fakeAPIClient) ListSchedules() ([]params.ActionSchedule, error) {
+	return c.schedules, c.apiErr
+}
+
+func (c *fakeAPIClient) RemoveSchedules(args params.ActionScheduleNames) (params.ErrorResults, error) {
+	c.removedSchedules = args
+	return params.ErrorResults{Results: c.errorResults}, c.apiErr
+}
+
 func (c *fakeAPIClient) ServiceCharmActions(params.Entity) (*charm.Actions, error) {
 	return c.charmActions, c.apiErr
 }
 
+func (c *fakeAPIClient) ServiceUnits(params.Entity) ([]names.UnitTag, error) {
+	return c.serviceUnits, c.apiErr
+}
+
 func (c *fakeAPIClient) Actions(args params.Entities) (params.ActionResults, error) {
 	// If the test supplies a delay time too long, we'll return an error
 	// to prevent the test hanging.  If the given wait is up, then return
@@ -214,3 +249,15 @@ func (c *fakeAPIClient) Actions(args params.Entities) (params.ActionResults, err
 func (c *fakeAPIClient) FindActionTagsByPrefix(arg params.FindTags) (params.FindTagsResults, error) {
 	return c.actionTagMatches, c.apiErr
 }
+
+func (c *fakeAPIClient) Output(args params.OutputQueries) (params.OutputResults, error) {
+	results := make([]params.OutputResult, len(args.Queries))
+	for i, query := range args.Queries {
+		for _, chunk := range c.outputChunks {
+			if chunk.Seq > query.After[chunk.Unit] {
+				results[i].Chunks = append(results[i].Chunks, chunk)
+			}
+		}
+	}
+	return params.OutputResults{Results: results}, c.apiErr
+}
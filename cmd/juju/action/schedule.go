@@ -0,0 +1,326 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/envcmd"
+)
+
+const scheduleDoc = `
+"juju action schedule" is used to manage schedules on which actions are
+queued up repeatedly, such as nightly backups.
+`
+
+// newScheduleSuperCommand returns the action schedule super-command,
+// with the subcommands that it supports registered.
+func newScheduleSuperCommand() cmd.Command {
+	schedulecmd := jujucmd.NewSubSuperCommand(cmd.SuperCommandParams{
+		Name:        "schedule",
+		Doc:         scheduleDoc,
+		UsagePrefix: "juju action",
+		Purpose:     "manage scheduled actions",
+	})
+	schedulecmd.Register(envcmd.Wrap(&ScheduleAddCommand{}))
+	schedulecmd.Register(envcmd.Wrap(&ScheduleListCommand{}))
+	schedulecmd.Register(envcmd.Wrap(&ScheduleRemoveCommand{}))
+	return schedulecmd
+}
+
+// leaderSuffix is appended to a service name to run a scheduled
+// action on the service's leader.
+const leaderSuffix = "/leader"
+
+// now returns the current time; it is replaced in tests.
+var now = time.Now
+
+// ScheduleAddCommand adds a schedule on which an Action is queued up.
+type ScheduleAddCommand struct {
+	ActionCommandBase
+	name         string
+	receiver     string
+	actionName   string
+	every        time.Duration
+	at           string
+	parseStrings bool
+	args         [][]string
+}
+
+const scheduleAddDoc = `
+Add a schedule with the given name, on which an action is queued up for
+execution on a unit every --every interval.  Give the name of a service
+followed by "/leader" to queue the action up on whichever unit is the
+leader of the service when the action is due.
+
+The action is first due at the time of day given with --at, in UTC, or
+straight away if --at is not given.  If the action queued up by the
+previous run of the schedule is still pending when the action is next due,
+that run is skipped.
+
+Params are given in the same way as for "juju action do".
+
+Examples:
+
+$ juju action schedule add nightly-backup mysql/leader backup --every 24h --at 02:00
+$ juju action schedule add rotate-logs wordpress/0 rotate --every 6h keep=4
+`
+
+func (c *ScheduleAddCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.DurationVar(&c.every, "every", 0, "the interval between runs of the action")
+	f.StringVar(&c.at, "at", "", "the time of day, as HH:MM in UTC, at which the action is first due")
+	f.BoolVar(&c.parseStrings, "string-args", false, "use raw string values of CLI args")
+}
+
+func (c *ScheduleAddCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "add",
+		Args:    "<name> <unit>|<service>/leader <action name> [key.key.key...=value]",
+		Purpose: "add a schedule for an action",
+		Doc:     scheduleAddDoc,
+	}
+}
+
+// Init checks the schedule's name, receiver, action and interval.
+func (c *ScheduleAddCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("no schedule name specified")
+	case 1:
+		return errors.New("no unit or service leader specified")
+	case 2:
+		return errors.New("no action specified")
+	}
+	c.name = args[0]
+	c.receiver = args[1]
+	serviceName := strings.TrimSuffix(c.receiver, leaderSuffix)
+	if serviceName == c.receiver {
+		if !names.IsValidUnit(c.receiver) {
+			return errors.Errorf("invalid unit or service leader %q", c.receiver)
+		}
+	} else if !names.IsValidService(serviceName) {
+		return errors.Errorf("invalid unit or service leader %q", c.receiver)
+	}
+	c.actionName = args[2]
+	if !actionNameRule.MatchString(c.actionName) {
+		return errors.Errorf("invalid action name %q", c.actionName)
+	}
+	if c.every <= 0 {
+		return errors.New("no interval specified with --every")
+	}
+	if c.at != "" {
+		if _, err := timeOfDay(now(), c.at); err != nil {
+			return err
+		}
+	}
+	var err error
+	c.args, err = parseKeyValueArgs(args[3:])
+	return err
+}
+
+// Run adds the schedule.
+func (c *ScheduleAddCommand) Run(ctx *cmd.Context) error {
+	actionParams := map[string]interface{}{}
+	if err := addKeyValueArgs(actionParams, c.args, c.parseStrings); err != nil {
+		return err
+	}
+	conformantParams, err := conform(actionParams)
+	if err != nil {
+		return err
+	}
+	typedConformantParams, ok := conformantParams.(map[string]interface{})
+	if !ok {
+		return errors.Errorf("params must be a map, got %T", conformantParams)
+	}
+
+	start := now().UTC()
+	if c.at != "" {
+		if start, err = timeOfDay(start, c.at); err != nil {
+			return err
+		}
+	}
+
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	results, err := api.AddSchedules(params.ActionSchedules{
+		Schedules: []params.ActionSchedule{{
+			Name:       c.name,
+			Receiver:   c.receiver,
+			ActionName: c.actionName,
+			Parameters: typedConformantParams,
+			Start:      start,
+			Interval:   c.every,
+		}},
+	})
+	if err != nil {
+		return err
+	}
+	return results.OneError()
+}
+
+// timeOfDay returns the first time that is not before from, and that
+// has the given time of day, which is given as HH:MM in UTC.
+func timeOfDay(from time.Time, hhmm string) (time.Time, error) {
+	at, err := time.Parse("15:04", hhmm)
+	if err != nil {
+		return time.Time{}, errors.Errorf("invalid time of day %q; expected HH:MM", hhmm)
+	}
+	from = from.UTC()
+	result := time.Date(from.Year(), from.Month(), from.Day(), at.Hour(), at.Minute(), 0, 0, time.UTC)
+	if result.Before(from) {
+		result = result.AddDate(0, 0, 1)
+	}
+	return result, nil
+}
+
+// ScheduleListCommand lists the schedules on which Actions are queued up.
+type ScheduleListCommand struct {
+	ActionCommandBase
+	out cmd.Output
+}
+
+const scheduleListDoc = `
+List the schedules on which actions are queued up, with the most recent
+runs of each schedule.
+`
+
+func (c *ScheduleListCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "smart", cmd.DefaultFormatters)
+}
+
+func (c *ScheduleListCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "list",
+		Purpose: "list action schedules",
+		Doc:     scheduleListDoc,
+	}
+}
+
+func (c *ScheduleListCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// Run lists the schedules.
+func (c *ScheduleListCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	schedules, err := api.ListSchedules()
+	if err != nil {
+		return err
+	}
+	return c.out.Write(ctx, formatSchedules(schedules))
+}
+
+// formatSchedules converts schedules to a map, keyed by schedule name,
+// for output.
+func formatSchedules(schedules []params.ActionSchedule) map[string]interface{} {
+	result := make(map[string]interface{})
+	for _, schedule := range schedules {
+		formatted := map[string]interface{}{
+			"receiver": schedule.Receiver,
+			"action":   schedule.ActionName,
+			"every":    schedule.Interval.String(),
+			"next-run": schedule.NextRun.UTC().Format(time.RFC3339),
+		}
+		if len(schedule.Parameters) > 0 {
+			formatted["params"] = schedule.Parameters
+		}
+		var runs []map[string]interface{}
+		for _, run := range schedule.Runs {
+			formattedRun := map[string]interface{}{
+				"due": run.Due.UTC().Format(time.RFC3339),
+			}
+			if run.Action != "" {
+				if tag, err := names.ParseActionTag(run.Action); err == nil {
+					formattedRun["id"] = tag.Id()
+				}
+			}
+			if run.Skipped {
+				formattedRun["skipped"] = true
+			}
+			if run.Message != "" {
+				formattedRun["message"] = run.Message
+			}
+			runs = append(runs, formattedRun)
+		}
+		if len(runs) > 0 {
+			formatted["runs"] = runs
+		}
+		result[schedule.Name] = formatted
+	}
+	return result
+}
+
+// ScheduleRemoveCommand removes schedules on which Actions are queued up.
+type ScheduleRemoveCommand struct {
+	ActionCommandBase
+	names []string
+}
+
+const scheduleRemoveDoc = `
+Remove the schedules with the given names.  Actions that the schedules have
+already queued up are not affected.
+`
+
+func (c *ScheduleRemoveCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "remove",
+		Args:    "<name> [<name>...]",
+		Purpose: "remove action schedules",
+		Doc:     scheduleRemoveDoc,
+	}
+}
+
+func (c *ScheduleRemoveCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no schedule name specified")
+	}
+	c.names = args
+	return nil
+}
+
+// Run removes the schedules.
+func (c *ScheduleRemoveCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	results, err := api.RemoveSchedules(params.ActionScheduleNames{Names: c.names})
+	if err != nil {
+		return err
+	}
+	if len(results.Results) != len(c.names) {
+		return errors.Errorf("expected %d results, got %d", len(c.names), len(results.Results))
+	}
+	failed := false
+	for i, result := range results.Results {
+		if result.Error != nil {
+			fmt.Fprintf(ctx.Stderr, "cannot remove schedule %q: %v\n", c.names[i], result.Error)
+			failed = true
+		}
+	}
+	if failed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
@@ -0,0 +1,194 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"bytes"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/action"
+	"github.com/juju/juju/testing"
+)
+
+type ScheduleSuite struct {
+	BaseActionSuite
+	now time.Time
+}
+
+var _ = gc.Suite(&ScheduleSuite{})
+
+func (s *ScheduleSuite) SetUpTest(c *gc.C) {
+	s.BaseActionSuite.SetUpTest(c)
+	s.now = time.Date(2015, 6, 1, 12, 30, 0, 0, time.UTC)
+	s.PatchValue(action.Now, func() time.Time { return s.now })
+}
+
+func (s *ScheduleSuite) TestAddInit(c *gc.C) {
+	for i, test := range []struct {
+		args        []string
+		expectedErr string
+	}{{
+		expectedErr: "no schedule name specified",
+	}, {
+		args:        []string{"backup"},
+		expectedErr: "no unit or service leader specified",
+	}, {
+		args:        []string{"backup", "mysql/0"},
+		expectedErr: "no action specified",
+	}, {
+		args:        []string{"backup", "mysql", "backup", "--every", "1h"},
+		expectedErr: `invalid unit or service leader "mysql"`,
+	}, {
+		args:        []string{"backup", "my$ql/leader", "backup", "--every", "1h"},
+		expectedErr: `invalid unit or service leader "my\$ql/leader"`,
+	}, {
+		args:        []string{"backup", "mysql/0", "Backup", "--every", "1h"},
+		expectedErr: `invalid action name "Backup"`,
+	}, {
+		args:        []string{"backup", "mysql/0", "backup"},
+		expectedErr: "no interval specified with --every",
+	}, {
+		args:        []string{"backup", "mysql/0", "backup", "--every", "1h", "--at", "2am"},
+		expectedErr: `invalid time of day "2am"; expected HH:MM`,
+	}, {
+		args:        []string{"backup", "mysql/0", "backup", "--every", "1h", "foo"},
+		expectedErr: `argument "foo" must be of the form key...=value`,
+	}, {
+		args: []string{"backup", "mysql/leader", "backup", "--every", "24h", "--at", "02:00", "foo=bar"},
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		err := testing.InitCommand(&action.ScheduleAddCommand{}, test.args)
+		if test.expectedErr == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.expectedErr)
+		}
+	}
+}
+
+func (s *ScheduleSuite) TestAdd(c *gc.C) {
+	fakeClient := &fakeAPIClient{
+		errorResults: []params.ErrorResult{{}},
+	}
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	_, err := testing.RunCommand(c, &action.ScheduleAddCommand{},
+		"nightly-backup", "mysql/leader", "backup",
+		"--every", "24h", "--at", "02:00", "outfile.compression=gzip")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fakeClient.addedSchedules, jc.DeepEquals, params.ActionSchedules{
+		Schedules: []params.ActionSchedule{{
+			Name:       "nightly-backup",
+			Receiver:   "mysql/leader",
+			ActionName: "backup",
+			Parameters: map[string]interface{}{
+				"outfile": map[string]interface{}{"compression": "gzip"},
+			},
+			Start:    time.Date(2015, 6, 2, 2, 0, 0, 0, time.UTC),
+			Interval: 24 * time.Hour,
+		}},
+	})
+}
+
+func (s *ScheduleSuite) TestAddStartsNow(c *gc.C) {
+	fakeClient := &fakeAPIClient{
+		errorResults: []params.ErrorResult{{}},
+	}
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	_, err := testing.RunCommand(c, &action.ScheduleAddCommand{},
+		"rotate-logs", "wordpress/0", "rotate", "--every", "6h", "--at", "14:15")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fakeClient.addedSchedules.Schedules, gc.HasLen, 1)
+	c.Assert(fakeClient.addedSchedules.Schedules[0].Start, gc.Equals, time.Date(2015, 6, 1, 14, 15, 0, 0, time.UTC))
+
+	_, err = testing.RunCommand(c, &action.ScheduleAddCommand{},
+		"rotate-logs", "wordpress/0", "rotate", "--every", "6h")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fakeClient.addedSchedules.Schedules[0].Start, gc.Equals, s.now)
+}
+
+func (s *ScheduleSuite) TestAddError(c *gc.C) {
+	fakeClient := &fakeAPIClient{
+		errorResults: []params.ErrorResult{{
+			Error: &params.Error{Message: `action schedule "backup" already exists`},
+		}},
+	}
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	_, err := testing.RunCommand(c, &action.ScheduleAddCommand{},
+		"backup", "mysql/0", "backup", "--every", "24h")
+	c.Assert(err, gc.ErrorMatches, `action schedule "backup" already exists`)
+}
+
+func (s *ScheduleSuite) TestList(c *gc.C) {
+	fakeClient := &fakeAPIClient{
+		schedules: []params.ActionSchedule{{
+			Name:       "nightly-backup",
+			Receiver:   "mysql/leader",
+			ActionName: "backup",
+			Parameters: map[string]interface{}{"outfile": "out.tar.bz2"},
+			Start:      time.Date(2015, 5, 30, 2, 0, 0, 0, time.UTC),
+			Interval:   24 * time.Hour,
+			NextRun:    time.Date(2015, 6, 1, 2, 0, 0, 0, time.UTC),
+			Runs: []params.ActionScheduleRun{{
+				Due:    time.Date(2015, 5, 30, 2, 0, 0, 0, time.UTC),
+				Action: validActionTagString,
+			}, {
+				Due:     time.Date(2015, 5, 31, 2, 0, 0, 0, time.UTC),
+				Skipped: true,
+				Message: "previous run still pending",
+			}},
+		}},
+	}
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	ctx, err := testing.RunCommand(c, &action.ScheduleListCommand{}, "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(ctx.Stdout.(*bytes.Buffer).String(), gc.Equals, `
+nightly-backup:
+  action: backup
+  every: 24h0m0s
+  next-run: "2015-06-01T02:00:00Z"
+  params:
+    outfile: out.tar.bz2
+  receiver: mysql/leader
+  runs:
+  - due: "2015-05-30T02:00:00Z"
+    id: `[1:]+validActionId+`
+  - due: "2015-05-31T02:00:00Z"
+    message: previous run still pending
+    skipped: true
+`)
+}
+
+func (s *ScheduleSuite) TestRemove(c *gc.C) {
+	fakeClient := &fakeAPIClient{
+		errorResults: []params.ErrorResult{{}, {
+			Error: &params.Error{Message: `action schedule "missing" not found`},
+		}},
+	}
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	ctx, err := testing.RunCommand(c, &action.ScheduleRemoveCommand{}, "nightly-backup", "missing")
+	c.Assert(err, gc.ErrorMatches, "cmd: error out silently")
+	c.Assert(fakeClient.removedSchedules, jc.DeepEquals, params.ActionScheduleNames{
+		Names: []string{"nightly-backup", "missing"},
+	})
+	c.Check(testing.Stderr(ctx), gc.Equals, `cannot remove schedule "missing": action schedule "missing" not found`+"\n")
+}
+
+func (s *ScheduleSuite) TestRemoveInit(c *gc.C) {
+	err := testing.InitCommand(&action.ScheduleRemoveCommand{}, nil)
+	c.Assert(err, gc.ErrorMatches, "no schedule name specified")
+}
@@ -0,0 +1,176 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/api/auditlog"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/envcmd"
+)
+
+const auditLogDoc = `
+Show the record of API calls that changed the environment.
+
+Every call made by a user that may change the environment is recorded
+along with its arguments (with secrets redacted) and its outcome. The
+record may be filtered by user, by facade and by time. Times may be
+given in RFC3339 format (e.g. 2015-06-01T12:00:00Z) or as a duration
+before now (e.g. 24h).
+
+Examples:
+    juju audit-log --user bob --since 24h
+    juju audit-log --facade Client --since 2015-06-01T00:00:00Z --until 2015-06-02T00:00:00Z
+`
+
+// AuditLogCommand shows the audit log for an environment.
+type AuditLogCommand struct {
+	envcmd.EnvCommandBase
+	out    cmd.Output
+	api    AuditLogAPI
+	user   string
+	facade string
+	since  string
+	until  string
+	limit  int
+	filter params.AuditLogFilter
+}
+
+// AuditLogAPI defines the API methods used by the audit-log command.
+type AuditLogAPI interface {
+	Close() error
+	Entries(filter params.AuditLogFilter) ([]params.AuditLogEntry, error)
+}
+
+// Info implements Command.Info.
+func (c *AuditLogCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "audit-log",
+		Purpose: "show the API calls that changed the environment",
+		Doc:     auditLogDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *AuditLogCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.StringVar(&c.user, "user", "", "only show calls made by this user")
+	f.StringVar(&c.facade, "facade", "", "only show calls made on this facade")
+	f.StringVar(&c.since, "since", "", "only show calls made at or after this time")
+	f.StringVar(&c.until, "until", "", "only show calls made before this time")
+	f.IntVar(&c.limit, "n", 0, "only show the most recent n calls")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatAuditLogTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *AuditLogCommand) Init(args []string) error {
+	if c.limit < 0 {
+		return errors.Errorf("invalid number of calls %d", c.limit)
+	}
+	now := time.Now()
+	c.filter = params.AuditLogFilter{
+		User:   c.user,
+		Facade: c.facade,
+		Limit:  c.limit,
+	}
+	if c.since != "" {
+		since, err := parseAuditLogTime(c.since, now)
+		if err != nil {
+			return errors.Annotate(err, "invalid --since value")
+		}
+		c.filter.Since = &since
+	}
+	if c.until != "" {
+		until, err := parseAuditLogTime(c.until, now)
+		if err != nil {
+			return errors.Annotate(err, "invalid --until value")
+		}
+		c.filter.Until = &until
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// parseAuditLogTime parses value either as an RFC3339 time or as a
+// duration before now.
+func parseAuditLogTime(value string, now time.Time) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return time.Time{}, errors.Errorf("expected RFC3339 time or positive duration, got %q", value)
+	}
+	return now.Add(-d), nil
+}
+
+func (c *AuditLogCommand) getAPI() (AuditLogAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return auditlog.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *AuditLogCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	entries, err := client.Entries(c.filter)
+	if err != nil {
+		return err
+	}
+	return c.out.Write(ctx, entries)
+}
+
+// formatAuditLogTabular returns a tabular summary of audit log entries.
+func formatAuditLogTabular(value interface{}) ([]byte, error) {
+	entries, ok := value.([]params.AuditLogEntry)
+	if !ok {
+		return nil, errors.Errorf("expected value of type %T, got %T", entries, value)
+	}
+	var out bytes.Buffer
+	const (
+		// To format things into columns.
+		minwidth = 0
+		tabwidth = 1
+		padding  = 2
+		padchar  = ' '
+		flags    = 0
+	)
+	tw := tabwriter.NewWriter(&out, minwidth, tabwidth, padding, padchar, flags)
+	fmt.Fprintf(tw, "TIME\tUSER\tCALL\tOUTCOME\n")
+	for _, entry := range entries {
+		outcome := "ok"
+		if entry.Error != "" {
+			outcome = entry.Error
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s.%s\t%s\n",
+			entry.Timestamp.UTC().Format("2006-01-02 15:04:05Z"),
+			entry.User,
+			entry.Facade,
+			entry.Method,
+			outcome,
+		)
+	}
+	tw.Flush()
+	return out.Bytes(), nil
+}
@@ -0,0 +1,123 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package main
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/envcmd"
+	"github.com/juju/juju/testing"
+)
+
+type AuditLogSuite struct {
+	testing.FakeJujuHomeSuite
+	fake *fakeAuditLogAPI
+}
+
+var _ = gc.Suite(&AuditLogSuite{})
+
+type fakeAuditLogAPI struct {
+	filter  params.AuditLogFilter
+	entries []params.AuditLogEntry
+}
+
+func (f *fakeAuditLogAPI) Close() error {
+	return nil
+}
+
+func (f *fakeAuditLogAPI) Entries(filter params.AuditLogFilter) ([]params.AuditLogEntry, error) {
+	f.filter = filter
+	return f.entries, nil
+}
+
+func (s *AuditLogSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuHomeSuite.SetUpTest(c)
+	t0 := time.Date(2015, 6, 1, 12, 0, 0, 0, time.UTC)
+	s.fake = &fakeAuditLogAPI{
+		entries: []params.AuditLogEntry{{
+			Timestamp: t0,
+			User:      "user-admin@local",
+			Facade:    "Client",
+			Method:    "ServiceDeploy",
+			Args:      `{"ServiceName":"wordpress"}`,
+		}, {
+			Timestamp: t0.Add(time.Minute),
+			User:      "user-bob@local",
+			Facade:    "Client",
+			Method:    "ServiceDestroy",
+			Error:     "permission denied",
+		}},
+	}
+}
+
+func (s *AuditLogSuite) run(c *gc.C, args ...string) (string, error) {
+	context, err := testing.RunCommand(c, envcmd.Wrap(&AuditLogCommand{api: s.fake}), args...)
+	if err != nil {
+		return "", err
+	}
+	return testing.Stdout(context), nil
+}
+
+func (s *AuditLogSuite) TestTabular(c *gc.C) {
+	out, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, ""+
+		"TIME                  USER              CALL                   OUTCOME\n"+
+		"2015-06-01 12:00:00Z  user-admin@local  Client.ServiceDeploy   ok\n"+
+		"2015-06-01 12:01:00Z  user-bob@local    Client.ServiceDestroy  permission denied\n")
+}
+
+func (s *AuditLogSuite) TestFilter(c *gc.C) {
+	_, err := s.run(c,
+		"--user", "bob",
+		"--facade", "Client",
+		"--since", "2015-06-01T00:00:00Z",
+		"--until", "2015-06-02T00:00:00Z",
+		"-n", "10",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	since := time.Date(2015, 6, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2015, 6, 2, 0, 0, 0, 0, time.UTC)
+	c.Assert(s.fake.filter.User, gc.Equals, "bob")
+	c.Assert(s.fake.filter.Facade, gc.Equals, "Client")
+	c.Assert(s.fake.filter.Since.Equal(since), jc.IsTrue)
+	c.Assert(s.fake.filter.Until.Equal(until), jc.IsTrue)
+	c.Assert(s.fake.filter.Limit, gc.Equals, 10)
+}
+
+func (s *AuditLogSuite) TestSinceDuration(c *gc.C) {
+	before := time.Now()
+	_, err := s.run(c, "--since", "24h")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.filter.Since, gc.NotNil)
+	c.Assert(s.fake.filter.Since.Before(before.Add(-23*time.Hour)), jc.IsTrue)
+	c.Assert(s.fake.filter.Until, gc.IsNil)
+}
+
+func (s *AuditLogSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"--since", "yesterday"},
+		err:  `invalid --since value: expected RFC3339 time or positive duration, got "yesterday"`,
+	}, {
+		args: []string{"--until", "-1h"},
+		err:  `invalid --until value: expected RFC3339 time or positive duration, got "-1h"`,
+	}, {
+		args: []string{"-n", "-1"},
+		err:  `invalid number of calls -1`,
+	}, {
+		args: []string{"extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		err := testing.InitCommand(envcmd.Wrap(&AuditLogCommand{}), test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
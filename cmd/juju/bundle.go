@@ -0,0 +1,632 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v5"
+	goyaml "gopkg.in/yaml.v1"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/state/multiwatcher"
+)
+
+// isBundleFile reports whether the deploy argument names a bundle
+// file rather than a charm.
+func isBundleFile(arg string) bool {
+	return strings.HasSuffix(arg, ".yaml")
+}
+
+// readBundleFile reads and verifies the bundle at the given path.
+func readBundleFile(path string) (*charm.BundleData, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer f.Close()
+	data, err := charm.ReadBundleData(f)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot read bundle %q", path)
+	}
+	verifyConstraints := func(s string) error {
+		_, err := constraints.Parse(s)
+		return err
+	}
+	if err := data.Verify(verifyConstraints); err != nil {
+		return nil, errors.Annotatef(err, "invalid bundle %q", path)
+	}
+	return data, nil
+}
+
+// deployBundle deploys the bundle at the given path, adding only
+// those parts of it that are missing from the environment. If dryRun
+// is true, the changes are printed instead of being applied.
+func deployBundle(
+	ctx *cmd.Context,
+	client *api.Client,
+	csClient *csClient,
+	repoPath string,
+	conf *config.Config,
+	bundlePath string,
+	dryRun bool,
+) error {
+	data, err := readBundleFile(bundlePath)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	status, err := client.Status(nil)
+	if err != nil {
+		return errors.Annotate(err, "cannot get environment status")
+	}
+	changes, err := bundleChanges(data, status)
+	if err != nil {
+		return errors.Annotate(err, "cannot deploy bundle")
+	}
+	if len(changes) == 0 {
+		ctx.Infof("No changes to apply; the bundle is already deployed.")
+		return nil
+	}
+	if dryRun {
+		for _, change := range changes {
+			fmt.Fprintln(ctx.Stdout, change)
+		}
+		return nil
+	}
+	h := &bundleHandler{
+		client:       client,
+		ctx:          ctx,
+		csClient:     csClient,
+		repoPath:     repoPath,
+		conf:         conf,
+		charms:       make(map[string]*charm.URL),
+		unitMachines: make(map[string][]string),
+	}
+	for name, service := range status.Services {
+		h.unitMachines[name] = sortedUnitMachines(service)
+	}
+	for _, change := range changes {
+		ctx.Infof("%s", change)
+		if err := change.apply(h); err != nil {
+			return errors.Annotatef(err, "cannot %s", change)
+		}
+	}
+	return nil
+}
+
+// bundleChange is a single step in deploying a bundle.
+type bundleChange interface {
+	// String describes the change, as printed by "juju deploy --dry-run".
+	String() string
+
+	// apply makes the change in the environment.
+	apply(h *bundleHandler) error
+}
+
+// bundleHandler holds the state required to apply bundle changes,
+// including the results of changes that later changes refer to.
+type bundleHandler struct {
+	client   *api.Client
+	ctx      *cmd.Context
+	csClient *csClient
+	repoPath string
+	conf     *config.Config
+
+	// charms maps the charms named in the bundle to the URLs
+	// they were added to the environment with.
+	charms map[string]*charm.URL
+
+	// newMachines holds the IDs of the machines added so far,
+	// in the order they were added.
+	newMachines []string
+
+	// unitMachines holds the IDs of the machines hosting the
+	// units of each service, ordered by unit number.
+	unitMachines map[string][]string
+}
+
+// resolve returns the ID of the machine identified by the target.
+func (h *bundleHandler) resolve(target machineTarget) (string, error) {
+	switch {
+	case target.machineId != "":
+		return target.machineId, nil
+	case target.unitService != "":
+		machines := h.unitMachines[target.unitService]
+		if target.unitIndex >= len(machines) {
+			return "", errors.Errorf(
+				"service %q has no unit %d", target.unitService, target.unitIndex,
+			)
+		}
+		return machines[target.unitIndex], nil
+	}
+	if target.newMachine >= len(h.newMachines) {
+		return "", errors.Errorf("new machine %d has not been added", target.newMachine)
+	}
+	return h.newMachines[target.newMachine], nil
+}
+
+// machineTarget identifies the machine that a unit is placed on: an
+// existing machine, a machine added by the same set of changes, or the
+// machine hosting a unit of another service.
+type machineTarget struct {
+	machineId   string
+	newMachine  int
+	unitService string
+	unitIndex   int
+}
+
+func (t machineTarget) String() string {
+	switch {
+	case t.machineId != "":
+		return "machine " + t.machineId
+	case t.unitService != "":
+		return fmt.Sprintf("the machine of %s unit %d", t.unitService, t.unitIndex)
+	}
+	return fmt.Sprintf("new machine %d", t.newMachine)
+}
+
+type addCharmChange struct {
+	charm string
+}
+
+func (c *addCharmChange) String() string {
+	return "add charm " + c.charm
+}
+
+func (c *addCharmChange) apply(h *bundleHandler) error {
+	curl, repo, err := resolveCharmURL(c.charm, h.csClient.params, h.repoPath, h.conf)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	curl, err = addCharmViaAPI(h.client, h.ctx, curl, repo, h.csClient)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	h.charms[c.charm] = curl
+	return nil
+}
+
+type deployChange struct {
+	service     string
+	charm       string
+	options     map[string]interface{}
+	constraints string
+}
+
+func (c *deployChange) String() string {
+	return fmt.Sprintf("deploy service %s using %s", c.service, c.charm)
+}
+
+func (c *deployChange) apply(h *bundleHandler) error {
+	curl, ok := h.charms[c.charm]
+	if !ok {
+		return errors.Errorf("charm %q has not been added", c.charm)
+	}
+	var configYAML string
+	if len(c.options) > 0 {
+		data, err := goyaml.Marshal(map[string]interface{}{c.service: c.options})
+		if err != nil {
+			return errors.Trace(err)
+		}
+		configYAML = string(data)
+	}
+	cons, err := constraints.Parse(c.constraints)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// Units are added separately, so that each may be placed
+	// according to the bundle's placement directives.
+	return h.client.ServiceDeploy(curl.String(), c.service, 0, configYAML, cons, "")
+}
+
+type addMachineChange struct {
+	index         int
+	bundleMachine string
+	series        string
+	constraints   string
+}
+
+func (c *addMachineChange) String() string {
+	if c.bundleMachine != "" {
+		return fmt.Sprintf("add new machine %d (bundle machine %s)", c.index, c.bundleMachine)
+	}
+	return fmt.Sprintf("add new machine %d", c.index)
+}
+
+func (c *addMachineChange) apply(h *bundleHandler) error {
+	if c.index != len(h.newMachines) {
+		return errors.Errorf("machines added out of order")
+	}
+	cons, err := constraints.Parse(c.constraints)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	results, err := h.client.AddMachines([]params.AddMachineParams{{
+		Series:      c.series,
+		Constraints: cons,
+		Jobs:        []multiwatcher.MachineJob{multiwatcher.JobHostUnits},
+	}})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if results[0].Error != nil {
+		return results[0].Error
+	}
+	h.newMachines = append(h.newMachines, results[0].Machine)
+	return nil
+}
+
+type addUnitChange struct {
+	service       string
+	containerType instance.ContainerType
+	target        *machineTarget
+}
+
+func (c *addUnitChange) String() string {
+	s := "add unit of " + c.service
+	if c.target == nil {
+		return s
+	}
+	if c.containerType != "" {
+		return fmt.Sprintf("%s to %s container on %s", s, c.containerType, c.target)
+	}
+	return fmt.Sprintf("%s to %s", s, c.target)
+}
+
+func (c *addUnitChange) apply(h *bundleHandler) error {
+	var machineSpec string
+	if c.target != nil {
+		machineId, err := h.resolve(*c.target)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		machineSpec = machineId
+		if c.containerType != "" {
+			machineSpec = fmt.Sprintf("%s:%s", c.containerType, machineId)
+		}
+	}
+	units, err := h.client.AddServiceUnits(c.service, 1, machineSpec)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// Record the unit's machine, so later units may be placed with it.
+	status, err := h.client.Status(units)
+	if err != nil {
+		return errors.Annotate(err, "cannot get unit status")
+	}
+	unit, ok := status.Services[c.service].Units[units[0]]
+	if !ok {
+		return errors.Errorf("unit %q not found", units[0])
+	}
+	h.unitMachines[c.service] = append(h.unitMachines[c.service], unit.Machine)
+	return nil
+}
+
+type addRelationChange struct {
+	endpoints []string
+}
+
+func (c *addRelationChange) String() string {
+	return "add relation " + strings.Join(c.endpoints, " ")
+}
+
+func (c *addRelationChange) apply(h *bundleHandler) error {
+	_, err := h.client.AddRelation(c.endpoints...)
+	return errors.Trace(err)
+}
+
+type exposeChange struct {
+	service string
+}
+
+func (c *exposeChange) String() string {
+	return "expose " + c.service
+}
+
+func (c *exposeChange) apply(h *bundleHandler) error {
+	return h.client.ServiceExpose(c.service)
+}
+
+// bundleChanges returns the changes required to deploy the bundle in
+// an environment with the given status. Services, units, relations and
+// exposure already present in the environment are not changed, so the
+// result is empty once the bundle has been deployed.
+//
+// Bundle machines are only added when a unit that is not yet deployed
+// must be placed on them; a bundle machine hosting any deployed unit is
+// taken to be the machine that unit is on.
+func bundleChanges(data *charm.BundleData, status *api.Status) ([]bundleChange, error) {
+	b := &bundleChangesBuilder{
+		data:           data,
+		status:         status,
+		bundleMachines: make(map[string]machineTarget),
+	}
+	serviceNames, err := b.serviceOrder()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	// Add charms and deploy the services that do not yet exist.
+	var deploys []bundleChange
+	charms := make(map[string]bool)
+	for _, name := range serviceNames {
+		spec := data.Services[name]
+		if existing, ok := status.Services[name]; ok {
+			if err := checkServiceCharm(name, spec.Charm, existing.Charm); err != nil {
+				return nil, errors.Trace(err)
+			}
+			continue
+		}
+		if !charms[spec.Charm] {
+			charms[spec.Charm] = true
+			b.changes = append(b.changes, &addCharmChange{spec.Charm})
+		}
+		deploys = append(deploys, &deployChange{
+			service:     name,
+			charm:       spec.Charm,
+			options:     spec.Options,
+			constraints: spec.Constraints,
+		})
+	}
+	b.changes = append(b.changes, deploys...)
+
+	// Add the missing units, and any machines they are placed on.
+	if err := b.mapBundleMachines(serviceNames); err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, name := range serviceNames {
+		spec := data.Services[name]
+		existing := len(status.Services[name].Units)
+		for i := existing; i < spec.NumUnits; i++ {
+			change, err := b.addUnit(name, spec, i)
+			if err != nil {
+				return nil, errors.Annotatef(err, "cannot place unit %d of %s", i, name)
+			}
+			b.changes = append(b.changes, change)
+		}
+	}
+
+	for _, endpoints := range data.Relations {
+		if !relationExists(status.Relations, endpoints) {
+			b.changes = append(b.changes, &addRelationChange{endpoints})
+		}
+	}
+	for _, name := range serviceNames {
+		if !data.Services[name].Expose || status.Services[name].Exposed {
+			continue
+		}
+		b.changes = append(b.changes, &exposeChange{name})
+	}
+	return b.changes, nil
+}
+
+// bundleChangesBuilder holds the intermediate state of bundleChanges.
+type bundleChangesBuilder struct {
+	data    *charm.BundleData
+	status  *api.Status
+	changes []bundleChange
+
+	// bundleMachines maps bundle machine IDs to the
+	// machines that represent them in the environment.
+	bundleMachines map[string]machineTarget
+
+	// newMachines is the number of machines added so far.
+	newMachines int
+}
+
+// serviceOrder returns the bundle's service names, ordered so that
+// services are placed after the services their units are placed with.
+func (b *bundleChangesBuilder) serviceOrder() ([]string, error) {
+	var remaining []string
+	for name := range b.data.Services {
+		remaining = append(remaining, name)
+	}
+	sort.Strings(remaining)
+	ordered := make([]string, 0, len(remaining))
+	placed := make(map[string]bool)
+	for len(remaining) > 0 {
+		var next []string
+		for _, name := range remaining {
+			ready := true
+			for _, to := range b.data.Services[name].To {
+				p, err := charm.ParsePlacement(to)
+				if err != nil {
+					return nil, errors.Trace(err)
+				}
+				if p.Service != "" && p.Service != name && !placed[p.Service] {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				ordered = append(ordered, name)
+				placed[name] = true
+			} else {
+				next = append(next, name)
+			}
+		}
+		if len(next) == len(remaining) {
+			return nil, errors.Errorf("cyclic placement of services %s", strings.Join(next, ", "))
+		}
+		remaining = next
+	}
+	return ordered, nil
+}
+
+// mapBundleMachines records the machines of deployed units as the
+// bundle machines those units are placed on.
+func (b *bundleChangesBuilder) mapBundleMachines(serviceNames []string) error {
+	for _, name := range serviceNames {
+		spec := b.data.Services[name]
+		machines := sortedUnitMachines(b.status.Services[name])
+		for i, machineId := range machines {
+			if i >= len(spec.To) {
+				break
+			}
+			p, err := charm.ParsePlacement(spec.To[i])
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if p.Machine == "" || p.Machine == "new" {
+				continue
+			}
+			if p.ContainerType != "" {
+				// The unit is in a container on the bundle machine.
+				machineId = strings.SplitN(machineId, "/", 2)[0]
+			}
+			if _, ok := b.bundleMachines[p.Machine]; !ok {
+				b.bundleMachines[p.Machine] = machineTarget{machineId: machineId}
+			}
+		}
+	}
+	return nil
+}
+
+// addUnit returns the change that adds unit i of the named service,
+// adding any machine the unit is placed on. Units without a placement
+// directive are placed by Juju.
+func (b *bundleChangesBuilder) addUnit(name string, spec *charm.ServiceSpec, i int) (bundleChange, error) {
+	change := &addUnitChange{service: name}
+	if i >= len(spec.To) {
+		return change, nil
+	}
+	p, err := charm.ParsePlacement(spec.To[i])
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	change.containerType = instance.ContainerType(p.ContainerType)
+	switch {
+	case p.Service != "":
+		index := p.Unit
+		if index < 0 {
+			numUnits := b.data.Services[p.Service].NumUnits
+			if numUnits == 0 {
+				return nil, errors.Errorf("service %q has no units", p.Service)
+			}
+			index = i % numUnits
+		}
+		change.target = &machineTarget{unitService: p.Service, unitIndex: index}
+	case p.Machine == "new":
+		var cons string
+		if p.ContainerType == "" {
+			cons = spec.Constraints
+		}
+		target := b.addMachine("", charmSeries(spec.Charm), cons)
+		change.target = &target
+	default:
+		target, ok := b.bundleMachines[p.Machine]
+		if !ok {
+			var series, cons string
+			if machine := b.data.Machines[p.Machine]; machine != nil {
+				series, cons = machine.Series, machine.Constraints
+			}
+			target = b.addMachine(p.Machine, series, cons)
+			b.bundleMachines[p.Machine] = target
+		}
+		change.target = &target
+	}
+	return change, nil
+}
+
+// addMachine adds a change that adds a new machine, and
+// returns the target identifying that machine.
+func (b *bundleChangesBuilder) addMachine(bundleMachine, series, cons string) machineTarget {
+	index := b.newMachines
+	b.newMachines++
+	b.changes = append(b.changes, &addMachineChange{
+		index:         index,
+		bundleMachine: bundleMachine,
+		series:        series,
+		constraints:   cons,
+	})
+	return machineTarget{newMachine: index}
+}
+
+// checkServiceCharm returns an error if an existing service
+// was deployed with a different charm than the bundle specifies.
+func checkServiceCharm(service, bundleCharm, existingCharm string) error {
+	ref, err := charm.ParseReference(bundleCharm)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	curl, err := charm.ParseURL(existingCharm)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if ref.Name != curl.Name {
+		return errors.Errorf(
+			"service %q already exists with charm %q, not %q",
+			service, existingCharm, bundleCharm,
+		)
+	}
+	return nil
+}
+
+// charmSeries returns the series in the given charm reference,
+// or the empty string if it does not specify one.
+func charmSeries(charmRef string) string {
+	ref, err := charm.ParseReference(charmRef)
+	if err != nil {
+		return ""
+	}
+	return ref.Series
+}
+
+// sortedUnitMachines returns the IDs of the machines hosting
+// the service's units, ordered by unit number.
+func sortedUnitMachines(service api.ServiceStatus) []string {
+	numbers := make([]int, 0, len(service.Units))
+	machines := make(map[int]string)
+	for unitName, unit := range service.Units {
+		n, err := strconv.Atoi(unitName[strings.LastIndex(unitName, "/")+1:])
+		if err != nil {
+			continue
+		}
+		numbers = append(numbers, n)
+		machines[n] = unit.Machine
+	}
+	sort.Ints(numbers)
+	result := make([]string, len(numbers))
+	for i, n := range numbers {
+		result[i] = machines[n]
+	}
+	return result
+}
+
+// relationExists reports whether a relation between the given
+// endpoints, specified as "service[:relation]", exists.
+func relationExists(relations []api.RelationStatus, endpoints []string) bool {
+	for _, rel := range relations {
+		if len(rel.Endpoints) != len(endpoints) {
+			continue
+		}
+		matched := 0
+		for _, endpoint := range endpoints {
+			service, relation := endpoint, ""
+			if i := strings.Index(endpoint, ":"); i >= 0 {
+				service, relation = endpoint[:i], endpoint[i+1:]
+			}
+			for _, ep := range rel.Endpoints {
+				if ep.ServiceName == service && (relation == "" || ep.Name == relation) {
+					matched++
+					break
+				}
+			}
+		}
+		if matched == len(endpoints) {
+			return true
+		}
+	}
+	return false
+}
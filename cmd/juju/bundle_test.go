@@ -0,0 +1,188 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package main
+
+import (
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v5"
+
+	"github.com/juju/juju/api"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type BundleChangesSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&BundleChangesSuite{})
+
+const wordpressBundle = `
+services:
+    wordpress:
+        charm: cs:trusty/wordpress-42
+        num_units: 2
+        to: ["1", "lxc:1"]
+        expose: true
+        options:
+            blog-title: hello
+    mysql:
+        charm: cs:trusty/mysql-28
+        num_units: 1
+        to: ["wordpress/0"]
+        constraints: mem=4G
+machines:
+    "1":
+        series: trusty
+        constraints: cpu-cores=2
+relations:
+    - ["wordpress:db", "mysql:server"]
+`
+
+func readBundle(c *gc.C, bundle string) *charm.BundleData {
+	data, err := charm.ReadBundleData(strings.NewReader(bundle))
+	c.Assert(err, jc.ErrorIsNil)
+	return data
+}
+
+func bundleChangeStrings(changes []bundleChange) []string {
+	result := make([]string, len(changes))
+	for i, change := range changes {
+		result[i] = change.String()
+	}
+	return result
+}
+
+func (s *BundleChangesSuite) TestEmptyEnvironment(c *gc.C) {
+	changes, err := bundleChanges(readBundle(c, wordpressBundle), &api.Status{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(bundleChangeStrings(changes), jc.DeepEquals, []string{
+		"add charm cs:trusty/wordpress-42",
+		"add charm cs:trusty/mysql-28",
+		"deploy service wordpress using cs:trusty/wordpress-42",
+		"deploy service mysql using cs:trusty/mysql-28",
+		"add new machine 0 (bundle machine 1)",
+		"add unit of wordpress to new machine 0",
+		"add unit of wordpress to lxc container on new machine 0",
+		"add unit of mysql to the machine of wordpress unit 0",
+		"add relation wordpress:db mysql:server",
+		"expose wordpress",
+	})
+	c.Assert(changes[2], jc.DeepEquals, &deployChange{
+		service: "wordpress",
+		charm:   "cs:trusty/wordpress-42",
+		options: map[string]interface{}{"blog-title": "hello"},
+	})
+	c.Assert(changes[3], jc.DeepEquals, &deployChange{
+		service:     "mysql",
+		charm:       "cs:trusty/mysql-28",
+		constraints: "mem=4G",
+	})
+	c.Assert(changes[4], jc.DeepEquals, &addMachineChange{
+		index:         0,
+		bundleMachine: "1",
+		series:        "trusty",
+		constraints:   "cpu-cores=2",
+	})
+}
+
+func (s *BundleChangesSuite) TestAlreadyDeployed(c *gc.C) {
+	status := &api.Status{
+		Services: map[string]api.ServiceStatus{
+			"wordpress": {
+				Charm:   "cs:trusty/wordpress-42",
+				Exposed: true,
+				Units: map[string]api.UnitStatus{
+					"wordpress/0": {Machine: "1"},
+					"wordpress/1": {Machine: "1/lxc/0"},
+				},
+			},
+			"mysql": {
+				Charm: "cs:trusty/mysql-28",
+				Units: map[string]api.UnitStatus{
+					"mysql/0": {Machine: "1"},
+				},
+			},
+		},
+		Relations: []api.RelationStatus{{
+			Endpoints: []api.EndpointStatus{
+				{ServiceName: "mysql", Name: "server"},
+				{ServiceName: "wordpress", Name: "db"},
+			},
+		}},
+	}
+	changes, err := bundleChanges(readBundle(c, wordpressBundle), status)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(changes, gc.HasLen, 0)
+}
+
+func (s *BundleChangesSuite) TestPartiallyDeployed(c *gc.C) {
+	// The deployed wordpress unit is on bundle machine "1",
+	// so the missing unit is placed on the same machine.
+	status := &api.Status{
+		Services: map[string]api.ServiceStatus{
+			"wordpress": {
+				Charm: "cs:trusty/wordpress-41",
+				Units: map[string]api.UnitStatus{
+					"wordpress/3": {Machine: "4"},
+				},
+			},
+		},
+	}
+	changes, err := bundleChanges(readBundle(c, wordpressBundle), status)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(bundleChangeStrings(changes), jc.DeepEquals, []string{
+		"add charm cs:trusty/mysql-28",
+		"deploy service mysql using cs:trusty/mysql-28",
+		"add unit of wordpress to lxc container on machine 4",
+		"add unit of mysql to the machine of wordpress unit 0",
+		"add relation wordpress:db mysql:server",
+		"expose wordpress",
+	})
+}
+
+func (s *BundleChangesSuite) TestServiceCharmMismatch(c *gc.C) {
+	status := &api.Status{
+		Services: map[string]api.ServiceStatus{
+			"wordpress": {Charm: "cs:trusty/mediawiki-1"},
+		},
+	}
+	_, err := bundleChanges(readBundle(c, wordpressBundle), status)
+	c.Assert(err, gc.ErrorMatches, `service "wordpress" already exists with charm "cs:trusty/mediawiki-1", not "cs:trusty/wordpress-42"`)
+}
+
+func (s *BundleChangesSuite) TestNewMachinePlacement(c *gc.C) {
+	bundle := `
+services:
+    django:
+        charm: cs:trusty/django-1
+        num_units: 3
+        to: ["new", "kvm:new"]
+        constraints: mem=2G
+`
+	changes, err := bundleChanges(readBundle(c, bundle), &api.Status{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(bundleChangeStrings(changes), jc.DeepEquals, []string{
+		"add charm cs:trusty/django-1",
+		"deploy service django using cs:trusty/django-1",
+		"add new machine 0",
+		"add unit of django to new machine 0",
+		"add new machine 1",
+		"add unit of django to kvm container on new machine 1",
+		"add unit of django",
+	})
+	// Service constraints apply to new machines that host units
+	// directly, but not to the hosts of containers.
+	c.Assert(changes[2], jc.DeepEquals, &addMachineChange{
+		index:       0,
+		series:      "trusty",
+		constraints: "mem=2G",
+	})
+	c.Assert(changes[4], jc.DeepEquals, &addMachineChange{
+		index:  1,
+		series: "trusty",
+	})
+}
@@ -35,12 +35,24 @@ type DeployCommand struct {
 	BumpRevision bool   // Remove this once the 1.16 support is dropped.
 	RepoPath     string // defaults to JUJU_REPOSITORY
 
+	// BundlePath is the path of the bundle file to deploy, if
+	// a bundle is being deployed instead of a charm.
+	BundlePath string
+
+	// DryRun indicates that the changes needed to deploy the
+	// bundle should be printed rather than applied.
+	DryRun bool
+
 	// TODO(axw) move this to UnitCommandBase once we support --storage
 	// on add-unit too.
 	//
 	// Storage is a map of storage constraints, keyed on the storage name
 	// defined in charm storage metadata.
 	Storage map[string]storage.Constraints
+
+	// Bindings maps the names of the charm's endpoints to the names
+	// of the network spaces they are bound to.
+	Bindings map[string]string
 }
 
 const deployDoc = `
@@ -94,6 +106,7 @@ Examples:
    juju deploy mysql --to 23       (deploy to machine 23)
    juju deploy mysql --to 24/lxc/3 (deploy to lxc container 3 on host machine 24)
    juju deploy mysql --to lxc:25   (deploy to a new lxc container on host machine 25)
+   juju deploy mysql --to lxd:3    (deploy to a new lxd container on host machine 3)
 
    juju deploy mysql -n 5 --constraints mem=8G
    (deploy 5 instances of mysql with at least 8 GB of RAM each)
@@ -111,6 +124,32 @@ to configure on it. The --networks argument instructs juju to add all the
 networks specified with it to all new machines deployed to host units of
 the service. Not supported on all providers.
 
+The endpoints of the service's charm can be bound to network spaces
+with the --bind argument, which takes a comma- or space-delimited list
+of endpoint=space pairs. Units of the service then use addresses in the
+bound space's subnets for that endpoint, and new machines for them are
+started in one of those subnets. All of a service's endpoints must be
+bound to the same space, and --bind fails on providers that cannot start
+machines in particular subnets.
+
+Examples:
+   juju deploy mysql --bind db=internal
+   juju deploy wordpress --bind "db=internal website=internal"
+
+A bundle of services can be deployed by specifying the path of a bundle
+YAML file in place of <charm name>. The bundle describes the services
+to deploy, with their charms, options, constraints, number of units and
+unit placement, the machines to place units on, and the relations between
+the services. Parts of the bundle that already exist in the environment
+are left alone, so deploying a bundle again only adds what is missing.
+Units with no placement directive are placed by Juju. Use --dry-run to
+print the changes that deploying the bundle would make without applying
+them.
+
+Examples:
+   juju deploy ./wordpress-bundle.yaml
+   juju deploy ./wordpress-bundle.yaml --dry-run
+
 See Also:
    juju help constraints
    juju help set-constraints
@@ -120,7 +159,7 @@ See Also:
 func (c *DeployCommand) Info() *cmd.Info {
 	return &cmd.Info{
 		Name:    "deploy",
-		Args:    "<charm name> [<service name>]",
+		Args:    "<charm name> [<service name>] | <bundle file>",
 		Purpose: "deploy a new service",
 		Doc:     deployDoc,
 	}
@@ -136,9 +175,17 @@ func (c *DeployCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.StringVar(&c.Networks, "networks", "", "bind the service to specific networks")
 	f.StringVar(&c.RepoPath, "repository", os.Getenv(osenv.JujuRepositoryEnvKey), "local charm repository")
 	f.Var(storageFlag{&c.Storage}, "storage", "charm storage constraints")
+	f.Var(bindFlag{&c.Bindings}, "bind", "bind charm endpoints to network spaces")
+	f.BoolVar(&c.DryRun, "dry-run", false, "print the changes needed to deploy a bundle without applying them")
 }
 
 func (c *DeployCommand) Init(args []string) error {
+	if len(args) > 0 && isBundleFile(args[0]) {
+		return c.initBundle(args)
+	}
+	if c.DryRun {
+		return errors.New("--dry-run can only be used when deploying a bundle")
+	}
 	switch len(args) {
 	case 2:
 		if !names.IsValidService(args[1]) {
@@ -159,6 +206,42 @@ func (c *DeployCommand) Init(args []string) error {
 	return c.UnitCommandBase.Init(args)
 }
 
+// initBundle initialises the command for deploying a bundle. The
+// bundle specifies everything that the charm flags would, so those
+// flags may not be used with it.
+func (c *DeployCommand) initBundle(args []string) error {
+	if err := cmd.CheckEmpty(args[1:]); err != nil {
+		return err
+	}
+	var flags []string
+	if c.Config.Path != "" {
+		flags = append(flags, "--config")
+	}
+	if !constraints.IsEmpty(&c.Constraints) {
+		flags = append(flags, "--constraints")
+	}
+	if c.Networks != "" {
+		flags = append(flags, "--networks")
+	}
+	if len(c.Storage) > 0 {
+		flags = append(flags, "--storage")
+	}
+	if len(c.Bindings) > 0 {
+		flags = append(flags, "--bind")
+	}
+	if c.NumUnits != 1 {
+		flags = append(flags, "--num-units")
+	}
+	if c.ToMachineSpec != "" {
+		flags = append(flags, "--to")
+	}
+	if len(flags) > 0 {
+		return fmt.Errorf("cannot use %s when deploying a bundle", strings.Join(flags, ", "))
+	}
+	c.BundlePath = args[0]
+	return nil
+}
+
 func (c *DeployCommand) newServiceAPIClient() (*apiservice.Client, error) {
 	root, err := c.NewAPIRoot()
 	if err != nil {
@@ -188,6 +271,13 @@ func (c *DeployCommand) Run(ctx *cmd.Context) error {
 		return errors.Trace(err)
 	}
 	defer csClient.jar.Save()
+	if c.BundlePath != "" {
+		err := deployBundle(
+			ctx, client, csClient, ctx.AbsPath(c.RepoPath), conf,
+			ctx.AbsPath(c.BundlePath), c.DryRun,
+		)
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
 	curl, repo, err := resolveCharmURL(c.CharmName, csClient.params, ctx.AbsPath(c.RepoPath), conf)
 	if err != nil {
 		return errors.Trace(err)
@@ -246,9 +336,10 @@ func (c *DeployCommand) Run(ctx *cmd.Context) error {
 		}
 	}
 
-	// If storage is specified, we attempt to use a new API on the service facade.
-	if len(c.Storage) > 0 {
-		notSupported := errors.New("cannot deploy charms with storage: not supported by the API server")
+	// If storage or endpoint bindings are specified, we attempt to use
+	// a new API on the service facade.
+	if len(c.Storage) > 0 || len(c.Bindings) > 0 {
+		notSupported := errors.New("cannot deploy charms with storage or endpoint bindings: not supported by the API server")
 		serviceClient, err := c.newServiceAPIClient()
 		if err != nil {
 			return notSupported
@@ -263,6 +354,7 @@ func (c *DeployCommand) Run(ctx *cmd.Context) error {
 			c.ToMachineSpec,
 			requestedNetworks,
 			c.Storage,
+			c.Bindings,
 		)
 		if params.IsCodeNotImplemented(err) {
 			return notSupported
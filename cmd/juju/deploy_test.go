@@ -8,6 +8,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"path/filepath"
 	"strings"
 
 	"github.com/juju/errors"
@@ -82,6 +83,24 @@ var initErrorTests = []struct {
 	}, {
 		args: []string{"craziness", "burble1", "--constraints", "gibber=plop"},
 		err:  `invalid value "gibber=plop" for flag --constraints: unknown constraint "gibber"`,
+	}, {
+		args: []string{"craziness", "--dry-run"},
+		err:  `--dry-run can only be used when deploying a bundle`,
+	}, {
+		args: []string{"craziness", "--bind", "db"},
+		err:  `invalid value "db" for flag --bind: expected <endpoint>=<space>`,
+	}, {
+		args: []string{"bundle.yaml", "burble1"},
+		err:  `unrecognized args: \["burble1"\]`,
+	}, {
+		args: []string{"bundle.yaml", "-n", "2"},
+		err:  `cannot use --num-units when deploying a bundle`,
+	}, {
+		args: []string{"bundle.yaml", "--config", "config.yaml", "--to", "1"},
+		err:  `cannot use --config, --to when deploying a bundle`,
+	}, {
+		args: []string{"bundle.yaml", "--bind", "db=internal"},
+		err:  `cannot use --bind when deploying a bundle`,
 	},
 }
 
@@ -244,6 +263,38 @@ func (s *DeploySuite) TestStorage(c *gc.C) {
 	})
 }
 
+func (s *DeploySuite) TestBind(c *gc.C) {
+	_, err := s.State.AddSpace("internal", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	testcharms.Repo.CharmArchivePath(s.SeriesPath, "wordpress")
+	err = runDeploy(c, "local:wordpress", "--bind", "db=internal url=internal")
+	c.Assert(err, jc.ErrorIsNil)
+	curl := charm.MustParseURL("local:trusty/wordpress-3")
+	service, _ := s.AssertService(c, "wordpress", curl, 1, 0)
+	c.Assert(service.EndpointBindings(), jc.DeepEquals, map[string]string{
+		"db":  "internal",
+		"url": "internal",
+	})
+}
+
+func (s *DeploySuite) TestBindMultipleSpaces(c *gc.C) {
+	_, err := s.State.AddSpace("internal", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSpace("public", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	testcharms.Repo.CharmArchivePath(s.SeriesPath, "wordpress")
+	err = runDeploy(c, "local:wordpress", "--bind", "db=internal url=public")
+	c.Assert(err, gc.ErrorMatches, `cannot set endpoint bindings for service "wordpress": binding endpoints to more than one space \(internal, public\) not supported`)
+}
+
+func (s *DeploySuite) TestBindUnknownSpace(c *gc.C) {
+	testcharms.Repo.CharmArchivePath(s.SeriesPath, "wordpress")
+	err := runDeploy(c, "local:wordpress", "--bind", "db=internal")
+	c.Assert(err, gc.ErrorMatches, `cannot set endpoint bindings for service "wordpress": space "internal" not found`)
+}
+
 func (s *DeploySuite) TestSubordinateConstraints(c *gc.C) {
 	testcharms.Repo.CharmArchivePath(s.SeriesPath, "logging")
 	err := runDeploy(c, "local:logging", "--constraints", "mem=1G")
@@ -337,6 +388,79 @@ func (s *DeploySuite) TestNonLocalCannotHostUnits(c *gc.C) {
 	c.Assert(err, gc.Not(gc.ErrorMatches), "machine 0 is the state server for a local environment and cannot host units")
 }
 
+const wordpressMysqlBundle = `
+services:
+    wordpress:
+        charm: local:wordpress
+        num_units: 1
+        expose: true
+    mysql:
+        charm: local:mysql
+        num_units: 1
+relations:
+    - ["wordpress:db", "mysql:server"]
+`
+
+func (s *DeploySuite) TestDeployBundle(c *gc.C) {
+	testcharms.Repo.CharmArchivePath(s.SeriesPath, "wordpress")
+	testcharms.Repo.CharmArchivePath(s.SeriesPath, "mysql")
+	path := setupBundleFile(c, c.MkDir(), wordpressMysqlBundle)
+	err := runDeploy(c, path)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertBundleDeployed(c)
+
+	// Deploying the bundle again makes no changes.
+	ctx, err := coretesting.RunCommand(c, envcmd.Wrap(&DeployCommand{}), path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(coretesting.Stderr(ctx), gc.Equals, "No changes to apply; the bundle is already deployed.\n")
+	s.assertBundleDeployed(c)
+}
+
+func (s *DeploySuite) TestDeployBundleAddsMissing(c *gc.C) {
+	testcharms.Repo.CharmArchivePath(s.SeriesPath, "wordpress")
+	testcharms.Repo.CharmArchivePath(s.SeriesPath, "mysql")
+	err := runDeploy(c, "local:mysql")
+	c.Assert(err, jc.ErrorIsNil)
+
+	path := setupBundleFile(c, c.MkDir(), wordpressMysqlBundle)
+	err = runDeploy(c, path)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertBundleDeployed(c)
+}
+
+func (s *DeploySuite) TestDeployBundleDryRun(c *gc.C) {
+	path := setupBundleFile(c, c.MkDir(), wordpressMysqlBundle)
+	ctx, err := coretesting.RunCommand(c, envcmd.Wrap(&DeployCommand{}), path, "--dry-run")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(coretesting.Stdout(ctx), gc.Equals, ""+
+		"add charm local:mysql\n"+
+		"add charm local:wordpress\n"+
+		"deploy service mysql using local:mysql\n"+
+		"deploy service wordpress using local:wordpress\n"+
+		"add unit of mysql\n"+
+		"add unit of wordpress\n"+
+		"add relation wordpress:db mysql:server\n"+
+		"expose wordpress\n",
+	)
+	services, err := s.State.AllServices()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(services, gc.HasLen, 0)
+}
+
+func (s *DeploySuite) assertBundleDeployed(c *gc.C) {
+	for _, name := range []string{"wordpress", "mysql"} {
+		service, err := s.State.Service(name)
+		c.Assert(err, jc.ErrorIsNil)
+		units, err := service.AllUnits()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(units, gc.HasLen, 1)
+		rels, err := service.Relations()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(rels, gc.HasLen, 1)
+		c.Assert(service.IsExposed(), gc.Equals, name == "wordpress")
+	}
+}
+
 type DeployLocalSuite struct {
 	testing.RepoSuite
 }
@@ -374,6 +498,13 @@ func setupConfigFile(c *gc.C, dir string) string {
 	return path
 }
 
+func setupBundleFile(c *gc.C, dir, content string) string {
+	path := filepath.Join(dir, "bundle.yaml")
+	err := ioutil.WriteFile(path, []byte(content), 0666)
+	c.Assert(err, jc.ErrorIsNil)
+	return path
+}
+
 type DeployCharmStoreSuite struct {
 	charmStoreSuite
 }
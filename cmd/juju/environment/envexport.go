@@ -0,0 +1,98 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package environment
+
+import (
+	"io/ioutil"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/api/environmentmigration"
+	"github.com/juju/juju/cmd/envcmd"
+)
+
+const exportEnvHelpDoc = `
+Export writes a description of the current environment's model to a
+YAML document. The description includes the environment's machines,
+services, units, relations, settings, constraints, storage, annotations
+and users, but not its configuration.
+
+The description can be imported into an empty environment, possibly
+managed by another state server, with "juju environment import".
+
+Examples:
+ juju environment export
+     Write the description of the current environment to stdout
+
+ juju environment export -o myenv.yaml
+     Write the description of the current environment to myenv.yaml
+`
+
+// ExportCommand writes a description of the environment's model.
+type ExportCommand struct {
+	envcmd.EnvCommandBase
+	api ExportAPI
+
+	// Output is the file to write the description to, or empty
+	// to write it to stdout.
+	Output string
+}
+
+// ExportAPI defines the API methods that the export command uses.
+type ExportAPI interface {
+	Close() error
+	Export() ([]byte, error)
+}
+
+// Info implements Command.Info.
+func (c *ExportCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "export",
+		Purpose: "export a description of the environment's model",
+		Doc:     exportEnvHelpDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *ExportCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.StringVar(&c.Output, "o", "", "specify an output file")
+	f.StringVar(&c.Output, "output", "", "")
+}
+
+// Init implements Command.Init.
+func (c *ExportCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+func (c *ExportCommand) getAPI() (ExportAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return environmentmigration.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *ExportCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	data, err := client.Export()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if c.Output == "" {
+		_, err = ctx.Stdout.Write(data)
+		return err
+	}
+	return ioutil.WriteFile(ctx.AbsPath(c.Output), data, 0600)
+}
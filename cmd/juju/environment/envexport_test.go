@@ -0,0 +1,75 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package environment_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/envcmd"
+	"github.com/juju/juju/cmd/juju/environment"
+	"github.com/juju/juju/testing"
+)
+
+type exportSuite struct {
+	testing.FakeJujuHomeSuite
+	fake *fakeExportAPI
+}
+
+var _ = gc.Suite(&exportSuite{})
+
+type fakeExportAPI struct {
+	data []byte
+	err  error
+}
+
+func (f *fakeExportAPI) Close() error {
+	return nil
+}
+
+func (f *fakeExportAPI) Export() ([]byte, error) {
+	return f.data, f.err
+}
+
+func (s *exportSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuHomeSuite.SetUpTest(c)
+	s.fake = &fakeExportAPI{data: []byte("version: 1\nowner: admin@local\n")}
+}
+
+func (s *exportSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	command := environment.NewExportCommand(s.fake)
+	return testing.RunCommand(c, envcmd.Wrap(command), args...)
+}
+
+func (s *exportSuite) TestInit(c *gc.C) {
+	err := testing.InitCommand(&environment.ExportCommand{}, []string{"extra"})
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["extra"\]`)
+}
+
+func (s *exportSuite) TestExportToStdout(c *gc.C) {
+	ctx, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, "version: 1\nowner: admin@local\n")
+}
+
+func (s *exportSuite) TestExportToFile(c *gc.C) {
+	path := filepath.Join(c.MkDir(), "env.yaml")
+	ctx, err := s.run(c, "-o", path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, "")
+	data, err := ioutil.ReadFile(path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "version: 1\nowner: admin@local\n")
+}
+
+func (s *exportSuite) TestExportError(c *gc.C) {
+	s.fake.err = errors.New("boom")
+	_, err := s.run(c)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
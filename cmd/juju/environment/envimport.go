@@ -0,0 +1,176 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package environment
+
+import (
+	"io/ioutil"
+	"os"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v5"
+	"gopkg.in/juju/charm.v5/charmrepo"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/environmentmigration"
+	"github.com/juju/juju/cmd/envcmd"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/description"
+	"github.com/juju/juju/juju/osenv"
+)
+
+const importEnvHelpDoc = `
+Import recreates the model described by a file written by "juju
+environment export" in the current environment, which must not yet have
+any services.
+
+Import does not provision anything. Machines are recorded with the
+instances they were running on, and storage with the volumes and
+filesystems that were provisioned for it. Machines that ran the state
+server of the exported environment are not imported. The users must
+already exist in the current environment's state server.
+
+The charms used by the services are added to the environment first.
+Local charms are read from the repository given with --repository.
+
+Examples:
+ juju environment import myenv.yaml
+     Recreate the model described in myenv.yaml in the current environment
+`
+
+// ImportCommand recreates an exported model in the environment.
+type ImportCommand struct {
+	envcmd.EnvCommandBase
+	api ImportAPI
+
+	// Filename is the file holding the description to import.
+	Filename string
+
+	// RepoPath is the path of the local charm repository.
+	RepoPath string
+}
+
+// ImportAPI defines the API methods that the import command uses.
+type ImportAPI interface {
+	Close() error
+	AddCharm(curl *charm.URL) error
+	AddLocalCharm(curl *charm.URL, ch charm.Charm) (*charm.URL, error)
+	Import(data []byte) error
+}
+
+// Info implements Command.Info.
+func (c *ImportCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "import",
+		Args:    "<file>",
+		Purpose: "import an exported model into the environment",
+		Doc:     importEnvHelpDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *ImportCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.StringVar(&c.RepoPath, "repository", os.Getenv(osenv.JujuRepositoryEnvKey), "local charm repository")
+}
+
+// Init implements Command.Init.
+func (c *ImportCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no file specified")
+	}
+	c.Filename = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// importAPIClient combines the client and environment migration
+// facades used by the import command.
+type importAPIClient struct {
+	*api.Client
+	migration *environmentmigration.Client
+}
+
+// Import implements ImportAPI.Import.
+func (c *importAPIClient) Import(data []byte) error {
+	return c.migration.Import(data)
+}
+
+func (c *ImportCommand) getAPI() (ImportAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &importAPIClient{
+		Client:    root.Client(),
+		migration: environmentmigration.NewClient(root),
+	}, nil
+}
+
+// Run implements Command.Run.
+func (c *ImportCommand) Run(ctx *cmd.Context) error {
+	data, err := ioutil.ReadFile(ctx.AbsPath(c.Filename))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	env, err := description.Deserialize(data)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	added := make(map[string]bool)
+	for _, service := range env.Services {
+		if added[service.Charm] {
+			continue
+		}
+		if err := c.addCharm(ctx, client, service.Charm); err != nil {
+			return block.ProcessBlockedError(err, block.BlockChange)
+		}
+		added[service.Charm] = true
+	}
+	if err := client.Import(data); err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	ctx.Infof("Imported %d machines and %d services.", len(env.Machines), len(env.Services))
+	return nil
+}
+
+// addCharm adds the charm with the given URL to the environment,
+// reading local charms from the repository.
+func (c *ImportCommand) addCharm(ctx *cmd.Context, client ImportAPI, curlStr string) error {
+	curl, err := charm.ParseURL(curlStr)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	switch curl.Schema {
+	case "local":
+		repo := &charmrepo.LocalRepository{Path: ctx.AbsPath(c.RepoPath)}
+		ch, err := repo.Get(curl)
+		if err != nil {
+			return errors.Annotatef(err, "cannot read charm %q", curl)
+		}
+		added, err := client.AddLocalCharm(curl, ch)
+		if err != nil {
+			return errors.Annotatef(err, "cannot add charm %q", curl)
+		}
+		if added.String() != curl.String() {
+			return errors.Errorf("charm %q was added as %q", curl, added)
+		}
+	case "cs":
+		if err := client.AddCharm(curl); err != nil {
+			return errors.Annotatef(err, "cannot add charm %q", curl)
+		}
+	default:
+		return errors.Errorf("unsupported charm URL schema: %q", curl.Schema)
+	}
+	ctx.Infof("Added charm %q to the environment.", curl)
+	return nil
+}
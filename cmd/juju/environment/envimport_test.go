@@ -0,0 +1,125 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package environment_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/cmd"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v5"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/envcmd"
+	"github.com/juju/juju/cmd/juju/environment"
+	"github.com/juju/juju/testcharms"
+	"github.com/juju/juju/testing"
+)
+
+type importSuite struct {
+	testing.FakeJujuHomeSuite
+	fake *fakeImportAPI
+	path string
+}
+
+var _ = gc.Suite(&importSuite{})
+
+type fakeImportAPI struct {
+	charms []string
+	data   []byte
+	err    error
+}
+
+func (f *fakeImportAPI) Close() error {
+	return nil
+}
+
+func (f *fakeImportAPI) AddCharm(curl *charm.URL) error {
+	f.charms = append(f.charms, curl.String())
+	return nil
+}
+
+func (f *fakeImportAPI) AddLocalCharm(curl *charm.URL, ch charm.Charm) (*charm.URL, error) {
+	f.charms = append(f.charms, curl.String())
+	return curl, nil
+}
+
+func (f *fakeImportAPI) Import(data []byte) error {
+	f.data = data
+	return f.err
+}
+
+const importedEnv = `
+version: 1
+owner: admin@local
+machines:
+- id: "0"
+  series: quantal
+  jobs: [JobHostUnits]
+services:
+- name: mysql
+  charm: cs:quantal/mysql-1
+  owner: admin@local
+- name: mysql-slave
+  charm: cs:quantal/mysql-1
+  owner: admin@local
+- name: wordpress
+  charm: local:quantal/wordpress-3
+  owner: admin@local
+`
+
+func (s *importSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuHomeSuite.SetUpTest(c)
+	s.fake = &fakeImportAPI{}
+	s.path = filepath.Join(c.MkDir(), "env.yaml")
+	err := ioutil.WriteFile(s.path, []byte(importedEnv), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *importSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	command := environment.NewImportCommand(s.fake)
+	return testing.RunCommand(c, envcmd.Wrap(command), args...)
+}
+
+func (s *importSuite) TestInit(c *gc.C) {
+	err := testing.InitCommand(&environment.ImportCommand{}, nil)
+	c.Assert(err, gc.ErrorMatches, "no file specified")
+
+	err = testing.InitCommand(&environment.ImportCommand{}, []string{"a.yaml", "b.yaml"})
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["b.yaml"\]`)
+}
+
+func (s *importSuite) TestImport(c *gc.C) {
+	ctx, err := s.run(c, "--repository", testcharms.Repo.Path(), s.path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.charms, jc.DeepEquals, []string{
+		"cs:quantal/mysql-1",
+		"local:quantal/wordpress-3",
+	})
+	c.Assert(string(s.fake.data), gc.Equals, importedEnv)
+	c.Assert(testing.Stderr(ctx), jc.Contains, "Imported 1 machines and 3 services.")
+}
+
+func (s *importSuite) TestImportMissingLocalCharm(c *gc.C) {
+	_, err := s.run(c, "--repository", c.MkDir(), s.path)
+	c.Assert(err, gc.ErrorMatches, `cannot read charm "local:quantal/wordpress-3": .*`)
+	c.Assert(s.fake.data, gc.IsNil)
+}
+
+func (s *importSuite) TestImportInvalidFile(c *gc.C) {
+	err := ioutil.WriteFile(s.path, []byte("version: 2\n"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.run(c, s.path)
+	c.Assert(err, gc.ErrorMatches, "environment description version 2 not supported")
+	c.Assert(s.fake.charms, gc.HasLen, 0)
+}
+
+func (s *importSuite) TestBlockImport(c *gc.C) {
+	s.fake.err = &params.Error{Code: params.CodeOperationBlocked}
+	_, err := s.run(c, "--repository", testcharms.Repo.Path(), s.path)
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Check(c.GetTestLog(), jc.Contains, "To unblock changes")
+}
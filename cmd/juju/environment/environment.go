@@ -30,10 +30,13 @@ func NewSuperCommand() cmd.Command {
 	environmentCmd.Register(envcmd.Wrap(&GetCommand{}))
 	environmentCmd.Register(envcmd.Wrap(&SetCommand{}))
 	environmentCmd.Register(envcmd.Wrap(&UnsetCommand{}))
+	environmentCmd.Register(envcmd.Wrap(&UpdateCredentialsCommand{}))
 	environmentCmd.Register(&JenvCommand{})
 	environmentCmd.Register(envcmd.Wrap(&RetryProvisioningCommand{}))
 	environmentCmd.Register(envcmd.Wrap(&EnvSetConstraintsCommand{}))
 	environmentCmd.Register(envcmd.Wrap(&EnvGetConstraintsCommand{}))
+	environmentCmd.Register(envcmd.Wrap(&ExportCommand{}))
+	environmentCmd.Register(envcmd.Wrap(&ImportCommand{}))
 
 	if featureflag.Enabled(feature.JES) {
 		environmentCmd.Register(envcmd.Wrap(&ShareCommand{}))
@@ -25,9 +25,11 @@ var _ = gc.Suite(&EnvironmentCommandSuite{})
 
 var expectedCommmandNames = []string{
 	"create",
+	"export",
 	"get",
 	"get-constraints",
 	"help",
+	"import",
 	"jenv",
 	"retry-provisioning",
 	"set",
@@ -35,6 +37,7 @@ var expectedCommmandNames = []string{
 	"share",
 	"unset",
 	"unshare",
+	"update-credentials",
 	"users",
 }
 
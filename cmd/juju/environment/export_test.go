@@ -58,3 +58,24 @@ func NewUsersCommand(api UsersAPI) *UsersCommand {
 		api: api,
 	}
 }
+
+// NewExportCommand returns an ExportCommand with the api provided as specified.
+func NewExportCommand(api ExportAPI) *ExportCommand {
+	return &ExportCommand{
+		api: api,
+	}
+}
+
+// NewImportCommand returns an ImportCommand with the api provided as specified.
+func NewImportCommand(api ImportAPI) *ImportCommand {
+	return &ImportCommand{
+		api: api,
+	}
+}
+
+// NewUpdateCredentialsCommand returns an UpdateCredentialsCommand with the api provided as specified.
+func NewUpdateCredentialsCommand(api UpdateCredentialsAPI) *UpdateCredentialsCommand {
+	return &UpdateCredentialsCommand{
+		api: api,
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/juju/names"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/testing"
 )
 
@@ -31,6 +32,7 @@ type fakeEnvAPI struct {
 	err         error
 	keys        []string
 	addUsers    []names.UserTag
+	access      string
 	removeUsers []names.UserTag
 }
 
@@ -47,12 +49,25 @@ func (f *fakeEnvAPI) EnvironmentSet(config map[string]interface{}) error {
 	return f.err
 }
 
+func (f *fakeEnvAPI) EnvironmentUpdateCredentials(credentials map[string]interface{}) (params.UpdateCredentialsResult, error) {
+	f.values = credentials
+	if f.err != nil {
+		return params.UpdateCredentialsResult{}, f.err
+	}
+	return params.UpdateCredentialsResult{
+		Workers:         []string{"environ-provisioner", "firewaller"},
+		PendingWorkers:  []string{"instancepoller"},
+		RetriedMachines: []string{"0", "2"},
+	}, nil
+}
+
 func (f *fakeEnvAPI) EnvironmentUnset(keys ...string) error {
 	f.keys = keys
 	return f.err
 }
 
-func (f *fakeEnvAPI) ShareEnvironment(users ...names.UserTag) error {
+func (f *fakeEnvAPI) ShareEnvironmentWithAccess(access string, users ...names.UserTag) error {
+	f.access = access
 	f.addUsers = users
 	return f.err
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/names"
+	"launchpad.net/gnuflag"
 
 	"github.com/juju/juju/cmd/envcmd"
 	"github.com/juju/juju/cmd/juju/block"
@@ -17,6 +18,12 @@ import (
 const shareEnvHelpDoc = `
 Share the current environment with another user.
 
+By default users are given write access, which allows them to inspect
+and change the environment. Users with read access may only inspect the
+environment. Users with admin access may also share and destroy it.
+Sharing the environment with a user who already has access changes their
+access to the level given with --access.
+
 Examples:
  juju environment share joe
      Give local user "joe" access to the current environment
@@ -26,6 +33,9 @@ Examples:
 
  juju environment share sam --environment myenv
      Give local user "sam" access to the environment named "myenv"
+
+ juju environment share --access read joe
+     Give local user "joe" read-only access to the current environment
  `
 
 // ShareCommand represents the command to share an environment with a user(s).
@@ -36,6 +46,9 @@ type ShareCommand struct {
 
 	// Users to share the environment with.
 	Users []names.UserTag
+
+	// Access is the level of access to give the users.
+	Access string
 }
 
 // Info implements Command.Info.
@@ -48,11 +61,22 @@ func (c *ShareCommand) Info() *cmd.Info {
 	}
 }
 
+// SetFlags implements Command.SetFlags.
+func (c *ShareCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.StringVar(&c.Access, "access", "", "the level of access to give: read, write or admin")
+}
+
 func (c *ShareCommand) Init(args []string) (err error) {
 	if len(args) == 0 {
 		return errors.New("no users specified")
 	}
 
+	switch c.Access {
+	case "", "read", "write", "admin":
+	default:
+		return errors.Errorf("invalid access level %q: expected read, write or admin", c.Access)
+	}
+
 	for _, arg := range args {
 		if !names.IsValidUser(arg) {
 			return errors.Errorf("invalid username: %q", arg)
@@ -73,7 +97,7 @@ func (c *ShareCommand) getAPI() (ShareEnvironmentAPI, error) {
 // ShareEnvironmentAPI defines the API functions used by the environment share command.
 type ShareEnvironmentAPI interface {
 	Close() error
-	ShareEnvironment(...names.UserTag) error
+	ShareEnvironmentWithAccess(access string, users ...names.UserTag) error
 }
 
 func (c *ShareCommand) Run(ctx *cmd.Context) error {
@@ -83,5 +107,5 @@ func (c *ShareCommand) Run(ctx *cmd.Context) error {
 	}
 	defer client.Close()
 
-	return block.ProcessBlockedError(client.ShareEnvironment(c.Users...), block.BlockChange)
+	return block.ProcessBlockedError(client.ShareEnvironmentWithAccess(c.Access, c.Users...), block.BlockChange)
 }
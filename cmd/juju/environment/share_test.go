@@ -42,6 +42,17 @@ func (s *shareSuite) TestInit(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `invalid username: "not valid/0"`)
 }
 
+func (s *shareSuite) TestInitAccess(c *gc.C) {
+	shareCmd := &environment.ShareCommand{}
+	err := testing.InitCommand(shareCmd, []string{"--access", "read", "bob"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(shareCmd.Access, gc.Equals, "read")
+
+	shareCmd = &environment.ShareCommand{}
+	err = testing.InitCommand(shareCmd, []string{"--access", "superuser", "bob"})
+	c.Assert(err, gc.ErrorMatches, `invalid access level "superuser": expected read, write or admin`)
+}
+
 func (s *shareSuite) TestPassesValues(c *gc.C) {
 	sam := names.NewUserTag("sam")
 	ralph := names.NewUserTag("ralph")
@@ -49,6 +60,14 @@ func (s *shareSuite) TestPassesValues(c *gc.C) {
 	_, err := s.run(c, "sam", "ralph")
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(s.fake.addUsers, jc.DeepEquals, []names.UserTag{sam, ralph})
+	c.Assert(s.fake.access, gc.Equals, "")
+}
+
+func (s *shareSuite) TestPassesAccess(c *gc.C) {
+	_, err := s.run(c, "--access", "admin", "sam")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.addUsers, jc.DeepEquals, []names.UserTag{names.NewUserTag("sam")})
+	c.Assert(s.fake.access, gc.Equals, "admin")
 }
 
 func (s *shareSuite) TestBlockShare(c *gc.C) {
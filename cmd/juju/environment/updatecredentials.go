@@ -0,0 +1,132 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package environment
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/yaml.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/envcmd"
+	"github.com/juju/juju/cmd/juju/block"
+)
+
+const updateCredentialsHelpDoc = `
+Replaces the provider credentials of a running environment, such as the
+EC2 access-key and secret-key or the OpenStack password. The credentials
+are read as YAML key/value pairs from the given file, or from standard
+input if the file is "-", so they are not left in the shell history.
+
+The new credentials are checked against the provider before they are
+applied, and the environment is left unchanged if they are rejected.
+
+Machines that failed to start because the provider rejected the old
+credentials are then retried automatically, and the command reports which
+state server workers picked up the new credentials, waiting briefly for
+any that have not done so yet.
+
+Examples:
+ juju environment update-credentials credentials.yaml
+ juju environment update-credentials - < credentials.yaml
+`
+
+// UpdateCredentialsCommand replaces the provider credentials of the
+// environment.
+type UpdateCredentialsCommand struct {
+	envcmd.EnvCommandBase
+	api UpdateCredentialsAPI
+
+	// Filename is the file holding the credentials, or "-" for
+	// standard input.
+	Filename string
+}
+
+// UpdateCredentialsAPI defines the API methods that the
+// update-credentials command uses.
+type UpdateCredentialsAPI interface {
+	Close() error
+	EnvironmentUpdateCredentials(credentials map[string]interface{}) (params.UpdateCredentialsResult, error)
+}
+
+// Info implements Command.Info.
+func (c *UpdateCredentialsCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "update-credentials",
+		Args:    "<file>|-",
+		Purpose: "replace the provider credentials of the environment",
+		Doc:     strings.TrimSpace(updateCredentialsHelpDoc),
+	}
+}
+
+// Init implements Command.Init.
+func (c *UpdateCredentialsCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no credentials file specified")
+	}
+	c.Filename = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+func (c *UpdateCredentialsCommand) getAPI() (UpdateCredentialsAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	return c.NewAPIClient()
+}
+
+// readCredentials returns the credentials held in the command's file.
+func (c *UpdateCredentialsCommand) readCredentials(ctx *cmd.Context) (map[string]interface{}, error) {
+	var data []byte
+	var err error
+	if c.Filename == "-" {
+		data, err = ioutil.ReadAll(ctx.Stdin)
+	} else {
+		data, err = ioutil.ReadFile(ctx.AbsPath(c.Filename))
+	}
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot read credentials")
+	}
+	var credentials map[string]interface{}
+	if err := yaml.Unmarshal(data, &credentials); err != nil {
+		return nil, errors.Annotate(err, "cannot parse credentials")
+	}
+	if len(credentials) == 0 {
+		return nil, errors.New("no credentials found")
+	}
+	return credentials, nil
+}
+
+// Run implements Command.Run.
+func (c *UpdateCredentialsCommand) Run(ctx *cmd.Context) error {
+	credentials, err := c.readCredentials(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	result, err := client.EnvironmentUpdateCredentials(credentials)
+	if err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	fmt.Fprintln(ctx.Stdout, "credentials updated")
+	if len(result.Workers) > 0 {
+		fmt.Fprintf(ctx.Stdout, "picked up by: %s\n", strings.Join(result.Workers, ", "))
+	}
+	if len(result.PendingWorkers) > 0 {
+		fmt.Fprintf(ctx.Stdout, "not yet picked up by: %s\n", strings.Join(result.PendingWorkers, ", "))
+	}
+	if len(result.RetriedMachines) > 0 {
+		fmt.Fprintf(ctx.Stdout, "retrying provisioning of machines: %s\n", strings.Join(result.RetriedMachines, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package environment_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/cmd"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/cmd/envcmd"
+	"github.com/juju/juju/cmd/juju/environment"
+	"github.com/juju/juju/testing"
+)
+
+type UpdateCredentialsSuite struct {
+	fakeEnvSuite
+}
+
+var _ = gc.Suite(&UpdateCredentialsSuite{})
+
+func (s *UpdateCredentialsSuite) run(c *gc.C, stdin string, args ...string) (*cmd.Context, error) {
+	command := envcmd.Wrap(environment.NewUpdateCredentialsCommand(s.fake))
+	ctx := testing.Context(c)
+	ctx.Stdin = strings.NewReader(stdin)
+	if err := testing.InitCommand(command, args); err != nil {
+		return ctx, err
+	}
+	return ctx, command.Run(ctx)
+}
+
+func (s *UpdateCredentialsSuite) TestInit(c *gc.C) {
+	updateCmd := &environment.UpdateCredentialsCommand{}
+	err := testing.InitCommand(updateCmd, []string{})
+	c.Assert(err, gc.ErrorMatches, "no credentials file specified")
+	err = testing.InitCommand(updateCmd, []string{"a", "b"})
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["b"\]`)
+	err = testing.InitCommand(updateCmd, []string{"-"})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *UpdateCredentialsSuite) TestFromFile(c *gc.C) {
+	path := filepath.Join(c.MkDir(), "credentials.yaml")
+	err := ioutil.WriteFile(path, []byte("access-key: foo\nsecret-key: bar\n"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	ctx, err := s.run(c, "", path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.values, jc.DeepEquals, map[string]interface{}{
+		"access-key": "foo",
+		"secret-key": "bar",
+	})
+	c.Assert(testing.Stdout(ctx), gc.Equals, ""+
+		"credentials updated\n"+
+		"picked up by: environ-provisioner, firewaller\n"+
+		"not yet picked up by: instancepoller\n"+
+		"retrying provisioning of machines: 0, 2\n")
+}
+
+func (s *UpdateCredentialsSuite) TestFromStdin(c *gc.C) {
+	_, err := s.run(c, "password: sekrit\n", "-")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.values, jc.DeepEquals, map[string]interface{}{
+		"password": "sekrit",
+	})
+}
+
+func (s *UpdateCredentialsSuite) TestNoCredentials(c *gc.C) {
+	_, err := s.run(c, "", "-")
+	c.Assert(err, gc.ErrorMatches, "no credentials found")
+}
+
+func (s *UpdateCredentialsSuite) TestMissingFile(c *gc.C) {
+	_, err := s.run(c, "", filepath.Join(c.MkDir(), "missing.yaml"))
+	c.Assert(err, gc.ErrorMatches, "cannot read credentials: .*")
+}
+
+func (s *UpdateCredentialsSuite) TestBlockedError(c *gc.C) {
+	s.fake.err = common.ErrOperationBlocked("TestBlockedError")
+	_, err := s.run(c, "password: sekrit\n", "-")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	// msg is logged
+	c.Check(c.GetTestLog(), jc.Contains, "TestBlockedError")
+}
@@ -7,21 +7,34 @@ import (
 	"errors"
 
 	"github.com/juju/cmd"
+	"launchpad.net/gnuflag"
 
 	"github.com/juju/juju/cmd/envcmd"
 	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/network"
 )
 
 // ExposeCommand is responsible exposing services.
 type ExposeCommand struct {
 	envcmd.EnvCommandBase
 	ServiceName string
+	SourceCIDRs []string
+	toCIDRs     string
 }
 
 var jujuExposeHelp = `
 Adjusts firewall rules and similar security mechanisms of the provider, to
 allow the service to be accessed on its public address.
 
+By default the service's open ports may be accessed from any address. The
+--to-cidrs option restricts access to a comma-separated list of networks,
+given in CIDR notation. Exposing a service again replaces any previous
+restriction. Not all providers are able to restrict access by source
+address; on those, exposing to CIDRs fails.
+
+Examples:
+   juju expose wordpress
+   juju expose --to-cidrs 10.0.0.0/8,192.168.1.0/24 wordpress
 `
 
 func (c *ExposeCommand) Info() *cmd.Info {
@@ -33,11 +46,22 @@ func (c *ExposeCommand) Info() *cmd.Info {
 	}
 }
 
+func (c *ExposeCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.StringVar(&c.toCIDRs, "to-cidrs", "", "comma-separated source CIDRs allowed to access the service")
+}
+
 func (c *ExposeCommand) Init(args []string) error {
 	if len(args) == 0 {
 		return errors.New("no service name specified")
 	}
 	c.ServiceName = args[0]
+	if c.toCIDRs != "" {
+		cidrs, err := network.ParseCIDRs(c.toCIDRs)
+		if err != nil {
+			return err
+		}
+		c.SourceCIDRs = cidrs
+	}
 	return cmd.CheckEmpty(args[1:])
 }
 
@@ -49,5 +73,5 @@ func (c *ExposeCommand) Run(_ *cmd.Context) error {
 		return err
 	}
 	defer client.Close()
-	return block.ProcessBlockedError(client.ServiceExpose(c.ServiceName), block.BlockChange)
+	return block.ProcessBlockedError(client.ServiceExpose(c.ServiceName, c.SourceCIDRs...), block.BlockChange)
 }
@@ -41,3 +41,33 @@ func (f storageFlag) String() string {
 	}
 	return strings.Join(strs, " ")
 }
+
+type bindFlag struct {
+	bindings *map[string]string
+}
+
+// Set implements gnuflag.Value.Set.
+func (f bindFlag) Set(s string) error {
+	for _, binding := range strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || r == ' '
+	}) {
+		fields := strings.SplitN(binding, "=", 2)
+		if len(fields) < 2 || fields[0] == "" || fields[1] == "" {
+			return errors.New("expected <endpoint>=<space>")
+		}
+		if *f.bindings == nil {
+			*f.bindings = make(map[string]string)
+		}
+		(*f.bindings)[fields[0]] = fields[1]
+	}
+	return nil
+}
+
+// String implements gnuflag.Value.String.
+func (f bindFlag) String() string {
+	strs := make([]string, 0, len(*f.bindings))
+	for endpoint, space := range *f.bindings {
+		strs = append(strs, fmt.Sprintf("%s=%s", endpoint, space))
+	}
+	return strings.Join(strs, " ")
+}
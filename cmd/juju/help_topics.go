@@ -372,6 +372,7 @@ container
       none - (default) no container
       lxc - an lxc container
       kvm - a kvm container
+      lxd - an lxd container
 
 cpu-power
    Cpu-power is a whole number that defines the speed of the machine's CPU,
@@ -0,0 +1,48 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package leadership
+
+import (
+	"github.com/juju/cmd"
+
+	"github.com/juju/juju/api/leadership"
+	"github.com/juju/juju/cmd/envcmd"
+)
+
+const leadershipCommandDoc = `
+"juju leadership" is used to see which unit is the leader of each service,
+and to move leadership from one unit of a service to another.
+`
+
+const leadershipCommandPurpose = "inspect and transfer service leadership"
+
+// NewSuperCommand creates the leadership supercommand and registers the
+// subcommands that it supports.
+func NewSuperCommand() cmd.Command {
+	leadershipcmd := cmd.NewSuperCommand(cmd.SuperCommandParams{
+		Name:        "leadership",
+		Doc:         leadershipCommandDoc,
+		UsagePrefix: "juju",
+		Purpose:     leadershipCommandPurpose,
+	})
+	leadershipcmd.Register(envcmd.Wrap(&ShowCommand{}))
+	leadershipcmd.Register(envcmd.Wrap(&TransferCommand{}))
+	return leadershipcmd
+}
+
+// LeadershipCommandBase is a helper base structure that has a method to
+// get the leadership admin client.
+type LeadershipCommandBase struct {
+	envcmd.EnvCommandBase
+}
+
+// NewLeadershipAdminClient returns a leadership admin client for the
+// root api endpoint that the environment command returns.
+func (c *LeadershipCommandBase) NewLeadershipAdminClient() (*leadership.AdminClient, error) {
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, err
+	}
+	return leadership.NewAdminClient(root), nil
+}
@@ -0,0 +1,131 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package leadership
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+const ShowCommandDoc = `
+Show the unit that is the leader of each service, and the time at which
+its leadership lease expires unless the leader renews it first.  If a
+service is given, only the leader of that service is shown.
+
+Examples:
+
+  # Show the leaders of all services.
+  juju leadership show
+
+  # Show the leader of mysql.
+  juju leadership show mysql
+`
+
+// ShowCommand shows the leaders of services.
+type ShowCommand struct {
+	LeadershipCommandBase
+	out         cmd.Output
+	serviceName string
+}
+
+// Info implements Command.Info.
+func (c *ShowCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "show",
+		Args:    "[<service>]",
+		Purpose: "show service leaders",
+		Doc:     ShowCommandDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *ShowCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.LeadershipCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+}
+
+// Init implements Command.Init.
+func (c *ShowCommand) Init(args []string) error {
+	if len(args) > 0 {
+		c.serviceName, args = args[0], args[1:]
+		if !names.IsValidService(c.serviceName) {
+			return errors.Errorf("invalid service name %q", c.serviceName)
+		}
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// ShowLeadersAPI defines the leadership admin API methods that the show
+// command uses.
+type ShowLeadersAPI interface {
+	Leaders(serviceNames ...string) ([]params.ServiceLeadershipResult, error)
+	Close() error
+}
+
+var getShowLeadersAPI = func(c *ShowCommand) (ShowLeadersAPI, error) {
+	return c.NewLeadershipAdminClient()
+}
+
+// LeaderInfo defines the serialization behaviour of the leader of a
+// service.
+type LeaderInfo struct {
+	Leader       string `yaml:"leader,omitempty" json:"leader,omitempty"`
+	LeaseExpires string `yaml:"lease-expires,omitempty" json:"lease-expires,omitempty"`
+	Error        string `yaml:"error,omitempty" json:"error,omitempty"`
+}
+
+// Run implements Command.Run.
+func (c *ShowCommand) Run(ctx *cmd.Context) error {
+	client, err := getShowLeadersAPI(c)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	var serviceNames []string
+	if c.serviceName != "" {
+		serviceNames = append(serviceNames, c.serviceName)
+	}
+	results, err := client.Leaders(serviceNames...)
+	if err != nil {
+		return err
+	}
+	leaders := make(map[string]LeaderInfo)
+	for _, result := range results {
+		// The service tag is not set on a failed result, but failures
+		// can only arise for a service that was asked for by name.
+		serviceName := c.serviceName
+		if serviceName == "" {
+			tag, err := names.ParseServiceTag(result.ServiceTag)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			serviceName = tag.Id()
+		}
+		if result.Error != nil {
+			leaders[serviceName] = LeaderInfo{Error: result.Error.Error()}
+			continue
+		}
+		var info LeaderInfo
+		if result.UnitTag != "" {
+			tag, err := names.ParseUnitTag(result.UnitTag)
+			if err != nil {
+				return errors.Annotatef(err, "invalid leader of service %q", serviceName)
+			}
+			info.Leader = tag.Id()
+			info.LeaseExpires = result.Expiry.UTC().Format(time.RFC3339)
+		}
+		leaders[serviceName] = info
+	}
+	return c.out.Write(ctx, leaders)
+}
@@ -0,0 +1,100 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package leadership_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/envcmd"
+	"github.com/juju/juju/cmd/juju/leadership"
+	"github.com/juju/juju/testing"
+)
+
+type showSuite struct {
+	testing.FakeJujuHomeSuite
+	mockAPI *fakeShowLeadersAPI
+}
+
+var _ = gc.Suite(&showSuite{})
+
+type fakeShowLeadersAPI struct {
+	serviceNames []string
+}
+
+func (*fakeShowLeadersAPI) Close() error {
+	return nil
+}
+
+func (f *fakeShowLeadersAPI) Leaders(serviceNames ...string) ([]params.ServiceLeadershipResult, error) {
+	f.serviceNames = serviceNames
+	mysql := params.ServiceLeadershipResult{
+		ServiceTag: "service-mysql",
+		UnitTag:    "unit-mysql-1",
+		Expiry:     time.Date(2015, time.June, 1, 12, 0, 30, 0, time.UTC),
+	}
+	if len(serviceNames) > 0 {
+		if serviceNames[0] != "mysql" {
+			return []params.ServiceLeadershipResult{{
+				Error: &params.Error{Message: "permission denied"},
+			}}, nil
+		}
+		return []params.ServiceLeadershipResult{mysql}, nil
+	}
+	return []params.ServiceLeadershipResult{
+		mysql,
+		{ServiceTag: "service-wordpress"},
+	}, nil
+}
+
+func (s *showSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuHomeSuite.SetUpTest(c)
+	s.mockAPI = &fakeShowLeadersAPI{}
+	s.PatchValue(leadership.GetShowLeadersAPI, func(c *leadership.ShowCommand) (leadership.ShowLeadersAPI, error) {
+		return s.mockAPI, nil
+	})
+}
+
+func runShowCommand(c *gc.C, args ...string) (*cmd.Context, error) {
+	return testing.RunCommand(c, envcmd.Wrap(&leadership.ShowCommand{}), args...)
+}
+
+func (s *showSuite) TestShowAll(c *gc.C) {
+	ctx, err := runShowCommand(c, "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.mockAPI.serviceNames, gc.HasLen, 0)
+	c.Check(testing.Stdout(ctx), gc.Equals,
+		`{"mysql":{"leader":"mysql/1","lease-expires":"2015-06-01T12:00:30Z"},"wordpress":{}}`+"\n")
+}
+
+func (s *showSuite) TestShowService(c *gc.C) {
+	ctx, err := runShowCommand(c, "mysql", "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.mockAPI.serviceNames, jc.DeepEquals, []string{"mysql"})
+	c.Check(testing.Stdout(ctx), gc.Equals,
+		`{"mysql":{"leader":"mysql/1","lease-expires":"2015-06-01T12:00:30Z"}}`+"\n")
+}
+
+func (s *showSuite) TestShowServiceError(c *gc.C) {
+	ctx, err := runShowCommand(c, "wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(testing.Stdout(ctx), gc.Equals, `
+wordpress:
+  error: permission denied
+`[1:])
+}
+
+func (*showSuite) TestInvalidService(c *gc.C) {
+	_, err := runShowCommand(c, "mysql/0")
+	c.Assert(err, gc.ErrorMatches, `invalid service name "mysql/0"`)
+}
+
+func (*showSuite) TestTooManyArgs(c *gc.C) {
+	_, err := runShowCommand(c, "mysql", "wordpress")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["wordpress"\]`)
+}
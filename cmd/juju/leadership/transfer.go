@@ -0,0 +1,75 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package leadership
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names"
+)
+
+const TransferCommandDoc = `
+Make the given unit the leader of its service, in place of the current
+leader.  The unit runs its leader-elected hook once it takes up leadership.
+
+The current leader stops being the leader straight away, but only finds out
+when it next tries to renew its leadership, which can take up to a minute.
+Use this command before taking down the current leader, so that another
+unit of your choice takes over from it.
+
+Examples:
+
+  # Make mysql/1 the leader of mysql.
+  juju leadership transfer mysql/1
+`
+
+// TransferCommand makes a unit the leader of its service.
+type TransferCommand struct {
+	LeadershipCommandBase
+	unitName string
+}
+
+// Info implements Command.Info.
+func (c *TransferCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "transfer",
+		Args:    "<unit>",
+		Purpose: "make a unit the leader of its service",
+		Doc:     TransferCommandDoc,
+	}
+}
+
+// Init implements Command.Init.
+func (c *TransferCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no unit specified")
+	}
+	c.unitName, args = args[0], args[1:]
+	if !names.IsValidUnit(c.unitName) {
+		return errors.Errorf("invalid unit name %q", c.unitName)
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// TransferLeadershipAPI defines the leadership admin API methods that
+// the transfer command uses.
+type TransferLeadershipAPI interface {
+	TransferLeadership(unitName string) error
+	Close() error
+}
+
+var getTransferLeadershipAPI = func(c *TransferCommand) (TransferLeadershipAPI, error) {
+	return c.NewLeadershipAdminClient()
+}
+
+// Run implements Command.Run.
+func (c *TransferCommand) Run(ctx *cmd.Context) error {
+	client, err := getTransferLeadershipAPI(c)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	return client.TransferLeadership(c.unitName)
+}
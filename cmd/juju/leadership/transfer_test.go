@@ -0,0 +1,67 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package leadership_test
+
+import (
+	"github.com/juju/cmd"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/envcmd"
+	"github.com/juju/juju/cmd/juju/leadership"
+	"github.com/juju/juju/testing"
+)
+
+type transferSuite struct {
+	testing.FakeJujuHomeSuite
+	mockAPI *fakeTransferLeadershipAPI
+}
+
+var _ = gc.Suite(&transferSuite{})
+
+type fakeTransferLeadershipAPI struct {
+	unitName string
+}
+
+func (*fakeTransferLeadershipAPI) Close() error {
+	return nil
+}
+
+func (f *fakeTransferLeadershipAPI) TransferLeadership(unitName string) error {
+	f.unitName = unitName
+	return nil
+}
+
+func (s *transferSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuHomeSuite.SetUpTest(c)
+	s.mockAPI = &fakeTransferLeadershipAPI{}
+	s.PatchValue(leadership.GetTransferLeadershipAPI, func(c *leadership.TransferCommand) (leadership.TransferLeadershipAPI, error) {
+		return s.mockAPI, nil
+	})
+}
+
+func runTransferCommand(c *gc.C, args ...string) (*cmd.Context, error) {
+	return testing.RunCommand(c, envcmd.Wrap(&leadership.TransferCommand{}), args...)
+}
+
+func (s *transferSuite) TestTransfer(c *gc.C) {
+	_, err := runTransferCommand(c, "mysql/1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.mockAPI.unitName, gc.Equals, "mysql/1")
+}
+
+func (*transferSuite) TestNoUnit(c *gc.C) {
+	_, err := runTransferCommand(c)
+	c.Assert(err, gc.ErrorMatches, "no unit specified")
+}
+
+func (*transferSuite) TestInvalidUnit(c *gc.C) {
+	_, err := runTransferCommand(c, "mysql")
+	c.Assert(err, gc.ErrorMatches, `invalid unit name "mysql"`)
+}
+
+func (*transferSuite) TestTooManyArgs(c *gc.C) {
+	_, err := runTransferCommand(c, "mysql/1", "mysql/2")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["mysql/2"\]`)
+}
@@ -194,6 +194,7 @@ var commandNames = []string{
 	"add-unit",
 	"api-endpoints",
 	"api-info",
+	"audit-log",
 	"authorised-keys", // alias for authorized-keys
 	"authorized-keys",
 	"backups",
@@ -220,7 +221,9 @@ var commandNames = []string{
 	"help",
 	"help-tool",
 	"init",
+	"leadership",
 	"machine",
+	"offer",
 	"publish",
 	"remove-machine",  // alias for destroy-machine
 	"remove-relation", // alias for destroy-relation
@@ -235,6 +238,7 @@ var commandNames = []string{
 	"set-constraints",
 	"set-env", // alias for set-environment
 	"set-environment",
+	"space",
 	"ssh",
 	"stat", // alias for status
 	"status",
@@ -248,6 +252,7 @@ var commandNames = []string{
 	"unset",
 	"unset-env", // alias for unset-environment
 	"unset-environment",
+	"update-credentials",
 	"upgrade-charm",
 	"upgrade-juju",
 	"user",
@@ -0,0 +1,83 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package offer
+
+import (
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names"
+)
+
+const addCommandDoc = `
+Offer the given endpoints of a service to the other environments on the
+state server.  The offer is named after the service unless an offer name
+is given.  Peer relations cannot be offered.
+
+Examples:
+
+  # Offer the server endpoint of mysql as "db".
+  juju offer add mysql:server db
+`
+
+// AddCommand offers the endpoints of a service.
+type AddCommand struct {
+	OfferCommandBase
+	serviceName string
+	endpoints   []string
+	name        string
+}
+
+// Info implements Command.Info.
+func (c *AddCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "add",
+		Args:    "<service>:<endpoint>[,<endpoint>...] [<offer name>]",
+		Purpose: "offer the endpoints of a service to other environments",
+		Doc:     addCommandDoc,
+	}
+}
+
+// Init implements Command.Init.
+func (c *AddCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("no service endpoints specified")
+	case 1, 2:
+	default:
+		return cmd.CheckEmpty(args[2:])
+	}
+	parts := strings.SplitN(args[0], ":", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return errors.Errorf("expected <service>:<endpoint>[,<endpoint>...], got %q", args[0])
+	}
+	c.serviceName, c.endpoints = parts[0], strings.Split(parts[1], ",")
+	if !names.IsValidService(c.serviceName) {
+		return errors.Errorf("invalid service name %q", c.serviceName)
+	}
+	c.name = c.serviceName
+	if len(args) == 2 {
+		c.name = args[1]
+	}
+	if !names.IsValidService(c.name) {
+		return errors.Errorf("invalid offer name %q", c.name)
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *AddCommand) Run(ctx *cmd.Context) error {
+	client, err := getOfferAPI(&c.OfferCommandBase)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if err := client.AddOffer(c.name, c.serviceName, c.endpoints); err != nil {
+		return errors.Annotatef(err, "cannot offer service %q", c.serviceName)
+	}
+	ctx.Infof("offered service %q as %q", c.serviceName, c.name)
+	return nil
+}
@@ -0,0 +1,89 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package offer
+
+import (
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names"
+)
+
+const consumeCommandDoc = `
+Consume an offer made by another environment on the same state server,
+adding a remote service that can be related to the services of this
+environment.  The environment making the offer is given by name or UUID.
+The remote service is named after the offer unless a service name is
+given.
+
+Examples:
+
+  # Consume the "db" offer of the "shared" environment.
+  juju offer consume shared:db
+
+  # Consume it as the "shared-db" service.
+  juju offer consume shared:db shared-db
+  juju add-relation wordpress shared-db
+`
+
+// ConsumeCommand consumes an offer.
+type ConsumeCommand struct {
+	OfferCommandBase
+	environment string
+	offerName   string
+	serviceName string
+}
+
+// Info implements Command.Info.
+func (c *ConsumeCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "consume",
+		Args:    "<environment>:<offer name> [<service name>]",
+		Purpose: "add a remote service for an offer of another environment",
+		Doc:     consumeCommandDoc,
+	}
+}
+
+// Init implements Command.Init.
+func (c *ConsumeCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("no offer specified")
+	case 1, 2:
+	default:
+		return cmd.CheckEmpty(args[2:])
+	}
+	parts := strings.SplitN(args[0], ":", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return errors.Errorf("expected <environment>:<offer name>, got %q", args[0])
+	}
+	c.environment, c.offerName = parts[0], parts[1]
+	if len(args) == 2 {
+		c.serviceName = args[1]
+		if !names.IsValidService(c.serviceName) {
+			return errors.Errorf("invalid service name %q", c.serviceName)
+		}
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *ConsumeCommand) Run(ctx *cmd.Context) error {
+	client, err := getOfferAPI(&c.OfferCommandBase)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if err := client.Consume(c.environment, c.offerName, c.serviceName); err != nil {
+		return errors.Annotatef(err, "cannot consume offer %q of environment %q", c.offerName, c.environment)
+	}
+	serviceName := c.serviceName
+	if serviceName == "" {
+		serviceName = c.offerName
+	}
+	ctx.Infof("added remote service %q", serviceName)
+	return nil
+}
@@ -0,0 +1,75 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package offer
+
+import (
+	"github.com/juju/cmd"
+	"launchpad.net/gnuflag"
+)
+
+const listCommandDoc = `
+List the offers made by the environment, with the offered service and
+endpoints.
+`
+
+// ListCommand lists offers.
+type ListCommand struct {
+	OfferCommandBase
+	out cmd.Output
+}
+
+// Info implements Command.Info.
+func (c *ListCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "list",
+		Purpose: "list the offers made by the environment",
+		Doc:     listCommandDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *ListCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.OfferCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+}
+
+// Init implements Command.Init.
+func (c *ListCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// OfferInfo defines the serialization behaviour of an offer.
+type OfferInfo struct {
+	Service   string   `yaml:"service" json:"service"`
+	Endpoints []string `yaml:"endpoints" json:"endpoints"`
+}
+
+// Run implements Command.Run.
+func (c *ListCommand) Run(ctx *cmd.Context) error {
+	client, err := getOfferAPI(&c.OfferCommandBase)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	offers, err := client.ListOffers()
+	if err != nil {
+		return err
+	}
+	if len(offers) == 0 {
+		ctx.Infof("no offers to display")
+		return nil
+	}
+	result := make(map[string]OfferInfo)
+	for _, offer := range offers {
+		result[offer.Name] = OfferInfo{
+			Service:   offer.ServiceName,
+			Endpoints: offer.Endpoints,
+		}
+	}
+	return c.out.Write(ctx, result)
+}
@@ -0,0 +1,61 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package offer
+
+import (
+	"github.com/juju/cmd"
+
+	"github.com/juju/juju/api/offers"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/envcmd"
+)
+
+const offerCommandDoc = `
+"juju offer" is used to offer services to the other environments on the
+same state server, and to consume the services they offer.
+
+A consumed offer is added to the environment as a remote service, which
+can be related to like any other service.  The units of the services on
+either side of such a relation see the units on the other side just as
+they would for a relation within one environment.
+`
+
+const offerCommandPurpose = "manage services offered between environments"
+
+// NewSuperCommand creates the offer supercommand and registers the
+// subcommands that it supports.
+func NewSuperCommand() cmd.Command {
+	offercmd := cmd.NewSuperCommand(cmd.SuperCommandParams{
+		Name:        "offer",
+		Doc:         offerCommandDoc,
+		UsagePrefix: "juju",
+		Purpose:     offerCommandPurpose,
+	})
+	offercmd.Register(envcmd.Wrap(&AddCommand{}))
+	offercmd.Register(envcmd.Wrap(&ListCommand{}))
+	offercmd.Register(envcmd.Wrap(&ConsumeCommand{}))
+	return offercmd
+}
+
+// OfferAPI defines the offers API methods that the offer commands use.
+type OfferAPI interface {
+	AddOffer(name, serviceName string, endpoints []string) error
+	ListOffers() ([]params.Offer, error)
+	Consume(environment, offerName, serviceName string) error
+	Close() error
+}
+
+// OfferCommandBase is a helper base structure that has a method to get
+// the offers API client.
+type OfferCommandBase struct {
+	envcmd.EnvCommandBase
+}
+
+var getOfferAPI = func(c *OfferCommandBase) (OfferAPI, error) {
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, err
+	}
+	return offers.NewClient(root), nil
+}
@@ -0,0 +1,157 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package offer_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/envcmd"
+	"github.com/juju/juju/cmd/juju/offer"
+	"github.com/juju/juju/testing"
+)
+
+type offerSuite struct {
+	testing.FakeJujuHomeSuite
+	mockAPI *fakeOfferAPI
+}
+
+var _ = gc.Suite(&offerSuite{})
+
+type fakeOfferAPI struct {
+	calls  []string
+	args   []interface{}
+	offers []params.Offer
+	err    error
+}
+
+func (*fakeOfferAPI) Close() error {
+	return nil
+}
+
+func (f *fakeOfferAPI) AddOffer(name, serviceName string, endpoints []string) error {
+	f.calls = append(f.calls, "AddOffer")
+	f.args = []interface{}{name, serviceName, endpoints}
+	return f.err
+}
+
+func (f *fakeOfferAPI) ListOffers() ([]params.Offer, error) {
+	f.calls = append(f.calls, "ListOffers")
+	return f.offers, f.err
+}
+
+func (f *fakeOfferAPI) Consume(environment, offerName, serviceName string) error {
+	f.calls = append(f.calls, "Consume")
+	f.args = []interface{}{environment, offerName, serviceName}
+	return f.err
+}
+
+func (s *offerSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuHomeSuite.SetUpTest(c)
+	s.mockAPI = &fakeOfferAPI{}
+	s.PatchValue(offer.GetOfferAPI, func(*offer.OfferCommandBase) (offer.OfferAPI, error) {
+		return s.mockAPI, nil
+	})
+}
+
+func runCommand(c *gc.C, command cmd.Command, args ...string) (*cmd.Context, error) {
+	return testing.RunCommand(c, envcmd.Wrap(command), args...)
+}
+
+func (s *offerSuite) TestAdd(c *gc.C) {
+	ctx, err := runCommand(c, &offer.AddCommand{}, "mysql:server,admin", "db")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(testing.Stderr(ctx), gc.Equals, "offered service \"mysql\" as \"db\"\n")
+	c.Check(s.mockAPI.calls, jc.DeepEquals, []string{"AddOffer"})
+	c.Check(s.mockAPI.args, jc.DeepEquals, []interface{}{"db", "mysql", []string{"server", "admin"}})
+}
+
+func (s *offerSuite) TestAddDefaultName(c *gc.C) {
+	_, err := runCommand(c, &offer.AddCommand{}, "mysql:server")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.mockAPI.args, jc.DeepEquals, []interface{}{"mysql", "mysql", []string{"server"}})
+}
+
+func (s *offerSuite) TestAddError(c *gc.C) {
+	s.mockAPI.err = errors.New("boom")
+	_, err := runCommand(c, &offer.AddCommand{}, "mysql:server")
+	c.Assert(err, gc.ErrorMatches, `cannot offer service "mysql": boom`)
+}
+
+func (s *offerSuite) TestAddInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{
+		{nil, "no service endpoints specified"},
+		{[]string{"mysql"}, `expected <service>:<endpoint>\[,<endpoint>...\], got "mysql"`},
+		{[]string{"mysql:"}, `expected <service>:<endpoint>\[,<endpoint>...\], got "mysql:"`},
+		{[]string{"Bad:server"}, `invalid service name "Bad"`},
+		{[]string{"mysql:server", "Bad Name"}, `invalid offer name "Bad Name"`},
+		{[]string{"mysql:server", "db", "extra"}, `unrecognized args: \["extra"\]`},
+	} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := runCommand(c, &offer.AddCommand{}, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *offerSuite) TestList(c *gc.C) {
+	s.mockAPI.offers = []params.Offer{{
+		Name:        "db",
+		ServiceName: "mysql",
+		Endpoints:   []string{"server"},
+	}}
+	ctx, err := runCommand(c, &offer.ListCommand{}, "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(testing.Stdout(ctx), gc.Equals, `{"db":{"service":"mysql","endpoints":["server"]}}`+"\n")
+}
+
+func (s *offerSuite) TestListEmpty(c *gc.C) {
+	ctx, err := runCommand(c, &offer.ListCommand{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(testing.Stdout(ctx), gc.Equals, "")
+	c.Check(testing.Stderr(ctx), gc.Equals, "no offers to display\n")
+}
+
+func (s *offerSuite) TestConsume(c *gc.C) {
+	ctx, err := runCommand(c, &offer.ConsumeCommand{}, "shared:db", "shared-db")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(testing.Stderr(ctx), gc.Equals, "added remote service \"shared-db\"\n")
+	c.Check(s.mockAPI.calls, jc.DeepEquals, []string{"Consume"})
+	c.Check(s.mockAPI.args, jc.DeepEquals, []interface{}{"shared", "db", "shared-db"})
+}
+
+func (s *offerSuite) TestConsumeDefaultName(c *gc.C) {
+	ctx, err := runCommand(c, &offer.ConsumeCommand{}, "shared:db")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(testing.Stderr(ctx), gc.Equals, "added remote service \"db\"\n")
+	c.Check(s.mockAPI.args, jc.DeepEquals, []interface{}{"shared", "db", ""})
+}
+
+func (s *offerSuite) TestConsumeError(c *gc.C) {
+	s.mockAPI.err = errors.New("boom")
+	_, err := runCommand(c, &offer.ConsumeCommand{}, "shared:db")
+	c.Assert(err, gc.ErrorMatches, `cannot consume offer "db" of environment "shared": boom`)
+}
+
+func (s *offerSuite) TestConsumeInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{
+		{nil, "no offer specified"},
+		{[]string{"db"}, `expected <environment>:<offer name>, got "db"`},
+		{[]string{":db"}, `expected <environment>:<offer name>, got ":db"`},
+		{[]string{"shared:db", "Bad Name"}, `invalid service name "Bad Name"`},
+		{[]string{"shared:db", "shared-db", "extra"}, `unrecognized args: \["extra"\]`},
+	} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := runCommand(c, &offer.ConsumeCommand{}, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
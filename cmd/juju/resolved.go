@@ -17,21 +17,39 @@ import (
 // ResolvedCommand marks a unit in an error state as ready to continue.
 type ResolvedCommand struct {
 	envcmd.EnvCommandBase
-	UnitName string
-	Retry    bool
+	UnitName    string
+	Retry       bool
+	CancelRetry bool
 }
 
+const resolvedDoc = `
+Marks the error of a unit in an error state as resolved, so that the unit
+continues as if the failed hook had succeeded. With --retry, the failed
+hook is executed again instead.
+
+If the environment or service is configured to retry failed hooks
+automatically, any pending retry is replaced by the resolution. With
+--cancel-retry, the pending retry is cancelled and the unit stays in an
+error state until it is resolved.
+
+See Also:
+   juju help environment
+   juju service help set-hook-retry
+`
+
 func (c *ResolvedCommand) Info() *cmd.Info {
 	return &cmd.Info{
 		Name:    "resolved",
 		Args:    "<unit>",
 		Purpose: "marks unit errors resolved",
+		Doc:     resolvedDoc,
 	}
 }
 
 func (c *ResolvedCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.Retry, "r", false, "re-execute failed hooks")
 	f.BoolVar(&c.Retry, "retry", false, "")
+	f.BoolVar(&c.CancelRetry, "cancel-retry", false, "cancel the automatic retry of failed hooks")
 }
 
 func (c *ResolvedCommand) Init(args []string) error {
@@ -44,6 +62,9 @@ func (c *ResolvedCommand) Init(args []string) error {
 	} else {
 		return fmt.Errorf("no unit specified")
 	}
+	if c.Retry && c.CancelRetry {
+		return fmt.Errorf("cannot specify both --retry and --cancel-retry")
+	}
 	return cmd.CheckEmpty(args)
 }
 
@@ -53,5 +74,8 @@ func (c *ResolvedCommand) Run(_ *cmd.Context) error {
 		return err
 	}
 	defer client.Close()
+	if c.CancelRetry {
+		return block.ProcessBlockedError(client.CancelHookRetry(c.UnitName), block.BlockChange)
+	}
 	return block.ProcessBlockedError(client.Resolved(c.UnitName, c.Retry), block.BlockChange)
 }
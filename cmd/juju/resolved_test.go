@@ -78,6 +78,18 @@ var resolvedTests = []struct {
 	}, {
 		args: []string{"dummy/4", "roflcopter"},
 		err:  `unrecognized args: \["roflcopter"\]`,
+	}, {
+		args: []string{"dummy/4", "--retry", "--cancel-retry"},
+		err:  `cannot specify both --retry and --cancel-retry`,
+	}, {
+		args: []string{"dummy/4", "--cancel-retry"},
+		unit: "dummy/4",
+		mode: state.ResolvedCancelRetry,
+	}, {
+		args: []string{"dummy/4"},
+		err:  `cannot set resolved mode for unit "dummy/4": already resolved`,
+		unit: "dummy/4",
+		mode: state.ResolvedCancelRetry,
 	},
 }
 
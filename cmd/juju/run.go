@@ -4,14 +4,17 @@
 package main
 
 import (
+	"bytes"
 	"encoding/base64"
 	"fmt"
+	"io"
 	"strings"
 	"time"
 	"unicode/utf8"
 
 	"github.com/juju/cmd"
 	"github.com/juju/names"
+	"github.com/juju/utils"
 	"launchpad.net/gnuflag"
 
 	"github.com/juju/juju/apiserver/params"
@@ -28,6 +31,7 @@ type RunCommand struct {
 	machines []string
 	services []string
 	units    []string
+	stream   bool
 	commands string
 }
 
@@ -57,6 +61,11 @@ the unit.
 in the environment.  If you specify --all you cannot provide additional
 targets.
 
+--stream writes the output of the commands as it is produced, with each
+line prefixed by the name of the unit that produced it, rather than once
+the commands have finished.  It can only be used with --service and --unit
+targets.
+
 `
 
 func (c *RunCommand) Info() *cmd.Info {
@@ -75,6 +84,7 @@ func (c *RunCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(cmd.NewStringsValue(nil, &c.machines), "machine", "one or more machine ids")
 	f.Var(cmd.NewStringsValue(nil, &c.services), "service", "one or more service names")
 	f.Var(cmd.NewStringsValue(nil, &c.units), "unit", "one or more unit ids")
+	f.BoolVar(&c.stream, "stream", false, "write the output of the commands as it is produced")
 }
 
 func (c *RunCommand) Init(args []string) error {
@@ -98,6 +108,9 @@ func (c *RunCommand) Init(args []string) error {
 			return fmt.Errorf("You must specify a target, either through --all, --machine, --service or --unit")
 		}
 	}
+	if c.stream && (c.all || len(c.machines) != 0) {
+		return fmt.Errorf("You can only use --stream with --service and --unit targets")
+	}
 
 	var nameErrors []string
 	for _, machineId := range c.machines {
@@ -179,6 +192,10 @@ func (c *RunCommand) Run(ctx *cmd.Context) error {
 	}
 	defer client.Close()
 
+	if c.stream {
+		return c.runStreaming(ctx, client)
+	}
+
 	var runResults []params.RunResult
 	if c.all {
 		runResults, err = client.RunOnAllMachines(c.commands, c.timeout)
@@ -217,6 +234,145 @@ func (c *RunCommand) Run(ctx *cmd.Context) error {
 	return nil
 }
 
+// runOutputInterval is the time between checks for new output of
+// commands run with --stream.
+var runOutputInterval = time.Second
+
+// runStreaming runs the commands, writing their output as it is produced
+// and then the status of each of them once they have all finished.
+func (c *RunCommand) runStreaming(ctx *cmd.Context, client RunClient) error {
+	outputId, err := utils.NewUUID()
+	if err != nil {
+		return err
+	}
+	runParams := params.RunParams{
+		Commands: c.commands,
+		Timeout:  c.timeout,
+		Services: c.services,
+		Units:    c.units,
+		OutputId: outputId.String(),
+	}
+	type runResponse struct {
+		results []params.RunResult
+		err     error
+	}
+	done := make(chan runResponse, 1)
+	go func() {
+		results, err := client.Run(runParams)
+		done <- runResponse{results, err}
+	}()
+
+	output := newPrefixedOutput(ctx.Stdout, ctx.Stderr)
+	after := make(map[string]int)
+	var response runResponse
+	for finished := false; !finished; {
+		select {
+		case response = <-done:
+			finished = true
+		case <-time.After(runOutputInterval):
+		}
+		// Once Run has returned, all of the output has been recorded, so
+		// a last check picks up anything written since the previous one.
+		chunks, err := client.RunOutput(runParams.OutputId, after)
+		if err != nil {
+			if finished {
+				logger.Warningf("cannot get output of commands: %v", err)
+				break
+			}
+			return err
+		}
+		for _, chunk := range chunks {
+			output.write(chunk)
+			after[chunk.Unit] = chunk.Seq
+		}
+	}
+	output.flush()
+	if response.err != nil {
+		return block.ProcessBlockedError(response.err, block.BlockChange)
+	}
+
+	if c.out.Name() != "smart" {
+		return c.out.Write(ctx, ConvertRunResults(response.results))
+	}
+	failed := false
+	for _, result := range response.results {
+		switch {
+		case result.Error != "":
+			fmt.Fprintf(ctx.Stderr, "%s: %s\n", result.UnitId, result.Error)
+			failed = true
+		case result.Code != 0:
+			fmt.Fprintf(ctx.Stderr, "%s: exit code %d\n", result.UnitId, result.Code)
+			failed = true
+		}
+	}
+	if len(response.results) == 1 && response.results[0].Error == "" && response.results[0].Code != 0 {
+		return cmd.NewRcPassthroughError(response.results[0].Code)
+	}
+	if failed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
+
+// prefixedOutput writes chunks of output, a line at a time, with each line
+// prefixed by the name of the unit that wrote it.  Partial lines are held
+// back until they are completed, or until the output is flushed.
+type prefixedOutput struct {
+	stdout  io.Writer
+	stderr  io.Writer
+	partial map[prefixedOutputKey]*bytes.Buffer
+	order   []prefixedOutputKey
+}
+
+type prefixedOutputKey struct {
+	unit   string
+	stream string
+}
+
+func newPrefixedOutput(stdout, stderr io.Writer) *prefixedOutput {
+	return &prefixedOutput{
+		stdout:  stdout,
+		stderr:  stderr,
+		partial: make(map[prefixedOutputKey]*bytes.Buffer),
+	}
+}
+
+func (p *prefixedOutput) writer(stream string) io.Writer {
+	if stream == params.OutputStderr {
+		return p.stderr
+	}
+	return p.stdout
+}
+
+// write writes the complete lines in chunk.
+func (p *prefixedOutput) write(chunk params.OutputChunk) {
+	key := prefixedOutputKey{chunk.Unit, chunk.Stream}
+	buf, ok := p.partial[key]
+	if !ok {
+		buf = &bytes.Buffer{}
+		p.partial[key] = buf
+		p.order = append(p.order, key)
+	}
+	buf.WriteString(chunk.Data)
+	for {
+		i := bytes.IndexByte(buf.Bytes(), '\n')
+		if i < 0 {
+			return
+		}
+		fmt.Fprintf(p.writer(chunk.Stream), "%s: %s", chunk.Unit, buf.Next(i+1))
+	}
+}
+
+// flush writes any partial lines that are held back.
+func (p *prefixedOutput) flush() {
+	for _, key := range p.order {
+		if buf := p.partial[key]; buf.Len() > 0 {
+			fmt.Fprintf(p.writer(key.stream), "%s: %s\n", key.unit, buf.Bytes())
+			buf.Reset()
+		}
+	}
+}
+
 // In order to be able to easily mock out the API side for testing,
 // the API client is got using a function.
 
@@ -224,6 +380,7 @@ type RunClient interface {
 	Close() error
 	RunOnAllMachines(commands string, timeout time.Duration) ([]params.RunResult, error)
 	Run(run params.RunParams) ([]params.RunResult, error)
+	RunOutput(outputId string, after map[string]int) ([]params.OutputChunk, error)
 }
 
 // Here we need the signature to be correct for the interface.
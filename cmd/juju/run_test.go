@@ -107,6 +107,19 @@ func (*RunSuite) TestTargetArgParsing(c *gc.C) {
 		machines: []string{"0"},
 		services: []string{"mysql"},
 		units:    []string{"wordpress/0", "wordpress/1"},
+	}, {
+		message:  "stream to units",
+		args:     []string{"--stream", "--unit=wordpress/0", "sudo reboot"},
+		commands: "sudo reboot",
+		units:    []string{"wordpress/0"},
+	}, {
+		message:  "stream to machines",
+		args:     []string{"--stream", "--machine=0", "--unit=wordpress/0", "sudo reboot"},
+		errMatch: `You can only use --stream with --service and --unit targets`,
+	}, {
+		message:  "stream to all machines",
+		args:     []string{"--stream", "--all", "sudo reboot"},
+		errMatch: `You can only use --stream with --service and --unit targets`,
 	}} {
 		c.Log(fmt.Sprintf("%v: %s", i, test.message))
 		runCmd := &RunCommand{}
@@ -375,6 +388,36 @@ func (s *RunSuite) TestSingleResponse(c *gc.C) {
 	}
 }
 
+func (s *RunSuite) TestStreamOutput(c *gc.C) {
+	mock := s.setupMockAPI()
+	mock.setResponse("unit/0", mockResponse{
+		stdout:    "one\ntwo\n",
+		machineId: "0",
+		unitId:    "unit/0",
+	})
+	mock.setResponse("unit/1", mockResponse{
+		stdout:    "three",
+		stderr:    "oops\n",
+		code:      1,
+		machineId: "1",
+		unitId:    "unit/1",
+	})
+	mock.outputChunks = []params.OutputChunk{
+		{Unit: "unit/0", Seq: 1, Stream: params.OutputStdout, Data: "o"},
+		{Unit: "unit/1", Seq: 1, Stream: params.OutputStderr, Data: "oops\n"},
+		{Unit: "unit/0", Seq: 2, Stream: params.OutputStdout, Data: "ne\ntwo\n"},
+		{Unit: "unit/1", Seq: 2, Stream: params.OutputStdout, Data: "three"},
+	}
+
+	context, err := testing.RunCommand(c, envcmd.Wrap(&RunCommand{}),
+		"--stream", "--unit=unit/0,unit/1", "hostname",
+	)
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Check(mock.outputId, gc.Not(gc.Equals), "")
+	c.Check(testing.Stdout(context), gc.Equals, "unit/0: one\nunit/0: two\nunit/1: three\n")
+	c.Check(testing.Stderr(context), gc.Equals, "unit/1: oops\nunit/1: exit code 1\n")
+}
+
 func (s *RunSuite) setupMockAPI() *mockRunAPI {
 	mock := &mockRunAPI{}
 	s.PatchValue(&getRunAPIClient, func(_ *RunCommand) (RunClient, error) {
@@ -391,6 +434,9 @@ type mockRunAPI struct {
 	machines  map[string]bool
 	responses map[string]params.RunResult
 	block     bool
+	// output streamed with --stream
+	outputId     string
+	outputChunks []params.OutputChunk
 }
 
 type mockResponse struct {
@@ -463,6 +509,7 @@ func (m *mockRunAPI) RunOnAllMachines(commands string, timeout time.Duration) ([
 
 func (m *mockRunAPI) Run(runParams params.RunParams) ([]params.RunResult, error) {
 	var result []params.RunResult
+	m.outputId = runParams.OutputId
 
 	if m.block {
 		return result, common.ErrOperationBlocked("The operation has been blocked.")
@@ -484,3 +531,13 @@ func (m *mockRunAPI) Run(runParams params.RunParams) ([]params.RunResult, error)
 
 	return result, nil
 }
+
+func (m *mockRunAPI) RunOutput(outputId string, after map[string]int) ([]params.OutputChunk, error) {
+	var chunks []params.OutputChunk
+	for _, chunk := range m.outputChunks {
+		if chunk.Seq > after[chunk.Unit] {
+			chunks = append(chunks, chunk)
+		}
+	}
+	return chunks, nil
+}
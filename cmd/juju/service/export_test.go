@@ -30,3 +30,19 @@ func NewAddUnitCommand(api ServiceAddUnitAPI) *AddUnitCommand {
 		api: api,
 	}
 }
+
+// NewGetHookRetryCommand returns a GetHookRetryCommand with the api
+// provided as specified.
+func NewGetHookRetryCommand(api GetHookRetryAPI) *GetHookRetryCommand {
+	return &GetHookRetryCommand{
+		api: api,
+	}
+}
+
+// NewSetHookRetryCommand returns a SetHookRetryCommand with the api
+// provided as specified.
+func NewSetHookRetryCommand(api SetHookRetryAPI) *SetHookRetryCommand {
+	return &SetHookRetryCommand{
+		api: api,
+	}
+}
@@ -12,14 +12,20 @@ import (
 )
 
 // fakeServiceAPI is the fake client API for testing the service set,
-// get and unset commands.  It implements the following interfaces:
-// SetServiceAPI, UnsetServiceAPI and GetServiceAPI
+// get, unset, get-hook-retry and set-hook-retry commands.  It implements
+// the following interfaces: SetServiceAPI, UnsetServiceAPI,
+// GetServiceAPI, GetHookRetryAPI and SetHookRetryAPI
 type fakeServiceAPI struct {
 	values    map[string]interface{}
 	servName  string
 	charmName string
 	config    string
 	err       error
+
+	// hookRetry is the service's hook retry policy, or nil if it has
+	// the environment's policy, envHookRetry.
+	hookRetry    *params.HookRetryPolicy
+	envHookRetry params.HookRetryPolicy
 }
 
 func (f *fakeServiceAPI) Close() error {
@@ -98,3 +104,31 @@ func (f *fakeServiceAPI) ServiceUnset(service string, options []string) error {
 
 	return nil
 }
+
+func (f *fakeServiceAPI) ServiceGetHookRetryPolicy(service string) (*params.ServiceHookRetryPolicyResults, error) {
+	if service != f.servName {
+		return nil, errors.NotFoundf("service %q", service)
+	}
+	if f.hookRetry == nil {
+		return &params.ServiceHookRetryPolicyResults{
+			Service:   service,
+			Policy:    f.envHookRetry,
+			Inherited: true,
+		}, nil
+	}
+	return &params.ServiceHookRetryPolicyResults{
+		Service: service,
+		Policy:  *f.hookRetry,
+	}, nil
+}
+
+func (f *fakeServiceAPI) ServiceSetHookRetryPolicy(service string, policy *params.HookRetryPolicy) error {
+	if f.err != nil {
+		return f.err
+	}
+	if service != f.servName {
+		return errors.NotFoundf("service %q", service)
+	}
+	f.hookRetry = policy
+	return nil
+}
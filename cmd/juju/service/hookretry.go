@@ -0,0 +1,249 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package service
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/envcmd"
+	"github.com/juju/juju/cmd/juju/block"
+)
+
+const getHookRetryDoc = `
+Shows the policy by which the failed hooks of the specified service's
+units are retried automatically. The source is "service" if the policy
+was set with juju service set-hook-retry, and "environment" if the
+environment's hook-retry-attempts, hook-retry-delay and
+hook-retry-max-delay settings apply.
+
+See Also:
+   juju service help set-hook-retry
+   juju help resolved
+`
+
+const setHookRetryDoc = `
+Sets the policy by which the failed hooks of the specified service's units
+are retried automatically, overriding the environment's policy. A failed
+hook is retried up to <attempts> times, waiting <delay> before the first
+retry and twice as long before each subsequent retry, up to <max-delay>.
+A unit whose retries are exhausted waits to be resolved. Setting attempts
+to 0 disables automatic retries.
+
+Settings that are not given keep their current values. Use --reset to
+revert the service to the environment's policy.
+
+Example:
+
+    set-hook-retry wordpress attempts=5 delay=30s max-delay=10m
+
+See Also:
+   juju service help get-hook-retry
+   juju help resolved
+`
+
+// GetHookRetryCommand shows the hook retry policy of a service.
+type GetHookRetryCommand struct {
+	envcmd.EnvCommandBase
+	ServiceName string
+	out         cmd.Output
+	api         GetHookRetryAPI
+}
+
+func (c *GetHookRetryCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "get-hook-retry",
+		Args:    "<service>",
+		Purpose: "view the hook retry policy of a service",
+		Doc:     getHookRetryDoc,
+	}
+}
+
+func (c *GetHookRetryCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "yaml", cmd.DefaultFormatters)
+}
+
+func (c *GetHookRetryCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no service name specified")
+	}
+	if !names.IsValidService(args[0]) {
+		return errors.Errorf("invalid service name %q", args[0])
+	}
+	c.ServiceName = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// GetHookRetryAPI defines the methods on the client API that the
+// service get-hook-retry command calls.
+type GetHookRetryAPI interface {
+	Close() error
+	ServiceGetHookRetryPolicy(service string) (*params.ServiceHookRetryPolicyResults, error)
+}
+
+func (c *GetHookRetryCommand) getAPI() (GetHookRetryAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	return c.NewAPIClient()
+}
+
+// hookRetryPolicy is the serialization format of a hook retry policy.
+type hookRetryPolicy struct {
+	Service  string `json:"service" yaml:"service"`
+	Source   string `json:"source" yaml:"source"`
+	Attempts int    `json:"attempts" yaml:"attempts"`
+	Delay    string `json:"delay" yaml:"delay"`
+	MaxDelay string `json:"max-delay" yaml:"max-delay"`
+}
+
+// Run fetches and displays the hook retry policy of the service.
+func (c *GetHookRetryCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	results, err := client.ServiceGetHookRetryPolicy(c.ServiceName)
+	if err != nil {
+		return err
+	}
+	source := "service"
+	if results.Inherited {
+		source = "environment"
+	}
+	return c.out.Write(ctx, hookRetryPolicy{
+		Service:  results.Service,
+		Source:   source,
+		Attempts: results.Policy.Attempts,
+		Delay:    results.Policy.Delay.String(),
+		MaxDelay: results.Policy.MaxDelay.String(),
+	})
+}
+
+// SetHookRetryCommand sets the hook retry policy of a service.
+type SetHookRetryCommand struct {
+	envcmd.EnvCommandBase
+	ServiceName string
+	Reset       bool
+	Attempts    *int
+	Delay       *time.Duration
+	MaxDelay    *time.Duration
+	api         SetHookRetryAPI
+}
+
+func (c *SetHookRetryCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "set-hook-retry",
+		Args:    "<service> [attempts=<n>] [delay=<duration>] [max-delay=<duration>]",
+		Purpose: "set the hook retry policy of a service",
+		Doc:     setHookRetryDoc,
+	}
+}
+
+func (c *SetHookRetryCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.BoolVar(&c.Reset, "reset", false, "revert to the environment's hook retry policy")
+}
+
+func (c *SetHookRetryCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no service name specified")
+	}
+	if !names.IsValidService(args[0]) {
+		return errors.Errorf("invalid service name %q", args[0])
+	}
+	c.ServiceName, args = args[0], args[1:]
+	if c.Reset {
+		if len(args) > 0 {
+			return errors.New("cannot specify settings with --reset")
+		}
+		return nil
+	}
+	if len(args) == 0 {
+		return errors.New("no settings specified")
+	}
+	for _, arg := range args {
+		parts := strings.SplitN(arg, "=", 2)
+		if len(parts) != 2 {
+			return errors.Errorf("expected <key>=<value>, got %q", arg)
+		}
+		key, value := parts[0], parts[1]
+		switch key {
+		case "attempts":
+			attempts, err := strconv.Atoi(value)
+			if err != nil || attempts < 0 {
+				return errors.Errorf("invalid attempts %q: expected non-negative integer", value)
+			}
+			c.Attempts = &attempts
+		case "delay", "max-delay":
+			d, err := time.ParseDuration(value)
+			if err != nil || d <= 0 {
+				return errors.Errorf("invalid %s %q: expected positive duration", key, value)
+			}
+			if key == "delay" {
+				c.Delay = &d
+			} else {
+				c.MaxDelay = &d
+			}
+		default:
+			return errors.Errorf("unknown setting %q", key)
+		}
+	}
+	return nil
+}
+
+// SetHookRetryAPI defines the methods on the client API that the
+// service set-hook-retry command calls.
+type SetHookRetryAPI interface {
+	Close() error
+	ServiceGetHookRetryPolicy(service string) (*params.ServiceHookRetryPolicyResults, error)
+	ServiceSetHookRetryPolicy(service string, policy *params.HookRetryPolicy) error
+}
+
+func (c *SetHookRetryCommand) getAPI() (SetHookRetryAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	return c.NewAPIClient()
+}
+
+// Run sets the hook retry policy of the service, taking any settings
+// not given from its current policy.
+func (c *SetHookRetryCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if c.Reset {
+		return block.ProcessBlockedError(client.ServiceSetHookRetryPolicy(c.ServiceName, nil), block.BlockChange)
+	}
+	current, err := client.ServiceGetHookRetryPolicy(c.ServiceName)
+	if err != nil {
+		return err
+	}
+	policy := current.Policy
+	if c.Attempts != nil {
+		policy.Attempts = *c.Attempts
+	}
+	if c.Delay != nil {
+		policy.Delay = *c.Delay
+	}
+	if c.MaxDelay != nil {
+		policy.MaxDelay = *c.MaxDelay
+	}
+	if policy.MaxDelay < policy.Delay {
+		return errors.Errorf("max-delay %v is less than delay %v", policy.MaxDelay, policy.Delay)
+	}
+	return block.ProcessBlockedError(client.ServiceSetHookRetryPolicy(c.ServiceName, &policy), block.BlockChange)
+}
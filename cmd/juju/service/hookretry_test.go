@@ -0,0 +1,143 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package service_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/envcmd"
+	"github.com/juju/juju/cmd/juju/service"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type HookRetrySuite struct {
+	coretesting.FakeJujuHomeSuite
+	fake *fakeServiceAPI
+}
+
+var _ = gc.Suite(&HookRetrySuite{})
+
+func (s *HookRetrySuite) SetUpTest(c *gc.C) {
+	s.FakeJujuHomeSuite.SetUpTest(c)
+	s.fake = &fakeServiceAPI{
+		servName: "dummy-service",
+		envHookRetry: params.HookRetryPolicy{
+			Attempts: 0,
+			Delay:    10 * time.Second,
+			MaxDelay: 10 * time.Minute,
+		},
+	}
+}
+
+func (s *HookRetrySuite) runGet(c *gc.C, args ...string) (*cmd.Context, error) {
+	return coretesting.RunCommand(c, envcmd.Wrap(service.NewGetHookRetryCommand(s.fake)), args...)
+}
+
+func (s *HookRetrySuite) runSet(c *gc.C, args ...string) (*cmd.Context, error) {
+	return coretesting.RunCommand(c, envcmd.Wrap(service.NewSetHookRetryCommand(s.fake)), args...)
+}
+
+func (s *HookRetrySuite) TestGetInit(c *gc.C) {
+	_, err := s.runGet(c)
+	c.Assert(err, gc.ErrorMatches, "no service name specified")
+	_, err = s.runGet(c, "dummy-service", "extra")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["extra"\]`)
+}
+
+func (s *HookRetrySuite) TestGetEnvironmentPolicy(c *gc.C) {
+	ctx, err := s.runGet(c, "dummy-service")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(coretesting.Stdout(ctx), gc.Equals, `
+service: dummy-service
+source: environment
+attempts: 0
+delay: 10s
+max-delay: 10m0s
+`[1:])
+}
+
+func (s *HookRetrySuite) TestGetServicePolicy(c *gc.C) {
+	s.fake.hookRetry = &params.HookRetryPolicy{
+		Attempts: 3,
+		Delay:    time.Minute,
+		MaxDelay: time.Hour,
+	}
+	ctx, err := s.runGet(c, "dummy-service", "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(coretesting.Stdout(ctx), gc.Equals,
+		`{"service":"dummy-service","source":"service","attempts":3,"delay":"1m0s","max-delay":"1h0m0s"}`+"\n")
+}
+
+var setHookRetryInitTests = []struct {
+	args []string
+	err  string
+}{{
+	err: "no service name specified",
+}, {
+	args: []string{"dummy-service"},
+	err:  "no settings specified",
+}, {
+	args: []string{"dummy-service", "attempts"},
+	err:  `expected <key>=<value>, got "attempts"`,
+}, {
+	args: []string{"dummy-service", "attempts=-1"},
+	err:  `invalid attempts "-1": expected non-negative integer`,
+}, {
+	args: []string{"dummy-service", "delay=soon"},
+	err:  `invalid delay "soon": expected positive duration`,
+}, {
+	args: []string{"dummy-service", "colour=blue"},
+	err:  `unknown setting "colour"`,
+}, {
+	args: []string{"dummy-service", "--reset", "attempts=3"},
+	err:  "cannot specify settings with --reset",
+}}
+
+func (s *HookRetrySuite) TestSetInit(c *gc.C) {
+	for i, t := range setHookRetryInitTests {
+		c.Logf("test %d: %v", i, t.args)
+		err := coretesting.InitCommand(&service.SetHookRetryCommand{}, t.args)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *HookRetrySuite) TestSet(c *gc.C) {
+	_, err := s.runSet(c, "dummy-service", "attempts=5", "delay=30s")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.hookRetry, jc.DeepEquals, &params.HookRetryPolicy{
+		Attempts: 5,
+		Delay:    30 * time.Second,
+		MaxDelay: 10 * time.Minute,
+	})
+
+	_, err = s.runSet(c, "dummy-service", "max-delay=1h")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.hookRetry, jc.DeepEquals, &params.HookRetryPolicy{
+		Attempts: 5,
+		Delay:    30 * time.Second,
+		MaxDelay: time.Hour,
+	})
+}
+
+func (s *HookRetrySuite) TestSetMaxDelayLessThanDelay(c *gc.C) {
+	_, err := s.runSet(c, "dummy-service", "delay=1h")
+	c.Assert(err, gc.ErrorMatches, "max-delay 10m0s is less than delay 1h0m0s")
+	c.Assert(s.fake.hookRetry, gc.IsNil)
+}
+
+func (s *HookRetrySuite) TestSetReset(c *gc.C) {
+	s.fake.hookRetry = &params.HookRetryPolicy{
+		Attempts: 3,
+		Delay:    time.Minute,
+		MaxDelay: time.Hour,
+	}
+	_, err := s.runSet(c, "dummy-service", "--reset")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.hookRetry, gc.IsNil)
+}
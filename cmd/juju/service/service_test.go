@@ -23,9 +23,11 @@ var expectedCommmandNames = []string{
 	"add-unit",
 	"get",
 	"get-constraints",
+	"get-hook-retry",
 	"help",
 	"set",
 	"set-constraints",
+	"set-hook-retry",
 	"unset",
 }
 
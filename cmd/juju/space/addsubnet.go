@@ -0,0 +1,87 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package space
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+const addSubnetCommandDoc = `
+Add the subnet with the given CIDR to a space.  If Juju does not know the
+subnet yet, it is added with the provider id and availability zone given
+with --provider-id and --zone; the provisioner uses these to start
+machines on the subnet.
+
+Examples:
+
+  # Add a subnet to the db space.
+  juju space add-subnet db 10.0.3.0/24 --provider-id subnet-c0ffee --zone us-east-1a
+`
+
+// AddSubnetCommand adds a subnet to a space.
+type AddSubnetCommand struct {
+	SpaceCommandBase
+	name       string
+	cidr       string
+	providerId string
+	zone       string
+}
+
+// Info implements Command.Info.
+func (c *AddSubnetCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "add-subnet",
+		Args:    "<name> <CIDR>",
+		Purpose: "add a subnet to a network space",
+		Doc:     addSubnetCommandDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *AddSubnetCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.SpaceCommandBase.SetFlags(f)
+	f.StringVar(&c.providerId, "provider-id", "", "the provider's id for the subnet")
+	f.StringVar(&c.zone, "zone", "", "the availability zone that the subnet is in")
+}
+
+// Init implements Command.Init.
+func (c *AddSubnetCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("no space name specified")
+	case 1:
+		return errors.New("no subnet CIDR specified")
+	}
+	c.name, c.cidr = args[0], args[1]
+	if err := validateSpaceName(c.name); err != nil {
+		return err
+	}
+	if err := validateCIDR(c.cidr); err != nil {
+		return err
+	}
+	return cmd.CheckEmpty(args[2:])
+}
+
+// Run implements Command.Run.
+func (c *AddSubnetCommand) Run(ctx *cmd.Context) error {
+	client, err := getSpaceAPI(&c.SpaceCommandBase)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	subnet := params.Subnet{
+		CIDR:             c.cidr,
+		ProviderId:       c.providerId,
+		AvailabilityZone: c.zone,
+	}
+	if err := client.AddSubnets(c.name, []params.Subnet{subnet}); err != nil {
+		return errors.Annotatef(err, "cannot add subnet %q to space %q", c.cidr, c.name)
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package space
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+const createCommandDoc = `
+Create a space with the given name, made up of the subnets with the given
+CIDRs.  More subnets can be added to the space later with
+"juju space add-subnet".  A subnet can only be in one space.
+
+Examples:
+
+  # Create a space for database traffic.
+  juju space create db 10.0.1.0/24 10.0.2.0/24
+`
+
+// CreateCommand creates a space.
+type CreateCommand struct {
+	SpaceCommandBase
+	name  string
+	cidrs []string
+}
+
+// Info implements Command.Info.
+func (c *CreateCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "create",
+		Args:    "<name> [<CIDR> ...]",
+		Purpose: "create a network space",
+		Doc:     createCommandDoc,
+	}
+}
+
+// Init implements Command.Init.
+func (c *CreateCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no space name specified")
+	}
+	c.name, c.cidrs = args[0], args[1:]
+	if err := validateSpaceName(c.name); err != nil {
+		return err
+	}
+	for _, cidr := range c.cidrs {
+		if err := validateCIDR(cidr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *CreateCommand) Run(ctx *cmd.Context) error {
+	client, err := getSpaceAPI(&c.SpaceCommandBase)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	subnets := make([]params.Subnet, len(c.cidrs))
+	for i, cidr := range c.cidrs {
+		subnets[i] = params.Subnet{CIDR: cidr}
+	}
+	if err := client.CreateSpace(c.name, subnets); err != nil {
+		return errors.Annotatef(err, "cannot create space %q", c.name)
+	}
+	ctx.Infof("created space %q", c.name)
+	return nil
+}
@@ -0,0 +1,79 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package space
+
+import (
+	"github.com/juju/cmd"
+	"launchpad.net/gnuflag"
+)
+
+const listCommandDoc = `
+List the network spaces in the environment, with their subnets.
+`
+
+// ListCommand lists spaces.
+type ListCommand struct {
+	SpaceCommandBase
+	out cmd.Output
+}
+
+// Info implements Command.Info.
+func (c *ListCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "list",
+		Purpose: "list network spaces",
+		Doc:     listCommandDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *ListCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.SpaceCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+}
+
+// Init implements Command.Init.
+func (c *ListCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// SubnetInfo defines the serialization behaviour of a subnet of a
+// space.
+type SubnetInfo struct {
+	ProviderId string `yaml:"provider-id,omitempty" json:"provider-id,omitempty"`
+	Zone       string `yaml:"zone,omitempty" json:"zone,omitempty"`
+}
+
+// Run implements Command.Run.
+func (c *ListCommand) Run(ctx *cmd.Context) error {
+	client, err := getSpaceAPI(&c.SpaceCommandBase)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	spaces, err := client.ListSpaces()
+	if err != nil {
+		return err
+	}
+	if len(spaces) == 0 {
+		ctx.Infof("no spaces to display")
+		return nil
+	}
+	result := make(map[string]map[string]SubnetInfo)
+	for _, space := range spaces {
+		subnets := make(map[string]SubnetInfo)
+		for _, subnet := range space.Subnets {
+			subnets[subnet.CIDR] = SubnetInfo{
+				ProviderId: subnet.ProviderId,
+				Zone:       subnet.AvailabilityZone,
+			}
+		}
+		result[space.Name] = subnets
+	}
+	return c.out.Write(ctx, result)
+}
@@ -0,0 +1,77 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package space
+
+import (
+	"net"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/spaces"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/envcmd"
+	"github.com/juju/juju/network"
+)
+
+const spaceCommandDoc = `
+"juju space" is used to manage network spaces, which are named groups of
+subnets.  The endpoints of a service can be bound to a space when the
+service is deployed, with "juju deploy --bind".
+`
+
+const spaceCommandPurpose = "manage network spaces"
+
+// NewSuperCommand creates the space supercommand and registers the
+// subcommands that it supports.
+func NewSuperCommand() cmd.Command {
+	spacecmd := cmd.NewSuperCommand(cmd.SuperCommandParams{
+		Name:        "space",
+		Doc:         spaceCommandDoc,
+		UsagePrefix: "juju",
+		Purpose:     spaceCommandPurpose,
+	})
+	spacecmd.Register(envcmd.Wrap(&CreateCommand{}))
+	spacecmd.Register(envcmd.Wrap(&ListCommand{}))
+	spacecmd.Register(envcmd.Wrap(&AddSubnetCommand{}))
+	return spacecmd
+}
+
+// SpaceAPI defines the spaces API methods that the space commands use.
+type SpaceAPI interface {
+	CreateSpace(name string, subnets []params.Subnet) error
+	AddSubnets(name string, subnets []params.Subnet) error
+	ListSpaces() ([]params.SpaceSubnets, error)
+	Close() error
+}
+
+// SpaceCommandBase is a helper base structure that has a method to get
+// the spaces API client.
+type SpaceCommandBase struct {
+	envcmd.EnvCommandBase
+}
+
+var getSpaceAPI = func(c *SpaceCommandBase) (SpaceAPI, error) {
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, err
+	}
+	return spaces.NewClient(root), nil
+}
+
+// validateSpaceName returns an error if name is not a valid space name.
+func validateSpaceName(name string) error {
+	if !network.IsValidSpaceName(name) {
+		return errors.Errorf("invalid space name %q", name)
+	}
+	return nil
+}
+
+// validateCIDR returns an error if cidr is not a valid subnet CIDR.
+func validateCIDR(cidr string) error {
+	if _, _, err := net.ParseCIDR(cidr); err != nil {
+		return errors.Errorf("invalid subnet CIDR %q", cidr)
+	}
+	return nil
+}
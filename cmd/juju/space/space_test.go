@@ -0,0 +1,151 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package space_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/envcmd"
+	"github.com/juju/juju/cmd/juju/space"
+	"github.com/juju/juju/testing"
+)
+
+type spaceSuite struct {
+	testing.FakeJujuHomeSuite
+	mockAPI *fakeSpaceAPI
+}
+
+var _ = gc.Suite(&spaceSuite{})
+
+type fakeSpaceAPI struct {
+	calls  []string
+	name   string
+	spaces []params.SpaceSubnets
+	err    error
+}
+
+func (*fakeSpaceAPI) Close() error {
+	return nil
+}
+
+func (f *fakeSpaceAPI) CreateSpace(name string, subnets []params.Subnet) error {
+	f.calls = append(f.calls, "CreateSpace")
+	f.name = name
+	f.spaces = []params.SpaceSubnets{{Name: name, Subnets: subnets}}
+	return f.err
+}
+
+func (f *fakeSpaceAPI) AddSubnets(name string, subnets []params.Subnet) error {
+	f.calls = append(f.calls, "AddSubnets")
+	f.name = name
+	f.spaces = []params.SpaceSubnets{{Name: name, Subnets: subnets}}
+	return f.err
+}
+
+func (f *fakeSpaceAPI) ListSpaces() ([]params.SpaceSubnets, error) {
+	f.calls = append(f.calls, "ListSpaces")
+	return f.spaces, f.err
+}
+
+func (s *spaceSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuHomeSuite.SetUpTest(c)
+	s.mockAPI = &fakeSpaceAPI{}
+	s.PatchValue(space.GetSpaceAPI, func(*space.SpaceCommandBase) (space.SpaceAPI, error) {
+		return s.mockAPI, nil
+	})
+}
+
+func runCommand(c *gc.C, command cmd.Command, args ...string) (*cmd.Context, error) {
+	return testing.RunCommand(c, envcmd.Wrap(command), args...)
+}
+
+func (s *spaceSuite) TestCreate(c *gc.C) {
+	ctx, err := runCommand(c, &space.CreateCommand{}, "db", "10.0.1.0/24", "10.0.2.0/24")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(testing.Stderr(ctx), gc.Equals, "created space \"db\"\n")
+	c.Check(s.mockAPI.calls, jc.DeepEquals, []string{"CreateSpace"})
+	c.Check(s.mockAPI.spaces, jc.DeepEquals, []params.SpaceSubnets{{
+		Name:    "db",
+		Subnets: []params.Subnet{{CIDR: "10.0.1.0/24"}, {CIDR: "10.0.2.0/24"}},
+	}})
+}
+
+func (s *spaceSuite) TestCreateError(c *gc.C) {
+	s.mockAPI.err = errors.New("boom")
+	_, err := runCommand(c, &space.CreateCommand{}, "db")
+	c.Assert(err, gc.ErrorMatches, `cannot create space "db": boom`)
+}
+
+func (s *spaceSuite) TestCreateInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{
+		{nil, "no space name specified"},
+		{[]string{"Bad Name"}, `invalid space name "Bad Name"`},
+		{[]string{"db", "10.0.0.0"}, `invalid subnet CIDR "10.0.0.0"`},
+	} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := runCommand(c, &space.CreateCommand{}, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *spaceSuite) TestAddSubnet(c *gc.C) {
+	_, err := runCommand(c, &space.AddSubnetCommand{},
+		"db", "10.0.3.0/24", "--provider-id", "subnet-c0ffee", "--zone", "us-east-1a")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.mockAPI.calls, jc.DeepEquals, []string{"AddSubnets"})
+	c.Check(s.mockAPI.spaces, jc.DeepEquals, []params.SpaceSubnets{{
+		Name: "db",
+		Subnets: []params.Subnet{{
+			CIDR:             "10.0.3.0/24",
+			ProviderId:       "subnet-c0ffee",
+			AvailabilityZone: "us-east-1a",
+		}},
+	}})
+}
+
+func (s *spaceSuite) TestAddSubnetInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{
+		{nil, "no space name specified"},
+		{[]string{"db"}, "no subnet CIDR specified"},
+		{[]string{"db", "nonsense"}, `invalid subnet CIDR "nonsense"`},
+		{[]string{"db", "10.0.0.0/24", "10.0.1.0/24"}, `unrecognized args: \["10.0.1.0/24"\]`},
+	} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := runCommand(c, &space.AddSubnetCommand{}, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *spaceSuite) TestList(c *gc.C) {
+	s.mockAPI.spaces = []params.SpaceSubnets{{
+		Name: "db",
+		Subnets: []params.Subnet{
+			{CIDR: "10.0.1.0/24", ProviderId: "subnet-1", AvailabilityZone: "zone-a"},
+			{CIDR: "10.0.2.0/24"},
+		},
+	}, {
+		Name: "dmz",
+	}}
+	ctx, err := runCommand(c, &space.ListCommand{}, "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(testing.Stdout(ctx), gc.Equals,
+		`{"db":{"10.0.1.0/24":{"provider-id":"subnet-1","zone":"zone-a"},"10.0.2.0/24":{}},"dmz":{}}`+"\n")
+}
+
+func (s *spaceSuite) TestListEmpty(c *gc.C) {
+	ctx, err := runCommand(c, &space.ListCommand{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(testing.Stdout(ctx), gc.Equals, "")
+	c.Check(testing.Stderr(ctx), gc.Equals, "no spaces to display\n")
+}
@@ -242,6 +242,14 @@ type unitStatus struct {
 	OpenedPorts   []string              `json:"open-ports,omitempty" yaml:"open-ports,omitempty"`
 	PublicAddress string                `json:"public-address,omitempty" yaml:"public-address,omitempty"`
 	Subordinates  map[string]unitStatus `json:"subordinates,omitempty" yaml:"subordinates,omitempty"`
+
+	HealthChecks map[string]healthCheckStatus `json:"health-checks,omitempty" yaml:"health-checks,omitempty"`
+}
+
+type healthCheckStatus struct {
+	Current string `json:"current" yaml:"current"`
+	Message string `json:"message,omitempty" yaml:"message,omitempty"`
+	Since   string `json:"since,omitempty" yaml:"since,omitempty"`
 }
 
 type statusInfoContents struct {
@@ -471,6 +479,26 @@ func (sf *statusFormatter) formatUnit(unit api.UnitStatus, serviceName string) u
 	for k, m := range unit.Subordinates {
 		out.Subordinates[k] = sf.formatUnit(m, serviceName)
 	}
+	if len(unit.HealthChecks) > 0 {
+		out.HealthChecks = make(map[string]healthCheckStatus)
+		for name, check := range unit.HealthChecks {
+			out.HealthChecks[name] = sf.formatHealthCheck(check)
+		}
+	}
+	return out
+}
+
+func (sf *statusFormatter) formatHealthCheck(check api.HealthCheckStatus) healthCheckStatus {
+	out := healthCheckStatus{
+		Current: "healthy",
+		Message: check.Message,
+	}
+	if !check.Healthy {
+		out.Current = "failing"
+	}
+	if check.Since != nil {
+		out.Since = formatStatusTime(check.Since, sf.isoTime)
+	}
 	return out
 }
 
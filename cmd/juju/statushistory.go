@@ -4,18 +4,22 @@
 package main
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"strconv"
+	"text/tabwriter"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/names"
+	"launchpad.net/gnuflag"
 
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/envcmd"
 	"github.com/juju/juju/juju/osenv"
-	"launchpad.net/gnuflag"
 )
 
 type StatusHistoryCommand struct {
@@ -24,25 +28,44 @@ type StatusHistoryCommand struct {
 	outputContent string
 	backlogSize   int
 	isoTime       bool
-	unitName      string
+	since         string
+	until         string
+	status        string
+	tag           names.Tag
+	filter        params.StatusHistoryFilter
 }
 
 var statusHistoryDoc = `
 This command will report the history of status changes for
-a given unit.
-The statuses for the unit workload and/or agent are available.
+a given unit, machine or service.
+The statuses for a unit's workload and/or agent are available;
+machines only have agent statuses, and services only have
+workload statuses.
 -type supports:
-    agent: will show statuses for the unit's agent
-    workload: will show statuses for the unit's workload
+    agent: will show statuses for the agent
+    workload: will show statuses for the workload
     combined: will show agent and workload statuses combined
  and sorted by time of occurence.
+
+The history may be limited to a range of time with --since and
+--until, which accept either an RFC3339 time (e.g.
+2015-06-01T12:00:00Z) or a duration before now (e.g. 24h), and to
+a single status value with --status.
+
+The yaml and json formats include the data recorded with each status.
+
+Examples:
+    juju status-history mysql/0
+    juju status-history --since 24h --status error mysql/0
+    juju status-history --format yaml 0
+    juju status-history --until 2015-06-01T00:00:00Z wordpress
 `
 
 func (c *StatusHistoryCommand) Info() *cmd.Info {
 	return &cmd.Info{
 		Name:    "status-history",
-		Args:    "[-n N] <unit>",
-		Purpose: "output past statuses for a unit",
+		Args:    "[-n N] <unit|machine|service>",
+		Purpose: "output past statuses for a unit, machine or service",
 		Doc:     statusHistoryDoc,
 	}
 }
@@ -51,16 +74,35 @@ func (c *StatusHistoryCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.StringVar(&c.outputContent, "type", "combined", "type of statuses to be displayed [agent|workload|combined].")
 	f.IntVar(&c.backlogSize, "n", 20, "size of logs backlog.")
 	f.BoolVar(&c.isoTime, "utc", false, "display time as UTC in RFC3339 format")
+	f.StringVar(&c.since, "since", "", "only show statuses set at or after this time")
+	f.StringVar(&c.until, "until", "", "only show statuses set at or before this time")
+	f.StringVar(&c.status, "status", "", "only show statuses with this value")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": c.formatTabular,
+	})
 }
 
 func (c *StatusHistoryCommand) Init(args []string) error {
 	switch {
 	case len(args) > 1:
-		return errors.Errorf("unexpected arguments after unit name.")
+		return errors.Errorf("unexpected arguments after entity name.")
 	case len(args) == 0:
-		return errors.Errorf("unit name is missing.")
+		return errors.Errorf("unit, machine or service name is missing.")
+	}
+	switch name := args[0]; {
+	case names.IsValidUnit(name):
+		c.tag = names.NewUnitTag(name)
+	case names.IsValidMachine(name):
+		c.tag = names.NewMachineTag(name)
+	case names.IsValidService(name):
+		c.tag = names.NewServiceTag(name)
 	default:
-		c.unitName = args[0]
+		return errors.Errorf("%q is not a valid unit, machine or service name", name)
+	}
+	if c.backlogSize < 0 {
+		return errors.Errorf("invalid backlog size %d", c.backlogSize)
 	}
 	// If use of ISO time not specified on command line,
 	// check env var.
@@ -76,10 +118,30 @@ func (c *StatusHistoryCommand) Init(args []string) error {
 	kind := params.HistoryKind(c.outputContent)
 	switch kind {
 	case params.KindCombined, params.KindAgent, params.KindWorkload:
-		return nil
+	default:
+		return errors.Errorf("unexpected status type %q", c.outputContent)
+	}
 
+	now := time.Now()
+	c.filter = params.StatusHistoryFilter{
+		Size:   c.backlogSize,
+		Status: params.Status(c.status),
+	}
+	if c.since != "" {
+		since, err := parseAuditLogTime(c.since, now)
+		if err != nil {
+			return errors.Annotate(err, "invalid --since value")
+		}
+		c.filter.Since = &since
+	}
+	if c.until != "" {
+		until, err := parseAuditLogTime(c.until, now)
+		if err != nil {
+			return errors.Annotate(err, "invalid --until value")
+		}
+		c.filter.Until = &until
 	}
-	return errors.Errorf("unexpected status type %q", c.outputContent)
+	return nil
 }
 
 func (c *StatusHistoryCommand) Run(ctx *cmd.Context) error {
@@ -88,9 +150,11 @@ func (c *StatusHistoryCommand) Run(ctx *cmd.Context) error {
 		return fmt.Errorf(connectionError, c.ConnectionName(), err)
 	}
 	defer apiclient.Close()
-	var statuses *api.UnitStatusHistory
 	kind := params.HistoryKind(c.outputContent)
-	statuses, err = apiclient.UnitStatusHistory(kind, c.unitName, c.backlogSize)
+	statuses, err := apiclient.StatusHistory(kind, c.tag, c.filter)
+	if errors.IsNotImplemented(err) {
+		statuses, err = c.legacyStatusHistory(apiclient, kind)
+	}
 	if err != nil {
 		if len(statuses.Statuses) == 0 {
 			return errors.Trace(err)
@@ -100,20 +164,52 @@ func (c *StatusHistoryCommand) Run(ctx *cmd.Context) error {
 	} else if len(statuses.Statuses) == 0 {
 		return errors.Errorf("no status history available")
 	}
-	table := [][]string{{"TIME", "TYPE", "STATUS", "MESSAGE"}}
-	lengths := []int{1, 1, 1, 1}
+	return c.out.Write(ctx, statuses.Statuses)
+}
+
+// legacyStatusHistory fetches the status history of a unit from an API
+// server that does not support filtered status history queries.
+func (c *StatusHistoryCommand) legacyStatusHistory(apiclient *api.Client, kind params.HistoryKind) (*params.StatusHistoryResult, error) {
+	result := &params.StatusHistoryResult{}
+	if c.tag.Kind() != names.UnitTagKind {
+		return result, errors.NotSupportedf("status history for %s on this server", names.ReadableString(c.tag))
+	}
+	if c.filter.Since != nil || c.filter.Until != nil || c.filter.Status != "" {
+		return result, errors.NotSupportedf("filtering status history on this server")
+	}
+	statuses, err := apiclient.UnitStatusHistory(kind, c.tag.Id(), c.backlogSize)
 	for _, v := range statuses.Statuses {
-		fields := []string{formatStatusTime(v.Since, c.isoTime), string(v.Kind), string(v.Status), v.Info}
-		for k, v := range fields {
-			if len(v) > lengths[k] {
-				lengths[k] = len(v)
-			}
-		}
-		table = append(table, fields)
+		result.Statuses = append(result.Statuses, params.StatusHistoryEntry{
+			Kind:   v.Kind,
+			Status: v.Status,
+			Info:   v.Info,
+			Data:   v.Data,
+			Since:  v.Since,
+		})
+	}
+	return result, err
+}
+
+// formatTabular returns a tabular summary of status history entries.
+func (c *StatusHistoryCommand) formatTabular(value interface{}) ([]byte, error) {
+	statuses, ok := value.([]params.StatusHistoryEntry)
+	if !ok {
+		return nil, errors.Errorf("expected value of type %T, got %T", statuses, value)
 	}
-	f := fmt.Sprintf("%%-%ds\t%%-%ds\t%%-%ds\t%%-%ds\n", lengths[0], lengths[1], lengths[2], lengths[3])
-	for _, v := range table {
-		fmt.Printf(f, v[0], v[1], v[2], v[3])
+	var out bytes.Buffer
+	const (
+		// To format things into columns.
+		minwidth = 0
+		tabwidth = 1
+		padding  = 2
+		padchar  = ' '
+		flags    = 0
+	)
+	tw := tabwriter.NewWriter(&out, minwidth, tabwidth, padding, padchar, flags)
+	fmt.Fprintf(tw, "TIME\tTYPE\tSTATUS\tMESSAGE\n")
+	for _, v := range statuses {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", formatStatusTime(v.Since, c.isoTime), v.Kind, v.Status, v.Info)
 	}
-	return nil
+	tw.Flush()
+	return out.Bytes(), nil
 }
@@ -0,0 +1,129 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package main
+
+import (
+	"time"
+
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/testing"
+)
+
+type StatusHistorySuite struct {
+	testing.FakeJujuHomeSuite
+}
+
+var _ = gc.Suite(&StatusHistorySuite{})
+
+func (s *StatusHistorySuite) TestInitEntity(c *gc.C) {
+	for i, test := range []struct {
+		arg string
+		tag names.Tag
+	}{{
+		arg: "mysql/0",
+		tag: names.NewUnitTag("mysql/0"),
+	}, {
+		arg: "0",
+		tag: names.NewMachineTag("0"),
+	}, {
+		arg: "0/lxc/1",
+		tag: names.NewMachineTag("0/lxc/1"),
+	}, {
+		arg: "mysql",
+		tag: names.NewServiceTag("mysql"),
+	}} {
+		c.Logf("test %d: %s", i, test.arg)
+		command := &StatusHistoryCommand{}
+		err := testing.InitCommand(command, []string{test.arg})
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(command.tag, gc.Equals, test.tag)
+		c.Check(command.filter, jc.DeepEquals, params.StatusHistoryFilter{Size: 20})
+	}
+}
+
+func (s *StatusHistorySuite) TestInitFilter(c *gc.C) {
+	command := &StatusHistoryCommand{}
+	err := testing.InitCommand(command, []string{
+		"-n", "5",
+		"--since", "2015-06-01T00:00:00Z",
+		"--until", "2015-06-02T00:00:00Z",
+		"--status", "error",
+		"mysql/0",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	since := time.Date(2015, 6, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2015, 6, 2, 0, 0, 0, 0, time.UTC)
+	c.Assert(command.filter.Size, gc.Equals, 5)
+	c.Assert(command.filter.Status, gc.Equals, params.StatusError)
+	c.Assert(command.filter.Since.Equal(since), jc.IsTrue)
+	c.Assert(command.filter.Until.Equal(until), jc.IsTrue)
+}
+
+func (s *StatusHistorySuite) TestInitSinceDuration(c *gc.C) {
+	before := time.Now()
+	command := &StatusHistoryCommand{}
+	err := testing.InitCommand(command, []string{"--since", "24h", "0"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(command.filter.Since, gc.NotNil)
+	c.Assert(command.filter.Since.Before(before.Add(-23*time.Hour)), jc.IsTrue)
+	c.Assert(command.filter.Until, gc.IsNil)
+}
+
+func (s *StatusHistorySuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{},
+		err:  `unit, machine or service name is missing.`,
+	}, {
+		args: []string{"mysql/0", "extra"},
+		err:  `unexpected arguments after entity name.`,
+	}, {
+		args: []string{"mysql/"},
+		err:  `"mysql/" is not a valid unit, machine or service name`,
+	}, {
+		args: []string{"--type", "bogus", "mysql/0"},
+		err:  `unexpected status type "bogus"`,
+	}, {
+		args: []string{"-n", "-1", "mysql/0"},
+		err:  `invalid backlog size -1`,
+	}, {
+		args: []string{"--since", "yesterday", "mysql/0"},
+		err:  `invalid --since value: expected RFC3339 time or positive duration, got "yesterday"`,
+	}, {
+		args: []string{"--until", "-1h", "mysql/0"},
+		err:  `invalid --until value: expected RFC3339 time or positive duration, got "-1h"`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		err := testing.InitCommand(&StatusHistoryCommand{}, test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *StatusHistorySuite) TestFormatTabular(c *gc.C) {
+	t0 := time.Date(2015, 6, 1, 12, 0, 0, 0, time.UTC)
+	t1 := t0.Add(time.Minute)
+	command := &StatusHistoryCommand{isoTime: true}
+	out, err := command.formatTabular([]params.StatusHistoryEntry{{
+		Kind:   params.KindAgent,
+		Status: params.StatusStarted,
+		Since:  &t0,
+	}, {
+		Kind:   params.KindAgent,
+		Status: params.StatusError,
+		Info:   "boom",
+		Data:   map[string]interface{}{"foo": "bar"},
+		Since:  &t1,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(out), gc.Equals, ""+
+		"TIME                  TYPE   STATUS   MESSAGE\n"+
+		"2015-06-01 12:00:00Z  agent  started  \n"+
+		"2015-06-01 12:01:00Z  agent  error    boom\n")
+}
@@ -9,6 +9,9 @@ var (
 	GetPoolListAPI    = &getPoolListAPI
 	GetPoolCreateAPI  = &getPoolCreateAPI
 	GetVolumeListAPI  = &getVolumeListAPI
+	GetSnapshotAPI    = &getSnapshotAPI
+
+	GetVolumeResizeAPI = &getVolumeResizeAPI
 
 	ConvertToVolumeInfo = convertToVolumeInfo
 	GetStorageAddAPI    = &getStorageAddAPI
@@ -5,6 +5,7 @@ package storage_test
 
 import (
 	"strings"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/names"
@@ -122,8 +123,32 @@ func (s *ShowSuite) assertValidShow(c *gc.C, args []string, expected string) {
 	c.Assert(obtained, gc.Equals, expected)
 }
 
+func (s *ShowSuite) TestShowSnapshots(c *gc.C) {
+	s.mockAPI.withSnapshots = true
+	s.assertValidShow(
+		c,
+		[]string{"db-dir/1000"},
+		`
+postgresql/0:
+  db-dir/1000:
+    storage: db-dir
+    kind: block
+    status: pending
+    persistent: false
+    snapshots:
+      0/0:
+        volume: 0/1
+        status: available
+        created: 2015-08-01 12:00:00Z
+        id: snap-0
+        size: 1024
+`[1:],
+	)
+}
+
 type mockShowAPI struct {
-	noMatch bool
+	noMatch       bool
+	withSnapshots bool
 }
 
 func (s mockShowAPI) Close() error {
@@ -145,6 +170,16 @@ func (s mockShowAPI) Show(tags []names.StorageTag) ([]params.StorageDetails, err
 		if i == 1 {
 			all[i].Persistent = true
 		}
+		if s.withSnapshots {
+			all[i].Snapshots = []params.VolumeSnapshotDetails{{
+				Id:         "0/0",
+				VolumeTag:  "volume-0-1",
+				Status:     "available",
+				Created:    time.Date(2015, 8, 1, 12, 0, 0, 0, time.UTC),
+				SnapshotId: "snap-0",
+				Size:       1024,
+			}}
+		}
 	}
 	for _, tag := range tags {
 		if strings.Contains(tag.String(), "shared") {
@@ -0,0 +1,99 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/envcmd"
+)
+
+const snapshotCmdDoc = `
+"juju storage snapshot" is used to manage snapshots of storage
+ volumes in the Juju environment.
+`
+
+const snapshotCmdPurpose = "manage storage volume snapshots"
+
+// NewSnapshotSuperCommand creates the storage snapshot super subcommand
+// and registers the subcommands that it supports.
+func NewSnapshotSuperCommand() cmd.Command {
+	snapshotcmd := Command{
+		SuperCommand: *jujucmd.NewSubSuperCommand(cmd.SuperCommandParams{
+			Name:        "snapshot",
+			Doc:         snapshotCmdDoc,
+			UsagePrefix: "juju storage",
+			Purpose:     snapshotCmdPurpose,
+		})}
+	snapshotcmd.Register(envcmd.Wrap(&SnapshotCreateCommand{}))
+	snapshotcmd.Register(envcmd.Wrap(&SnapshotListCommand{}))
+	snapshotcmd.Register(envcmd.Wrap(&SnapshotDeleteCommand{}))
+	snapshotcmd.Register(envcmd.Wrap(&SnapshotRestoreCommand{}))
+	return &snapshotcmd
+}
+
+// SnapshotCommandBase is a helper base structure for snapshot commands.
+type SnapshotCommandBase struct {
+	StorageCommandBase
+}
+
+var getSnapshotAPI = (*SnapshotCommandBase).getSnapshotAPI
+
+// SnapshotAPI defines the API methods that the snapshot commands use.
+type SnapshotAPI interface {
+	Close() error
+	CreateVolumeSnapshots(volumes []string) ([]params.VolumeSnapshotDetailsResult, error)
+	ListVolumeSnapshots(volumes []string) ([]params.VolumeSnapshotDetailsResult, error)
+	DestroyVolumeSnapshots(ids []string) ([]params.ErrorResult, error)
+	RestoreVolumeSnapshots(restores []params.VolumeSnapshotRestore) ([]params.StringResult, error)
+}
+
+func (c *SnapshotCommandBase) getSnapshotAPI() (SnapshotAPI, error) {
+	return c.NewStorageAPI()
+}
+
+// SnapshotInfo defines the serialization behaviour for storage volume
+// snapshots.
+type SnapshotInfo struct {
+	// from params.VolumeSnapshotDetails. This is juju volume id.
+	Volume string `yaml:"volume" json:"volume"`
+
+	// from params.VolumeSnapshotDetails
+	Status string `yaml:"status" json:"status"`
+
+	// from params.VolumeSnapshotDetails
+	Created string `yaml:"created" json:"created"`
+
+	// from params.VolumeSnapshotDetails. This is provider-supplied
+	// unique snapshot id.
+	SnapshotId string `yaml:"id,omitempty" json:"id,omitempty"`
+
+	// from params.VolumeSnapshotDetails
+	Size uint64 `yaml:"size,omitempty" json:"size,omitempty"`
+}
+
+const snapshotTimeFormat = "2006-01-02 15:04:05Z"
+
+// convertToSnapshotInfo returns a map of snapshot info keyed on
+// juju snapshot id.
+func convertToSnapshotInfo(all []params.VolumeSnapshotDetails) (map[string]SnapshotInfo, error) {
+	result := make(map[string]SnapshotInfo)
+	for _, one := range all {
+		volume, err := idFromTag(one.VolumeTag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result[one.Id] = SnapshotInfo{
+			Volume:     volume,
+			Status:     one.Status,
+			Created:    one.Created.UTC().Format(snapshotTimeFormat),
+			SnapshotId: one.SnapshotId,
+			Size:       one.Size,
+		}
+	}
+	return result, nil
+}
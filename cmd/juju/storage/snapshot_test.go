@@ -0,0 +1,255 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/envcmd"
+	"github.com/juju/juju/cmd/juju/storage"
+	"github.com/juju/juju/testing"
+)
+
+var expectedSnapshotCommmandNames = []string{
+	"create",
+	"delete",
+	"help",
+	"list",
+	"restore",
+}
+
+type snapshotHelpSuite struct {
+	HelpStorageSuite
+}
+
+var _ = gc.Suite(&snapshotHelpSuite{})
+
+func (s *snapshotHelpSuite) TestSnapshotHelp(c *gc.C) {
+	s.command = storage.NewSnapshotSuperCommand().(*storage.Command)
+	s.assertHelp(c, expectedSnapshotCommmandNames)
+}
+
+type snapshotSuite struct {
+	SubStorageSuite
+	mockAPI *mockSnapshotAPI
+}
+
+var _ = gc.Suite(&snapshotSuite{})
+
+func (s *snapshotSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+
+	s.mockAPI = &mockSnapshotAPI{}
+	s.PatchValue(storage.GetSnapshotAPI,
+		func(c *storage.SnapshotCommandBase) (storage.SnapshotAPI, error) {
+			return s.mockAPI, nil
+		})
+}
+
+func runSnapshotCommand(c *gc.C, command cmd.Command, args ...string) (*cmd.Context, error) {
+	return testing.RunCommand(c, envcmd.Wrap(command), args...)
+}
+
+func (s *snapshotSuite) TestCreate(c *gc.C) {
+	context, err := runSnapshotCommand(c, &storage.SnapshotCreateCommand{}, "0/1", "2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mockAPI.volumes, jc.DeepEquals, []string{"0/1", "2"})
+	c.Assert(testing.Stderr(context), gc.Equals, `
+snapshot 0/0 of volume 0/1 requested
+cannot snapshot volume 2: volume "2" not found
+`[1:])
+}
+
+func (s *snapshotSuite) TestCreateNoVolumes(c *gc.C) {
+	_, err := runSnapshotCommand(c, &storage.SnapshotCreateCommand{})
+	c.Assert(err, gc.ErrorMatches, "must specify volume id\\(s\\)")
+}
+
+func (s *snapshotSuite) TestCreateInvalidVolume(c *gc.C) {
+	_, err := runSnapshotCommand(c, &storage.SnapshotCreateCommand{}, "foo")
+	c.Assert(err, gc.ErrorMatches, `volume id "foo" not valid`)
+}
+
+func (s *snapshotSuite) TestCreateError(c *gc.C) {
+	s.mockAPI.err = errors.New("just my luck")
+	_, err := runSnapshotCommand(c, &storage.SnapshotCreateCommand{}, "0/1")
+	c.Assert(err, gc.ErrorMatches, "just my luck")
+}
+
+func (s *snapshotSuite) TestListTabular(c *gc.C) {
+	context, err := runSnapshotCommand(c, &storage.SnapshotListCommand{}, "0/1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mockAPI.volumes, jc.DeepEquals, []string{"0/1"})
+	c.Assert(testing.Stdout(context), gc.Equals, `
+SNAPSHOT  VOLUME  STATUS     CREATED               ID      SIZE
+0/0       0/1     available  2015-08-01 12:00:00Z  snap-0  1.0GiB
+1         0/1     pending    2015-08-01 12:00:00Z          
+`[1:])
+}
+
+func (s *snapshotSuite) TestListYaml(c *gc.C) {
+	context, err := runSnapshotCommand(c, &storage.SnapshotListCommand{}, "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mockAPI.volumes, gc.HasLen, 0)
+	c.Assert(testing.Stdout(context), gc.Equals, `
+0/0:
+  volume: 0/1
+  status: available
+  created: 2015-08-01 12:00:00Z
+  id: snap-0
+  size: 1024
+"1":
+  volume: 0/1
+  status: pending
+  created: 2015-08-01 12:00:00Z
+`[1:])
+}
+
+func (s *snapshotSuite) TestListInvalidVolume(c *gc.C) {
+	_, err := runSnapshotCommand(c, &storage.SnapshotListCommand{}, "foo")
+	c.Assert(err, gc.ErrorMatches, `volume id "foo" not valid`)
+}
+
+func (s *snapshotSuite) TestDelete(c *gc.C) {
+	context, err := runSnapshotCommand(c, &storage.SnapshotDeleteCommand{}, "0/0", "42")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mockAPI.ids, jc.DeepEquals, []string{"0/0", "42"})
+	c.Assert(testing.Stderr(context), gc.Equals, `
+cannot delete snapshot 42: volume snapshot "42" not found
+`[1:])
+}
+
+func (s *snapshotSuite) TestDeleteNoSnapshots(c *gc.C) {
+	_, err := runSnapshotCommand(c, &storage.SnapshotDeleteCommand{})
+	c.Assert(err, gc.ErrorMatches, "must specify snapshot id\\(s\\)")
+}
+
+func (s *snapshotSuite) TestRestore(c *gc.C) {
+	context, err := runSnapshotCommand(c, &storage.SnapshotRestoreCommand{}, "0/0", "1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mockAPI.restores, jc.DeepEquals, []params.VolumeSnapshotRestore{{
+		Id: "0/0", MachineTag: "machine-1",
+	}})
+	c.Assert(testing.Stderr(context), gc.Equals, "volume 1/0 created from snapshot 0/0\n")
+}
+
+func (s *snapshotSuite) TestRestoreFailure(c *gc.C) {
+	_, err := runSnapshotCommand(c, &storage.SnapshotRestoreCommand{}, "42", "1")
+	c.Assert(err, gc.ErrorMatches, `cannot restore snapshot 42: volume snapshot "42" not found`)
+}
+
+func (s *snapshotSuite) TestRestoreInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "must specify snapshot id",
+	}, {
+		args: []string{"0/0"},
+		err:  "must specify machine id",
+	}, {
+		args: []string{"0/0", "foo"},
+		err:  `machine id "foo" not valid`,
+	}, {
+		args: []string{"0/0", "1", "2"},
+		err:  `unrecognized args: \["2"\]`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := runSnapshotCommand(c, &storage.SnapshotRestoreCommand{}, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+type mockSnapshotAPI struct {
+	err      error
+	volumes  []string
+	ids      []string
+	restores []params.VolumeSnapshotRestore
+}
+
+func (s *mockSnapshotAPI) Close() error {
+	return nil
+}
+
+var snapshotCreated = time.Date(2015, 8, 1, 12, 0, 0, 0, time.UTC)
+
+func (s *mockSnapshotAPI) CreateVolumeSnapshots(volumes []string) ([]params.VolumeSnapshotDetailsResult, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	s.volumes = volumes
+	results := make([]params.VolumeSnapshotDetailsResult, len(volumes))
+	results[0].Result = params.VolumeSnapshotDetails{
+		Id:        "0/0",
+		VolumeTag: "volume-0-1",
+		Status:    "pending",
+		Created:   snapshotCreated,
+	}
+	for i := 1; i < len(volumes); i++ {
+		results[i].Error = common.ServerError(errors.NotFoundf("volume %q", volumes[i]))
+	}
+	return results, nil
+}
+
+func (s *mockSnapshotAPI) ListVolumeSnapshots(volumes []string) ([]params.VolumeSnapshotDetailsResult, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	s.volumes = volumes
+	return []params.VolumeSnapshotDetailsResult{{
+		Result: params.VolumeSnapshotDetails{
+			Id:         "0/0",
+			VolumeTag:  "volume-0-1",
+			Status:     "available",
+			Created:    snapshotCreated,
+			SnapshotId: "snap-0",
+			Size:       1024,
+		},
+	}, {
+		Result: params.VolumeSnapshotDetails{
+			Id:        "1",
+			VolumeTag: "volume-0-1",
+			Status:    "pending",
+			Created:   snapshotCreated,
+		},
+	}}, nil
+}
+
+func (s *mockSnapshotAPI) DestroyVolumeSnapshots(ids []string) ([]params.ErrorResult, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	s.ids = ids
+	results := make([]params.ErrorResult, len(ids))
+	for i, id := range ids {
+		if id == "42" {
+			results[i].Error = common.ServerError(errors.NotFoundf("volume snapshot %q", id))
+		}
+	}
+	return results, nil
+}
+
+func (s *mockSnapshotAPI) RestoreVolumeSnapshots(restores []params.VolumeSnapshotRestore) ([]params.StringResult, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	s.restores = restores
+	results := make([]params.StringResult, len(restores))
+	for i, restore := range restores {
+		if restore.Id == "42" {
+			results[i].Error = common.ServerError(errors.NotFoundf("volume snapshot %q", restore.Id))
+			continue
+		}
+		results[i].Result = "volume-1-0"
+	}
+	return results, nil
+}
@@ -0,0 +1,81 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"fmt"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names"
+
+	"github.com/juju/juju/cmd/juju/block"
+)
+
+const SnapshotCreateCommandDoc = `
+Request point-in-time snapshots of storage volumes. Snapshots are taken
+asynchronously; use "juju storage snapshot list" to see when they become
+available.
+
+options:
+-e, --environment (= "")
+    juju environment to operate in
+<volume> [...]
+    ids of the volumes to snapshot
+
+Example:
+    juju storage snapshot create 0/1 2
+`
+
+// SnapshotCreateCommand requests snapshots of storage volumes.
+type SnapshotCreateCommand struct {
+	SnapshotCommandBase
+	Ids []string
+}
+
+// Init implements Command.Init.
+func (c *SnapshotCreateCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("must specify volume id(s)")
+	}
+	for _, id := range args {
+		if !names.IsValidVolume(id) {
+			return errors.NotValidf("volume id %q", id)
+		}
+	}
+	c.Ids = args
+	return nil
+}
+
+// Info implements Command.Info.
+func (c *SnapshotCreateCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "create",
+		Args:    "<volume> [...]",
+		Purpose: "snapshot storage volumes",
+		Doc:     SnapshotCreateCommandDoc,
+	}
+}
+
+// Run implements Command.Run.
+func (c *SnapshotCreateCommand) Run(ctx *cmd.Context) error {
+	api, err := getSnapshotAPI(&c.SnapshotCommandBase)
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	results, err := api.CreateVolumeSnapshots(c.Ids)
+	if err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	for i, one := range results {
+		if one.Error != nil {
+			fmt.Fprintf(ctx.Stderr, "cannot snapshot volume %s: %v\n", c.Ids[i], one.Error)
+			continue
+		}
+		ctx.Infof("snapshot %s of volume %s requested", one.Result.Id, c.Ids[i])
+	}
+	return nil
+}
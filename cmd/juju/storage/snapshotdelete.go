@@ -0,0 +1,72 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"fmt"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/cmd/juju/block"
+)
+
+const SnapshotDeleteCommandDoc = `
+Delete storage volume snapshots. Snapshots are destroyed asynchronously;
+they are listed with the "destroying" status until they are gone.
+
+options:
+-e, --environment (= "")
+    juju environment to operate in
+<snapshot> [...]
+    ids of the snapshots to delete
+
+Example:
+    juju storage snapshot delete 0/0 1
+`
+
+// SnapshotDeleteCommand deletes storage volume snapshots.
+type SnapshotDeleteCommand struct {
+	SnapshotCommandBase
+	Ids []string
+}
+
+// Init implements Command.Init.
+func (c *SnapshotDeleteCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("must specify snapshot id(s)")
+	}
+	c.Ids = args
+	return nil
+}
+
+// Info implements Command.Info.
+func (c *SnapshotDeleteCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "delete",
+		Args:    "<snapshot> [...]",
+		Purpose: "delete storage volume snapshots",
+		Doc:     SnapshotDeleteCommandDoc,
+	}
+}
+
+// Run implements Command.Run.
+func (c *SnapshotDeleteCommand) Run(ctx *cmd.Context) error {
+	api, err := getSnapshotAPI(&c.SnapshotCommandBase)
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	results, err := api.DestroyVolumeSnapshots(c.Ids)
+	if err != nil {
+		return block.ProcessBlockedError(err, block.BlockRemove)
+	}
+	for i, one := range results {
+		if one.Error != nil {
+			fmt.Fprintf(ctx.Stderr, "cannot delete snapshot %s: %v\n", c.Ids[i], one.Error)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/dustin/go-humanize"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+const SnapshotListCommandDoc = `
+List snapshots of storage volumes in the environment.
+
+options:
+-e, --environment (= "")
+    juju environment to operate in
+-o, --output (= "")
+    specify an output file
+--format (= tabular)
+    specify output format (json|tabular|yaml)
+[volume]
+    volume ids for filtering the list
+
+`
+
+// SnapshotListCommand lists storage volume snapshots.
+type SnapshotListCommand struct {
+	SnapshotCommandBase
+	Ids []string
+	out cmd.Output
+}
+
+// Init implements Command.Init.
+func (c *SnapshotListCommand) Init(args []string) error {
+	for _, id := range args {
+		if !names.IsValidVolume(id) {
+			return errors.NotValidf("volume id %q", id)
+		}
+	}
+	c.Ids = args
+	return nil
+}
+
+// Info implements Command.Info.
+func (c *SnapshotListCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "list",
+		Purpose: "list storage volume snapshots",
+		Doc:     SnapshotListCommandDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *SnapshotListCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.StorageCommandBase.SetFlags(f)
+
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatSnapshotListTabular,
+	})
+}
+
+// Run implements Command.Run.
+func (c *SnapshotListCommand) Run(ctx *cmd.Context) error {
+	api, err := getSnapshotAPI(&c.SnapshotCommandBase)
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	found, err := api.ListVolumeSnapshots(c.Ids)
+	if err != nil {
+		return err
+	}
+	// filter out valid output, if any
+	var valid []params.VolumeSnapshotDetails
+	for _, one := range found {
+		if one.Error == nil {
+			valid = append(valid, one.Result)
+			continue
+		}
+		// display individual error
+		fmt.Fprintf(ctx.Stderr, "%v\n", one.Error)
+	}
+	if len(valid) == 0 {
+		return nil
+	}
+	output, err := convertToSnapshotInfo(valid)
+	if err != nil {
+		return err
+	}
+	return c.out.Write(ctx, output)
+}
+
+// formatSnapshotListTabular returns a tabular summary of volume snapshots.
+func formatSnapshotListTabular(value interface{}) ([]byte, error) {
+	infos, ok := value.(map[string]SnapshotInfo)
+	if !ok {
+		return nil, errors.Errorf("expected value of type %T, got %T", infos, value)
+	}
+	var out bytes.Buffer
+	const (
+		// To format things into columns.
+		minwidth = 0
+		tabwidth = 1
+		padding  = 2
+		padchar  = ' '
+		flags    = 0
+	)
+	tw := tabwriter.NewWriter(&out, minwidth, tabwidth, padding, padchar, flags)
+
+	print := func(values ...string) {
+		fmt.Fprintln(tw, strings.Join(values, "\t"))
+	}
+	print("SNAPSHOT", "VOLUME", "STATUS", "CREATED", "ID", "SIZE")
+
+	ids := make([]string, 0, len(infos))
+	for id := range infos {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		info := infos[id]
+		var size string
+		if info.Size > 0 {
+			size = humanize.IBytes(info.Size * humanize.MiByte)
+		}
+		print(id, info.Volume, info.Status, info.Created, info.SnapshotId, size)
+	}
+	tw.Flush()
+	return out.Bytes(), nil
+}
@@ -0,0 +1,91 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/block"
+)
+
+const SnapshotRestoreCommandDoc = `
+Create a new storage volume from a snapshot, and attach it to a machine.
+The new volume is created in the same storage pool as the snapshotted
+volume, and is at least as large as the snapshot.
+
+options:
+-e, --environment (= "")
+    juju environment to operate in
+<snapshot>
+    id of the snapshot to restore
+<machine>
+    id of the machine to attach the new volume to
+
+Example:
+    juju storage snapshot restore 0/0 0
+`
+
+// SnapshotRestoreCommand creates a volume from a storage volume snapshot.
+type SnapshotRestoreCommand struct {
+	SnapshotCommandBase
+	Id      string
+	Machine string
+}
+
+// Init implements Command.Init.
+func (c *SnapshotRestoreCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("must specify snapshot id")
+	case 1:
+		return errors.New("must specify machine id")
+	}
+	if !names.IsValidMachine(args[1]) {
+		return errors.NotValidf("machine id %q", args[1])
+	}
+	c.Id, c.Machine = args[0], args[1]
+	return cmd.CheckEmpty(args[2:])
+}
+
+// Info implements Command.Info.
+func (c *SnapshotRestoreCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "restore",
+		Args:    "<snapshot> <machine>",
+		Purpose: "create a storage volume from a snapshot",
+		Doc:     SnapshotRestoreCommandDoc,
+	}
+}
+
+// Run implements Command.Run.
+func (c *SnapshotRestoreCommand) Run(ctx *cmd.Context) error {
+	api, err := getSnapshotAPI(&c.SnapshotCommandBase)
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	results, err := api.RestoreVolumeSnapshots([]params.VolumeSnapshotRestore{{
+		Id:         c.Id,
+		MachineTag: names.NewMachineTag(c.Machine).String(),
+	}})
+	if err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	if len(results) != 1 {
+		return errors.Errorf("expected 1 result, got %d", len(results))
+	}
+	if err := results[0].Error; err != nil {
+		return errors.Annotatef(err, "cannot restore snapshot %s", c.Id)
+	}
+	volume, err := idFromTag(results[0].Result)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ctx.Infof("volume %s created from snapshot %s", volume, c.Id)
+	return nil
+}
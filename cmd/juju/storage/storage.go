@@ -44,6 +44,7 @@ func NewSuperCommand() cmd.Command {
 	storagecmd.Register(envcmd.Wrap(&AddCommand{}))
 	storagecmd.Register(NewPoolSuperCommand())
 	storagecmd.Register(NewVolumeSuperCommand())
+	storagecmd.Register(NewSnapshotSuperCommand())
 	return &storagecmd
 }
 
@@ -70,6 +71,10 @@ type StorageInfo struct {
 	Status      string `yaml:"status,omitempty" json:"status,omitempty"`
 	Persistent  bool   `yaml:"persistent" json:"persistent"`
 	Location    string `yaml:"location,omitempty" json:"location,omitempty"`
+
+	// Snapshots holds the snapshots of the storage's volume, keyed
+	// on snapshot id.
+	Snapshots map[string]SnapshotInfo `yaml:"snapshots,omitempty" json:"snapshots,omitempty"`
 }
 
 // formatStorageDetails takes a set of StorageDetail and creates a
@@ -100,6 +105,12 @@ func formatStorageDetails(storages []params.StorageDetails) (map[string]map[stri
 			Location:    one.Location,
 			Persistent:  one.Persistent,
 		}
+		if len(one.Snapshots) > 0 {
+			si.Snapshots, err = convertToSnapshotInfo(one.Snapshots)
+			if err != nil {
+				return nil, errors.Annotate(err, "invalid snapshot")
+			}
+		}
 		unit := unitTag.Id()
 		unitColl, ok := output[unit]
 		if !ok {
@@ -15,6 +15,7 @@ var expectedSubCommmandNames = []string{
 	"list",
 	"pool",
 	"show",
+	"snapshot",
 	"volume",
 }
 
@@ -31,6 +31,7 @@ func NewVolumeSuperCommand() cmd.Command {
 			Purpose:     volumeCmdPurpose,
 		})}
 	poolcmd.Register(envcmd.Wrap(&VolumeListCommand{}))
+	poolcmd.Register(envcmd.Wrap(&VolumeResizeCommand{}))
 	return &poolcmd
 }
 
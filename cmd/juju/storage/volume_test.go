@@ -12,6 +12,7 @@ import (
 var expectedVolumeCommmandNames = []string{
 	"help",
 	"list",
+	"resize",
 }
 
 type volumeSuite struct {
@@ -0,0 +1,109 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	"github.com/juju/utils"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/block"
+)
+
+const VolumeResizeCommandDoc = `
+Grow a storage volume to the specified size. The size is a decimal
+number with an optional M, G, T or P suffix (MiB by default).
+
+The volume is resized by the storage provisioner, and the charm is
+notified with the storage-resized hook once the new size is available.
+Volumes may only be grown; requests to shrink a volume are rejected.
+Not all storage providers support resizing volumes.
+
+options:
+-e, --environment (= "")
+    juju environment to operate in
+<volume>
+    id of the volume to resize
+<size>
+    new size of the volume
+
+Example:
+    juju storage volume resize 0/1 20G
+`
+
+// VolumeResizeCommand requests that a storage volume be resized.
+type VolumeResizeCommand struct {
+	VolumeCommandBase
+	Volume string
+	Size   uint64
+}
+
+// Init implements Command.Init.
+func (c *VolumeResizeCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("must specify volume id")
+	case 1:
+		return errors.New("must specify size")
+	}
+	if !names.IsValidVolume(args[0]) {
+		return errors.NotValidf("volume id %q", args[0])
+	}
+	size, err := utils.ParseSize(args[1])
+	if err != nil {
+		return errors.Annotate(err, "cannot parse size")
+	}
+	c.Volume, c.Size = args[0], size
+	return cmd.CheckEmpty(args[2:])
+}
+
+// Info implements Command.Info.
+func (c *VolumeResizeCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "resize",
+		Args:    "<volume> <size>",
+		Purpose: "grow a storage volume",
+		Doc:     VolumeResizeCommandDoc,
+	}
+}
+
+// Run implements Command.Run.
+func (c *VolumeResizeCommand) Run(ctx *cmd.Context) error {
+	api, err := getVolumeResizeAPI(c)
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	results, err := api.ResizeVolumes([]params.VolumeSize{{
+		VolumeTag: names.NewVolumeTag(c.Volume).String(),
+		Size:      c.Size,
+	}})
+	if err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	if len(results) != 1 {
+		return errors.Errorf("expected 1 result, got %d", len(results))
+	}
+	if err := results[0].Error; err != nil {
+		return errors.Annotatef(err, "cannot resize volume %s", c.Volume)
+	}
+	ctx.Infof("resize of volume %s to %dMiB requested", c.Volume, c.Size)
+	return nil
+}
+
+var getVolumeResizeAPI = (*VolumeResizeCommand).getVolumeResizeAPI
+
+// VolumeResizeAPI defines the API methods that the volume resize
+// command uses.
+type VolumeResizeAPI interface {
+	Close() error
+	ResizeVolumes([]params.VolumeSize) ([]params.ErrorResult, error)
+}
+
+func (c *VolumeResizeCommand) getVolumeResizeAPI() (VolumeResizeAPI, error) {
+	return c.NewStorageAPI()
+}
@@ -0,0 +1,95 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/envcmd"
+	"github.com/juju/juju/cmd/juju/storage"
+	"github.com/juju/juju/testing"
+)
+
+type volumeResizeSuite struct {
+	SubStorageSuite
+	mockAPI *mockVolumeResizeAPI
+}
+
+var _ = gc.Suite(&volumeResizeSuite{})
+
+func (s *volumeResizeSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+
+	s.mockAPI = &mockVolumeResizeAPI{}
+	s.PatchValue(storage.GetVolumeResizeAPI,
+		func(c *storage.VolumeResizeCommand) (storage.VolumeResizeAPI, error) {
+			return s.mockAPI, nil
+		})
+}
+
+func (s *volumeResizeSuite) TestResize(c *gc.C) {
+	context, err := testing.RunCommand(c, envcmd.Wrap(&storage.VolumeResizeCommand{}), "0/1", "2G")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mockAPI.sizes, jc.DeepEquals, []params.VolumeSize{{
+		VolumeTag: "volume-0-1",
+		Size:      2048,
+	}})
+	c.Assert(testing.Stderr(context), gc.Equals, "resize of volume 0/1 to 2048MiB requested\n")
+}
+
+func (s *volumeResizeSuite) TestResizeFailure(c *gc.C) {
+	_, err := testing.RunCommand(c, envcmd.Wrap(&storage.VolumeResizeCommand{}), "0/1", "512")
+	c.Assert(err, gc.ErrorMatches, "cannot resize volume 0/1: cannot shrink volume from 1024MiB to 512MiB")
+}
+
+func (s *volumeResizeSuite) TestResizeInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "must specify volume id",
+	}, {
+		args: []string{"0/1"},
+		err:  "must specify size",
+	}, {
+		args: []string{"foo", "2G"},
+		err:  `volume id "foo" not valid`,
+	}, {
+		args: []string{"0/1", "big"},
+		err:  `cannot parse size: .*`,
+	}, {
+		args: []string{"0/1", "2G", "3G"},
+		err:  `unrecognized args: \["3G"\]`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := testing.RunCommand(c, envcmd.Wrap(&storage.VolumeResizeCommand{}), test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+type mockVolumeResizeAPI struct {
+	sizes []params.VolumeSize
+}
+
+func (s *mockVolumeResizeAPI) Close() error {
+	return nil
+}
+
+func (s *mockVolumeResizeAPI) ResizeVolumes(sizes []params.VolumeSize) ([]params.ErrorResult, error) {
+	s.sizes = sizes
+	results := make([]params.ErrorResult, len(sizes))
+	for i, size := range sizes {
+		if size.Size < 1024 {
+			results[i].Error = common.ServerError(errors.Errorf(
+				"cannot shrink volume from 1024MiB to %dMiB", size.Size,
+			))
+		}
+	}
+	return results, nil
+}
@@ -56,3 +56,33 @@ func NewListCommand(api UserInfoAPI) *ListCommand {
 		},
 	}
 }
+
+// NewCreateTokenCommand returns a CreateTokenCommand with the api
+// provided as specified.
+func NewCreateTokenCommand(api TokenAPI) *CreateTokenCommand {
+	return &CreateTokenCommand{
+		TokenCommandBase: TokenCommandBase{
+			api: api,
+		},
+	}
+}
+
+// NewListTokensCommand returns a ListTokensCommand with the api
+// provided as specified.
+func NewListTokensCommand(api TokenAPI) *ListTokensCommand {
+	return &ListTokensCommand{
+		TokenCommandBase: TokenCommandBase{
+			api: api,
+		},
+	}
+}
+
+// NewRevokeTokenCommand returns a RevokeTokenCommand with the api
+// provided as specified.
+func NewRevokeTokenCommand(api TokenAPI) *RevokeTokenCommand {
+	return &RevokeTokenCommand{
+		TokenCommandBase: TokenCommandBase{
+			api: api,
+		},
+	}
+}
@@ -0,0 +1,281 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package user
+
+import (
+	"bytes"
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/block"
+)
+
+const createTokenDoc = `
+Create an API token for the current user.
+
+A token may be used in place of the user's password to log in, and is
+intended for non-interactive clients such as CI jobs. Every token
+expires, and every use of a token is recorded in the audit log against
+the token's ID. The token's credential is only shown when the token is
+created.
+
+A token grants at most the level of access given with --access (read,
+write or admin) to an environment, and never more than the user has.
+By default, the token grants the same access as the user has. A token
+cannot be used to change the user's password or to manage tokens.
+
+Examples:
+  # Create a token that expires in one day
+  juju user create-token --expires 24h
+
+  # Create a token that may only be used to inspect environments
+  juju user create-token --expires 168h --access read
+
+See Also:
+  juju user list-tokens
+  juju user revoke-token
+`
+
+const listTokensDoc = `
+List the API tokens of the current user, including any that have
+expired. Token credentials are never shown.
+
+See Also:
+  juju user create-token
+  juju user revoke-token
+`
+
+const revokeTokenDoc = `
+Revoke an API token of the current user, so that it can no longer be
+used to log in.
+
+Examples:
+  juju user revoke-token 5f1bd7a0c3e2f841
+
+See Also:
+  juju user create-token
+  juju user list-tokens
+`
+
+// TokenAPI defines the API methods that the token commands use.
+type TokenAPI interface {
+	CreateToken(expiresIn time.Duration, access string) (params.CreateTokenResult, error)
+	ListTokens() ([]params.UserToken, error)
+	RevokeToken(id string) error
+	Close() error
+}
+
+// TokenCommandBase is a common base for the token commands.
+type TokenCommandBase struct {
+	UserCommandBase
+	api TokenAPI
+}
+
+func (c *TokenCommandBase) getTokenAPI() (TokenAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	return c.NewUserManagerClient()
+}
+
+// TokenInfo defines the serialization behaviour of API token information.
+type TokenInfo struct {
+	ID         string `yaml:"id" json:"id"`
+	Access     string `yaml:"access" json:"access"`
+	Created    string `yaml:"created" json:"created"`
+	Expires    string `yaml:"expires" json:"expires"`
+	LastUsed   string `yaml:"last-used,omitempty" json:"last-used,omitempty"`
+	Credential string `yaml:"credential,omitempty" json:"credential,omitempty"`
+}
+
+const tokenTimeFormat = "2006-01-02 15:04:05Z"
+
+func apiTokenToTokenInfo(token params.UserToken) TokenInfo {
+	info := TokenInfo{
+		ID:      token.ID,
+		Access:  token.Access,
+		Created: token.Created.UTC().Format(tokenTimeFormat),
+		Expires: token.Expires.UTC().Format(tokenTimeFormat),
+	}
+	if token.LastUsed != nil {
+		info.LastUsed = token.LastUsed.UTC().Format(tokenTimeFormat)
+	}
+	return info
+}
+
+// CreateTokenCommand creates an API token for the current user.
+type CreateTokenCommand struct {
+	TokenCommandBase
+	out       cmd.Output
+	ExpiresIn time.Duration
+	Access    string
+}
+
+// Info implements Command.Info.
+func (c *CreateTokenCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "create-token",
+		Purpose: "create an expiring API token for the current user",
+		Doc:     createTokenDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *CreateTokenCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.DurationVar(&c.ExpiresIn, "expires", 24*time.Hour, "how long the token remains valid for")
+	f.StringVar(&c.Access, "access", "", "the most access the token grants: read, write or admin")
+	c.out.AddFlags(f, "yaml", cmd.DefaultFormatters)
+}
+
+// Init implements Command.Init.
+func (c *CreateTokenCommand) Init(args []string) error {
+	if c.ExpiresIn <= 0 {
+		return errors.Errorf("invalid expiry %v: must be positive", c.ExpiresIn)
+	}
+	switch c.Access {
+	case "", "read", "write", "admin":
+	default:
+		return errors.Errorf("invalid access level %q: expected read, write or admin", c.Access)
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// Run implements Command.Run.
+func (c *CreateTokenCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getTokenAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	result, err := client.CreateToken(c.ExpiresIn, c.Access)
+	if err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	info := apiTokenToTokenInfo(result.UserToken)
+	info.Credential = result.Credential
+	return c.out.Write(ctx, info)
+}
+
+// ListTokensCommand lists the API tokens of the current user.
+type ListTokensCommand struct {
+	TokenCommandBase
+	out cmd.Output
+}
+
+// Info implements Command.Info.
+func (c *ListTokensCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "list-tokens",
+		Purpose: "list the API tokens of the current user",
+		Doc:     listTokensDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *ListTokensCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatTokensTabular,
+	})
+}
+
+// Run implements Command.Run.
+func (c *ListTokensCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getTokenAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	tokens, err := client.ListTokens()
+	if err != nil {
+		return err
+	}
+	output := []TokenInfo{}
+	for _, token := range tokens {
+		output = append(output, apiTokenToTokenInfo(token))
+	}
+	return c.out.Write(ctx, output)
+}
+
+func formatTokensTabular(value interface{}) ([]byte, error) {
+	tokens, ok := value.([]TokenInfo)
+	if !ok {
+		return nil, errors.Errorf("expected value of type %T, got %T", tokens, value)
+	}
+	var out bytes.Buffer
+	const (
+		// To format things into columns.
+		minwidth = 0
+		tabwidth = 1
+		padding  = 2
+		padchar  = ' '
+		flags    = 0
+	)
+	now := time.Now().UTC().Format(tokenTimeFormat)
+	tw := tabwriter.NewWriter(&out, minwidth, tabwidth, padding, padchar, flags)
+	fmt.Fprintf(tw, "ID\tACCESS\tCREATED\tEXPIRES\tLAST USED\n")
+	for _, token := range tokens {
+		expires := token.Expires
+		// The times share a fixed-width format, so they sort as strings.
+		if expires <= now {
+			expires += " (expired)"
+		}
+		lastUsed := token.LastUsed
+		if lastUsed == "" {
+			lastUsed = "never used"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", token.ID, token.Access, token.Created, expires, lastUsed)
+	}
+	tw.Flush()
+	return out.Bytes(), nil
+}
+
+// RevokeTokenCommand revokes an API token of the current user.
+type RevokeTokenCommand struct {
+	TokenCommandBase
+	ID string
+}
+
+// Info implements Command.Info.
+func (c *RevokeTokenCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "revoke-token",
+		Args:    "<token id>",
+		Purpose: "revoke an API token of the current user",
+		Doc:     revokeTokenDoc,
+	}
+}
+
+// Init implements Command.Init.
+func (c *RevokeTokenCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no token ID supplied")
+	}
+	c.ID = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run implements Command.Run.
+func (c *RevokeTokenCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getTokenAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if err := client.RevokeToken(c.ID); err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	ctx.Infof("Token %q revoked", c.ID)
+	return nil
+}
@@ -0,0 +1,201 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package user_test
+
+import (
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/envcmd"
+	"github.com/juju/juju/cmd/juju/user"
+	"github.com/juju/juju/testing"
+)
+
+type TokensSuite struct {
+	BaseSuite
+	mock *mockTokenAPI
+}
+
+var _ = gc.Suite(&TokensSuite{})
+
+func (s *TokensSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	created := time.Date(2015, 3, 20, 10, 0, 0, 0, time.UTC)
+	lastUsed := time.Date(2015, 3, 20, 11, 30, 0, 0, time.UTC)
+	s.mock = &mockTokenAPI{
+		tokens: []params.UserToken{{
+			ID:       "0123abcd",
+			Access:   "read",
+			Created:  created,
+			Expires:  created.Add(24 * time.Hour),
+			LastUsed: &lastUsed,
+		}, {
+			ID:      "4567cdef",
+			Access:  "admin",
+			Created: created,
+			Expires: time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC),
+		}},
+	}
+}
+
+func (s *TokensSuite) TestCreateTokenInit(c *gc.C) {
+	for i, test := range []struct {
+		args      []string
+		errMatch  string
+		expiresIn time.Duration
+		access    string
+	}{{
+		expiresIn: 24 * time.Hour,
+	}, {
+		args:      []string{"--expires", "1h", "--access", "read"},
+		expiresIn: time.Hour,
+		access:    "read",
+	}, {
+		args:     []string{"--expires", "0s"},
+		errMatch: "invalid expiry 0: must be positive",
+	}, {
+		args:     []string{"--access", "root"},
+		errMatch: `invalid access level "root": expected read, write or admin`,
+	}, {
+		args:     []string{"extra"},
+		errMatch: `unrecognized args: \["extra"\]`,
+	}} {
+		c.Logf("test %d, args %v", i, test.args)
+		command := &user.CreateTokenCommand{}
+		err := testing.InitCommand(command, test.args)
+		if test.errMatch != "" {
+			c.Check(err, gc.ErrorMatches, test.errMatch)
+			continue
+		}
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(command.ExpiresIn, gc.Equals, test.expiresIn)
+		c.Check(command.Access, gc.Equals, test.access)
+	}
+}
+
+func (s *TokensSuite) TestCreateToken(c *gc.C) {
+	command := envcmd.Wrap(user.NewCreateTokenCommand(s.mock))
+	context, err := testing.RunCommand(c, command, "--expires", "48h", "--access", "write")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mock.expiresIn, gc.Equals, 48*time.Hour)
+	c.Assert(s.mock.access, gc.Equals, "write")
+	c.Assert(testing.Stdout(context), gc.Equals, `
+id: 89abef01
+access: write
+created: 2015-03-20 10:00:00Z
+expires: 2015-03-22 10:00:00Z
+credential: token:89abef01:sekrit
+`[1:])
+}
+
+func (s *TokensSuite) TestCreateTokenBlocked(c *gc.C) {
+	s.mock.err = common.ErrOperationBlocked("The operation has been blocked.")
+	command := envcmd.Wrap(user.NewCreateTokenCommand(s.mock))
+	_, err := testing.RunCommand(c, command)
+	c.Assert(err, gc.ErrorMatches, cmd.ErrSilent.Error())
+	// msg is logged
+	stripped := strings.Replace(c.GetTestLog(), "\n", "", -1)
+	c.Check(stripped, gc.Matches, ".*To unblock changes.*")
+}
+
+func (s *TokensSuite) TestListTokensTabular(c *gc.C) {
+	command := envcmd.Wrap(user.NewListTokensCommand(s.mock))
+	context, err := testing.RunCommand(c, command)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(context), gc.Equals, ""+
+		"ID        ACCESS  CREATED               EXPIRES                         LAST USED\n"+
+		"0123abcd  read    2015-03-20 10:00:00Z  2015-03-21 10:00:00Z (expired)  2015-03-20 11:30:00Z\n"+
+		"4567cdef  admin   2015-03-20 10:00:00Z  2100-01-01 00:00:00Z            never used\n"+
+		"\n")
+}
+
+func (s *TokensSuite) TestListTokensYaml(c *gc.C) {
+	command := envcmd.Wrap(user.NewListTokensCommand(s.mock))
+	context, err := testing.RunCommand(c, command, "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(context), gc.Equals, `
+- id: 0123abcd
+  access: read
+  created: 2015-03-20 10:00:00Z
+  expires: 2015-03-21 10:00:00Z
+  last-used: 2015-03-20 11:30:00Z
+- id: 4567cdef
+  access: admin
+  created: 2015-03-20 10:00:00Z
+  expires: 2100-01-01 00:00:00Z
+`[1:])
+}
+
+func (s *TokensSuite) TestRevokeTokenInit(c *gc.C) {
+	command := &user.RevokeTokenCommand{}
+	err := testing.InitCommand(command, nil)
+	c.Assert(err, gc.ErrorMatches, "no token ID supplied")
+	err = testing.InitCommand(command, []string{"0123abcd", "extra"})
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["extra"\]`)
+}
+
+func (s *TokensSuite) TestRevokeToken(c *gc.C) {
+	command := envcmd.Wrap(user.NewRevokeTokenCommand(s.mock))
+	context, err := testing.RunCommand(c, command, "0123abcd")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mock.revoked, jc.DeepEquals, []string{"0123abcd"})
+	c.Assert(testing.Stderr(context), gc.Equals, "Token \"0123abcd\" revoked\n")
+}
+
+func (s *TokensSuite) TestRevokeTokenError(c *gc.C) {
+	s.mock.err = common.ErrPerm
+	command := envcmd.Wrap(user.NewRevokeTokenCommand(s.mock))
+	_, err := testing.RunCommand(c, command, "0123abcd")
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+type mockTokenAPI struct {
+	tokens    []params.UserToken
+	expiresIn time.Duration
+	access    string
+	revoked   []string
+	err       error
+}
+
+var _ user.TokenAPI = (*mockTokenAPI)(nil)
+
+func (m *mockTokenAPI) CreateToken(expiresIn time.Duration, access string) (params.CreateTokenResult, error) {
+	if m.err != nil {
+		return params.CreateTokenResult{}, m.err
+	}
+	m.expiresIn = expiresIn
+	m.access = access
+	created := time.Date(2015, 3, 20, 10, 0, 0, 0, time.UTC)
+	return params.CreateTokenResult{
+		UserToken: params.UserToken{
+			ID:      "89abef01",
+			Access:  access,
+			Created: created,
+			Expires: created.Add(expiresIn),
+		},
+		Credential: "token:89abef01:sekrit",
+	}, nil
+}
+
+func (m *mockTokenAPI) ListTokens() ([]params.UserToken, error) {
+	return m.tokens, m.err
+}
+
+func (m *mockTokenAPI) RevokeToken(id string) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.revoked = append(m.revoked, id)
+	return nil
+}
+
+func (m *mockTokenAPI) Close() error {
+	return nil
+}
@@ -36,10 +36,13 @@ func NewSuperCommand() cmd.Command {
 	})
 	usercmd.Register(envcmd.Wrap(&AddCommand{}))
 	usercmd.Register(envcmd.Wrap(&ChangePasswordCommand{}))
+	usercmd.Register(envcmd.Wrap(&CreateTokenCommand{}))
 	usercmd.Register(envcmd.Wrap(&InfoCommand{}))
 	usercmd.Register(envcmd.Wrap(&DisableCommand{}))
 	usercmd.Register(envcmd.Wrap(&EnableCommand{}))
 	usercmd.Register(envcmd.Wrap(&ListCommand{}))
+	usercmd.Register(envcmd.Wrap(&ListTokensCommand{}))
+	usercmd.Register(envcmd.Wrap(&RevokeTokenCommand{}))
 	return usercmd
 }
 
@@ -24,11 +24,14 @@ var _ = gc.Suite(&UserCommandSuite{})
 var expectedUserCommmandNames = []string{
 	"add",
 	"change-password",
+	"create-token",
 	"disable",
 	"enable",
 	"help",
 	"info",
 	"list",
+	"list-tokens",
+	"revoke-token",
 }
 
 func (s *UserCommandSuite) TestHelp(c *gc.C) {
@@ -31,6 +31,7 @@ type RunCommand struct {
 	forceRemoteUnit bool
 	relationId      string
 	remoteUnitName  string
+	outputId        string
 }
 
 const runCommandDoc = `
@@ -63,6 +64,7 @@ func (c *RunCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.StringVar(&c.relationId, "relation", "", "")
 	f.StringVar(&c.remoteUnitName, "remote-unit", "", "run the commands for a specific remote unit in a relation context on a unit")
 	f.BoolVar(&c.forceRemoteUnit, "force-remote-unit", false, "run the commands for a specific relation context, bypassing the remote unit check")
+	f.StringVar(&c.outputId, "output-id", "", "forward the output of the commands to the controller under this id as it is produced")
 }
 
 func (c *RunCommand) Init(args []string) error {
@@ -89,6 +91,9 @@ func (c *RunCommand) Init(args []string) error {
 			}
 		}
 	}
+	if c.noContext && c.outputId != "" {
+		return fmt.Errorf("--output-id cannot be used with --no-context")
+	}
 	if len(args) < 1 {
 		return fmt.Errorf("missing commands")
 	}
@@ -149,6 +154,7 @@ func (c *RunCommand) executeInUnitContext() (*exec.ExecResponse, error) {
 		RelationId:      relationId,
 		RemoteUnitName:  c.remoteUnitName,
 		ForceRemoteUnit: c.forceRemoteUnit,
+		OutputId:        c.outputId,
 	}
 	err = client.Call(uniter.JujuRunEndpoint, args, &result)
 	return &result, errors.Trace(err)
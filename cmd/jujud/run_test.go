@@ -48,6 +48,7 @@ func (*RunTestSuite) TestArgParsing(c *gc.C) {
 		relationId      string
 		remoteUnit      string
 		forceRemoteUnit bool
+		outputId        string
 	}{{
 		title:    "no args",
 		errMatch: "missing unit-name",
@@ -107,6 +108,16 @@ func (*RunTestSuite) TestArgParsing(c *gc.C) {
 		unit:            names.NewUnitTag("name/2"),
 		relationId:      "mongodb:1",
 		forceRemoteUnit: true,
+	}, {
+		title:    "output-id",
+		args:     []string{"--output-id", "some-id", "unit-name-2", "command"},
+		commands: "command",
+		unit:     names.NewUnitTag("name/2"),
+		outputId: "some-id",
+	}, {
+		title:    "output-id without context",
+		args:     []string{"--output-id", "some-id", "--no-context", "command"},
+		errMatch: "--output-id cannot be used with --no-context",
 	},
 	} {
 		c.Logf("%d: %s", i, test.title)
@@ -120,6 +131,7 @@ func (*RunTestSuite) TestArgParsing(c *gc.C) {
 			c.Assert(runCommand.relationId, gc.Equals, test.relationId)
 			c.Assert(runCommand.remoteUnitName, gc.Equals, test.remoteUnit)
 			c.Assert(runCommand.forceRemoteUnit, gc.Equals, test.forceRemoteUnit)
+			c.Assert(runCommand.outputId, gc.Equals, test.outputId)
 		} else {
 			c.Assert(err, gc.ErrorMatches, test.errMatch)
 		}
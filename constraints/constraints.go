@@ -29,6 +29,8 @@ const (
 	Tags         = "tags"
 	InstanceType = "instance-type"
 	Networks     = "networks"
+	Spot         = "spot"
+	SpotPrice    = "spot-price"
 )
 
 // Value describes a user's requirements of the hardware on which units
@@ -78,6 +80,16 @@ type Value struct {
 	// negative values are accepted, and the difference is the latter
 	// have a "^" prefix to the name.
 	Networks *[]string `json:"networks,omitempty" yaml:"networks,omitempty"`
+
+	// Spot, if not nil, indicates whether the machine should be started
+	// on the provider's discounted, reclaimable capacity (EC2 spot or
+	// GCE preemptible instances).
+	Spot *bool `json:"spot,omitempty" yaml:"spot,omitempty"`
+
+	// SpotPrice, if not nil or empty, holds the maximum hourly price, in
+	// US dollars, to bid for a spot instance. It is only used when Spot
+	// is true, by providers that support bidding.
+	SpotPrice *string `json:"spot-price,omitempty" yaml:"spot-price,omitempty"`
 }
 
 // fieldNames records a mapping from the constraint tag to struct field name.
@@ -151,6 +163,12 @@ func (v *Value) HaveNetworks() bool {
 	return v.Networks != nil && len(*v.Networks) > 0
 }
 
+// HasSpot returns true if the constraints.Value asks for a spot
+// instance.
+func (v *Value) HasSpot() bool {
+	return v.Spot != nil && *v.Spot
+}
+
 // String expresses a constraints.Value in the language in which it was specified.
 func (v Value) String() string {
 	var strs []string
@@ -191,6 +209,12 @@ func (v Value) String() string {
 		s := strings.Join(*v.Networks, ",")
 		strs = append(strs, "networks="+s)
 	}
+	if v.Spot != nil {
+		strs = append(strs, "spot="+strconv.FormatBool(*v.Spot))
+	}
+	if v.SpotPrice != nil {
+		strs = append(strs, "spot-price="+*v.SpotPrice)
+	}
 	return strings.Join(strs, " ")
 }
 
@@ -330,6 +354,10 @@ func (v *Value) setRaw(raw string) error {
 		err = v.setInstanceType(str)
 	case Networks:
 		err = v.setNetworks(str)
+	case Spot:
+		err = v.setSpot(str)
+	case SpotPrice:
+		err = v.setSpotPrice(str)
 	default:
 		return fmt.Errorf("unknown constraint %q", name)
 	}
@@ -376,6 +404,10 @@ func (v *Value) SetYAML(tag string, value interface{}) bool {
 			if err == nil {
 				err = v.validateNetworks(networks)
 			}
+		case Spot:
+			v.Spot, err = parseBool(vstr)
+		case SpotPrice:
+			v.SpotPrice, err = parsePrice(vstr)
 		default:
 			return false
 		}
@@ -494,6 +526,44 @@ func (v *Value) validateNetworks(networks *[]string) error {
 	return nil
 }
 
+func (v *Value) setSpot(str string) (err error) {
+	if v.Spot != nil {
+		return fmt.Errorf("already set")
+	}
+	v.Spot, err = parseBool(str)
+	return
+}
+
+func (v *Value) setSpotPrice(str string) (err error) {
+	if v.SpotPrice != nil {
+		return fmt.Errorf("already set")
+	}
+	v.SpotPrice, err = parsePrice(str)
+	return
+}
+
+func parseBool(str string) (*bool, error) {
+	var value bool
+	if str != "" {
+		val, err := strconv.ParseBool(str)
+		if err != nil {
+			return nil, fmt.Errorf("must be true or false")
+		}
+		value = val
+	}
+	return &value, nil
+}
+
+func parsePrice(str string) (*string, error) {
+	if str != "" {
+		val, err := strconv.ParseFloat(str, 64)
+		if err != nil || val <= 0 {
+			return nil, fmt.Errorf("must be a positive decimal number")
+		}
+	}
+	return &str, nil
+}
+
 func parseUint64(str string) (*uint64, error) {
 	var value uint64
 	if str != "" {
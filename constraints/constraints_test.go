@@ -289,6 +289,47 @@ var parseConstraintsTests = []struct {
 		args:    []string{"instance-type="},
 	},
 
+	// spot
+	{
+		summary: "set spot",
+		args:    []string{"spot=true"},
+	}, {
+		summary: "set spot false",
+		args:    []string{"spot=false"},
+	}, {
+		summary: "set spot empty",
+		args:    []string{"spot="},
+	}, {
+		summary: "set nonsense spot",
+		args:    []string{"spot=cheese"},
+		err:     `bad "spot" constraint: must be true or false`,
+	}, {
+		summary: "double set spot",
+		args:    []string{"spot=true", "spot=false"},
+		err:     `bad "spot" constraint: already set`,
+	},
+
+	// spot-price
+	{
+		summary: "set spot-price",
+		args:    []string{"spot=true spot-price=0.05"},
+	}, {
+		summary: "set spot-price empty",
+		args:    []string{"spot-price="},
+	}, {
+		summary: "set negative spot-price",
+		args:    []string{"spot-price=-1"},
+		err:     `bad "spot-price" constraint: must be a positive decimal number`,
+	}, {
+		summary: "set nonsense spot-price",
+		args:    []string{"spot-price=cheese"},
+		err:     `bad "spot-price" constraint: must be a positive decimal number`,
+	}, {
+		summary: "double set spot-price",
+		args:    []string{"spot-price=0.1 spot-price=0.2"},
+		err:     `bad "spot-price" constraint: already set`,
+	},
+
 	// Everything at once.
 	{
 		summary: "kitchen sink together",
@@ -414,6 +455,10 @@ func (s *ConstraintsSuite) TestIsEmpty(c *gc.C) {
 	c.Check(&con, gc.Not(jc.Satisfies), constraints.IsEmpty)
 	con = constraints.MustParse("instance-type=")
 	c.Check(&con, gc.Not(jc.Satisfies), constraints.IsEmpty)
+	con = constraints.MustParse("spot=")
+	c.Check(&con, gc.Not(jc.Satisfies), constraints.IsEmpty)
+	con = constraints.MustParse("spot-price=")
+	c.Check(&con, gc.Not(jc.Satisfies), constraints.IsEmpty)
 }
 
 func uint64p(i uint64) *uint64 {
@@ -424,6 +469,10 @@ func strp(s string) *string {
 	return &s
 }
 
+func boolp(b bool) *bool {
+	return &b
+}
+
 func ctypep(ctype string) *instance.ContainerType {
 	res := instance.ContainerType(ctype)
 	return &res
@@ -461,6 +510,10 @@ var constraintsRoundtripTests = []roundTrip{
 	{"Networks3", constraints.Value{Networks: &[]string{"net1", "^net2"}}},
 	{"InstanceType1", constraints.Value{InstanceType: strp("")}},
 	{"InstanceType2", constraints.Value{InstanceType: strp("foo")}},
+	{"Spot1", constraints.Value{Spot: boolp(false)}},
+	{"Spot2", constraints.Value{Spot: boolp(true)}},
+	{"SpotPrice1", constraints.Value{SpotPrice: strp("")}},
+	{"SpotPrice2", constraints.Value{Spot: boolp(true), SpotPrice: strp("0.05")}},
 	{"All", constraints.Value{
 		Arch:         strp("i386"),
 		Container:    ctypep("lxc"),
@@ -471,6 +524,8 @@ var constraintsRoundtripTests = []roundTrip{
 		Tags:         &[]string{"foo", "bar"},
 		Networks:     &[]string{"net1", "^net2"},
 		InstanceType: strp("foo"),
+		Spot:         boolp(true),
+		SpotPrice:    strp("0.05"),
 	}},
 }
 
@@ -541,6 +596,15 @@ func (s *ConstraintsSuite) TestHasContainer(c *gc.C) {
 	}
 }
 
+func (s *ConstraintsSuite) TestHasSpot(c *gc.C) {
+	cons := constraints.MustParse("arch=amd64")
+	c.Check(cons.HasSpot(), jc.IsFalse)
+	cons = constraints.MustParse("spot=false")
+	c.Check(cons.HasSpot(), jc.IsFalse)
+	cons = constraints.MustParse("spot=true spot-price=0.05")
+	c.Check(cons.HasSpot(), jc.IsTrue)
+}
+
 func (s *ConstraintsSuite) TestHasInstanceType(c *gc.C) {
 	cons := constraints.MustParse("arch=amd64")
 	c.Check(cons.HasInstanceType(), jc.IsFalse)
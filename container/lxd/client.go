@@ -0,0 +1,251 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lxd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// Status codes reported by LXD for containers and operations.
+const (
+	StatusCodeSuccess = 200
+	StatusCodeStopped = 102
+	StatusCodeRunning = 103
+)
+
+// Client talks to the LXD daemon through its REST API, served over a
+// unix socket.
+type Client struct {
+	http *http.Client
+}
+
+// NewClient returns a client that talks to the LXD daemon listening on
+// the unix socket at socketPath. No connection is made until the
+// first request.
+func NewClient(socketPath string) *Client {
+	transport := &http.Transport{
+		Dial: func(string, string) (net.Conn, error) {
+			return net.Dial("unix", socketPath)
+		},
+	}
+	return &Client{http: &http.Client{Transport: transport}}
+}
+
+// ContainerSource describes what a new container is created from:
+// either an image, pulled from a simplestreams server and cached by
+// the daemon, or a copy of an existing container.
+type ContainerSource struct {
+	// Type is "image" or "copy".
+	Type string `json:"type"`
+
+	// Mode, Server, Protocol and Alias identify the image of an
+	// "image" source.
+	Mode     string `json:"mode,omitempty"`
+	Server   string `json:"server,omitempty"`
+	Protocol string `json:"protocol,omitempty"`
+	Alias    string `json:"alias,omitempty"`
+
+	// Source is the name of the container copied by a "copy" source.
+	// On storage backends that support it, the copy is a
+	// copy-on-write clone.
+	Source string `json:"source,omitempty"`
+}
+
+// ContainerRequest holds the parameters of a new container.
+type ContainerRequest struct {
+	Name     string            `json:"name"`
+	Profiles []string          `json:"profiles,omitempty"`
+	Config   map[string]string `json:"config,omitempty"`
+	Source   ContainerSource   `json:"source"`
+}
+
+// ContainerState describes the run-time state of a container.
+type ContainerState struct {
+	Status     string                  `json:"status"`
+	StatusCode int                     `json:"status_code"`
+	Network    map[string]NetworkState `json:"network"`
+}
+
+// NetworkState describes a network interface of a running container.
+type NetworkState struct {
+	Addresses []NetworkAddress `json:"addresses"`
+}
+
+// NetworkAddress is an address of a container's network interface.
+type NetworkAddress struct {
+	Family  string `json:"family"`
+	Address string `json:"address"`
+	Scope   string `json:"scope"`
+}
+
+// Profile holds configuration and devices that are applied to the
+// containers using it.
+type Profile struct {
+	Name    string                       `json:"name"`
+	Config  map[string]string            `json:"config,omitempty"`
+	Devices map[string]map[string]string `json:"devices,omitempty"`
+}
+
+// StateRequest changes the run-time state of a container.
+type StateRequest struct {
+	Action  string `json:"action"`
+	Timeout int    `json:"timeout"`
+	Force   bool   `json:"force"`
+}
+
+// Response is the envelope of every response sent by the LXD daemon.
+type Response struct {
+	Type       string          `json:"type"`
+	Status     string          `json:"status,omitempty"`
+	StatusCode int             `json:"status_code,omitempty"`
+	Operation  string          `json:"operation,omitempty"`
+	ErrorCode  int             `json:"error_code,omitempty"`
+	Error      string          `json:"error,omitempty"`
+	Metadata   json.RawMessage `json:"metadata,omitempty"`
+}
+
+// Operation describes a background operation run by the daemon.
+type Operation struct {
+	ID         string `json:"id"`
+	Status     string `json:"status"`
+	StatusCode int    `json:"status_code"`
+	Err        string `json:"err"`
+}
+
+// Ping checks that the daemon is responding.
+func (c *Client) Ping() error {
+	_, err := c.do("GET", "/1.0", nil, nil)
+	return errors.Trace(err)
+}
+
+// ContainerNames returns the names of all the daemon's containers.
+func (c *Client) ContainerNames() ([]string, error) {
+	var urls []string
+	if _, err := c.do("GET", "/1.0/containers", nil, &urls); err != nil {
+		return nil, errors.Trace(err)
+	}
+	names := make([]string, len(urls))
+	for i, u := range urls {
+		names[i] = u[strings.LastIndex(u, "/")+1:]
+	}
+	return names, nil
+}
+
+// ContainerState returns the run-time state of the named container.
+func (c *Client) ContainerState(name string) (*ContainerState, error) {
+	var state ContainerState
+	if _, err := c.do("GET", containerPath(name)+"/state", nil, &state); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &state, nil
+}
+
+// CreateContainer creates a container, and waits for it to be created.
+// The new container is not started.
+func (c *Client) CreateContainer(req ContainerRequest) error {
+	return errors.Trace(c.doAndWait("POST", "/1.0/containers", req))
+}
+
+// StartContainer starts the named container.
+func (c *Client) StartContainer(name string) error {
+	req := StateRequest{Action: "start", Timeout: -1}
+	return errors.Trace(c.doAndWait("PUT", containerPath(name)+"/state", req))
+}
+
+// StopContainer stops the named container, killing it if necessary.
+func (c *Client) StopContainer(name string) error {
+	req := StateRequest{Action: "stop", Timeout: -1, Force: true}
+	return errors.Trace(c.doAndWait("PUT", containerPath(name)+"/state", req))
+}
+
+// DeleteContainer deletes the named container, which must be stopped.
+func (c *Client) DeleteContainer(name string) error {
+	return errors.Trace(c.doAndWait("DELETE", containerPath(name), nil))
+}
+
+// CreateProfile creates a profile.
+func (c *Client) CreateProfile(profile Profile) error {
+	_, err := c.do("POST", "/1.0/profiles", profile, nil)
+	return errors.Trace(err)
+}
+
+// DeleteProfile deletes the named profile, which must not be in use.
+func (c *Client) DeleteProfile(name string) error {
+	_, err := c.do("DELETE", "/1.0/profiles/"+url.QueryEscape(name), nil, nil)
+	return errors.Trace(err)
+}
+
+func containerPath(name string) string {
+	return "/1.0/containers/" + url.QueryEscape(name)
+}
+
+// doAndWait sends a request and, if the daemon runs it as a background
+// operation, waits for the operation to finish.
+func (c *Client) doAndWait(method, path string, body interface{}) error {
+	resp, err := c.do(method, path, body, nil)
+	if err != nil {
+		return err
+	}
+	if resp.Type != "async" {
+		return nil
+	}
+	var op Operation
+	if _, err := c.do("GET", resp.Operation+"/wait", nil, &op); err != nil {
+		return errors.Annotate(err, "cannot wait for operation")
+	}
+	if op.StatusCode != StatusCodeSuccess {
+		return errors.New(op.Err)
+	}
+	return nil
+}
+
+// do sends a request to the daemon, and unmarshals the metadata of the
+// response into result, if it is not nil.
+func (c *Client) do(method, path string, body, result interface{}) (*Response, error) {
+	var reqBody bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&reqBody).Encode(body); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	// The host is ignored, as the transport always dials the socket.
+	req, err := http.NewRequest(method, "http://lxd"+path, &reqBody)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	httpResp, err := c.http.Do(req)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot connect to LXD")
+	}
+	defer httpResp.Body.Close()
+	var resp Response
+	if err := json.NewDecoder(httpResp.Body).Decode(&resp); err != nil {
+		return nil, errors.Annotatef(err, "cannot decode response to %s %s", method, path)
+	}
+	switch resp.Type {
+	case "error":
+		if resp.ErrorCode == http.StatusNotFound {
+			return nil, errors.NewNotFound(nil, resp.Error)
+		}
+		return nil, fmt.Errorf("%s %s: %s", method, path, resp.Error)
+	case "sync", "async":
+	default:
+		return nil, errors.Errorf("unexpected response type %q", resp.Type)
+	}
+	if result != nil {
+		if err := json.Unmarshal(resp.Metadata, result); err != nil {
+			return nil, errors.Annotatef(err, "cannot decode response to %s %s", method, path)
+		}
+	}
+	return &resp, nil
+}
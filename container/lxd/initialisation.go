@@ -0,0 +1,42 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lxd
+
+import (
+	"github.com/juju/utils/packaging/manager"
+
+	"github.com/juju/juju/container"
+)
+
+// requiredPackages returns the arguments given to the package manager
+// to install LXD on the series. Trusty gets LXD from its backports
+// pocket.
+func requiredPackages(series string) []string {
+	if series == "trusty" {
+		return []string{"--target-release", "trusty-backports", "lxd"}
+	}
+	return []string{"lxd"}
+}
+
+type containerInitialiser struct {
+	series string
+}
+
+// containerInitialiser implements container.Initialiser.
+var _ container.Initialiser = (*containerInitialiser)(nil)
+
+// NewContainerInitialiser returns an instance used to perform the steps
+// required to allow a host machine to run a LXD container.
+func NewContainerInitialiser(series string) container.Initialiser {
+	return &containerInitialiser{series}
+}
+
+// Initialise is specified on the container.Initialiser interface.
+func (ci *containerInitialiser) Initialise() error {
+	pacman, err := manager.NewPackageManager(ci.series)
+	if err != nil {
+		return err
+	}
+	return pacman.Install(requiredPackages(ci.series)...)
+}
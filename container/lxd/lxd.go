@@ -0,0 +1,320 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lxd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/names"
+	"github.com/juju/utils"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/cloudconfig/containerinit"
+	"github.com/juju/juju/cloudconfig/instancecfg"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/container"
+	"github.com/juju/juju/container/lxc"
+	"github.com/juju/juju/environs/imagemetadata"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/version"
+)
+
+var (
+	logger = loggo.GetLogger("juju.container.lxd")
+
+	// SocketPath is the path of the unix socket on which the LXD
+	// daemon serves its REST API.
+	SocketPath = "/var/lib/lxd/unix.socket"
+
+	DefaultLxdBridge = "lxcbr0"
+
+	runtimeGOOS = runtime.GOOS
+)
+
+const (
+	// userDataKey is the container config key holding the cloud-init
+	// user-data, which LXD images pass to cloud-init on first boot.
+	userDataKey = "user.user-data"
+
+	// loopMountConfig is the raw LXC configuration that allows a
+	// container to mount loop devices.
+	loopMountConfig = `lxc.aa_profile = lxc-container-default-with-mounting
+lxc.cgroup.devices.allow = b 7:* rwm
+lxc.cgroup.devices.allow = c 10:237 rwm`
+)
+
+// IsLXDSupported reports whether this machine can run LXD containers.
+// LXD is not packaged for precise, and nested containers are not
+// supported. It is a variable to allow us to override behaviour in the
+// tests.
+var IsLXDSupported = func() (bool, error) {
+	if runtimeGOOS != "linux" || !utils.IsUbuntu() {
+		return false, nil
+	}
+	if version.Current.Series == "precise" {
+		return false, nil
+	}
+	return lxc.IsLXCSupported()
+}
+
+// NewContainerManager returns a manager object that can start and stop
+// LXD containers. The containers that are created are namespaced by the
+// name parameter.
+func NewContainerManager(conf container.ManagerConfig) (container.Manager, error) {
+	name := conf.PopValue(container.ConfigName)
+	if name == "" {
+		return nil, fmt.Errorf("name is required")
+	}
+	logDir := conf.PopValue(container.ConfigLogDir)
+	if logDir == "" {
+		logDir = agent.DefaultLogDir
+	}
+	conf.WarnAboutUnused()
+	return &containerManager{
+		name:   name,
+		logdir: logDir,
+		client: NewClient(SocketPath),
+	}, nil
+}
+
+// containerManager creates LXD containers by cloning a per-series
+// template container, which is itself created from a cloud image. Each
+// container gets a profile of its own that holds its network and
+// storage configuration.
+type containerManager struct {
+	name   string
+	logdir string
+	client *Client
+}
+
+var _ container.Manager = (*containerManager)(nil)
+
+func (manager *containerManager) CreateContainer(
+	instanceConfig *instancecfg.InstanceConfig,
+	series string,
+	networkConfig *container.NetworkConfig,
+	storageConfig *container.StorageConfig,
+) (_ instance.Instance, _ *instance.HardwareCharacteristics, err error) {
+
+	name := names.NewMachineTag(instanceConfig.MachineId).String()
+	if manager.name != "" {
+		name = fmt.Sprintf("%s-%s", manager.name, name)
+	}
+	instanceConfig.MachineContainerHostname = name
+
+	// Create the cloud-init.
+	directory, err := container.NewDirectory(name)
+	if err != nil {
+		return nil, nil, errors.Annotate(err, "failed to create container directory")
+	}
+	logger.Tracef("write cloud-init")
+	userDataFilename, err := containerinit.WriteUserData(instanceConfig, networkConfig, directory)
+	if err != nil {
+		return nil, nil, errors.Annotate(err, "failed to write user data")
+	}
+	userData, err := ioutil.ReadFile(userDataFilename)
+	if err != nil {
+		return nil, nil, errors.Annotate(err, "failed to read user data")
+	}
+
+	templateName, err := manager.ensureTemplate(series, instanceConfig.ImageStream)
+	if err != nil {
+		return nil, nil, errors.Annotate(err, "failed to retrieve the template to clone")
+	}
+
+	if err := manager.client.CreateProfile(containerProfile(name, networkConfig, storageConfig)); err != nil {
+		return nil, nil, errors.Annotate(err, "failed to create container profile")
+	}
+	defer func() {
+		if err != nil {
+			if err := manager.client.DeleteProfile(name); err != nil {
+				logger.Warningf("cannot delete profile %q: %v", name, err)
+			}
+		}
+	}()
+
+	config := limitsConfig(instanceConfig.Constraints)
+	config[userDataKey] = string(userData)
+	logger.Tracef("clone %q from template %q", name, templateName)
+	if err := manager.client.CreateContainer(ContainerRequest{
+		Name:     name,
+		Profiles: []string{"default", name},
+		Config:   config,
+		Source:   ContainerSource{Type: "copy", Source: templateName},
+	}); err != nil {
+		return nil, nil, errors.Annotate(err, "lxd container cloning failed")
+	}
+	if err := manager.client.StartContainer(name); err != nil {
+		if err := manager.client.DeleteContainer(name); err != nil {
+			logger.Warningf("cannot delete container %q: %v", name, err)
+		}
+		return nil, nil, errors.Annotate(err, "lxd container failed to start")
+	}
+
+	arch := version.Current.Arch
+	hardware := &instance.HardwareCharacteristics{
+		Arch:     &arch,
+		Mem:      instanceConfig.Constraints.Mem,
+		CpuCores: instanceConfig.Constraints.CpuCores,
+	}
+	logger.Tracef("lxd container created")
+	return &lxdInstance{name, manager.client}, hardware, nil
+}
+
+// ensureTemplate returns the name of the template container for the
+// series, creating it from the series' cloud image if it does not yet
+// exist. The template is never started; it only serves as the source
+// of copy-on-write clones.
+func (manager *containerManager) ensureTemplate(series, imageStream string) (string, error) {
+	name := fmt.Sprintf("juju-%s-lxd-template", series)
+	_, err := manager.client.ContainerState(name)
+	if err == nil {
+		return name, nil
+	} else if !errors.IsNotFound(err) {
+		return "", errors.Trace(err)
+	}
+	server := imagemetadata.UbuntuCloudImagesURL + "/releases"
+	if imageStream != "" && imageStream != imagemetadata.ReleasedStream {
+		server = imagemetadata.UbuntuCloudImagesURL + "/" + imageStream
+	}
+	logger.Infof("creating template container %q from %s image at %s", name, series, server)
+	if err := manager.client.CreateContainer(ContainerRequest{
+		Name:     name,
+		Profiles: []string{"default"},
+		Source: ContainerSource{
+			Type:     "image",
+			Mode:     "pull",
+			Server:   server,
+			Protocol: "simplestreams",
+			Alias:    series,
+		},
+	}); err != nil {
+		return "", errors.Trace(err)
+	}
+	return name, nil
+}
+
+// containerProfile returns the profile holding the network and storage
+// configuration of the named container.
+func containerProfile(name string, networkConfig *container.NetworkConfig, storageConfig *container.StorageConfig) Profile {
+	nicType, parent, mtu := "bridged", DefaultLxdBridge, 0
+	if networkConfig != nil {
+		if networkConfig.NetworkType == container.PhysicalNetwork {
+			nicType = "physical"
+		}
+		if networkConfig.Device != "" {
+			parent = networkConfig.Device
+		}
+		mtu = networkConfig.MTU
+	}
+	newNIC := func(name, macAddress string) map[string]string {
+		nic := map[string]string{
+			"type":    "nic",
+			"nictype": nicType,
+			"parent":  parent,
+			"name":    name,
+		}
+		if macAddress != "" {
+			nic["hwaddr"] = macAddress
+		}
+		if mtu > 0 {
+			nic["mtu"] = strconv.Itoa(mtu)
+		}
+		return nic
+	}
+
+	devices := make(map[string]map[string]string)
+	if networkConfig != nil && len(networkConfig.Interfaces) > 0 {
+		for _, iface := range networkConfig.Interfaces {
+			devices[iface.InterfaceName] = newNIC(iface.InterfaceName, iface.MACAddress)
+		}
+	} else {
+		devices["eth0"] = newNIC("eth0", "")
+	}
+	config := make(map[string]string)
+	if storageConfig != nil && storageConfig.AllowMount {
+		config["raw.lxc"] = loopMountConfig
+	}
+	return Profile{Name: name, Config: config, Devices: devices}
+}
+
+// limitsConfig returns the container config that limits the container
+// to the memory and cpu-cores constraints, if given. Other constraints
+// cause a warning to be emitted.
+func limitsConfig(cons constraints.Value) map[string]string {
+	config := make(map[string]string)
+	if cons.Mem != nil {
+		config["limits.memory"] = fmt.Sprintf("%dMB", *cons.Mem)
+	}
+	if cons.CpuCores != nil {
+		config["limits.cpus"] = strconv.FormatUint(*cons.CpuCores, 10)
+	}
+	if cons.RootDisk != nil {
+		logger.Infof("root-disk constraint of %v being ignored as not supported", *cons.RootDisk)
+	}
+	if cons.CpuPower != nil {
+		logger.Infof("cpu-power constraint of %v being ignored as not supported", *cons.CpuPower)
+	}
+	if cons.Tags != nil {
+		logger.Infof("tags constraint of %q being ignored as not supported", strings.Join(*cons.Tags, ","))
+	}
+	return config
+}
+
+func (manager *containerManager) IsInitialized() bool {
+	return manager.client.Ping() == nil
+}
+
+func (manager *containerManager) DestroyContainer(id instance.Id) error {
+	name := string(id)
+	state, err := manager.client.ContainerState(name)
+	if err != nil {
+		return errors.Annotatef(err, "cannot get state of lxd container %q", name)
+	}
+	if state.StatusCode != StatusCodeStopped {
+		if err := manager.client.StopContainer(name); err != nil {
+			logger.Errorf("failed to stop lxd container: %v", err)
+			return err
+		}
+	}
+	if err := manager.client.DeleteContainer(name); err != nil {
+		return errors.Annotatef(err, "cannot delete lxd container %q", name)
+	}
+	if err := manager.client.DeleteProfile(name); err != nil && !errors.IsNotFound(err) {
+		return errors.Annotatef(err, "cannot delete profile of lxd container %q", name)
+	}
+	return container.RemoveDirectory(name)
+}
+
+func (manager *containerManager) ListContainers() (result []instance.Instance, err error) {
+	containers, err := manager.client.ContainerNames()
+	if err != nil {
+		logger.Errorf("failed getting all instances: %v", err)
+		return nil, err
+	}
+	managerPrefix := fmt.Sprintf("%s-", manager.name)
+	for _, name := range containers {
+		// Filter out those not starting with our name.
+		if !strings.HasPrefix(name, managerPrefix) {
+			continue
+		}
+		state, err := manager.client.ContainerState(name)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		// Templates are never started, so are not listed.
+		if state.StatusCode == StatusCodeRunning {
+			result = append(result, &lxdInstance{name, manager.client})
+		}
+	}
+	return result, nil
+}
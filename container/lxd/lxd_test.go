@@ -0,0 +1,208 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lxd_test
+
+import (
+	"path/filepath"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/container"
+	"github.com/juju/juju/container/lxd"
+	lxdtesting "github.com/juju/juju/container/lxd/testing"
+	containertesting "github.com/juju/juju/container/testing"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/provider/dummy"
+)
+
+type LXDSuite struct {
+	lxdtesting.TestSuite
+	manager container.Manager
+}
+
+var _ = gc.Suite(&LXDSuite{})
+
+func (s *LXDSuite) SetUpTest(c *gc.C) {
+	s.TestSuite.SetUpTest(c)
+	var err error
+	s.manager, err = lxd.NewContainerManager(container.ManagerConfig{container.ConfigName: "test"})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (*LXDSuite) TestManagerNameNeeded(c *gc.C) {
+	manager, err := lxd.NewContainerManager(container.ManagerConfig{container.ConfigName: ""})
+	c.Assert(err, gc.ErrorMatches, "name is required")
+	c.Assert(manager, gc.IsNil)
+}
+
+func (s *LXDSuite) TestIsInitialized(c *gc.C) {
+	c.Assert(s.manager.IsInitialized(), jc.IsTrue)
+	s.Server.Close()
+	c.Assert(s.manager.IsInitialized(), jc.IsFalse)
+}
+
+func (s *LXDSuite) TestCreateContainer(c *gc.C) {
+	inst := containertesting.CreateContainer(c, s.manager, "1/lxd/0")
+	name := string(inst.Id())
+	c.Assert(name, gc.Equals, "test-machine-1-lxd-0")
+	c.Assert(inst.Status(), gc.Equals, "running")
+	cloudInit := containertesting.AssertCloudInit(c, filepath.Join(s.ContainerDir, name, "cloud-init"))
+
+	// The template is created from the series' cloud image, and left
+	// stopped.
+	c.Assert(s.Server.Images(), jc.DeepEquals, map[string]string{
+		"quantal": "http://cloud-images.ubuntu.com/releases",
+	})
+	template, ok := s.Server.Container("juju-quantal-lxd-template")
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(template.Image, gc.Equals, "quantal")
+	c.Assert(template.Running, jc.IsFalse)
+
+	// The container is cloned from the template, and configured
+	// through its own profile.
+	ctr, ok := s.Server.Container(name)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(ctr.Copy, gc.Equals, "juju-quantal-lxd-template")
+	c.Assert(ctr.Running, jc.IsTrue)
+	c.Assert(ctr.Profiles, jc.DeepEquals, []string{"default", name})
+	c.Assert(ctr.Config["user.user-data"], gc.Equals, string(cloudInit))
+	profile, ok := s.Server.Profile(name)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(profile.Config, gc.HasLen, 0)
+	c.Assert(profile.Devices, jc.DeepEquals, map[string]map[string]string{
+		"eth0": {
+			"type":    "nic",
+			"nictype": "bridged",
+			"parent":  "nic42",
+			"name":    "eth0",
+		},
+	})
+
+	addrs, err := inst.Addresses()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addrs, jc.DeepEquals, network.NewAddresses(ctr.Address))
+}
+
+func (s *LXDSuite) TestCreateContainerReusesTemplate(c *gc.C) {
+	containertesting.CreateContainer(c, s.manager, "1/lxd/0")
+	inst := containertesting.CreateContainer(c, s.manager, "1/lxd/1")
+	ctr, ok := s.Server.Container(string(inst.Id()))
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(ctr.Copy, gc.Equals, "juju-quantal-lxd-template")
+	c.Assert(s.Server.Images(), gc.HasLen, 1)
+}
+
+func (s *LXDSuite) TestCreateContainerUtilizesDailySimpleStream(c *gc.C) {
+	instanceConfig, err := containertesting.MockMachineConfig("1/lxd/0")
+	c.Assert(err, jc.ErrorIsNil)
+	instanceConfig.ImageStream = "daily"
+	containertesting.CreateContainerWithMachineConfig(c, s.manager, instanceConfig)
+	c.Assert(s.Server.Images(), jc.DeepEquals, map[string]string{
+		"quantal": "http://cloud-images.ubuntu.com/daily",
+	})
+}
+
+func (s *LXDSuite) TestCreateContainerWithConstraints(c *gc.C) {
+	instanceConfig, err := containertesting.MockMachineConfig("1/lxd/0")
+	c.Assert(err, jc.ErrorIsNil)
+	envConfig, err := config.New(config.NoDefaults, dummy.SampleConfig())
+	c.Assert(err, jc.ErrorIsNil)
+	instanceConfig.Config = envConfig
+	instanceConfig.Constraints = constraints.MustParse("mem=1G cpu-cores=2 root-disk=10G")
+
+	inst, hardware, err := s.manager.CreateContainer(instanceConfig, "quantal", nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(*hardware.Mem, gc.Equals, uint64(1024))
+	c.Assert(*hardware.CpuCores, gc.Equals, uint64(2))
+	c.Assert(hardware.RootDisk, gc.IsNil)
+
+	ctr, _ := s.Server.Container(string(inst.Id()))
+	c.Assert(ctr.Config["limits.memory"], gc.Equals, "1024MB")
+	c.Assert(ctr.Config["limits.cpus"], gc.Equals, "2")
+	c.Assert(c.GetTestLog(), jc.Contains, "root-disk constraint of 10240 being ignored as not supported")
+}
+
+func (s *LXDSuite) TestCreateContainerNetworkAndStorageConfig(c *gc.C) {
+	instanceConfig, err := containertesting.MockMachineConfig("1/lxd/0")
+	c.Assert(err, jc.ErrorIsNil)
+	envConfig, err := config.New(config.NoDefaults, dummy.SampleConfig())
+	c.Assert(err, jc.ErrorIsNil)
+	instanceConfig.Config = envConfig
+	networkConfig := container.PhysicalNetworkConfig("eth1", 9000, nil)
+	storageConfig := &container.StorageConfig{AllowMount: true}
+
+	inst, _, err := s.manager.CreateContainer(instanceConfig, "quantal", networkConfig, storageConfig)
+	c.Assert(err, jc.ErrorIsNil)
+	profile, ok := s.Server.Profile(string(inst.Id()))
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(profile.Devices, jc.DeepEquals, map[string]map[string]string{
+		"eth0": {
+			"type":    "nic",
+			"nictype": "physical",
+			"parent":  "eth1",
+			"name":    "eth0",
+			"mtu":     "9000",
+		},
+	})
+	c.Assert(profile.Config["raw.lxc"], jc.Contains, "lxc.aa_profile = lxc-container-default-with-mounting")
+}
+
+func (s *LXDSuite) TestCreateContainerFailureRemovesProfile(c *gc.C) {
+	// A container of the same name already exists, so cannot be
+	// created.
+	s.Server.AddContainer(lxdtesting.Container{Name: "test-machine-1-lxd-0"})
+	_, err := containertesting.CreateContainerTest(c, s.manager, "1/lxd/0")
+	c.Assert(err, gc.ErrorMatches, `lxd container cloning failed: .*container "test-machine-1-lxd-0" already exists`)
+	_, ok := s.Server.Profile("test-machine-1-lxd-0")
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *LXDSuite) TestListContainers(c *gc.C) {
+	inst0 := containertesting.CreateContainer(c, s.manager, "1/lxd/0")
+	inst1 := containertesting.CreateContainer(c, s.manager, "1/lxd/1")
+	s.Server.AddContainer(lxdtesting.Container{Name: "test-stopped"})
+	s.Server.AddContainer(lxdtesting.Container{Name: "other", Running: true})
+
+	containers, err := s.manager.ListContainers()
+	c.Assert(err, jc.ErrorIsNil)
+	var ids []instance.Id
+	for _, inst := range containers {
+		ids = append(ids, inst.Id())
+	}
+	c.Assert(ids, jc.SameContents, []instance.Id{inst0.Id(), inst1.Id()})
+}
+
+func (s *LXDSuite) TestDestroyContainer(c *gc.C) {
+	inst := containertesting.CreateContainer(c, s.manager, "1/lxd/0")
+
+	err := s.manager.DestroyContainer(inst.Id())
+	c.Assert(err, jc.ErrorIsNil)
+
+	name := string(inst.Id())
+	_, ok := s.Server.Container(name)
+	c.Assert(ok, jc.IsFalse)
+	_, ok = s.Server.Profile(name)
+	c.Assert(ok, jc.IsFalse)
+	// The template is kept for later clones.
+	_, ok = s.Server.Container("juju-quantal-lxd-template")
+	c.Assert(ok, jc.IsTrue)
+	// Check that the container dir is no longer in the container dir
+	c.Assert(filepath.Join(s.ContainerDir, name), jc.DoesNotExist)
+	// but instead, in the removed container dir
+	c.Assert(filepath.Join(s.RemovedDir, name), jc.IsDirectory)
+}
+
+func (s *LXDSuite) TestDestroyMissingContainer(c *gc.C) {
+	err := s.manager.DestroyContainer("test-machine-1-lxd-0")
+	c.Assert(err, gc.ErrorMatches, `cannot get state of lxd container "test-machine-1-lxd-0": container "test-machine-1-lxd-0" not found`)
+}
+
+func (*LXDSuite) TestRequiredPackages(c *gc.C) {
+	c.Assert(lxd.RequiredPackages("trusty"), jc.DeepEquals, []string{"--target-release", "trusty-backports", "lxd"})
+	c.Assert(lxd.RequiredPackages("wily"), jc.DeepEquals, []string{"lxd"})
+}
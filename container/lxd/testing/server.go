@@ -0,0 +1,354 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Functions defined in this file should *ONLY* be used for testing.  These
+// functions are exported for testing purposes only, and shouldn't be called
+// from code that isn't in a test file.
+
+package testing
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/container/lxd"
+)
+
+// Container records a container created through the fake LXD server.
+type Container struct {
+	Name     string
+	Profiles []string
+	Config   map[string]string
+
+	// Image is the alias of the image the container was created
+	// from, and Copy the name of the container it was copied from.
+	Image string
+	Copy  string
+
+	Running bool
+	Address string
+}
+
+// Server is an in-memory fake of the LXD daemon's REST API, served
+// over a unix socket. It understands enough of the API for the lxd
+// package's client.
+type Server struct {
+	listener net.Listener
+
+	mu         sync.Mutex
+	containers map[string]*Container
+	profiles   map[string]*lxd.Profile
+	images     map[string]string
+	operations map[string]lxd.Operation
+	nextOp     int
+	nextAddr   int
+}
+
+// NewServer starts a fake LXD server listening on a unix socket at
+// socketPath.
+func NewServer(socketPath string) (*Server, error) {
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	srv := &Server{
+		listener:   listener,
+		containers: make(map[string]*Container),
+		profiles: map[string]*lxd.Profile{
+			"default": {Name: "default"},
+		},
+		images:     make(map[string]string),
+		operations: make(map[string]lxd.Operation),
+	}
+	go http.Serve(listener, srv)
+	return srv, nil
+}
+
+// Close stops the server.
+func (srv *Server) Close() error {
+	return srv.listener.Close()
+}
+
+// Container returns a copy of the named container, and whether it
+// exists.
+func (srv *Server) Container(name string) (Container, bool) {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	ctr, ok := srv.containers[name]
+	if !ok {
+		return Container{}, false
+	}
+	return *ctr, true
+}
+
+// Profile returns a copy of the named profile, and whether it exists.
+func (srv *Server) Profile(name string) (lxd.Profile, bool) {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	profile, ok := srv.profiles[name]
+	if !ok {
+		return lxd.Profile{}, false
+	}
+	return *profile, true
+}
+
+// Images returns the aliases of the images pulled by the server, mapped
+// to the servers they were pulled from.
+func (srv *Server) Images() map[string]string {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	images := make(map[string]string)
+	for alias, server := range srv.images {
+		images[alias] = server
+	}
+	return images
+}
+
+// AddContainer adds a container to the server, as if it had been
+// created outside juju.
+func (srv *Server) AddContainer(ctr Container) {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	srv.containers[ctr.Name] = &ctr
+}
+
+type lxdError struct {
+	code    int
+	message string
+}
+
+func (srv *Server) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	var resp *lxd.Response
+	result, async, err := srv.serve(req)
+	if err != nil {
+		resp = &lxd.Response{
+			Type:      "error",
+			ErrorCode: err.code,
+			Error:     err.message,
+		}
+	} else if async {
+		srv.nextOp++
+		id := fmt.Sprint(srv.nextOp)
+		op := lxd.Operation{ID: id, Status: "Success", StatusCode: lxd.StatusCodeSuccess}
+		srv.operations[id] = op
+		resp = &lxd.Response{
+			Type:       "async",
+			Status:     "Operation created",
+			StatusCode: 100,
+			Operation:  "/1.0/operations/" + id,
+		}
+	} else {
+		metadata, _ := json.Marshal(result)
+		resp = &lxd.Response{
+			Type:       "sync",
+			Status:     "Success",
+			StatusCode: lxd.StatusCodeSuccess,
+			Metadata:   metadata,
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// serve handles a request, returning the metadata of a synchronous
+// response or whether the request was run as a background operation.
+func (srv *Server) serve(req *http.Request) (interface{}, bool, *lxdError) {
+	parts := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	if parts[0] != "1.0" {
+		return nil, false, notFound("not found")
+	}
+	parts = parts[1:]
+	route := req.Method
+	if len(parts) > 0 {
+		route += " " + parts[0]
+	}
+	switch {
+	case route == "GET" && len(parts) == 0:
+		return map[string]interface{}{"api_compat": 1, "auth": "trusted"}, false, nil
+	case route == "GET containers" && len(parts) == 1:
+		var urls []string
+		for name := range srv.containers {
+			urls = append(urls, "/1.0/containers/"+name)
+		}
+		return urls, false, nil
+	case route == "POST containers" && len(parts) == 1:
+		var args lxd.ContainerRequest
+		if err := decode(req, &args); err != nil {
+			return nil, false, err
+		}
+		return nil, true, srv.createContainer(args)
+	case route == "GET containers" && len(parts) == 3 && parts[2] == "state":
+		ctr, err := srv.container(parts[1])
+		if err != nil {
+			return nil, false, err
+		}
+		return containerState(ctr), false, nil
+	case route == "PUT containers" && len(parts) == 3 && parts[2] == "state":
+		var args lxd.StateRequest
+		if err := decode(req, &args); err != nil {
+			return nil, false, err
+		}
+		return nil, true, srv.changeState(parts[1], args.Action)
+	case route == "DELETE containers" && len(parts) == 2:
+		return nil, true, srv.deleteContainer(parts[1])
+	case route == "POST profiles" && len(parts) == 1:
+		var profile lxd.Profile
+		if err := decode(req, &profile); err != nil {
+			return nil, false, err
+		}
+		if _, ok := srv.profiles[profile.Name]; ok {
+			return nil, false, conflict("profile %q already exists", profile.Name)
+		}
+		srv.profiles[profile.Name] = &profile
+		return nil, false, nil
+	case route == "DELETE profiles" && len(parts) == 2:
+		return nil, false, srv.deleteProfile(parts[1])
+	case route == "GET operations" && len(parts) == 3 && parts[2] == "wait":
+		op, ok := srv.operations[parts[1]]
+		if !ok {
+			return nil, false, notFound("operation %q not found", parts[1])
+		}
+		return op, false, nil
+	}
+	return nil, false, notFound("not found")
+}
+
+func (srv *Server) container(name string) (*Container, *lxdError) {
+	ctr, ok := srv.containers[name]
+	if !ok {
+		return nil, notFound("container %q not found", name)
+	}
+	return ctr, nil
+}
+
+func (srv *Server) createContainer(args lxd.ContainerRequest) *lxdError {
+	if _, ok := srv.containers[args.Name]; ok {
+		return conflict("container %q already exists", args.Name)
+	}
+	for _, name := range args.Profiles {
+		if _, ok := srv.profiles[name]; !ok {
+			return badRequest("profile %q not found", name)
+		}
+	}
+	ctr := &Container{
+		Name:     args.Name,
+		Profiles: args.Profiles,
+		Config:   args.Config,
+	}
+	switch args.Source.Type {
+	case "image":
+		if args.Source.Alias == "" || args.Source.Server == "" {
+			return badRequest("image source requires server and alias")
+		}
+		srv.images[args.Source.Alias] = args.Source.Server
+		ctr.Image = args.Source.Alias
+	case "copy":
+		source, err := srv.container(args.Source.Source)
+		if err != nil {
+			return err
+		}
+		ctr.Image = source.Image
+		ctr.Copy = source.Name
+	default:
+		return badRequest("unknown source type %q", args.Source.Type)
+	}
+	srv.containers[ctr.Name] = ctr
+	return nil
+}
+
+func (srv *Server) changeState(name, action string) *lxdError {
+	ctr, err := srv.container(name)
+	if err != nil {
+		return err
+	}
+	switch action {
+	case "start":
+		if ctr.Running {
+			return badRequest("container %q is already running", name)
+		}
+		srv.nextAddr++
+		ctr.Running = true
+		ctr.Address = fmt.Sprintf("10.0.3.%d", srv.nextAddr)
+	case "stop":
+		if !ctr.Running {
+			return badRequest("container %q is already stopped", name)
+		}
+		ctr.Running = false
+		ctr.Address = ""
+	default:
+		return badRequest("unknown action %q", action)
+	}
+	return nil
+}
+
+func (srv *Server) deleteContainer(name string) *lxdError {
+	ctr, err := srv.container(name)
+	if err != nil {
+		return err
+	}
+	if ctr.Running {
+		return badRequest("container %q is running", name)
+	}
+	delete(srv.containers, name)
+	return nil
+}
+
+func (srv *Server) deleteProfile(name string) *lxdError {
+	if _, ok := srv.profiles[name]; !ok {
+		return notFound("profile %q not found", name)
+	}
+	for _, ctr := range srv.containers {
+		for _, profile := range ctr.Profiles {
+			if profile == name {
+				return badRequest("profile %q is in use by container %q", name, ctr.Name)
+			}
+		}
+	}
+	delete(srv.profiles, name)
+	return nil
+}
+
+func containerState(ctr *Container) lxd.ContainerState {
+	if !ctr.Running {
+		return lxd.ContainerState{Status: "Stopped", StatusCode: lxd.StatusCodeStopped}
+	}
+	return lxd.ContainerState{
+		Status:     "Running",
+		StatusCode: lxd.StatusCodeRunning,
+		Network: map[string]lxd.NetworkState{
+			"lo": {Addresses: []lxd.NetworkAddress{
+				{Family: "inet", Address: "127.0.0.1", Scope: "local"},
+			}},
+			"eth0": {Addresses: []lxd.NetworkAddress{
+				{Family: "inet", Address: ctr.Address, Scope: "global"},
+			}},
+		},
+	}
+}
+
+func decode(req *http.Request, v interface{}) *lxdError {
+	if err := json.NewDecoder(req.Body).Decode(v); err != nil {
+		return badRequest("cannot decode request: %v", err)
+	}
+	return nil
+}
+
+func notFound(format string, args ...interface{}) *lxdError {
+	return &lxdError{http.StatusNotFound, fmt.Sprintf(format, args...)}
+}
+
+func conflict(format string, args ...interface{}) *lxdError {
+	return &lxdError{http.StatusConflict, fmt.Sprintf(format, args...)}
+}
+
+func badRequest(format string, args ...interface{}) *lxdError {
+	return &lxdError{http.StatusBadRequest, fmt.Sprintf(format, args...)}
+}
@@ -0,0 +1,43 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Functions defined in this file should *ONLY* be used for testing.  These
+// functions are exported for testing purposes only, and shouldn't be called
+// from code that isn't in a test file.
+
+package testing
+
+import (
+	"path/filepath"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/container"
+	"github.com/juju/juju/container/lxd"
+	"github.com/juju/juju/testing"
+)
+
+// TestSuite points the lxd package at a fake LXD server, started
+// afresh for each test.
+type TestSuite struct {
+	testing.BaseSuite
+	Server       *Server
+	ContainerDir string
+	RemovedDir   string
+}
+
+func (s *TestSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.ContainerDir = c.MkDir()
+	s.PatchValue(&container.ContainerDir, s.ContainerDir)
+	s.RemovedDir = c.MkDir()
+	s.PatchValue(&container.RemovedContainerDir, s.RemovedDir)
+
+	socketPath := filepath.Join(c.MkDir(), "unix.socket")
+	server, err := NewServer(socketPath)
+	c.Assert(err, jc.ErrorIsNil)
+	s.Server = server
+	s.AddCleanup(func(*gc.C) { server.Close() })
+	s.PatchValue(&lxd.SocketPath, socketPath)
+}
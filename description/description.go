@@ -0,0 +1,164 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package description defines a portable, versioned description of the
+// model of a Juju environment: its machines, services, units, relations
+// and users. A description may be exported from one environment and
+// imported into another, possibly managed by a different state server.
+package description
+
+import (
+	"github.com/juju/errors"
+	goyaml "gopkg.in/yaml.v1"
+)
+
+// Version is the version of the description format written by
+// Serialize. Deserialize rejects descriptions of any other version.
+const Version = 1
+
+// Environment describes the model of a Juju environment.
+type Environment struct {
+	// Version is the version of the description format.
+	Version int `yaml:"version"`
+
+	// Owner is the name of the user that owns the environment.
+	Owner string `yaml:"owner"`
+
+	Constraints string            `yaml:"constraints,omitempty"`
+	Annotations map[string]string `yaml:"annotations,omitempty"`
+
+	Users     []User     `yaml:"users,omitempty"`
+	Machines  []Machine  `yaml:"machines,omitempty"`
+	Services  []Service  `yaml:"services,omitempty"`
+	Relations []Relation `yaml:"relations,omitempty"`
+}
+
+// User describes a user's access to the environment.
+type User struct {
+	Name        string `yaml:"name"`
+	DisplayName string `yaml:"display-name,omitempty"`
+	Access      string `yaml:"access"`
+}
+
+// Machine describes a machine in the environment. Machines
+// are listed with hosts before the containers they host.
+type Machine struct {
+	Id            string `yaml:"id"`
+	Series        string `yaml:"series"`
+	ContainerType string `yaml:"container-type,omitempty"`
+
+	// InstanceId, Nonce and Hardware are set if the machine
+	// has been provisioned.
+	InstanceId string `yaml:"instance-id,omitempty"`
+	Nonce      string `yaml:"nonce,omitempty"`
+	Hardware   string `yaml:"hardware,omitempty"`
+
+	Jobs        []string          `yaml:"jobs"`
+	Placement   string            `yaml:"placement,omitempty"`
+	Constraints string            `yaml:"constraints,omitempty"`
+	Annotations map[string]string `yaml:"annotations,omitempty"`
+}
+
+// Service describes a service in the environment.
+type Service struct {
+	Name        string                        `yaml:"name"`
+	Charm       string                        `yaml:"charm"`
+	Owner       string                        `yaml:"owner"`
+	Exposed     bool                          `yaml:"exposed,omitempty"`
+	ExposedTo   []string                      `yaml:"exposed-to,omitempty"`
+	Settings    map[string]interface{}        `yaml:"settings,omitempty"`
+	Constraints string                        `yaml:"constraints,omitempty"`
+	Storage     map[string]StorageConstraints `yaml:"storage,omitempty"`
+	Annotations map[string]string             `yaml:"annotations,omitempty"`
+	Units       []Unit                        `yaml:"units,omitempty"`
+}
+
+// StorageConstraints describes the storage requested for each
+// unit of a service.
+type StorageConstraints struct {
+	Pool  string `yaml:"pool,omitempty"`
+	Size  uint64 `yaml:"size"`
+	Count uint64 `yaml:"count"`
+}
+
+// Unit describes a unit of a service. Principal units record the
+// machine they are assigned to; subordinate units record their
+// principal unit instead.
+type Unit struct {
+	Name        string            `yaml:"name"`
+	Machine     string            `yaml:"machine,omitempty"`
+	Principal   string            `yaml:"principal,omitempty"`
+	Annotations map[string]string `yaml:"annotations,omitempty"`
+	Storage     []Storage         `yaml:"storage,omitempty"`
+}
+
+// Storage describes a storage instance owned by a unit, and the
+// volume or filesystem that was provisioned for it.
+type Storage struct {
+	// Name is the name of the storage in the charm's metadata.
+	Name string `yaml:"name"`
+
+	// Id is the ID of the storage instance. Storage instances
+	// may be given different IDs when imported.
+	Id string `yaml:"id"`
+
+	// Kind is either "block" or "filesystem".
+	Kind string `yaml:"kind"`
+
+	Volume     *Volume     `yaml:"volume,omitempty"`
+	Filesystem *Filesystem `yaml:"filesystem,omitempty"`
+}
+
+// Volume describes a provisioned volume and its attachment
+// to the unit's machine.
+type Volume struct {
+	Pool       string `yaml:"pool,omitempty"`
+	VolumeId   string `yaml:"volume-id"`
+	HardwareId string `yaml:"hardware-id,omitempty"`
+	Size       uint64 `yaml:"size"`
+	Persistent bool   `yaml:"persistent,omitempty"`
+	DeviceName string `yaml:"device-name,omitempty"`
+	ReadOnly   bool   `yaml:"read-only,omitempty"`
+}
+
+// Filesystem describes a provisioned filesystem and its attachment
+// to the unit's machine.
+type Filesystem struct {
+	Pool         string `yaml:"pool,omitempty"`
+	FilesystemId string `yaml:"filesystem-id,omitempty"`
+	Size         uint64 `yaml:"size"`
+	MountPoint   string `yaml:"mount-point,omitempty"`
+	ReadOnly     bool   `yaml:"read-only,omitempty"`
+}
+
+// Relation describes a relation between services. Each endpoint
+// is of the form <service>:<relation>.
+type Relation struct {
+	Id        int      `yaml:"id"`
+	Endpoints []string `yaml:"endpoints"`
+}
+
+// Serialize returns the YAML encoding of the environment description,
+// stamped with the current version.
+func Serialize(env *Environment) ([]byte, error) {
+	copied := *env
+	copied.Version = Version
+	data, err := goyaml.Marshal(&copied)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot serialize environment")
+	}
+	return data, nil
+}
+
+// Deserialize parses the YAML encoding of an environment description.
+// An error is returned if the description's version is not supported.
+func Deserialize(data []byte) (*Environment, error) {
+	var env Environment
+	if err := goyaml.Unmarshal(data, &env); err != nil {
+		return nil, errors.Annotate(err, "cannot parse environment description")
+	}
+	if env.Version != Version {
+		return nil, errors.NotSupportedf("environment description version %d", env.Version)
+	}
+	return &env, nil
+}
@@ -0,0 +1,92 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package description_test
+
+import (
+	stdtesting "testing"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/description"
+)
+
+func TestAll(t *stdtesting.T) {
+	gc.TestingT(t)
+}
+
+type DescriptionSuite struct{}
+
+var _ = gc.Suite(&DescriptionSuite{})
+
+func (*DescriptionSuite) TestRoundTrip(c *gc.C) {
+	env := &description.Environment{
+		Owner:       "admin@local",
+		Constraints: "mem=4G",
+		Annotations: map[string]string{"colour": "blue"},
+		Users: []description.User{
+			{Name: "admin@local", Access: "admin"},
+			{Name: "bob@local", DisplayName: "Bob", Access: "read"},
+		},
+		Machines: []description.Machine{{
+			Id:         "0",
+			Series:     "trusty",
+			InstanceId: "i-0",
+			Nonce:      "nonce",
+			Hardware:   "arch=amd64 mem=4096M",
+			Jobs:       []string{"JobHostUnits"},
+		}, {
+			Id:            "0/lxc/0",
+			Series:        "trusty",
+			ContainerType: "lxc",
+			Jobs:          []string{"JobHostUnits"},
+		}},
+		Services: []description.Service{{
+			Name:     "mysql",
+			Charm:    "cs:trusty/mysql-1",
+			Owner:    "admin@local",
+			Exposed:  true,
+			Settings: map[string]interface{}{"dataset-size": "80%"},
+			Storage: map[string]description.StorageConstraints{
+				"data": {Pool: "ebs", Size: 1024, Count: 1},
+			},
+			Units: []description.Unit{{
+				Name:    "mysql/0",
+				Machine: "0",
+				Storage: []description.Storage{{
+					Name: "data",
+					Id:   "data/0",
+					Kind: "block",
+					Volume: &description.Volume{
+						Pool:     "ebs",
+						VolumeId: "vol-0",
+						Size:     1024,
+					},
+				}},
+			}},
+		}},
+		Relations: []description.Relation{{
+			Id:        0,
+			Endpoints: []string{"mysql:cluster"},
+		}},
+	}
+	data, err := description.Serialize(env)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(env.Version, gc.Equals, 0)
+
+	result, err := description.Deserialize(data)
+	c.Assert(err, jc.ErrorIsNil)
+	env.Version = description.Version
+	c.Assert(result, jc.DeepEquals, env)
+}
+
+func (*DescriptionSuite) TestDeserializeUnsupportedVersion(c *gc.C) {
+	_, err := description.Deserialize([]byte("version: 2\nowner: admin@local\n"))
+	c.Assert(err, gc.ErrorMatches, "environment description version 2 not supported")
+}
+
+func (*DescriptionSuite) TestDeserializeInvalid(c *gc.C) {
+	_, err := description.Deserialize([]byte("version: [\n"))
+	c.Assert(err, gc.ErrorMatches, "cannot parse environment description: .*")
+}
@@ -49,6 +49,13 @@ type StartInstanceParams struct {
 	// NetworkInfo is an optional list of network interface details,
 	// necessary to configure on the instance.
 	NetworkInfo []network.InterfaceInfo
+
+	// SubnetsToZones is an optional map of provider-specific subnet
+	// ids to the availability zones they are in. When set, the
+	// instance should be started in one of those subnets, as the
+	// endpoints of the units it will host are bound to spaces
+	// containing them.
+	SubnetsToZones map[network.Id][]string
 }
 
 // StartInstanceResult holds the result of an
@@ -95,6 +95,19 @@ const (
 	// config setting. Only non-zero, positive integer values will
 	// have effect.
 	DefaultLXCDefaultMTU = 0
+
+	// DefaultHookRetryAttempts is the default value for the
+	// "hook-retry-attempts" config setting. Failed hooks are not
+	// retried automatically unless it is changed.
+	DefaultHookRetryAttempts = 0
+
+	// DefaultHookRetryDelay is the default value for the
+	// "hook-retry-delay" config setting.
+	DefaultHookRetryDelay = 10 * time.Second
+
+	// DefaultHookRetryMaxDelay is the default value for the
+	// "hook-retry-max-delay" config setting.
+	DefaultHookRetryMaxDelay = 10 * time.Minute
 )
 
 // TODO(katco-): Please grow this over time.
@@ -171,6 +184,19 @@ const (
 	// interfaces created for LXC containers. See also bug #1442257.
 	LXCDefaultMTU = "lxc-default-mtu"
 
+	// HookRetryAttemptsKey stores the number of times a failed hook
+	// is retried automatically before a unit waits to be resolved.
+	HookRetryAttemptsKey = "hook-retry-attempts"
+
+	// HookRetryDelayKey stores the time waited before the first
+	// automatic retry of a failed hook. The delay doubles with each
+	// subsequent attempt.
+	HookRetryDelayKey = "hook-retry-delay"
+
+	// HookRetryMaxDelayKey stores the longest time waited between
+	// automatic retries of a failed hook.
+	HookRetryMaxDelayKey = "hook-retry-max-delay"
+
 	//
 	// Deprecated Settings Attributes
 	//
@@ -644,6 +670,11 @@ func Validate(cfg, old *Config) error {
 		return errors.Errorf("%s: expected positive integer, got %v", LXCDefaultMTU, lxcDefaultMTU)
 	}
 
+	// Check the hook retry policy is sane.
+	if _, err := cfg.hookRetryPolicy(); err != nil {
+		return errors.Trace(err)
+	}
+
 	cfg.defined = ProcessDeprecatedAttributes(cfg.defined)
 	return nil
 }
@@ -1138,6 +1169,73 @@ func (c *Config) LXCDefaultMTU() (int, bool) {
 	return v, ok
 }
 
+// HookRetryPolicy describes how failed hooks are retried automatically.
+type HookRetryPolicy struct {
+	// Attempts is the number of times a failed hook is retried before
+	// the unit waits to be resolved. Zero disables automatic retries.
+	Attempts int
+
+	// Delay is the time waited before the first retry. It doubles with
+	// each subsequent retry.
+	Delay time.Duration
+
+	// MaxDelay is the longest time waited between retries.
+	MaxDelay time.Duration
+}
+
+// Validate returns an error if the policy is not valid.
+func (p HookRetryPolicy) Validate() error {
+	if p.Attempts < 0 {
+		return errors.Errorf("%s: expected non-negative integer, got %d", HookRetryAttemptsKey, p.Attempts)
+	}
+	if p.Delay <= 0 {
+		return errors.Errorf("%s: expected positive duration, got %v", HookRetryDelayKey, p.Delay)
+	}
+	if p.MaxDelay < p.Delay {
+		return errors.Errorf("%s: %v is less than %s %v", HookRetryMaxDelayKey, p.MaxDelay, HookRetryDelayKey, p.Delay)
+	}
+	return nil
+}
+
+// HookRetryPolicy returns the policy by which failed hooks are retried
+// automatically in the environment.
+func (c *Config) HookRetryPolicy() HookRetryPolicy {
+	policy, err := c.hookRetryPolicy()
+	if err != nil {
+		panic(err) // should be prevented by Validate
+	}
+	return policy
+}
+
+func (c *Config) hookRetryPolicy() (HookRetryPolicy, error) {
+	policy := HookRetryPolicy{
+		Attempts: DefaultHookRetryAttempts,
+		Delay:    DefaultHookRetryDelay,
+		MaxDelay: DefaultHookRetryMaxDelay,
+	}
+	if v, ok := c.defined[HookRetryAttemptsKey].(int); ok {
+		policy.Attempts = v
+	}
+	for key, d := range map[string]*time.Duration{
+		HookRetryDelayKey:    &policy.Delay,
+		HookRetryMaxDelayKey: &policy.MaxDelay,
+	} {
+		v := c.asString(key)
+		if v == "" {
+			continue
+		}
+		parsed, err := time.ParseDuration(v)
+		if err != nil {
+			return HookRetryPolicy{}, errors.Annotatef(err, "invalid %s", key)
+		}
+		*d = parsed
+	}
+	if err := policy.Validate(); err != nil {
+		return HookRetryPolicy{}, err
+	}
+	return policy, nil
+}
+
 // DisableNetworkManagement reports whether Juju is allowed to
 // configure and manage networking inside the environment.
 func (c *Config) DisableNetworkManagement() (bool, bool) {
@@ -1289,6 +1387,9 @@ var fields = schema.Fields{
 	StorageDefaultBlockSourceKey: schema.String(),
 	AllowLXCLoopMounts:           schema.Bool(),
 	ResourceTagsKey:              schema.OneOf(schema.String(), schema.List(schema.String())),
+	HookRetryAttemptsKey:         schema.ForceInt(),
+	HookRetryDelayKey:            schema.String(),
+	HookRetryMaxDelayKey:         schema.String(),
 
 	// Deprecated fields, retain for backwards compatibility.
 	ToolsMetadataURLKey:    schema.String(),
@@ -1334,6 +1435,9 @@ var alwaysOptional = schema.Defaults{
 	SetNumaControlPolicyKey:      DefaultNumaControlPolicy,
 	AllowLXCLoopMounts:           false,
 	ResourceTagsKey:              schema.Omit,
+	HookRetryAttemptsKey:         schema.Omit,
+	HookRetryDelayKey:            schema.Omit,
+	HookRetryMaxDelayKey:         schema.Omit,
 
 	// Storage related config.
 	// Environ providers will specify their own defaults.
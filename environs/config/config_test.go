@@ -256,6 +256,44 @@ var configTests = []configTest{
 			"lxc-default-mtu": -42,
 		},
 		err: `lxc-default-mtu: expected positive integer, got -42`,
+	}, {
+		about:       "Hook retry policy set explicitly",
+		useDefaults: config.UseDefaults,
+		attrs: testing.Attrs{
+			"type":                 "my-type",
+			"name":                 "my-name",
+			"hook-retry-attempts":  5,
+			"hook-retry-delay":     "30s",
+			"hook-retry-max-delay": "1h",
+		},
+	}, {
+		about:       "Hook retry attempts invalid (negative)",
+		useDefaults: config.UseDefaults,
+		attrs: testing.Attrs{
+			"type":                "my-type",
+			"name":                "my-name",
+			"hook-retry-attempts": -1,
+		},
+		err: `hook-retry-attempts: expected non-negative integer, got -1`,
+	}, {
+		about:       "Hook retry delay invalid",
+		useDefaults: config.UseDefaults,
+		attrs: testing.Attrs{
+			"type":             "my-type",
+			"name":             "my-name",
+			"hook-retry-delay": "soon",
+		},
+		err: `invalid hook-retry-delay: time: invalid duration "?soon"?`,
+	}, {
+		about:       "Hook retry max delay less than delay",
+		useDefaults: config.UseDefaults,
+		attrs: testing.Attrs{
+			"type":                 "my-type",
+			"name":                 "my-name",
+			"hook-retry-delay":     "5m",
+			"hook-retry-max-delay": "1m",
+		},
+		err: `hook-retry-max-delay: 1m0s is less than hook-retry-delay 5m0s`,
 	}, {
 		about:       "CA cert & key from path",
 		useDefaults: config.UseDefaults,
@@ -1657,6 +1695,26 @@ func newTestConfig(c *gc.C, explicit testing.Attrs) *config.Config {
 	return result
 }
 
+func (s *ConfigSuite) TestHookRetryPolicy(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.HookRetryPolicy(), gc.Equals, config.HookRetryPolicy{
+		Attempts: config.DefaultHookRetryAttempts,
+		Delay:    config.DefaultHookRetryDelay,
+		MaxDelay: config.DefaultHookRetryMaxDelay,
+	})
+
+	cfg = newTestConfig(c, testing.Attrs{
+		"hook-retry-attempts":  3,
+		"hook-retry-delay":     "1m",
+		"hook-retry-max-delay": "2m",
+	})
+	c.Assert(cfg.HookRetryPolicy(), gc.Equals, config.HookRetryPolicy{
+		Attempts: 3,
+		Delay:    time.Minute,
+		MaxDelay: 2 * time.Minute,
+	})
+}
+
 func (s *ConfigSuite) TestLoggingConfig(c *gc.C) {
 	s.addJujuFiles(c)
 	config := newTestConfig(c, testing.Attrs{
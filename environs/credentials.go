@@ -0,0 +1,40 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package environs
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"sort"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/environs/config"
+)
+
+// CredentialsFingerprint returns a fingerprint of the provider
+// credentials in the given configuration, which are the provider's
+// secret attributes. The fingerprint changes whenever the credentials
+// do, without revealing them, so it can be used to tell which
+// credentials an environment was opened with.
+func CredentialsFingerprint(cfg *config.Config) (string, error) {
+	provider, err := Provider(cfg.Type())
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	credentials, err := provider.SecretAttrs(cfg)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	keys := make([]string, 0, len(credentials))
+	for key := range credentials {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	hash := sha256.New()
+	for _, key := range keys {
+		fmt.Fprintf(hash, "%s=%q\n", key, credentials[key])
+	}
+	return fmt.Sprintf("%x", hash.Sum(nil)), nil
+}
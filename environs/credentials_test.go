@@ -0,0 +1,37 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package environs_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/testing"
+)
+
+type CredentialsSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&CredentialsSuite{})
+
+func (s *CredentialsSuite) fingerprint(c *gc.C, attrs testing.Attrs) string {
+	cfg, err := config.New(config.NoDefaults, dummySampleConfig().Merge(attrs))
+	c.Assert(err, jc.ErrorIsNil)
+	fingerprint, err := environs.CredentialsFingerprint(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+	return fingerprint
+}
+
+func (s *CredentialsSuite) TestCredentialsFingerprint(c *gc.C) {
+	fingerprint := s.fingerprint(c, nil)
+	c.Assert(fingerprint, gc.Matches, "[0-9a-f]{64}")
+	c.Assert(fingerprint, gc.Not(gc.Matches), ".*pork.*")
+
+	// Only the credentials affect the fingerprint.
+	c.Assert(s.fingerprint(c, testing.Attrs{"default-series": "trusty"}), gc.Equals, fingerprint)
+	c.Assert(s.fingerprint(c, testing.Attrs{"secret": "beef"}), gc.Not(gc.Equals), fingerprint)
+}
@@ -0,0 +1,59 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package environs
+
+import (
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+)
+
+// IngressFirewall defines the methods of environments able to restrict
+// the source addresses admitted to ports opened for the whole
+// environment. Like OpenPorts, ClosePorts and Ports, they must only be
+// used if the environment was setup with the FwGlobal firewall mode.
+type IngressFirewall interface {
+	// OpenIngressRules opens the port ranges of the given rules to
+	// their source CIDRs.
+	OpenIngressRules(rules []network.IngressRule) error
+
+	// CloseIngressRules closes the port ranges of the given rules to
+	// their source CIDRs.
+	CloseIngressRules(rules []network.IngressRule) error
+
+	// IngressRules returns the ingress rules opened for the whole
+	// environment, including port ranges opened to any address.
+	IngressRules() ([]network.IngressRule, error)
+}
+
+// InstanceIngressFirewall defines the methods of instances able to
+// restrict the source addresses admitted to ports opened on them. Like
+// the port methods of instance.Instance, they must be passed the id of
+// the machine the instance was started for.
+type InstanceIngressFirewall interface {
+	// OpenIngressRules opens the port ranges of the given rules to
+	// their source CIDRs on the instance.
+	OpenIngressRules(machineId string, rules []network.IngressRule) error
+
+	// CloseIngressRules closes the port ranges of the given rules to
+	// their source CIDRs on the instance.
+	CloseIngressRules(machineId string, rules []network.IngressRule) error
+
+	// IngressRules returns the ingress rules opened on the instance,
+	// including port ranges opened to any address.
+	IngressRules(machineId string) ([]network.IngressRule, error)
+}
+
+// SupportsIngressFirewall is a convenience helper to check if an
+// environment supports restricting ingress to source CIDRs.
+func SupportsIngressFirewall(environ Environ) (IngressFirewall, bool) {
+	fw, ok := environ.(IngressFirewall)
+	return fw, ok
+}
+
+// InstanceSupportsIngressFirewall is a convenience helper to check if
+// an instance supports restricting ingress to source CIDRs.
+func InstanceSupportsIngressFirewall(inst instance.Instance) (InstanceIngressFirewall, bool) {
+	fw, ok := inst.(InstanceIngressFirewall)
+	return fw, ok
+}
@@ -0,0 +1,25 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package environs
+
+import (
+	"github.com/juju/juju/instance"
+)
+
+// InstanceReclaimer defines the method of environments able to start
+// instances on discounted capacity that the provider may take back at
+// any time, such as EC2 spot or GCE preemptible instances.
+type InstanceReclaimer interface {
+	// ReclaimedInstances returns those of the given instance ids whose
+	// instances have been reclaimed by the provider. Reclaimed
+	// instances are not returned by Instances.
+	ReclaimedInstances(ids []instance.Id) ([]instance.Id, error)
+}
+
+// SupportsInstanceReclaiming is a convenience helper to check if an
+// environment may reclaim its instances.
+func SupportsInstanceReclaiming(environ Environ) (InstanceReclaimer, bool) {
+	reclaimer, ok := environ.(InstanceReclaimer)
+	return reclaimer, ok
+}
@@ -87,12 +87,14 @@ func (s *apiEnvironmentSuite) TestEnvironmentUserInfo(c *gc.C) {
 			CreatedBy:      owner.UserName(),
 			DateCreated:    owner.DateCreated(),
 			LastConnection: owner.LastConnection(),
+			Access:         "admin",
 		}, {
 			UserName:       "bobjohns@ubuntuone",
 			DisplayName:    "Bob Johns",
 			CreatedBy:      owner.UserName(),
 			DateCreated:    envUser.DateCreated(),
 			LastConnection: envUser.LastConnection(),
+			Access:         "write",
 		},
 	})
 }
@@ -35,6 +35,9 @@ type DeployServiceParams struct {
 	// Networks holds a list of networks to required to start on boot.
 	Networks []string
 	Storage  map[string]storage.Constraints
+	// EndpointBindings maps the names of the service's endpoints to
+	// the names of the spaces they are bound to.
+	EndpointBindings map[string]string
 }
 
 // DeployService takes a charm and various parameters and deploys it.
@@ -76,6 +79,11 @@ func DeployService(st *state.State, args DeployServiceParams) (*state.Service, e
 			return nil, fmt.Errorf("cannot deploy with networks: not suppored by the environment")
 		}
 	}
+	// Reject bad bindings before the service is added, so that a
+	// deployment never leaves behind a service with none.
+	if err := st.ValidateEndpointBindings(args.Charm, args.EndpointBindings); err != nil {
+		return nil, err
+	}
 	service, err := st.AddService(
 		args.ServiceName,
 		args.ServiceOwner,
@@ -91,6 +99,11 @@ func DeployService(st *state.State, args DeployServiceParams) (*state.Service, e
 			return nil, err
 		}
 	}
+	if len(args.EndpointBindings) > 0 {
+		if err := service.SetEndpointBindings(args.EndpointBindings); err != nil {
+			return nil, err
+		}
+	}
 	if args.Charm.Meta().Subordinate {
 		return service, nil
 	}
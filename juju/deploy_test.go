@@ -116,6 +116,18 @@ func (s *DeployLocalSuite) TestDeploySettingsError(c *gc.C) {
 	c.Assert(err, jc.Satisfies, errors.IsNotFound)
 }
 
+func (s *DeployLocalSuite) TestDeployEndpointBindingsError(c *gc.C) {
+	_, err := juju.DeployService(s.State,
+		juju.DeployServiceParams{
+			ServiceName:      "bob",
+			Charm:            s.charm,
+			EndpointBindings: map[string]string{"nonsense": "db"},
+		})
+	c.Assert(err, gc.ErrorMatches, `invalid endpoint bindings: .*`)
+	_, err = s.State.Service("bob")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
 func (s *DeployLocalSuite) TestDeployConstraints(c *gc.C) {
 	err := s.State.SetEnvironConstraints(constraints.MustParse("mem=2G"))
 	c.Assert(err, jc.ErrorIsNil)
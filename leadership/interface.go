@@ -40,6 +40,10 @@ type LeadershipLeaseManager interface {
 	// ReleaseLease releases the lease held for namespace by id.
 	ReleaseLease(namespace, id string) (err error)
 
+	// TransferLease replaces the lease held for namespace by fromId
+	// with a lease held by toId for the given duration.
+	TransferLease(namespace, fromId, toId string, forDur time.Duration) error
+
 	// RetrieveLease retrieves the current lease token for a given
 	// namespace. This is not intended to be exposed to clients, and is
 	// only available within a server-process.
@@ -41,6 +41,44 @@ func (m *Manager) Leader(sid, uid string) (bool, error) {
 	return tok.Id == uid, nil
 }
 
+// ServiceLeader returns the ID of the unit that is currently the leader
+// for the given service ID. It returns a NotFound error if the service
+// has no leader.
+func (m *Manager) ServiceLeader(sid string) (string, error) {
+	uid, _, err := m.ServiceLeaderLease(sid)
+	return uid, err
+}
+
+// ServiceLeaderLease returns the ID of the unit that is currently the
+// leader for the given service ID, and the time at which its lease
+// expires. It returns a NotFound error if the service has no leader.
+func (m *Manager) ServiceLeaderLease(sid string) (string, time.Time, error) {
+	tok, err := m.leaseMgr.RetrieveLease(leadershipNamespace(sid))
+	if errors.IsNotFound(err) {
+		return "", time.Time{}, errors.NotFoundf("leader of service %q", sid)
+	} else if err != nil {
+		return "", time.Time{}, err
+	}
+	return tok.Id, tok.Expiration, nil
+}
+
+// TransferLeadership makes the given unit ID the leader for the given
+// service ID for the supplied duration, in place of the current leader.
+// Units waiting for leadership to be released are notified, so that the
+// new leader goes on to claim leadership for itself; the old leader
+// finds out when its next claim is denied.
+func (m *Manager) TransferLeadership(sid, uid string, duration time.Duration) error {
+	leader, _, err := m.ServiceLeaderLease(sid)
+	if err != nil {
+		return err
+	}
+	if leader == uid {
+		return nil
+	}
+	err = m.leaseMgr.TransferLease(leadershipNamespace(sid), leader, uid, duration)
+	return errors.Annotate(err, "unable to transfer leadership")
+}
+
 // ClaimLeadership implements the LeadershipManager interface.
 func (m *Manager) ClaimLeadership(sid, uid string, duration time.Duration) error {
 
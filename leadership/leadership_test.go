@@ -31,6 +31,7 @@ type leadershipSuite struct{}
 type leaseStub struct {
 	ClaimLeaseFn            func(string, string, time.Duration) (string, error)
 	ReleaseLeaseFn          func(string, string) error
+	TransferLeaseFn         func(string, string, string, time.Duration) error
 	LeaseReleasedNotifierFn func(string) (<-chan struct{}, error)
 	RetrieveLeaseFn         func(string) (lease.Token, error)
 }
@@ -49,6 +50,13 @@ func (s *leaseStub) ReleaseLease(namespace, id string) error {
 	return nil
 }
 
+func (s *leaseStub) TransferLease(namespace, fromId, toId string, forDur time.Duration) error {
+	if s.TransferLeaseFn != nil {
+		return s.TransferLeaseFn(namespace, fromId, toId, forDur)
+	}
+	return nil
+}
+
 func (s *leaseStub) LeaseReleasedNotifier(namespace string) (<-chan struct{}, error) {
 	if s.LeaseReleasedNotifierFn != nil {
 		return s.LeaseReleasedNotifierFn(namespace)
@@ -107,6 +115,76 @@ func (s *leadershipSuite) leader(c *gc.C, result lease.Token, resultErr error) (
 	return leader, err
 }
 
+func (s *leadershipSuite) TestServiceLeader(c *gc.C) {
+	stub := &leaseStub{
+		RetrieveLeaseFn: func(namespace string) (lease.Token, error) {
+			c.Check(namespace, gc.Equals, leadershipNamespace(StubServiceNm))
+			return lease.Token{Namespace: namespace, Id: StubUnitNm}, nil
+		},
+	}
+	leader, err := NewLeadershipManager(stub).ServiceLeader(StubServiceNm)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(leader, gc.Equals, StubUnitNm)
+}
+
+func (s *leadershipSuite) TestServiceLeaderNone(c *gc.C) {
+	_, err := NewLeadershipManager(&leaseStub{}).ServiceLeader(StubServiceNm)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `leader of service "stub-service" not found`)
+}
+
+func (s *leadershipSuite) TestServiceLeaderLease(c *gc.C) {
+	expiry := time.Date(2015, time.June, 1, 12, 0, 0, 0, time.UTC)
+	stub := &leaseStub{
+		RetrieveLeaseFn: func(namespace string) (lease.Token, error) {
+			return lease.Token{Namespace: namespace, Id: StubUnitNm, Expiration: expiry}, nil
+		},
+	}
+	leader, leaderExpiry, err := NewLeadershipManager(stub).ServiceLeaderLease(StubServiceNm)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(leader, gc.Equals, StubUnitNm)
+	c.Check(leaderExpiry, gc.Equals, expiry)
+}
+
+func (s *leadershipSuite) TestTransferLeadership(c *gc.C) {
+	numStubCalls := 0
+	stub := &leaseStub{
+		RetrieveLeaseFn: func(namespace string) (lease.Token, error) {
+			return lease.Token{Namespace: namespace, Id: StubUnitNm}, nil
+		},
+		TransferLeaseFn: func(namespace, fromId, toId string, forDur time.Duration) error {
+			numStubCalls++
+			c.Check(namespace, gc.Equals, leadershipNamespace(StubServiceNm))
+			c.Check(fromId, gc.Equals, StubUnitNm)
+			c.Check(toId, gc.Equals, "stub-unit/1")
+			c.Check(forDur, gc.Equals, time.Minute)
+			return nil
+		},
+	}
+	err := NewLeadershipManager(stub).TransferLeadership(StubServiceNm, "stub-unit/1", time.Minute)
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(numStubCalls, gc.Equals, 1)
+}
+
+func (s *leadershipSuite) TestTransferLeadershipToLeader(c *gc.C) {
+	stub := &leaseStub{
+		RetrieveLeaseFn: func(namespace string) (lease.Token, error) {
+			return lease.Token{Namespace: namespace, Id: StubUnitNm}, nil
+		},
+		TransferLeaseFn: func(namespace, fromId, toId string, forDur time.Duration) error {
+			c.Errorf("unexpected transfer")
+			return nil
+		},
+	}
+	err := NewLeadershipManager(stub).TransferLeadership(StubServiceNm, StubUnitNm, time.Minute)
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *leadershipSuite) TestTransferLeadershipNoLeader(c *gc.C) {
+	err := NewLeadershipManager(&leaseStub{}).TransferLeadership(StubServiceNm, StubUnitNm, time.Minute)
+	c.Check(err, gc.ErrorMatches, `leader of service "stub-service" not found`)
+}
+
 func (s *leadershipSuite) TestClaimLeadershipTranslation(c *gc.C) {
 
 	numStubCalls := 0
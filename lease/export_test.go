@@ -0,0 +1,18 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lease
+
+var RefreshInterval = &refreshInterval
+
+// NewUnregisteredLeaseManager starts a lease manager without making it
+// the package's singleton, so that several can run side by side, as
+// they do on the state servers of a highly available environment.
+func NewUnregisteredLeaseManager(leasePersistor leasePersistor) *leaseManager {
+	m := newLeaseManager(leasePersistor)
+	go func() {
+		defer m.tomb.Done()
+		m.tomb.Kill(m.loop())
+	}()
+	return m
+}
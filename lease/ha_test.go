@@ -0,0 +1,206 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lease_test
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/set"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/lease"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	coretesting "github.com/juju/juju/testing"
+)
+
+// haSuite runs several lease managers against a single mongo, each
+// with its own connection to state, as the state servers of a highly
+// available environment do.
+type haSuite struct {
+	statetesting.StateSuite
+	managers []leaseManager
+}
+
+var _ = gc.Suite(&haSuite{})
+
+const numManagers = 3
+
+// leaseManager is the part of a lease manager exercised by haSuite.
+type leaseManager interface {
+	ClaimLease(namespace, id string, forDur time.Duration) (string, error)
+	ReleaseLease(namespace, id string) error
+	TransferLease(namespace, fromId, toId string, forDur time.Duration) error
+	RetrieveLease(namespace string) (lease.Token, error)
+	LeaseReleasedNotifier(namespace string) (<-chan struct{}, error)
+	Kill()
+	Wait() error
+}
+
+func (s *haSuite) SetUpTest(c *gc.C) {
+	s.StateSuite.SetUpTest(c)
+	s.PatchValue(lease.RefreshInterval, 10*time.Millisecond)
+
+	s.managers = nil
+	for i := 0; i < numManagers; i++ {
+		st, err := state.Open(statetesting.NewMongoInfo(), statetesting.NewDialOpts(), nil)
+		c.Assert(err, jc.ErrorIsNil)
+		manager := lease.NewUnregisteredLeaseManager(st)
+		s.AddCleanup(func(c *gc.C) {
+			manager.Kill()
+			c.Check(manager.Wait(), jc.ErrorIsNil)
+			c.Check(st.Close(), jc.ErrorIsNil)
+		})
+		s.managers = append(s.managers, manager)
+	}
+}
+
+func (s *haSuite) TestOneHolderAcrossManagers(c *gc.C) {
+	owners := make([]string, len(s.managers))
+	var wg sync.WaitGroup
+	for i, manager := range s.managers {
+		wg.Add(1)
+		go func(i int, manager leaseManager) {
+			defer wg.Done()
+			owners[i], _ = manager.ClaimLease("mysql", fmt.Sprintf("mysql/%d", i), time.Minute)
+		}(i, manager)
+	}
+	wg.Wait()
+
+	for _, owner := range owners[1:] {
+		c.Check(owner, gc.Equals, owners[0])
+	}
+	c.Check(owners[0], gc.Matches, `mysql/\d`)
+}
+
+func (s *haSuite) TestClaimDeniedByOtherManager(c *gc.C) {
+	owner, err := s.managers[0].ClaimLease("mysql", "mysql/0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(owner, gc.Equals, "mysql/0")
+
+	owner, err = s.managers[1].ClaimLease("mysql", "mysql/1", time.Minute)
+	c.Assert(err, gc.Equals, lease.LeaseClaimDeniedErr)
+	c.Assert(owner, gc.Equals, "mysql/0")
+
+	// The holder can extend its lease through any manager.
+	owner, err = s.managers[2].ClaimLease("mysql", "mysql/0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(owner, gc.Equals, "mysql/0")
+}
+
+func (s *haSuite) TestClaimExpiredLease(c *gc.C) {
+	_, err := s.managers[0].ClaimLease("mysql", "mysql/0", 100*time.Millisecond)
+	c.Assert(err, jc.ErrorIsNil)
+
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		owner, err := s.managers[1].ClaimLease("mysql", "mysql/1", time.Minute)
+		if err == nil {
+			c.Assert(owner, gc.Equals, "mysql/1")
+			return
+		}
+		c.Assert(err, gc.Equals, lease.LeaseClaimDeniedErr)
+	}
+	c.Fatalf("expired lease was never granted to another unit")
+}
+
+func (s *haSuite) TestReleaseNotifiesOtherManagers(c *gc.C) {
+	_, err := s.managers[0].ClaimLease("mysql", "mysql/0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitForHolder(c, s.managers[1], "mysql", "mysql/0")
+	notifier, err := s.managers[1].LeaseReleasedNotifier("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.managers[0].ReleaseLease("mysql", "mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case <-notifier:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("release was not noticed by another manager")
+	}
+
+	owner, err := s.managers[2].ClaimLease("mysql", "mysql/2", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(owner, gc.Equals, "mysql/2")
+}
+
+func (s *haSuite) TestReleaseByNonHolder(c *gc.C) {
+	_, err := s.managers[0].ClaimLease("mysql", "mysql/0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Releasing a lease that is not held is logged and ignored.
+	err = s.managers[1].ReleaseLease("mysql", "mysql/1")
+	c.Assert(err, jc.ErrorIsNil)
+
+	owner, err := s.managers[1].ClaimLease("mysql", "mysql/1", time.Minute)
+	c.Assert(err, gc.Equals, lease.LeaseClaimDeniedErr)
+	c.Assert(owner, gc.Equals, "mysql/0")
+}
+
+func (s *haSuite) TestTransferSeenByOtherManagers(c *gc.C) {
+	_, err := s.managers[0].ClaimLease("mysql", "mysql/0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.managers[1].TransferLease("mysql", "mysql/0", "mysql/1", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitForHolder(c, s.managers[2], "mysql", "mysql/1")
+
+	// The old holder can no longer extend its lease.
+	owner, err := s.managers[0].ClaimLease("mysql", "mysql/0", time.Minute)
+	c.Assert(err, gc.Equals, lease.LeaseClaimDeniedErr)
+	c.Assert(owner, gc.Equals, "mysql/1")
+}
+
+func (s *haSuite) TestContentionDoesNotStopManagers(c *gc.C) {
+	// Every manager claims and transfers the same lease at once, so
+	// that their writes contend; whatever errors the callers see, no
+	// manager may stop.
+	const rounds = 20
+	var wg sync.WaitGroup
+	for i, manager := range s.managers {
+		for j := 0; j < rounds; j++ {
+			wg.Add(1)
+			go func(i, j int, manager leaseManager) {
+				defer wg.Done()
+				id := fmt.Sprintf("mysql/%d", (i+j)%numManagers)
+				next := fmt.Sprintf("mysql/%d", (i+j+1)%numManagers)
+				_, err := manager.ClaimLease("mysql", id, time.Minute)
+				if err != nil {
+					c.Check(err, gc.Not(gc.ErrorMatches), "worker stopped")
+				}
+				err = manager.TransferLease("mysql", id, next, time.Minute)
+				if err != nil {
+					c.Check(err, gc.Not(gc.ErrorMatches), "worker stopped")
+				}
+			}(i, j, manager)
+		}
+	}
+	wg.Wait()
+
+	// All the managers still answer, and agree on a single holder.
+	owners := set.NewStrings()
+	for i, manager := range s.managers {
+		owner, err := manager.ClaimLease("mysql", fmt.Sprintf("mysql/%d", i), time.Minute)
+		if err != nil {
+			c.Check(err, gc.Equals, lease.LeaseClaimDeniedErr)
+		}
+		owners.Add(owner)
+	}
+	c.Check(owners.Size(), gc.Equals, 1)
+	c.Check(owners.Contains(""), jc.IsFalse)
+}
+
+// waitForHolder waits until the given manager reports that id holds the
+// lease for namespace.
+func (s *haSuite) waitForHolder(c *gc.C, manager leaseManager, namespace, id string) {
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		tok, err := manager.RetrieveLease(namespace)
+		if err == nil && tok.Id == id {
+			return
+		}
+	}
+	c.Fatalf("lease for %q never seen held by %q", namespace, id)
+}
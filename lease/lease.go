@@ -27,11 +27,35 @@ var (
 	errWorkerStopped    = errors.New("worker stopped")
 
 	logger = loggo.GetLogger("juju.lease")
+
+	// refreshInterval is the time between reads of the leases held in
+	// the data store, through which a lease manager finds out about
+	// claims and releases made through other lease managers.
+	refreshInterval = 5 * time.Second
 )
 
+// leasePersistor arbitrates leases between all the lease managers that
+// share a data store, such as those running on each state server. The
+// persistor judges whether a lease has expired by a clock that all of
+// those managers share, so that leases cannot overlap even when their
+// own clocks differ; the expiration times of the tokens it returns are
+// converted to the local clock.
 type leasePersistor interface {
-	WriteToken(string, Token) error
-	RemoveToken(id string) error
+	// ClaimToken grants the lease for namespace to id for the given
+	// duration, unless another id holds an unexpired lease for the
+	// namespace. It returns the token of the holder of the lease.
+	ClaimToken(namespace, id string, forDur time.Duration) (Token, error)
+
+	// TransferToken replaces the unexpired lease held for namespace by
+	// fromId with a lease held by toId for the given duration. It
+	// returns NotLeaseOwnerErr if fromId does not hold the lease.
+	TransferToken(namespace, fromId, toId string, forDur time.Duration) (Token, error)
+
+	// RemoveToken removes the lease held for namespace by id. It
+	// returns NotLeaseOwnerErr if id does not hold the lease.
+	RemoveToken(namespace, id string) error
+
+	// PersistedTokens returns the tokens of all unexpired leases.
 	PersistedTokens() ([]Token, error)
 }
 
@@ -47,7 +71,13 @@ type Token struct {
 
 type claimLeaseMsg struct {
 	Token    Token
-	Response chan<- Token
+	Duration time.Duration
+	Response chan<- claimLeaseResult
+}
+
+type claimLeaseResult struct {
+	Token Token
+	Err   error
 }
 
 type releaseLeaseMsg struct {
@@ -55,6 +85,13 @@ type releaseLeaseMsg struct {
 	Response chan<- error
 }
 
+type transferLeaseMsg struct {
+	Token    Token
+	FromId   string
+	Duration time.Duration
+	Response chan<- error
+}
+
 type leaseReleasedMsg struct {
 	Watcher      chan<- struct{}
 	ForNamespace string
@@ -69,6 +106,7 @@ type leaseManager struct {
 	leasePersistor   leasePersistor
 	claimLease       chan claimLeaseMsg
 	releaseLease     chan releaseLeaseMsg
+	transferLease    chan transferLeaseMsg
 	leaseReleasedSub chan leaseReleasedMsg
 	copyOfTokens     chan copyTokensMsg
 }
@@ -81,13 +119,7 @@ type leaseManager struct {
 // will result if NewLeaseManager is called while another lease manager is
 // still active.
 func NewLeaseManager(leasePersistor leasePersistor) (*leaseManager, error) {
-	m := &leaseManager{
-		leasePersistor:   leasePersistor,
-		claimLease:       make(chan claimLeaseMsg),
-		releaseLease:     make(chan releaseLeaseMsg),
-		leaseReleasedSub: make(chan leaseReleasedMsg),
-		copyOfTokens:     make(chan copyTokensMsg),
-	}
+	m := newLeaseManager(leasePersistor)
 	if err := singleton.setLeaseManager(m); err != nil {
 		return nil, err
 	}
@@ -99,6 +131,18 @@ func NewLeaseManager(leasePersistor leasePersistor) (*leaseManager, error) {
 	return m, nil
 }
 
+// newLeaseManager returns a new leaseManager that has not been started.
+func newLeaseManager(leasePersistor leasePersistor) *leaseManager {
+	return &leaseManager{
+		leasePersistor:   leasePersistor,
+		claimLease:       make(chan claimLeaseMsg),
+		releaseLease:     make(chan releaseLeaseMsg),
+		transferLease:    make(chan transferLeaseMsg),
+		leaseReleasedSub: make(chan leaseReleasedMsg),
+		copyOfTokens:     make(chan copyTokensMsg),
+	}
+}
+
 func (m *leaseManager) Kill() {
 	m.tomb.Kill(nil)
 }
@@ -144,18 +188,21 @@ func (m *leaseManager) RetrieveLease(namespace string) (Token, error) {
 // LeaseClaimDeniedErr will be returned. Either way the current lease
 // owner's ID will be returned.
 func (m *leaseManager) ClaimLease(namespace, id string, forDur time.Duration) (leaseOwnerId string, err error) {
-	ch := make(chan Token, 1)
-	token := Token{namespace, id, time.Now().Add(forDur)}
+	ch := make(chan claimLeaseResult, 1)
+	token := Token{Namespace: namespace, Id: id}
 	select {
 	case <-m.tomb.Dying():
 		return "", errWorkerStopped
-	case m.claimLease <- claimLeaseMsg{token, ch}:
+	case m.claimLease <- claimLeaseMsg{token, forDur, ch}:
 	}
 	select {
 	case <-m.tomb.Dying():
 		return "", errWorkerStopped
-	case lease := <-ch:
-		leaseOwnerId = lease.Id
+	case result := <-ch:
+		if result.Err != nil {
+			return "", errors.Annotatef(result.Err, `could not claim lease for namespace %q, id %q`, namespace, id)
+		}
+		leaseOwnerId = result.Token.Id
 		if id != leaseOwnerId {
 			err = LeaseClaimDeniedErr
 		}
@@ -195,6 +242,29 @@ func (m *leaseManager) ReleaseLease(namespace, id string) (err error) {
 	return nil
 }
 
+// TransferLease replaces the lease held for namespace by fromId with
+// a lease held by toId for the given duration. Subscribers are notified
+// as if the lease had been released, so that toId can go on to claim it,
+// but nobody else can claim the lease in between.
+func (m *leaseManager) TransferLease(namespace, fromId, toId string, forDur time.Duration) error {
+	ch := make(chan error, 1)
+	token := Token{Namespace: namespace, Id: toId}
+	select {
+	case <-m.tomb.Dying():
+		return errWorkerStopped
+	case m.transferLease <- transferLeaseMsg{token, fromId, forDur, ch}:
+	}
+	select {
+	case <-m.tomb.Dying():
+		return errWorkerStopped
+	case err := <-ch:
+		if err != nil {
+			return errors.Annotatef(err, `could not transfer lease for namespace %q from %q to %q`, namespace, fromId, toId)
+		}
+		return nil
+	}
+}
+
 // LeaseReleasedNotifier returns a channel a caller can block on to be
 // notified of when a lease is released for namespace. This channel is
 // reusable, but will be closed if it does not respond within
@@ -225,6 +295,7 @@ func (m *leaseManager) loop() error {
 	if err != nil {
 		return errors.Annotate(err, "expiring leases")
 	}
+	refresh := time.After(refreshInterval)
 
 	for {
 		select {
@@ -238,33 +309,65 @@ func (m *leaseManager) loop() error {
 			}
 			return tomb.ErrDying
 		case claim := <-m.claimLease:
-			lease := claimLease(leaseCache, claim.Token)
-			if lease.Id == claim.Token.Id {
-				if err := m.leasePersistor.WriteToken(lease.Namespace, lease); err != nil {
-					return errors.Annotate(err, "writing lease token")
+			// The persistor decides who holds the lease, because
+			// other lease managers may have granted it.
+			// A failed write, such as excessive contention with
+			// the other lease managers, is the caller's to handle;
+			// it must not stop the manager.
+			lease, err := m.leasePersistor.ClaimToken(claim.Token.Namespace, claim.Token.Id, claim.Duration)
+			if err == nil {
+				leaseCache[lease.Namespace] = lease
+				if lease.Id == claim.Token.Id {
+					logger.Infof(`%q obtained lease for %q`, lease.Id, lease.Namespace)
 				}
 				if lease.Expiration.Before(nextExpiration) {
 					nextExpiration = lease.Expiration
 				}
+			} else {
+				err = errors.Annotate(err, "writing lease token")
+				logger.Warningf("%q could not claim lease for %q: %v", claim.Token.Id, claim.Token.Namespace, err)
 			}
 			select {
 			case <-m.tomb.Dying():
-			case claim.Response <- lease:
+			case claim.Response <- claimLeaseResult{lease, err}:
 			}
 		case release := <-m.releaseLease:
-			// Unwind our layers from most volatile to least.
-			err := releaseLease(leaseCache, release.Token)
+			namespace := release.Token.Namespace
+			err := m.leasePersistor.RemoveToken(namespace, release.Token.Id)
 			if err == nil {
-				namespace := release.Token.Namespace
-				if err := m.leasePersistor.RemoveToken(namespace); err != nil {
-					return errors.Annotate(err, "removing lease token")
-				}
+				delete(leaseCache, namespace)
+				logger.Infof(`%q released lease for namespace %q`, release.Token.Id, namespace)
 				notifyOfRelease(releaseSubs[namespace], namespace)
+			} else if errors.Cause(err) == NotLeaseOwnerErr {
+				err = NotLeaseOwnerErr
+			} else {
+				err = errors.Annotate(err, "removing lease token")
+				logger.Warningf("%q could not release lease for %q: %v", release.Token.Id, namespace, err)
 			}
 			select {
 			case <-m.tomb.Dying():
 			case release.Response <- err:
 			}
+		case transfer := <-m.transferLease:
+			namespace := transfer.Token.Namespace
+			lease, err := m.leasePersistor.TransferToken(namespace, transfer.FromId, transfer.Token.Id, transfer.Duration)
+			if err == nil {
+				leaseCache[namespace] = lease
+				logger.Infof(`%q transferred lease for namespace %q to %q`, transfer.FromId, namespace, lease.Id)
+				if lease.Expiration.Before(nextExpiration) {
+					nextExpiration = lease.Expiration
+				}
+				notifyOfRelease(releaseSubs[namespace], namespace)
+			} else if errors.Cause(err) == NotLeaseOwnerErr {
+				err = NotLeaseOwnerErr
+			} else {
+				err = errors.Annotate(err, "writing lease token")
+				logger.Warningf("%q could not transfer lease for %q: %v", transfer.FromId, namespace, err)
+			}
+			select {
+			case <-m.tomb.Dying():
+			case transfer.Response <- err:
+			}
 		case subscription := <-m.leaseReleasedSub:
 			subscribe(releaseSubs, subscription)
 		case msg := <-m.copyOfTokens:
@@ -274,6 +377,21 @@ func (m *leaseManager) loop() error {
 			case <-m.tomb.Dying():
 			case msg.Response <- copyTokens(leaseCache):
 			}
+		case <-refresh:
+			refresh = time.After(refreshInterval)
+			freshCache, err := populateTokenCache(m.leasePersistor)
+			if err != nil {
+				// Keep the leases we know about and try again on the
+				// next refresh.
+				logger.Warningf("cannot refresh lease cache: %v", err)
+				continue
+			}
+			notifyOfChanges(leaseCache, freshCache, releaseSubs)
+			leaseCache = freshCache
+			nextExpiration, err = m.expireLeases(leaseCache, releaseSubs)
+			if err != nil {
+				return errors.Annotate(err, "expiring leases")
+			}
 		case <-time.After(nextExpiration.Sub(time.Now())):
 			nextExpiration, err = m.expireLeases(leaseCache, releaseSubs)
 			if err != nil {
@@ -322,21 +440,11 @@ func copyTokens(cache map[string]Token) (copy []Token) {
 	return copy
 }
 
-func claimLease(cache map[string]Token, claim Token) Token {
-	if active, ok := cache[claim.Namespace]; ok && active.Id != claim.Id {
-		return active
-	}
-	cache[claim.Namespace] = claim
-	logger.Infof(`%q obtained lease for %q`, claim.Id, claim.Namespace)
-	return claim
-}
-
 func releaseLease(cache map[string]Token, claim Token) error {
 	if active, ok := cache[claim.Namespace]; !ok || active.Id != claim.Id {
 		return NotLeaseOwnerErr
 	}
 	delete(cache, claim.Namespace)
-	logger.Infof(`%q released lease for namespace %q`, claim.Id, claim.Namespace)
 	return nil
 }
 
@@ -346,6 +454,18 @@ func subscribe(subMap map[string][]chan<- struct{}, subscription leaseReleasedMs
 	subMap[subscription.ForNamespace] = subList
 }
 
+// notifyOfChanges notifies the subscribers to each namespace whose lease
+// is held in the old cache, but is no longer held by the same id in the
+// fresh one, as if the lease had been released.
+func notifyOfChanges(oldCache, freshCache map[string]Token, subscribers map[string][]chan<- struct{}) {
+	for namespace, old := range oldCache {
+		if fresh, ok := freshCache[namespace]; ok && fresh.Id == old.Id {
+			continue
+		}
+		notifyOfRelease(subscribers[namespace], namespace)
+	}
+}
+
 func notifyOfRelease(subscribers []chan<- struct{}, namespace string) {
 	logger.Infof(`Notifying namespace %q subscribers that its lease has been released.`, namespace)
 	for _, subscriber := range subscribers {
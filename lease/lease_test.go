@@ -10,6 +10,7 @@ import (
 	"time"
 
 	jc "github.com/juju/testing/checkers"
+	jujutxn "github.com/juju/txn"
 	"github.com/juju/utils/set"
 	gc "gopkg.in/check.v1"
 
@@ -17,7 +18,7 @@ import (
 	coretesting "github.com/juju/juju/testing"
 )
 
-func Test(t *testing.T) { gc.TestingT(t) }
+func Test(t *testing.T) { coretesting.MgoTestPackage(t) }
 
 const (
 	testNamespace = "leadership-stub-service"
@@ -29,26 +30,51 @@ var (
 	_ = gc.Suite(&leaseSuite{})
 )
 
+// stubLeasePersistor arbitrates leases in memory, by the local clock,
+// unless its functions are set.
 type stubLeasePersistor struct {
-	WriteTokenFn      func(string, Token) error
-	RemoveTokenFn     func(string) error
+	ClaimTokenFn      func(string, string, time.Duration) (Token, error)
+	RemoveTokenFn     func(string, string) error
 	PersistedTokensFn func() ([]Token, error)
+
+	mu     sync.Mutex
+	tokens map[string]Token
 }
 
-func (p *stubLeasePersistor) WriteToken(id string, tok Token) error {
-	if tok.Id == "error" {
-		return errors.New("error")
+func (p *stubLeasePersistor) ClaimToken(namespace, id string, forDur time.Duration) (Token, error) {
+	if id == "error" {
+		return Token{}, errors.New("error")
 	}
-	if p.WriteTokenFn != nil {
-		return p.WriteTokenFn(id, tok)
+	if p.ClaimTokenFn != nil {
+		return p.ClaimTokenFn(namespace, id, forDur)
 	}
-	return nil
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if tok, ok := p.tokens[namespace]; ok && tok.Id != id && tok.Expiration.After(time.Now()) {
+		return tok, nil
+	}
+	return p.setToken(Token{namespace, id, time.Now().Add(forDur)}), nil
 }
 
-func (p *stubLeasePersistor) RemoveToken(id string) error {
+func (p *stubLeasePersistor) TransferToken(namespace, fromId, toId string, forDur time.Duration) (Token, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if tok, ok := p.tokens[namespace]; !ok || tok.Id != fromId {
+		return Token{}, NotLeaseOwnerErr
+	}
+	return p.setToken(Token{namespace, toId, time.Now().Add(forDur)}), nil
+}
+
+func (p *stubLeasePersistor) RemoveToken(namespace, id string) error {
 	if p.RemoveTokenFn != nil {
-		return p.RemoveTokenFn(id)
+		return p.RemoveTokenFn(namespace, id)
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if tok, ok := p.tokens[namespace]; !ok || tok.Id != id {
+		return NotLeaseOwnerErr
 	}
+	delete(p.tokens, namespace)
 	return nil
 }
 
@@ -56,7 +82,23 @@ func (p *stubLeasePersistor) PersistedTokens() ([]Token, error) {
 	if p.PersistedTokensFn != nil {
 		return p.PersistedTokensFn()
 	}
-	return nil, nil
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var tokens []Token
+	for _, tok := range p.tokens {
+		if tok.Expiration.After(time.Now()) {
+			tokens = append(tokens, tok)
+		}
+	}
+	return tokens, nil
+}
+
+func (p *stubLeasePersistor) setToken(tok Token) Token {
+	if p.tokens == nil {
+		p.tokens = make(map[string]Token)
+	}
+	p.tokens[tok.Namespace] = tok
+	return tok
 }
 
 type leaseSuite struct {
@@ -155,15 +197,26 @@ func (s *leaseSuite) TestClaimLeaseSuccess(c *gc.C) {
 }
 
 func (s *leaseSuite) TestClaimLeaseError(c *gc.C) {
-	s.manager.Kill()
-	c.Assert(s.manager.Wait(), jc.ErrorIsNil)
+	_, err := s.manager.ClaimLease(testNamespace, "error", testDuration)
+	c.Assert(err, gc.ErrorMatches, `could not claim lease for namespace "leadership-stub-service", id "error": writing lease token: error`)
 
-	manager, err := NewLeaseManager(s.persistor)
+	// The manager keeps running.
+	owner, err := s.manager.ClaimLease(testNamespace, testId, testDuration)
 	c.Assert(err, jc.ErrorIsNil)
-	_, err = manager.ClaimLease(testNamespace, "error", testDuration)
-	c.Assert(err, gc.ErrorMatches, "worker stopped")
-	err = manager.Wait()
-	c.Assert(err, gc.ErrorMatches, "writing lease token: error")
+	c.Assert(owner, gc.Equals, testId)
+}
+
+func (s *leaseSuite) TestClaimLeaseContention(c *gc.C) {
+	s.persistor.ClaimTokenFn = func(string, string, time.Duration) (Token, error) {
+		return Token{}, jujutxn.ErrExcessiveContention
+	}
+	_, err := s.manager.ClaimLease(testNamespace, testId, testDuration)
+	c.Assert(errors.Cause(err), gc.Equals, jujutxn.ErrExcessiveContention)
+
+	s.persistor.ClaimTokenFn = nil
+	owner, err := s.manager.ClaimLease(testNamespace, testId, testDuration)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(owner, gc.Equals, testId)
 }
 
 func (s *leaseSuite) TestClaimLeaseRaces(c *gc.C) {
@@ -216,7 +269,7 @@ type stubLeasePersistorRemoveError struct {
 	stubLeasePersistor
 }
 
-func (p *stubLeasePersistorRemoveError) RemoveToken(id string) error {
+func (p *stubLeasePersistorRemoveError) RemoveToken(namespace, id string) error {
 	return errors.New("error")
 }
 
@@ -229,10 +282,53 @@ func (s *leaseSuite) TestReleaseLeaseError(c *gc.C) {
 	_, err = manager.ClaimLease(testNamespace, testId, testDuration)
 	c.Check(err, jc.ErrorIsNil)
 	err = manager.ReleaseLease(testNamespace, testId)
-	c.Check(err, gc.ErrorMatches, "worker stopped")
+	c.Check(err, gc.ErrorMatches, `could not release lease for namespace "leadership-stub-service", id "stub-unit/0": removing lease token: error`)
+
+	// The manager keeps running.
+	owner, err := manager.ClaimLease(testNamespace, testId, testDuration)
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(owner, gc.Equals, testId)
 	manager.Kill()
-	err = manager.Wait()
-	c.Assert(err, gc.ErrorMatches, "removing lease token: error")
+	c.Assert(manager.Wait(), jc.ErrorIsNil)
+}
+
+func (s *leaseSuite) TestRefreshError(c *gc.C) {
+	s.manager.Kill()
+	c.Assert(s.manager.Wait(), jc.ErrorIsNil)
+	s.PatchValue(&refreshInterval, 10*time.Millisecond)
+
+	var mu sync.Mutex
+	numCalls := 0
+	s.persistor.PersistedTokensFn = func() ([]Token, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		numCalls++
+		switch {
+		case numCalls == 1:
+			return nil, nil
+		case numCalls <= 3:
+			return nil, errors.New("error")
+		}
+		return []Token{{testNamespace, testId, time.Now().Add(testDuration)}}, nil
+	}
+	manager, err := NewLeaseManager(s.persistor)
+	c.Assert(err, jc.ErrorIsNil)
+	defer func() {
+		manager.Kill()
+		c.Assert(manager.Wait(), jc.ErrorIsNil)
+	}()
+
+	// The manager keeps running after failing to refresh, and picks
+	// up the leases on a later refresh.
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		toks, err := manager.CopyOfLeaseTokens()
+		c.Assert(err, jc.ErrorIsNil)
+		if len(toks) == 1 {
+			c.Check(toks[0].Id, gc.Equals, testId)
+			return
+		}
+	}
+	c.Fatalf("lease cache never refreshed")
 }
 
 func (s *leaseSuite) TestReleaseLeaseNotOwned(c *gc.C) {
@@ -279,6 +375,48 @@ func (s *leaseSuite) TestReleaseLeaseRaces(c *gc.C) {
 	c.Assert(toks, gc.HasLen, 0)
 }
 
+func (s *leaseSuite) TestTransferLease(c *gc.C) {
+	_, err := s.manager.ClaimLease(testNamespace, testId, testDuration)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Listen for the lease to be transferred.
+	subscription, err := s.manager.LeaseReleasedNotifier(testNamespace)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.manager.TransferLease(testNamespace, testId, "stub-unit/1", testDuration)
+	c.Assert(err, jc.ErrorIsNil)
+
+	select {
+	case <-subscription:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("Failed to notify after transfer. Waited for %s", coretesting.LongWait)
+	}
+
+	tok, err := s.manager.RetrieveLease(testNamespace)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(tok.Id, gc.Equals, "stub-unit/1")
+
+	// The new holder can claim the lease; the old one cannot.
+	owner, err := s.manager.ClaimLease(testNamespace, "stub-unit/1", testDuration)
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(owner, gc.Equals, "stub-unit/1")
+	owner, err = s.manager.ClaimLease(testNamespace, testId, testDuration)
+	c.Check(err, gc.Equals, LeaseClaimDeniedErr)
+	c.Check(owner, gc.Equals, "stub-unit/1")
+}
+
+func (s *leaseSuite) TestTransferLeaseNotOwned(c *gc.C) {
+	_, err := s.manager.ClaimLease(testNamespace, testId, testDuration)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.manager.TransferLease(testNamespace, "1234", "stub-unit/1", testDuration)
+	c.Assert(err, gc.ErrorMatches, `could not transfer lease for namespace "leadership-stub-service" from "1234" to "stub-unit/1": caller did not own lease for namespace`)
+
+	tok, err := s.manager.RetrieveLease(testNamespace)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(tok.Id, gc.Equals, testId)
+}
+
 func (s *leaseSuite) TestRetrieveLease(c *gc.C) {
 	_, err := s.manager.ClaimLease(testNamespace, testId, testDuration)
 	c.Assert(err, jc.ErrorIsNil)
@@ -376,15 +514,14 @@ func (s *leaseSuite) TestLeaseExpiration(c *gc.C) {
 func (s *leaseSuite) TestManagerPeresistsOnClaims(c *gc.C) {
 
 	numWriteCalls := 0
-	s.persistor.WriteTokenFn = func(id string, tok Token) error {
+	s.persistor.ClaimTokenFn = func(namespace, id string, forDur time.Duration) (Token, error) {
 		numWriteCalls++
 
-		c.Assert(tok, gc.NotNil)
-		c.Check(tok.Namespace, gc.Equals, testNamespace)
-		c.Check(tok.Id, gc.Equals, testId)
-		c.Check(id, gc.Equals, testNamespace)
+		c.Check(namespace, gc.Equals, testNamespace)
+		c.Check(id, gc.Equals, testId)
+		c.Check(forDur, gc.Equals, testDuration)
 
-		return nil
+		return Token{namespace, id, time.Now().Add(forDur)}, nil
 	}
 
 	_, err := s.manager.ClaimLease(testNamespace, testId, testDuration)
@@ -399,9 +536,10 @@ func (s *leaseSuite) TestManagerRemovesOnRelease(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 
 	numRemoveCalls := 0
-	s.persistor.RemoveTokenFn = func(id string) error {
+	s.persistor.RemoveTokenFn = func(namespace, id string) error {
 		numRemoveCalls++
-		c.Check(id, gc.Equals, testNamespace)
+		c.Check(namespace, gc.Equals, testNamespace)
+		c.Check(id, gc.Equals, testId)
 		return nil
 	}
 
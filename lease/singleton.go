@@ -64,6 +64,14 @@ func (s *singletonLeaseManager) ReleaseLease(namespace, id string) (err error) {
 	return m.ReleaseLease(namespace, id)
 }
 
+func (s *singletonLeaseManager) TransferLease(namespace, fromId, toId string, forDur time.Duration) error {
+	m, err := s.getLeaseManager()
+	if err != nil {
+		return err
+	}
+	return m.TransferLease(namespace, fromId, toId, forDur)
+}
+
 func (s *singletonLeaseManager) RetrieveLease(namespace string) (Token, error) {
 	m, err := s.getLeaseManager()
 	if err != nil {
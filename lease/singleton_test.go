@@ -4,6 +4,8 @@
 package lease
 
 import (
+	"time"
+
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
@@ -21,9 +23,9 @@ func (s *singletonLeaseSuite) TestSingletonNewLeaseManager(c *gc.C) {
 
 	var called bool
 	manager, err := NewLeaseManager(&stubLeasePersistor{
-		WriteTokenFn: func(string, Token) error {
+		ClaimTokenFn: func(namespace, id string, forDur time.Duration) (Token, error) {
 			called = true
-			return nil
+			return Token{namespace, id, time.Now().Add(forDur)}, nil
 		},
 	})
 	c.Assert(err, jc.ErrorIsNil)
@@ -39,9 +41,9 @@ func (s *singletonLeaseSuite) TestSingletonNewLeaseManager(c *gc.C) {
 func (s *singletonLeaseSuite) TestSingletonSingular(c *gc.C) {
 	var called bool
 	manager, err := NewLeaseManager(&stubLeasePersistor{
-		WriteTokenFn: func(string, Token) error {
+		ClaimTokenFn: func(namespace, id string, forDur time.Duration) (Token, error) {
 			called = true
-			return nil
+			return Token{namespace, id, time.Now().Add(forDur)}, nil
 		},
 	})
 	c.Assert(err, jc.ErrorIsNil)
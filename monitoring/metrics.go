@@ -0,0 +1,317 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package monitoring
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DefaultBuckets holds the upper bounds, in seconds, of the buckets
+// used by histograms that are not given any. They suit the latency of
+// typical API requests.
+var DefaultBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// sampler is implemented by the values held in a metric family.
+type sampler interface {
+	// writeSamples writes the value's samples with the given
+	// metric name and labels.
+	writeSamples(w io.Writer, name string, labelNames, labelValues []string) error
+}
+
+// child holds a value in a metric family and the label values that
+// identify it.
+type child struct {
+	labelValues []string
+	value       sampler
+}
+
+// family holds a set of values of the same kind, distinguished by
+// their label values.
+type family struct {
+	name       string
+	help       string
+	kind       string
+	labelNames []string
+	newValue   func() sampler
+
+	mu       sync.Mutex
+	children map[string]child
+}
+
+func newFamily(name, help, kind string, labelNames []string, newValue func() sampler) *family {
+	return &family{
+		name:       name,
+		help:       help,
+		kind:       kind,
+		labelNames: labelNames,
+		newValue:   newValue,
+		children:   make(map[string]child),
+	}
+}
+
+// Name implements Metric.
+func (f *family) Name() string {
+	return f.name
+}
+
+// with returns the value with the given label values, creating it if
+// necessary. It panics if the number of label values is wrong.
+func (f *family) with(labelValues []string) sampler {
+	if len(labelValues) != len(f.labelNames) {
+		panic(fmt.Sprintf("metric %q has %d labels, got %d values", f.name, len(f.labelNames), len(labelValues)))
+	}
+	key := strings.Join(labelValues, "\xff")
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	c, ok := f.children[key]
+	if !ok {
+		c = child{
+			labelValues: append([]string(nil), labelValues...),
+			value:       f.newValue(),
+		}
+		f.children[key] = c
+	}
+	return c.value
+}
+
+// write implements Metric.
+func (f *family) write(w io.Writer) error {
+	f.mu.Lock()
+	keys := make([]string, 0, len(f.children))
+	for key := range f.children {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	children := make([]child, len(keys))
+	for i, key := range keys {
+		children[i] = f.children[key]
+	}
+	f.mu.Unlock()
+
+	if err := writeHeader(w, f.name, f.help, f.kind); err != nil {
+		return err
+	}
+	for _, c := range children {
+		if err := c.value.writeSamples(w, f.name, f.labelNames, c.labelValues); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Counter holds a value that only ever increases, such as the number
+// of requests served.
+type Counter struct {
+	mu    sync.Mutex
+	value float64
+}
+
+// Inc adds one to the counter.
+func (c *Counter) Inc() {
+	c.Add(1)
+}
+
+// Add adds the given value, which must not be negative, to the counter.
+func (c *Counter) Add(v float64) {
+	if v < 0 {
+		panic("counters cannot decrease")
+	}
+	c.mu.Lock()
+	c.value += v
+	c.mu.Unlock()
+}
+
+// Value returns the current value of the counter.
+func (c *Counter) Value() float64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.value
+}
+
+func (c *Counter) writeSamples(w io.Writer, name string, labelNames, labelValues []string) error {
+	return writeSample(w, name, labelNames, labelValues, c.Value())
+}
+
+// CounterVec is a family of counters distinguished by their labels.
+type CounterVec struct {
+	*family
+}
+
+// NewCounterVec returns a new family of counters with the given name,
+// help text and label names.
+func NewCounterVec(name, help string, labelNames ...string) *CounterVec {
+	return &CounterVec{newFamily(name, help, "counter", labelNames, func() sampler {
+		return &Counter{}
+	})}
+}
+
+// With returns the counter with the given label values, which must be
+// given in the same order as the family's label names.
+func (v *CounterVec) With(labelValues ...string) *Counter {
+	return v.with(labelValues).(*Counter)
+}
+
+// Gauge holds a value that may go up and down, such as the number of
+// open connections.
+type Gauge struct {
+	mu    sync.Mutex
+	value float64
+}
+
+// Set sets the gauge to the given value.
+func (g *Gauge) Set(v float64) {
+	g.mu.Lock()
+	g.value = v
+	g.mu.Unlock()
+}
+
+// Inc adds one to the gauge.
+func (g *Gauge) Inc() {
+	g.Add(1)
+}
+
+// Dec subtracts one from the gauge.
+func (g *Gauge) Dec() {
+	g.Add(-1)
+}
+
+// Add adds the given value to the gauge.
+func (g *Gauge) Add(v float64) {
+	g.mu.Lock()
+	g.value += v
+	g.mu.Unlock()
+}
+
+// Value returns the current value of the gauge.
+func (g *Gauge) Value() float64 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.value
+}
+
+func (g *Gauge) writeSamples(w io.Writer, name string, labelNames, labelValues []string) error {
+	return writeSample(w, name, labelNames, labelValues, g.Value())
+}
+
+// GaugeVec is a family of gauges distinguished by their labels.
+type GaugeVec struct {
+	*family
+}
+
+// NewGaugeVec returns a new family of gauges with the given name,
+// help text and label names.
+func NewGaugeVec(name, help string, labelNames ...string) *GaugeVec {
+	return &GaugeVec{newFamily(name, help, "gauge", labelNames, func() sampler {
+		return &Gauge{}
+	})}
+}
+
+// With returns the gauge with the given label values, which must be
+// given in the same order as the family's label names.
+func (v *GaugeVec) With(labelValues ...string) *Gauge {
+	return v.with(labelValues).(*Gauge)
+}
+
+// Histogram counts observed values, such as request latencies, in
+// buckets of increasing size.
+type Histogram struct {
+	buckets []float64
+
+	mu     sync.Mutex
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+// Observe records the given value.
+func (h *Histogram) Observe(v float64) {
+	// The last bucket, with an upper bound of +Inf, is implicit.
+	i := sort.SearchFloat64s(h.buckets, v)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if i < len(h.buckets) {
+		h.counts[i]++
+	}
+	h.count++
+	h.sum += v
+}
+
+// Count returns the number of values observed.
+func (h *Histogram) Count() uint64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.count
+}
+
+// Sum returns the sum of the values observed.
+func (h *Histogram) Sum() float64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.sum
+}
+
+func (h *Histogram) writeSamples(w io.Writer, name string, labelNames, labelValues []string) error {
+	h.mu.Lock()
+	counts := append([]uint64(nil), h.counts...)
+	count, sum := h.count, h.sum
+	h.mu.Unlock()
+
+	bucketLabelNames := append(append([]string(nil), labelNames...), "le")
+	bucketLabelValues := append(append([]string(nil), labelValues...), "")
+	last := len(bucketLabelValues) - 1
+	var cumulative uint64
+	for i, upperBound := range h.buckets {
+		cumulative += counts[i]
+		bucketLabelValues[last] = formatValue(upperBound)
+		if err := writeSample(w, name+"_bucket", bucketLabelNames, bucketLabelValues, float64(cumulative)); err != nil {
+			return err
+		}
+	}
+	bucketLabelValues[last] = formatValue(math.Inf(1))
+	if err := writeSample(w, name+"_bucket", bucketLabelNames, bucketLabelValues, float64(count)); err != nil {
+		return err
+	}
+	if err := writeSample(w, name+"_sum", labelNames, labelValues, sum); err != nil {
+		return err
+	}
+	return writeSample(w, name+"_count", labelNames, labelValues, float64(count))
+}
+
+// HistogramVec is a family of histograms distinguished by their labels.
+type HistogramVec struct {
+	*family
+}
+
+// NewHistogramVec returns a new family of histograms with the given
+// name, help text, bucket upper bounds and label names. The bucket
+// upper bounds must be in increasing order; if none are given,
+// DefaultBuckets is used.
+func NewHistogramVec(name, help string, buckets []float64, labelNames ...string) *HistogramVec {
+	if len(buckets) == 0 {
+		buckets = DefaultBuckets
+	}
+	for i := 1; i < len(buckets); i++ {
+		if buckets[i] <= buckets[i-1] {
+			panic(fmt.Sprintf("histogram %q buckets are not in increasing order", name))
+		}
+	}
+	buckets = append([]float64(nil), buckets...)
+	return &HistogramVec{newFamily(name, help, "histogram", labelNames, func() sampler {
+		return &Histogram{
+			buckets: buckets,
+			counts:  make([]uint64, len(buckets)),
+		}
+	})}
+}
+
+// With returns the histogram with the given label values, which must
+// be given in the same order as the family's label names.
+func (v *HistogramVec) With(labelValues ...string) *Histogram {
+	return v.with(labelValues).(*Histogram)
+}
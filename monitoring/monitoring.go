@@ -0,0 +1,156 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package monitoring records operational metrics about a running Juju
+// agent, and writes them in the Prometheus text exposition format.
+//
+// Metrics are created once, at package level, by the code they describe
+// and registered with the default registry, for example:
+//
+//	var restarts = monitoring.NewCounterVec(
+//		"juju_worker_restarts_total",
+//		"Number of times each worker has been restarted.",
+//		"worker",
+//	)
+//
+//	func init() {
+//		monitoring.MustRegister(restarts)
+//	}
+//
+// The API server serves the contents of the default registry.
+package monitoring
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/juju/errors"
+)
+
+// ContentType is the HTTP content type of the text written by
+// Registry.Write.
+const ContentType = "text/plain; version=0.0.4"
+
+// Metric is implemented by the metric families defined in this
+// package: CounterVec, GaugeVec and HistogramVec.
+type Metric interface {
+	// Name returns the name of the metric family.
+	Name() string
+
+	// write writes the family's samples in the text format.
+	write(w io.Writer) error
+}
+
+// Registry holds a set of metrics, keyed by name.
+type Registry struct {
+	mu      sync.Mutex
+	metrics map[string]Metric
+}
+
+// NewRegistry returns a new, empty registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		metrics: make(map[string]Metric),
+	}
+}
+
+// Register adds the given metric to the registry. An error satisfying
+// errors.IsAlreadyExists is returned if a metric with the same name has
+// already been registered.
+func (r *Registry) Register(m Metric) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.metrics[m.Name()]; ok {
+		return errors.AlreadyExistsf("metric %q", m.Name())
+	}
+	r.metrics[m.Name()] = m
+	return nil
+}
+
+// Write writes all the registered metrics, sorted by name, to w.
+func (r *Registry) Write(w io.Writer) error {
+	r.mu.Lock()
+	metrics := make([]Metric, 0, len(r.metrics))
+	for _, m := range r.metrics {
+		metrics = append(metrics, m)
+	}
+	r.mu.Unlock()
+	sort.Sort(metricsByName(metrics))
+	for _, m := range metrics {
+		if err := m.write(w); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+type metricsByName []Metric
+
+func (m metricsByName) Len() int           { return len(m) }
+func (m metricsByName) Less(i, j int) bool { return m[i].Name() < m[j].Name() }
+func (m metricsByName) Swap(i, j int)      { m[i], m[j] = m[j], m[i] }
+
+// DefaultRegistry holds the metrics of the running agent.
+var DefaultRegistry = NewRegistry()
+
+// MustRegister adds the given metrics to the default registry. It
+// panics if a metric with the same name has already been registered.
+func MustRegister(metrics ...Metric) {
+	for _, m := range metrics {
+		if err := DefaultRegistry.Register(m); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// writeHeader writes the HELP and TYPE lines that introduce a
+// metric family.
+func writeHeader(w io.Writer, name, help, kind string) error {
+	_, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, helpEscaper.Replace(help), name, kind)
+	return err
+}
+
+// writeSample writes a single sample line. The label names and values
+// must be of the same length.
+func writeSample(w io.Writer, name string, labelNames, labelValues []string, value float64) error {
+	var buf bytes.Buffer
+	buf.WriteString(name)
+	if len(labelNames) > 0 {
+		buf.WriteByte('{')
+		for i, label := range labelNames {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			fmt.Fprintf(&buf, `%s="%s"`, label, labelValueEscaper.Replace(labelValues[i]))
+		}
+		buf.WriteByte('}')
+	}
+	buf.WriteByte(' ')
+	buf.WriteString(formatValue(value))
+	buf.WriteByte('\n')
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+var (
+	helpEscaper       = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+	labelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+)
+
+func formatValue(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	case math.IsNaN(value):
+		return "NaN"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
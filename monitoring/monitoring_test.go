@@ -0,0 +1,141 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package monitoring_test
+
+import (
+	"bytes"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/monitoring"
+)
+
+type MonitoringSuite struct{}
+
+var _ = gc.Suite(&MonitoringSuite{})
+
+func (*MonitoringSuite) write(c *gc.C, registry *monitoring.Registry) string {
+	var buf bytes.Buffer
+	err := registry.Write(&buf)
+	c.Assert(err, jc.ErrorIsNil)
+	return buf.String()
+}
+
+func (s *MonitoringSuite) TestCounterVec(c *gc.C) {
+	registry := monitoring.NewRegistry()
+	requests := monitoring.NewCounterVec("requests_total", "Requests served.", "facade", "method")
+	err := registry.Register(requests)
+	c.Assert(err, jc.ErrorIsNil)
+
+	requests.With("Client", "Status").Inc()
+	requests.With("Client", "Status").Add(2)
+	requests.With("Action", "Enqueue").Inc()
+	c.Assert(requests.With("Client", "Status").Value(), gc.Equals, 3.0)
+
+	c.Assert(s.write(c, registry), gc.Equals, `
+# HELP requests_total Requests served.
+# TYPE requests_total counter
+requests_total{facade="Action",method="Enqueue"} 1
+requests_total{facade="Client",method="Status"} 3
+`[1:])
+}
+
+func (s *MonitoringSuite) TestCounterCannotDecrease(c *gc.C) {
+	counter := monitoring.NewCounterVec("total", "Total.").With()
+	c.Assert(func() { counter.Add(-1) }, gc.PanicMatches, "counters cannot decrease")
+}
+
+func (s *MonitoringSuite) TestGaugeVec(c *gc.C) {
+	registry := monitoring.NewRegistry()
+	connections := monitoring.NewGaugeVec("connections", "Open connections.")
+	err := registry.Register(connections)
+	c.Assert(err, jc.ErrorIsNil)
+
+	connections.With().Inc()
+	connections.With().Inc()
+	connections.With().Dec()
+	c.Assert(connections.With().Value(), gc.Equals, 1.0)
+	connections.With().Set(5)
+
+	c.Assert(s.write(c, registry), gc.Equals, `
+# HELP connections Open connections.
+# TYPE connections gauge
+connections 5
+`[1:])
+}
+
+func (s *MonitoringSuite) TestHistogramVec(c *gc.C) {
+	registry := monitoring.NewRegistry()
+	latency := monitoring.NewHistogramVec("latency_seconds", "Request latency.", []float64{0.1, 1}, "method")
+	err := registry.Register(latency)
+	c.Assert(err, jc.ErrorIsNil)
+
+	latency.With("Status").Observe(0.05)
+	latency.With("Status").Observe(0.1)
+	latency.With("Status").Observe(0.5)
+	latency.With("Status").Observe(2)
+	c.Assert(latency.With("Status").Count(), gc.Equals, uint64(4))
+	c.Assert(latency.With("Status").Sum(), gc.Equals, 2.65)
+
+	c.Assert(s.write(c, registry), gc.Equals, `
+# HELP latency_seconds Request latency.
+# TYPE latency_seconds histogram
+latency_seconds_bucket{method="Status",le="0.1"} 2
+latency_seconds_bucket{method="Status",le="1"} 3
+latency_seconds_bucket{method="Status",le="+Inf"} 4
+latency_seconds_sum{method="Status"} 2.65
+latency_seconds_count{method="Status"} 4
+`[1:])
+}
+
+func (s *MonitoringSuite) TestHistogramBucketsMustIncrease(c *gc.C) {
+	c.Assert(func() {
+		monitoring.NewHistogramVec("latency_seconds", "Latency.", []float64{1, 0.1})
+	}, gc.PanicMatches, `histogram "latency_seconds" buckets are not in increasing order`)
+}
+
+func (s *MonitoringSuite) TestWrongNumberOfLabelValues(c *gc.C) {
+	requests := monitoring.NewCounterVec("requests_total", "Requests.", "facade", "method")
+	c.Assert(func() { requests.With("Client") }, gc.PanicMatches, `metric "requests_total" has 2 labels, got 1 values`)
+}
+
+func (s *MonitoringSuite) TestEscaping(c *gc.C) {
+	registry := monitoring.NewRegistry()
+	errs := monitoring.NewCounterVec("errors_total", "Errors,\nby \\ message.", "message")
+	err := registry.Register(errs)
+	c.Assert(err, jc.ErrorIsNil)
+	errs.With("a \"b\"\nc\\d").Inc()
+
+	c.Assert(s.write(c, registry), gc.Equals, `
+# HELP errors_total Errors,\nby \\ message.
+# TYPE errors_total counter
+errors_total{message="a \"b\"\nc\\d"} 1
+`[1:])
+}
+
+func (s *MonitoringSuite) TestRegistryWritesMetricsByName(c *gc.C) {
+	registry := monitoring.NewRegistry()
+	err := registry.Register(monitoring.NewGaugeVec("b", "B."))
+	c.Assert(err, jc.ErrorIsNil)
+	err = registry.Register(monitoring.NewGaugeVec("a", "A."))
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.write(c, registry), gc.Equals, `
+# HELP a A.
+# TYPE a gauge
+# HELP b B.
+# TYPE b gauge
+`[1:])
+}
+
+func (s *MonitoringSuite) TestRegisterDuplicate(c *gc.C) {
+	registry := monitoring.NewRegistry()
+	err := registry.Register(monitoring.NewGaugeVec("a", "A."))
+	c.Assert(err, jc.ErrorIsNil)
+	err = registry.Register(monitoring.NewCounterVec("a", "A."))
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+	c.Assert(err, gc.ErrorMatches, `metric "a" already exists`)
+}
@@ -0,0 +1,116 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package network
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// AnySourceCIDR is the source CIDR of an ingress rule that admits
+// connections from any address.
+const AnySourceCIDR = "0.0.0.0/0"
+
+// IngressRule represents a port range opened to connections from a
+// set of source CIDRs.
+type IngressRule struct {
+	PortRange
+	SourceCIDRs []string
+}
+
+// NewIngressRule returns an IngressRule for the port range, open to
+// the given source CIDRs. When no source CIDRs are given the rule
+// admits connections from any address.
+func NewIngressRule(portRange PortRange, sourceCIDRs ...string) (IngressRule, error) {
+	rule := IngressRule{PortRange: portRange}
+	if len(sourceCIDRs) == 0 {
+		sourceCIDRs = []string{AnySourceCIDR}
+	}
+	for _, cidr := range sourceCIDRs {
+		if err := ValidateCIDR(cidr); err != nil {
+			return rule, errors.Trace(err)
+		}
+	}
+	rule.SourceCIDRs = append([]string(nil), sourceCIDRs...)
+	sort.Strings(rule.SourceCIDRs)
+	return rule, rule.PortRange.Validate()
+}
+
+// MustNewIngressRule returns an IngressRule for the port range and
+// source CIDRs, and panics if they are invalid.
+func MustNewIngressRule(portRange string, sourceCIDRs ...string) IngressRule {
+	rule, err := NewIngressRule(MustParsePortRange(portRange), sourceCIDRs...)
+	if err != nil {
+		panic(err)
+	}
+	return rule
+}
+
+// IsUnrestricted reports whether the rule admits connections from
+// any address.
+func (r IngressRule) IsUnrestricted() bool {
+	for _, cidr := range r.SourceCIDRs {
+		if cidr == AnySourceCIDR {
+			return true
+		}
+	}
+	return false
+}
+
+func (r IngressRule) String() string {
+	return fmt.Sprintf("%s from %s", r.PortRange, strings.Join(r.SourceCIDRs, ","))
+}
+
+func (r IngressRule) GoString() string {
+	return r.String()
+}
+
+type ingressRuleSlice []IngressRule
+
+func (r ingressRuleSlice) Len() int      { return len(r) }
+func (r ingressRuleSlice) Swap(i, j int) { r[i], r[j] = r[j], r[i] }
+func (r ingressRuleSlice) Less(i, j int) bool {
+	if r[i].PortRange != r[j].PortRange {
+		return portRangeSlice{r[i].PortRange, r[j].PortRange}.Less(0, 1)
+	}
+	return strings.Join(r[i].SourceCIDRs, ",") < strings.Join(r[j].SourceCIDRs, ",")
+}
+
+// SortIngressRules sorts the given rules, first by port range, then by
+// source CIDRs.
+func SortIngressRules(rules []IngressRule) {
+	sort.Sort(ingressRuleSlice(rules))
+}
+
+// ValidateCIDR returns an error if cidr is not an IPv4 network in
+// CIDR notation.
+func ValidateCIDR(cidr string) error {
+	ip, _, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return errors.NotValidf("CIDR %q", cidr)
+	}
+	if ip.To4() == nil {
+		return errors.NotValidf("CIDR %q (only IPv4 is supported)", cidr)
+	}
+	return nil
+}
+
+// ParseCIDRs splits the provided string on commas and validates each
+// CIDR in it. Whitespace is ignored.
+// Example strings: "10.0.0.0/8", "10.0.0.0/8, 192.168.1.0/24".
+func ParseCIDRs(inCIDRs string) ([]string, error) {
+	var cidrs []string
+	for _, cidr := range strings.Split(inCIDRs, ",") {
+		cidr = strings.TrimSpace(cidr)
+		if err := ValidateCIDR(cidr); err != nil {
+			return nil, errors.Trace(err)
+		}
+		cidrs = append(cidrs, cidr)
+	}
+	return cidrs, nil
+}
@@ -0,0 +1,68 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package network_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/testing"
+)
+
+type IngressRuleSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&IngressRuleSuite{})
+
+func (*IngressRuleSuite) TestNewIngressRule(c *gc.C) {
+	rule, err := network.NewIngressRule(network.MustParsePortRange("80/tcp"), "192.168.1.0/24", "10.0.0.0/8")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rule.PortRange, gc.Equals, network.PortRange{80, 80, "tcp"})
+	c.Assert(rule.SourceCIDRs, jc.DeepEquals, []string{"10.0.0.0/8", "192.168.1.0/24"})
+	c.Assert(rule.IsUnrestricted(), jc.IsFalse)
+	c.Assert(rule.String(), gc.Equals, "80/tcp from 10.0.0.0/8,192.168.1.0/24")
+}
+
+func (*IngressRuleSuite) TestNewIngressRuleDefaultsToAnySource(c *gc.C) {
+	rule, err := network.NewIngressRule(network.MustParsePortRange("8000-8080/udp"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rule.SourceCIDRs, jc.DeepEquals, []string{network.AnySourceCIDR})
+	c.Assert(rule.IsUnrestricted(), jc.IsTrue)
+}
+
+func (*IngressRuleSuite) TestNewIngressRuleInvalid(c *gc.C) {
+	_, err := network.NewIngressRule(network.MustParsePortRange("80/tcp"), "10.0.0.0")
+	c.Assert(err, gc.ErrorMatches, `CIDR "10.0.0.0" not valid`)
+	_, err = network.NewIngressRule(network.MustParsePortRange("80/tcp"), "2001:db8::/32")
+	c.Assert(err, gc.ErrorMatches, `CIDR "2001:db8::/32" \(only IPv4 is supported\) not valid`)
+	_, err = network.NewIngressRule(network.PortRange{80, 70, "tcp"}, "10.0.0.0/8")
+	c.Assert(err, gc.ErrorMatches, "invalid port range 80-70/tcp")
+}
+
+func (*IngressRuleSuite) TestSortIngressRules(c *gc.C) {
+	rules := []network.IngressRule{
+		network.MustNewIngressRule("80/tcp", "192.168.1.0/24"),
+		network.MustNewIngressRule("80/tcp", "10.0.0.0/8"),
+		network.MustNewIngressRule("53/udp"),
+		network.MustNewIngressRule("22/tcp"),
+	}
+	network.SortIngressRules(rules)
+	c.Assert(rules, jc.DeepEquals, []network.IngressRule{
+		network.MustNewIngressRule("22/tcp"),
+		network.MustNewIngressRule("80/tcp", "10.0.0.0/8"),
+		network.MustNewIngressRule("80/tcp", "192.168.1.0/24"),
+		network.MustNewIngressRule("53/udp"),
+	})
+}
+
+func (*IngressRuleSuite) TestParseCIDRs(c *gc.C) {
+	cidrs, err := network.ParseCIDRs("10.0.0.0/8, 192.168.1.0/24")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cidrs, jc.DeepEquals, []string{"10.0.0.0/8", "192.168.1.0/24"})
+
+	_, err = network.ParseCIDRs("10.0.0.0/8,bad")
+	c.Assert(err, gc.ErrorMatches, `CIDR "bad" not valid`)
+}
@@ -0,0 +1,17 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package network
+
+import (
+	"regexp"
+)
+
+// validSpaceName matches space names: lowercase letters and digits,
+// in groups separated by single hyphens.
+var validSpaceName = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// IsValidSpaceName returns whether name is a valid name for a space.
+func IsValidSpaceName(name string) bool {
+	return validSpaceName.MatchString(name)
+}
@@ -567,6 +567,8 @@ func (env *azureEnviron) selectInstanceTypeAndImage(constraint *instances.Instan
 var unsupportedConstraints = []string{
 	constraints.CpuPower,
 	constraints.Tags,
+	constraints.Spot,
+	constraints.SpotPrice,
 }
 
 // ConstraintsValidator is defined on the Environs interface.
@@ -13,3 +13,15 @@ type SupportsUnitPlacementPolicy struct{}
 func (*SupportsUnitPlacementPolicy) SupportsUnitPlacement() error {
 	return nil
 }
+
+// SupportsSubnetPlacementPolicy provides an
+// implementation of SupportsSubnetPlacement
+// that never returns an error, and is
+// intended for embedding in environs.Environ
+// implementations that honour
+// StartInstanceParams.SubnetsToZones.
+type SupportsSubnetPlacementPolicy struct{}
+
+func (*SupportsSubnetPlacementPolicy) SupportsSubnetPlacement() error {
+	return nil
+}
@@ -255,6 +255,7 @@ type environState struct {
 	maxAddr      int // maximum allocated address last byte
 	insts        map[instance.Id]*dummyInstance
 	globalPorts  map[network.PortRange]bool
+	globalRules  map[ingressKey]bool
 	bootstrapped bool
 	storageDelay time.Duration
 	storage      *storageServer
@@ -269,6 +270,7 @@ type environState struct {
 // state.
 type environ struct {
 	common.SupportsUnitPlacementPolicy
+	common.SupportsSubnetPlacementPolicy
 
 	name         string
 	ecfgMutex    sync.Mutex
@@ -276,6 +278,8 @@ type environ struct {
 }
 
 var _ environs.Environ = (*environ)(nil)
+var _ environs.IngressFirewall = (*environ)(nil)
+var _ environs.InstanceIngressFirewall = (*dummyInstance)(nil)
 
 // discardOperations discards all Operations written to it.
 var discardOperations chan<- Operation
@@ -370,6 +374,7 @@ func newState(name string, ops chan<- Operation, policy state.Policy) *environSt
 		statePolicy: policy,
 		insts:       make(map[instance.Id]*dummyInstance),
 		globalPorts: make(map[network.PortRange]bool),
+		globalRules: make(map[ingressKey]bool),
 	}
 	s.storage = newStorageServer(s, "/"+name+"/private")
 	s.listenStorage()
@@ -692,6 +697,7 @@ func (e *environ) Bootstrap(ctx environs.BootstrapContext, args environs.Bootstr
 		id:           BootstrapInstanceId,
 		addresses:    network.NewAddresses("localhost"),
 		ports:        make(map[network.PortRange]bool),
+		rules:        make(map[ingressKey]bool),
 		machineId:    agent.BootstrapMachineId,
 		series:       series,
 		firewallMode: e.Config().FirewallMode(),
@@ -884,6 +890,7 @@ func (e *environ) StartInstance(args environs.StartInstanceParams) (*environs.St
 		id:           instance.Id(idString),
 		addresses:    addrs,
 		ports:        make(map[network.PortRange]bool),
+		rules:        make(map[ingressKey]bool),
 		machineId:    machineId,
 		series:       series,
 		firewallMode: e.Config().FirewallMode(),
@@ -1345,6 +1352,103 @@ func (e *environ) Ports() (ports []network.PortRange, err error) {
 	return
 }
 
+// ingressKey identifies a port range opened to a single source CIDR.
+type ingressKey struct {
+	portRange network.PortRange
+	cidr      string
+}
+
+// addIngressRules records the rules, split by source CIDR. Rules
+// open to any address are recorded as plain open ports.
+func addIngressRules(ports map[network.PortRange]bool, keys map[ingressKey]bool, rules []network.IngressRule) {
+	for _, rule := range rules {
+		for _, cidr := range rule.SourceCIDRs {
+			if cidr == network.AnySourceCIDR {
+				ports[rule.PortRange] = true
+			} else {
+				keys[ingressKey{rule.PortRange, cidr}] = true
+			}
+		}
+	}
+}
+
+// removeIngressRules forgets the rules recorded by addIngressRules.
+func removeIngressRules(ports map[network.PortRange]bool, keys map[ingressKey]bool, rules []network.IngressRule) {
+	for _, rule := range rules {
+		for _, cidr := range rule.SourceCIDRs {
+			if cidr == network.AnySourceCIDR {
+				delete(ports, rule.PortRange)
+			} else {
+				delete(keys, ingressKey{rule.PortRange, cidr})
+			}
+		}
+	}
+}
+
+// ingressRules returns the rules recorded by addIngressRules, one for
+// each source CIDR.
+func ingressRules(ports map[network.PortRange]bool, keys map[ingressKey]bool) []network.IngressRule {
+	var rules []network.IngressRule
+	for p := range ports {
+		rules = append(rules, network.IngressRule{
+			PortRange:   p,
+			SourceCIDRs: []string{network.AnySourceCIDR},
+		})
+	}
+	for key := range keys {
+		rules = append(rules, network.IngressRule{
+			PortRange:   key.portRange,
+			SourceCIDRs: []string{key.cidr},
+		})
+	}
+	network.SortIngressRules(rules)
+	return rules
+}
+
+// OpenIngressRules is specified in the environs.IngressFirewall interface.
+func (e *environ) OpenIngressRules(rules []network.IngressRule) error {
+	if mode := e.ecfg().FirewallMode(); mode != config.FwGlobal {
+		return fmt.Errorf("invalid firewall mode %q for opening ingress rules on environment", mode)
+	}
+	estate, err := e.state()
+	if err != nil {
+		return err
+	}
+	estate.mu.Lock()
+	defer estate.mu.Unlock()
+	addIngressRules(estate.globalPorts, estate.globalRules, rules)
+	return nil
+}
+
+// CloseIngressRules is specified in the environs.IngressFirewall interface.
+func (e *environ) CloseIngressRules(rules []network.IngressRule) error {
+	if mode := e.ecfg().FirewallMode(); mode != config.FwGlobal {
+		return fmt.Errorf("invalid firewall mode %q for closing ingress rules on environment", mode)
+	}
+	estate, err := e.state()
+	if err != nil {
+		return err
+	}
+	estate.mu.Lock()
+	defer estate.mu.Unlock()
+	removeIngressRules(estate.globalPorts, estate.globalRules, rules)
+	return nil
+}
+
+// IngressRules is specified in the environs.IngressFirewall interface.
+func (e *environ) IngressRules() ([]network.IngressRule, error) {
+	if mode := e.ecfg().FirewallMode(); mode != config.FwGlobal {
+		return nil, fmt.Errorf("invalid firewall mode %q for retrieving ingress rules from environment", mode)
+	}
+	estate, err := e.state()
+	if err != nil {
+		return nil, err
+	}
+	estate.mu.Lock()
+	defer estate.mu.Unlock()
+	return ingressRules(estate.globalPorts, estate.globalRules), nil
+}
+
 func (*environ) Provider() environs.EnvironProvider {
 	return &providerInstance
 }
@@ -1352,6 +1456,7 @@ func (*environ) Provider() environs.EnvironProvider {
 type dummyInstance struct {
 	state        *environState
 	ports        map[network.PortRange]bool
+	rules        map[ingressKey]bool
 	id           instance.Id
 	status       string
 	machineId    string
@@ -1465,6 +1570,56 @@ func (inst *dummyInstance) Ports(machineId string) (ports []network.PortRange, e
 	return
 }
 
+// OpenIngressRules is specified in the environs.InstanceIngressFirewall
+// interface.
+func (inst *dummyInstance) OpenIngressRules(machineId string, rules []network.IngressRule) error {
+	defer delay()
+	if inst.firewallMode != config.FwInstance {
+		return fmt.Errorf("invalid firewall mode %q for opening ingress rules on instance",
+			inst.firewallMode)
+	}
+	if inst.machineId != machineId {
+		panic(fmt.Errorf("OpenIngressRules with mismatched machine id, expected %q got %q", inst.machineId, machineId))
+	}
+	inst.state.mu.Lock()
+	defer inst.state.mu.Unlock()
+	addIngressRules(inst.ports, inst.rules, rules)
+	return nil
+}
+
+// CloseIngressRules is specified in the environs.InstanceIngressFirewall
+// interface.
+func (inst *dummyInstance) CloseIngressRules(machineId string, rules []network.IngressRule) error {
+	defer delay()
+	if inst.firewallMode != config.FwInstance {
+		return fmt.Errorf("invalid firewall mode %q for closing ingress rules on instance",
+			inst.firewallMode)
+	}
+	if inst.machineId != machineId {
+		panic(fmt.Errorf("CloseIngressRules with mismatched machine id, expected %q got %q", inst.machineId, machineId))
+	}
+	inst.state.mu.Lock()
+	defer inst.state.mu.Unlock()
+	removeIngressRules(inst.ports, inst.rules, rules)
+	return nil
+}
+
+// IngressRules is specified in the environs.InstanceIngressFirewall
+// interface.
+func (inst *dummyInstance) IngressRules(machineId string) ([]network.IngressRule, error) {
+	defer delay()
+	if inst.firewallMode != config.FwInstance {
+		return nil, fmt.Errorf("invalid firewall mode %q for retrieving ingress rules from instance",
+			inst.firewallMode)
+	}
+	if inst.machineId != machineId {
+		panic(fmt.Errorf("IngressRules with mismatched machine id, expected %q got %q", inst.machineId, machineId))
+	}
+	inst.state.mu.Lock()
+	defer inst.state.mu.Unlock()
+	return ingressRules(inst.ports, inst.rules), nil
+}
+
 // providerDelay controls the delay before dummy responds.
 // non empty values in JUJU_DUMMY_DELAY will be parsed as
 // time.Durations into this value.
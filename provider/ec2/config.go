@@ -31,6 +31,13 @@ amazon:
     #
     # secret-key: <secret>
 
+    # vpc-id names an existing VPC in which instances and security
+    # groups are created. The VPC must have an internet gateway and
+    # at least one available subnet. It defaults to the account's
+    # default VPC, or EC2-Classic when there is none.
+    #
+    # vpc-id: vpc-0123abcd
+
     # image-stream chooses a simplestreams stream from which to select
     # OS images, for example daily or released images (or any other stream
     # available on simplestreams).
@@ -64,6 +71,7 @@ var configFields = schema.Fields{
 	"secret-key":     schema.String(),
 	"region":         schema.String(),
 	"control-bucket": schema.String(),
+	"vpc-id":         schema.String(),
 }
 
 var configDefaults = schema.Defaults{
@@ -71,6 +79,7 @@ var configDefaults = schema.Defaults{
 	"secret-key":     "",
 	"region":         "us-east-1",
 	"control-bucket": "",
+	"vpc-id":         "",
 }
 
 type environConfig struct {
@@ -86,6 +95,10 @@ func (c *environConfig) controlBucket() string {
 	return c.attrs["control-bucket"].(string)
 }
 
+func (c *environConfig) vpcID() string {
+	return c.attrs["vpc-id"].(string)
+}
+
 func (c *environConfig) accessKey() string {
 	return c.attrs["access-key"].(string)
 }
@@ -146,6 +159,9 @@ func validateConfig(cfg, old *config.Config) (*environConfig, error) {
 		if bucket, _ := attrs["control-bucket"].(string); ecfg.controlBucket() != bucket {
 			return nil, fmt.Errorf("cannot change control-bucket from %q to %q", bucket, ecfg.controlBucket())
 		}
+		if vpcID, _ := attrs["vpc-id"].(string); ecfg.vpcID() != vpcID {
+			return nil, fmt.Errorf("cannot change vpc-id from %q to %q", vpcID, ecfg.vpcID())
+		}
 	}
 
 	// ssl-hostname-verification cannot be disabled
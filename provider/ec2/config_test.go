@@ -179,6 +179,23 @@ var configTests = []configTest{
 			"control-bucket": "new-x",
 		},
 		err: `.*cannot change control-bucket from "x" to "new-x"`,
+	}, {
+		config: attrs{
+			"vpc-id": "vpc-abcd",
+		},
+		expect: attrs{
+			"vpc-id": "vpc-abcd",
+		},
+	}, {
+		config: attrs{
+			"vpc-id": 666,
+		},
+		err: `.*expected string, got int\(666\)`,
+	}, {
+		change: attrs{
+			"vpc-id": "vpc-abcd",
+		},
+		err: `.*cannot change vpc-id from "" to "vpc-abcd"`,
 	}, {
 		config: attrs{
 			"access-key": "jujuer",
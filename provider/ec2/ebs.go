@@ -4,7 +4,9 @@
 package ec2
 
 import (
+	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -52,6 +54,7 @@ const (
 	volumeInUse        = "VolumeInUse"
 	attachmentNotFound = "InvalidAttachment.NotFound"
 	incorrectState     = "IncorrectState"
+	snapshotNotFound   = "InvalidSnapshot.NotFound"
 )
 
 const (
@@ -265,6 +268,7 @@ func (v *ebsVolumeSource) CreateVolumes(params []storage.VolumeParams) (_ []stor
 		instId := string(p.Attachment.InstanceId)
 		vol, persistent, _ := parseVolumeOptions(p.Size, p.Attributes)
 		vol.AvailZone = instances[instId].AvailZone
+		vol.SnapshotId = p.Snapshot
 		resp, err := v.ec2.CreateVolume(vol)
 		if err != nil {
 			return nil, nil, err
@@ -572,6 +576,95 @@ func (v *ebsVolumeSource) DetachVolumes(attachParams []storage.VolumeAttachmentP
 	return nil
 }
 
+// ResizeVolumes is specified on the storage.VolumeSource interface.
+//
+// EBS volumes cannot be resized while attached: the EC2 client offers
+// no way to change the size of an existing volume, only to create a
+// new, larger volume from a snapshot of it.
+func (v *ebsVolumeSource) ResizeVolumes(params []storage.VolumeResizeParams) ([]uint64, []error) {
+	errs := make([]error, len(params))
+	for i, p := range params {
+		errs[i] = errors.NotSupportedf("resizing EBS volume %v", p.VolumeId)
+	}
+	return make([]uint64, len(params)), errs
+}
+
+// CreateVolumeSnapshots is specified on the storage.VolumeSource interface.
+func (v *ebsVolumeSource) CreateVolumeSnapshots(params []storage.VolumeSnapshotParams) ([]storage.VolumeSnapshot, error) {
+	snapshots := make([]storage.VolumeSnapshot, 0, len(params))
+	for _, p := range params {
+		description := fmt.Sprintf("juju-%s snapshot %s of %s", v.envName, p.Snapshot, p.Volume.Id())
+		resp, err := v.ec2.CreateSnapshot(p.VolumeId, description)
+		if err != nil {
+			return nil, errors.Annotatef(err, "creating snapshot of %v", p.VolumeId)
+		}
+		info, err := snapshotInfo(resp.Snapshot)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		snapshots = append(snapshots, storage.VolumeSnapshot{p.Snapshot, info})
+
+		resourceTags := make(map[string]string)
+		for k, v := range p.ResourceTags {
+			resourceTags[k] = v
+		}
+		resourceTags[tagName] = fmt.Sprintf("juju-%s-snapshot-%s", v.envName, p.Snapshot)
+		if err := tagResources(v.ec2, resourceTags, info.SnapshotId); err != nil {
+			return nil, errors.Annotate(err, "tagging snapshot")
+		}
+	}
+	return snapshots, nil
+}
+
+// ListVolumeSnapshots is specified on the storage.VolumeSource interface.
+func (v *ebsVolumeSource) ListVolumeSnapshots(volumeId string) ([]storage.VolumeSnapshotInfo, error) {
+	filter := ec2.NewFilter()
+	filter.Add("volume-id", volumeId)
+	resp, err := v.ec2.Snapshots(nil, filter)
+	if err != nil {
+		return nil, errors.Annotatef(err, "querying snapshots of %v", volumeId)
+	}
+	snapshots := make([]storage.VolumeSnapshotInfo, len(resp.Snapshots))
+	for i, snapshot := range resp.Snapshots {
+		snapshots[i], err = snapshotInfo(snapshot)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return snapshots, nil
+}
+
+// DestroyVolumeSnapshots is specified on the storage.VolumeSource interface.
+func (v *ebsVolumeSource) DestroyVolumeSnapshots(snapshotIds []string) []error {
+	results := make([]error, len(snapshotIds))
+	for i, snapshotId := range snapshotIds {
+		if _, err := v.ec2.DeleteSnapshots(snapshotId); err != nil {
+			if ec2Err, ok := err.(*ec2.Error); ok && ec2Err.Code == snapshotNotFound {
+				// The snapshot has already been destroyed.
+				continue
+			}
+			results[i] = errors.Annotatef(err, "destroying %q", snapshotId)
+		}
+	}
+	return results
+}
+
+// snapshotInfo converts an EBS snapshot to a storage.VolumeSnapshotInfo.
+func snapshotInfo(snapshot ec2.Snapshot) (storage.VolumeSnapshotInfo, error) {
+	// EC2 reports the volume size of snapshots as a string, in GiB.
+	sizeGiB, err := strconv.ParseUint(snapshot.VolumeSize, 10, 64)
+	if err != nil {
+		return storage.VolumeSnapshotInfo{}, errors.Annotatef(
+			err, "parsing size of snapshot %v", snapshot.Id,
+		)
+	}
+	return storage.VolumeSnapshotInfo{
+		SnapshotId: snapshot.Id,
+		VolumeId:   snapshot.VolumeId,
+		Size:       gibToMib(sizeGiB),
+	}, nil
+}
+
 var errTooManyVolumes = errors.New("too many EBS volumes to attach")
 
 // blockDeviceNamer returns a function that cycles through block device names.
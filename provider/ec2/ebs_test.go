@@ -515,6 +515,94 @@ func (s *ebsVolumeSuite) TestDetachVolumes(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *ebsVolumeSuite) TestResizeVolumes(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	_, errs := vs.ResizeVolumes([]storage.VolumeResizeParams{{
+		Volume:   names.NewVolumeTag("0"),
+		VolumeId: "vol-0",
+		Size:     20 * 1024,
+	}})
+	c.Assert(errs, gc.HasLen, 1)
+	c.Assert(errs[0], jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *ebsVolumeSuite) createVolumeSnapshot(c *gc.C, vs storage.VolumeSource) storage.VolumeSnapshot {
+	snapshots, err := vs.CreateVolumeSnapshots([]storage.VolumeSnapshotParams{{
+		Snapshot: "0",
+		Volume:   names.NewVolumeTag("0"),
+		VolumeId: "vol-0",
+		Provider: ec2.EBS_ProviderType,
+		ResourceTags: map[string]string{
+			"abc": "123",
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshots, gc.HasLen, 1)
+	return snapshots[0]
+}
+
+func (s *ebsVolumeSuite) TestCreateVolumeSnapshots(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	s.assertCreateVolumes(c, vs, "")
+
+	snapshot := s.createVolumeSnapshot(c, vs)
+	c.Assert(snapshot.Snapshot, gc.Equals, "0")
+	c.Assert(snapshot.SnapshotId, gc.Not(gc.Equals), "")
+	c.Assert(snapshot.VolumeId, gc.Equals, "vol-0")
+	c.Assert(snapshot.Size, gc.Equals, uint64(10240))
+
+	ec2Client := ec2.StorageEC2(vs)
+	resp, err := ec2Client.Snapshots([]string{snapshot.SnapshotId}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resp.Snapshots, gc.HasLen, 1)
+	c.Assert(resp.Snapshots[0].Tags, jc.SameContents, []awsec2.Tag{
+		{"Name", "juju-sample-snapshot-0"},
+		{"abc", "123"},
+	})
+}
+
+func (s *ebsVolumeSuite) TestCreateVolumeSnapshotsUnknownVolume(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	_, err := vs.CreateVolumeSnapshots([]storage.VolumeSnapshotParams{{
+		Snapshot: "0",
+		Volume:   names.NewVolumeTag("0"),
+		VolumeId: "vol-42",
+		Provider: ec2.EBS_ProviderType,
+	}})
+	c.Assert(err, gc.ErrorMatches, "creating snapshot of vol-42: .*")
+}
+
+func (s *ebsVolumeSuite) TestListVolumeSnapshots(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	s.assertCreateVolumes(c, vs, "")
+	snapshot := s.createVolumeSnapshot(c, vs)
+
+	snapshots, err := vs.ListVolumeSnapshots("vol-0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshots, jc.DeepEquals, []storage.VolumeSnapshotInfo{snapshot.VolumeSnapshotInfo})
+
+	snapshots, err = vs.ListVolumeSnapshots("vol-1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshots, gc.HasLen, 0)
+}
+
+func (s *ebsVolumeSuite) TestDestroyVolumeSnapshots(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	s.assertCreateVolumes(c, vs, "")
+	snapshot := s.createVolumeSnapshot(c, vs)
+
+	errs := vs.DestroyVolumeSnapshots([]string{snapshot.SnapshotId})
+	c.Assert(errs, jc.DeepEquals, []error{nil})
+	snapshots, err := vs.ListVolumeSnapshots("vol-0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshots, gc.HasLen, 0)
+
+	// Destroying an unknown snapshot is not an error: it has
+	// already been destroyed.
+	errs = vs.DestroyVolumeSnapshots([]string{snapshot.SnapshotId, "snap-42"})
+	c.Assert(errs, jc.DeepEquals, []error{nil, nil})
+}
+
 type blockDeviceMappingSuite struct {
 	testing.BaseSuite
 }
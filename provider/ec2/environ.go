@@ -6,6 +6,7 @@ package ec2
 import (
 	"fmt"
 	"net"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -55,6 +56,7 @@ var AssignPrivateIPAddress = assignPrivateIPAddress
 
 type environ struct {
 	common.SupportsUnitPlacementPolicy
+	common.SupportsSubnetPlacementPolicy
 
 	name string
 
@@ -80,6 +82,7 @@ type environ struct {
 
 // Ensure EC2 provider supports environs.NetworkingEnviron.
 var _ environs.NetworkingEnviron = (*environ)(nil)
+var _ environs.IngressFirewall = (*environ)(nil)
 var _ simplestreams.HasRegion = (*environ)(nil)
 var _ state.Prechecker = (*environ)(nil)
 var _ state.InstanceDistributor = (*environ)(nil)
@@ -197,6 +200,11 @@ func (e *environ) Storage() envstorage.Storage {
 }
 
 func (e *environ) Bootstrap(ctx environs.BootstrapContext, args environs.BootstrapParams) (arch, series string, _ environs.BootstrapFinalizer, _ error) {
+	if vpcID := e.ecfg().vpcID(); vpcID != "" {
+		if err := validateVPC(e.ec2(), vpcID); err != nil {
+			return "", "", nil, errors.Annotate(err, "invalid vpc-id")
+		}
+	}
 	return common.Bootstrap(ctx, e, args)
 }
 
@@ -433,8 +441,36 @@ func resourceName(tag names.Tag, envName string) string {
 	return fmt.Sprintf("juju-%s-%s", envName, tag)
 }
 
+// subnetsByZone returns, for each availability zone, the id of the
+// first of the given subnets in that zone. Subnets in no known zone
+// cannot be chosen, and are logged.
+func subnetsByZone(subnetsToZones map[network.Id][]string) map[string]string {
+	subnetIds := make([]string, 0, len(subnetsToZones))
+	for subnetId := range subnetsToZones {
+		subnetIds = append(subnetIds, string(subnetId))
+	}
+	sort.Strings(subnetIds)
+	zoneSubnets := make(map[string]string)
+	for _, subnetId := range subnetIds {
+		zones := subnetsToZones[network.Id(subnetId)]
+		if len(zones) == 0 {
+			logger.Warningf("ignoring subnet %q: no availability zone", subnetId)
+			continue
+		}
+		for _, zone := range zones {
+			if _, ok := zoneSubnets[zone]; !ok {
+				zoneSubnets[zone] = subnetId
+			}
+		}
+	}
+	return zoneSubnets
+}
+
 // StartInstance is specified in the InstanceBroker interface.
 func (e *environ) StartInstance(args environs.StartInstanceParams) (_ *environs.StartInstanceResult, resultErr error) {
+	defer func() {
+		resultErr = maybeConvertCredentialError(resultErr)
+	}()
 	var inst *ec2Instance
 	defer func() {
 		if resultErr == nil || inst == nil {
@@ -481,6 +517,46 @@ func (e *environ) StartInstance(args environs.StartInstanceParams) (_ *environs.
 		}
 	}
 
+	// If the units to be hosted have endpoints bound to spaces, the
+	// instance must be started in one of the spaces' subnets, so only
+	// the zones containing those subnets may be used. In a configured
+	// VPC, those subnets must be in the VPC; without bound spaces, the
+	// instance is started in one of the VPC's subnets.
+	var zoneSubnets map[string]string
+	subnetsToZones := args.SubnetsToZones
+	vpcID := e.ecfg().vpcID()
+	if vpcID != "" {
+		vpcSubnets, err := vpcSubnetsToZones(e.ec2(), vpcID)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if len(subnetsToZones) > 0 {
+			if err := checkSubnetsInVPC(subnetsToZones, vpcSubnets, vpcID); err != nil {
+				return nil, errors.Trace(err)
+			}
+		} else {
+			subnetsToZones = vpcSubnets
+		}
+	}
+	if len(subnetsToZones) > 0 {
+		zoneSubnets = subnetsByZone(subnetsToZones)
+		zones := zonesWithSubnets(availabilityZones, zoneSubnets)
+		if len(zones) == 0 && len(args.SubnetsToZones) > 0 {
+			return nil, errors.Errorf(
+				"no subnets of the bound spaces are in availability zones %v",
+				availabilityZones,
+			)
+		} else if len(zones) == 0 {
+			return nil, errors.Errorf(
+				"VPC %q has no subnets in availability zones %v",
+				vpcID, availabilityZones,
+			)
+		}
+		availabilityZones = zones
+	} else if vpcID != "" {
+		return nil, errors.Errorf("VPC %q has no available subnets mapping public IP addresses", vpcID)
+	}
+
 	if args.InstanceConfig.HasNetworks() {
 		return nil, errors.New("starting instances with networks is not supported yet")
 	}
@@ -530,8 +606,9 @@ func (e *environ) StartInstance(args environs.StartInstanceParams) (_ *environs.
 	rootDiskSize := uint64(blockDeviceMappings[0].VolumeSize) * 1024
 
 	for _, availZone := range availabilityZones {
-		instResp, err = runInstances(e.ec2(), &ec2.RunInstances{
+		ri := &ec2.RunInstances{
 			AvailZone:           availZone,
+			SubnetId:            zoneSubnets[availZone],
 			ImageId:             spec.Image.Id,
 			MinCount:            1,
 			MaxCount:            1,
@@ -539,7 +616,12 @@ func (e *environ) StartInstance(args environs.StartInstanceParams) (_ *environs.
 			InstanceType:        spec.InstanceType.Name,
 			SecurityGroups:      groups,
 			BlockDeviceMappings: blockDeviceMappings,
-		})
+		}
+		if args.Constraints.HasSpot() {
+			instResp, err = runSpotInstance(e.ec2(), ri, spotPrice(args.Constraints, spec.InstanceType))
+		} else {
+			instResp, err = runInstances(e.ec2(), ri)
+		}
 		if isZoneConstrainedError(err) {
 			logger.Infof("%q is constrained, trying another availability zone", availZone)
 		} else {
@@ -627,10 +709,7 @@ func (e *environ) StopInstances(ids ...instance.Id) error {
 // groupInfoByName returns information on the security group
 // with the given name including rules and other details.
 func (e *environ) groupInfoByName(groupName string) (ec2.SecurityGroupInfo, error) {
-	// Non-default VPC does not support name-based group lookups, can
-	// use a filter by group name instead when support is needed.
-	limitToGroups := []ec2.SecurityGroup{{Name: groupName}}
-	resp, err := e.ec2().SecurityGroups(limitToGroups, nil)
+	resp, err := e.securityGroupsByName(groupName)
 	if err != nil {
 		return ec2.SecurityGroupInfo{}, err
 	}
@@ -640,6 +719,21 @@ func (e *environ) groupInfoByName(groupName string) (ec2.SecurityGroupInfo, erro
 	return resp.Groups[0], nil
 }
 
+// securityGroupsByName returns the security groups with the given
+// names. A non-default VPC does not support name-based group lookups,
+// so when the environment has a vpc-id the groups are found with a
+// filter on the group name instead.
+func (e *environ) securityGroupsByName(names ...string) (*ec2.SecurityGroupsResp, error) {
+	vpcID := e.ecfg().vpcID()
+	if vpcID == "" {
+		return e.ec2().SecurityGroups(ec2.SecurityGroupNames(names...), nil)
+	}
+	filter := ec2.NewFilter()
+	filter.Add("vpc-id", vpcID)
+	filter.Add("group-name", names...)
+	return e.ec2().SecurityGroups(nil, filter)
+}
+
 // groupByName returns the security group with the given name.
 func (e *environ) groupByName(groupName string) (ec2.SecurityGroup, error) {
 	groupInfo, err := e.groupInfoByName(groupName)
@@ -993,11 +1087,11 @@ func (e *environ) AllInstances() ([]instance.Instance, error) {
 		if ec2ErrCode(err) == "InvalidGroup.NotFound" {
 			return nil, nil
 		}
-		return nil, err
+		return nil, maybeConvertCredentialError(err)
 	}
 	resp, err := e.ec2().Instances(nil, filter)
 	if err != nil {
-		return nil, err
+		return nil, maybeConvertCredentialError(err)
 	}
 	var insts []instance.Instance
 	for _, r := range resp.Reservations {
@@ -1024,7 +1118,25 @@ func portsToIPPerms(ports []network.PortRange) []ec2.IPPerm {
 			Protocol:  p.Protocol,
 			FromPort:  p.FromPort,
 			ToPort:    p.ToPort,
-			SourceIPs: []string{"0.0.0.0/0"},
+			SourceIPs: []string{network.AnySourceCIDR},
+		}
+	}
+	return ipPerms
+}
+
+// rulesToIPPerms returns an IP permission for each port range and
+// source CIDR of the rules, so that each can be authorized or revoked
+// on its own.
+func rulesToIPPerms(rules []network.IngressRule) []ec2.IPPerm {
+	var ipPerms []ec2.IPPerm
+	for _, r := range rules {
+		for _, cidr := range r.SourceCIDRs {
+			ipPerms = append(ipPerms, ec2.IPPerm{
+				Protocol:  r.Protocol,
+				FromPort:  r.FromPort,
+				ToPort:    r.ToPort,
+				SourceIPs: []string{cidr},
+			})
 		}
 	}
 	return ipPerms
@@ -1035,14 +1147,26 @@ func (e *environ) openPortsInGroup(name string, ports []network.PortRange) error
 		return nil
 	}
 	// Give permissions for anyone to access the given ports.
+	return e.authorizeInGroup(name, portsToIPPerms(ports))
+}
+
+func (e *environ) openRulesInGroup(name string, rules []network.IngressRule) error {
+	if len(rules) == 0 {
+		return nil
+	}
+	// Give permissions for the rules' source CIDRs to access their
+	// ports.
+	return e.authorizeInGroup(name, rulesToIPPerms(rules))
+}
+
+func (e *environ) authorizeInGroup(name string, ipPerms []ec2.IPPerm) error {
 	g, err := e.groupByName(name)
 	if err != nil {
 		return err
 	}
-	ipPerms := portsToIPPerms(ports)
 	_, err = e.ec2().AuthorizeSecurityGroup(g, ipPerms)
 	if err != nil && ec2ErrCode(err) == "InvalidPermission.Duplicate" {
-		if len(ports) == 1 {
+		if len(ipPerms) == 1 {
 			return nil
 		}
 		// If there's more than one port and we get a duplicate error,
@@ -1068,29 +1192,48 @@ func (e *environ) closePortsInGroup(name string, ports []network.PortRange) erro
 		return nil
 	}
 	// Revoke permissions for anyone to access the given ports.
+	return e.revokeInGroup(name, portsToIPPerms(ports))
+}
+
+func (e *environ) closeRulesInGroup(name string, rules []network.IngressRule) error {
+	if len(rules) == 0 {
+		return nil
+	}
+	// Revoke permissions for the rules' source CIDRs to access their
+	// ports.
+	return e.revokeInGroup(name, rulesToIPPerms(rules))
+}
+
+func (e *environ) revokeInGroup(name string, ipPerms []ec2.IPPerm) error {
 	// Note that ec2 allows the revocation of permissions that aren't
 	// granted, so this is naturally idempotent.
 	g, err := e.groupByName(name)
 	if err != nil {
 		return err
 	}
-	_, err = e.ec2().RevokeSecurityGroup(g, portsToIPPerms(ports))
+	_, err = e.ec2().RevokeSecurityGroup(g, ipPerms)
 	if err != nil {
 		return fmt.Errorf("cannot close ports: %v", err)
 	}
 	return nil
 }
 
+// portsInGroup returns the port ranges open to any address in the
+// named group. Port ranges only open to some source CIDRs are
+// reported by rulesInGroup.
 func (e *environ) portsInGroup(name string) (ports []network.PortRange, err error) {
 	group, err := e.groupInfoByName(name)
 	if err != nil {
 		return nil, err
 	}
 	for _, p := range group.IPPerms {
-		if len(p.SourceIPs) != 1 {
+		if len(p.SourceIPs) == 0 {
 			logger.Warningf("unexpected IP permission found: %v", p)
 			continue
 		}
+		if !containsString(p.SourceIPs, network.AnySourceCIDR) {
+			continue
+		}
 		ports = append(ports, network.PortRange{
 			Protocol: p.Protocol,
 			FromPort: p.FromPort,
@@ -1101,6 +1244,41 @@ func (e *environ) portsInGroup(name string) (ports []network.PortRange, err erro
 	return ports, nil
 }
 
+// rulesInGroup returns the ingress rules of the named group.
+func (e *environ) rulesInGroup(name string) (rules []network.IngressRule, err error) {
+	group, err := e.groupInfoByName(name)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range group.IPPerms {
+		if len(p.SourceIPs) == 0 {
+			logger.Warningf("unexpected IP permission found: %v", p)
+			continue
+		}
+		rule, err := network.NewIngressRule(network.PortRange{
+			Protocol: p.Protocol,
+			FromPort: p.FromPort,
+			ToPort:   p.ToPort,
+		}, p.SourceIPs...)
+		if err != nil {
+			logger.Warningf("unexpected IP permission found: %v", p)
+			continue
+		}
+		rules = append(rules, rule)
+	}
+	network.SortIngressRules(rules)
+	return rules, nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
 func (e *environ) OpenPorts(ports []network.PortRange) error {
 	if e.Config().FirewallMode() != config.FwGlobal {
 		return fmt.Errorf("invalid firewall mode %q for opening ports on environment",
@@ -1133,6 +1311,41 @@ func (e *environ) Ports() ([]network.PortRange, error) {
 	return e.portsInGroup(e.globalGroupName())
 }
 
+// OpenIngressRules is specified in the environs.IngressFirewall interface.
+func (e *environ) OpenIngressRules(rules []network.IngressRule) error {
+	if e.Config().FirewallMode() != config.FwGlobal {
+		return fmt.Errorf("invalid firewall mode %q for opening ingress rules on environment",
+			e.Config().FirewallMode())
+	}
+	if err := e.openRulesInGroup(e.globalGroupName(), rules); err != nil {
+		return err
+	}
+	logger.Infof("opened ingress rules in global group: %v", rules)
+	return nil
+}
+
+// CloseIngressRules is specified in the environs.IngressFirewall interface.
+func (e *environ) CloseIngressRules(rules []network.IngressRule) error {
+	if e.Config().FirewallMode() != config.FwGlobal {
+		return fmt.Errorf("invalid firewall mode %q for closing ingress rules on environment",
+			e.Config().FirewallMode())
+	}
+	if err := e.closeRulesInGroup(e.globalGroupName(), rules); err != nil {
+		return err
+	}
+	logger.Infof("closed ingress rules in global group: %v", rules)
+	return nil
+}
+
+// IngressRules is specified in the environs.IngressFirewall interface.
+func (e *environ) IngressRules() ([]network.IngressRule, error) {
+	if e.Config().FirewallMode() != config.FwGlobal {
+		return nil, fmt.Errorf("invalid firewall mode %q for retrieving ingress rules from environment",
+			e.Config().FirewallMode())
+	}
+	return e.rulesInGroup(e.globalGroupName())
+}
+
 func (*environ) Provider() environs.EnvironProvider {
 	return &providerInstance
 }
@@ -1248,7 +1461,7 @@ var zeroGroup ec2.SecurityGroup
 // the named group only.
 func (e *environ) ensureGroup(name string, perms []ec2.IPPerm) (g ec2.SecurityGroup, err error) {
 	ec2inst := e.ec2()
-	resp, err := ec2inst.CreateSecurityGroup("", name, "juju group")
+	resp, err := ec2inst.CreateSecurityGroup(e.ecfg().vpcID(), name, "juju group")
 	if err != nil && ec2ErrCode(err) != "InvalidGroup.Duplicate" {
 		return zeroGroup, err
 	}
@@ -1257,10 +1470,13 @@ func (e *environ) ensureGroup(name string, perms []ec2.IPPerm) (g ec2.SecurityGr
 	if err == nil {
 		g = resp.SecurityGroup
 	} else {
-		resp, err := ec2inst.SecurityGroups(ec2.SecurityGroupNames(name), nil)
+		resp, err := e.securityGroupsByName(name)
 		if err != nil {
 			return zeroGroup, err
 		}
+		if len(resp.Groups) == 0 {
+			return zeroGroup, errors.NotFoundf("security group %q", name)
+		}
 		info := resp.Groups[0]
 		// It's possible that the old group has the wrong
 		// description here, but if it does it's probably due
@@ -1383,6 +1599,17 @@ func isZoneConstrainedError(err error) bool {
 	return false
 }
 
+// maybeConvertCredentialError returns err wrapped so that it satisfies
+// errors.IsUnauthorized if its cause shows that EC2 rejected the
+// configured credentials, and err unchanged otherwise.
+func maybeConvertCredentialError(err error) error {
+	switch ec2ErrCode(errors.Cause(err)) {
+	case "AuthFailure", "SignatureDoesNotMatch", "InvalidClientTokenId":
+		return errors.NewUnauthorized(err, "")
+	}
+	return err
+}
+
 // If the err is of type *ec2.Error, ec2ErrCode returns
 // its code, otherwise it returns the empty string.
 func ec2ErrCode(err error) string {
@@ -140,3 +140,26 @@ func (*Suite) TestPortsToIPPerms(c *gc.C) {
 		c.Assert(ipperms, gc.DeepEquals, t.expected)
 	}
 }
+
+func (*Suite) TestRulesToIPPerms(c *gc.C) {
+	rules := []network.IngressRule{
+		network.MustNewIngressRule("80-82/tcp", "10.0.0.0/8", "192.168.1.0/24"),
+		network.MustNewIngressRule("53/udp"),
+	}
+	c.Assert(rulesToIPPerms(rules), gc.DeepEquals, []amzec2.IPPerm{{
+		Protocol:  "tcp",
+		FromPort:  80,
+		ToPort:    82,
+		SourceIPs: []string{"10.0.0.0/8"},
+	}, {
+		Protocol:  "tcp",
+		FromPort:  80,
+		ToPort:    82,
+		SourceIPs: []string{"192.168.1.0/24"},
+	}, {
+		Protocol:  "udp",
+		FromPort:  53,
+		ToPort:    53,
+		SourceIPs: []string{"0.0.0.0/0"},
+	}})
+}
@@ -9,6 +9,7 @@ import (
 
 	"gopkg.in/amz.v3/ec2"
 
+	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
@@ -26,6 +27,7 @@ func (inst *ec2Instance) String() string {
 }
 
 var _ instance.Instance = (*ec2Instance)(nil)
+var _ environs.InstanceIngressFirewall = (*ec2Instance)(nil)
 
 func (inst *ec2Instance) getInstance() *ec2.Instance {
 	inst.mu.Lock()
@@ -125,3 +127,44 @@ func (inst *ec2Instance) Ports(machineId string) ([]network.PortRange, error) {
 	}
 	return ranges, nil
 }
+
+// OpenIngressRules is specified in the environs.InstanceIngressFirewall
+// interface.
+func (inst *ec2Instance) OpenIngressRules(machineId string, rules []network.IngressRule) error {
+	if inst.e.Config().FirewallMode() != config.FwInstance {
+		return fmt.Errorf("invalid firewall mode %q for opening ingress rules on instance",
+			inst.e.Config().FirewallMode())
+	}
+	name := inst.e.machineGroupName(machineId)
+	if err := inst.e.openRulesInGroup(name, rules); err != nil {
+		return err
+	}
+	logger.Infof("opened ingress rules in security group %s: %v", name, rules)
+	return nil
+}
+
+// CloseIngressRules is specified in the environs.InstanceIngressFirewall
+// interface.
+func (inst *ec2Instance) CloseIngressRules(machineId string, rules []network.IngressRule) error {
+	if inst.e.Config().FirewallMode() != config.FwInstance {
+		return fmt.Errorf("invalid firewall mode %q for closing ingress rules on instance",
+			inst.e.Config().FirewallMode())
+	}
+	name := inst.e.machineGroupName(machineId)
+	if err := inst.e.closeRulesInGroup(name, rules); err != nil {
+		return err
+	}
+	logger.Infof("closed ingress rules in security group %s: %v", name, rules)
+	return nil
+}
+
+// IngressRules is specified in the environs.InstanceIngressFirewall
+// interface.
+func (inst *ec2Instance) IngressRules(machineId string) ([]network.IngressRule, error) {
+	if inst.e.Config().FirewallMode() != config.FwInstance {
+		return nil, fmt.Errorf("invalid firewall mode %q for retrieving ingress rules from instance",
+			inst.e.Config().FirewallMode())
+	}
+	name := inst.e.machineGroupName(machineId)
+	return inst.e.rulesInGroup(name)
+}
@@ -538,6 +538,223 @@ func (t *localServerSuite) testStartInstanceAvailZoneAllConstrained(c *gc.C, run
 	c.Assert(azArgs, gc.DeepEquals, []string{"az1", "az2"})
 }
 
+func (t *localServerSuite) TestStartInstanceAuthFailure(c *gc.C) {
+	env := t.Prepare(c)
+	err := bootstrap.Bootstrap(envtesting.BootstrapContext(c), env, bootstrap.BootstrapParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	t.PatchValue(ec2.RunInstances, func(e *amzec2.EC2, ri *amzec2.RunInstances) (*amzec2.RunInstancesResp, error) {
+		return nil, &amzec2.Error{
+			Code:    "AuthFailure",
+			Message: "AWS was not able to validate the provided access credentials",
+		}
+	})
+	_, _, _, err = testing.StartInstance(env, "1")
+	c.Assert(err, gc.ErrorMatches, "cannot run instances: AWS was not able to validate the provided access credentials \\(AuthFailure\\)")
+	c.Assert(err, jc.Satisfies, errors.IsUnauthorized)
+}
+
+func (t *localServerSuite) TestStartInstanceSubnetsToZones(c *gc.C) {
+	env := t.Prepare(c)
+	err := bootstrap.Bootstrap(envtesting.BootstrapContext(c), env, bootstrap.BootstrapParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	mock := mockAvailabilityZoneAllocations{
+		result: []common.AvailabilityZoneInstances{
+			{ZoneName: "az1"}, {ZoneName: "az2"}, {ZoneName: "az3"},
+		},
+	}
+	t.PatchValue(ec2.AvailabilityZoneAllocations, mock.AvailabilityZoneAllocations)
+
+	// Only zones holding a subnet of the bound spaces are tried, and
+	// the instance is started in that subnet.
+	var azArgs, subnetArgs []string
+	t.PatchValue(ec2.RunInstances, func(e *amzec2.EC2, ri *amzec2.RunInstances) (*amzec2.RunInstancesResp, error) {
+		azArgs = append(azArgs, ri.AvailZone)
+		subnetArgs = append(subnetArgs, ri.SubnetId)
+		return nil, azConstrainedErr
+	})
+	params := environs.StartInstanceParams{
+		SubnetsToZones: map[network.Id][]string{
+			"subnet-3": {"az3"},
+			"subnet-2": {"az2"},
+			"subnet-4": {"az4"},
+		},
+	}
+	_, err = testing.StartInstanceWithParams(env, "1", params, nil)
+	c.Assert(err, gc.ErrorMatches, "cannot run instances: .*")
+	c.Assert(azArgs, gc.DeepEquals, []string{"az2", "az3"})
+	c.Assert(subnetArgs, gc.DeepEquals, []string{"subnet-2", "subnet-3"})
+
+	params.SubnetsToZones = map[network.Id][]string{"subnet-4": {"az4"}}
+	_, err = testing.StartInstanceWithParams(env, "1", params, nil)
+	c.Assert(err, gc.ErrorMatches, `no subnets of the bound spaces are in availability zones \[az1 az2 az3\]`)
+}
+
+// addVPC adds a VPC with an internet gateway to the test server, and
+// an available subnet in each of the given availability zones. It
+// returns the ids of the VPC and of its subnets.
+func (t *localServerSuite) addVPC(c *gc.C, withGateway bool, zones ...string) (string, []string) {
+	vpc := t.srv.ec2srv.AddVPC(amzec2.VPC{
+		CIDRBlock: "10.1.0.0/16",
+		State:     "available",
+	})
+	if withGateway {
+		_, err := t.srv.ec2srv.AddInternetGateway(amzec2.InternetGateway{
+			VPCId:           vpc.Id,
+			AttachmentState: "available",
+		})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	var subnetIds []string
+	for i, zone := range zones {
+		subnet, err := t.srv.ec2srv.AddSubnet(amzec2.Subnet{
+			VPCId:     vpc.Id,
+			CIDRBlock: fmt.Sprintf("10.1.%d.0/24", i),
+			AvailZone: zone,
+			State:     "available",
+
+			MapPublicIPOnLaunch: true,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+		subnetIds = append(subnetIds, subnet.Id)
+	}
+	return vpc.Id, subnetIds
+}
+
+func (t *localServerSuite) TestBootstrapInVPC(c *gc.C) {
+	vpcID, subnetIds := t.addVPC(c, true, "test-available")
+	t.PatchValue(&t.TestConfig, localConfigAttrs.Merge(coretesting.Attrs{"vpc-id": vpcID}))
+	env := t.Prepare(c)
+	err := bootstrap.Bootstrap(envtesting.BootstrapContext(c), env, bootstrap.BootstrapParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	instanceIds, err := env.StateServerInstances()
+	c.Assert(err, jc.ErrorIsNil)
+	inst := t.srv.ec2srv.Instance(string(instanceIds[0]))
+	c.Assert(inst, gc.NotNil)
+	c.Assert(inst.SubnetId, gc.Equals, subnetIds[0])
+	c.Assert(inst.VPCId, gc.Equals, vpcID)
+
+	// The security groups are created inside the VPC.
+	ec2conn := ec2.EnvironEC2(env)
+	filter := amzec2.NewFilter()
+	filter.Add("vpc-id", vpcID)
+	resp, err := ec2conn.SecurityGroups(nil, filter)
+	c.Assert(err, jc.ErrorIsNil)
+	var groupNames []string
+	for _, group := range resp.Groups {
+		groupNames = append(groupNames, group.Name)
+	}
+	c.Assert(groupNames, jc.SameContents, []string{"juju-sample", "juju-sample-0"})
+}
+
+func (t *localServerSuite) TestBootstrapVPCNotFound(c *gc.C) {
+	t.PatchValue(&t.TestConfig, localConfigAttrs.Merge(coretesting.Attrs{"vpc-id": "vpc-missing"}))
+	env := t.Prepare(c)
+	err := bootstrap.Bootstrap(envtesting.BootstrapContext(c), env, bootstrap.BootstrapParams{})
+	c.Assert(err, gc.ErrorMatches, `invalid vpc-id: VPC "vpc-missing" not found`)
+}
+
+func (t *localServerSuite) TestBootstrapVPCWithoutInternetGateway(c *gc.C) {
+	vpcID, _ := t.addVPC(c, false, "test-available")
+	t.PatchValue(&t.TestConfig, localConfigAttrs.Merge(coretesting.Attrs{"vpc-id": vpcID}))
+	env := t.Prepare(c)
+	err := bootstrap.Bootstrap(envtesting.BootstrapContext(c), env, bootstrap.BootstrapParams{})
+	c.Assert(err, gc.ErrorMatches, `invalid vpc-id: VPC ".*" has no internet gateway`)
+}
+
+func (t *localServerSuite) TestBootstrapVPCWithoutSubnets(c *gc.C) {
+	vpcID, _ := t.addVPC(c, true)
+	t.PatchValue(&t.TestConfig, localConfigAttrs.Merge(coretesting.Attrs{"vpc-id": vpcID}))
+	env := t.Prepare(c)
+	err := bootstrap.Bootstrap(envtesting.BootstrapContext(c), env, bootstrap.BootstrapParams{})
+	c.Assert(err, gc.ErrorMatches, `invalid vpc-id: VPC ".*" has no available subnets mapping public IP addresses`)
+}
+
+func (t *localServerSuite) TestBootstrapVPCWithoutPublicSubnets(c *gc.C) {
+	vpcID, _ := t.addVPC(c, true)
+	_, err := t.srv.ec2srv.AddSubnet(amzec2.Subnet{
+		VPCId:     vpcID,
+		CIDRBlock: "10.1.0.0/24",
+		AvailZone: "test-available",
+		State:     "available",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	t.PatchValue(&t.TestConfig, localConfigAttrs.Merge(coretesting.Attrs{"vpc-id": vpcID}))
+	env := t.Prepare(c)
+	err = bootstrap.Bootstrap(envtesting.BootstrapContext(c), env, bootstrap.BootstrapParams{})
+	c.Assert(err, gc.ErrorMatches, `invalid vpc-id: VPC ".*" has no available subnets mapping public IP addresses`)
+}
+
+func (t *localServerSuite) TestStartInstanceVPCSubnetsByZone(c *gc.C) {
+	vpcID, subnetIds := t.addVPC(c, true, "test-available", "az2")
+	t.PatchValue(&t.TestConfig, localConfigAttrs.Merge(coretesting.Attrs{"vpc-id": vpcID}))
+	env := t.Prepare(c)
+	err := bootstrap.Bootstrap(envtesting.BootstrapContext(c), env, bootstrap.BootstrapParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	mock := mockAvailabilityZoneAllocations{
+		result: []common.AvailabilityZoneInstances{
+			{ZoneName: "az1"}, {ZoneName: "az2"},
+		},
+	}
+	t.PatchValue(ec2.AvailabilityZoneAllocations, mock.AvailabilityZoneAllocations)
+
+	// Only zones holding a subnet of the VPC are tried, and the
+	// instance is started in that subnet.
+	var azArgs, subnetArgs []string
+	t.PatchValue(ec2.RunInstances, func(e *amzec2.EC2, ri *amzec2.RunInstances) (*amzec2.RunInstancesResp, error) {
+		azArgs = append(azArgs, ri.AvailZone)
+		subnetArgs = append(subnetArgs, ri.SubnetId)
+		return nil, azConstrainedErr
+	})
+	_, _, _, err = testing.StartInstance(env, "1")
+	c.Assert(err, gc.ErrorMatches, "cannot run instances: .*")
+	c.Assert(azArgs, gc.DeepEquals, []string{"az2"})
+	c.Assert(subnetArgs, gc.DeepEquals, []string{subnetIds[1]})
+
+	mock.result = []common.AvailabilityZoneInstances{{ZoneName: "az1"}}
+	_, _, _, err = testing.StartInstance(env, "1")
+	c.Assert(err, gc.ErrorMatches, `VPC ".*" has no subnets in availability zones \[az1\]`)
+}
+
+func (t *localServerSuite) TestStartInstanceVPCSubnetsToZones(c *gc.C) {
+	vpcID, subnetIds := t.addVPC(c, true, "test-available", "az2")
+	t.PatchValue(&t.TestConfig, localConfigAttrs.Merge(coretesting.Attrs{"vpc-id": vpcID}))
+	env := t.Prepare(c)
+	err := bootstrap.Bootstrap(envtesting.BootstrapContext(c), env, bootstrap.BootstrapParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	mock := mockAvailabilityZoneAllocations{
+		result: []common.AvailabilityZoneInstances{
+			{ZoneName: "az1"}, {ZoneName: "az2"},
+		},
+	}
+	t.PatchValue(ec2.AvailabilityZoneAllocations, mock.AvailabilityZoneAllocations)
+	var subnetArgs []string
+	t.PatchValue(ec2.RunInstances, func(e *amzec2.EC2, ri *amzec2.RunInstances) (*amzec2.RunInstancesResp, error) {
+		subnetArgs = append(subnetArgs, ri.SubnetId)
+		return nil, azConstrainedErr
+	})
+
+	// Bound subnets outside the VPC are rejected.
+	params := environs.StartInstanceParams{
+		SubnetsToZones: map[network.Id][]string{
+			network.Id(subnetIds[1]): {"az2"},
+			"subnet-elsewhere":       {"az1"},
+		},
+	}
+	_, err = testing.StartInstanceWithParams(env, "1", params, nil)
+	c.Assert(err, gc.ErrorMatches, `subnets subnet-elsewhere are not usable subnets of VPC ".*"`)
+	c.Assert(subnetArgs, gc.HasLen, 0)
+
+	params.SubnetsToZones = map[network.Id][]string{network.Id(subnetIds[1]): {"az2"}}
+	_, err = testing.StartInstanceWithParams(env, "1", params, nil)
+	c.Assert(err, gc.ErrorMatches, "cannot run instances: .*")
+	c.Assert(subnetArgs, gc.DeepEquals, []string{subnetIds[1]})
+}
+
 func (t *localServerSuite) TestStartInstanceAvailZoneOneConstrained(c *gc.C) {
 	t.testStartInstanceAvailZoneOneConstrained(c, azConstrainedErr)
 }
@@ -663,6 +880,70 @@ func (t *localServerSuite) TestPrecheckInstanceUnsupportedArch(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `invalid AWS instance type "cc1.4xlarge" and arch "i386" specified`)
 }
 
+func (t *localServerSuite) TestPrecheckInstanceSpot(c *gc.C) {
+	env := t.Prepare(c)
+	cons := constraints.MustParse("spot=true spot-price=0.05")
+	err := env.PrecheckInstance(coretesting.FakeDefaultSeries, cons, "")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (t *localServerSuite) TestStartInstanceSpot(c *gc.C) {
+	env := t.Prepare(c)
+	err := bootstrap.Bootstrap(envtesting.BootstrapContext(c), env, bootstrap.BootstrapParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The test server cannot fulfil spot requests, so they are
+	// run as ordinary instances.
+	var prices []string
+	t.PatchValue(ec2.RunSpotInstance, func(e *amzec2.EC2, ri *amzec2.RunInstances, price string) (*amzec2.RunInstancesResp, error) {
+		prices = append(prices, price)
+		return e.RunInstances(ri)
+	})
+	t.PatchValue(ec2.RunInstances, func(e *amzec2.EC2, ri *amzec2.RunInstances) (*amzec2.RunInstancesResp, error) {
+		c.Fatalf("spot instance started on demand")
+		return nil, nil
+	})
+
+	// Without a spot-price, the bid is the on-demand price.
+	cons := constraints.MustParse("instance-type=m1.small spot=true")
+	inst, _, _, err := testing.StartInstanceWithConstraints(env, "1", cons)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(inst, gc.NotNil)
+
+	cons = constraints.MustParse("instance-type=m1.small spot=true spot-price=0.02")
+	_, _, _, err = testing.StartInstanceWithConstraints(env, "2", cons)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(prices, gc.DeepEquals, []string{"0.060", "0.02"})
+}
+
+func (t *localServerSuite) TestReclaimedInstances(c *gc.C) {
+	env := t.Prepare(c)
+	var instIds []string
+	t.PatchValue(ec2.DescribeSpotRequests, func(e *amzec2.EC2, ids []string) ([]amzec2.SpotRequestResult, error) {
+		instIds = ids
+		return []amzec2.SpotRequestResult{{
+			InstanceId: "i-price",
+			State:      "closed",
+			Status:     amzec2.SpotStatus{Code: "instance-terminated-by-price"},
+		}, {
+			InstanceId: "i-user",
+			State:      "closed",
+			Status:     amzec2.SpotStatus{Code: "instance-terminated-by-user"},
+		}, {
+			InstanceId: "i-running",
+			State:      "active",
+			Status:     amzec2.SpotStatus{Code: "fulfilled"},
+		}}, nil
+	})
+
+	reclaimer, ok := environs.SupportsInstanceReclaiming(env)
+	c.Assert(ok, jc.IsTrue)
+	ids, err := reclaimer.ReclaimedInstances([]instance.Id{"i-price", "i-user", "i-running", "i-ondemand"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ids, gc.DeepEquals, []instance.Id{"i-price"})
+	c.Assert(instIds, gc.DeepEquals, []string{"i-price", "i-user", "i-running", "i-ondemand"})
+}
+
 func (t *localServerSuite) TestPrecheckInstanceAvailZone(c *gc.C) {
 	env := t.Prepare(c)
 	placement := "zone=test-available"
@@ -0,0 +1,186 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"gopkg.in/amz.v3/ec2"
+
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/environs/instances"
+	"github.com/juju/juju/instance"
+)
+
+// spotRequestAttempt is used to wait for a spot request to be
+// fulfilled.
+var spotRequestAttempt = utils.AttemptStrategy{
+	Total: 5 * time.Minute,
+	Delay: 10 * time.Second,
+}
+
+// spotPrice returns the maximum hourly price to bid for a spot instance
+// of the given type: the spot-price constraint if it is set, or else
+// the type's on-demand price, so that the bid is never more than the
+// instance would otherwise cost.
+func spotPrice(cons constraints.Value, instType instances.InstanceType) string {
+	if cons.SpotPrice != nil && *cons.SpotPrice != "" {
+		return *cons.SpotPrice
+	}
+	// Costs are held in thousandths of a US dollar per hour.
+	return fmt.Sprintf("%.3f", float64(instType.Cost)/1000)
+}
+
+var runSpotInstance = _runSpotInstance
+
+// _runSpotInstance requests a spot instance with the parameters of ri,
+// bidding at most price, and waits for the request to be fulfilled. The
+// request is cancelled if it is not fulfilled in time.
+func _runSpotInstance(e *ec2.EC2, ri *ec2.RunInstances, price string) (*ec2.RunInstancesResp, error) {
+	resp, err := e.RequestSpotInstances(&ec2.RequestSpotInstances{
+		SpotPrice:           price,
+		InstanceCount:       1,
+		Type:                "one-time",
+		AvailZone:           ri.AvailZone,
+		SubnetId:            ri.SubnetId,
+		ImageId:             ri.ImageId,
+		UserData:            ri.UserData,
+		InstanceType:        ri.InstanceType,
+		SecurityGroups:      ri.SecurityGroups,
+		BlockDeviceMappings: ri.BlockDeviceMappings,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.SpotRequests) != 1 {
+		return nil, errors.Errorf("expected 1 spot request, got %d", len(resp.SpotRequests))
+	}
+	requestId := resp.SpotRequests[0].SpotRequestId
+
+	instId, err := waitSpotRequest(e, requestId)
+	if err != nil {
+		cancelSpotRequest(e, requestId)
+		return nil, errors.Trace(err)
+	}
+	instResp, err := e.Instances([]string{instId}, nil)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get instance %q of spot request %q", instId, requestId)
+	}
+	if len(instResp.Reservations) != 1 || len(instResp.Reservations[0].Instances) != 1 {
+		return nil, errors.Errorf("instance %q of spot request %q not found", instId, requestId)
+	}
+	reservation := instResp.Reservations[0]
+	return &ec2.RunInstancesResp{
+		ReservationId:  reservation.ReservationId,
+		OwnerId:        reservation.OwnerId,
+		SecurityGroups: reservation.SecurityGroups,
+		Instances:      reservation.Instances,
+	}, nil
+}
+
+// waitSpotRequest waits for the spot request with the given id to be
+// fulfilled, and returns the id of its instance.
+func waitSpotRequest(e *ec2.EC2, requestId string) (string, error) {
+	for a := spotRequestAttempt.Start(); a.Next(); {
+		resp, err := e.DescribeSpotRequests([]string{requestId}, nil)
+		if ec2ErrCode(err) == "InvalidSpotInstanceRequestID.NotFound" {
+			// New requests may not be visible yet.
+			continue
+		} else if err != nil {
+			return "", errors.Annotatef(err, "cannot get spot request %q", requestId)
+		}
+		if len(resp.SpotRequests) == 0 {
+			continue
+		}
+		req := resp.SpotRequests[0]
+		switch req.State {
+		case "active":
+			if req.InstanceId != "" {
+				return req.InstanceId, nil
+			}
+		case "open":
+			// A bid below the current spot price stays open until
+			// the price drops, which may never happen.
+			if req.Status.Code == "price-too-low" {
+				return "", errors.Errorf("spot request %q: %s", requestId, req.Status.Message)
+			}
+		default:
+			return "", errors.Errorf("spot request %q is %s: %s", requestId, req.State, req.Status.Message)
+		}
+	}
+	return "", errors.Errorf("spot request %q was not fulfilled in time", requestId)
+}
+
+// cancelSpotRequest cancels the spot request with the given id, and
+// terminates its instance should it have been fulfilled regardless.
+// Failures are logged, as there is nothing more the caller can do.
+func cancelSpotRequest(e *ec2.EC2, requestId string) {
+	if _, err := e.CancelSpotRequests([]string{requestId}); err != nil {
+		logger.Errorf("cannot cancel spot request %q: %v", requestId, err)
+		return
+	}
+	resp, err := e.DescribeSpotRequests([]string{requestId}, nil)
+	if err != nil {
+		logger.Errorf("cannot get cancelled spot request %q: %v", requestId, err)
+		return
+	}
+	for _, req := range resp.SpotRequests {
+		if req.InstanceId == "" {
+			continue
+		}
+		if _, err := e.TerminateInstances([]string{req.InstanceId}); err != nil {
+			logger.Errorf("cannot terminate instance %q of cancelled spot request %q: %v", req.InstanceId, requestId, err)
+		}
+	}
+}
+
+var describeSpotRequests = _describeSpotRequests
+
+// _describeSpotRequests returns the spot requests of the instances
+// with the given ids.
+func _describeSpotRequests(e *ec2.EC2, instIds []string) ([]ec2.SpotRequestResult, error) {
+	filter := ec2.NewFilter()
+	filter.Add("instance-id", instIds...)
+	resp, err := e.DescribeSpotRequests(nil, filter)
+	if err != nil {
+		return nil, err
+	}
+	return resp.SpotRequests, nil
+}
+
+// spotRequestReclaimed returns whether EC2 has terminated the
+// instance of the given spot request, because the spot price rose
+// above the bid or capacity ran out. Instances terminated by juju
+// itself are not reclaimed.
+func spotRequestReclaimed(req ec2.SpotRequestResult) bool {
+	code := req.Status.Code
+	return strings.HasPrefix(code, "instance-terminated-") && code != "instance-terminated-by-user"
+}
+
+// ReclaimedInstances returns those of the given instance ids whose
+// spot instances have been terminated by EC2.
+func (e *environ) ReclaimedInstances(ids []instance.Id) ([]instance.Id, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	instIds := make([]string, len(ids))
+	for i, id := range ids {
+		instIds[i] = string(id)
+	}
+	requests, err := describeSpotRequests(e.ec2(), instIds)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot get spot requests")
+	}
+	var results []instance.Id
+	for _, req := range requests {
+		if spotRequestReclaimed(req) {
+			results = append(results, instance.Id(req.InstanceId))
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,107 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/amz.v3/ec2"
+
+	"github.com/juju/juju/network"
+)
+
+// validateVPC checks that the VPC with the given id can be used by
+// the environment: it must exist and be available, have an internet
+// gateway attached so instances are reachable, and hold at least one
+// available subnet that gives its instances public IP addresses.
+func validateVPC(ec2Client *ec2.EC2, vpcID string) error {
+	resp, err := ec2Client.VPCs([]string{vpcID}, nil)
+	if ec2ErrCode(err) == "InvalidVpcID.NotFound" || err == nil && len(resp.VPCs) == 0 {
+		return errors.NotFoundf("VPC %q", vpcID)
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot get VPC %q", vpcID)
+	}
+	if state := resp.VPCs[0].State; state != "available" {
+		return errors.Errorf("VPC %q is %s", vpcID, state)
+	}
+
+	filter := ec2.NewFilter()
+	filter.Add("attachment.vpc-id", vpcID)
+	gatewaysResp, err := ec2Client.InternetGateways(nil, filter)
+	if err != nil {
+		return errors.Annotatef(err, "cannot get internet gateways of VPC %q", vpcID)
+	}
+	if len(gatewaysResp.InternetGateways) == 0 {
+		return errors.Errorf("VPC %q has no internet gateway", vpcID)
+	}
+
+	subnetsToZones, err := vpcSubnetsToZones(ec2Client, vpcID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(subnetsToZones) == 0 {
+		return errors.Errorf("VPC %q has no available subnets mapping public IP addresses", vpcID)
+	}
+	return nil
+}
+
+// vpcSubnetsToZones returns the available subnets of the VPC with the
+// given id, mapped to the availability zones they are in. Subnets that
+// do not map public IP addresses to their instances are left out, as
+// instances started in them would be unreachable from outside the VPC.
+func vpcSubnetsToZones(ec2Client *ec2.EC2, vpcID string) (map[network.Id][]string, error) {
+	filter := ec2.NewFilter()
+	filter.Add("vpc-id", vpcID)
+	resp, err := ec2Client.Subnets(nil, filter)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get subnets of VPC %q", vpcID)
+	}
+	subnetsToZones := make(map[network.Id][]string)
+	for _, subnet := range resp.Subnets {
+		if subnet.State != "available" {
+			logger.Debugf("skipping subnet %q of VPC %q: %s", subnet.Id, vpcID, subnet.State)
+			continue
+		}
+		if !subnet.MapPublicIPOnLaunch {
+			logger.Warningf("skipping subnet %q of VPC %q: public IP addresses not mapped on launch", subnet.Id, vpcID)
+			continue
+		}
+		subnetsToZones[network.Id(subnet.Id)] = []string{subnet.AvailZone}
+	}
+	return subnetsToZones, nil
+}
+
+// zonesWithSubnets returns those of the given availability zones that
+// have a subnet in zoneSubnets, keeping their order.
+func zonesWithSubnets(zones []string, zoneSubnets map[string]string) []string {
+	var result []string
+	for _, zone := range zones {
+		if _, ok := zoneSubnets[zone]; ok {
+			result = append(result, zone)
+		}
+	}
+	return result
+}
+
+// checkSubnetsInVPC returns an error naming those of the given subnets
+// that are not usable subnets of the VPC with the given id, as given by
+// vpcSubnetsToZones.
+func checkSubnetsInVPC(subnetsToZones, vpcSubnets map[network.Id][]string, vpcID string) error {
+	var outside []string
+	for subnetId := range subnetsToZones {
+		if _, ok := vpcSubnets[subnetId]; !ok {
+			outside = append(outside, string(subnetId))
+		}
+	}
+	if len(outside) == 0 {
+		return nil
+	}
+	sort.Strings(outside)
+	return errors.Errorf(
+		"subnets %s are not usable subnets of VPC %q",
+		strings.Join(outside, ", "), vpcID,
+	)
+}
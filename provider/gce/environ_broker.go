@@ -33,7 +33,10 @@ func (*environ) MaintainInstance(args environs.StartInstanceParams) error {
 }
 
 // StartInstance implements environs.InstanceBroker.
-func (env *environ) StartInstance(args environs.StartInstanceParams) (*environs.StartInstanceResult, error) {
+func (env *environ) StartInstance(args environs.StartInstanceParams) (_ *environs.StartInstanceResult, resultErr error) {
+	defer func() {
+		resultErr = maybeConvertCredentialError(resultErr)
+	}()
 	// Please note that in order to fulfil the demands made of Instances and
 	// AllInstances, it is imperative that some environment feature be used to
 	// keep track of which instances were actually started by juju.
@@ -182,6 +185,7 @@ func (env *environ) newRawInstance(args environs.StartInstanceParams, spec *inst
 		NetworkInterfaces: []string{"ExternalNAT"},
 		Metadata:          metadata,
 		Tags:              tags,
+		Preemptible:       args.Constraints.HasSpot(),
 		// Network is omitted (left empty).
 	}
 
@@ -269,7 +273,17 @@ func (env *environ) getHardwareCharacteristics(spec *instances.InstanceSpec, ins
 // AllInstances implements environs.InstanceBroker.
 func (env *environ) AllInstances() ([]instance.Instance, error) {
 	instances, err := getInstances(env)
-	return instances, errors.Trace(err)
+	return instances, maybeConvertCredentialError(errors.Trace(err))
+}
+
+// maybeConvertCredentialError returns err wrapped so that it satisfies
+// errors.IsUnauthorized if it shows that GCE rejected the configured
+// credentials, and err unchanged otherwise.
+func maybeConvertCredentialError(err error) error {
+	if google.IsAuthorisationFailure(err) {
+		return errors.NewUnauthorized(err, "")
+	}
+	return err
 }
 
 // StopInstances implements environs.InstanceBroker.
@@ -4,10 +4,15 @@
 package gce_test
 
 import (
+	"net/http"
+
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
+	"google.golang.org/api/googleapi"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/environs/imagemetadata"
 	"github.com/juju/juju/environs/instances"
 	"github.com/juju/juju/environs/simplestreams"
@@ -166,6 +171,27 @@ func (s *environBrokerSuite) TestNewRawInstance(c *gc.C) {
 	c.Check(inst, gc.DeepEquals, s.BaseInstance)
 }
 
+func (s *environBrokerSuite) TestNewRawInstancePreemptible(c *gc.C) {
+	s.FakeConn.Inst = s.BaseInstance
+	s.FakeCommon.AZInstances = []common.AvailabilityZoneInstances{{
+		ZoneName:  "home-zone",
+		Instances: []instance.Id{s.Instance.Id()},
+	}}
+	s.StartInstArgs.Constraints = constraints.MustParse("spot=true")
+
+	_, err := gce.NewRawInstance(s.Env, s.StartInstArgs, s.spec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var addInstanceCalled bool
+	for _, call := range s.FakeConn.Calls {
+		if call.FuncName == "AddInstance" {
+			addInstanceCalled = true
+			c.Check(call.InstanceSpec.Preemptible, jc.IsTrue)
+		}
+	}
+	c.Check(addInstanceCalled, jc.IsTrue)
+}
+
 func (s *environBrokerSuite) TestGetMetadata(c *gc.C) {
 	metadata, err := gce.GetMetadata(s.StartInstArgs)
 
@@ -204,6 +230,13 @@ func (s *environBrokerSuite) TestAllInstances(c *gc.C) {
 	c.Check(insts, jc.DeepEquals, []instance.Instance{s.Instance})
 }
 
+func (s *environBrokerSuite) TestAllInstancesUnauthorized(c *gc.C) {
+	s.FakeEnviron.Err = &googleapi.Error{Code: http.StatusUnauthorized, Message: "Invalid Credentials"}
+
+	_, err := s.Env.AllInstances()
+	c.Assert(err, jc.Satisfies, errors.IsUnauthorized)
+}
+
 func (s *environBrokerSuite) TestStopInstances(c *gc.C) {
 	err := s.Env.StopInstances(s.Instance.Id())
 	c.Assert(err, jc.ErrorIsNil)
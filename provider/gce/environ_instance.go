@@ -94,6 +94,29 @@ func (env *environ) instances() ([]instance.Instance, error) {
 	return results, err
 }
 
+// ReclaimedInstances returns those of the given instance ids whose
+// preemptible instances have been terminated by GCE.
+func (env *environ) ReclaimedInstances(ids []instance.Id) ([]instance.Id, error) {
+	env = env.getSnapshot()
+
+	prefix := common.MachineFullName(env, "")
+	instances, err := env.gce.Instances(prefix, google.StatusTerminated)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var results []instance.Id
+	for _, id := range ids {
+		for _, inst := range instances {
+			if inst.ID == string(id) && inst.Reclaimed() {
+				results = append(results, id)
+				break
+			}
+		}
+	}
+	return results, nil
+}
+
 // StateServerInstances returns the IDs of the instances corresponding
 // to juju state servers.
 func (env *environ) StateServerInstances() ([]instance.Id, error) {
@@ -99,6 +99,23 @@ func (s *environInstSuite) TestBasicInstancesAPI(c *gc.C) {
 	c.Check(s.FakeConn.Calls[0].Statuses, jc.DeepEquals, []string{google.StatusPending, google.StatusStaging, google.StatusRunning})
 }
 
+func (s *environInstSuite) TestReclaimedInstances(c *gc.C) {
+	spam := s.NewBaseInstance(c, "spam")
+	spam.InstanceSummary.Status = google.StatusTerminated
+	spam.InstanceSummary.Preemptible = true
+	ham := s.NewBaseInstance(c, "ham")
+	ham.InstanceSummary.Status = google.StatusTerminated
+	s.FakeConn.Insts = []google.Instance{*spam, *ham}
+
+	ids, err := s.Env.ReclaimedInstances([]instance.Id{"spam", "ham", "eggs"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(ids, jc.DeepEquals, []instance.Id{"spam"})
+	c.Check(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "Instances")
+	c.Check(s.FakeConn.Calls[0].Statuses, jc.DeepEquals, []string{google.StatusTerminated})
+}
+
 func (s *environInstSuite) TestStateServerInstances(c *gc.C) {
 	s.FakeConn.Insts = []google.Instance{*s.BaseInstance}
 
@@ -4,7 +4,12 @@
 package google
 
 import (
+	"crypto/sha256"
+	"fmt"
+	"sort"
+
 	"github.com/juju/errors"
+	"google.golang.org/api/compute/v1"
 
 	"github.com/juju/juju/network"
 )
@@ -20,7 +25,11 @@ func (gce Connection) Ports(fwname string) ([]network.PortRange, error) {
 	if err != nil {
 		return nil, errors.Annotate(err, "while getting ports from GCE")
 	}
+	return firewallPorts(firewall)
+}
 
+// firewallPorts returns the port ranges the firewall allows.
+func firewallPorts(firewall *compute.Firewall) ([]network.PortRange, error) {
 	var ports []network.PortRange
 	for _, allowed := range firewall.Allowed {
 		for _, portRangeStr := range allowed.Ports {
@@ -43,6 +52,12 @@ func (gce Connection) Ports(fwname string) ([]network.PortRange, error) {
 // ports it already has open. The call blocks until the ports are
 // opened or the request fails.
 func (gce Connection) OpenPorts(fwname string, ports ...network.PortRange) error {
+	return gce.openPorts(fwname, fwname, network.AnySourceCIDR, ports)
+}
+
+// openPorts opens the port ranges to the source CIDR on the named
+// firewall, which applies to instances tagged with the target.
+func (gce Connection) openPorts(fwname, target, sourceCIDR string, ports []network.PortRange) error {
 	// TODO(ericsnow) Short-circuit if ports is empty.
 
 	// Compose the full set of open ports.
@@ -59,7 +74,7 @@ func (gce Connection) OpenPorts(fwname string, ports ...network.PortRange) error
 	// Send the request, depending on the current ports.
 	if currentPortsSet.IsEmpty() {
 		// Create a new firewall.
-		firewall := firewallSpec(fwname, inputPortsSet)
+		firewall := ingressFirewallSpec(fwname, target, sourceCIDR, inputPortsSet)
 		if err := gce.raw.AddFirewall(gce.projectID, firewall); err != nil {
 			return errors.Annotatef(err, "opening port(s) %+v", ports)
 		}
@@ -68,7 +83,7 @@ func (gce Connection) OpenPorts(fwname string, ports ...network.PortRange) error
 
 	// Update an existing firewall.
 	newPortsSet := currentPortsSet.Union(inputPortsSet)
-	firewall := firewallSpec(fwname, newPortsSet)
+	firewall := ingressFirewallSpec(fwname, target, sourceCIDR, newPortsSet)
 	if err := gce.raw.UpdateFirewall(gce.projectID, fwname, firewall); err != nil {
 		return errors.Annotatef(err, "opening port(s) %+v", ports)
 	}
@@ -82,6 +97,12 @@ func (gce Connection) OpenPorts(fwname string, ports ...network.PortRange) error
 // match the provided port ranges. The call blocks until the ports are
 // closed or the request fails.
 func (gce Connection) ClosePorts(fwname string, ports ...network.PortRange) error {
+	return gce.closePorts(fwname, fwname, network.AnySourceCIDR, ports)
+}
+
+// closePorts closes the port ranges to the source CIDR on the named
+// firewall, which applies to instances tagged with the target.
+func (gce Connection) closePorts(fwname, target, sourceCIDR string, ports []network.PortRange) error {
 	// Compose the full set of open ports.
 	currentPorts, err := gce.Ports(fwname)
 	if err != nil {
@@ -105,9 +126,113 @@ func (gce Connection) ClosePorts(fwname string, ports ...network.PortRange) erro
 	}
 
 	// Update an existing firewall.
-	firewall := firewallSpec(fwname, newPortsSet)
+	firewall := ingressFirewallSpec(fwname, target, sourceCIDR, newPortsSet)
 	if err := gce.raw.UpdateFirewall(gce.projectID, fwname, firewall); err != nil {
 		return errors.Annotatef(err, "closing port(s) %+v", ports)
 	}
 	return nil
 }
+
+// ingressFirewallName returns the name of the firewall opening ports
+// to the source CIDR on the instances the named firewall applies to.
+// GCE firewalls have a single set of source ranges, so port ranges
+// restricted to a source CIDR each get their own firewall.
+func ingressFirewallName(fwname, sourceCIDR string) string {
+	if sourceCIDR == network.AnySourceCIDR {
+		return fwname
+	}
+	sum := sha256.Sum256([]byte(sourceCIDR))
+	return fmt.Sprintf("%s-%x", fwname, sum[:4])
+}
+
+// IngressRules returns the ingress rules open on the instances the
+// named firewall applies to: its own port ranges, which are open to
+// any address, and those of the firewalls restricting port ranges to
+// source CIDRs on the same instances.
+func (gce Connection) IngressRules(fwname string) ([]network.IngressRule, error) {
+	ports, err := gce.Ports(fwname)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var rules []network.IngressRule
+	for _, portRange := range ports {
+		rules = append(rules, network.IngressRule{
+			PortRange:   portRange,
+			SourceCIDRs: []string{network.AnySourceCIDR},
+		})
+	}
+
+	firewalls, err := gce.raw.ListFirewalls(gce.projectID, fwname+"-")
+	if err != nil {
+		return nil, errors.Annotate(err, "while getting ingress rules from GCE")
+	}
+	for _, firewall := range firewalls {
+		if len(firewall.SourceRanges) != 1 {
+			continue
+		}
+		sourceCIDR := firewall.SourceRanges[0]
+		if firewall.Name != ingressFirewallName(fwname, sourceCIDR) {
+			continue
+		}
+		ports, err := firewallPorts(firewall)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, portRange := range ports {
+			rules = append(rules, network.IngressRule{
+				PortRange:   portRange,
+				SourceCIDRs: []string{sourceCIDR},
+			})
+		}
+	}
+	network.SortIngressRules(rules)
+	return rules, nil
+}
+
+// OpenIngressRules sends requests to the GCE API to open the port
+// ranges of the provided rules to their source CIDRs, on the instances
+// the named firewall applies to. Port ranges open to any address are
+// opened on the named firewall, as OpenPorts does.
+func (gce Connection) OpenIngressRules(fwname string, rules ...network.IngressRule) error {
+	byCIDR := portsBySourceCIDR(rules)
+	for _, sourceCIDR := range sortedKeys(byCIDR) {
+		name := ingressFirewallName(fwname, sourceCIDR)
+		if err := gce.openPorts(name, fwname, sourceCIDR, byCIDR[sourceCIDR]); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// CloseIngressRules sends requests to the GCE API to close the port
+// ranges of the provided rules to their source CIDRs, on the instances
+// the named firewall applies to.
+func (gce Connection) CloseIngressRules(fwname string, rules ...network.IngressRule) error {
+	byCIDR := portsBySourceCIDR(rules)
+	for _, sourceCIDR := range sortedKeys(byCIDR) {
+		name := ingressFirewallName(fwname, sourceCIDR)
+		if err := gce.closePorts(name, fwname, sourceCIDR, byCIDR[sourceCIDR]); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+func portsBySourceCIDR(rules []network.IngressRule) map[string][]network.PortRange {
+	byCIDR := make(map[string][]network.PortRange)
+	for _, rule := range rules {
+		for _, sourceCIDR := range rule.SourceCIDRs {
+			byCIDR[sourceCIDR] = append(byCIDR[sourceCIDR], rule.PortRange)
+		}
+	}
+	return byCIDR
+}
+
+func sortedKeys(byCIDR map[string][]network.PortRange) []string {
+	var keys []string
+	for key := range byCIDR {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
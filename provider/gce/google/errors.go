@@ -5,8 +5,11 @@ package google
 
 import (
 	"fmt"
+	"net/http"
+	"strings"
 
 	"github.com/juju/errors"
+	"google.golang.org/api/googleapi"
 )
 
 // InvalidConfigValue indicates that one of the config values failed validation.
@@ -80,3 +83,18 @@ func (err InvalidConfigValue) Underlying() error {
 func (err InvalidConfigValue) Error() string {
 	return fmt.Sprintf("invalid config value (%s) for %q: %v", err.Value, err.Key, err.Reason)
 }
+
+// IsAuthorisationFailure returns whether or not the provided error (or
+// its cause) shows that GCE rejected the connection's credentials,
+// either when fetching an OAuth token or when making an API call.
+func IsAuthorisationFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+	err = errors.Cause(err)
+	if apiErr, ok := err.(*googleapi.Error); ok {
+		return apiErr.Code == http.StatusUnauthorized
+	}
+	// Token failures are not typed by the oauth2 package.
+	return strings.Contains(err.Error(), "oauth2: cannot fetch token")
+}
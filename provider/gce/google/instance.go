@@ -49,6 +49,11 @@ type InstanceSpec struct {
 	// useful when making bulk calls or in relation to some API methods
 	// (e.g. related to firewalls access rules).
 	Tags []string
+	// Preemptible indicates whether the instance should be started on
+	// GCE's discounted capacity, which may be reclaimed at any time.
+	// Preemptible instances are terminated rather than migrated during
+	// maintenance, and are not restarted automatically.
+	Preemptible bool
 }
 
 func (is InstanceSpec) raw() *compute.Instance {
@@ -58,10 +63,22 @@ func (is InstanceSpec) raw() *compute.Instance {
 		NetworkInterfaces: is.networkInterfaces(),
 		Metadata:          packMetadata(is.Metadata),
 		Tags:              &compute.Tags{Items: is.Tags},
+		Scheduling:        is.scheduling(),
 		// MachineType is set in the addInstance call.
 	}
 }
 
+func (is InstanceSpec) scheduling() *compute.Scheduling {
+	if !is.Preemptible {
+		return nil
+	}
+	return &compute.Scheduling{
+		Preemptible:       true,
+		OnHostMaintenance: "TERMINATE",
+		AutomaticRestart:  false,
+	}
+}
+
 // Summary builds an InstanceSummary based on the spec and returns it.
 func (is InstanceSpec) Summary() InstanceSummary {
 	raw := is.raw()
@@ -105,15 +122,19 @@ type InstanceSummary struct {
 	Metadata map[string]string
 	// Addresses are the IP Addresses associated with the instance.
 	Addresses []network.Address
+	// Preemptible indicates whether the instance runs on capacity
+	// that GCE may reclaim.
+	Preemptible bool
 }
 
 func newInstanceSummary(raw *compute.Instance) InstanceSummary {
 	return InstanceSummary{
-		ID:        raw.Name,
-		ZoneName:  path.Base(raw.Zone),
-		Status:    raw.Status,
-		Metadata:  unpackMetadata(raw.Metadata),
-		Addresses: extractAddresses(raw.NetworkInterfaces...),
+		ID:          raw.Name,
+		ZoneName:    path.Base(raw.Zone),
+		Status:      raw.Status,
+		Metadata:    unpackMetadata(raw.Metadata),
+		Addresses:   extractAddresses(raw.NetworkInterfaces...),
+		Preemptible: raw.Scheduling != nil && raw.Scheduling.Preemptible,
 	}
 }
 
@@ -168,6 +189,12 @@ func (gi Instance) Status() string {
 	return gi.InstanceSummary.Status
 }
 
+// Reclaimed returns whether the instance was preemptible and has been
+// terminated by GCE.
+func (gi Instance) Reclaimed() bool {
+	return gi.Preemptible && gi.Status() == StatusTerminated
+}
+
 // InstGetter exposes the Connection functionality needed by refresh.
 type InstGetter interface {
 	// Instance gets the up-to-date info about the given instance
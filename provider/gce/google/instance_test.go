@@ -36,6 +36,22 @@ func (s *instanceSuite) TestNewInstanceNoSpec(c *gc.C) {
 	c.Check(spec, gc.IsNil)
 }
 
+func (s *instanceSuite) TestNewInstancePreemptible(c *gc.C) {
+	s.InstanceSpec.Preemptible = true
+	raw := google.InstanceSpecRaw(s.InstanceSpec)
+	c.Check(raw.Scheduling, jc.DeepEquals, &compute.Scheduling{
+		Preemptible:       true,
+		OnHostMaintenance: "TERMINATE",
+	})
+
+	inst := google.NewInstanceRaw(raw, &s.InstanceSpec)
+	c.Check(inst.Preemptible, jc.IsTrue)
+	c.Check(inst.Reclaimed(), jc.IsFalse)
+
+	inst.InstanceSummary.Status = google.StatusTerminated
+	c.Check(inst.Reclaimed(), jc.IsTrue)
+}
+
 func (s *instanceSuite) TestInstanceRootDiskGB(c *gc.C) {
 	size := s.Instance.RootDiskGB()
 
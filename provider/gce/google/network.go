@@ -58,14 +58,21 @@ func (ns *NetworkSpec) newInterface(name string) *compute.NetworkInterface {
 // firewallSpec expands a port range set in to compute.FirewallAllowed
 // and returns a compute.Firewall for the provided name.
 func firewallSpec(name string, ps network.PortSet) *compute.Firewall {
+	return ingressFirewallSpec(name, name, network.AnySourceCIDR, ps)
+}
+
+// ingressFirewallSpec returns a compute.Firewall for the provided name
+// that opens the port range set, to the source CIDR only, on instances
+// tagged with the target.
+func ingressFirewallSpec(name, target, sourceCIDR string, ps network.PortSet) *compute.Firewall {
 	firewall := compute.Firewall{
 		// Allowed is set below.
 		// Description is not set.
 		Name: name,
 		// Network: (defaults to global)
 		// SourceTags is not set.
-		TargetTags:   []string{name},
-		SourceRanges: []string{"0.0.0.0/0"},
+		TargetTags:   []string{target},
+		SourceRanges: []string{sourceCIDR},
 	}
 
 	for _, protocol := range ps.Protocols() {
@@ -77,36 +84,3 @@ func firewallSpec(name string, ps network.PortSet) *compute.Firewall {
 	}
 	return &firewall
 }
-
-func extractAddresses(interfaces ...*compute.NetworkInterface) []network.Address {
-	var addresses []network.Address
-
-	for _, netif := range interfaces {
-		// Add public addresses.
-		for _, accessConfig := range netif.AccessConfigs {
-			if accessConfig.NatIP == "" {
-				continue
-			}
-			address := network.Address{
-				Value: accessConfig.NatIP,
-				Type:  network.IPv4Address,
-				Scope: network.ScopePublic,
-			}
-			addresses = append(addresses, address)
-
-		}
-
-		// Add private address.
-		if netif.NetworkIP == "" {
-			continue
-		}
-		address := network.Address{
-			Value: netif.NetworkIP,
-			Type:  network.IPv4Address,
-			Scope: network.ScopeCloudLocal,
-		}
-		addresses = append(addresses, address)
-	}
-
-	return addresses
-}
@@ -130,6 +130,25 @@ func (rc *rawConn) GetFirewall(projectID, name string) (*compute.Firewall, error
 	return firewallList.Items[0], nil
 }
 
+func (rc *rawConn) ListFirewalls(projectID, prefix string) ([]*compute.Firewall, error) {
+	call := rc.Firewalls.List(projectID)
+	call = call.Filter("name eq " + prefix + ".*")
+
+	var results []*compute.Firewall
+	for {
+		firewallList, err := call.Do()
+		if err != nil {
+			return nil, errors.Annotate(err, "while listing firewalls from GCE")
+		}
+		results = append(results, firewallList.Items...)
+		if firewallList.NextPageToken == "" {
+			break
+		}
+		call = call.PageToken(firewallList.NextPageToken)
+	}
+	return results, nil
+}
+
 func (rc *rawConn) AddFirewall(projectID string, firewall *compute.Firewall) error {
 	call := rc.Firewalls.Insert(projectID, firewall)
 	operation, err := call.Do()
@@ -62,6 +62,8 @@ func (env *joyentEnviron) machineFullName(machineId string) string {
 var unsupportedConstraints = []string{
 	constraints.CpuPower,
 	constraints.Tags,
+	constraints.Spot,
+	constraints.SpotPrice,
 }
 
 // ConstraintsValidator is defined on the Environs interface.
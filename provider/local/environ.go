@@ -344,6 +344,8 @@ var unsupportedConstraints = []string{
 	constraints.CpuPower,
 	constraints.InstanceType,
 	constraints.Tags,
+	constraints.Spot,
+	constraints.SpotPrice,
 }
 
 // ConstraintsValidator is defined on the Environs interface.
@@ -375,6 +375,18 @@ func (s *localJujuTestSuite) TestConstraintsValidator(c *gc.C) {
 	c.Assert(unsupported, jc.SameContents, []string{"cpu-cores", "cpu-power", "instance-type", "tags"})
 }
 
+func (s *localJujuTestSuite) TestConstraintsValidatorSpot(c *gc.C) {
+	ctx := envtesting.BootstrapContext(c)
+	env, err := local.Provider.PrepareForBootstrap(ctx, minimalConfig(c))
+	c.Assert(err, jc.ErrorIsNil)
+	validator, err := env.ConstraintsValidator()
+	c.Assert(err, jc.ErrorIsNil)
+	cons := constraints.MustParse(fmt.Sprintf("arch=%s spot=true spot-price=0.05", arch.HostArch()))
+	unsupported, err := validator.Validate(cons)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unsupported, jc.SameContents, []string{"spot", "spot-price"})
+}
+
 func (s *localJujuTestSuite) TestConstraintsValidatorVocab(c *gc.C) {
 	env := s.Prepare(c)
 	validator, err := env.ConstraintsValidator()
@@ -745,6 +745,8 @@ func (environ *maasEnviron) startNode(node gomaasapi.MAASObject, series string,
 var unsupportedConstraints = []string{
 	constraints.CpuPower,
 	constraints.InstanceType,
+	constraints.Spot,
+	constraints.SpotPrice,
 }
 
 // ConstraintsValidator is defined on the Environs interface.
@@ -4,6 +4,7 @@
 package openstack
 
 import (
+	"fmt"
 	"math"
 	"net/url"
 	"time"
@@ -157,6 +158,7 @@ func (s *cinderVolumeSource) createVolume(arg storage.VolumeParams) (storage.Vol
 		// TODO(axw) use the AZ of the initially attached machine.
 		AvailabilityZone: "",
 		Metadata:         metadata,
+		SnapshotId:       arg.Snapshot,
 	})
 	if err != nil {
 		return storage.Volume{}, errors.Trace(err)
@@ -302,6 +304,70 @@ func (s *cinderVolumeSource) DetachVolumes(args []storage.VolumeAttachmentParams
 	return nil
 }
 
+// ResizeVolumes implements storage.VolumeSource.
+func (s *cinderVolumeSource) ResizeVolumes(args []storage.VolumeResizeParams) ([]uint64, []error) {
+	errs := make([]error, len(args))
+	for i, arg := range args {
+		errs[i] = errors.NotSupportedf("resizing cinder volume %v", arg.VolumeId)
+	}
+	return make([]uint64, len(args)), errs
+}
+
+// CreateVolumeSnapshots implements storage.VolumeSource.
+func (s *cinderVolumeSource) CreateVolumeSnapshots(args []storage.VolumeSnapshotParams) ([]storage.VolumeSnapshot, error) {
+	snapshots := make([]storage.VolumeSnapshot, len(args))
+	for i, arg := range args {
+		cinderSnapshot, err := s.storageAdapter.CreateSnapshot(cinder.CreateSnapshotSnapshotParams{
+			VolumeId: arg.VolumeId,
+			Name:     fmt.Sprintf("juju-%s-snapshot-%s", s.envName, arg.Snapshot),
+			// Snapshots are taken of volumes while they
+			// are attached; this requires force.
+			Force: true,
+		})
+		if err != nil {
+			return nil, errors.Annotatef(err, "creating snapshot of %v", arg.VolumeId)
+		}
+		logger.Debugf("created snapshot: %+v", cinderSnapshot)
+		snapshots[i] = storage.VolumeSnapshot{arg.Snapshot, cinderToJujuVolumeSnapshotInfo(cinderSnapshot)}
+	}
+	return snapshots, nil
+}
+
+// ListVolumeSnapshots implements storage.VolumeSource.
+func (s *cinderVolumeSource) ListVolumeSnapshots(volumeId string) ([]storage.VolumeSnapshotInfo, error) {
+	cinderSnapshots, err := s.storageAdapter.GetSnapshotsSimple()
+	if err != nil {
+		return nil, err
+	}
+	var snapshots []storage.VolumeSnapshotInfo
+	for i, snapshot := range cinderSnapshots {
+		if snapshot.VolumeID != volumeId {
+			continue
+		}
+		snapshots = append(snapshots, cinderToJujuVolumeSnapshotInfo(&cinderSnapshots[i]))
+	}
+	return snapshots, nil
+}
+
+// DestroyVolumeSnapshots implements storage.VolumeSource.
+func (s *cinderVolumeSource) DestroyVolumeSnapshots(snapshotIds []string) []error {
+	errors := make([]error, len(snapshotIds))
+	for i, snapshotId := range snapshotIds {
+		if err := s.storageAdapter.DeleteSnapshot(snapshotId); err != nil {
+			errors[i] = err
+		}
+	}
+	return errors
+}
+
+func cinderToJujuVolumeSnapshotInfo(snapshot *cinder.Snapshot) storage.VolumeSnapshotInfo {
+	return storage.VolumeSnapshotInfo{
+		SnapshotId: snapshot.ID,
+		VolumeId:   snapshot.VolumeID,
+		Size:       uint64(snapshot.Size * 1024),
+	}
+}
+
 func cinderToJujuVolumeInfo(volume *cinder.Volume) storage.VolumeInfo {
 	return storage.VolumeInfo{
 		VolumeId: volume.ID,
@@ -340,6 +406,9 @@ type openstackStorage interface {
 	AttachVolume(serverId, volumeId, mountPoint string) (*nova.VolumeAttachment, error)
 	DetachVolume(serverId, attachmentId string) error
 	ListVolumeAttachments(serverId string) ([]nova.VolumeAttachment, error)
+	CreateSnapshot(cinder.CreateSnapshotSnapshotParams) (*cinder.Snapshot, error)
+	GetSnapshotsSimple() ([]cinder.Snapshot, error)
+	DeleteSnapshot(snapshotId string) error
 }
 
 func newOpenstackStorageAdapter(environConfig *config.Config) (openstackStorage, error) {
@@ -403,3 +472,21 @@ func (ga *openstackStorageAdapter) GetVolume(volumeId string) (*cinder.Volume, e
 	}
 	return &resp.Volume, nil
 }
+
+// CreateSnapshot is part of the openstackStorage interface.
+func (ga *openstackStorageAdapter) CreateSnapshot(args cinder.CreateSnapshotSnapshotParams) (*cinder.Snapshot, error) {
+	resp, err := ga.cinderClient.CreateSnapshot(args)
+	if err != nil {
+		return nil, err
+	}
+	return &resp.Snapshot, nil
+}
+
+// GetSnapshotsSimple is part of the openstackStorage interface.
+func (ga *openstackStorageAdapter) GetSnapshotsSimple() ([]cinder.Snapshot, error) {
+	resp, err := ga.cinderClient.GetSnapshotsSimple()
+	if err != nil {
+		return nil, err
+	}
+	return resp.Snapshots, nil
+}
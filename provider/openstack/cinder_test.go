@@ -393,6 +393,152 @@ func (s *cinderVolumeSourceSuite) TestCreateVolumeCleanupDestroys(c *gc.C) {
 	c.Assert(numDestroyCalls, gc.Equals, 4)
 }
 
+func (s *cinderVolumeSourceSuite) TestCreateVolumeFromSnapshot(c *gc.C) {
+	var created bool
+	mockAdapter := &mockAdapter{
+		createVolume: func(args cinder.CreateVolumeVolumeParams) (*cinder.Volume, error) {
+			created = true
+			c.Assert(args, jc.DeepEquals, cinder.CreateVolumeVolumeParams{
+				Size:       1,
+				Name:       "juju-testenv-volume-123",
+				SnapshotId: "snap-id",
+			})
+			return &cinder.Volume{ID: mockVolId}, nil
+		},
+		getVolume: func(volumeId string) (*cinder.Volume, error) {
+			return &cinder.Volume{
+				ID:     volumeId,
+				Size:   1,
+				Status: "available",
+			}, nil
+		},
+		attachVolume: func(serverId, volId, mountPoint string) (*nova.VolumeAttachment, error) {
+			return &nova.VolumeAttachment{
+				Id:       volId,
+				VolumeId: volId,
+				ServerId: serverId,
+				Device:   "/dev/sda",
+			}, nil
+		},
+	}
+
+	volSource := openstack.NewCinderVolumeSource(mockAdapter)
+	_, _, err := volSource.CreateVolumes([]storage.VolumeParams{{
+		Provider: openstack.CinderProviderType,
+		Tag:      mockVolumeTag,
+		Size:     1024,
+		Snapshot: "snap-id",
+		Attachment: &storage.VolumeAttachmentParams{
+			AttachmentParams: storage.AttachmentParams{
+				Provider:   openstack.CinderProviderType,
+				Machine:    mockMachineTag,
+				InstanceId: instance.Id(mockServerId),
+			},
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(created, jc.IsTrue)
+}
+
+func (s *cinderVolumeSourceSuite) TestCreateVolumeSnapshots(c *gc.C) {
+	mockAdapter := &mockAdapter{
+		createSnapshot: func(args cinder.CreateSnapshotSnapshotParams) (*cinder.Snapshot, error) {
+			c.Assert(args, jc.DeepEquals, cinder.CreateSnapshotSnapshotParams{
+				VolumeId: mockVolId,
+				Name:     "juju-testenv-snapshot-7",
+				Force:    true,
+			})
+			return &cinder.Snapshot{
+				ID:       "snap-id",
+				VolumeID: mockVolId,
+				Size:     mockVolSize / 1024,
+			}, nil
+		},
+	}
+	volSource := openstack.NewCinderVolumeSource(mockAdapter)
+	snapshots, err := volSource.CreateVolumeSnapshots([]storage.VolumeSnapshotParams{{
+		Snapshot: "7",
+		Volume:   mockVolumeTag,
+		VolumeId: mockVolId,
+		Provider: openstack.CinderProviderType,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(snapshots, jc.DeepEquals, []storage.VolumeSnapshot{{
+		"7",
+		storage.VolumeSnapshotInfo{
+			SnapshotId: "snap-id",
+			VolumeId:   mockVolId,
+			Size:       mockVolSize,
+		},
+	}})
+}
+
+func (s *cinderVolumeSourceSuite) TestCreateVolumeSnapshotsFails(c *gc.C) {
+	mockAdapter := &mockAdapter{
+		createSnapshot: func(args cinder.CreateSnapshotSnapshotParams) (*cinder.Snapshot, error) {
+			return nil, errors.New("no snapshot for you")
+		},
+	}
+	volSource := openstack.NewCinderVolumeSource(mockAdapter)
+	_, err := volSource.CreateVolumeSnapshots([]storage.VolumeSnapshotParams{{
+		Snapshot: "7",
+		Volume:   mockVolumeTag,
+		VolumeId: mockVolId,
+	}})
+	c.Assert(err, gc.ErrorMatches, "creating snapshot of 0: no snapshot for you")
+}
+
+func (s *cinderVolumeSourceSuite) TestListVolumeSnapshots(c *gc.C) {
+	mockAdapter := &mockAdapter{
+		getSnapshotsSimple: func() ([]cinder.Snapshot, error) {
+			return []cinder.Snapshot{{
+				ID:       "snap-0",
+				VolumeID: mockVolId,
+				Size:     1,
+			}, {
+				ID:       "snap-1",
+				VolumeID: "some-other-volume",
+				Size:     1,
+			}, {
+				ID:       "snap-2",
+				VolumeID: mockVolId,
+				Size:     2,
+			}}, nil
+		},
+	}
+	volSource := openstack.NewCinderVolumeSource(mockAdapter)
+	snapshots, err := volSource.ListVolumeSnapshots(mockVolId)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(snapshots, jc.DeepEquals, []storage.VolumeSnapshotInfo{{
+		SnapshotId: "snap-0",
+		VolumeId:   mockVolId,
+		Size:       1024,
+	}, {
+		SnapshotId: "snap-2",
+		VolumeId:   mockVolId,
+		Size:       2048,
+	}})
+}
+
+func (s *cinderVolumeSourceSuite) TestDestroyVolumeSnapshots(c *gc.C) {
+	var deleted []string
+	mockAdapter := &mockAdapter{
+		deleteSnapshot: func(snapshotId string) error {
+			deleted = append(deleted, snapshotId)
+			if snapshotId == "snap-1" {
+				return errors.New("oops")
+			}
+			return nil
+		},
+	}
+	volSource := openstack.NewCinderVolumeSource(mockAdapter)
+	errs := volSource.DestroyVolumeSnapshots([]string{"snap-0", "snap-1"})
+	c.Assert(errs, gc.HasLen, 2)
+	c.Assert(errs[0], jc.ErrorIsNil)
+	c.Assert(errs[1], gc.ErrorMatches, "oops")
+	c.Assert(deleted, jc.DeepEquals, []string{"snap-0", "snap-1"})
+}
+
 type mockAdapter struct {
 	getVolume             func(string) (*cinder.Volume, error)
 	getVolumesSimple      func() ([]cinder.Volume, error)
@@ -402,6 +548,9 @@ type mockAdapter struct {
 	volumeStatusNotifier  func(string, string, int, time.Duration) <-chan error
 	detachVolume          func(string, string) error
 	listVolumeAttachments func(string) ([]nova.VolumeAttachment, error)
+	createSnapshot        func(cinder.CreateSnapshotSnapshotParams) (*cinder.Snapshot, error)
+	getSnapshotsSimple    func() ([]cinder.Snapshot, error)
+	deleteSnapshot        func(string) error
 }
 
 func (ma *mockAdapter) GetVolume(volumeId string) (*cinder.Volume, error) {
@@ -455,3 +604,24 @@ func (ma *mockAdapter) ListVolumeAttachments(serverId string) ([]nova.VolumeAtta
 	}
 	return nil, nil
 }
+
+func (ma *mockAdapter) CreateSnapshot(args cinder.CreateSnapshotSnapshotParams) (*cinder.Snapshot, error) {
+	if ma.createSnapshot != nil {
+		return ma.createSnapshot(args)
+	}
+	return nil, errors.NotImplementedf("CreateSnapshot")
+}
+
+func (ma *mockAdapter) GetSnapshotsSimple() ([]cinder.Snapshot, error) {
+	if ma.getSnapshotsSimple != nil {
+		return ma.getSnapshotsSimple()
+	}
+	return nil, nil
+}
+
+func (ma *mockAdapter) DeleteSnapshot(snapshotId string) error {
+	if ma.deleteSnapshot != nil {
+		return ma.deleteSnapshot(snapshotId)
+	}
+	return nil
+}
@@ -40,6 +40,8 @@ var (
 	CinderAttempt  = &cinderAttempt
 )
 
+var MaybeConvertCredentialError = maybeConvertCredentialError
+
 // MetadataStorage returns a Storage instance which is used to store simplestreams metadata for tests.
 func MetadataStorage(e environs.Environ) envstorage.Storage {
 	ecfg := e.(*environ).ecfg()
@@ -356,6 +358,7 @@ func ResolveNetwork(e environs.Environ, networkName string) (string, error) {
 
 var PortsToRuleInfo = portsToRuleInfo
 var RuleMatchesPortRange = ruleMatchesPortRange
+var IngressRulesToRuleInfo = ingressRulesToRuleInfo
 
 var MakeServiceURL = &makeServiceURL
 var ProviderInstance = providerInstance
@@ -363,6 +363,7 @@ type environ struct {
 }
 
 var _ environs.Environ = (*environ)(nil)
+var _ environs.IngressFirewall = (*environ)(nil)
 var _ simplestreams.HasRegion = (*environ)(nil)
 var _ state.Prechecker = (*environ)(nil)
 var _ state.InstanceDistributor = (*environ)(nil)
@@ -383,6 +384,7 @@ func (inst *openstackInstance) String() string {
 }
 
 var _ instance.Instance = (*openstackInstance)(nil)
+var _ environs.InstanceIngressFirewall = (*openstackInstance)(nil)
 
 func (inst *openstackInstance) Refresh() error {
 	inst.mu.Lock()
@@ -575,6 +577,8 @@ func (e *environ) SupportedArchitectures() ([]string, error) {
 var unsupportedConstraints = []string{
 	constraints.Tags,
 	constraints.CpuPower,
+	constraints.Spot,
+	constraints.SpotPrice,
 }
 
 // ConstraintsValidator is defined on the Environs interface.
@@ -941,7 +945,10 @@ func (*environ) MaintainInstance(args environs.StartInstanceParams) error {
 }
 
 // StartInstance is specified in the InstanceBroker interface.
-func (e *environ) StartInstance(args environs.StartInstanceParams) (*environs.StartInstanceResult, error) {
+func (e *environ) StartInstance(args environs.StartInstanceParams) (_ *environs.StartInstanceResult, resultErr error) {
+	defer func() {
+		resultErr = maybeConvertCredentialError(resultErr)
+	}()
 	var availabilityZones []string
 	if args.Placement != "" {
 		placement, err := e.parsePlacement(args.Placement)
@@ -1111,6 +1118,16 @@ func (e *environ) StartInstance(args environs.StartInstanceParams) (*environs.St
 	}, nil
 }
 
+// maybeConvertCredentialError returns err wrapped so that it satisfies
+// errors.IsUnauthorized if its cause shows that OpenStack rejected the
+// configured credentials, and err unchanged otherwise.
+func maybeConvertCredentialError(err error) error {
+	if err != nil && gooseerrors.IsUnauthorised(errors.Cause(err)) {
+		return errors.NewUnauthorized(err, "")
+	}
+	return err
+}
+
 func isNoValidHostsError(err error) bool {
 	gooseErr, ok := err.(gooseerrors.Error)
 	return ok && strings.Contains(gooseErr.Cause().Error(), "No valid host was found")
@@ -1269,7 +1286,7 @@ func (e *environ) Instances(ids []instance.Id) ([]instance.Instance, error) {
 func (e *environ) AllInstances() (insts []instance.Instance, err error) {
 	servers, err := e.nova().ListServersDetail(e.machinesFilter())
 	if err != nil {
-		return nil, err
+		return nil, maybeConvertCredentialError(err)
 	}
 	instsById := make(map[string]instance.Instance)
 	for _, server := range servers {
@@ -1337,6 +1354,47 @@ func resourceName(tag names.Tag, envName string) string {
 	return fmt.Sprintf("juju-%s-%s", envName, tag)
 }
 
+// OpenIngressRules is specified in the environs.InstanceIngressFirewall
+// interface.
+func (inst *openstackInstance) OpenIngressRules(machineId string, rules []network.IngressRule) error {
+	if inst.e.Config().FirewallMode() != config.FwInstance {
+		return fmt.Errorf("invalid firewall mode %q for opening ingress rules on instance",
+			inst.e.Config().FirewallMode())
+	}
+	name := inst.e.machineGroupName(machineId)
+	if err := inst.e.openIngressRulesInGroup(name, rules); err != nil {
+		return err
+	}
+	logger.Infof("opened ingress rules in security group %s: %v", name, rules)
+	return nil
+}
+
+// CloseIngressRules is specified in the environs.InstanceIngressFirewall
+// interface.
+func (inst *openstackInstance) CloseIngressRules(machineId string, rules []network.IngressRule) error {
+	if inst.e.Config().FirewallMode() != config.FwInstance {
+		return fmt.Errorf("invalid firewall mode %q for closing ingress rules on instance",
+			inst.e.Config().FirewallMode())
+	}
+	name := inst.e.machineGroupName(machineId)
+	if err := inst.e.closeIngressRulesInGroup(name, rules); err != nil {
+		return err
+	}
+	logger.Infof("closed ingress rules in security group %s: %v", name, rules)
+	return nil
+}
+
+// IngressRules is specified in the environs.InstanceIngressFirewall
+// interface.
+func (inst *openstackInstance) IngressRules(machineId string) ([]network.IngressRule, error) {
+	if inst.e.Config().FirewallMode() != config.FwInstance {
+		return nil, fmt.Errorf("invalid firewall mode %q for retrieving ingress rules from instance",
+			inst.e.Config().FirewallMode())
+	}
+	name := inst.e.machineGroupName(machineId)
+	return inst.e.ingressRulesInGroup(name)
+}
+
 // machinesFilter returns a nova.Filter matching all machines in the environment.
 func (e *environ) machinesFilter() *nova.Filter {
 	filter := nova.NewFilter()
@@ -1353,19 +1411,49 @@ func portsToRuleInfo(groupId string, ports []network.PortRange) []nova.RuleInfo
 			FromPort:      portRange.FromPort,
 			ToPort:        portRange.ToPort,
 			IPProtocol:    portRange.Protocol,
-			Cidr:          "0.0.0.0/0",
+			Cidr:          network.AnySourceCIDR,
+		}
+	}
+	return rules
+}
+
+// ingressRulesToRuleInfo maps ingress rules to nova rules, one for
+// each port range and source CIDR.
+func ingressRulesToRuleInfo(groupId string, ingressRules []network.IngressRule) []nova.RuleInfo {
+	var rules []nova.RuleInfo
+	for _, ingressRule := range ingressRules {
+		for _, cidr := range ingressRule.SourceCIDRs {
+			rules = append(rules, nova.RuleInfo{
+				ParentGroupId: groupId,
+				FromPort:      ingressRule.FromPort,
+				ToPort:        ingressRule.ToPort,
+				IPProtocol:    ingressRule.Protocol,
+				Cidr:          cidr,
+			})
 		}
 	}
 	return rules
 }
 
 func (e *environ) openPortsInGroup(name string, portRanges []network.PortRange) error {
+	return e.createRulesInGroup(name, func(groupId string) []nova.RuleInfo {
+		return portsToRuleInfo(groupId, portRanges)
+	})
+}
+
+func (e *environ) openIngressRulesInGroup(name string, ingressRules []network.IngressRule) error {
+	return e.createRulesInGroup(name, func(groupId string) []nova.RuleInfo {
+		return ingressRulesToRuleInfo(groupId, ingressRules)
+	})
+}
+
+func (e *environ) createRulesInGroup(name string, ruleInfo func(groupId string) []nova.RuleInfo) error {
 	novaclient := e.nova()
 	group, err := novaclient.SecurityGroupByName(name)
 	if err != nil {
 		return err
 	}
-	rules := portsToRuleInfo(group.Id, portRanges)
+	rules := ruleInfo(group.Id)
 	for _, rule := range rules {
 		_, err := novaclient.CreateSecurityGroupRule(rule)
 		if err != nil {
@@ -1376,18 +1464,45 @@ func (e *environ) openPortsInGroup(name string, portRanges []network.PortRange)
 	return nil
 }
 
+// ruleSourceCIDR returns the source CIDR of the nova security group
+// rule. Rules without one are open to any address.
+func ruleSourceCIDR(rule nova.SecurityGroupRule) string {
+	if cidr := rule.IPRange["cidr"]; cidr != "" {
+		return cidr
+	}
+	return network.AnySourceCIDR
+}
+
 // ruleMatchesPortRange checks if supplied nova security group rule matches the port range
 func ruleMatchesPortRange(rule nova.SecurityGroupRule, portRange network.PortRange) bool {
+	return ruleMatches(rule, portRange, network.AnySourceCIDR)
+}
+
+// ruleMatches checks if supplied nova security group rule matches the
+// port range and source CIDR.
+func ruleMatches(rule nova.SecurityGroupRule, portRange network.PortRange, cidr string) bool {
 	if rule.IPProtocol == nil || rule.FromPort == nil || rule.ToPort == nil {
 		return false
 	}
 	return *rule.IPProtocol == portRange.Protocol &&
 		*rule.FromPort == portRange.FromPort &&
-		*rule.ToPort == portRange.ToPort
+		*rule.ToPort == portRange.ToPort &&
+		ruleSourceCIDR(rule) == cidr
 }
 
 func (e *environ) closePortsInGroup(name string, portRanges []network.PortRange) error {
-	if len(portRanges) == 0 {
+	ingressRules := make([]network.IngressRule, len(portRanges))
+	for i, portRange := range portRanges {
+		ingressRules[i] = network.IngressRule{
+			PortRange:   portRange,
+			SourceCIDRs: []string{network.AnySourceCIDR},
+		}
+	}
+	return e.closeIngressRulesInGroup(name, ingressRules)
+}
+
+func (e *environ) closeIngressRulesInGroup(name string, ingressRules []network.IngressRule) error {
+	if len(ingressRules) == 0 {
 		return nil
 	}
 	novaclient := e.nova()
@@ -1396,27 +1511,35 @@ func (e *environ) closePortsInGroup(name string, portRanges []network.PortRange)
 		return err
 	}
 	// TODO: Hey look ma, it's quadratic
-	for _, portRange := range portRanges {
-		for _, p := range (*group).Rules {
-			if !ruleMatchesPortRange(p, portRange) {
-				continue
-			}
-			err := novaclient.DeleteSecurityGroupRule(p.Id)
-			if err != nil {
-				return err
+	for _, ingressRule := range ingressRules {
+		for _, cidr := range ingressRule.SourceCIDRs {
+			for _, p := range (*group).Rules {
+				if !ruleMatches(p, ingressRule.PortRange, cidr) {
+					continue
+				}
+				err := novaclient.DeleteSecurityGroupRule(p.Id)
+				if err != nil {
+					return err
+				}
+				break
 			}
-			break
 		}
 	}
 	return nil
 }
 
+// portsInGroup returns the port ranges open to any address in the
+// named group. Port ranges only open to some source CIDRs are
+// reported by ingressRulesInGroup.
 func (e *environ) portsInGroup(name string) (portRanges []network.PortRange, err error) {
 	group, err := e.nova().SecurityGroupByName(name)
 	if err != nil {
 		return nil, err
 	}
 	for _, p := range (*group).Rules {
+		if ruleSourceCIDR(p) != network.AnySourceCIDR {
+			continue
+		}
 		portRanges = append(portRanges, network.PortRange{
 			Protocol: *p.IPProtocol,
 			FromPort: *p.FromPort,
@@ -1427,6 +1550,27 @@ func (e *environ) portsInGroup(name string) (portRanges []network.PortRange, err
 	return portRanges, nil
 }
 
+// ingressRulesInGroup returns the ingress rules of the named group,
+// one for each nova security group rule.
+func (e *environ) ingressRulesInGroup(name string) (ingressRules []network.IngressRule, err error) {
+	group, err := e.nova().SecurityGroupByName(name)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range (*group).Rules {
+		ingressRules = append(ingressRules, network.IngressRule{
+			PortRange: network.PortRange{
+				Protocol: *p.IPProtocol,
+				FromPort: *p.FromPort,
+				ToPort:   *p.ToPort,
+			},
+			SourceCIDRs: []string{ruleSourceCIDR(p)},
+		})
+	}
+	network.SortIngressRules(ingressRules)
+	return ingressRules, nil
+}
+
 // TODO: following 30 lines nearly verbatim from environs/ec2
 
 func (e *environ) OpenPorts(ports []network.PortRange) error {
@@ -1461,6 +1605,41 @@ func (e *environ) Ports() ([]network.PortRange, error) {
 	return e.portsInGroup(e.globalGroupName())
 }
 
+// OpenIngressRules is specified in the environs.IngressFirewall interface.
+func (e *environ) OpenIngressRules(rules []network.IngressRule) error {
+	if e.Config().FirewallMode() != config.FwGlobal {
+		return fmt.Errorf("invalid firewall mode %q for opening ingress rules on environment",
+			e.Config().FirewallMode())
+	}
+	if err := e.openIngressRulesInGroup(e.globalGroupName(), rules); err != nil {
+		return err
+	}
+	logger.Infof("opened ingress rules in global group: %v", rules)
+	return nil
+}
+
+// CloseIngressRules is specified in the environs.IngressFirewall interface.
+func (e *environ) CloseIngressRules(rules []network.IngressRule) error {
+	if e.Config().FirewallMode() != config.FwGlobal {
+		return fmt.Errorf("invalid firewall mode %q for closing ingress rules on environment",
+			e.Config().FirewallMode())
+	}
+	if err := e.closeIngressRulesInGroup(e.globalGroupName(), rules); err != nil {
+		return err
+	}
+	logger.Infof("closed ingress rules in global group: %v", rules)
+	return nil
+}
+
+// IngressRules is specified in the environs.IngressFirewall interface.
+func (e *environ) IngressRules() ([]network.IngressRule, error) {
+	if e.Config().FirewallMode() != config.FwGlobal {
+		return nil, fmt.Errorf("invalid firewall mode %q for retrieving ingress rules from environment",
+			e.Config().FirewallMode())
+	}
+	return e.ingressRulesInGroup(e.globalGroupName())
+}
+
 func (e *environ) Provider() environs.EnvironProvider {
 	return &providerInstance
 }
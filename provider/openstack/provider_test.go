@@ -4,8 +4,10 @@
 package openstack_test
 
 import (
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	gooseerrors "gopkg.in/goose.v1/errors"
 	"gopkg.in/goose.v1/nova"
 
 	"github.com/juju/juju/environs/config"
@@ -349,6 +351,39 @@ func (*localTests) TestRuleMatchesPortRange(c *gc.C) {
 	}
 }
 
+func (*localTests) TestIngressRulesToRuleInfo(c *gc.C) {
+	rules := openstack.IngressRulesToRuleInfo("groupid", []network.IngressRule{
+		network.MustNewIngressRule("80-82/tcp", "10.0.0.0/8", "192.168.1.0/24"),
+	})
+	c.Assert(rules, gc.DeepEquals, []nova.RuleInfo{{
+		IPProtocol:    "tcp",
+		FromPort:      80,
+		ToPort:        82,
+		Cidr:          "10.0.0.0/8",
+		ParentGroupId: "groupid",
+	}, {
+		IPProtocol:    "tcp",
+		FromPort:      80,
+		ToPort:        82,
+		Cidr:          "192.168.1.0/24",
+		ParentGroupId: "groupid",
+	}})
+}
+
+func (*localTests) TestRuleMatchesPortRangeIgnoresRestrictedRules(c *gc.C) {
+	proto := "tcp"
+	port := 80
+	rule := nova.SecurityGroupRule{
+		IPProtocol: &proto,
+		FromPort:   &port,
+		ToPort:     &port,
+		IPRange:    map[string]string{"cidr": "10.0.0.0/8"},
+	}
+	c.Check(openstack.RuleMatchesPortRange(rule, network.MustParsePortRange("80/tcp")), jc.IsFalse)
+	rule.IPRange["cidr"] = "0.0.0.0/0"
+	c.Check(openstack.RuleMatchesPortRange(rule, network.MustParsePortRange("80/tcp")), jc.IsTrue)
+}
+
 func (t *localTests) TestPrepareSetsControlBucket(c *gc.C) {
 	attrs := testing.FakeConfig().Merge(testing.Attrs{
 		"type": "openstack",
@@ -362,3 +397,17 @@ func (t *localTests) TestPrepareSetsControlBucket(c *gc.C) {
 	bucket := cfg.UnknownAttrs()["control-bucket"]
 	c.Assert(bucket, gc.Matches, "[a-f0-9]{32}")
 }
+
+func (*localTests) TestMaybeConvertCredentialError(c *gc.C) {
+	err := openstack.MaybeConvertCredentialError(
+		gooseerrors.NewUnauthorisedf(nil, nil, "authentication failed"),
+	)
+	c.Assert(err, jc.Satisfies, errors.IsUnauthorized)
+	c.Assert(err, gc.ErrorMatches, "authentication failed")
+
+	err = openstack.MaybeConvertCredentialError(
+		gooseerrors.NewNotFoundf(nil, nil, "server not found"),
+	)
+	c.Assert(err, gc.Not(jc.Satisfies), errors.IsUnauthorized)
+	c.Assert(openstack.MaybeConvertCredentialError(nil), jc.ErrorIsNil)
+}
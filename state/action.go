@@ -9,6 +9,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/names"
+	jujutxn "github.com/juju/txn"
 	"github.com/juju/utils"
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
@@ -39,7 +40,26 @@ const (
 
 	// ActionRunning indicates that the Action is currently running.
 	ActionRunning ActionStatus = "running"
+
+	// ActionAborted means that the Action was stopped while it was
+	// running.
+	ActionAborted ActionStatus = "aborted"
+
+	// ActionTimedOut means that the Action was stopped because it ran
+	// for longer than its timeout.
+	ActionTimedOut ActionStatus = "timed-out"
 )
+
+// finishedActionStatuses holds the statuses of Actions that can no
+// longer change.
+var finishedActionStatuses = []interface{}{
+	ActionCompleted,
+	ActionCancelled,
+	ActionFailed,
+	ActionAborted,
+	ActionTimedOut,
+}
+
 const actionMarker string = "_a_"
 
 type actionNotificationDoc struct {
@@ -99,6 +119,14 @@ type actionDoc struct {
 
 	// Results are the structured results from the action.
 	Results map[string]interface{} `bson:"results"`
+
+	// Timeout is the time the action may run for before it is
+	// stopped; zero means that it may run indefinitely.
+	Timeout time.Duration `bson:"timeout,omitempty"`
+
+	// Aborting is set when the action has been asked to stop while
+	// it is running.
+	Aborting bool `bson:"aborting,omitempty"`
 }
 
 // Action represents an instruction to do some "action" and is expected
@@ -157,6 +185,18 @@ func (a *Action) Results() (map[string]interface{}, string) {
 	return a.doc.Results, a.doc.Message
 }
 
+// Timeout returns the time the action may run for before it is
+// stopped, or zero if it may run indefinitely.
+func (a *Action) Timeout() time.Duration {
+	return a.doc.Timeout
+}
+
+// Aborting reports whether the action has been asked to stop while it
+// is running.
+func (a *Action) Aborting() bool {
+	return a.doc.Aborting
+}
+
 // ValidateTag should be called before calls to Tag() or ActionTag(). It verifies
 // that the Action can produce a valid Tag.
 func (a *Action) ValidateTag() bool {
@@ -202,6 +242,55 @@ func (a *Action) Begin() (*Action, error) {
 	return a.st.Action(a.Id())
 }
 
+// Abort stops the action. A pending action is cancelled straight
+// away; a running action is marked as aborting, and is stopped by its
+// receiver, which records it as aborted. It is an error to abort an
+// action that has already finished.
+func (a *Action) Abort() (*Action, error) {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if err := a.refresh(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		switch a.doc.Status {
+		case ActionPending:
+			ops := a.removeAndLogOps(ActionCancelled, nil, "action aborted before it was run")
+			ops[0].Assert = bson.D{{"status", ActionPending}}
+			return ops, nil
+		case ActionRunning:
+			if a.doc.Aborting {
+				return nil, jujutxn.ErrNoOperations
+			}
+			return []txn.Op{{
+				C:      actionsC,
+				Id:     a.doc.DocId,
+				Assert: bson.D{{"status", ActionRunning}},
+				Update: bson.D{{"$set", bson.D{{"aborting", true}}}},
+			}}, nil
+		}
+		return nil, errors.Errorf("action %q has already finished", a.Id())
+	}
+	if err := a.st.run(buildTxn); err != nil {
+		return nil, errors.Annotatef(err, "cannot abort action %q", a.Id())
+	}
+	return a.st.Action(a.Id())
+}
+
+// Watch returns a watcher that notifies when the action changes, for
+// instance when it is asked to stop.
+func (a *Action) Watch() NotifyWatcher {
+	return newEntityWatcher(a.st, actionsC, a.doc.DocId)
+}
+
+// refresh reloads the action's document from state.
+func (a *Action) refresh() error {
+	action, err := a.st.Action(a.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	a.doc = action.doc
+	return nil
+}
+
 // Finish removes action from the pending queue and captures the output
 // and end state of the action.
 func (a *Action) Finish(results ActionResults) (*Action, error) {
@@ -212,16 +301,22 @@ func (a *Action) Finish(results ActionResults) (*Action, error) {
 // an actionresult to capture the outcome of the action. It asserts that
 // the action is not already completed.
 func (a *Action) removeAndLog(finalStatus ActionStatus, results map[string]interface{}, message string) (*Action, error) {
-	err := a.st.runTransaction([]txn.Op{
+	err := a.st.runTransaction(a.removeAndLogOps(finalStatus, results, message))
+	if err != nil {
+		return nil, err
+	}
+	return a.st.Action(a.Id())
+}
+
+// removeAndLogOps returns the operations needed to take the action off
+// of the pending queue and record its outcome.
+func (a *Action) removeAndLogOps(finalStatus ActionStatus, results map[string]interface{}, message string) []txn.Op {
+	return []txn.Op{
 		{
 			C:  actionsC,
 			Id: a.doc.DocId,
 			Assert: bson.D{{"status", bson.D{
-				{"$nin", []interface{}{
-					ActionCompleted,
-					ActionCancelled,
-					ActionFailed,
-				}}}}},
+				{"$nin", finishedActionStatuses}}}},
 			Update: bson.D{{"$set", bson.D{
 				{"status", finalStatus},
 				{"message", message},
@@ -232,11 +327,7 @@ func (a *Action) removeAndLog(finalStatus ActionStatus, results map[string]inter
 			C:      actionNotificationsC,
 			Id:     a.st.docID(ensureActionMarker(a.Receiver()) + a.Id()),
 			Remove: true,
-		}})
-	if err != nil {
-		return nil, err
-	}
-	return a.st.Action(a.Id())
+		}}
 }
 
 // newActionTagFromNotification converts an actionNotificationDoc into
@@ -254,8 +345,9 @@ func newAction(st *State, adoc actionDoc) *Action {
 	}
 }
 
-// newActionDoc builds the actionDoc with the given name and parameters.
-func newActionDoc(st *State, receiverTag names.Tag, actionName string, parameters map[string]interface{}) (actionDoc, actionNotificationDoc, error) {
+// newActionDoc builds the actionDoc with the given name, parameters
+// and timeout.
+func newActionDoc(st *State, receiverTag names.Tag, actionName string, parameters map[string]interface{}, timeout time.Duration) (actionDoc, actionNotificationDoc, error) {
 	prefix := ensureActionMarker(receiverTag.Id())
 	actionId, err := NewUUID()
 	if err != nil {
@@ -271,6 +363,7 @@ func newActionDoc(st *State, receiverTag names.Tag, actionName string, parameter
 			Parameters: parameters,
 			Enqueued:   nowToTheSecond(),
 			Status:     ActionPending,
+			Timeout:    timeout,
 		}, actionNotificationDoc{
 			DocId:    st.docID(prefix + actionId.String()),
 			EnvUUID:  envuuid,
@@ -328,20 +421,59 @@ func (st *State) FindActionTagsByPrefix(prefix string) []names.ActionTag {
 	return results
 }
 
-// EnqueueAction
+// EnqueueAction queues an action with the given name and payload for
+// the receiver with the given tag. The action may run indefinitely.
 func (st *State) EnqueueAction(receiver names.Tag, actionName string, payload map[string]interface{}) (*Action, error) {
+	return st.enqueueAction(receiver, actionName, payload, 0)
+}
+
+// enqueueAction queues an action with the given name, payload and
+// timeout for the receiver with the given tag.
+func (st *State) enqueueAction(receiver names.Tag, actionName string, payload map[string]interface{}, timeout time.Duration) (*Action, error) {
+	doc, ops, err := st.enqueueActionOps(receiver, actionName, payload, timeout)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	receiverCollectionName, receiverId, err := st.tagToCollectionAndId(receiver)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if notDead, err := isNotDead(st, receiverCollectionName, receiverId); err != nil {
+			return nil, err
+		} else if !notDead {
+			return nil, ErrDead
+		} else if attempt != 0 {
+			return nil, errors.Errorf("unexpected attempt number '%d'", attempt)
+		}
+		return ops, nil
+	}
+	if err = st.run(buildTxn); err == nil {
+		return newAction(st, doc), nil
+	}
+	return nil, err
+}
+
+// enqueueActionOps returns the action document for an action with the
+// given name, payload and timeout for the receiver with the given tag,
+// and the operations that queue it while the receiver is not dead.
+func (st *State) enqueueActionOps(receiver names.Tag, actionName string, payload map[string]interface{}, timeout time.Duration) (actionDoc, []txn.Op, error) {
 	if len(actionName) == 0 {
-		return nil, errors.New("action name required")
+		return actionDoc{}, nil, errors.New("action name required")
+	}
+	if timeout < 0 {
+		return actionDoc{}, nil, errors.Errorf("invalid timeout %v", timeout)
 	}
 
 	receiverCollectionName, receiverId, err := st.tagToCollectionAndId(receiver)
 	if err != nil {
-		return nil, errors.Trace(err)
+		return actionDoc{}, nil, errors.Trace(err)
 	}
 
-	doc, ndoc, err := newActionDoc(st, receiver, actionName, payload)
+	doc, ndoc, err := newActionDoc(st, receiver, actionName, payload, timeout)
 	if err != nil {
-		return nil, errors.Trace(err)
+		return actionDoc{}, nil, errors.Trace(err)
 	}
 
 	ops := []txn.Op{{
@@ -359,21 +491,7 @@ func (st *State) EnqueueAction(receiver names.Tag, actionName string, payload ma
 		Assert: txn.DocMissing,
 		Insert: ndoc,
 	}}
-
-	buildTxn := func(attempt int) ([]txn.Op, error) {
-		if notDead, err := isNotDead(st, receiverCollectionName, receiverId); err != nil {
-			return nil, err
-		} else if !notDead {
-			return nil, ErrDead
-		} else if attempt != 0 {
-			return nil, errors.Errorf("unexpected attempt number '%d'", attempt)
-		}
-		return ops, nil
-	}
-	if err = st.run(buildTxn); err == nil {
-		return newAction(st, doc), nil
-	}
-	return nil, err
+	return doc, ops, nil
 }
 
 // matchingActions finds actions that match ActionReceiver.
@@ -433,11 +551,7 @@ func (st *State) matchingActionsRunning(ar ActionReceiver) ([]*Action, error) {
 // matchingActionsCompleted finds actions that match ActionReceiver and
 // that are complete.
 func (st *State) matchingActionsCompleted(ar ActionReceiver) ([]*Action, error) {
-	completed := bson.D{{"$or", []bson.D{
-		{{"status", ActionCompleted}},
-		{{"status", ActionCancelled}},
-		{{"status", ActionFailed}},
-	}}}
+	completed := bson.D{{"status", bson.D{{"$in", finishedActionStatuses}}}}
 	return st.matchingActionsByReceiverAndStatus(ar.Tag(), completed)
 }
 
@@ -7,6 +7,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/names"
@@ -14,6 +15,7 @@ import (
 	"github.com/juju/txn"
 	"github.com/juju/utils"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v5"
 
 	"github.com/juju/juju/state"
 	statetesting "github.com/juju/juju/state/testing"
@@ -463,6 +465,121 @@ func (s *ActionSuite) TestComplete(c *gc.C) {
 	c.Assert(len(actions), gc.Equals, 0)
 }
 
+func (s *ActionSuite) TestAddActionWithTimeout(c *gc.C) {
+	action, err := s.unit.AddActionWithTimeout("snapshot", nil, 5*time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(action.Timeout(), gc.Equals, 5*time.Minute)
+
+	action, err = s.State.Action(action.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(action.Timeout(), gc.Equals, 5*time.Minute)
+
+	action, err = s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(action.Timeout(), gc.Equals, time.Duration(0))
+
+	_, err = s.unit.AddActionWithTimeout("snapshot", nil, -time.Second)
+	c.Assert(err, gc.ErrorMatches, "invalid timeout -1s")
+}
+
+func (s *ActionSuite) TestActionSpecTimeout(c *gc.C) {
+	for i, test := range []struct {
+		timeout  interface{}
+		expected time.Duration
+		err      string
+	}{
+		{nil, 0, ""},
+		{"90s", 90 * time.Second, ""},
+		{30, 30 * time.Second, ""},
+		{1.5, 1500 * time.Millisecond, ""},
+		{"soon", 0, `invalid timeout "soon"`},
+		{-1, 0, "invalid timeout -1s"},
+		{true, 0, "invalid timeout true"},
+	} {
+		c.Logf("test %d: %v", i, test.timeout)
+		spec := charm.ActionSpec{Params: map[string]interface{}{}}
+		if test.timeout != nil {
+			spec.Params["timeout"] = test.timeout
+		}
+		timeout, err := state.ActionSpecTimeout(spec)
+		if test.err != "" {
+			c.Check(err, gc.ErrorMatches, test.err)
+			continue
+		}
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(timeout, gc.Equals, test.expected)
+	}
+}
+
+func (s *ActionSuite) TestAbortPending(c *gc.C) {
+	action, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	action, err = action.Abort()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(action.Status(), gc.Equals, state.ActionCancelled)
+	c.Assert(action.Aborting(), jc.IsFalse)
+
+	pending, err := s.unit.PendingActions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pending, gc.HasLen, 0)
+}
+
+func (s *ActionSuite) TestAbortRunning(c *gc.C) {
+	action, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	action, err = action.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+
+	w := action.Watch()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	action, err = action.Abort()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(action.Status(), gc.Equals, state.ActionRunning)
+	c.Assert(action.Aborting(), jc.IsTrue)
+	wc.AssertOneChange()
+
+	// Aborting again is a no-op.
+	action, err = action.Abort()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(action.Aborting(), jc.IsTrue)
+	wc.AssertNoChange()
+
+	output := map[string]interface{}{"partial": "output"}
+	action, err = action.Finish(state.ActionResults{
+		Status:  state.ActionAborted,
+		Results: output,
+		Message: "action aborted",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(action.Status(), gc.Equals, state.ActionAborted)
+	results, message := action.Results()
+	c.Assert(results, gc.DeepEquals, output)
+	c.Assert(message, gc.Equals, "action aborted")
+
+	completed, err := s.unit.CompletedActions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(completed, gc.HasLen, 1)
+	c.Assert(completed[0].Status(), gc.Equals, state.ActionAborted)
+}
+
+func (s *ActionSuite) TestAbortFinished(c *gc.C) {
+	action, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	action, err = action.Finish(state.ActionResults{Status: state.ActionTimedOut})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = action.Abort()
+	c.Assert(err, gc.ErrorMatches, `cannot abort action ".*": action ".*" has already finished`)
+
+	// A finished action cannot be finished again.
+	_, err = action.Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, gc.NotNil)
+}
+
 func (s *ActionSuite) TestFindActionTagsByPrefix(c *gc.C) {
 	prefix := "feedbeef"
 	uuidMock := uuidMockHelper{}
@@ -859,6 +976,9 @@ var _ state.ActionReceiver = (*mockAR)(nil)
 func (r mockAR) AddAction(name string, payload map[string]interface{}) (*state.Action, error) {
 	return nil, nil
 }
+func (r mockAR) AddActionWithTimeout(name string, payload map[string]interface{}, timeout time.Duration) (*state.Action, error) {
+	return nil, nil
+}
 func (r mockAR) CancelAction(*state.Action) (*state.Action, error) { return nil, nil }
 func (r mockAR) WatchActionNotifications() state.StringsWatcher    { return nil }
 func (r mockAR) Actions() ([]*state.Action, error)                 { return nil, nil }
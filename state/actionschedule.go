@@ -0,0 +1,397 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/juju/charm.v5"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// LeaderSuffix is appended to a service name to form the receiver of
+// an ActionSchedule that runs on the service's leader, whichever unit
+// that is when the action is due.
+const LeaderSuffix = "/leader"
+
+// maxActionScheduleRuns is the number of runs kept in the history of
+// an ActionSchedule.
+const maxActionScheduleRuns = 50
+
+var validActionScheduleName = regexp.MustCompile("^[a-z][a-z0-9-]*$")
+
+// ActionScheduleArgs holds the arguments for adding an ActionSchedule.
+type ActionScheduleArgs struct {
+	// Name identifies the schedule in the environment.
+	Name string
+
+	// Receiver is the name of the unit to run the action on, or the
+	// name of a service followed by LeaderSuffix to run the action
+	// on the service's leader.
+	Receiver string
+
+	// ActionName is the name of the action to run.
+	ActionName string
+
+	// Parameters holds the parameters to run the action with.
+	Parameters map[string]interface{}
+
+	// Start is the time the action is first due.
+	Start time.Time
+
+	// Interval is the time between runs of the action.
+	Interval time.Duration
+}
+
+// ActionScheduleRun records an occasion on which a scheduled action
+// was due.
+type ActionScheduleRun struct {
+	// Due is the time the action was due.
+	Due time.Time `bson:"due"`
+
+	// ActionId is the id of the action that was enqueued, if any.
+	ActionId string `bson:"actionid,omitempty"`
+
+	// Skipped is set if the action was not enqueued because the
+	// previous run was still pending.
+	Skipped bool `bson:"skipped,omitempty"`
+
+	// Message explains why no action was enqueued, if none was.
+	Message string `bson:"message,omitempty"`
+}
+
+// ActionSchedule represents an action that is enqueued repeatedly at
+// a fixed interval.
+type ActionSchedule struct {
+	st  *State
+	doc actionScheduleDoc
+}
+
+type actionScheduleDoc struct {
+	DocId      string                 `bson:"_id"`
+	EnvUUID    string                 `bson:"env-uuid"`
+	Name       string                 `bson:"name"`
+	Receiver   string                 `bson:"receiver"`
+	ActionName string                 `bson:"actionname"`
+	Parameters map[string]interface{} `bson:"parameters"`
+	Start      time.Time              `bson:"start"`
+	Interval   time.Duration          `bson:"interval"`
+	NextRun    time.Time              `bson:"nextrun"`
+	Runs       []ActionScheduleRun    `bson:"runs"`
+}
+
+// Name returns the name of the schedule.
+func (s *ActionSchedule) Name() string {
+	return s.doc.Name
+}
+
+// Receiver returns the name of the unit the action runs on, or the
+// name of a service followed by LeaderSuffix.
+func (s *ActionSchedule) Receiver() string {
+	return s.doc.Receiver
+}
+
+// ActionName returns the name of the action that is run.
+func (s *ActionSchedule) ActionName() string {
+	return s.doc.ActionName
+}
+
+// Parameters returns the parameters the action is run with.
+func (s *ActionSchedule) Parameters() map[string]interface{} {
+	return s.doc.Parameters
+}
+
+// Start returns the time the action was first due.
+func (s *ActionSchedule) Start() time.Time {
+	return s.doc.Start
+}
+
+// Interval returns the time between runs of the action.
+func (s *ActionSchedule) Interval() time.Duration {
+	return s.doc.Interval
+}
+
+// NextRun returns the time the action is next due.
+func (s *ActionSchedule) NextRun() time.Time {
+	return s.doc.NextRun
+}
+
+// Runs returns the most recent runs of the schedule, oldest first.
+func (s *ActionSchedule) Runs() []ActionScheduleRun {
+	return s.doc.Runs
+}
+
+// ReceiverTag returns the tag of the unit the action should run on,
+// using leaderOf to find the leader of a service when the schedule's
+// receiver is a service's leader.
+func (s *ActionSchedule) ReceiverTag(leaderOf func(serviceName string) (string, error)) (names.UnitTag, error) {
+	serviceName, ok := leaderReceiverService(s.doc.Receiver)
+	if !ok {
+		return names.NewUnitTag(s.doc.Receiver), nil
+	}
+	unitName, err := leaderOf(serviceName)
+	if err != nil {
+		return names.UnitTag{}, errors.Trace(err)
+	}
+	return names.NewUnitTag(unitName), nil
+}
+
+// RecordRun records the outcome of the run that was due at the
+// schedule's next run time, and moves the next run time on by whole
+// intervals until it is after now. Runs that were missed while no
+// worker was checking the schedule are not recorded.
+func (s *ActionSchedule) RecordRun(run ActionScheduleRun, now time.Time) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if err := s.refreshForRun(attempt, run.Due); err != nil {
+			return nil, err
+		}
+		return s.recordRunOps(run, now), nil
+	}
+	if err := s.st.run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot record run of action schedule %q", s.doc.Name)
+	}
+	return nil
+}
+
+// RunAction queues up the schedule's action on the given unit, as
+// Unit.AddAction does, and records the run that was due at the
+// schedule's next run time as RecordRun does, in the same transaction.
+// If the run has already been recorded or the schedule has been
+// removed, no action is queued up and a nil Action is returned.
+func (s *ActionSchedule) RunAction(unit *Unit, now time.Time) (_ *Action, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot run action schedule %q", s.doc.Name)
+	payload, timeout, err := unit.actionParams(s.doc.ActionName, s.doc.Parameters, 0)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	doc, actionOps, err := s.st.enqueueActionOps(unit.Tag(), s.doc.ActionName, payload, timeout)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	action := newAction(s.st, doc)
+	run := ActionScheduleRun{Due: s.doc.NextRun, ActionId: action.Id()}
+	recorded := false
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if err := s.refreshForRun(attempt, run.Due); err == jujutxn.ErrNoOperations {
+			recorded = true
+			return nil, err
+		} else if err != nil {
+			return nil, err
+		}
+		if attempt > 0 {
+			if notDead, err := isNotDead(s.st, unitsC, unit.doc.DocID); err != nil {
+				return nil, err
+			} else if !notDead {
+				return nil, ErrDead
+			}
+		}
+		return append(actionOps, s.recordRunOps(run, now)...), nil
+	}
+	if err := s.st.run(buildTxn); err != nil {
+		return nil, err
+	}
+	if recorded {
+		return nil, nil
+	}
+	return action, nil
+}
+
+// refreshForRun refreshes the schedule after the given number of
+// failed attempts to record the run that was due at the given time,
+// and returns jujutxn.ErrNoOperations if the run has already been
+// recorded or the schedule removed.
+func (s *ActionSchedule) refreshForRun(attempt int, due time.Time) error {
+	if attempt > 0 {
+		current, err := s.st.ActionSchedule(s.doc.Name)
+		if errors.IsNotFound(err) {
+			return jujutxn.ErrNoOperations
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		s.doc = current.doc
+	}
+	if !s.doc.NextRun.Equal(due) {
+		// The run has already been recorded.
+		return jujutxn.ErrNoOperations
+	}
+	return nil
+}
+
+// recordRunOps returns the operations that record the given run and
+// move the schedule's next run time on past now.
+func (s *ActionSchedule) recordRunOps(run ActionScheduleRun, now time.Time) []txn.Op {
+	runs := append(s.doc.Runs, run)
+	if len(runs) > maxActionScheduleRuns {
+		runs = runs[len(runs)-maxActionScheduleRuns:]
+	}
+	return []txn.Op{{
+		C:      actionSchedulesC,
+		Id:     s.doc.Name,
+		Assert: bson.D{{"nextrun", s.doc.NextRun}},
+		Update: bson.D{{"$set", bson.D{
+			{"nextrun", nextActionScheduleRun(s.doc.NextRun, s.doc.Interval, now)},
+			{"runs", runs},
+		}}},
+	}}
+}
+
+// nextActionScheduleRun returns the first time after now that is a
+// whole number of intervals after the given due time.
+func nextActionScheduleRun(due time.Time, interval time.Duration, now time.Time) time.Time {
+	next := due.Add(interval)
+	if next.After(now) {
+		return next
+	}
+	missed := now.Sub(due) / interval
+	return due.Add((missed + 1) * interval)
+}
+
+// leaderReceiverService returns the name of the service whose leader
+// is the given receiver, and whether the receiver is a leader at all.
+func leaderReceiverService(receiver string) (string, bool) {
+	if !strings.HasSuffix(receiver, LeaderSuffix) {
+		return "", false
+	}
+	return strings.TrimSuffix(receiver, LeaderSuffix), true
+}
+
+// AddActionSchedule adds a schedule on which the given action is run.
+// The action and its parameters are checked against the actions of
+// the receiver's charm, and the defaults of any missing parameters are
+// filled in.
+func (st *State) AddActionSchedule(args ActionScheduleArgs) (_ *ActionSchedule, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot add action schedule %q", args.Name)
+	if !validActionScheduleName.MatchString(args.Name) {
+		return nil, errors.NotValidf("schedule name")
+	}
+	if args.ActionName == "" {
+		return nil, errors.New("action name required")
+	}
+	if args.Interval < time.Minute {
+		return nil, errors.Errorf("interval %v is less than a minute", args.Interval)
+	}
+	if args.Start.IsZero() {
+		return nil, errors.New("start time required")
+	}
+
+	var service *Service
+	if serviceName, ok := leaderReceiverService(args.Receiver); ok {
+		if !names.IsValidService(serviceName) {
+			return nil, errors.NotValidf("receiver %q", args.Receiver)
+		}
+		if service, err = st.Service(serviceName); err != nil {
+			return nil, errors.Trace(err)
+		}
+	} else {
+		if !names.IsValidUnit(args.Receiver) {
+			return nil, errors.NotValidf("receiver %q", args.Receiver)
+		}
+		unit, err := st.Unit(args.Receiver)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if service, err = unit.Service(); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	ch, _, err := service.Charm()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var spec *charm.ActionSpec
+	if actions := ch.Actions(); actions != nil {
+		if found, ok := actions.ActionSpecs[args.ActionName]; ok {
+			spec = &found
+		}
+	}
+	if spec == nil {
+		return nil, errors.Errorf("action %q not defined on service %q", args.ActionName, service.Name())
+	}
+	if err := spec.ValidateParams(args.Parameters); err != nil {
+		return nil, errors.Trace(err)
+	}
+	parameters, err := spec.InsertDefaults(args.Parameters)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	start := args.Start.UTC().Truncate(time.Second)
+	doc := actionScheduleDoc{
+		Name:       args.Name,
+		Receiver:   args.Receiver,
+		ActionName: args.ActionName,
+		Parameters: parameters,
+		Start:      start,
+		Interval:   args.Interval,
+		NextRun:    start,
+	}
+	ops := []txn.Op{{
+		C:      actionSchedulesC,
+		Id:     args.Name,
+		Assert: txn.DocMissing,
+		Insert: &doc,
+	}}
+	if err := st.runTransaction(ops); err == txn.ErrAborted {
+		return nil, errors.AlreadyExistsf("action schedule %q", args.Name)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return st.ActionSchedule(args.Name)
+}
+
+// ActionSchedule returns the ActionSchedule with the given name.
+func (st *State) ActionSchedule(name string) (*ActionSchedule, error) {
+	coll, closer := st.getCollection(actionSchedulesC)
+	defer closer()
+
+	var doc actionScheduleDoc
+	err := coll.FindId(name).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("action schedule %q", name)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get action schedule %q", name)
+	}
+	return &ActionSchedule{st: st, doc: doc}, nil
+}
+
+// AllActionSchedules returns all of the ActionSchedules in the
+// environment, ordered by name.
+func (st *State) AllActionSchedules() ([]*ActionSchedule, error) {
+	coll, closer := st.getCollection(actionSchedulesC)
+	defer closer()
+
+	var docs []actionScheduleDoc
+	if err := coll.Find(nil).Sort("name").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get action schedules")
+	}
+	schedules := make([]*ActionSchedule, len(docs))
+	for i, doc := range docs {
+		schedules[i] = &ActionSchedule{st: st, doc: doc}
+	}
+	return schedules, nil
+}
+
+// RemoveActionSchedule removes the ActionSchedule with the given name.
+// Actions that it has already enqueued are not affected.
+func (st *State) RemoveActionSchedule(name string) error {
+	ops := []txn.Op{{
+		C:      actionSchedulesC,
+		Id:     name,
+		Assert: txn.DocExists,
+		Remove: true,
+	}}
+	if err := st.runTransaction(ops); err == txn.ErrAborted {
+		return errors.NotFoundf("action schedule %q", name)
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot remove action schedule %q", name)
+	}
+	return nil
+}
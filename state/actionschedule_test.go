@@ -0,0 +1,241 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type ActionScheduleSuite struct {
+	ConnSuite
+	service *state.Service
+	unit    *state.Unit
+	start   time.Time
+}
+
+var _ = gc.Suite(&ActionScheduleSuite{})
+
+func (s *ActionScheduleSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.service = s.AddTestingService(c, "dummy", s.AddTestingCharm(c, "dummy"))
+	var err error
+	s.unit, err = s.service.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	s.start = time.Date(2015, 6, 1, 2, 0, 0, 0, time.UTC)
+}
+
+func (s *ActionScheduleSuite) addSchedule(c *gc.C, name, receiver string) *state.ActionSchedule {
+	schedule, err := s.State.AddActionSchedule(state.ActionScheduleArgs{
+		Name:       name,
+		Receiver:   receiver,
+		ActionName: "snapshot",
+		Start:      s.start,
+		Interval:   24 * time.Hour,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return schedule
+}
+
+func (s *ActionScheduleSuite) TestAddActionSchedule(c *gc.C) {
+	schedule := s.addSchedule(c, "nightly", "dummy/leader")
+	c.Assert(schedule.Name(), gc.Equals, "nightly")
+	c.Assert(schedule.Receiver(), gc.Equals, "dummy/leader")
+	c.Assert(schedule.ActionName(), gc.Equals, "snapshot")
+	c.Assert(schedule.Parameters(), jc.DeepEquals, map[string]interface{}{"outfile": "foo.bz2"})
+	c.Assert(schedule.Start().Equal(s.start), jc.IsTrue)
+	c.Assert(schedule.NextRun().Equal(s.start), jc.IsTrue)
+	c.Assert(schedule.Interval(), gc.Equals, 24*time.Hour)
+	c.Assert(schedule.Runs(), gc.HasLen, 0)
+
+	s.addSchedule(c, "hourly", s.unit.Name())
+	schedules, err := s.State.AllActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedules, gc.HasLen, 2)
+	c.Assert(schedules[0].Name(), gc.Equals, "hourly")
+	c.Assert(schedules[1].Name(), gc.Equals, "nightly")
+}
+
+func (s *ActionScheduleSuite) TestAddActionScheduleInvalid(c *gc.C) {
+	for i, test := range []struct {
+		args state.ActionScheduleArgs
+		err  string
+	}{{
+		args: state.ActionScheduleArgs{Name: "Bad Name"},
+		err:  `cannot add action schedule "Bad Name": schedule name not valid`,
+	}, {
+		args: state.ActionScheduleArgs{Name: "s", Receiver: "dummy/leader", Interval: time.Hour, Start: s.start},
+		err:  `cannot add action schedule "s": action name required`,
+	}, {
+		args: state.ActionScheduleArgs{Name: "s", Receiver: "dummy/leader", ActionName: "snapshot", Interval: time.Second, Start: s.start},
+		err:  `cannot add action schedule "s": interval 1s is less than a minute`,
+	}, {
+		args: state.ActionScheduleArgs{Name: "s", Receiver: "dummy/leader", ActionName: "snapshot", Interval: time.Hour},
+		err:  `cannot add action schedule "s": start time required`,
+	}, {
+		args: state.ActionScheduleArgs{Name: "s", Receiver: "nonsuch/leader", ActionName: "snapshot", Interval: time.Hour, Start: s.start},
+		err:  `cannot add action schedule "s": service "nonsuch" not found`,
+	}, {
+		args: state.ActionScheduleArgs{Name: "s", Receiver: "dummy", ActionName: "snapshot", Interval: time.Hour, Start: s.start},
+		err:  `cannot add action schedule "s": receiver "dummy" not valid`,
+	}, {
+		args: state.ActionScheduleArgs{Name: "s", Receiver: "dummy/leader", ActionName: "nonsuch", Interval: time.Hour, Start: s.start},
+		err:  `cannot add action schedule "s": action "nonsuch" not defined on service "dummy"`,
+	}, {
+		args: state.ActionScheduleArgs{
+			Name: "s", Receiver: "dummy/leader", ActionName: "snapshot", Interval: time.Hour, Start: s.start,
+			Parameters: map[string]interface{}{"outfile": 5},
+		},
+		err: `cannot add action schedule "s": validation failed: .*`,
+	}} {
+		c.Logf("test %d", i)
+		_, err := s.State.AddActionSchedule(test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *ActionScheduleSuite) TestAddActionScheduleDuplicate(c *gc.C) {
+	s.addSchedule(c, "nightly", "dummy/leader")
+	_, err := s.State.AddActionSchedule(state.ActionScheduleArgs{
+		Name:       "nightly",
+		Receiver:   s.unit.Name(),
+		ActionName: "snapshot",
+		Start:      s.start,
+		Interval:   time.Hour,
+	})
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *ActionScheduleSuite) TestRemoveActionSchedule(c *gc.C) {
+	s.addSchedule(c, "nightly", "dummy/leader")
+	err := s.State.RemoveActionSchedule("nightly")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.ActionSchedule("nightly")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	err = s.State.RemoveActionSchedule("nightly")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ActionScheduleSuite) TestReceiverTag(c *gc.C) {
+	schedule := s.addSchedule(c, "unit", s.unit.Name())
+	tag, err := schedule.ReceiverTag(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tag, gc.Equals, s.unit.UnitTag())
+
+	schedule = s.addSchedule(c, "leader", "dummy/leader")
+	tag, err = schedule.ReceiverTag(func(serviceName string) (string, error) {
+		c.Check(serviceName, gc.Equals, "dummy")
+		return "dummy/7", nil
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tag.Id(), gc.Equals, "dummy/7")
+}
+
+func (s *ActionScheduleSuite) TestRecordRun(c *gc.C) {
+	schedule := s.addSchedule(c, "nightly", "dummy/leader")
+	run := state.ActionScheduleRun{Due: schedule.NextRun(), ActionId: "some-id"}
+	err := schedule.RecordRun(run, s.start.Add(time.Minute))
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Recording the same run again does nothing.
+	err = schedule.RecordRun(run, s.start.Add(time.Minute))
+	c.Assert(err, jc.ErrorIsNil)
+
+	schedule, err = s.State.ActionSchedule("nightly")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedule.NextRun().Equal(s.start.Add(24*time.Hour)), jc.IsTrue)
+	runs := schedule.Runs()
+	c.Assert(runs, gc.HasLen, 1)
+	c.Assert(runs[0].Due.Equal(s.start), jc.IsTrue)
+	c.Assert(runs[0].ActionId, gc.Equals, "some-id")
+
+	// Runs missed in the meantime are skipped over.
+	due := schedule.NextRun()
+	err = schedule.RecordRun(state.ActionScheduleRun{Due: due, Skipped: true}, due.Add(50*time.Hour))
+	c.Assert(err, jc.ErrorIsNil)
+	schedule, err = s.State.ActionSchedule("nightly")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedule.NextRun().Equal(due.Add(72*time.Hour)), jc.IsTrue)
+	c.Assert(schedule.Runs(), gc.HasLen, 2)
+	c.Assert(schedule.Runs()[1].Skipped, jc.IsTrue)
+}
+
+func (s *ActionScheduleSuite) TestRunAction(c *gc.C) {
+	schedule := s.addSchedule(c, "nightly", "dummy/leader")
+	action, err := schedule.RunAction(s.unit, s.start.Add(time.Minute))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(action, gc.NotNil)
+	c.Assert(action.Name(), gc.Equals, "snapshot")
+	c.Assert(action.Receiver(), gc.Equals, s.unit.Name())
+	c.Assert(action.Parameters(), jc.DeepEquals, map[string]interface{}{"outfile": "foo.bz2"})
+
+	// Running the same schedule again queues up nothing.
+	again, err := schedule.RunAction(s.unit, s.start.Add(time.Minute))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(again, gc.IsNil)
+
+	schedule, err = s.State.ActionSchedule("nightly")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedule.NextRun().Equal(s.start.Add(24*time.Hour)), jc.IsTrue)
+	runs := schedule.Runs()
+	c.Assert(runs, gc.HasLen, 1)
+	c.Assert(runs[0].Due.Equal(s.start), jc.IsTrue)
+	c.Assert(runs[0].ActionId, gc.Equals, action.Id())
+	actions, err := s.unit.PendingActions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(actions, gc.HasLen, 1)
+}
+
+func (s *ActionScheduleSuite) TestRunActionDeadUnit(c *gc.C) {
+	schedule := s.addSchedule(c, "nightly", s.unit.Name())
+	err := s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = schedule.RunAction(s.unit, s.start.Add(time.Minute))
+	c.Assert(err, gc.ErrorMatches, `cannot run action schedule "nightly": .*`)
+
+	// Nothing is queued up or recorded.
+	actions, err := s.unit.PendingActions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(actions, gc.HasLen, 0)
+	schedule, err = s.State.ActionSchedule("nightly")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedule.NextRun().Equal(s.start), jc.IsTrue)
+	c.Assert(schedule.Runs(), gc.HasLen, 0)
+}
+
+func (s *ActionScheduleSuite) TestRecordRunKeepsRecentRuns(c *gc.C) {
+	schedule := s.addSchedule(c, "nightly", "dummy/leader")
+	for i := 0; i < 60; i++ {
+		due := schedule.NextRun()
+		err := schedule.RecordRun(state.ActionScheduleRun{Due: due}, due)
+		c.Assert(err, jc.ErrorIsNil)
+		schedule, err = s.State.ActionSchedule("nightly")
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	runs := schedule.Runs()
+	c.Assert(runs, gc.HasLen, 50)
+	c.Assert(runs[49].Due.Equal(s.start.Add(59*24*time.Hour)), jc.IsTrue)
+}
+
+func (s *ActionScheduleSuite) TestNextActionScheduleRun(c *gc.C) {
+	due := s.start
+	for i, test := range []struct {
+		now      time.Time
+		expected time.Time
+	}{
+		{due, due.Add(time.Hour)},
+		{due.Add(59 * time.Minute), due.Add(time.Hour)},
+		{due.Add(time.Hour), due.Add(2 * time.Hour)},
+		{due.Add(150 * time.Minute), due.Add(3 * time.Hour)},
+	} {
+		c.Logf("test %d", i)
+		next := state.NextActionScheduleRun(due, time.Hour, test.now)
+		c.Check(next.Equal(test.expected), jc.IsTrue)
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// AuditEntry records a single auditable API call made against an
+// environment.
+type AuditEntry struct {
+	// Timestamp holds the time at which the call completed.
+	Timestamp time.Time
+
+	// User holds the tag of the entity that made the call.
+	User string
+
+	// TokenID holds the ID of the API token the user logged in with,
+	// if any.
+	TokenID string
+
+	// Facade, Version and Method identify the API call made.
+	Facade  string
+	Version int
+	Method  string
+
+	// Args holds the arguments to the call, serialised as JSON with
+	// any secrets redacted.
+	Args string
+
+	// Result holds the result of the call, serialised as JSON with any
+	// secrets redacted. It is empty if the call failed.
+	Result string
+
+	// Error holds the error returned by the call, if any.
+	Error string
+}
+
+// auditEntryDoc is the mongo representation of an AuditEntry.
+//
+// The audit log lives in a capped collection that is shared by all
+// environments, so documents are never updated or removed and the
+// env-uuid field is filtered on explicitly rather than through the
+// multi-environment collection machinery.
+type auditEntryDoc struct {
+	Id        bson.ObjectId `bson:"_id"`
+	EnvUUID   string        `bson:"env-uuid"`
+	Timestamp time.Time     `bson:"timestamp"`
+	User      string        `bson:"user"`
+	TokenID   string        `bson:"token-id,omitempty"`
+	Facade    string        `bson:"facade"`
+	Version   int           `bson:"version"`
+	Method    string        `bson:"method"`
+	Args      string        `bson:"args,omitempty"`
+	Result    string        `bson:"result,omitempty"`
+	Error     string        `bson:"error,omitempty"`
+}
+
+func (doc *auditEntryDoc) entry() AuditEntry {
+	return AuditEntry{
+		Timestamp: doc.Timestamp,
+		User:      doc.User,
+		TokenID:   doc.TokenID,
+		Facade:    doc.Facade,
+		Version:   doc.Version,
+		Method:    doc.Method,
+		Args:      doc.Args,
+		Result:    doc.Result,
+		Error:     doc.Error,
+	}
+}
+
+// AddAuditEntry records the given audit entry against the environment.
+// If the entry has no timestamp, the current time is used.
+func (st *State) AddAuditEntry(entry AuditEntry) error {
+	if entry.User == "" {
+		return errors.New("cannot add audit entry: empty user")
+	}
+	if entry.Facade == "" || entry.Method == "" {
+		return errors.New("cannot add audit entry: empty facade or method")
+	}
+	if entry.Timestamp.IsZero() {
+		entry.Timestamp = time.Now()
+	}
+	auditLog, closer := st.getRawCollection(auditLogC)
+	defer closer()
+	err := auditLog.Insert(&auditEntryDoc{
+		Id:        bson.NewObjectId(),
+		EnvUUID:   st.EnvironUUID(),
+		Timestamp: entry.Timestamp.UTC(),
+		User:      entry.User,
+		TokenID:   entry.TokenID,
+		Facade:    entry.Facade,
+		Version:   entry.Version,
+		Method:    entry.Method,
+		Args:      entry.Args,
+		Result:    entry.Result,
+		Error:     entry.Error,
+	})
+	return errors.Annotate(err, "cannot add audit entry")
+}
+
+// AuditFilter restricts the audit entries returned by AuditEntries.
+// Zero-valued fields do not restrict the result.
+type AuditFilter struct {
+	// User, if set, restricts entries to calls made by the entity
+	// with this tag.
+	User string
+
+	// Facade, if set, restricts entries to calls made on this facade.
+	Facade string
+
+	// Since and Until, if set, restrict entries to calls completed in
+	// the range [Since, Until).
+	Since time.Time
+	Until time.Time
+
+	// Limit, if positive, restricts the number of entries returned to
+	// the most recent Limit entries.
+	Limit int
+}
+
+// AuditEntries returns the audit entries for the environment that match
+// the given filter, oldest first.
+func (st *State) AuditEntries(filter AuditFilter) ([]AuditEntry, error) {
+	auditLog, closer := st.getRawCollection(auditLogC)
+	defer closer()
+
+	query := bson.D{{"env-uuid", st.EnvironUUID()}}
+	if filter.User != "" {
+		query = append(query, bson.DocElem{"user", filter.User})
+	}
+	if filter.Facade != "" {
+		query = append(query, bson.DocElem{"facade", filter.Facade})
+	}
+	timeRange := bson.D{}
+	if !filter.Since.IsZero() {
+		timeRange = append(timeRange, bson.DocElem{"$gte", filter.Since.UTC()})
+	}
+	if !filter.Until.IsZero() {
+		timeRange = append(timeRange, bson.DocElem{"$lt", filter.Until.UTC()})
+	}
+	if len(timeRange) > 0 {
+		query = append(query, bson.DocElem{"timestamp", timeRange})
+	}
+
+	q := auditLog.Find(query).Sort("-timestamp", "-_id")
+	if filter.Limit > 0 {
+		q = q.Limit(filter.Limit)
+	}
+	var docs []auditEntryDoc
+	if err := q.All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get audit entries")
+	}
+	entries := make([]AuditEntry, len(docs))
+	for i, doc := range docs {
+		// The query returns the most recent entries first so that
+		// the limit applies to them; reverse them into time order.
+		entries[len(docs)-1-i] = doc.entry()
+	}
+	return entries, nil
+}
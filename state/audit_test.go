@@ -0,0 +1,111 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type AuditSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&AuditSuite{})
+
+var auditT0 = time.Date(2015, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func (s *AuditSuite) addEntries(c *gc.C, st *state.State) {
+	for i, entry := range []state.AuditEntry{{
+		User:   "user-admin@local",
+		Facade: "Client",
+		Method: "ServiceDeploy",
+		Args:   `{"ServiceName":"wordpress"}`,
+	}, {
+		User:   "user-bob@local",
+		Facade: "Client",
+		Method: "ServiceExpose",
+		Error:  "permission denied",
+	}, {
+		User:   "user-admin@local",
+		Facade: "Block",
+		Method: "SwitchBlockOn",
+		Result: `{"Error":null}`,
+	}} {
+		entry.Version = 1
+		entry.Timestamp = auditT0.Add(time.Duration(i) * time.Minute)
+		err := st.AddAuditEntry(entry)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+}
+
+func (s *AuditSuite) TestAddAuditEntryValidation(c *gc.C) {
+	err := s.State.AddAuditEntry(state.AuditEntry{Facade: "Client", Method: "ServiceDeploy"})
+	c.Assert(err, gc.ErrorMatches, "cannot add audit entry: empty user")
+	err = s.State.AddAuditEntry(state.AuditEntry{User: "user-admin@local"})
+	c.Assert(err, gc.ErrorMatches, "cannot add audit entry: empty facade or method")
+}
+
+func (s *AuditSuite) TestAuditEntriesAll(c *gc.C) {
+	s.addEntries(c, s.State)
+	entries, err := s.State.AuditEntries(state.AuditFilter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, jc.DeepEquals, []state.AuditEntry{{
+		Timestamp: auditT0,
+		User:      "user-admin@local",
+		Facade:    "Client",
+		Version:   1,
+		Method:    "ServiceDeploy",
+		Args:      `{"ServiceName":"wordpress"}`,
+	}, {
+		Timestamp: auditT0.Add(time.Minute),
+		User:      "user-bob@local",
+		Facade:    "Client",
+		Version:   1,
+		Method:    "ServiceExpose",
+		Error:     "permission denied",
+	}, {
+		Timestamp: auditT0.Add(2 * time.Minute),
+		User:      "user-admin@local",
+		Facade:    "Block",
+		Version:   1,
+		Method:    "SwitchBlockOn",
+		Result:    `{"Error":null}`,
+	}})
+}
+
+func (s *AuditSuite) assertMethods(c *gc.C, filter state.AuditFilter, expect ...string) {
+	entries, err := s.State.AuditEntries(filter)
+	c.Assert(err, jc.ErrorIsNil)
+	var methods []string
+	for _, entry := range entries {
+		methods = append(methods, entry.Method)
+	}
+	c.Assert(methods, jc.DeepEquals, expect)
+}
+
+func (s *AuditSuite) TestAuditEntriesFilter(c *gc.C) {
+	s.addEntries(c, s.State)
+	s.assertMethods(c, state.AuditFilter{User: "user-admin@local"}, "ServiceDeploy", "SwitchBlockOn")
+	s.assertMethods(c, state.AuditFilter{Facade: "Client"}, "ServiceDeploy", "ServiceExpose")
+	s.assertMethods(c, state.AuditFilter{Since: auditT0.Add(time.Minute)}, "ServiceExpose", "SwitchBlockOn")
+	s.assertMethods(c, state.AuditFilter{Until: auditT0.Add(time.Minute)}, "ServiceDeploy")
+	s.assertMethods(c, state.AuditFilter{Limit: 2}, "ServiceExpose", "SwitchBlockOn")
+	s.assertMethods(c, state.AuditFilter{User: "user-nobody@local"})
+}
+
+func (s *AuditSuite) TestAuditEntriesPerEnvironment(c *gc.C) {
+	otherSt := s.factory.MakeEnvironment(c, nil)
+	defer otherSt.Close()
+	s.addEntries(c, otherSt)
+
+	s.assertMethods(c, state.AuditFilter{})
+	entries, err := otherSt.AuditEntries(state.AuditFilter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, gc.HasLen, 3)
+}
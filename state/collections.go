@@ -62,6 +62,7 @@ type stateCollection interface {
 // these collections.
 var multiEnvCollections = set.NewStrings(
 	actionNotificationsC,
+	actionSchedulesC,
 	actionsC,
 	annotationsC,
 	blockDevicesC,
@@ -70,9 +71,11 @@ var multiEnvCollections = set.NewStrings(
 	cleanupsC,
 	constraintsC,
 	containerRefsC,
+	credentialsLoadedC,
 	envUsersC,
 	filesystemsC,
 	filesystemAttachmentsC,
+	healthChecksC,
 	instanceDataC,
 	ipaddressesC,
 	machinesC,
@@ -80,7 +83,9 @@ var multiEnvCollections = set.NewStrings(
 	minUnitsC,
 	networkInterfacesC,
 	networksC,
+	offersC,
 	openedPortsC,
+	outputChunksC,
 	rebootC,
 	relationScopesC,
 	relationsC,
@@ -89,6 +94,7 @@ var multiEnvCollections = set.NewStrings(
 	servicesC,
 	settingsC,
 	settingsrefsC,
+	spacesC,
 	statusesC,
 	statusesHistoryC,
 	storageAttachmentsC,
@@ -98,6 +104,8 @@ var multiEnvCollections = set.NewStrings(
 	unitsC,
 	volumesC,
 	volumeAttachmentsC,
+	volumeSnapshotsC,
+	volumeResizesC,
 )
 
 func newStateCollection(coll *mgo.Collection, envUUID string) stateCollection {
@@ -27,6 +27,8 @@ type constraintsDoc struct {
 	Container    *instance.ContainerType
 	Tags         *[]string `bson:",omitempty"`
 	Networks     *[]string `bson:",omitempty"`
+	Spot         *bool     `bson:",omitempty"`
+	SpotPrice    *string   `bson:",omitempty"`
 }
 
 func (doc constraintsDoc) value() constraints.Value {
@@ -40,6 +42,8 @@ func (doc constraintsDoc) value() constraints.Value {
 		Container:    doc.Container,
 		Tags:         doc.Tags,
 		Networks:     doc.Networks,
+		Spot:         doc.Spot,
+		SpotPrice:    doc.SpotPrice,
 	}
 }
 
@@ -55,6 +59,8 @@ func newConstraintsDoc(st *State, cons constraints.Value) constraintsDoc {
 		Container:    cons.Container,
 		Tags:         cons.Tags,
 		Networks:     cons.Networks,
+		Spot:         cons.Spot,
+		SpotPrice:    cons.SpotPrice,
 	}
 }
 
@@ -0,0 +1,75 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// credentialsLoadedDoc records the provider credentials with which a
+// state server worker last opened the environment.
+type credentialsLoadedDoc struct {
+	DocID       string `bson:"_id"`
+	EnvUUID     string `bson:"env-uuid"`
+	Worker      string `bson:"worker"`
+	Fingerprint string `bson:"fingerprint"`
+}
+
+// SetCredentialsLoaded records that the named state server worker has
+// opened the environment with the provider credentials that have the
+// given fingerprint (see environs.CredentialsFingerprint).
+func (st *State) SetCredentialsLoaded(worker, fingerprint string) error {
+	if worker == "" {
+		return errors.New("worker name required")
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		coll, closer := st.getCollection(credentialsLoadedC)
+		defer closer()
+		count, err := coll.FindId(worker).Count()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if count == 0 {
+			return []txn.Op{{
+				C:      credentialsLoadedC,
+				Id:     worker,
+				Assert: txn.DocMissing,
+				Insert: &credentialsLoadedDoc{
+					Worker:      worker,
+					Fingerprint: fingerprint,
+				},
+			}}, nil
+		}
+		return []txn.Op{{
+			C:      credentialsLoadedC,
+			Id:     worker,
+			Assert: txn.DocExists,
+			Update: bson.D{{"$set", bson.D{{"fingerprint", fingerprint}}}},
+		}}, nil
+	}
+	if err := st.run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot record credentials loaded by %q", worker)
+	}
+	return nil
+}
+
+// CredentialsLoaded returns the fingerprints of the provider
+// credentials with which state server workers last opened the
+// environment, keyed by worker name.
+func (st *State) CredentialsLoaded() (map[string]string, error) {
+	coll, closer := st.getCollection(credentialsLoadedC)
+	defer closer()
+
+	var docs []credentialsLoadedDoc
+	if err := coll.Find(nil).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get loaded credentials")
+	}
+	fingerprints := make(map[string]string, len(docs))
+	for _, doc := range docs {
+		fingerprints[doc.Worker] = doc.Fingerprint
+	}
+	return fingerprints, nil
+}
@@ -0,0 +1,40 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type CredentialsLoadedSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&CredentialsLoadedSuite{})
+
+func (s *CredentialsLoadedSuite) TestCredentialsLoaded(c *gc.C) {
+	fingerprints, err := s.State.CredentialsLoaded()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fingerprints, gc.HasLen, 0)
+
+	err = s.State.SetCredentialsLoaded("firewaller", "old")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetCredentialsLoaded("instancepoller", "old")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetCredentialsLoaded("firewaller", "new")
+	c.Assert(err, jc.ErrorIsNil)
+
+	fingerprints, err = s.State.CredentialsLoaded()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fingerprints, jc.DeepEquals, map[string]string{
+		"firewaller":     "new",
+		"instancepoller": "old",
+	})
+}
+
+func (s *CredentialsLoadedSuite) TestSetCredentialsLoadedNoWorker(c *gc.C) {
+	err := s.State.SetCredentialsLoaded("", "new")
+	c.Assert(err, gc.ErrorMatches, "worker name required")
+}
@@ -23,7 +23,8 @@ type EnvironCapabilitySuite struct {
 var _ = gc.Suite(&EnvironCapabilitySuite{})
 
 type mockEnvironCapability struct {
-	supportsUnitPlacementError error
+	supportsUnitPlacementError   error
+	supportsSubnetPlacementError error
 }
 
 func (p *mockEnvironCapability) SupportedArchitectures() ([]string, error) {
@@ -34,6 +35,16 @@ func (p *mockEnvironCapability) SupportsUnitPlacement() error {
 	return p.supportsUnitPlacementError
 }
 
+func (p *mockEnvironCapability) SupportsSubnetPlacement() error {
+	return p.supportsSubnetPlacementError
+}
+
+// unplacingEnvironCapability is an EnvironCapability that is not a
+// SubnetPlacer.
+type unplacingEnvironCapability struct {
+	state.EnvironCapability
+}
+
 func (s *EnvironCapabilitySuite) SetUpTest(c *gc.C) {
 	s.ConnSuite.SetUpTest(c)
 	s.capability = mockEnvironCapability{}
@@ -127,3 +138,29 @@ func (s *EnvironCapabilitySuite) TestSupportsUnitPlacementNoPolicy(c *gc.C) {
 	_, err := s.addOneMachine(c)
 	c.Assert(err, jc.ErrorIsNil)
 }
+
+func (s *EnvironCapabilitySuite) TestSupportsSubnetPlacementEndpointBindings(c *gc.C) {
+	_, err := s.State.AddSpace("db", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	service := s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+
+	s.capability.supportsSubnetPlacementError = fmt.Errorf("no bindings for you")
+	err = service.SetEndpointBindings(map[string]string{"db": "db"})
+	c.Assert(err, gc.ErrorMatches, ".*no bindings for you")
+
+	// Environments that do not place machines in subnets cannot have
+	// endpoints bound to spaces.
+	s.policy.GetEnvironCapability = func(*config.Config) (state.EnvironCapability, error) {
+		return unplacingEnvironCapability{&s.capability}, nil
+	}
+	err = service.SetEndpointBindings(map[string]string{"db": "db"})
+	c.Assert(err, gc.ErrorMatches, `cannot set endpoint bindings for service "wordpress": binding endpoints to spaces in ".*" environments not supported`)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+
+	s.policy.GetEnvironCapability = func(*config.Config) (state.EnvironCapability, error) {
+		return &s.capability, nil
+	}
+	s.capability.supportsSubnetPlacementError = nil
+	err = service.SetEndpointBindings(map[string]string{"db": "db"})
+	c.Assert(err, jc.ErrorIsNil)
+}
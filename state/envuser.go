@@ -24,6 +24,46 @@ type EnvironmentUser struct {
 	doc envUserDoc
 }
 
+// Access represents the level of access a user has to an environment.
+type Access string
+
+const (
+	// ReadAccess allows a user to inspect an environment, but not to
+	// change it.
+	ReadAccess Access = "read"
+
+	// WriteAccess allows a user to change an environment, for example
+	// by deploying services or setting configuration.
+	WriteAccess Access = "write"
+
+	// AdminAccess allows a user to change an environment and to
+	// administer it, for example by sharing it with other users or by
+	// destroying it.
+	AdminAccess Access = "admin"
+)
+
+// Validate returns an error if the access level is not known.
+func (a Access) Validate() error {
+	switch a {
+	case ReadAccess, WriteAccess, AdminAccess:
+		return nil
+	}
+	return errors.NotValidf("access level %q", string(a))
+}
+
+// accessLevels orders the access levels from least to most permissive.
+var accessLevels = map[Access]int{
+	ReadAccess:  1,
+	WriteAccess: 2,
+	AdminAccess: 3,
+}
+
+// Includes returns whether the access level grants at least the
+// permissions of the other level.
+func (a Access) Includes(other Access) bool {
+	return accessLevels[a] >= accessLevels[other]
+}
+
 type envUserDoc struct {
 	ID          string    `bson:"_id"`
 	EnvUUID     string    `bson:"env-uuid"`
@@ -31,6 +71,10 @@ type envUserDoc struct {
 	DisplayName string    `bson:"displayname"`
 	CreatedBy   string    `bson:"createdby"`
 	DateCreated time.Time `bson:"datecreated"`
+	// Access holds the user's level of access to the environment.
+	// Environment users created before access levels were introduced
+	// have no access recorded; they were granted full access.
+	Access Access `bson:"access,omitempty"`
 	// LastConnection is updated by the apiserver whenever the user
 	// connects over the API. This update is not done using mgo.txn
 	// so this value could well change underneath a normal transaction
@@ -75,6 +119,36 @@ func (e *EnvironmentUser) DateCreated() time.Time {
 	return e.doc.DateCreated.UTC()
 }
 
+// Access returns the user's level of access to the environment.
+func (e *EnvironmentUser) Access() Access {
+	if e.doc.Access == "" {
+		return AdminAccess
+	}
+	return e.doc.Access
+}
+
+// SetAccess changes the user's level of access to the environment.
+func (e *EnvironmentUser) SetAccess(access Access) error {
+	if err := access.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	ops := []txn.Op{{
+		C:      envUsersC,
+		Id:     e.doc.ID,
+		Assert: txn.DocExists,
+		Update: bson.D{{"$set", bson.D{{"access", access}}}},
+	}}
+	err := e.st.runTransaction(ops)
+	if err == txn.ErrAborted {
+		err = errors.NotFoundf("environment user %q", e.UserName())
+	}
+	if err != nil {
+		return errors.Annotatef(err, "cannot set access for environment user %q", e.UserName())
+	}
+	e.doc.Access = access
+	return nil
+}
+
 // LastLogin returns when this EnvironmentUser last connected through the API
 // in UTC. The resulting time will be nil if the user has never logged in.
 func (e *EnvironmentUser) LastConnection() *time.Time {
@@ -124,8 +198,19 @@ func (st *State) EnvironmentUser(user names.UserTag) (*EnvironmentUser, error) {
 	return envUser, nil
 }
 
-// AddEnvironmentUser adds a new user to the database.
+// AddEnvironmentUser adds a new user to the database with write access
+// to the environment.
 func (st *State) AddEnvironmentUser(user, createdBy names.UserTag, displayName string) (*EnvironmentUser, error) {
+	return st.AddEnvironmentUserWithAccess(user, createdBy, displayName, WriteAccess)
+}
+
+// AddEnvironmentUserWithAccess adds a new user to the database with the
+// given level of access to the environment.
+func (st *State) AddEnvironmentUserWithAccess(user, createdBy names.UserTag, displayName string, access Access) (*EnvironmentUser, error) {
+	if err := access.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
 	// Ensure local user exists in state before adding them as an environment user.
 	if user.IsLocal() {
 		localUser, err := st.User(user)
@@ -145,7 +230,7 @@ func (st *State) AddEnvironmentUser(user, createdBy names.UserTag, displayName s
 	}
 
 	envuuid := st.EnvironUUID()
-	op, doc := createEnvUserOpAndDoc(envuuid, user, createdBy, displayName)
+	op, doc := createEnvUserOpAndDoc(envuuid, user, createdBy, displayName, access)
 	err := st.runTransaction([]txn.Op{op})
 	if err == txn.ErrAborted {
 		err = errors.AlreadyExistsf("environment user %q", user.Username())
@@ -156,7 +241,7 @@ func (st *State) AddEnvironmentUser(user, createdBy names.UserTag, displayName s
 	return &EnvironmentUser{st: st, doc: *doc}, nil
 }
 
-func createEnvUserOpAndDoc(envuuid string, user, createdBy names.UserTag, displayName string) (txn.Op, *envUserDoc) {
+func createEnvUserOpAndDoc(envuuid string, user, createdBy names.UserTag, displayName string, access Access) (txn.Op, *envUserDoc) {
 	username := user.Username()
 	usernameLowerCase := strings.ToLower(username)
 	creatorname := createdBy.Username()
@@ -167,6 +252,7 @@ func createEnvUserOpAndDoc(envuuid string, user, createdBy names.UserTag, displa
 		DisplayName: displayName,
 		CreatedBy:   creatorname,
 		DateCreated: nowToTheSecond(),
+		Access:      access,
 	}
 	op := txn.Op{
 		C:      envUsersC,
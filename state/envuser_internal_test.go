@@ -17,11 +17,12 @@ var _ = gc.Suite(&internalEnvUserSuite{})
 
 func (s *internalEnvUserSuite) TestCreateEnvUserOpAndDoc(c *gc.C) {
 	tag := names.NewUserTag("UserName")
-	op, doc := createEnvUserOpAndDoc("ignored", tag, names.NewUserTag("ignored"), "ignored")
+	op, doc := createEnvUserOpAndDoc("ignored", tag, names.NewUserTag("ignored"), "ignored", ReadAccess)
 
 	c.Assert(op.Id, gc.Equals, "username@local")
 	c.Assert(doc.ID, gc.Equals, "username@local")
 	c.Assert(doc.UserName, gc.Equals, "UserName@local")
+	c.Assert(doc.Access, gc.Equals, ReadAccess)
 }
 
 func (s *internalEnvUserSuite) TestCaseUserNameVsId(c *gc.C) {
@@ -50,6 +50,57 @@ func (s *EnvUserSuite) TestAddEnvironmentUser(c *gc.C) {
 	c.Assert(envUser.LastConnection(), gc.IsNil)
 }
 
+func (s *EnvUserSuite) TestAddEnvironmentUserDefaultAccess(c *gc.C) {
+	user := s.factory.MakeUser(c, &factory.UserParams{NoEnvUser: true})
+	envUser, err := s.State.AddEnvironmentUser(user.UserTag(), s.Owner, "")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(envUser.Access(), gc.Equals, state.WriteAccess)
+}
+
+func (s *EnvUserSuite) TestAddEnvironmentUserWithAccess(c *gc.C) {
+	user := s.factory.MakeUser(c, &factory.UserParams{NoEnvUser: true})
+	envUser, err := s.State.AddEnvironmentUserWithAccess(user.UserTag(), s.Owner, "", state.ReadAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(envUser.Access(), gc.Equals, state.ReadAccess)
+
+	envUser, err = s.State.EnvironmentUser(user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(envUser.Access(), gc.Equals, state.ReadAccess)
+}
+
+func (s *EnvUserSuite) TestAddEnvironmentUserInvalidAccess(c *gc.C) {
+	user := s.factory.MakeUser(c, &factory.UserParams{NoEnvUser: true})
+	_, err := s.State.AddEnvironmentUserWithAccess(user.UserTag(), s.Owner, "", "superuser")
+	c.Assert(err, gc.ErrorMatches, `access level "superuser" not valid`)
+}
+
+func (s *EnvUserSuite) TestOwnerHasAdminAccess(c *gc.C) {
+	envUser, err := s.State.EnvironmentUser(s.Owner)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(envUser.Access(), gc.Equals, state.AdminAccess)
+}
+
+func (s *EnvUserSuite) TestSetAccess(c *gc.C) {
+	envUser := s.factory.MakeEnvUser(c, nil)
+	err := envUser.SetAccess(state.ReadAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(envUser.Access(), gc.Equals, state.ReadAccess)
+
+	envUser, err = s.State.EnvironmentUser(envUser.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(envUser.Access(), gc.Equals, state.ReadAccess)
+
+	err = envUser.SetAccess("bogus")
+	c.Assert(err, gc.ErrorMatches, `access level "bogus" not valid`)
+}
+
+func (s *EnvUserSuite) TestAccessIncludes(c *gc.C) {
+	c.Assert(state.AdminAccess.Includes(state.WriteAccess), jc.IsTrue)
+	c.Assert(state.WriteAccess.Includes(state.WriteAccess), jc.IsTrue)
+	c.Assert(state.WriteAccess.Includes(state.AdminAccess), jc.IsFalse)
+	c.Assert(state.ReadAccess.Includes(state.WriteAccess), jc.IsFalse)
+}
+
 func (s *EnvUserSuite) TestCaseSensitiveEnvUserErrors(c *gc.C) {
 	env, err := s.State.Environment()
 	c.Assert(err, jc.ErrorIsNil)
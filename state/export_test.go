@@ -30,6 +30,7 @@ const (
 	SettingsC          = settingsC
 	UnitsC             = unitsC
 	UsersC             = usersC
+	UserTokensC        = userTokensC
 	BlockDevicesC      = blockDevicesC
 	StorageInstancesC  = storageInstancesC
 	StatusesHistoryC   = statusesHistoryC
@@ -50,6 +51,8 @@ var (
 	AddVolumeOp            = (*State).addVolumeOp
 	CombineMeterStatus     = combineMeterStatus
 	NewStatusNotFound      = newStatusNotFound
+	ActionSpecTimeout      = actionSpecTimeout
+	NextActionScheduleRun  = nextActionScheduleRun
 )
 
 type (
@@ -198,6 +201,7 @@ func GetPasswordHash(e Authenticator) string {
 
 func init() {
 	txnLogSize = txnLogSizeTests
+	auditLogSize = auditLogSizeTests
 }
 
 // TxnRevno returns the txn-revno field of the document
@@ -388,6 +392,7 @@ func NewHistoricalStatusDoc(s StatusDoc, key string) *historicalStatusDoc {
 }
 
 var StatusHistory = statusHistory
+var FilteredStatusHistory = filteredStatusHistory
 var UpdateStatusHistory = updateStatusHistory
 
 func EraseUnitHistory(u *Unit) error {
@@ -9,6 +9,7 @@ import (
 
 	"github.com/juju/errors"
 	"github.com/juju/names"
+	jujutxn "github.com/juju/txn"
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
@@ -83,6 +84,7 @@ type FilesystemAttachment interface {
 }
 
 type filesystem struct {
+	st  *State
 	doc filesystemDoc
 }
 
@@ -232,10 +234,18 @@ func (f *filesystemAttachment) Params() (FilesystemAttachmentParams, bool) {
 
 // Filesystem returns the Filesystem with the specified name.
 func (st *State) Filesystem(tag names.FilesystemTag) (Filesystem, error) {
+	f, err := st.filesystemByTag(tag)
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (st *State) filesystemByTag(tag names.FilesystemTag) (*filesystem, error) {
 	coll, cleanup := st.getCollection(filesystemsC)
 	defer cleanup()
 
-	var fs filesystem
+	fs := filesystem{st: st}
 	err := coll.FindId(tag.Id()).One(&fs.doc)
 	if err == mgo.ErrNotFound {
 		return nil, errors.NotFoundf("filesystem %q", tag.Id())
@@ -264,7 +274,7 @@ func (st *State) filesystem(query bson.D, description string) (Filesystem, error
 	coll, cleanup := st.getCollection(filesystemsC)
 	defer cleanup()
 
-	var f filesystem
+	f := filesystem{st: st}
 	err := coll.Find(query).One(&f.doc)
 	if err == mgo.ErrNotFound {
 		return nil, errors.NotFoundf(description)
@@ -293,15 +303,33 @@ func (st *State) FilesystemAttachment(machine names.MachineTag, filesystem names
 // MachineFilesystemAttachments returns all of the FilesystemAttachments for the
 // specified machine.
 func (st *State) MachineFilesystemAttachments(machine names.MachineTag) ([]FilesystemAttachment, error) {
+	attachments, err := st.filesystemAttachments(bson.D{{"machineid", machine.Id()}})
+	if err != nil {
+		return nil, errors.Annotatef(err, "getting filesystem attachments for machine %q", machine.Id())
+	}
+	return attachments, nil
+}
+
+// FilesystemAttachments returns all of the FilesystemAttachments for the
+// specified filesystem.
+func (st *State) FilesystemAttachments(filesystem names.FilesystemTag) ([]FilesystemAttachment, error) {
+	attachments, err := st.filesystemAttachments(bson.D{{"filesystemid", filesystem.Id()}})
+	if err != nil {
+		return nil, errors.Annotatef(err, "getting filesystem attachments for filesystem %q", filesystem.Id())
+	}
+	return attachments, nil
+}
+
+func (st *State) filesystemAttachments(query bson.D) ([]FilesystemAttachment, error) {
 	coll, cleanup := st.getCollection(filesystemAttachmentsC)
 	defer cleanup()
 
 	var docs []filesystemAttachmentDoc
-	err := coll.Find(bson.D{{"machineid", machine.Id()}}).All(&docs)
+	err := coll.Find(query).All(&docs)
 	if err == mgo.ErrNotFound {
 		return nil, nil
 	} else if err != nil {
-		return nil, errors.Annotatef(err, "getting filesystem attachments for machine %q", machine.Id())
+		return nil, errors.Trace(err)
 	}
 	attachments := make([]FilesystemAttachment, len(docs))
 	for i, doc := range docs {
@@ -310,6 +338,241 @@ func (st *State) MachineFilesystemAttachments(machine names.MachineTag) ([]Files
 	return attachments, nil
 }
 
+// DestroyFilesystem ensures that the filesystem with the specified tag
+// will be destroyed and removed from state at some point in the future.
+// The filesystem's attachments are made Dying, so that the storage
+// provisioner detaches them; the filesystem remains Dying until the
+// last of them is removed, at which point it is made Dead, and the
+// storage provisioner destroys it and removes it from state.
+//
+// Filesystems assigned to a storage instance are destroyed when the
+// storage instance is removed, and cannot be destroyed directly.
+func (st *State) DestroyFilesystem(tag names.FilesystemTag) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot destroy filesystem %q", tag.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		f, err := st.filesystemByTag(tag)
+		if errors.IsNotFound(err) {
+			return nil, jujutxn.ErrNoOperations
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if f.doc.Life != Alive {
+			return nil, jujutxn.ErrNoOperations
+		}
+		if f.doc.StorageId != "" {
+			return nil, errors.Errorf(
+				"filesystem is assigned to storage %q",
+				f.doc.StorageId,
+			)
+		}
+		ops, life, err := st.destroyFilesystemAttachmentsOps(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		// The storage ID is either absent, or empty if the storage
+		// instance the filesystem was assigned to has been removed.
+		return append(ops, txn.Op{
+			C:  filesystemsC,
+			Id: tag.Id(),
+			Assert: bson.D{
+				{"life", Alive},
+				{"storageid", bson.D{{"$in", []interface{}{"", nil}}}},
+			},
+			Update: bson.D{{"$set", bson.D{{"life", life}}}},
+		}), nil
+	}
+	return st.run(buildTxn)
+}
+
+// destroyFilesystemAttachmentsOps returns txn.Ops to make the Alive
+// attachments of the specified filesystem Dying, along with the life
+// the filesystem should be given when it is destroyed: Dying if it
+// has any attachments, and Dead otherwise.
+func (st *State) destroyFilesystemAttachmentsOps(tag names.FilesystemTag) ([]txn.Op, Life, error) {
+	attachments, err := st.FilesystemAttachments(tag)
+	if err != nil {
+		return nil, Dead, errors.Trace(err)
+	}
+	if len(attachments) == 0 {
+		return nil, Dead, nil
+	}
+	ops := make([]txn.Op, len(attachments))
+	for i, a := range attachments {
+		ops[i] = txn.Op{
+			C:  filesystemAttachmentsC,
+			Id: filesystemAttachmentId(a.Machine().Id(), tag.Id()),
+			// Attachments that are already Dying must not be
+			// removed before the filesystem is made Dying, or
+			// nothing would ever make the filesystem Dead.
+			Assert: txn.DocExists,
+		}
+		if a.Life() == Alive {
+			ops[i].Assert = isAliveDoc
+			ops[i].Update = bson.D{{"$set", bson.D{{"life", Dying}}}}
+		}
+	}
+	return ops, Dying, nil
+}
+
+// DetachFilesystem marks the filesystem attachment identified by the
+// specified machine and filesystem tags as Dying, if it is Alive. The
+// storage provisioner responsible for the attachment will detach the
+// filesystem from the machine, and then remove the attachment.
+func (st *State) DetachFilesystem(machine names.MachineTag, filesystem names.FilesystemTag) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot detach filesystem %q from machine %q", filesystem.Id(), machine.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		attachment, err := st.FilesystemAttachment(machine, filesystem)
+		if errors.IsNotFound(err) {
+			return nil, jujutxn.ErrNoOperations
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if attachment.Life() != Alive {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return []txn.Op{{
+			C:      filesystemAttachmentsC,
+			Id:     filesystemAttachmentId(machine.Id(), filesystem.Id()),
+			Assert: isAliveDoc,
+			Update: bson.D{{"$set", bson.D{{"life", Dying}}}},
+		}}, nil
+	}
+	return st.run(buildTxn)
+}
+
+// RemoveFilesystemAttachment removes the filesystem attachment from state.
+// The attachment must be Dying. If it is the last attachment of a Dying
+// filesystem, the filesystem is made Dead so that it can be destroyed.
+// Removing an attachment that has already been removed is not an error.
+func (st *State) RemoveFilesystemAttachment(machine names.MachineTag, filesystem names.FilesystemTag) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot remove attachment of filesystem %q from machine %q", filesystem.Id(), machine.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		attachment, err := st.FilesystemAttachment(machine, filesystem)
+		if errors.IsNotFound(err) {
+			return nil, jujutxn.ErrNoOperations
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if attachment.Life() != Dying {
+			return nil, errors.New("filesystem attachment is not dying")
+		}
+		ops := []txn.Op{{
+			C:      filesystemAttachmentsC,
+			Id:     filesystemAttachmentId(machine.Id(), filesystem.Id()),
+			Assert: bson.D{{"life", Dying}},
+			Remove: true,
+		}}
+		f, err := st.filesystemByTag(filesystem)
+		if errors.IsNotFound(err) {
+			return ops, nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if f.doc.Life != Dying {
+			return ops, nil
+		}
+		attachments, err := st.FilesystemAttachments(filesystem)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, a := range attachments {
+			if a.Machine() == machine {
+				continue
+			}
+			// Some other attachment remains, so the filesystem
+			// stays Dying; make sure that attachment is not
+			// removed concurrently, leaving the filesystem Dying.
+			return append(ops, txn.Op{
+				C:      filesystemAttachmentsC,
+				Id:     filesystemAttachmentId(a.Machine().Id(), filesystem.Id()),
+				Assert: txn.DocExists,
+			}), nil
+		}
+		return append(ops, txn.Op{
+			C:      filesystemsC,
+			Id:     filesystem.Id(),
+			Assert: bson.D{{"life", Dying}},
+			Update: bson.D{{"$set", bson.D{{"life", Dead}}}},
+		}), nil
+	}
+	return st.run(buildTxn)
+}
+
+// EnsureDead is required to implement state.EnsureDeader. EnsureDead
+// sets the filesystem's life to Dead, if it has not already been made
+// Dead or removed. The filesystem must be Dying, and must have no
+// remaining attachments.
+func (f *filesystem) EnsureDead() (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot ensure filesystem %q dead", f.doc.FilesystemId)
+	tag := f.FilesystemTag()
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		current, err := f.st.filesystemByTag(tag)
+		if errors.IsNotFound(err) {
+			return nil, jujutxn.ErrNoOperations
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		switch current.doc.Life {
+		case Alive:
+			return nil, errors.New("filesystem is alive")
+		case Dead:
+			return nil, jujutxn.ErrNoOperations
+		}
+		attachments, err := f.st.FilesystemAttachments(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if len(attachments) > 0 {
+			return nil, errors.New("filesystem is still attached")
+		}
+		return []txn.Op{{
+			C:      filesystemsC,
+			Id:     tag.Id(),
+			Assert: bson.D{{"life", Dying}},
+			Update: bson.D{{"$set", bson.D{{"life", Dead}}}},
+		}}, nil
+	}
+	if err := f.st.run(buildTxn); err != nil {
+		return err
+	}
+	f.doc.Life = Dead
+	return nil
+}
+
+// Remove is required to implement state.Remover. Remove removes the
+// Dead filesystem from state; the filesystem must have no remaining
+// attachments. Removing a filesystem that has already been removed
+// is not an error.
+func (f *filesystem) Remove() (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot remove filesystem %q", f.doc.FilesystemId)
+	tag := f.FilesystemTag()
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		current, err := f.st.filesystemByTag(tag)
+		if errors.IsNotFound(err) {
+			return nil, jujutxn.ErrNoOperations
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if current.doc.Life != Dead {
+			return nil, errors.New("filesystem is not dead")
+		}
+		attachments, err := f.st.FilesystemAttachments(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if len(attachments) > 0 {
+			return nil, errors.New("filesystem is still attached")
+		}
+		return []txn.Op{{
+			C:      filesystemsC,
+			Id:     tag.Id(),
+			Assert: isDeadDoc,
+			Remove: true,
+		}}, nil
+	}
+	return f.st.run(buildTxn)
+}
+
 // filesystemAttachmentId returns a filesystem attachment document ID,
 // given the corresponding filesystem name and machine ID.
 func filesystemAttachmentId(machineId, filesystemId string) string {
@@ -399,7 +399,7 @@ func (s *FilesystemStateSuite) TestAssignToMachineErrors(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `cannot assign unit "storage-filesystem/0" to machine 0/lxc/0: adding storage to lxc container not supported`)
 }
 
-func (s *FilesystemStateSuite) TestRemoveStorageInstanceUnassignsFilesystem(c *gc.C) {
+func (s *FilesystemStateSuite) TestRemoveStorageInstanceDestroysFilesystem(c *gc.C) {
 	filesystemAttachment, storageAttachment := s.addUnitWithFilesystem(c, "loop", true)
 	filesystem := s.filesystem(c, filesystemAttachment.Filesystem())
 	volume := s.filesystemVolume(c, filesystemAttachment.Filesystem())
@@ -426,9 +426,183 @@ func (s *FilesystemStateSuite) TestRemoveStorageInstanceUnassignsFilesystem(c *g
 	_, err = s.State.StorageInstanceVolume(storageTag)
 	c.Assert(err, gc.ErrorMatches, `volume for storage instance "data/0" not found`)
 
-	// The filesystem and volume should not have been destroyed, though.
-	s.filesystem(c, filesystem.FilesystemTag())
-	s.volume(c, volume.VolumeTag())
+	// The filesystem is of no further use, so it should have been
+	// destroyed, and its attachment made Dying so that it is detached
+	// first. The volume should not have been destroyed, though.
+	filesystem = s.filesystem(c, filesystem.FilesystemTag())
+	c.Assert(filesystem.Life(), gc.Equals, state.Dying)
+	filesystemAttachment = s.filesystemAttachment(c, filesystemAttachment.Machine(), filesystemAttachment.Filesystem())
+	c.Assert(filesystemAttachment.Life(), gc.Equals, state.Dying)
+	volume = s.volume(c, volume.VolumeTag())
+	c.Assert(volume.Life(), gc.Equals, state.Alive)
+}
+
+// addMachineWithFilesystem adds a machine with a single machine-scoped
+// filesystem that is not assigned to any storage instance, and returns
+// the filesystem's tag.
+func (s *FilesystemStateSuite) addMachineWithFilesystem(c *gc.C) names.FilesystemTag {
+	_, err := s.State.AddOneMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+		Filesystems: []state.MachineFilesystemParams{{
+			Filesystem: state.FilesystemParams{Pool: "rootfs", Size: 1024},
+			Attachment: state.FilesystemAttachmentParams{Location: "/srv"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return names.NewFilesystemTag("0/0")
+}
+
+func (s *FilesystemStateSuite) TestDestroyFilesystem(c *gc.C) {
+	tag := s.addMachineWithFilesystem(c)
+	machineTag := names.NewMachineTag("0")
+	err := s.State.DestroyFilesystem(tag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The filesystem remains Dying while it is attached; the attachment
+	// is made Dying so that it will be detached.
+	filesystem := s.filesystem(c, tag)
+	c.Assert(filesystem.Life(), gc.Equals, state.Dying)
+	attachment := s.filesystemAttachment(c, machineTag, tag)
+	c.Assert(attachment.Life(), gc.Equals, state.Dying)
+
+	// Destroying a Dying filesystem is a no-op.
+	err = s.State.DestroyFilesystem(tag)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *FilesystemStateSuite) TestDestroyFilesystemDetached(c *gc.C) {
+	tag := s.addMachineWithFilesystem(c)
+	machineTag := names.NewMachineTag("0")
+	err := s.State.DetachFilesystem(machineTag, tag)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.RemoveFilesystemAttachment(machineTag, tag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// A filesystem with no attachments is made Dead immediately.
+	err = s.State.DestroyFilesystem(tag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.filesystem(c, tag).Life(), gc.Equals, state.Dead)
+}
+
+func (s *FilesystemStateSuite) TestDestroyFilesystemNotFound(c *gc.C) {
+	err := s.State.DestroyFilesystem(names.NewFilesystemTag("42"))
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *FilesystemStateSuite) TestDestroyFilesystemAssigned(c *gc.C) {
+	filesystemAttachment, _ := s.addUnitWithFilesystem(c, "rootfs", false)
+	err := s.State.DestroyFilesystem(filesystemAttachment.Filesystem())
+	c.Assert(err, gc.ErrorMatches, `cannot destroy filesystem "0/0": filesystem is assigned to storage "data/0"`)
+}
+
+func (s *FilesystemStateSuite) TestDetachFilesystem(c *gc.C) {
+	tag := s.addMachineWithFilesystem(c)
+	machineTag := names.NewMachineTag("0")
+	err := s.State.DetachFilesystem(machineTag, tag)
+	c.Assert(err, jc.ErrorIsNil)
+	attachment := s.filesystemAttachment(c, machineTag, tag)
+	c.Assert(attachment.Life(), gc.Equals, state.Dying)
+
+	// Detaching is idempotent, and the filesystem is unaffected.
+	err = s.State.DetachFilesystem(machineTag, tag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.filesystem(c, tag).Life(), gc.Equals, state.Alive)
+}
+
+func (s *FilesystemStateSuite) TestRemoveFilesystemAttachmentNotDying(c *gc.C) {
+	tag := s.addMachineWithFilesystem(c)
+	machineTag := names.NewMachineTag("0")
+	err := s.State.RemoveFilesystemAttachment(machineTag, tag)
+	c.Assert(err, gc.ErrorMatches, `cannot remove attachment of filesystem "0/0" from machine "0": filesystem attachment is not dying`)
+	s.filesystemAttachment(c, machineTag, tag)
+}
+
+func (s *FilesystemStateSuite) TestRemoveFilesystemAttachmentFilesystemAlive(c *gc.C) {
+	tag := s.addMachineWithFilesystem(c)
+	machineTag := names.NewMachineTag("0")
+	err := s.State.DetachFilesystem(machineTag, tag)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.RemoveFilesystemAttachment(machineTag, tag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.FilesystemAttachment(machineTag, tag)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(s.filesystem(c, tag).Life(), gc.Equals, state.Alive)
+
+	// Removing a removed attachment is not an error.
+	err = s.State.RemoveFilesystemAttachment(machineTag, tag)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *FilesystemStateSuite) TestRemoveLastFilesystemAttachmentDying(c *gc.C) {
+	tag := s.addMachineWithFilesystem(c)
+	machineTag := names.NewMachineTag("0")
+	err := s.State.DestroyFilesystem(tag)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.RemoveFilesystemAttachment(machineTag, tag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Removing the last attachment of a Dying filesystem makes
+	// the filesystem Dead, ready to be destroyed.
+	c.Assert(s.filesystem(c, tag).Life(), gc.Equals, state.Dead)
+}
+
+func (s *FilesystemStateSuite) TestEnsureDeadFilesystem(c *gc.C) {
+	tag := s.addMachineWithFilesystem(c)
+	machineTag := names.NewMachineTag("0")
+	filesystem := s.filesystem(c, tag)
+	err := filesystem.(state.EnsureDeader).EnsureDead()
+	c.Assert(err, gc.ErrorMatches, `cannot ensure filesystem "0/0" dead: filesystem is alive`)
+
+	err = s.State.DestroyFilesystem(tag)
+	c.Assert(err, jc.ErrorIsNil)
+	err = filesystem.(state.EnsureDeader).EnsureDead()
+	c.Assert(err, gc.ErrorMatches, `cannot ensure filesystem "0/0" dead: filesystem is still attached`)
+
+	err = s.State.RemoveFilesystemAttachment(machineTag, tag)
+	c.Assert(err, jc.ErrorIsNil)
+	err = filesystem.(state.EnsureDeader).EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(filesystem.Life(), gc.Equals, state.Dead)
+	c.Assert(s.filesystem(c, tag).Life(), gc.Equals, state.Dead)
+
+	// EnsureDead is idempotent.
+	err = filesystem.(state.EnsureDeader).EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *FilesystemStateSuite) TestRemoveFilesystem(c *gc.C) {
+	tag := s.addMachineWithFilesystem(c)
+	machineTag := names.NewMachineTag("0")
+	s.filesystemAttachment(c, machineTag, tag)
+
+	filesystem := s.filesystem(c, tag)
+	err := filesystem.(state.Remover).Remove()
+	c.Assert(err, gc.ErrorMatches, `cannot remove filesystem "0/0": filesystem is not dead`)
+
+	err = s.State.DestroyFilesystem(tag)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.RemoveFilesystemAttachment(machineTag, tag)
+	c.Assert(err, jc.ErrorIsNil)
+	err = filesystem.(state.Remover).Remove()
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.Filesystem(tag)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	// Removing a removed filesystem is not an error.
+	err = filesystem.(state.Remover).Remove()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *FilesystemStateSuite) TestFilesystemAttachments(c *gc.C) {
+	tag := s.addMachineWithFilesystem(c)
+	attachments, err := s.State.FilesystemAttachments(tag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(attachments, gc.HasLen, 1)
+	c.Assert(attachments[0].Machine(), gc.Equals, names.NewMachineTag("0"))
+	c.Assert(attachments[0].Filesystem(), gc.Equals, tag)
 }
 
 func (s *FilesystemStateSuite) TestSetFilesystemAttachmentInfoFilesystemNotProvisioned(c *gc.C) {
@@ -0,0 +1,150 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// HealthCheckResult holds the most recent outcome of one of the health
+// checks declared by a unit's charm.
+type HealthCheckResult struct {
+	// Name is the name of the health check.
+	Name string
+
+	// Healthy reports whether the check passed.
+	Healthy bool
+
+	// Message describes why the check failed. It is empty if the check
+	// passed.
+	Message string
+
+	// Since is the time at which the check last became healthy or
+	// unhealthy.
+	Since time.Time
+}
+
+type healthChecksDoc struct {
+	DocID   string           `bson:"_id"`
+	EnvUUID string           `bson:"env-uuid"`
+	Checks  []healthCheckDoc `bson:"checks"`
+}
+
+type healthCheckDoc struct {
+	Name    string    `bson:"name"`
+	Healthy bool      `bson:"healthy"`
+	Message string    `bson:"message,omitempty"`
+	Since   time.Time `bson:"since"`
+}
+
+// SetHealthChecks records the results of the health checks declared by
+// the unit's charm, replacing any previously recorded.
+func (u *Unit) SetHealthChecks(results []HealthCheckResult) error {
+	checks := make([]healthCheckDoc, len(results))
+	for i, result := range results {
+		if result.Name == "" {
+			return errors.Errorf("cannot set health checks for unit %q: empty health check name", u)
+		}
+		checks[i] = healthCheckDoc{
+			Name:    result.Name,
+			Healthy: result.Healthy,
+			Message: result.Message,
+			Since:   result.Since.UTC(),
+		}
+	}
+	docID := u.st.docID(u.globalKey())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := u.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if u.doc.Life == Dead {
+			return nil, errors.Errorf("unit is dead")
+		}
+		ops := []txn.Op{{
+			C:      unitsC,
+			Id:     u.doc.DocID,
+			Assert: notDeadDoc,
+		}}
+		_, err := u.healthChecksDoc()
+		switch {
+		case errors.IsNotFound(err):
+			ops = append(ops, txn.Op{
+				C:      healthChecksC,
+				Id:     docID,
+				Assert: txn.DocMissing,
+				Insert: &healthChecksDoc{
+					DocID:   docID,
+					EnvUUID: u.st.EnvironUUID(),
+					Checks:  checks,
+				},
+			})
+		case err == nil:
+			ops = append(ops, txn.Op{
+				C:      healthChecksC,
+				Id:     docID,
+				Assert: txn.DocExists,
+				Update: bson.D{{"$set", bson.D{{"checks", checks}}}},
+			})
+		default:
+			return nil, errors.Trace(err)
+		}
+		return ops, nil
+	}
+	if err := u.st.run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot set health checks for unit %q", u)
+	}
+	return nil
+}
+
+// HealthChecks returns the most recently recorded results of the health
+// checks declared by the unit's charm.
+func (u *Unit) HealthChecks() ([]HealthCheckResult, error) {
+	doc, err := u.healthChecksDoc()
+	if errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get health checks for unit %q", u)
+	}
+	results := make([]HealthCheckResult, len(doc.Checks))
+	for i, check := range doc.Checks {
+		results[i] = HealthCheckResult{
+			Name:    check.Name,
+			Healthy: check.Healthy,
+			Message: check.Message,
+			Since:   check.Since.UTC(),
+		}
+	}
+	return results, nil
+}
+
+func (u *Unit) healthChecksDoc() (*healthChecksDoc, error) {
+	healthChecks, closer := u.st.getCollection(healthChecksC)
+	defer closer()
+
+	var doc healthChecksDoc
+	err := healthChecks.FindId(u.globalKey()).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("health checks for unit %q", u)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &doc, nil
+}
+
+// removeHealthChecksOp returns the operation needed to remove the health
+// check results recorded for the unit with the given global key.
+func removeHealthChecksOp(st *State, globalKey string) txn.Op {
+	return txn.Op{
+		C:      healthChecksC,
+		Id:     st.docID(globalKey),
+		Remove: true,
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type HealthCheckSuite struct {
+	ConnSuite
+	unit *state.Unit
+}
+
+var _ = gc.Suite(&HealthCheckSuite{})
+
+func (s *HealthCheckSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	service := s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	var err error
+	s.unit, err = service.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *HealthCheckSuite) TestSetHealthChecks(c *gc.C) {
+	results, err := s.unit.HealthChecks()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 0)
+
+	since := time.Date(2015, 7, 1, 12, 0, 0, 0, time.UTC)
+	expected := []state.HealthCheckResult{{
+		Name:    "http",
+		Healthy: false,
+		Message: "connection refused",
+		Since:   since,
+	}, {
+		Name:    "db",
+		Healthy: true,
+		Since:   since,
+	}}
+	err = s.unit.SetHealthChecks(expected)
+	c.Assert(err, jc.ErrorIsNil)
+	results, err = s.unit.HealthChecks()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, expected)
+
+	// Later results replace the earlier ones.
+	expected = expected[1:]
+	err = s.unit.SetHealthChecks(expected)
+	c.Assert(err, jc.ErrorIsNil)
+	results, err = s.unit.HealthChecks()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, expected)
+}
+
+func (s *HealthCheckSuite) TestSetHealthChecksErrors(c *gc.C) {
+	err := s.unit.SetHealthChecks([]state.HealthCheckResult{{}})
+	c.Assert(err, gc.ErrorMatches, `cannot set health checks for unit "wordpress/0": empty health check name`)
+
+	err = s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.SetHealthChecks([]state.HealthCheckResult{{Name: "http", Healthy: true}})
+	c.Assert(err, gc.ErrorMatches, `cannot set health checks for unit "wordpress/0": unit is dead`)
+}
+
+func (s *HealthCheckSuite) TestHealthChecksRemovedWithUnit(c *gc.C) {
+	err := s.unit.SetHealthChecks([]state.HealthCheckResult{{Name: "http", Healthy: true}})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.unit.HealthChecks()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 0)
+}
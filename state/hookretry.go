@@ -0,0 +1,79 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/environs/config"
+)
+
+// hookRetryPolicyDoc records the policy by which a service's failed
+// hooks are retried, overriding the environment's policy.
+type hookRetryPolicyDoc struct {
+	Attempts int           `bson:"attempts"`
+	Delay    time.Duration `bson:"delay"`
+	MaxDelay time.Duration `bson:"maxdelay"`
+}
+
+// HookRetryPolicy returns the policy by which failed hooks of the
+// service's units are retried automatically. This is the policy set on
+// the service, if any, and the environment's policy otherwise.
+func (s *Service) HookRetryPolicy() (config.HookRetryPolicy, error) {
+	if doc := s.doc.HookRetryPolicy; doc != nil {
+		return config.HookRetryPolicy{
+			Attempts: doc.Attempts,
+			Delay:    doc.Delay,
+			MaxDelay: doc.MaxDelay,
+		}, nil
+	}
+	cfg, err := s.st.EnvironConfig()
+	if err != nil {
+		return config.HookRetryPolicy{}, errors.Trace(err)
+	}
+	return cfg.HookRetryPolicy(), nil
+}
+
+// HasHookRetryPolicy reports whether the service has its own hook retry
+// policy, overriding the environment's policy.
+func (s *Service) HasHookRetryPolicy() bool {
+	return s.doc.HookRetryPolicy != nil
+}
+
+// SetHookRetryPolicy sets the policy by which failed hooks of the
+// service's units are retried automatically. A nil policy reverts the
+// service to the environment's policy.
+func (s *Service) SetHookRetryPolicy(policy *config.HookRetryPolicy) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set hook retry policy for service %q", s)
+	var doc *hookRetryPolicyDoc
+	var update bson.D
+	if policy != nil {
+		if err := policy.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+		doc = &hookRetryPolicyDoc{
+			Attempts: policy.Attempts,
+			Delay:    policy.Delay,
+			MaxDelay: policy.MaxDelay,
+		}
+		update = bson.D{{"$set", bson.D{{"hookretrypolicy", doc}}}}
+	} else {
+		update = bson.D{{"$unset", bson.D{{"hookretrypolicy", nil}}}}
+	}
+	ops := []txn.Op{{
+		C:      servicesC,
+		Id:     s.doc.DocID,
+		Assert: isAliveDoc,
+		Update: update,
+	}}
+	if err := s.st.runTransaction(ops); err != nil {
+		return onAbort(err, errNotAlive)
+	}
+	s.doc.HookRetryPolicy = doc
+	return nil
+}
@@ -0,0 +1,89 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/state"
+)
+
+type HookRetryPolicySuite struct {
+	ConnSuite
+	service *state.Service
+}
+
+var _ = gc.Suite(&HookRetryPolicySuite{})
+
+func (s *HookRetryPolicySuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.service = s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+}
+
+func (s *HookRetryPolicySuite) TestEnvironmentPolicy(c *gc.C) {
+	err := s.State.UpdateEnvironConfig(map[string]interface{}{
+		"hook-retry-attempts": 3,
+	}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	policy, err := s.service.HookRetryPolicy()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(policy, gc.Equals, config.HookRetryPolicy{
+		Attempts: 3,
+		Delay:    config.DefaultHookRetryDelay,
+		MaxDelay: config.DefaultHookRetryMaxDelay,
+	})
+	c.Assert(s.service.HasHookRetryPolicy(), jc.IsFalse)
+}
+
+func (s *HookRetryPolicySuite) TestSetHookRetryPolicy(c *gc.C) {
+	expected := config.HookRetryPolicy{
+		Attempts: 5,
+		Delay:    time.Minute,
+		MaxDelay: time.Hour,
+	}
+	err := s.service.SetHookRetryPolicy(&expected)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.service.HasHookRetryPolicy(), jc.IsTrue)
+
+	service, err := s.State.Service("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	policy, err := service.HookRetryPolicy()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(policy, gc.Equals, expected)
+
+	err = service.SetHookRetryPolicy(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(service.HasHookRetryPolicy(), jc.IsFalse)
+	err = service.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(service.HasHookRetryPolicy(), jc.IsFalse)
+	policy, err = service.HookRetryPolicy()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(policy.Attempts, gc.Equals, config.DefaultHookRetryAttempts)
+}
+
+func (s *HookRetryPolicySuite) TestSetHookRetryPolicyInvalid(c *gc.C) {
+	err := s.service.SetHookRetryPolicy(&config.HookRetryPolicy{
+		Attempts: 1,
+		Delay:    time.Minute,
+		MaxDelay: time.Second,
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot set hook retry policy for service "wordpress": hook-retry-max-delay: 1s is less than hook-retry-delay 1m0s`)
+}
+
+func (s *HookRetryPolicySuite) TestSetHookRetryPolicyNotAlive(c *gc.C) {
+	err := s.service.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.service.SetHookRetryPolicy(&config.HookRetryPolicy{
+		Attempts: 1,
+		Delay:    time.Second,
+		MaxDelay: time.Second,
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot set hook retry policy for service "wordpress": not found or not alive`)
+}
@@ -4,6 +4,8 @@
 package state
 
 import (
+	"time"
+
 	"github.com/juju/names"
 
 	"github.com/juju/juju/environs/config"
@@ -71,6 +73,7 @@ type EnsureDeader interface {
 var (
 	_ EnsureDeader = (*Machine)(nil)
 	_ EnsureDeader = (*Unit)(nil)
+	_ EnsureDeader = (*filesystem)(nil)
 )
 
 // Remover represents entities with a Remove method.
@@ -81,6 +84,7 @@ type Remover interface {
 var (
 	_ Remover = (*Machine)(nil)
 	_ Remover = (*Unit)(nil)
+	_ Remover = (*filesystem)(nil)
 )
 
 // Authenticator represents entites capable of handling password
@@ -137,6 +141,15 @@ type EnvironAccessor interface {
 
 var _ EnvironAccessor = (*State)(nil)
 
+// CredentialsLoadedSetter defines the method needed to record the
+// provider credentials with which state server workers have opened the
+// environment.
+type CredentialsLoadedSetter interface {
+	SetCredentialsLoaded(worker, fingerprint string) error
+}
+
+var _ CredentialsLoadedSetter = (*State)(nil)
+
 // UnitsWatcher defines the methods needed to retrieve an entity (a
 // machine or a service) and watch its units.
 type UnitsWatcher interface {
@@ -187,6 +200,11 @@ type ActionReceiver interface {
 	// ActionReceiver.
 	AddAction(name string, payload map[string]interface{}) (*Action, error)
 
+	// AddActionWithTimeout queues an action with the given name and
+	// payload for this ActionReceiver, which will be stopped if it
+	// runs for longer than the given timeout.
+	AddActionWithTimeout(name string, payload map[string]interface{}, timeout time.Duration) (*Action, error)
+
 	// CancelAction removes a pending Action from the queue for this
 	// ActionReceiver and marks it as cancelled.
 	CancelAction(action *Action) (*Action, error)
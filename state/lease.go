@@ -15,7 +15,8 @@ import (
 	"github.com/juju/juju/lease"
 )
 
-// leaseEntity represents a lease in mongo.
+// leaseEntity represents a lease in mongo. The token's expiration is
+// recorded in database time.
 type leaseEntity struct {
 	LastUpdate  time.Time `bson:"lastupdate"`
 	lease.Token `bson:"token"`
@@ -26,11 +27,13 @@ type leaseEntity struct {
 }
 
 // NewLeasePersistor returns a new LeasePersistor. It should be passed
-// functions it can use to run transactions and get collections.
+// functions it can use to run transactions, get collections, and read
+// the database's clock.
 func NewLeasePersistor(
 	collectionName string,
 	runTransaction func(jujutxn.TransactionSource) error,
 	getCollection func(string) (_ stateCollection, closer func()),
+	clock func() (time.Time, error),
 ) *LeasePersistor {
 	getLeaseCollection := func(name string) (_ leaseCollection, closer func()) {
 		sc, closer := getCollection(name)
@@ -40,15 +43,20 @@ func NewLeasePersistor(
 		collectionName: collectionName,
 		runTransaction: runTransaction,
 		getCollection:  getLeaseCollection,
+		clock:          clock,
 	}
 }
 
 // LeasePersistor represents logic which can persist lease tokens to a
-// data store.
+// data store. Leases are claimed, transferred and removed by conditional
+// transactions, and their expiry is judged by the database's clock, so
+// that lease managers on different state servers agree on who holds
+// each lease.
 type LeasePersistor struct {
 	collectionName string
 	runTransaction func(jujutxn.TransactionSource) error
 	getCollection  func(string) (_ leaseCollection, closer func())
+	clock          func() (time.Time, error)
 }
 
 // leaseCollection provides bespoke lease methods on top of a standard
@@ -73,80 +81,155 @@ func (lf *genericLeaseCollection) FindById(id string) (*leaseEntity, error) {
 	return &lease, nil
 }
 
-// WriteToken writes the given token to the data store with the given
-// ID.
-func (p *LeasePersistor) WriteToken(id string, tok lease.Token) error {
+// now returns the database's current time, and how far ahead of the
+// local clock it is.
+func (p *LeasePersistor) now() (time.Time, time.Duration, error) {
+	// The local time is read first, so that any delay in reading the
+	// database's time makes local expiration times earlier, not later.
+	local := time.Now()
+	now, err := p.clock()
+	if err != nil {
+		return time.Time{}, 0, errors.Annotate(err, "reading database time")
+	}
+	return now, now.Sub(local), nil
+}
+
+// localToken returns the token with its expiration converted from
+// database time to local time.
+func localToken(tok lease.Token, offset time.Duration) lease.Token {
+	tok.Expiration = tok.Expiration.Add(-offset)
+	return tok
+}
+
+// ClaimToken grants the lease for namespace to id for the given
+// duration, unless another id holds an unexpired lease for it. It
+// returns the token of the holder of the lease.
+func (p *LeasePersistor) ClaimToken(namespace, id string, forDur time.Duration) (lease.Token, error) {
 
 	collection, closer := p.getCollection(p.collectionName)
 	defer closer()
 
-	// TODO(wallyworld) - this logic is a stop-gap until a proper refactoring is done
-	// We'll be especially paranoid here - to avoid potentially overwriting lease info
-	// from another client, if the txn fails to apply, we'll abort instead of retrying.
+	var result lease.Token
+	var offset time.Duration
 	buildTxn := func(attempt int) ([]txn.Op, error) {
-		if attempt > 0 {
-			return nil, errors.New("simultaneous lease updates occurred")
+		now, nowOffset, err := p.now()
+		if err != nil {
+			return nil, err
 		}
-		existing, err := collection.FindById(id)
+		offset = nowOffset
+		claim := lease.Token{Namespace: namespace, Id: id, Expiration: now.Add(forDur)}
+		existing, err := collection.FindById(namespace)
 		if err == mgo.ErrNotFound {
-			entity := leaseEntity{LastUpdate: time.Now(), Token: tok}
-			return []txn.Op{
-				{
-					C:      p.collectionName,
-					Id:     id,
-					Assert: txn.DocMissing,
-					Insert: entity,
-				},
-			}, nil
+			result = claim
+			return []txn.Op{{
+				C:      p.collectionName,
+				Id:     namespace,
+				Assert: txn.DocMissing,
+				Insert: leaseEntity{LastUpdate: now, Token: claim},
+			}}, nil
 		} else if err != nil {
-			return nil, errors.Annotatef(err, "reading existing lease for token %v", tok)
+			return nil, errors.Annotatef(err, "reading existing lease for namespace %q", namespace)
 		}
-		return []txn.Op{
-			{
-				C:      p.collectionName,
-				Id:     id,
-				Assert: bson.D{{"txn-revno", existing.TxnRevno}},
-				Update: bson.M{"$set": bson.M{"lastupdate": time.Now(), "token": tok}},
-			},
-		}, nil
+		if existing.Id != id && existing.Expiration.After(now) {
+			result = existing.Token
+			return nil, jujutxn.ErrNoOperations
+		}
+		// The lease is ours to extend, or has expired; either way,
+		// nobody else may have changed it in the meantime.
+		result = claim
+		return []txn.Op{{
+			C:      p.collectionName,
+			Id:     namespace,
+			Assert: bson.D{{"txn-revno", existing.TxnRevno}},
+			Update: bson.M{"$set": bson.M{"lastupdate": now, "token": claim}},
+		}}, nil
 	}
 	if err := p.runTransaction(buildTxn); err != nil {
-		return errors.Annotatef(err, `could not add token "%s" to data-store`, tok.Id)
+		return lease.Token{}, errors.Annotatef(err, "could not claim lease for namespace %q for %q", namespace, id)
 	}
+	return localToken(result, offset), nil
+}
 
-	return nil
+// TransferToken replaces the unexpired lease held for namespace by
+// fromId with a lease held by toId for the given duration.
+func (p *LeasePersistor) TransferToken(namespace, fromId, toId string, forDur time.Duration) (lease.Token, error) {
+
+	collection, closer := p.getCollection(p.collectionName)
+	defer closer()
+
+	var result lease.Token
+	var offset time.Duration
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		now, nowOffset, err := p.now()
+		if err != nil {
+			return nil, err
+		}
+		offset = nowOffset
+		existing, err := collection.FindById(namespace)
+		if err == mgo.ErrNotFound {
+			return nil, lease.NotLeaseOwnerErr
+		} else if err != nil {
+			return nil, errors.Annotatef(err, "reading existing lease for namespace %q", namespace)
+		}
+		if existing.Id != fromId || !existing.Expiration.After(now) {
+			return nil, lease.NotLeaseOwnerErr
+		}
+		result = lease.Token{Namespace: namespace, Id: toId, Expiration: now.Add(forDur)}
+		return []txn.Op{{
+			C:      p.collectionName,
+			Id:     namespace,
+			Assert: bson.D{{"txn-revno", existing.TxnRevno}},
+			Update: bson.M{"$set": bson.M{"lastupdate": now, "token": result}},
+		}}, nil
+	}
+	if err := p.runTransaction(buildTxn); err != nil {
+		return lease.Token{}, errors.Annotatef(err, "could not transfer lease for namespace %q", namespace)
+	}
+	return localToken(result, offset), nil
 }
 
-// RemoveToken removes the lease token with the given ID from the data
+// RemoveToken removes the lease held for namespace by id from the data
 // store.
-func (p *LeasePersistor) RemoveToken(id string) error {
+func (p *LeasePersistor) RemoveToken(namespace, id string) error {
+
+	collection, closer := p.getCollection(p.collectionName)
+	defer closer()
 
 	buildTxn := func(attempt int) ([]txn.Op, error) {
-		if attempt == 0 {
-			return []txn.Op{
-				{
-					C:      p.collectionName,
-					Id:     id,
-					Assert: txn.DocExists,
-					Remove: true,
-				},
-			}, nil
+		existing, err := collection.FindById(namespace)
+		if err == mgo.ErrNotFound {
+			return nil, lease.NotLeaseOwnerErr
+		} else if err != nil {
+			return nil, errors.Annotatef(err, "reading existing lease for namespace %q", namespace)
+		}
+		if existing.Id != id {
+			return nil, lease.NotLeaseOwnerErr
 		}
-		return nil, jujutxn.ErrNoOperations
+		return []txn.Op{{
+			C:      p.collectionName,
+			Id:     namespace,
+			Assert: bson.D{{"txn-revno", existing.TxnRevno}},
+			Remove: true,
+		}}, nil
 	}
 	if err := p.runTransaction(buildTxn); err != nil {
-		return errors.Annotatef(err, `could not remove token "%s"`, id)
+		return errors.Annotatef(err, `could not remove token for namespace %q`, namespace)
 	}
 
 	return nil
 }
 
-// PersistedTokens retrieves all tokens currently persisted.
+// PersistedTokens retrieves the tokens of all unexpired leases.
 func (p *LeasePersistor) PersistedTokens() (tokens []lease.Token, _ error) {
 
 	collection, closer := p.getCollection(p.collectionName)
 	defer closer()
 
+	now, offset, err := p.now()
+	if err != nil {
+		return nil, err
+	}
+
 	// Pipeline entities into tokens.
 	var query bson.D
 	iter := collection.Find(query).Iter()
@@ -154,7 +237,9 @@ func (p *LeasePersistor) PersistedTokens() (tokens []lease.Token, _ error) {
 
 	var doc leaseEntity
 	for iter.Next(&doc) {
-		tokens = append(tokens, doc.Token)
+		if doc.Expiration.After(now) {
+			tokens = append(tokens, localToken(doc.Token, offset))
+		}
 	}
 
 	if err := iter.Err(); err != nil {
@@ -163,3 +248,20 @@ func (p *LeasePersistor) PersistedTokens() (tokens []lease.Token, _ error) {
 
 	return tokens, nil
 }
+
+// databaseTime returns the current time according to the database
+// server, which is the clock shared by all of the state servers.
+func (st *State) databaseTime() (time.Time, error) {
+	session := st.db.Session.Copy()
+	defer session.Close()
+	var isMaster struct {
+		LocalTime time.Time `bson:"localTime"`
+	}
+	if err := st.db.With(session).Run("isMaster", &isMaster); err != nil {
+		return time.Time{}, errors.Trace(err)
+	}
+	if isMaster.LocalTime.IsZero() {
+		return time.Time{}, errors.New("database did not report its time")
+	}
+	return isMaster.LocalTime, nil
+}
@@ -6,6 +6,7 @@ package state
 import (
 	"time"
 
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	jujutxn "github.com/juju/txn"
 	gc "gopkg.in/check.v1"
@@ -39,6 +40,13 @@ func stubGetCollection(collectionName string) (stateCollection, func()) {
 	return &genericStateCollection{}, func() {}
 }
 
+// testDatabaseTime is the time reported by stubClock.
+var testDatabaseTime = time.Date(2015, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func stubClock() (time.Time, error) {
+	return testDatabaseTime, nil
+}
+
 type stubLeaseCollection struct {
 	stateCollection
 	tokenToReturn *leaseEntity
@@ -53,9 +61,9 @@ func (s *stubLeaseCollection) FindById(id string) (*leaseEntity, error) {
 
 type leaseSuite struct{}
 
-func (s *leaseSuite) TestWriteNewToken(c *gc.C) {
+func (s *leaseSuite) TestClaimNewToken(c *gc.C) {
 
-	tok := lease.Token{testNamespace, testId, time.Now().Add(testDuration)}
+	tok := lease.Token{testNamespace, testId, testDatabaseTime.Add(testDuration)}
 
 	stubRunTransaction := func(txns jujutxn.TransactionSource) error {
 		ops, err := txns(0)
@@ -63,7 +71,7 @@ func (s *leaseSuite) TestWriteNewToken(c *gc.C) {
 		c.Check(ops[0].Assert, gc.Equals, txn.DocMissing)
 		c.Check(ops[0].C, gc.Equals, testCollectionName)
 		c.Check(ops[0].Insert.(leaseEntity).Token, gc.DeepEquals, tok)
-		c.Check(ops[0].Id, gc.Equals, testId)
+		c.Check(ops[0].Id, gc.Equals, testNamespace)
 		return nil
 	}
 
@@ -72,21 +80,22 @@ func (s *leaseSuite) TestWriteNewToken(c *gc.C) {
 		c.Check(collectionName, gc.Equals, testCollectionName)
 		return &stubLeaseCollection{&genericStateCollection{}, nil}, func() { closerCallCount++ }
 	}
-	persistor := LeasePersistor{testCollectionName, stubRunTransaction, stubGetCollection}
-	err := persistor.WriteToken(testId, tok)
+	persistor := LeasePersistor{testCollectionName, stubRunTransaction, stubGetCollection, stubClock}
+	claimed, err := persistor.ClaimToken(testNamespace, testId, testDuration)
 	c.Assert(err, gc.IsNil)
+	c.Check(claimed.Id, gc.Equals, testId)
 	c.Assert(closerCallCount, gc.Equals, 1)
 }
 
-func (s *leaseSuite) TestWriteTokenReplaceExisting(c *gc.C) {
+func (s *leaseSuite) TestClaimTokenReplaceExpired(c *gc.C) {
 
-	tok := lease.Token{testNamespace, testId, time.Now().Add(testDuration)}
+	tok := lease.Token{testNamespace, testId, testDatabaseTime.Add(testDuration)}
 	stubRunTransaction := func(txns jujutxn.TransactionSource) error {
 		ops, err := txns(0)
 		c.Assert(err, jc.ErrorIsNil)
 		c.Check(ops[0].Assert, gc.DeepEquals, bson.D{bson.DocElem{Name: "txn-revno", Value: int64(10)}})
 		c.Check(ops[0].C, gc.Equals, testCollectionName)
-		c.Check(ops[0].Id, gc.Equals, testId)
+		c.Check(ops[0].Id, gc.Equals, testNamespace)
 
 		values := ops[0].Update.(bson.M)["$set"].(bson.M)
 		token := values["token"].(lease.Token)
@@ -94,28 +103,44 @@ func (s *leaseSuite) TestWriteTokenReplaceExisting(c *gc.C) {
 		return nil
 	}
 
-	existingTok := lease.Token{testNamespace, "1234", time.Now().Add(testDuration)}
-	existing := leaseEntity{time.Now(), existingTok, 10}
+	// The existing lease has expired by the database's clock.
+	existingTok := lease.Token{testNamespace, "1234", testDatabaseTime.Add(-time.Second)}
+	existing := leaseEntity{testDatabaseTime, existingTok, 10}
 	closerCallCount := 0
 	stubGetCollection := func(collectionName string) (leaseCollection, func()) {
 		c.Check(collectionName, gc.Equals, testCollectionName)
 		return &stubLeaseCollection{&genericStateCollection{}, &existing}, func() { closerCallCount++ }
 	}
-	persistor := LeasePersistor{testCollectionName, stubRunTransaction, stubGetCollection}
-	err := persistor.WriteToken(testId, tok)
+	persistor := LeasePersistor{testCollectionName, stubRunTransaction, stubGetCollection, stubClock}
+	claimed, err := persistor.ClaimToken(testNamespace, testId, testDuration)
 	c.Assert(err, gc.IsNil)
+	c.Check(claimed.Id, gc.Equals, testId)
 	c.Assert(closerCallCount, gc.Equals, 1)
 }
 
-func (s *leaseSuite) TestWriteTokenConflict(c *gc.C) {
+func (s *leaseSuite) TestClaimTokenHeldByAnother(c *gc.C) {
 
-	tok := lease.Token{testNamespace, testId, time.Now().Add(testDuration)}
+	stubRunTransaction := func(txns jujutxn.TransactionSource) error {
+		_, err := txns(0)
+		c.Assert(err, gc.Equals, jujutxn.ErrNoOperations)
+		return nil
+	}
+
+	existingTok := lease.Token{testNamespace, "1234", testDatabaseTime.Add(time.Minute)}
+	existing := leaseEntity{testDatabaseTime, existingTok, 10}
+	stubGetCollection := func(collectionName string) (leaseCollection, func()) {
+		return &stubLeaseCollection{&genericStateCollection{}, &existing}, func() {}
+	}
+	persistor := LeasePersistor{testCollectionName, stubRunTransaction, stubGetCollection, stubClock}
+	claimed, err := persistor.ClaimToken(testNamespace, testId, testDuration)
+	c.Assert(err, gc.IsNil)
+	c.Check(claimed.Id, gc.Equals, "1234")
+}
+
+func (s *leaseSuite) TestClaimTokenConflict(c *gc.C) {
 
 	stubRunTransaction := func(txns jujutxn.TransactionSource) error {
-		// Any attempt to build txns with attempt>1 is rejected.
-		_, err := txns(1)
-		c.Assert(err, gc.NotNil)
-		return err
+		return jujutxn.ErrExcessiveContention
 	}
 
 	closerCallCount := 0
@@ -123,12 +148,71 @@ func (s *leaseSuite) TestWriteTokenConflict(c *gc.C) {
 		c.Check(collectionName, gc.Equals, testCollectionName)
 		return &stubLeaseCollection{&genericStateCollection{}, nil}, func() { closerCallCount++ }
 	}
-	persistor := LeasePersistor{testCollectionName, stubRunTransaction, stubGetCollection}
-	err := persistor.WriteToken(testId, tok)
+	persistor := LeasePersistor{testCollectionName, stubRunTransaction, stubGetCollection, stubClock}
+	_, err := persistor.ClaimToken(testNamespace, testId, testDuration)
 	c.Assert(err, gc.NotNil)
 	c.Assert(closerCallCount, gc.Equals, 1)
 }
 
+func (s *leaseSuite) TestClaimTokenClockError(c *gc.C) {
+
+	stubRunTransaction := func(txns jujutxn.TransactionSource) error {
+		_, err := txns(0)
+		return err
+	}
+	stubGetCollection := func(collectionName string) (leaseCollection, func()) {
+		return &stubLeaseCollection{&genericStateCollection{}, nil}, func() {}
+	}
+	stubClock := func() (time.Time, error) {
+		return time.Time{}, errors.New("no clock")
+	}
+	persistor := LeasePersistor{testCollectionName, stubRunTransaction, stubGetCollection, stubClock}
+	_, err := persistor.ClaimToken(testNamespace, testId, testDuration)
+	c.Assert(err, gc.ErrorMatches, `could not claim lease for namespace .*: reading database time: no clock`)
+}
+
+func (s *leaseSuite) TestTransferToken(c *gc.C) {
+
+	tok := lease.Token{testNamespace, "stub-unit/1", testDatabaseTime.Add(testDuration)}
+	stubRunTransaction := func(txns jujutxn.TransactionSource) error {
+		ops, err := txns(0)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(ops[0].Assert, gc.DeepEquals, bson.D{bson.DocElem{Name: "txn-revno", Value: int64(10)}})
+		c.Check(ops[0].Id, gc.Equals, testNamespace)
+
+		values := ops[0].Update.(bson.M)["$set"].(bson.M)
+		c.Assert(values["token"].(lease.Token), gc.DeepEquals, tok)
+		return nil
+	}
+
+	existingTok := lease.Token{testNamespace, testId, testDatabaseTime.Add(time.Minute)}
+	existing := leaseEntity{testDatabaseTime, existingTok, 10}
+	stubGetCollection := func(collectionName string) (leaseCollection, func()) {
+		return &stubLeaseCollection{&genericStateCollection{}, &existing}, func() {}
+	}
+	persistor := LeasePersistor{testCollectionName, stubRunTransaction, stubGetCollection, stubClock}
+	transferred, err := persistor.TransferToken(testNamespace, testId, "stub-unit/1", testDuration)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(transferred.Id, gc.Equals, "stub-unit/1")
+}
+
+func (s *leaseSuite) TestTransferTokenNotOwner(c *gc.C) {
+
+	stubRunTransaction := func(txns jujutxn.TransactionSource) error {
+		_, err := txns(0)
+		return err
+	}
+
+	existingTok := lease.Token{testNamespace, "1234", testDatabaseTime.Add(time.Minute)}
+	existing := leaseEntity{testDatabaseTime, existingTok, 10}
+	stubGetCollection := func(collectionName string) (leaseCollection, func()) {
+		return &stubLeaseCollection{&genericStateCollection{}, &existing}, func() {}
+	}
+	persistor := LeasePersistor{testCollectionName, stubRunTransaction, stubGetCollection, stubClock}
+	_, err := persistor.TransferToken(testNamespace, testId, "stub-unit/1", testDuration)
+	c.Assert(errors.Cause(err), gc.Equals, lease.NotLeaseOwnerErr)
+}
+
 func (s *leaseSuite) TestRemoveToken(c *gc.C) {
 
 	stubRunTransaction := func(txns jujutxn.TransactionSource) error {
@@ -138,20 +222,36 @@ func (s *leaseSuite) TestRemoveToken(c *gc.C) {
 
 		c.Check(ops[0].C, gc.Equals, testCollectionName)
 		c.Check(ops[0].Remove, gc.Equals, true)
-		c.Check(ops[0].Id, gc.Equals, testId)
-
-		_, err = txns(1)
-		c.Assert(err, gc.Equals, jujutxn.ErrNoOperations)
-
+		c.Check(ops[0].Id, gc.Equals, testNamespace)
+		c.Check(ops[0].Assert, gc.DeepEquals, bson.D{bson.DocElem{Name: "txn-revno", Value: int64(10)}})
 		return nil
 	}
 
-	persistor := NewLeasePersistor(testCollectionName, stubRunTransaction, stubGetCollection)
-	err := persistor.RemoveToken(testId)
+	existingTok := lease.Token{testNamespace, testId, testDatabaseTime.Add(time.Minute)}
+	existing := leaseEntity{testDatabaseTime, existingTok, 10}
+	stubGetCollection := func(collectionName string) (leaseCollection, func()) {
+		return &stubLeaseCollection{&genericStateCollection{}, &existing}, func() {}
+	}
+	persistor := LeasePersistor{testCollectionName, stubRunTransaction, stubGetCollection, stubClock}
+	err := persistor.RemoveToken(testNamespace, testId)
 
 	c.Assert(err, gc.IsNil)
 }
 
+func (s *leaseSuite) TestRemoveTokenNotOwner(c *gc.C) {
+
+	stubRunTransaction := func(txns jujutxn.TransactionSource) error {
+		_, err := txns(0)
+		return err
+	}
+	stubGetCollection := func(collectionName string) (leaseCollection, func()) {
+		return &stubLeaseCollection{&genericStateCollection{}, nil}, func() {}
+	}
+	persistor := LeasePersistor{testCollectionName, stubRunTransaction, stubGetCollection, stubClock}
+	err := persistor.RemoveToken(testNamespace, testId)
+	c.Assert(errors.Cause(err), gc.Equals, lease.NotLeaseOwnerErr)
+}
+
 func (s *leaseSuite) TestPersistedTokens(c *gc.C) {
 
 	closerCallCount := 0
@@ -160,7 +260,7 @@ func (s *leaseSuite) TestPersistedTokens(c *gc.C) {
 		return &genericStateCollection{}, func() { closerCallCount++ }
 	}
 
-	persistor := NewLeasePersistor(testCollectionName, stubRunTransaction, stubGetCollection)
+	persistor := NewLeasePersistor(testCollectionName, stubRunTransaction, stubGetCollection, stubClock)
 
 	// PersistedTokens will panic when it tries to use the empty collection.
 	defer func() {
@@ -855,6 +855,71 @@ func (m *Machine) SetInstanceStatus(status string) (err error) {
 	return errors.NotProvisionedf("machine %v", m.Id())
 }
 
+// reclaimedStatusInfo is the status message of machines whose
+// instances were reclaimed by the provider.
+const reclaimedStatusInfo = "instance reclaimed by the provider"
+
+// MarkReclaimed records that the provider has reclaimed the machine's
+// instance, as happens to spot or preemptible instances. The instance
+// data, nonce and provider addresses are cleared, so the machine is no
+// longer provisioned, and its status is set to a transient error so
+// that the provisioner starts a replacement instance.
+func (m *Machine) MarkReclaimed() (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot mark machine %q reclaimed", m)
+
+	instData, err := getInstanceData(m.st, m.Id())
+	if errors.IsNotFound(err) {
+		return errors.NotProvisionedf("machine %v", m.Id())
+	} else if err != nil {
+		return err
+	}
+	oldDoc, err := getStatus(m.st, m.globalKey())
+	if err != nil && !IsStatusNotFound(err) {
+		return err
+	}
+	doc, err := newMachineStatusDoc(StatusError, reclaimedStatusInfo, map[string]interface{}{
+		"transient": true,
+	}, false)
+	if err != nil {
+		return err
+	}
+	ops := []txn.Op{
+		{
+			C:      machinesC,
+			Id:     m.doc.DocID,
+			Assert: isAliveDoc,
+			Update: bson.D{{"$set", bson.D{
+				{"nonce", ""},
+				{"addresses", []address{}},
+			}}},
+		}, {
+			C:      instanceDataC,
+			Id:     m.doc.DocID,
+			Assert: bson.D{{"instanceid", instData.InstanceId}},
+			Remove: true,
+		},
+		updateStatusOp(m.st, m.globalKey(), doc.statusDoc),
+	}
+	if err = m.st.runTransaction(ops); err == txn.ErrAborted {
+		if alive, err := isAlive(m.st, machinesC, m.doc.DocID); err != nil {
+			return err
+		} else if !alive {
+			return errNotAlive
+		}
+		return errors.Errorf("instance %q is no longer the machine's instance", instData.InstanceId)
+	} else if err != nil {
+		return err
+	}
+	m.doc.Nonce = ""
+	m.doc.Addresses = nil
+	if oldDoc.Status != "" {
+		if err := updateStatusHistory(oldDoc, m.globalKey(), m.st); err != nil {
+			logger.Errorf("could not record status history before marking reclaimed: %v", err)
+		}
+	}
+	return nil
+}
+
 // AvailabilityZone returns the provier-specific instance availability
 // zone in which the machine was provisioned.
 func (m *Machine) AvailabilityZone() (string, error) {
@@ -1347,9 +1412,16 @@ func (m *Machine) Status() (StatusInfo, error) {
 
 // SetStatus sets the status of the machine.
 func (m *Machine) SetStatus(status Status, info string, data map[string]interface{}) error {
+	oldDoc, err := getStatus(m.st, m.globalKey())
+	if IsStatusNotFound(err) {
+		logger.Debugf("there is no state for %q yet", m.globalKey())
+	} else if err != nil {
+		logger.Debugf("cannot get state for %q yet", m.globalKey())
+	}
+
 	// If a machine is not yet provisioned, we allow its status
 	// to be set back to pending (when a retry is to occur).
-	_, err := m.InstanceId()
+	_, err = m.InstanceId()
 	allowPending := errors.IsNotProvisioned(err)
 	doc, err := newMachineStatusDoc(status, info, data, allowPending)
 	if err != nil {
@@ -1365,9 +1437,21 @@ func (m *Machine) SetStatus(status Status, info string, data map[string]interfac
 	if err = m.st.runTransaction(ops); err != nil {
 		return fmt.Errorf("cannot set status of machine %q: %v", m, onAbort(err, errNotAlive))
 	}
+
+	if oldDoc.Status != "" {
+		if err := updateStatusHistory(oldDoc, m.globalKey(), m.st); err != nil {
+			logger.Errorf("could not record status history before change to %q: %v", status, err)
+		}
+	}
 	return nil
 }
 
+// StatusHistory returns the past statuses of the machine, as reported
+// by its agent, that match the given filter, most recent first.
+func (m *Machine) StatusHistory(filter StatusHistoryFilter) ([]StatusInfo, error) {
+	return filteredStatusHistory(filter, m.globalKey(), m.st)
+}
+
 // Clean returns true if the machine does not have any deployed units or containers.
 func (m *Machine) Clean() bool {
 	return m.doc.Clean
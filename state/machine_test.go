@@ -1151,6 +1151,38 @@ func (s *MachineSuite) TestMachineSetInstanceStatus(c *gc.C) {
 	c.Assert(status, gc.DeepEquals, "ALIVE")
 }
 
+func (s *MachineSuite) TestMachineMarkReclaimed(c *gc.C) {
+	err := s.machine.SetProvisioned("umbrella/0", "fake_nonce", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.SetProviderAddresses(network.NewAddress("10.0.0.1"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.machine.MarkReclaimed()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.machine.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.machine.InstanceId()
+	c.Assert(err, jc.Satisfies, errors.IsNotProvisioned)
+	c.Assert(s.machine.CheckProvisioned("fake_nonce"), jc.IsFalse)
+	c.Assert(s.machine.ProviderAddresses(), gc.HasLen, 0)
+	statusInfo, err := s.machine.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(statusInfo.Status, gc.Equals, state.StatusError)
+	c.Assert(statusInfo.Message, gc.Equals, "instance reclaimed by the provider")
+	c.Assert(statusInfo.Data, jc.DeepEquals, map[string]interface{}{"transient": true})
+
+	// The machine can be provisioned again with a new instance.
+	err = s.machine.SetProvisioned("umbrella/1", "other_nonce", nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *MachineSuite) TestNotProvisionedMachineMarkReclaimed(c *gc.C) {
+	err := s.machine.MarkReclaimed()
+	c.Assert(err, gc.ErrorMatches, `cannot mark machine "1" reclaimed: machine 1 not provisioned`)
+	c.Assert(err, jc.Satisfies, errors.IsNotProvisioned)
+}
+
 func (s *MachineSuite) TestNotProvisionedMachineSetInstanceStatus(c *gc.C) {
 	err := s.machine.SetInstanceStatus("ALIVE")
 	c.Assert(err, gc.ErrorMatches, ".* not provisioned")
@@ -1715,6 +1747,31 @@ func (s *MachineSuite) TestGetSetStatusWhileAlive(c *gc.C) {
 	})
 }
 
+func (s *MachineSuite) TestStatusHistory(c *gc.C) {
+	err := s.machine.SetStatus(state.StatusStarted, "", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.SetStatus(state.StatusError, "provisioning failed", map[string]interface{}{
+		"foo": "bar",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.SetStatus(state.StatusStarted, "", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := s.machine.StatusHistory(state.StatusHistoryFilter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 3)
+	c.Assert(history[0].Status, gc.Equals, state.StatusError)
+	c.Assert(history[0].Message, gc.Equals, "provisioning failed")
+	c.Assert(history[0].Data, jc.DeepEquals, map[string]interface{}{"foo": "bar"})
+	c.Assert(history[1].Status, gc.Equals, state.StatusStarted)
+	c.Assert(history[2].Status, gc.Equals, state.StatusPending)
+
+	history, err = s.machine.StatusHistory(state.StatusHistoryFilter{Status: state.StatusError})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 1)
+	c.Assert(history[0].Message, gc.Equals, "provisioning failed")
+}
+
 func (s *MachineSuite) TestSetStatusPending(c *gc.C) {
 	err := s.machine.SetStatus(state.StatusPending, "", nil)
 	c.Assert(err, jc.ErrorIsNil)
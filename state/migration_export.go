@@ -0,0 +1,393 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+	"github.com/juju/names"
+
+	"github.com/juju/juju/description"
+)
+
+// Export returns a portable description of the environment's model:
+// its users, machines, services, units, relations and storage. The
+// environment's configuration is not included, as it is specific to
+// the cloud and state server.
+func (st *State) Export() (*description.Environment, error) {
+	env, err := st.Environment()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := &description.Environment{
+		Version: description.Version,
+		Owner:   env.Owner().Username(),
+	}
+	cons, err := st.EnvironConstraints()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result.Constraints = cons.String()
+	if result.Annotations, err = st.exportAnnotations(env); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Users, err = st.exportUsers(env); err != nil {
+		return nil, errors.Annotate(err, "cannot export users")
+	}
+	if result.Machines, err = st.exportMachines(); err != nil {
+		return nil, errors.Annotate(err, "cannot export machines")
+	}
+	if result.Services, err = st.exportServices(); err != nil {
+		return nil, errors.Annotate(err, "cannot export services")
+	}
+	if result.Relations, err = st.exportRelations(); err != nil {
+		return nil, errors.Annotate(err, "cannot export relations")
+	}
+	return result, nil
+}
+
+// exportAnnotations returns the entity's annotations, or nil if it
+// has none.
+func (st *State) exportAnnotations(entity GlobalEntity) (map[string]string, error) {
+	annotations, err := st.Annotations(entity)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(annotations) == 0 {
+		return nil, nil
+	}
+	return annotations, nil
+}
+
+func (st *State) exportUsers(env *Environment) ([]description.User, error) {
+	users, err := env.Users()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]description.User, len(users))
+	for i, user := range users {
+		result[i] = description.User{
+			Name:        user.UserName(),
+			DisplayName: user.DisplayName(),
+			Access:      string(user.Access()),
+		}
+	}
+	sort.Sort(usersByName(result))
+	return result, nil
+}
+
+func (st *State) exportMachines() ([]description.Machine, error) {
+	machines, err := st.AllMachines()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]description.Machine, len(machines))
+	for i, m := range machines {
+		exported := description.Machine{
+			Id:            m.Id(),
+			Series:        m.Series(),
+			ContainerType: string(m.ContainerType()),
+			Placement:     m.Placement(),
+		}
+		for _, job := range m.Jobs() {
+			exported.Jobs = append(exported.Jobs, job.String())
+		}
+		instId, err := m.InstanceId()
+		if err == nil {
+			hw, err := m.HardwareCharacteristics()
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			exported.InstanceId = string(instId)
+			exported.Nonce = m.doc.Nonce
+			exported.Hardware = hw.String()
+		} else if !errors.IsNotProvisioned(err) {
+			return nil, errors.Trace(err)
+		}
+		cons, err := m.Constraints()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		exported.Constraints = cons.String()
+		if exported.Annotations, err = st.exportAnnotations(m); err != nil {
+			return nil, errors.Trace(err)
+		}
+		result[i] = exported
+	}
+	return result, nil
+}
+
+func (st *State) exportServices() ([]description.Service, error) {
+	services, err := st.AllServices()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]description.Service, len(services))
+	for i, s := range services {
+		curl, _ := s.CharmURL()
+		exported := description.Service{
+			Name:      s.Name(),
+			Charm:     curl.String(),
+			Exposed:   s.IsExposed(),
+			ExposedTo: s.ExposedCIDRs(),
+		}
+		owner, err := names.ParseUserTag(s.GetOwnerTag())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		exported.Owner = owner.Username()
+		settings, err := s.ConfigSettings()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if len(settings) > 0 {
+			exported.Settings = settings
+		}
+		if s.IsPrincipal() {
+			cons, err := s.Constraints()
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			exported.Constraints = cons.String()
+		}
+		storageCons, err := s.StorageConstraints()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if len(storageCons) > 0 {
+			exported.Storage = make(map[string]description.StorageConstraints)
+			for name, cons := range storageCons {
+				exported.Storage[name] = description.StorageConstraints{
+					Pool:  cons.Pool,
+					Size:  cons.Size,
+					Count: cons.Count,
+				}
+			}
+		}
+		if exported.Annotations, err = st.exportAnnotations(s); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if exported.Units, err = st.exportUnits(s); err != nil {
+			return nil, errors.Annotatef(err, "service %q", s.Name())
+		}
+		result[i] = exported
+	}
+	sort.Sort(servicesByName(result))
+	return result, nil
+}
+
+func (st *State) exportUnits(s *Service) ([]description.Unit, error) {
+	units, err := s.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]description.Unit, len(units))
+	for i, u := range units {
+		exported := description.Unit{Name: u.Name()}
+		if principal, ok := u.PrincipalName(); ok {
+			exported.Principal = principal
+		} else {
+			machineId, err := u.AssignedMachineId()
+			if err != nil && !errors.IsNotAssigned(err) {
+				return nil, errors.Trace(err)
+			}
+			exported.Machine = machineId
+		}
+		if exported.Annotations, err = st.exportAnnotations(u); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if exported.Storage, err = st.exportUnitStorage(u); err != nil {
+			return nil, errors.Annotatef(err, "unit %q", u.Name())
+		}
+		result[i] = exported
+	}
+	sort.Sort(unitsByName(result))
+	return result, nil
+}
+
+// exportUnitStorage returns a description of the unit's storage instances,
+// along with any volumes and filesystems provisioned for them.
+func (st *State) exportUnitStorage(u *Unit) ([]description.Storage, error) {
+	attachments, err := st.UnitStorageAttachments(u.UnitTag())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(attachments) == 0 {
+		return nil, nil
+	}
+	var machineTag names.MachineTag
+	if machineId, err := u.AssignedMachineId(); err == nil {
+		machineTag = names.NewMachineTag(machineId)
+	} else if !errors.IsNotAssigned(err) {
+		return nil, errors.Trace(err)
+	}
+	result := make([]description.Storage, len(attachments))
+	for i, attachment := range attachments {
+		instance, err := st.StorageInstance(attachment.StorageInstance())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		exported := description.Storage{
+			Name: instance.StorageName(),
+			Id:   instance.StorageTag().Id(),
+		}
+		var volumeTag names.VolumeTag
+		switch instance.Kind() {
+		case StorageKindBlock:
+			exported.Kind = "block"
+			volume, err := st.StorageInstanceVolume(instance.StorageTag())
+			if err == nil {
+				volumeTag = volume.VolumeTag()
+			} else if !errors.IsNotFound(err) {
+				return nil, errors.Trace(err)
+			}
+		case StorageKindFilesystem:
+			exported.Kind = "filesystem"
+			filesystem, err := st.StorageInstanceFilesystem(instance.StorageTag())
+			if errors.IsNotFound(err) {
+				break
+			} else if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if exported.Filesystem, err = st.exportFilesystem(filesystem, machineTag); err != nil {
+				return nil, errors.Trace(err)
+			}
+			if volumeTag, err = filesystem.Volume(); err == ErrNoBackingVolume {
+				volumeTag = names.VolumeTag{}
+			} else if err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if volumeTag != (names.VolumeTag{}) {
+			if exported.Volume, err = st.exportVolume(volumeTag, machineTag); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		result[i] = exported
+	}
+	sort.Sort(storageById(result))
+	return result, nil
+}
+
+// exportVolume returns a description of the volume and its attachment
+// to the machine, or nil if the volume has not been provisioned.
+func (st *State) exportVolume(tag names.VolumeTag, machine names.MachineTag) (*description.Volume, error) {
+	volume, err := st.Volume(tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	info, err := volume.Info()
+	if errors.IsNotProvisioned(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := &description.Volume{
+		Pool:       info.Pool,
+		VolumeId:   info.VolumeId,
+		HardwareId: info.HardwareId,
+		Size:       info.Size,
+		Persistent: info.Persistent,
+	}
+	if machine == (names.MachineTag{}) {
+		return result, nil
+	}
+	attachment, err := st.VolumeAttachment(machine, tag)
+	if errors.IsNotFound(err) {
+		return result, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	attachmentInfo, err := attachment.Info()
+	if err == nil {
+		result.DeviceName = attachmentInfo.DeviceName
+		result.ReadOnly = attachmentInfo.ReadOnly
+	} else if !errors.IsNotProvisioned(err) {
+		return nil, errors.Trace(err)
+	}
+	return result, nil
+}
+
+// exportFilesystem returns a description of the filesystem and its
+// attachment to the machine, or nil if the filesystem has not been
+// provisioned.
+func (st *State) exportFilesystem(filesystem Filesystem, machine names.MachineTag) (*description.Filesystem, error) {
+	info, err := filesystem.Info()
+	if errors.IsNotProvisioned(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := &description.Filesystem{
+		Pool:         info.Pool,
+		FilesystemId: info.FilesystemId,
+		Size:         info.Size,
+	}
+	if machine == (names.MachineTag{}) {
+		return result, nil
+	}
+	attachment, err := st.FilesystemAttachment(machine, filesystem.FilesystemTag())
+	if errors.IsNotFound(err) {
+		return result, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	attachmentInfo, err := attachment.Info()
+	if err == nil {
+		result.MountPoint = attachmentInfo.MountPoint
+		result.ReadOnly = attachmentInfo.ReadOnly
+	} else if !errors.IsNotProvisioned(err) {
+		return nil, errors.Trace(err)
+	}
+	return result, nil
+}
+
+func (st *State) exportRelations() ([]description.Relation, error) {
+	relations, err := st.AllRelations()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]description.Relation, len(relations))
+	for i, r := range relations {
+		exported := description.Relation{Id: r.Id()}
+		for _, ep := range r.Endpoints() {
+			exported.Endpoints = append(exported.Endpoints, ep.String())
+		}
+		sort.Strings(exported.Endpoints)
+		result[i] = exported
+	}
+	sort.Sort(relationsById(result))
+	return result, nil
+}
+
+type usersByName []description.User
+
+func (s usersByName) Len() int           { return len(s) }
+func (s usersByName) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s usersByName) Less(i, j int) bool { return s[i].Name < s[j].Name }
+
+type servicesByName []description.Service
+
+func (s servicesByName) Len() int           { return len(s) }
+func (s servicesByName) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s servicesByName) Less(i, j int) bool { return s[i].Name < s[j].Name }
+
+type unitsByName []description.Unit
+
+func (s unitsByName) Len() int           { return len(s) }
+func (s unitsByName) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s unitsByName) Less(i, j int) bool { return unitNumber(s[i].Name) < unitNumber(s[j].Name) }
+
+type storageById []description.Storage
+
+func (s storageById) Len() int           { return len(s) }
+func (s storageById) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s storageById) Less(i, j int) bool { return s[i].Id < s[j].Id }
+
+type relationsById []description.Relation
+
+func (s relationsById) Len() int           { return len(s) }
+func (s relationsById) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s relationsById) Less(i, j int) bool { return s[i].Id < s[j].Id }
@@ -0,0 +1,469 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	"gopkg.in/juju/charm.v5"
+
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/description"
+	"github.com/juju/juju/instance"
+)
+
+// Import recreates the model described by desc in the environment, which
+// must not yet have any services. Machines, units and relations keep
+// their identifiers; storage instances may be given new identifiers.
+//
+// Import does not provision anything: machines that were provisioned are
+// recorded with their existing instances, and volumes and filesystems
+// with their existing provider IDs. State server machines are not
+// imported, as the environment has its own. The charms used by the
+// services must already have been added to the environment, and the
+// users must already exist.
+func (st *State) Import(desc *description.Environment) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot import environment")
+	if desc.Version != description.Version {
+		return errors.NotSupportedf("environment description version %d", desc.Version)
+	}
+	services, err := st.AllServices()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(services) > 0 {
+		return errors.New("environment already has services")
+	}
+	env, err := st.Environment()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := st.importUsers(env, desc.Users); err != nil {
+		return errors.Trace(err)
+	}
+	if desc.Constraints != "" {
+		cons, err := constraints.Parse(desc.Constraints)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := st.SetEnvironConstraints(cons); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if err := st.importAnnotations(env, desc.Annotations); err != nil {
+		return errors.Trace(err)
+	}
+	if err := st.importMachines(desc.Machines); err != nil {
+		return errors.Trace(err)
+	}
+	if err := st.importServices(desc.Services); err != nil {
+		return errors.Trace(err)
+	}
+	return st.importRelations(desc.Relations)
+}
+
+func (st *State) importAnnotations(entity GlobalEntity, annotations map[string]string) error {
+	if len(annotations) == 0 {
+		return nil
+	}
+	return st.SetAnnotations(entity, annotations)
+}
+
+// importUsers gives the users access to the environment, unless they
+// already have it. The users are recorded as added by the owner of the
+// environment.
+func (st *State) importUsers(env *Environment, users []description.User) error {
+	for _, user := range users {
+		if !names.IsValidUser(user.Name) {
+			return errors.NotValidf("user name %q", user.Name)
+		}
+		tag := names.NewUserTag(user.Name)
+		if _, err := st.EnvironmentUser(tag); err == nil {
+			continue
+		} else if !errors.IsNotFound(err) {
+			return errors.Trace(err)
+		}
+		_, err := st.AddEnvironmentUserWithAccess(tag, env.Owner(), user.DisplayName, Access(user.Access))
+		if err != nil {
+			return errors.Annotatef(err, "cannot import user %q", user.Name)
+		}
+	}
+	return nil
+}
+
+// importMachines adds the machines with their original ids, and
+// advances the machine and container sequences past them.
+func (st *State) importMachines(machines []description.Machine) error {
+	sequences := make(map[string]int)
+	for _, m := range machines {
+		jobs, err := importJobs(m.Jobs)
+		if err != nil {
+			return errors.Annotatef(err, "machine %s", m.Id)
+		}
+		if hasJob(jobs, JobManageEnviron) {
+			logger.Infof("not importing state server machine %s", m.Id)
+			continue
+		}
+		machine, err := st.importMachine(m, jobs)
+		if err != nil {
+			return errors.Annotatef(err, "cannot import machine %s", m.Id)
+		}
+		if err := st.importAnnotations(machine, m.Annotations); err != nil {
+			return errors.Trace(err)
+		}
+		sequence, number := machineSequence(m.Id)
+		if number >= sequences[sequence] {
+			sequences[sequence] = number + 1
+		}
+	}
+	for sequence, next := range sequences {
+		if err := st.ensureSequence(sequence, next); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+func (st *State) importMachine(m description.Machine, jobs []MachineJob) (*Machine, error) {
+	template := MachineTemplate{
+		Series:     m.Series,
+		Jobs:       jobs,
+		InstanceId: instance.Id(m.InstanceId),
+		Nonce:      m.Nonce,
+		Placement:  m.Placement,
+	}
+	var err error
+	if template.Constraints, err = constraints.Parse(m.Constraints); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if template.HardwareCharacteristics, err = instance.ParseHardware(m.Hardware); err != nil {
+		return nil, errors.Trace(err)
+	}
+	mdoc := st.machineDocForTemplate(template, m.Id)
+	mdoc.ContainerType = m.ContainerType
+	prereqOps, machineOp, err := st.insertNewMachineOps(mdoc, template)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if parentId := ParentId(m.Id); parentId != "" {
+		prereqOps = append(prereqOps, st.addChildToContainerRefOp(parentId, mdoc.Id))
+	}
+	prereqOps = append(prereqOps, st.insertNewContainerRefOp(mdoc.Id))
+	if template.InstanceId != "" {
+		prereqOps = append(prereqOps, insertInstanceDataOp(mdoc, template))
+	}
+	return st.addMachine(mdoc, append(prereqOps, machineOp))
+}
+
+// importJobs returns the machine jobs with the given names.
+func importJobs(jobNames []string) ([]MachineJob, error) {
+	var jobs []MachineJob
+	for _, name := range jobNames {
+		found := false
+		for _, job := range AllJobs() {
+			if job.String() == name {
+				jobs = append(jobs, job)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, errors.NotValidf("machine job %q", name)
+		}
+	}
+	return jobs, nil
+}
+
+// machineSequence returns the name of the sequence from which the
+// machine's id was allocated, and the machine's number within it.
+func machineSequence(id string) (string, int) {
+	i := strings.LastIndex(id, "/")
+	number, _ := strconv.Atoi(id[i+1:])
+	if i < 0 {
+		return "machine", number
+	}
+	parentAndType := id[:i]
+	j := strings.LastIndex(parentAndType, "/")
+	return fmt.Sprintf("machine%s%sContainer", parentAndType[:j], parentAndType[j+1:]), number
+}
+
+// unitNumber returns the number of the named unit.
+func unitNumber(unitName string) int {
+	number, _ := strconv.Atoi(unitName[strings.LastIndex(unitName, "/")+1:])
+	return number
+}
+
+// importServices adds the services, and then their units. Principal
+// units are added before subordinate units, which must be added with
+// their principals.
+func (st *State) importServices(services []description.Service) error {
+	imported := make(map[string]*Service)
+	for _, s := range services {
+		service, err := st.importService(s)
+		if err != nil {
+			return errors.Annotatef(err, "cannot import service %q", s.Name)
+		}
+		imported[s.Name] = service
+	}
+	for _, principals := range []bool{true, false} {
+		for _, s := range services {
+			service := imported[s.Name]
+			if service.IsPrincipal() != principals {
+				continue
+			}
+			units := make([]description.Unit, len(s.Units))
+			copy(units, s.Units)
+			sort.Sort(unitsByName(units))
+			for _, u := range units {
+				if err := st.importUnit(service, u); err != nil {
+					return errors.Annotatef(err, "cannot import unit %q", u.Name)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (st *State) importService(s description.Service) (*Service, error) {
+	curl, err := charm.ParseURL(s.Charm)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ch, err := st.Charm(curl)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	storage := make(map[string]StorageConstraints)
+	for name, cons := range s.Storage {
+		storage[name] = StorageConstraints{
+			Pool:  cons.Pool,
+			Size:  cons.Size,
+			Count: cons.Count,
+		}
+	}
+	owner := names.NewUserTag(s.Owner).String()
+	service, err := st.AddService(s.Name, owner, ch, nil, storage)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(s.Settings) > 0 {
+		if err := service.UpdateConfigSettings(charm.Settings(s.Settings)); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	if s.Constraints != "" {
+		cons, err := constraints.Parse(s.Constraints)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if err := service.SetConstraints(cons); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	if s.Exposed {
+		if err := service.SetExposedTo(s.ExposedTo); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	if err := st.importAnnotations(service, s.Annotations); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return service, nil
+}
+
+// importUnit adds the unit with its original name, assigns it to its
+// original machine and records the provisioned state of its storage.
+func (st *State) importUnit(service *Service, u description.Unit) error {
+	if err := st.ensureSequence(service.Tag().String(), unitNumber(u.Name)); err != nil {
+		return errors.Trace(err)
+	}
+	name, ops, err := service.addUnitOps(u.Principal, nil)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if name != u.Name {
+		return errors.Errorf("unit would be named %q", name)
+	}
+	if err := st.runTransaction(ops); err != nil {
+		return errors.Trace(err)
+	}
+	unit, err := st.Unit(name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if u.Machine != "" {
+		machine, err := st.Machine(u.Machine)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := unit.AssignToMachine(machine); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if err := st.importAnnotations(unit, u.Annotations); err != nil {
+		return errors.Trace(err)
+	}
+	return st.importUnitStorage(unit, u.Storage)
+}
+
+// importUnitStorage records the provisioned volumes and filesystems of
+// the unit's storage. The unit's storage instances are matched to the
+// described storage by name.
+func (st *State) importUnitStorage(unit *Unit, storage []description.Storage) error {
+	if len(storage) == 0 {
+		return nil
+	}
+	attachments, err := st.UnitStorageAttachments(unit.UnitTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	instances := make(map[string][]names.StorageTag)
+	for _, attachment := range attachments {
+		storageInstance, err := st.StorageInstance(attachment.StorageInstance())
+		if err != nil {
+			return errors.Trace(err)
+		}
+		name := storageInstance.StorageName()
+		instances[name] = append(instances[name], storageInstance.StorageTag())
+	}
+	for _, tags := range instances {
+		sort.Sort(storageTagsById(tags))
+	}
+	var machineTag names.MachineTag
+	if machineId, err := unit.AssignedMachineId(); err == nil {
+		machineTag = names.NewMachineTag(machineId)
+	} else if !errors.IsNotAssigned(err) {
+		return errors.Trace(err)
+	}
+	for _, s := range storage {
+		tags := instances[s.Name]
+		if len(tags) == 0 {
+			return errors.NotFoundf("storage %q for %q", s.Id, s.Name)
+		}
+		tag := tags[0]
+		instances[s.Name] = tags[1:]
+		if err := st.importStorageInstance(tag, machineTag, s); err != nil {
+			return errors.Annotatef(err, "cannot import storage %q", s.Id)
+		}
+	}
+	return nil
+}
+
+func (st *State) importStorageInstance(tag names.StorageTag, machineTag names.MachineTag, s description.Storage) error {
+	var filesystem Filesystem
+	var volumeTag names.VolumeTag
+	if s.Filesystem != nil {
+		var err error
+		if filesystem, err = st.StorageInstanceFilesystem(tag); err != nil {
+			return errors.Trace(err)
+		}
+		if volumeTag, err = filesystem.Volume(); err == ErrNoBackingVolume {
+			volumeTag = names.VolumeTag{}
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+	} else if s.Volume != nil {
+		volume, err := st.StorageInstanceVolume(tag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		volumeTag = volume.VolumeTag()
+	}
+	provisioned, err := st.machineProvisioned(machineTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if s.Volume != nil && volumeTag != (names.VolumeTag{}) {
+		if err := st.SetVolumeInfo(volumeTag, VolumeInfo{
+			HardwareId: s.Volume.HardwareId,
+			Size:       s.Volume.Size,
+			Pool:       s.Volume.Pool,
+			VolumeId:   s.Volume.VolumeId,
+			Persistent: s.Volume.Persistent,
+		}); err != nil {
+			return errors.Trace(err)
+		}
+		if provisioned {
+			if err := st.SetVolumeAttachmentInfo(machineTag, volumeTag, VolumeAttachmentInfo{
+				DeviceName: s.Volume.DeviceName,
+				ReadOnly:   s.Volume.ReadOnly,
+			}); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+	// The info of a volume-backed filesystem can only be set once its
+	// volume is attached.
+	if s.Filesystem != nil && (volumeTag == (names.VolumeTag{}) || provisioned) {
+		if err := st.SetFilesystemInfo(filesystem.FilesystemTag(), FilesystemInfo{
+			Size:         s.Filesystem.Size,
+			Pool:         s.Filesystem.Pool,
+			FilesystemId: s.Filesystem.FilesystemId,
+		}); err != nil {
+			return errors.Trace(err)
+		}
+		if provisioned {
+			if err := st.SetFilesystemAttachmentInfo(machineTag, filesystem.FilesystemTag(), FilesystemAttachmentInfo{
+				MountPoint: s.Filesystem.MountPoint,
+				ReadOnly:   s.Filesystem.ReadOnly,
+			}); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+	return nil
+}
+
+// machineProvisioned reports whether the machine with the given tag
+// exists and has been provisioned.
+func (st *State) machineProvisioned(tag names.MachineTag) (bool, error) {
+	if tag == (names.MachineTag{}) {
+		return false, nil
+	}
+	m, err := st.Machine(tag.Id())
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	if _, err := m.InstanceId(); errors.IsNotProvisioned(err) {
+		return false, nil
+	} else if err != nil {
+		return false, errors.Trace(err)
+	}
+	return true, nil
+}
+
+// importRelations adds the relations with their original ids.
+func (st *State) importRelations(relations []description.Relation) error {
+	sorted := make([]description.Relation, len(relations))
+	copy(sorted, relations)
+	sort.Sort(relationsById(sorted))
+	for _, r := range sorted {
+		eps, err := st.InferEndpoints(r.Endpoints...)
+		if err != nil {
+			return errors.Annotatef(err, "cannot import relation %d", r.Id)
+		}
+		if err := st.ensureSequence("relation", r.Id); err != nil {
+			return errors.Trace(err)
+		}
+		relation, err := st.AddRelation(eps...)
+		if err != nil {
+			return errors.Annotatef(err, "cannot import relation %d", r.Id)
+		}
+		if relation.Id() != r.Id {
+			return errors.Errorf("relation %d would have id %d", r.Id, relation.Id())
+		}
+	}
+	return nil
+}
+
+type storageTagsById []names.StorageTag
+
+func (s storageTagsById) Len() int           { return len(s) }
+func (s storageTagsById) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s storageTagsById) Less(i, j int) bool { return s[i].Id() < s[j].Id() }
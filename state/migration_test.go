@@ -0,0 +1,176 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v5"
+
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/description"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type MigrationSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&MigrationSuite{})
+
+// makeModel populates the environment with a provisioned machine
+// hosting a container, related principal services, and a subordinate.
+func (s *MigrationSuite) makeModel(c *gc.C) {
+	machine := s.factory.MakeMachine(c, nil)
+	container := s.factory.MakeMachineNested(c, machine.Id(), nil)
+	err := s.State.SetAnnotations(machine, map[string]string{"rack": "a1"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	wordpress := s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	err = wordpress.UpdateConfigSettings(charm.Settings{"blog-title": "migrated"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = wordpress.SetConstraints(constraints.MustParse("mem=2G"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = wordpress.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetAnnotations(wordpress, map[string]string{"owner": "ops"})
+	c.Assert(err, jc.ErrorIsNil)
+	mysql := s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	s.AddTestingService(c, "logging", s.AddTestingCharm(c, "logging"))
+
+	// Leave a gap in the unit numbers, which must be preserved.
+	wordpress0 := s.factory.MakeUnit(c, &factory.UnitParams{Service: wordpress, Machine: machine})
+	wordpress1 := s.factory.MakeUnit(c, &factory.UnitParams{Service: wordpress, Machine: container})
+	err = wordpress1.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = wordpress1.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+	s.factory.MakeUnit(c, &factory.UnitParams{Service: wordpress, Machine: container})
+	s.factory.MakeUnit(c, &factory.UnitParams{Service: mysql, Machine: machine})
+
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+	eps, err = s.State.InferEndpoints("logging", "wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+	ru, err := rel.Unit(wordpress0)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ru.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *MigrationSuite) TestExport(c *gc.C) {
+	s.makeModel(c)
+	desc, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(desc.Version, gc.Equals, description.Version)
+	c.Assert(desc.Owner, gc.Equals, s.Owner.Username())
+	c.Assert(desc.Users, gc.HasLen, 1)
+	c.Assert(desc.Users[0].Name, gc.Equals, s.Owner.Username())
+
+	c.Assert(desc.Machines, gc.HasLen, 2)
+	c.Assert(desc.Machines[0].Id, gc.Equals, "0")
+	c.Assert(desc.Machines[0].InstanceId, gc.Not(gc.Equals), "")
+	c.Assert(desc.Machines[0].Jobs, jc.DeepEquals, []string{"JobHostUnits"})
+	c.Assert(desc.Machines[0].Annotations, jc.DeepEquals, map[string]string{"rack": "a1"})
+	c.Assert(desc.Machines[1].Id, gc.Equals, "0/lxc/0")
+	c.Assert(desc.Machines[1].ContainerType, gc.Equals, "lxc")
+
+	c.Assert(desc.Services, gc.HasLen, 3)
+	c.Assert(desc.Services[0].Name, gc.Equals, "logging")
+	c.Assert(desc.Services[0].Units, jc.DeepEquals, []description.Unit{
+		{Name: "logging/0", Principal: "wordpress/0"},
+	})
+	c.Assert(desc.Services[1].Name, gc.Equals, "mysql")
+	wordpress := desc.Services[2]
+	c.Assert(wordpress.Name, gc.Equals, "wordpress")
+	c.Assert(wordpress.Charm, gc.Equals, "local:quantal/quantal-wordpress-3")
+	c.Assert(wordpress.Exposed, jc.IsTrue)
+	c.Assert(wordpress.Constraints, gc.Equals, "mem=2048M")
+	c.Assert(wordpress.Settings, jc.DeepEquals, map[string]interface{}{"blog-title": "migrated"})
+	c.Assert(wordpress.Annotations, jc.DeepEquals, map[string]string{"owner": "ops"})
+	c.Assert(wordpress.Units, jc.DeepEquals, []description.Unit{
+		{Name: "wordpress/0", Machine: "0"},
+		{Name: "wordpress/2", Machine: "0/lxc/0"},
+	})
+
+	c.Assert(desc.Relations, jc.DeepEquals, []description.Relation{
+		{Id: 0, Endpoints: []string{"mysql:server", "wordpress:db"}},
+		{Id: 1, Endpoints: []string{"logging:logging-directory", "wordpress:logging-dir"}},
+	})
+}
+
+func (s *MigrationSuite) TestImport(c *gc.C) {
+	s.makeModel(c)
+	desc, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+
+	st := s.factory.MakeEnvironment(c, nil)
+	defer st.Close()
+	for _, name := range []string{"wordpress", "mysql", "logging"} {
+		state.AddTestingCharm(c, st, name)
+	}
+	err = st.Import(desc)
+	c.Assert(err, jc.ErrorIsNil)
+
+	imported, err := st.Export()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(imported.Machines, jc.DeepEquals, desc.Machines)
+	c.Assert(imported.Services, jc.DeepEquals, desc.Services)
+	c.Assert(imported.Relations, jc.DeepEquals, desc.Relations)
+	c.Assert(imported.Users, jc.DeepEquals, desc.Users)
+
+	// Imported machines keep their instances.
+	m, err := st.Machine("0")
+	c.Assert(err, jc.ErrorIsNil)
+	instId, err := m.InstanceId()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(instId), gc.Equals, desc.Machines[0].InstanceId)
+
+	// New entities are numbered after the imported ones.
+	m, err = st.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m.Id(), gc.Equals, "1")
+	m, err = st.AddMachineInsideMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	}, "0", instance.LXC)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m.Id(), gc.Equals, "0/lxc/1")
+	wordpress, err := st.Service("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	unit, err := wordpress.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unit.Name(), gc.Equals, "wordpress/3")
+}
+
+func (s *MigrationSuite) TestImportIntoEnvironmentWithServices(c *gc.C) {
+	s.makeModel(c)
+	desc, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.Import(desc)
+	c.Assert(err, gc.ErrorMatches, "cannot import environment: environment already has services")
+}
+
+func (s *MigrationSuite) TestImportMissingCharm(c *gc.C) {
+	s.makeModel(c)
+	desc, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+
+	st := s.factory.MakeEnvironment(c, nil)
+	defer st.Close()
+	err = st.Import(desc)
+	c.Assert(err, gc.ErrorMatches, `cannot import environment: cannot import service "logging": charm "local:quantal/quantal-logging-1" not found`)
+}
+
+func (s *MigrationSuite) TestImportUnsupportedVersion(c *gc.C) {
+	err := s.State.Import(&description.Environment{Version: 2})
+	c.Assert(err, gc.ErrorMatches, "cannot import environment: environment description version 2 not supported")
+}
@@ -7,18 +7,35 @@ import (
 	"container/list"
 	stderrors "errors"
 	"reflect"
+	"sync"
 
 	"github.com/juju/errors"
 	"launchpad.net/tomb"
 
+	"github.com/juju/juju/monitoring"
 	"github.com/juju/juju/state/multiwatcher"
 	"github.com/juju/juju/state/watcher"
 )
 
+// allWatcherSubscribers tracks the number of Multiwatchers that have
+// been created and not yet stopped.
+var allWatcherSubscribers = monitoring.NewGaugeVec(
+	"juju_state_allwatcher_subscribers",
+	"Number of AllWatcher subscribers.",
+)
+
+func init() {
+	monitoring.MustRegister(allWatcherSubscribers)
+}
+
 // Multiwatcher watches any changes to the state.
 type Multiwatcher struct {
 	all *storeManager
 
+	// unsubscribe ensures that the watcher is only
+	// removed from allWatcherSubscribers once.
+	unsubscribe sync.Once
+
 	// The following fields are maintained by the storeManager
 	// goroutine.
 	revno   int64
@@ -28,6 +45,7 @@ type Multiwatcher struct {
 // NewMultiwatcher creates a new watcher that can observe
 // changes to an underlying store manager.
 func NewMultiwatcher(all *storeManager) *Multiwatcher {
+	allWatcherSubscribers.With().Inc()
 	return &Multiwatcher{
 		all: all,
 	}
@@ -35,6 +53,7 @@ func NewMultiwatcher(all *storeManager) *Multiwatcher {
 
 // Stop stops the watcher.
 func (w *Multiwatcher) Stop() error {
+	w.unsubscribe.Do(allWatcherSubscribers.With().Dec)
 	select {
 	case w.all.request <- &request{w: w}:
 		return nil
@@ -655,6 +655,24 @@ func (*storeManagerSuite) TestMultiwatcherStop(c *gc.C) {
 	<-done
 }
 
+func (*storeManagerSuite) TestMultiwatcherSubscribers(c *gc.C) {
+	sm := newStoreManager(newTestBacking(nil))
+	defer func() {
+		c.Check(sm.Stop(), gc.IsNil)
+	}()
+	subscribers := allWatcherSubscribers.With().Value()
+	w := NewMultiwatcher(sm)
+	c.Assert(allWatcherSubscribers.With().Value(), gc.Equals, subscribers+1)
+	err := w.Stop()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(allWatcherSubscribers.With().Value(), gc.Equals, subscribers)
+
+	// Stopping the watcher again does not change the count.
+	err = w.Stop()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(allWatcherSubscribers.With().Value(), gc.Equals, subscribers)
+}
+
 func (*storeManagerSuite) TestMultiwatcherStopBecauseStoreManagerError(c *gc.C) {
 	b := newTestBacking([]multiwatcher.EntityInfo{&multiwatcher.MachineInfo{Id: "0"}})
 	sm := newStoreManager(b)
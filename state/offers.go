@@ -0,0 +1,159 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	"gopkg.in/juju/charm.v5"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// Offer represents a service published, with selected endpoints, so
+// that other environments on the same state server can relate to it.
+type Offer struct {
+	st  *State
+	doc offerDoc
+}
+
+type offerDoc struct {
+	DocID       string   `bson:"_id"`
+	EnvUUID     string   `bson:"env-uuid"`
+	Name        string   `bson:"name"`
+	ServiceName string   `bson:"service"`
+	Endpoints   []string `bson:"endpoints"`
+}
+
+// Name returns the name of the offer.
+func (o *Offer) Name() string {
+	return o.doc.Name
+}
+
+// ServiceName returns the name of the offered service.
+func (o *Offer) ServiceName() string {
+	return o.doc.ServiceName
+}
+
+// Endpoints returns the names of the offered endpoints.
+func (o *Offer) Endpoints() []string {
+	return append([]string(nil), o.doc.Endpoints...)
+}
+
+// String implements fmt.Stringer.
+func (o *Offer) String() string {
+	return o.doc.Name
+}
+
+// Remove removes the offer. Remote services that were added by consuming
+// the offer are left alone, but the relations to them are no longer
+// mirrored into this environment.
+func (o *Offer) Remove() error {
+	ops := []txn.Op{{
+		C:      offersC,
+		Id:     o.doc.DocID,
+		Remove: true,
+	}}
+	if err := o.st.runTransaction(ops); err != nil {
+		return errors.Annotatef(err, "cannot remove offer %q", o)
+	}
+	return nil
+}
+
+// AddOffer offers the named endpoints of the named service to the other
+// environments on the state server, under the given offer name. It
+// returns an error satisfying errors.IsAlreadyExists if an offer with
+// the same name already exists.
+func (st *State) AddOffer(name, serviceName string, endpoints []string) (offer *Offer, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot add offer %q", name)
+
+	if !names.IsValidService(name) {
+		return nil, errors.NotValidf("offer name %q", name)
+	}
+	if len(endpoints) == 0 {
+		return nil, errors.New("no endpoints specified")
+	}
+	doc := offerDoc{
+		DocID:       st.docID(name),
+		EnvUUID:     st.EnvironUUID(),
+		Name:        name,
+		ServiceName: serviceName,
+		Endpoints:   endpoints,
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if _, err := st.Offer(name); err == nil {
+			return nil, errors.AlreadyExistsf("offer %q", name)
+		} else if !errors.IsNotFound(err) {
+			return nil, errors.Trace(err)
+		}
+		service, err := st.Service(serviceName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if service.Life() != Alive {
+			return nil, errors.Errorf("service %q is not alive", serviceName)
+		}
+		if service.doc.Remote != nil {
+			return nil, errors.Errorf("service %q is a remote service", serviceName)
+		}
+		if service.doc.Subordinate {
+			return nil, errors.Errorf("service %q is a subordinate service", serviceName)
+		}
+		for _, name := range endpoints {
+			ep, err := service.Endpoint(name)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if ep.Role == charm.RolePeer {
+				return nil, errors.Errorf("cannot offer peer relation %q", name)
+			}
+		}
+		return []txn.Op{{
+			C:      servicesC,
+			Id:     service.doc.DocID,
+			Assert: isAliveDoc,
+		}, {
+			C:      offersC,
+			Id:     doc.DocID,
+			Assert: txn.DocMissing,
+			Insert: doc,
+		}}, nil
+	}
+	if err := st.run(buildTxn); err != nil {
+		return nil, err
+	}
+	return &Offer{st, doc}, nil
+}
+
+// Offer returns the offer with the given name.
+func (st *State) Offer(name string) (*Offer, error) {
+	offers, closer := st.getCollection(offersC)
+	defer closer()
+
+	var doc offerDoc
+	err := offers.FindId(name).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("offer %q", name)
+	}
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get offer %q", name)
+	}
+	return &Offer{st, doc}, nil
+}
+
+// AllOffers returns all the offers made by the environment.
+func (st *State) AllOffers() ([]*Offer, error) {
+	offers, closer := st.getCollection(offersC)
+	defer closer()
+
+	var docs []offerDoc
+	if err := offers.Find(nil).Sort("name").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get all offers")
+	}
+	result := make([]*Offer, len(docs))
+	for i, doc := range docs {
+		result[i] = &Offer{st, doc}
+	}
+	return result, nil
+}
@@ -0,0 +1,82 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type OfferSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&OfferSuite{})
+
+func (s *OfferSuite) TestAddOffer(c *gc.C) {
+	s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+
+	offer, err := s.State.AddOffer("blog", "wordpress", []string{"url", "db"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(offer.Name(), gc.Equals, "blog")
+	c.Assert(offer.ServiceName(), gc.Equals, "wordpress")
+	c.Assert(offer.Endpoints(), jc.DeepEquals, []string{"url", "db"})
+
+	offer, err = s.State.Offer("blog")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(offer.Name(), gc.Equals, "blog")
+	c.Assert(offer.ServiceName(), gc.Equals, "wordpress")
+	c.Assert(offer.Endpoints(), jc.DeepEquals, []string{"url", "db"})
+}
+
+func (s *OfferSuite) TestAddOfferErrors(c *gc.C) {
+	_, err := s.State.AddOffer("Bad Name", "wordpress", []string{"url"})
+	c.Assert(err, gc.ErrorMatches, `cannot add offer "Bad Name": offer name "Bad Name" not valid`)
+
+	_, err = s.State.AddOffer("blog", "wordpress", nil)
+	c.Assert(err, gc.ErrorMatches, `cannot add offer "blog": no endpoints specified`)
+
+	_, err = s.State.AddOffer("blog", "wordpress", []string{"url"})
+	c.Assert(err, gc.ErrorMatches, `cannot add offer "blog": service "wordpress" not found`)
+
+	s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	_, err = s.State.AddOffer("blog", "wordpress", []string{"nonsense"})
+	c.Assert(err, gc.ErrorMatches, `cannot add offer "blog": service "wordpress" has no "nonsense" relation`)
+
+	s.AddTestingService(c, "riak", s.AddTestingCharm(c, "riak"))
+	_, err = s.State.AddOffer("storage", "riak", []string{"ring"})
+	c.Assert(err, gc.ErrorMatches, `cannot add offer "storage": cannot offer peer relation "ring"`)
+
+	s.AddTestingService(c, "logging", s.AddTestingCharm(c, "logging"))
+	_, err = s.State.AddOffer("logs", "logging", []string{"info"})
+	c.Assert(err, gc.ErrorMatches, `cannot add offer "logs": service "logging" is a subordinate service`)
+
+	_, err = s.State.AddOffer("blog", "wordpress", []string{"url"})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddOffer("blog", "wordpress", []string{"db"})
+	c.Assert(err, gc.ErrorMatches, `cannot add offer "blog": offer "blog" already exists`)
+	c.Assert(errors.IsAlreadyExists(errors.Cause(err)), jc.IsTrue)
+}
+
+func (s *OfferSuite) TestAllOffersAndRemove(c *gc.C) {
+	s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	_, err := s.State.AddOffer("db", "mysql", []string{"server"})
+	c.Assert(err, jc.ErrorIsNil)
+	blog, err := s.State.AddOffer("blog", "wordpress", []string{"url"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	offers, err := s.State.AllOffers()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(offers, gc.HasLen, 2)
+	c.Assert(offers[0].Name(), gc.Equals, "blog")
+	c.Assert(offers[1].Name(), gc.Equals, "db")
+
+	err = blog.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.Offer("blog")
+	c.Assert(err, gc.ErrorMatches, `offer "blog" not found`)
+	c.Assert(errors.IsNotFound(err), jc.IsTrue)
+}
@@ -138,7 +138,7 @@ func (st *State) envSetupOps(cfg *config.Config, envUUID, serverUUID string, own
 	if serverUUID == "" {
 		serverUUID = envUUID
 	}
-	envUserOp, _ := createEnvUserOpAndDoc(envUUID, owner, owner, owner.Name())
+	envUserOp, _ := createEnvUserOpAndDoc(envUUID, owner, owner, owner.Name(), AdminAccess)
 	ops := []txn.Op{
 		createConstraintsOp(st, environGlobalKey, constraints.Value{}),
 		createSettingsOp(st, environGlobalKey, cfg.AllAttrs()),
@@ -163,8 +163,10 @@ var indexes = []struct {
 	{unitsC, []string{"env-uuid", "service"}, false, false},
 	{unitsC, []string{"env-uuid", "principal"}, false, false},
 	{unitsC, []string{"env-uuid", "machineid"}, false, false},
+	{unitsC, []string{"env-uuid", "service", "remoteunit"}, false, false},
 	// TODO(thumper): schema change to remove this index.
 	{usersC, []string{"name"}, false, false},
+	{userTokensC, []string{"user"}, false, false},
 	{networksC, []string{"env-uuid", "providerid"}, true, false},
 	{networkInterfacesC, []string{"env-uuid", "interfacename", "machineid"}, true, false},
 	{networkInterfacesC, []string{"env-uuid", "macaddress", "networkname"}, true, false},
@@ -172,14 +174,20 @@ var indexes = []struct {
 	{networkInterfacesC, []string{"env-uuid", "machineid"}, false, false},
 	{blockDevicesC, []string{"env-uuid", "machineid"}, false, false},
 	{subnetsC, []string{"providerid"}, true, true},
+	{subnetsC, []string{"env-uuid", "spacename"}, false, false},
 	{ipaddressesC, []string{"env-uuid", "state"}, false, false},
 	{ipaddressesC, []string{"env-uuid", "subnetid"}, false, false},
 	{storageInstancesC, []string{"env-uuid", "owner"}, false, false},
 	{storageAttachmentsC, []string{"env-uuid", "storageid"}, false, false},
 	{storageAttachmentsC, []string{"env-uuid", "unitid"}, false, false},
 	{volumesC, []string{"env-uuid", "storageid"}, false, false},
+	{volumeSnapshotsC, []string{"env-uuid", "volumeid"}, false, false},
 	{filesystemsC, []string{"env-uuid", "storageid"}, false, false},
 	{statusesHistoryC, []string{"env-uuid", "entityid"}, false, false},
+	{outputChunksC, []string{"env-uuid", "outputid", "unit", "seq"}, false, false},
+	{outputChunksC, []string{"env-uuid", "time"}, false, false},
+	{auditLogC, []string{"env-uuid", "timestamp"}, false, false},
+	{auditLogC, []string{"env-uuid", "user"}, false, false},
 }
 
 // The capped collection used for transaction logs defaults to 10MB.
@@ -190,6 +198,13 @@ var (
 	txnLogSizeTests = 1000000
 )
 
+// The capped collection used for the audit log defaults to 50MB.
+// Like the transaction log, it is shrunk for tests.
+var (
+	auditLogSize      = 50000000
+	auditLogSizeTests = 1000000
+)
+
 func maybeUnauthorized(err error, msg string) error {
 	if err == nil {
 		return nil
@@ -245,6 +260,14 @@ func newState(session *mgo.Session, mongoInfo *mongo.MongoInfo, policy Policy) (
 		return nil, maybeUnauthorized(err, "cannot create transaction collection")
 	}
 
+	// Create the capped collection used to record audit events.
+	auditLog := db.C(auditLogC)
+	auditLogInfo := mgo.CollectionInfo{Capped: true, MaxBytes: auditLogSize}
+	err = auditLog.Create(&auditLogInfo)
+	if isCollectionExistsError(err) {
+		return nil, maybeUnauthorized(err, "cannot create audit log collection")
+	}
+
 	// Create and set up State.
 	st := &State{
 		mongoInfo: mongoInfo,
@@ -259,7 +282,7 @@ func newState(session *mgo.Session, mongoInfo *mongo.MongoInfo, policy Policy) (
 			}
 		}
 	}()
-	st.LeasePersistor = NewLeasePersistor(leaseC, st.run, st.getCollection)
+	st.LeasePersistor = NewLeasePersistor(leaseC, st.run, st.getCollection, st.databaseTime)
 
 	// Create DB indexes.
 	for _, item := range indexes {
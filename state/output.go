@@ -0,0 +1,192 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	jujutxn "github.com/juju/txn"
+	"github.com/juju/utils/set"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+const (
+	// OutputStdout identifies output that was written to stdout.
+	OutputStdout = "stdout"
+
+	// OutputStderr identifies output that was written to stderr.
+	OutputStderr = "stderr"
+)
+
+// OutputChunk holds a piece of the output of a running action, or of
+// commands run on a unit with juju run, as it was produced.
+type OutputChunk struct {
+	// Unit is the name of the unit that produced the output.
+	Unit string
+
+	// Seq orders the chunks produced by a unit for the same output,
+	// starting at 1.
+	Seq int
+
+	// Stream is OutputStdout or OutputStderr.
+	Stream string
+
+	// Data holds the output itself.
+	Data string
+
+	// Time records when the output was produced.
+	Time time.Time
+}
+
+type outputChunkDoc struct {
+	DocId    string    `bson:"_id"`
+	EnvUUID  string    `bson:"env-uuid"`
+	OutputId string    `bson:"outputid"`
+	Unit     string    `bson:"unit"`
+	Seq      int       `bson:"seq"`
+	Stream   string    `bson:"stream"`
+	Data     string    `bson:"data"`
+	Time     time.Time `bson:"time"`
+}
+
+// outputChunkId returns the local id of the document holding the
+// chunk of the given output with the given sequence number.
+func outputChunkId(outputId, unitName string, seq int) string {
+	return fmt.Sprintf("%s#%s#%d", outputId, unitName, seq)
+}
+
+// AddOutput records chunks of output produced by the given unit. The
+// output id is the id of the action that produced the output, or the id
+// supplied with commands run on the unit. Chunks that have already been
+// recorded are left unchanged, and the remaining chunks are recorded.
+func (st *State) AddOutput(outputId string, unit names.UnitTag, chunks []OutputChunk) error {
+	if outputId == "" {
+		return errors.New("output id required")
+	}
+	for _, chunk := range chunks {
+		if chunk.Seq < 1 {
+			return errors.Errorf("invalid output sequence number %d", chunk.Seq)
+		}
+		if chunk.Stream != OutputStdout && chunk.Stream != OutputStderr {
+			return errors.NotValidf("output stream %q", chunk.Stream)
+		}
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		recorded, err := st.recordedOutputChunks(outputId, unit, chunks)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops := make([]txn.Op, 0, len(chunks))
+		for _, chunk := range chunks {
+			id := outputChunkId(outputId, unit.Id(), chunk.Seq)
+			if recorded.Contains(id) {
+				continue
+			}
+			ops = append(ops, txn.Op{
+				C:      outputChunksC,
+				Id:     id,
+				Assert: txn.DocMissing,
+				Insert: &outputChunkDoc{
+					OutputId: outputId,
+					Unit:     unit.Id(),
+					Seq:      chunk.Seq,
+					Stream:   chunk.Stream,
+					Data:     chunk.Data,
+					Time:     chunk.Time.UTC(),
+				},
+			})
+		}
+		if len(ops) == 0 {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return ops, nil
+	}
+	if err := st.run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot add output %q", outputId)
+	}
+	return nil
+}
+
+// recordedOutputChunks returns the local ids of the documents holding
+// those of the given chunks that have already been recorded.
+func (st *State) recordedOutputChunks(outputId string, unit names.UnitTag, chunks []OutputChunk) (set.Strings, error) {
+	coll, closer := st.getCollection(outputChunksC)
+	defer closer()
+
+	docIds := make([]string, len(chunks))
+	for i, chunk := range chunks {
+		docIds[i] = st.docID(outputChunkId(outputId, unit.Id(), chunk.Seq))
+	}
+	recorded := make(set.Strings)
+	var doc struct {
+		DocId string `bson:"_id"`
+	}
+	iter := coll.Find(bson.D{{"_id", bson.D{{"$in", docIds}}}}).Select(bson.D{{"_id", 1}}).Iter()
+	for iter.Next(&doc) {
+		recorded.Add(st.localID(doc.DocId))
+	}
+	if err := iter.Close(); err != nil {
+		return nil, errors.Annotate(err, "cannot get recorded output")
+	}
+	return recorded, nil
+}
+
+// PruneOutput removes the chunks of output that were produced longer
+// ago than maxAge.
+func PruneOutput(st *State, maxAge time.Duration) error {
+	coll, closer := st.getCollection(outputChunksC)
+	defer closer()
+
+	oldest := time.Now().Add(-maxAge).UTC()
+	if _, err := coll.RemoveAll(bson.D{{"time", bson.D{{"$lt", oldest}}}}); err != nil {
+		return errors.Annotate(err, "cannot prune output")
+	}
+	return nil
+}
+
+// Output returns the recorded chunks of the output with the given id,
+// ordered by unit name and then by sequence number. For each unit named
+// in after, only the chunks that follow the given sequence number are
+// returned.
+func (st *State) Output(outputId string, after map[string]int) ([]OutputChunk, error) {
+	coll, closer := st.getCollection(outputChunksC)
+	defer closer()
+
+	query := bson.D{{"outputid", outputId}}
+	if len(after) > 0 {
+		unitNames := make([]string, 0, len(after))
+		for unitName := range after {
+			unitNames = append(unitNames, unitName)
+		}
+		sort.Strings(unitNames)
+		or := []bson.D{{{"unit", bson.D{{"$nin", unitNames}}}}}
+		for _, unitName := range unitNames {
+			or = append(or, bson.D{
+				{"unit", unitName},
+				{"seq", bson.D{{"$gt", after[unitName]}}},
+			})
+		}
+		query = append(query, bson.DocElem{"$or", or})
+	}
+	var docs []outputChunkDoc
+	if err := coll.Find(query).Sort("unit", "seq").All(&docs); err != nil {
+		return nil, errors.Annotatef(err, "cannot get output %q", outputId)
+	}
+	chunks := make([]OutputChunk, len(docs))
+	for i, doc := range docs {
+		chunks[i] = OutputChunk{
+			Unit:   doc.Unit,
+			Seq:    doc.Seq,
+			Stream: doc.Stream,
+			Data:   doc.Data,
+			Time:   doc.Time.UTC(),
+		}
+	}
+	return chunks, nil
+}
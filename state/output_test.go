@@ -0,0 +1,149 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type OutputSuite struct {
+	ConnSuite
+	now time.Time
+}
+
+var _ = gc.Suite(&OutputSuite{})
+
+func (s *OutputSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.now = time.Date(2015, 6, 1, 2, 0, 0, 0, time.UTC)
+}
+
+func (s *OutputSuite) chunk(unit string, seq int, stream, data string) state.OutputChunk {
+	return state.OutputChunk{
+		Unit:   unit,
+		Seq:    seq,
+		Stream: stream,
+		Data:   data,
+		Time:   s.now.Add(time.Duration(seq) * time.Second),
+	}
+}
+
+func (s *OutputSuite) TestAddOutput(c *gc.C) {
+	mysql0 := names.NewUnitTag("mysql/0")
+	mysql1 := names.NewUnitTag("mysql/1")
+	err := s.State.AddOutput("output-id", mysql0, []state.OutputChunk{
+		s.chunk("", 1, state.OutputStdout, "hello\n"),
+		s.chunk("", 2, state.OutputStderr, "oops\n"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AddOutput("output-id", mysql1, []state.OutputChunk{
+		s.chunk("", 1, state.OutputStdout, "hi\n"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AddOutput("other-id", mysql0, []state.OutputChunk{
+		s.chunk("", 1, state.OutputStdout, "other\n"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	chunks, err := s.State.Output("output-id", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(chunks, jc.DeepEquals, []state.OutputChunk{
+		s.chunk("mysql/0", 1, state.OutputStdout, "hello\n"),
+		s.chunk("mysql/0", 2, state.OutputStderr, "oops\n"),
+		s.chunk("mysql/1", 1, state.OutputStdout, "hi\n"),
+	})
+}
+
+func (s *OutputSuite) TestAddOutputIgnoresRecordedChunks(c *gc.C) {
+	mysql0 := names.NewUnitTag("mysql/0")
+	err := s.State.AddOutput("output-id", mysql0, []state.OutputChunk{
+		s.chunk("", 1, state.OutputStdout, "hello\n"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AddOutput("output-id", mysql0, []state.OutputChunk{
+		s.chunk("", 1, state.OutputStdout, "goodbye\n"),
+		s.chunk("", 2, state.OutputStdout, "world\n"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Recording only chunks that have already been
+	// recorded is not an error.
+	err = s.State.AddOutput("output-id", mysql0, []state.OutputChunk{
+		s.chunk("", 2, state.OutputStdout, "again\n"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	chunks, err := s.State.Output("output-id", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(chunks, jc.DeepEquals, []state.OutputChunk{
+		s.chunk("mysql/0", 1, state.OutputStdout, "hello\n"),
+		s.chunk("mysql/0", 2, state.OutputStdout, "world\n"),
+	})
+}
+
+func (s *OutputSuite) TestAddOutputInvalid(c *gc.C) {
+	mysql0 := names.NewUnitTag("mysql/0")
+	err := s.State.AddOutput("", mysql0, nil)
+	c.Assert(err, gc.ErrorMatches, "output id required")
+	err = s.State.AddOutput("output-id", mysql0, []state.OutputChunk{
+		s.chunk("", 0, state.OutputStdout, "hello\n"),
+	})
+	c.Assert(err, gc.ErrorMatches, "invalid output sequence number 0")
+	err = s.State.AddOutput("output-id", mysql0, []state.OutputChunk{
+		s.chunk("", 1, "stdin", "hello\n"),
+	})
+	c.Assert(err, gc.ErrorMatches, `output stream "stdin" not valid`)
+}
+
+func (s *OutputSuite) TestOutputAfter(c *gc.C) {
+	for _, unitName := range []string{"mysql/0", "mysql/1"} {
+		err := s.State.AddOutput("output-id", names.NewUnitTag(unitName), []state.OutputChunk{
+			s.chunk("", 1, state.OutputStdout, "one\n"),
+			s.chunk("", 2, state.OutputStdout, "two\n"),
+			s.chunk("", 3, state.OutputStdout, "three\n"),
+		})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	err := s.State.AddOutput("output-id", names.NewUnitTag("mysql/2"), []state.OutputChunk{
+		s.chunk("", 1, state.OutputStdout, "one\n"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	chunks, err := s.State.Output("output-id", map[string]int{"mysql/0": 2, "mysql/1": 3})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(chunks, jc.DeepEquals, []state.OutputChunk{
+		s.chunk("mysql/0", 3, state.OutputStdout, "three\n"),
+		s.chunk("mysql/2", 1, state.OutputStdout, "one\n"),
+	})
+}
+
+func (s *OutputSuite) TestPruneOutput(c *gc.C) {
+	mysql0 := names.NewUnitTag("mysql/0")
+	recent := state.OutputChunk{
+		Seq:    3,
+		Stream: state.OutputStdout,
+		Data:   "recent\n",
+		Time:   time.Now().UTC().Truncate(time.Millisecond),
+	}
+	err := s.State.AddOutput("output-id", mysql0, []state.OutputChunk{
+		s.chunk("", 1, state.OutputStdout, "old\n"),
+		s.chunk("", 2, state.OutputStderr, "older\n"),
+		recent,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = state.PruneOutput(s.State, time.Hour)
+	c.Assert(err, jc.ErrorIsNil)
+
+	recent.Unit = "mysql/0"
+	chunks, err := s.State.Output("output-id", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(chunks, jc.DeepEquals, []state.OutputChunk{recent})
+}
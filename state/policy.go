@@ -77,6 +77,16 @@ type EnvironCapability interface {
 	SupportsUnitPlacement() error
 }
 
+// SubnetPlacer is implemented by the EnvironCapability of environments
+// that start machines in the subnets of the spaces that the endpoints
+// of their units are bound to.
+type SubnetPlacer interface {
+	// SupportsSubnetPlacement returns an error which, if non-nil,
+	// indicates that the environment does not start machines in
+	// particular subnets, so endpoints cannot be bound to spaces.
+	SupportsSubnetPlacement() error
+}
+
 // precheckInstance calls the state's assigned policy, if non-nil, to obtain
 // a Prechecker, and calls PrecheckInstance if a non-nil Prechecker is returned.
 func (st *State) precheckInstance(series string, cons constraints.Value, placement string) error {
@@ -188,6 +198,34 @@ func (st *State) supportsUnitPlacement() error {
 	return capability.SupportsUnitPlacement()
 }
 
+// supportsSubnetPlacement calls the state's assigned policy, if non-nil,
+// to obtain an EnvironCapability, and calls SupportsSubnetPlacement if
+// it is a SubnetPlacer. Environments that are not cannot have endpoints
+// bound to spaces.
+func (st *State) supportsSubnetPlacement() error {
+	if st.policy == nil {
+		return nil
+	}
+	cfg, err := st.EnvironConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	capability, err := st.policy.EnvironCapability(cfg)
+	if errors.IsNotImplemented(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if capability == nil {
+		return fmt.Errorf("policy returned nil EnvironCapability without an error")
+	}
+	placer, ok := capability.(SubnetPlacer)
+	if !ok {
+		return errors.NotSupportedf("binding endpoints to spaces in %q environments", cfg.Type())
+	}
+	return placer.SupportsSubnetPlacement()
+}
+
 // InstanceDistributor is a policy interface that is provided
 // to State to perform distribution of units across instances
 // for high availability.
@@ -0,0 +1,161 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// RemoteServiceInfo identifies the service in another environment on the
+// same state server that a remote service is a proxy for.
+type RemoteServiceInfo struct {
+	// EnvUUID is the UUID of the environment holding the service.
+	EnvUUID string
+
+	// ServiceName is the name of the service in that environment.
+	ServiceName string
+
+	// OfferName is the name of the offer that was consumed to add the
+	// remote service. It is empty for the remote services that are
+	// added to the offering environment to stand in for the services
+	// related to the offer.
+	OfferName string
+
+	// Endpoints holds the names of the endpoints that may be related
+	// to. All endpoints may be related to if it is empty.
+	Endpoints []string
+}
+
+type remoteServiceDoc struct {
+	EnvUUID     string   `bson:"env-uuid"`
+	ServiceName string   `bson:"service"`
+	OfferName   string   `bson:"offer,omitempty"`
+	Endpoints   []string `bson:"endpoints,omitempty"`
+}
+
+// offers reports whether the named endpoint may be related to.
+func (doc *remoteServiceDoc) offers(endpoint string) bool {
+	if len(doc.Endpoints) == 0 {
+		return true
+	}
+	for _, name := range doc.Endpoints {
+		if name == endpoint {
+			return true
+		}
+	}
+	return false
+}
+
+// AddRemoteService adds a service that is a proxy for the service in
+// another environment described by info. The remote service runs a copy
+// of that service's charm, ch, which is added to this environment if it
+// is not already known. The units of a remote service are proxies for
+// the units of the service in the other environment; they are never
+// assigned to machines.
+func (st *State) AddRemoteService(name, owner string, ch *Charm, info RemoteServiceInfo) (_ *Service, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot add remote service %q", name)
+
+	if info.EnvUUID == st.EnvironUUID() {
+		return nil, errors.Errorf("service %q is in this environment", info.ServiceName)
+	}
+	meta := ch.Meta()
+	if meta.Subordinate {
+		return nil, errors.Errorf("charm %q is subordinate", ch.URL())
+	}
+	for _, endpoint := range info.Endpoints {
+		_, provides := meta.Provides[endpoint]
+		_, requires := meta.Requires[endpoint]
+		if !provides && !requires {
+			return nil, errors.NotFoundf("relation %q of charm %q", endpoint, ch.URL())
+		}
+	}
+	localCharm, err := st.Charm(ch.URL())
+	if errors.IsNotFound(err) {
+		localCharm, err = st.AddCharm(ch, ch.URL(), ch.StoragePath(), ch.BundleSha256())
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return st.addService(name, owner, localCharm, nil, nil, &remoteServiceDoc{
+		EnvUUID:     info.EnvUUID,
+		ServiceName: info.ServiceName,
+		OfferName:   info.OfferName,
+		Endpoints:   info.Endpoints,
+	})
+}
+
+// RemoteInfo returns the service in another environment that the
+// service is a proxy for, and whether the service is a remote service.
+func (s *Service) RemoteInfo() (RemoteServiceInfo, bool) {
+	if s.doc.Remote == nil {
+		return RemoteServiceInfo{}, false
+	}
+	return RemoteServiceInfo{
+		EnvUUID:     s.doc.Remote.EnvUUID,
+		ServiceName: s.doc.Remote.ServiceName,
+		OfferName:   s.doc.Remote.OfferName,
+		Endpoints:   append([]string(nil), s.doc.Remote.Endpoints...),
+	}, true
+}
+
+// AddRemoteUnit adds a unit to the remote service that is a proxy for
+// the named unit of the service in the other environment.
+func (s *Service) AddRemoteUnit(remoteName string) (unit *Unit, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot add unit for %q to service %q", remoteName, s)
+	if s.doc.Remote == nil {
+		return nil, errors.Errorf("service is not a remote service")
+	}
+	if _, err := s.RemoteUnit(remoteName); err == nil {
+		return nil, errors.AlreadyExistsf("unit for %q", remoteName)
+	} else if !errors.IsNotFound(err) {
+		return nil, errors.Trace(err)
+	}
+	name, ops, err := s.addUnitOpsWithRemote("", remoteName, nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := s.st.runTransaction(ops); err == txn.ErrAborted {
+		if alive, err := isAlive(s.st, servicesC, s.doc.DocID); err != nil {
+			return nil, errors.Trace(err)
+		} else if !alive {
+			return nil, fmt.Errorf("service is not alive")
+		}
+		return nil, fmt.Errorf("inconsistent state")
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return s.st.Unit(name)
+}
+
+// RemoteUnit returns the unit of the remote service that is a proxy for
+// the named unit of the service in the other environment.
+func (s *Service) RemoteUnit(remoteName string) (*Unit, error) {
+	units, closer := s.st.getCollection(unitsC)
+	defer closer()
+
+	var doc unitDoc
+	err := units.Find(bson.D{
+		{"service", s.doc.Name},
+		{"remoteunit", remoteName},
+	}).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("unit of service %q for %q", s, remoteName)
+	}
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get unit of service %q for %q", s, remoteName)
+	}
+	return newUnit(s.st, &doc), nil
+}
+
+// RemoteName returns the name of the unit in another environment that
+// the unit is a proxy for, or an empty string if the unit does not
+// belong to a remote service.
+func (u *Unit) RemoteName() string {
+	return u.doc.RemoteUnit
+}
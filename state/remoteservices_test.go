@@ -0,0 +1,144 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type RemoteServiceSuite struct {
+	ConnSuite
+	otherState *state.State
+	mysql      *state.Service
+	info       state.RemoteServiceInfo
+}
+
+var _ = gc.Suite(&RemoteServiceSuite{})
+
+func (s *RemoteServiceSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.otherState = s.factory.MakeEnvironment(c, nil)
+	s.AddCleanup(func(*gc.C) { s.otherState.Close() })
+	s.mysql = state.AddTestingService(c, s.otherState, "mysql", state.AddTestingCharm(c, s.otherState, "mysql"), s.Owner)
+	s.info = state.RemoteServiceInfo{
+		EnvUUID:     s.otherState.EnvironUUID(),
+		ServiceName: "mysql",
+		OfferName:   "db",
+		Endpoints:   []string{"server"},
+	}
+}
+
+func (s *RemoteServiceSuite) addRemoteService(c *gc.C) *state.Service {
+	ch, _, err := s.mysql.Charm()
+	c.Assert(err, jc.ErrorIsNil)
+	service, err := s.State.AddRemoteService("shared-db", s.Owner.String(), ch, s.info)
+	c.Assert(err, jc.ErrorIsNil)
+	return service
+}
+
+func (s *RemoteServiceSuite) TestAddRemoteService(c *gc.C) {
+	service := s.addRemoteService(c)
+	c.Assert(service.Name(), gc.Equals, "shared-db")
+	info, ok := service.RemoteInfo()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(info, jc.DeepEquals, s.info)
+
+	// The source service's charm has been copied into this environment.
+	ch, _, err := service.Charm()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ch.URL().String(), gc.Equals, "local:quantal/quantal-mysql-1")
+
+	service, err = s.State.Service("shared-db")
+	c.Assert(err, jc.ErrorIsNil)
+	info, ok = service.RemoteInfo()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(info, jc.DeepEquals, s.info)
+
+	_, ok = s.mysql.RemoteInfo()
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *RemoteServiceSuite) TestAddRemoteServiceErrors(c *gc.C) {
+	ch, _, err := s.mysql.Charm()
+	c.Assert(err, jc.ErrorIsNil)
+
+	info := s.info
+	info.Endpoints = []string{"nonsense"}
+	_, err = s.State.AddRemoteService("shared-db", s.Owner.String(), ch, info)
+	c.Assert(err, gc.ErrorMatches, `cannot add remote service "shared-db": relation "nonsense" of charm "local:quantal/quantal-mysql-1" not found`)
+
+	info = s.info
+	info.EnvUUID = s.State.EnvironUUID()
+	_, err = s.State.AddRemoteService("shared-db", s.Owner.String(), ch, info)
+	c.Assert(err, gc.ErrorMatches, `cannot add remote service "shared-db": service "mysql" is in this environment`)
+}
+
+func (s *RemoteServiceSuite) TestRemoteUnits(c *gc.C) {
+	service := s.addRemoteService(c)
+
+	_, err := service.AddUnit()
+	c.Assert(err, gc.ErrorMatches, `cannot add unit to service "shared-db": service is a remote service`)
+
+	unit, err := service.AddRemoteUnit("mysql/3")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unit.Name(), gc.Equals, "shared-db/0")
+	c.Assert(unit.RemoteName(), gc.Equals, "mysql/3")
+
+	unit, err = service.RemoteUnit("mysql/3")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unit.Name(), gc.Equals, "shared-db/0")
+
+	_, err = service.RemoteUnit("mysql/4")
+	c.Assert(errors.IsNotFound(err), jc.IsTrue)
+
+	_, err = service.AddRemoteUnit("mysql/3")
+	c.Assert(err, gc.ErrorMatches, `cannot add unit for "mysql/3" to service "shared-db": unit for "mysql/3" already exists`)
+
+	_, err = s.mysql.AddRemoteUnit("mysql/3")
+	c.Assert(err, gc.ErrorMatches, `cannot add unit for "mysql/3" to service "mysql": service is not a remote service`)
+}
+
+func (s *RemoteServiceSuite) TestAddRelation(c *gc.C) {
+	s.addRemoteService(c)
+	s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+
+	eps, err := s.State.InferEndpoints("wordpress", "shared-db")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *RemoteServiceSuite) TestAddRelationNotOffered(c *gc.C) {
+	_, err := s.State.AddRemoteService("blog", s.Owner.String(), s.AddTestingCharm(c, "wordpress"), state.RemoteServiceInfo{
+		EnvUUID:     s.otherState.EnvironUUID(),
+		ServiceName: "wordpress",
+		OfferName:   "blog",
+		Endpoints:   []string{"url"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+
+	eps, err := s.State.InferEndpoints("blog", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddRelation(eps...)
+	c.Assert(err, gc.ErrorMatches, `cannot add relation "blog:db mysql:server": remote service "blog" does not offer "db"`)
+}
+
+func (s *RemoteServiceSuite) TestAddRelationBetweenRemoteServices(c *gc.C) {
+	s.addRemoteService(c)
+	_, err := s.State.AddRemoteService("blog", s.Owner.String(), s.AddTestingCharm(c, "wordpress"), state.RemoteServiceInfo{
+		EnvUUID:     s.otherState.EnvironUUID(),
+		ServiceName: "wordpress",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	eps, err := s.State.InferEndpoints("blog", "shared-db")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddRelation(eps...)
+	c.Assert(err, gc.ErrorMatches, `cannot add relation "blog:db shared-db:server": cannot relate two remote services`)
+}
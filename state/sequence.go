@@ -36,3 +36,30 @@ func (s *State) sequence(name string) (int, error) {
 	}
 	return result.Counter, nil
 }
+
+// ensureSequence ensures that the next value returned by the named
+// sequence is at least next. It is not safe to call concurrently with
+// other updates to the sequence, and is only used when importing an
+// environment, to preserve the identifiers of the imported entities.
+func (s *State) ensureSequence(name string, next int) error {
+	sequences := s.db.C(sequenceC)
+	var doc sequenceDoc
+	err := sequences.FindId(s.docID(name)).One(&doc)
+	if err != nil && err != mgo.ErrNotFound {
+		return fmt.Errorf("cannot read %q sequence number: %v", name, err)
+	}
+	if err == nil && doc.Counter >= next {
+		return nil
+	}
+	_, err = sequences.UpsertId(s.docID(name), bson.M{
+		"$set": bson.M{
+			"name":     name,
+			"env-uuid": s.EnvironUUID(),
+			"counter":  next,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("cannot set %q sequence number: %v", name, err)
+	}
+	return nil
+}
@@ -14,12 +14,14 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/names"
 	jujutxn "github.com/juju/txn"
+	"github.com/juju/utils/set"
 	"gopkg.in/juju/charm.v5"
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
 
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/network"
 )
 
 // Service represents the state of a service.
@@ -42,10 +44,23 @@ type serviceDoc struct {
 	UnitCount         int        `bson:"unitcount"`
 	RelationCount     int        `bson:"relationcount"`
 	Exposed           bool       `bson:"exposed"`
+	ExposedCIDRs      []string   `bson:"exposedcidrs,omitempty"`
 	MinUnits          int        `bson:"minunits"`
 	OwnerTag          string     `bson:"ownertag"`
 	TxnRevno          int64      `bson:"txn-revno"`
 	MetricCredentials []byte     `bson:"metric-credentials"`
+
+	// EndpointBindings maps the names of the service's endpoints to
+	// the names of the spaces they are bound to.
+	EndpointBindings map[string]string `bson:"endpointbindings,omitempty"`
+
+	// Remote identifies the service in another environment that this
+	// service is a proxy for, if it is a remote service.
+	Remote *remoteServiceDoc `bson:"remote,omitempty"`
+
+	// HookRetryPolicy overrides the environment's policy for retrying
+	// the failed hooks of the service's units, if set.
+	HookRetryPolicy *hookRetryPolicyDoc `bson:"hookretrypolicy,omitempty"`
 }
 
 func newService(st *State, doc *serviceDoc) *Service {
@@ -240,29 +255,58 @@ func (s *Service) IsExposed() bool {
 	return s.doc.Exposed
 }
 
-// SetExposed marks the service as exposed.
+// SetExposed marks the service as exposed to any address.
 // See ClearExposed and IsExposed.
 func (s *Service) SetExposed() error {
-	return s.setExposed(true)
+	return s.setExposed(true, nil)
+}
+
+// SetExposedTo marks the service as exposed, restricting access to
+// its open ports to the given source CIDRs. Exposing a service to no
+// CIDRs exposes it to any address, as SetExposed does. The CIDRs are
+// stored sorted, without duplicates.
+func (s *Service) SetExposedTo(cidrs []string) error {
+	for _, cidr := range cidrs {
+		if err := network.ValidateCIDR(cidr); err != nil {
+			return errors.Annotatef(err, "cannot expose service %q", s)
+		}
+	}
+	return s.setExposed(true, set.NewStrings(cidrs...).SortedValues())
+}
+
+// ExposedCIDRs returns the source CIDRs the open ports of the exposed
+// service may be accessed from. No CIDRs means any address.
+func (s *Service) ExposedCIDRs() []string {
+	return s.doc.ExposedCIDRs
 }
 
 // ClearExposed removes the exposed flag from the service.
 // See SetExposed and IsExposed.
 func (s *Service) ClearExposed() error {
-	return s.setExposed(false)
+	return s.setExposed(false, nil)
 }
 
-func (s *Service) setExposed(exposed bool) (err error) {
+func (s *Service) setExposed(exposed bool, cidrs []string) (err error) {
+	update := bson.D{
+		{"$set", bson.D{{"exposed", exposed}}},
+		{"$unset", bson.D{{"exposedcidrs", nil}}},
+	}
+	if len(cidrs) > 0 {
+		update = bson.D{{"$set", bson.D{{"exposed", exposed}, {"exposedcidrs", cidrs}}}}
+	} else {
+		cidrs = nil
+	}
 	ops := []txn.Op{{
 		C:      servicesC,
 		Id:     s.doc.DocID,
 		Assert: isAliveDoc,
-		Update: bson.D{{"$set", bson.D{{"exposed", exposed}}}},
+		Update: update,
 	}}
 	if err := s.st.runTransaction(ops); err != nil {
 		return fmt.Errorf("cannot set exposed flag for service %q to %v: %v", s, exposed, onAbort(err, errNotAlive))
 	}
 	s.doc.Exposed = exposed
+	s.doc.ExposedCIDRs = cidrs
 	return nil
 }
 
@@ -660,6 +704,12 @@ func (s *Service) addUnitOps(principalName string, asserts bson.D) (string, []tx
 	} else if !s.doc.Subordinate && principalName != "" {
 		return "", nil, fmt.Errorf("service is not a subordinate")
 	}
+	return s.addUnitOpsWithRemote(principalName, "", asserts)
+}
+
+// addUnitOpsWithRemote is addUnitOps for a unit that, if remoteName is
+// not empty, is a proxy for the named unit in another environment.
+func (s *Service) addUnitOpsWithRemote(principalName, remoteName string, asserts bson.D) (string, []txn.Op, error) {
 	name, err := s.newUnitName()
 	if err != nil {
 		return "", nil, err
@@ -684,6 +734,7 @@ func (s *Service) addUnitOps(principalName string, asserts bson.D) (string, []tx
 		Life:                   Alive,
 		Principal:              principalName,
 		StorageAttachmentCount: numStorageAttachments,
+		RemoteUnit:             remoteName,
 	}
 	now := time.Now()
 	agentStatusDoc := statusDoc{
@@ -778,6 +829,9 @@ func (s *Service) GetOwnerTag() string {
 // AddUnit adds a new principal unit to the service.
 func (s *Service) AddUnit() (unit *Unit, err error) {
 	defer errors.DeferredAnnotatef(&err, "cannot add unit to service %q", s)
+	if s.doc.Remote != nil {
+		return nil, errors.Errorf("service is a remote service")
+	}
 	name, ops, err := s.addUnitOps("", nil)
 	if err != nil {
 		return nil, err
@@ -823,6 +877,7 @@ func (s *Service) removeUnitOps(u *Unit, asserts bson.D) ([]txn.Op, error) {
 			Remove: true,
 		},
 		removeMeterStatusOp(s.st, u.globalMeterStatusKey()),
+		removeHealthChecksOp(s.st, u.globalKey()),
 		removeStatusOp(s.st, u.globalAgentKey()),
 		removeStatusOp(s.st, u.globalKey()),
 		removeConstraintsOp(s.st, u.globalAgentKey()),
@@ -990,6 +1045,136 @@ func (s *Service) Networks() ([]string, error) {
 	return readRequestedNetworks(s.st, s.globalKey())
 }
 
+// EndpointBindings returns the names of the spaces that the service's
+// endpoints are bound to, keyed by endpoint name. Endpoints that are not
+// bound to a space are not included.
+func (s *Service) EndpointBindings() map[string]string {
+	bindings := make(map[string]string, len(s.doc.EndpointBindings))
+	for endpoint, space := range s.doc.EndpointBindings {
+		bindings[endpoint] = space
+	}
+	return bindings
+}
+
+// ValidateEndpointBindings returns an error if a new service of the
+// given charm could not be bound to spaces as given, for the reasons
+// that SetEndpointBindings would reject the bindings. It allows the
+// bindings to be checked before the service is added.
+func (st *State) ValidateEndpointBindings(ch *Charm, bindings map[string]string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "invalid endpoint bindings")
+	if len(bindings) == 0 {
+		return nil
+	}
+	if err := st.supportsSubnetPlacement(); err != nil {
+		return errors.Trace(err)
+	}
+	meta := ch.Meta()
+	spaceNames := set.NewStrings()
+	for endpoint, spaceName := range bindings {
+		if !charmHasRelation(meta, endpoint) {
+			return errors.Errorf("charm %q has no %q relation", ch.URL(), endpoint)
+		}
+		spaceNames.Add(spaceName)
+	}
+	if spaceNames.Size() > 1 {
+		return errors.NotSupportedf(
+			"binding endpoints to more than one space (%s)",
+			strings.Join(spaceNames.SortedValues(), ", "),
+		)
+	}
+	_, err = st.Space(spaceNames.Values()[0])
+	return errors.Trace(err)
+}
+
+// charmHasRelation returns whether a service of the charm with the
+// given metadata has the named relation.
+func charmHasRelation(meta *charm.Meta, relationName string) bool {
+	if relationName == "juju-info" {
+		return true
+	}
+	for _, rels := range []map[string]charm.Relation{meta.Peers, meta.Provides, meta.Requires} {
+		if _, ok := rels[relationName]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// SetEndpointBindings binds the service's endpoints to spaces, keyed by
+// endpoint name. Endpoints that are not in bindings are left as they
+// are. Each endpoint must be one of the service's relations, and each
+// space must exist. As machines are started in a single subnet, all of
+// the service's endpoints must be bound to the same space, and the
+// environment must support starting machines in particular subnets.
+func (s *Service) SetEndpointBindings(bindings map[string]string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set endpoint bindings for service %q", s)
+	if len(bindings) == 0 {
+		return nil
+	}
+	if s.doc.Life != Alive {
+		return errNotAlive
+	}
+	if err := s.st.supportsSubnetPlacement(); err != nil {
+		return errors.Trace(err)
+	}
+	for endpoint := range bindings {
+		if _, err := s.Endpoint(endpoint); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	spaceNames := set.NewStrings()
+	for endpoint, spaceName := range s.doc.EndpointBindings {
+		if _, ok := bindings[endpoint]; !ok {
+			spaceNames.Add(spaceName)
+		}
+	}
+	for _, spaceName := range bindings {
+		spaceNames.Add(spaceName)
+	}
+	if spaceNames.Size() > 1 {
+		return errors.NotSupportedf(
+			"binding endpoints to more than one space (%s)",
+			strings.Join(spaceNames.SortedValues(), ", "),
+		)
+	}
+	spaceName := spaceNames.Values()[0]
+	space, err := s.st.Space(spaceName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	// The existing bindings must not change underneath us, or the
+	// service could end up bound to more than one space.
+	assert := bson.D{{"life", Alive}, {"charmurl", s.doc.CharmURL}}
+	for endpoint, boundSpace := range s.doc.EndpointBindings {
+		assert = append(assert, bson.DocElem{Name: "endpointbindings." + endpoint, Value: boundSpace})
+	}
+	update := bson.D{}
+	for endpoint := range bindings {
+		update = append(update, bson.DocElem{Name: "endpointbindings." + endpoint, Value: spaceName})
+	}
+	ops := []txn.Op{{
+		C:      servicesC,
+		Id:     s.doc.DocID,
+		Assert: assert,
+		Update: bson.D{{"$set", update}},
+	}, {
+		C:      spacesC,
+		Id:     space.doc.DocID,
+		Assert: isAliveDoc,
+	}}
+	if err := s.st.runTransaction(ops); err != nil {
+		return onAbort(err, errors.New("service, its charm, its bindings, or the space changed"))
+	}
+	if s.doc.EndpointBindings == nil {
+		s.doc.EndpointBindings = make(map[string]string)
+	}
+	for endpoint := range bindings {
+		s.doc.EndpointBindings[endpoint] = spaceName
+	}
+	return nil
+}
+
 // MetricCredentials returns any metric credentials associated with this service.
 func (s *Service) MetricCredentials() []byte {
 	return s.doc.MetricCredentials
@@ -1189,6 +1374,14 @@ func (s *Service) SetStatus(status Status, info string, data map[string]interfac
 	return nil
 }
 
+// StatusHistory returns the past statuses of the service that match
+// the given filter, most recent first. Only statuses set explicitly
+// are recorded; statuses derived from those of the service's units
+// are not.
+func (s *Service) StatusHistory(filter StatusHistoryFilter) ([]StatusInfo, error) {
+	return filteredStatusHistory(filter, s.globalKey(), s.st)
+}
+
 // ServiceAndUnitsStatus returns the status for this service and all its units.
 func (s *Service) ServiceAndUnitsStatus() (StatusInfo, map[string]StatusInfo, error) {
 	serviceStatus, err := s.Status()
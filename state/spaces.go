@@ -0,0 +1,234 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"net"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/network"
+)
+
+// Space represents a named group of subnets. The endpoints of a service
+// can be bound to a space, so that the service's units communicate over
+// that endpoint using addresses in the space's subnets.
+type Space struct {
+	st  *State
+	doc spaceDoc
+}
+
+type spaceDoc struct {
+	DocID   string `bson:"_id"`
+	EnvUUID string `bson:"env-uuid"`
+	Life    Life   `bson:"life"`
+	Name    string `bson:"name"`
+}
+
+// Name returns the name of the space.
+func (s *Space) Name() string {
+	return s.doc.Name
+}
+
+// Life returns whether the space is Alive, Dying or Dead.
+func (s *Space) Life() Life {
+	return s.doc.Life
+}
+
+// String implements fmt.Stringer.
+func (s *Space) String() string {
+	return s.Name()
+}
+
+// Subnets returns the subnets in the space.
+func (s *Space) Subnets() ([]*Subnet, error) {
+	subnets, closer := s.st.getCollection(subnetsC)
+	defer closer()
+
+	var docs []subnetDoc
+	err := subnets.Find(bson.D{{"spacename", s.doc.Name}}).Sort("cidr").All(&docs)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get subnets of space %q", s)
+	}
+	result := make([]*Subnet, len(docs))
+	for i, doc := range docs {
+		result[i] = &Subnet{s.st, doc}
+	}
+	return result, nil
+}
+
+// AddSubnets adds the subnets with the given CIDRs to the space. A
+// subnet can only be in one space.
+func (s *Space) AddSubnets(cidrs []string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot add subnets to space %q", s)
+
+	ops := []txn.Op{{
+		C:      spacesC,
+		Id:     s.doc.DocID,
+		Assert: isAliveDoc,
+	}}
+	subnetOps, err := s.st.addSubnetsToSpaceOps(s.doc.Name, cidrs)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ops = append(ops, subnetOps...)
+	if err := s.st.runTransaction(ops); err == txn.ErrAborted {
+		if err := s.Refresh(); err != nil {
+			return errors.Trace(err)
+		} else if s.doc.Life != Alive {
+			return errNotAlive
+		}
+		// The subnets were checked above, so one of them must
+		// have been removed or added to a space in the meantime.
+		_, err := s.st.addSubnetsToSpaceOps(s.doc.Name, cidrs)
+		return errors.Trace(err)
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// Refresh refreshes the contents of the Space from the underlying
+// state. It returns an error that satisfies errors.IsNotFound if the
+// Space has been removed.
+func (s *Space) Refresh() error {
+	spaces, closer := s.st.getCollection(spacesC)
+	defer closer()
+
+	err := spaces.FindId(s.doc.DocID).One(&s.doc)
+	if err == mgo.ErrNotFound {
+		return errors.NotFoundf("space %q", s)
+	}
+	if err != nil {
+		return errors.Errorf("cannot refresh space %q: %v", s, err)
+	}
+	return nil
+}
+
+// addSubnetsToSpaceOps returns the operations that put the subnets with
+// the given CIDRs into the named space. It fails if any of the subnets
+// does not exist, is not alive, or is already in another space.
+func (st *State) addSubnetsToSpaceOps(spaceName string, cidrs []string) ([]txn.Op, error) {
+	var ops []txn.Op
+	for _, cidr := range cidrs {
+		subnet, err := st.Subnet(cidr)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if subnet.Life() != Alive {
+			return nil, errors.Errorf("subnet %q is not alive", cidr)
+		}
+		if subnet.SpaceName() != "" {
+			return nil, errors.Errorf("subnet %q is already in space %q", cidr, subnet.SpaceName())
+		}
+		ops = append(ops, txn.Op{
+			C:  subnetsC,
+			Id: subnet.doc.DocID,
+			Assert: bson.D{
+				{"life", Alive},
+				{"spacename", bson.D{{"$exists", false}}},
+			},
+			Update: bson.D{{"$set", bson.D{{"spacename", spaceName}}}},
+		})
+	}
+	return ops, nil
+}
+
+// AddSpace creates and returns a new space made up of the subnets with
+// the given CIDRs. It returns an error satisfying errors.IsAlreadyExists
+// if a space with the same name already exists.
+func (st *State) AddSpace(name string, cidrs []string) (space *Space, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot add space %q", name)
+
+	if !network.IsValidSpaceName(name) {
+		return nil, errors.NotValidf("space name %q", name)
+	}
+	spaceID := st.docID(name)
+	doc := spaceDoc{
+		DocID:   spaceID,
+		EnvUUID: st.EnvironUUID(),
+		Life:    Alive,
+		Name:    name,
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if _, err := st.Space(name); err == nil {
+			return nil, errors.AlreadyExistsf("space %q", name)
+		} else if !errors.IsNotFound(err) {
+			return nil, errors.Trace(err)
+		}
+		ops := []txn.Op{{
+			C:      spacesC,
+			Id:     spaceID,
+			Assert: txn.DocMissing,
+			Insert: doc,
+		}}
+		subnetOps, err := st.addSubnetsToSpaceOps(name, cidrs)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return append(ops, subnetOps...), nil
+	}
+	if err := st.run(buildTxn); err != nil {
+		return nil, err
+	}
+	return &Space{st, doc}, nil
+}
+
+// Space returns the space with the given name.
+func (st *State) Space(name string) (*Space, error) {
+	spaces, closer := st.getCollection(spacesC)
+	defer closer()
+
+	var doc spaceDoc
+	err := spaces.FindId(name).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("space %q", name)
+	}
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get space %q", name)
+	}
+	return &Space{st, doc}, nil
+}
+
+// AllSpaces returns all the spaces in the environment.
+func (st *State) AllSpaces() ([]*Space, error) {
+	spaces, closer := st.getCollection(spacesC)
+	defer closer()
+
+	var docs []spaceDoc
+	if err := spaces.Find(nil).Sort("name").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get all spaces")
+	}
+	result := make([]*Space, len(docs))
+	for i, doc := range docs {
+		result[i] = &Space{st, doc}
+	}
+	return result, nil
+}
+
+// selectSpaceAddress returns the first of the given addresses that is
+// in one of the given subnets, and whether there was one.
+func selectSpaceAddress(addresses []network.Address, subnets []*Subnet) (network.Address, bool) {
+	var nets []*net.IPNet
+	for _, subnet := range subnets {
+		if _, ipNet, err := net.ParseCIDR(subnet.CIDR()); err == nil {
+			nets = append(nets, ipNet)
+		}
+	}
+	for _, addr := range addresses {
+		ip := net.ParseIP(addr.Value)
+		if ip == nil {
+			continue
+		}
+		for _, ipNet := range nets {
+			if ipNet.Contains(ip) {
+				return addr, true
+			}
+		}
+	}
+	return network.Address{}, false
+}
@@ -0,0 +1,220 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
+)
+
+type SpaceSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&SpaceSuite{})
+
+func (s *SpaceSuite) addSubnet(c *gc.C, cidr string) *state.Subnet {
+	subnet, err := s.State.AddSubnet(state.SubnetInfo{CIDR: cidr})
+	c.Assert(err, jc.ErrorIsNil)
+	return subnet
+}
+
+func subnetCIDRs(c *gc.C, space *state.Space) []string {
+	subnets, err := space.Subnets()
+	c.Assert(err, jc.ErrorIsNil)
+	var cidrs []string
+	for _, subnet := range subnets {
+		cidrs = append(cidrs, subnet.CIDR())
+	}
+	return cidrs
+}
+
+func (s *SpaceSuite) TestAddSpace(c *gc.C) {
+	s.addSubnet(c, "10.0.1.0/24")
+	s.addSubnet(c, "10.0.0.0/24")
+
+	space, err := s.State.AddSpace("dmz", []string{"10.0.1.0/24", "10.0.0.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(space.Name(), gc.Equals, "dmz")
+	c.Assert(space.Life(), gc.Equals, state.Alive)
+	c.Assert(subnetCIDRs(c, space), jc.DeepEquals, []string{"10.0.0.0/24", "10.0.1.0/24"})
+
+	subnet, err := s.State.Subnet("10.0.0.0/24")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(subnet.SpaceName(), gc.Equals, "dmz")
+
+	space, err = s.State.Space("dmz")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(space.Name(), gc.Equals, "dmz")
+}
+
+func (s *SpaceSuite) TestAddSpaceWithoutSubnets(c *gc.C) {
+	space, err := s.State.AddSpace("dmz", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(subnetCIDRs(c, space), gc.HasLen, 0)
+}
+
+func (s *SpaceSuite) TestAddSpaceErrors(c *gc.C) {
+	_, err := s.State.AddSpace("Bad Name", nil)
+	c.Assert(err, gc.ErrorMatches, `cannot add space "Bad Name": space name "Bad Name" not valid`)
+	c.Assert(errors.IsNotValid(errors.Cause(err)), jc.IsTrue)
+
+	_, err = s.State.AddSpace("dmz", []string{"10.0.0.0/24"})
+	c.Assert(err, gc.ErrorMatches, `cannot add space "dmz": subnet "10.0.0.0/24" not found`)
+
+	s.addSubnet(c, "10.0.0.0/24")
+	_, err = s.State.AddSpace("dmz", []string{"10.0.0.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.AddSpace("dmz", nil)
+	c.Assert(err, gc.ErrorMatches, `cannot add space "dmz": space "dmz" already exists`)
+	c.Assert(errors.IsAlreadyExists(errors.Cause(err)), jc.IsTrue)
+
+	_, err = s.State.AddSpace("public", []string{"10.0.0.0/24"})
+	c.Assert(err, gc.ErrorMatches, `cannot add space "public": subnet "10.0.0.0/24" is already in space "dmz"`)
+}
+
+func (s *SpaceSuite) TestSpaceNotFound(c *gc.C) {
+	_, err := s.State.Space("dmz")
+	c.Assert(err, gc.ErrorMatches, `space "dmz" not found`)
+	c.Assert(errors.IsNotFound(err), jc.IsTrue)
+}
+
+func (s *SpaceSuite) TestAllSpaces(c *gc.C) {
+	_, err := s.State.AddSpace("public", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSpace("dmz", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	spaces, err := s.State.AllSpaces()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(spaces, gc.HasLen, 2)
+	c.Assert(spaces[0].Name(), gc.Equals, "dmz")
+	c.Assert(spaces[1].Name(), gc.Equals, "public")
+}
+
+func (s *SpaceSuite) TestAddSubnets(c *gc.C) {
+	s.addSubnet(c, "10.0.0.0/24")
+	s.addSubnet(c, "10.0.1.0/24")
+	space, err := s.State.AddSpace("dmz", []string{"10.0.0.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = space.AddSubnets([]string{"10.0.1.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(subnetCIDRs(c, space), jc.DeepEquals, []string{"10.0.0.0/24", "10.0.1.0/24"})
+
+	err = space.AddSubnets([]string{"10.0.1.0/24"})
+	c.Assert(err, gc.ErrorMatches, `cannot add subnets to space "dmz": subnet "10.0.1.0/24" is already in space "dmz"`)
+}
+
+func (s *SpaceSuite) TestEndpointBindings(c *gc.C) {
+	_, err := s.State.AddSpace("db", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	service := s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	c.Assert(service.EndpointBindings(), gc.HasLen, 0)
+
+	err = service.SetEndpointBindings(map[string]string{"db": "db"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(service.EndpointBindings(), jc.DeepEquals, map[string]string{"db": "db"})
+
+	err = service.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(service.EndpointBindings(), jc.DeepEquals, map[string]string{"db": "db"})
+}
+
+func (s *SpaceSuite) TestEndpointBindingsErrors(c *gc.C) {
+	service := s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+
+	err := service.SetEndpointBindings(map[string]string{"nonsense": "db"})
+	c.Assert(err, gc.ErrorMatches, `cannot set endpoint bindings for service "wordpress": service "wordpress" has no "nonsense" relation`)
+
+	err = service.SetEndpointBindings(map[string]string{"db": "db"})
+	c.Assert(err, gc.ErrorMatches, `cannot set endpoint bindings for service "wordpress": space "db" not found`)
+}
+
+func (s *SpaceSuite) TestEndpointBindingsOneSpace(c *gc.C) {
+	for _, name := range []string{"db", "public"} {
+		_, err := s.State.AddSpace(name, nil)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	service := s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+
+	err := service.SetEndpointBindings(map[string]string{"db": "db", "url": "public"})
+	c.Assert(err, gc.ErrorMatches, `cannot set endpoint bindings for service "wordpress": binding endpoints to more than one space \(db, public\) not supported`)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+
+	err = service.SetEndpointBindings(map[string]string{"db": "db"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = service.SetEndpointBindings(map[string]string{"url": "public"})
+	c.Assert(err, gc.ErrorMatches, `cannot set endpoint bindings for service "wordpress": binding endpoints to more than one space \(db, public\) not supported`)
+
+	// An endpoint can be rebound, as long as a single space remains.
+	err = service.SetEndpointBindings(map[string]string{"db": "public", "url": "public"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = service.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(service.EndpointBindings(), jc.DeepEquals, map[string]string{"db": "public", "url": "public"})
+}
+
+func (s *SpaceSuite) TestValidateEndpointBindings(c *gc.C) {
+	for _, name := range []string{"db", "public"} {
+		_, err := s.State.AddSpace(name, nil)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	ch := s.AddTestingCharm(c, "wordpress")
+
+	err := s.State.ValidateEndpointBindings(ch, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.ValidateEndpointBindings(ch, map[string]string{"db": "db", "juju-info": "db"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.ValidateEndpointBindings(ch, map[string]string{"nonsense": "db"})
+	c.Assert(err, gc.ErrorMatches, `invalid endpoint bindings: charm ".*wordpress.*" has no "nonsense" relation`)
+	err = s.State.ValidateEndpointBindings(ch, map[string]string{"db": "db", "url": "public"})
+	c.Assert(err, gc.ErrorMatches, `invalid endpoint bindings: binding endpoints to more than one space \(db, public\) not supported`)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	err = s.State.ValidateEndpointBindings(ch, map[string]string{"db": "missing"})
+	c.Assert(err, gc.ErrorMatches, `invalid endpoint bindings: space "missing" not found`)
+}
+
+func (s *SpaceSuite) TestBindingAddress(c *gc.C) {
+	s.addSubnet(c, "10.0.0.0/24")
+	_, err := s.State.AddSpace("db", []string{"10.0.0.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+	service := s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	err = service.SetEndpointBindings(map[string]string{"db": "db"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	unit, err := service.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(machine)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = unit.BindingAddress("db")
+	c.Assert(err, gc.ErrorMatches, `address of unit "wordpress/0" in space "db" not found`)
+
+	err = machine.SetProviderAddresses(
+		network.NewScopedAddress("192.168.0.5", network.ScopeCloudLocal),
+		network.NewScopedAddress("10.0.0.5", network.ScopeCloudLocal),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	address, err := unit.BindingAddress("db")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(address, gc.Equals, "10.0.0.5")
+
+	// Unbound endpoints use the unit's private address.
+	address, err = unit.BindingAddress("url")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(address, gc.Equals, "192.168.0.5")
+
+	_, err = unit.BindingAddress("nonsense")
+	c.Assert(err, gc.ErrorMatches, `endpoint "nonsense" of service "wordpress" not found`)
+}
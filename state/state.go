@@ -56,6 +56,8 @@ const (
 	unitsC             = "units"
 	subnetsC           = "subnets"
 	ipaddressesC       = "ipaddresses"
+	spacesC            = "spaces"
+	offersC            = "offers"
 
 	// actionsC and related collections store state of Actions that
 	// have been enqueued.
@@ -66,8 +68,16 @@ const (
 	// actionResultsC is deprecated and will soon be folded into
 	// actionsC.
 	actionresultsC = "actionresults"
+	// actionSchedulesC stores the schedules on which Actions are
+	// enqueued repeatedly.
+	actionSchedulesC = "actionschedules"
+	// outputChunksC stores the output of actions, and of commands run
+	// on units, as it is produced. Old output is removed by the
+	// output pruner.
+	outputChunksC = "outputchunks"
 
 	usersC                 = "users"
+	userTokensC            = "usertokens"
 	envUsersC              = "envusers"
 	presenceC              = "presence"
 	cleanupsC              = "cleanups"
@@ -86,18 +96,32 @@ const (
 	storageInstancesC      = "storageinstances"
 	volumesC               = "volumes"
 	volumeAttachmentsC     = "volumeattachments"
+	volumeSnapshotsC       = "volumesnapshots"
+	volumeResizesC         = "volumeresizes"
 	filesystemsC           = "filesystems"
 	filesystemAttachmentsC = "filesystemAttachments"
 
 	// leaseC is used to store lease tokens
 	leaseC = "lease"
 
+	// auditLogC is the capped collection used to record auditable
+	// API calls.
+	auditLogC = "audit.log"
+
 	// sequenceC is used to generate unique identifiers.
 	sequenceC = "sequence"
 
 	// meterStatusC is the collection used to store meter status information.
 	meterStatusC = "meterStatus"
 
+	// healthChecksC is the collection used to store the results of the
+	// health checks declared by units' charms.
+	healthChecksC = "healthchecks"
+
+	// credentialsLoadedC records the provider credentials with which
+	// state server workers last opened the environment.
+	credentialsLoadedC = "credentialsloaded"
+
 	// toolsmetadataC is the collection used to store tools metadata.
 	toolsmetadataC = "toolsmetadata"
 
@@ -1244,6 +1268,16 @@ func (st *State) AddService(
 	name, owner string, ch *Charm, networks []string, storage map[string]StorageConstraints,
 ) (service *Service, err error) {
 	defer errors.DeferredAnnotatef(&err, "cannot add service %q", name)
+	return st.addService(name, owner, ch, networks, storage, nil)
+}
+
+// addService creates a new service as described for AddService. If
+// remote is not nil, the service is a proxy for a service in another
+// environment, and no peer relations are created for it.
+func (st *State) addService(
+	name, owner string, ch *Charm, networks []string, storage map[string]StorageConstraints,
+	remote *remoteServiceDoc,
+) (*Service, error) {
 	ownerTag, err := names.ParseUserTag(owner)
 	if err != nil {
 		return nil, errors.Annotatef(err, "Invalid ownertag %s", owner)
@@ -1281,6 +1315,9 @@ func (st *State) AddService(
 	serviceID := st.docID(name)
 	// Create the service addition operations.
 	peers := ch.Meta().Peers
+	if remote != nil {
+		peers = nil
+	}
 	svcDoc := &serviceDoc{
 		DocID:         serviceID,
 		Name:          name,
@@ -1291,6 +1328,7 @@ func (st *State) AddService(
 		RelationCount: len(peers),
 		Life:          Alive,
 		OwnerTag:      owner,
+		Remote:        remote,
 	}
 	svc := newService(st, svcDoc)
 	ops := []txn.Op{
@@ -1819,7 +1857,7 @@ func (st *State) AddRelation(eps ...Endpoint) (r *Relation, err error) {
 		}
 		// Collect per-service operations, checking sanity as we go.
 		var ops []txn.Op
-		var subordinateCount int
+		var subordinateCount, remoteCount int
 		series := map[string]bool{}
 		for _, ep := range eps {
 			svc, err := st.Service(ep.ServiceName)
@@ -1833,6 +1871,12 @@ func (st *State) AddRelation(eps ...Endpoint) (r *Relation, err error) {
 			if svc.doc.Subordinate {
 				subordinateCount++
 			}
+			if svc.doc.Remote != nil {
+				remoteCount++
+				if !svc.doc.Remote.offers(ep.Name) {
+					return nil, errors.Errorf("remote service %q does not offer %q", ep.ServiceName, ep.Name)
+				}
+			}
 			series[svc.doc.Series] = true
 			ch, _, err := svc.Charm()
 			if err != nil {
@@ -1854,6 +1898,12 @@ func (st *State) AddRelation(eps ...Endpoint) (r *Relation, err error) {
 		if eps[0].Scope == charm.ScopeContainer && subordinateCount < 1 {
 			return nil, errors.Errorf("container scoped relation requires at least one subordinate service")
 		}
+		if remoteCount > 1 {
+			return nil, errors.Errorf("cannot relate two remote services")
+		}
+		if remoteCount > 0 && subordinateCount > 0 {
+			return nil, errors.Errorf("subordinate services cannot be related to remote services")
+		}
 
 		// Create a new unique id if that has not already been done, and add
 		// an operation to create the relation document.
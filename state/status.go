@@ -13,10 +13,14 @@ import (
 )
 
 var (
-	_ StatusSetter = (*Machine)(nil)
-	_ StatusSetter = (*Unit)(nil)
-	_ StatusGetter = (*Machine)(nil)
-	_ StatusGetter = (*Unit)(nil)
+	_ StatusSetter        = (*Machine)(nil)
+	_ StatusSetter        = (*Unit)(nil)
+	_ StatusGetter        = (*Machine)(nil)
+	_ StatusGetter        = (*Unit)(nil)
+	_ StatusHistoryGetter = (*Machine)(nil)
+	_ StatusHistoryGetter = (*Service)(nil)
+	_ StatusHistoryGetter = (*Unit)(nil)
+	_ StatusHistoryGetter = (*UnitAgent)(nil)
 )
 
 // Status represents the status of an entity.
@@ -240,6 +244,78 @@ type StatusGetter interface {
 	Status() (StatusInfo, error)
 }
 
+// StatusHistoryGetter represents a type whose past statuses can be read.
+type StatusHistoryGetter interface {
+	StatusHistory(filter StatusHistoryFilter) ([]StatusInfo, error)
+}
+
+// StatusHistoryFilter holds the criteria used to select entries from
+// an entity's status history. Zero-valued fields select all entries.
+type StatusHistoryFilter struct {
+	// Size limits the results to the given number of most recent
+	// entries.
+	Size int
+
+	// Since excludes entries that were no longer in effect at the
+	// given time; the entry in effect at that time is included even
+	// if it was recorded earlier.
+	Since *time.Time
+
+	// Until excludes entries recorded after the given time.
+	Until *time.Time
+
+	// Status excludes entries with any other status value.
+	Status Status
+}
+
+// Matches returns whether the filter selects the given status.
+func (f StatusHistoryFilter) Matches(info StatusInfo) bool {
+	if f.Status != "" && info.Status != f.Status {
+		return false
+	}
+	if f.Since != nil && (info.Since == nil || info.Since.Before(*f.Since)) {
+		return false
+	}
+	if f.Until != nil && (info.Since == nil || info.Since.After(*f.Until)) {
+		return false
+	}
+	return true
+}
+
+// MatchesCurrent returns whether the filter selects the given current
+// status. The current status remains in effect until now, so it is
+// selected if it was set before the end of the time range, even if
+// that was before the start of the range.
+func (f StatusHistoryFilter) MatchesCurrent(info StatusInfo) bool {
+	if f.Status != "" && info.Status != f.Status {
+		return false
+	}
+	if f.Until != nil && (info.Since == nil || info.Since.After(*f.Until)) {
+		return false
+	}
+	return true
+}
+
+// query returns the query selecting the history entries of the entity
+// with the given global key that match the filter.
+func (f StatusHistoryFilter) query(globalKey string) bson.D {
+	query := bson.D{{"entityid", globalKey}}
+	if f.Status != "" {
+		query = append(query, bson.DocElem{"status", f.Status})
+	}
+	updated := bson.D{}
+	if f.Since != nil {
+		updated = append(updated, bson.DocElem{"$gte", *f.Since})
+	}
+	if f.Until != nil {
+		updated = append(updated, bson.DocElem{"$lte", *f.Until})
+	}
+	if len(updated) > 0 {
+		query = append(query, bson.DocElem{"updated", updated})
+	}
+	return query
+}
+
 // StatusInfo holds the status information for a machine, unit, service etc.
 type StatusInfo struct {
 	Status  Status
@@ -284,7 +360,7 @@ func newHistoricalStatusDoc(s statusDoc, entityId string) *historicalStatusDoc {
 func updateStatusHistory(oldDoc statusDoc, globalKey string, st *State) error {
 	id, err := st.sequence("statushistory")
 	if err != nil {
-		errors.Annotatef(err, "cannot make id updating status history of unit agent %q", globalKey)
+		return errors.Annotatef(err, "cannot make id updating status history of %q", globalKey)
 	}
 	hDoc := newHistoricalStatusDoc(oldDoc, globalKey)
 
@@ -295,22 +371,52 @@ func updateStatusHistory(oldDoc statusDoc, globalKey string, st *State) error {
 	}
 
 	err = st.runTransaction([]txn.Op{h})
-	return errors.Annotatef(err, "cannot update status history of unit agent %q", globalKey)
+	return errors.Annotatef(err, "cannot update status history of %q", globalKey)
 }
 
 func statusHistory(size int, globalKey string, st *State) ([]StatusInfo, error) {
+	return filteredStatusHistory(StatusHistoryFilter{Size: size}, globalKey, st)
+}
+
+// filteredStatusHistory returns the past statuses of the entity with
+// the given global key that match the filter, most recent first.
+func filteredStatusHistory(filter StatusHistoryFilter, globalKey string, st *State) ([]StatusInfo, error) {
 	statusHistory, closer := st.getCollection(statusesHistoryC)
 	defer closer()
 
 	sInfo := []StatusInfo{}
 	results := []historicalStatusDoc{}
-	err := statusHistory.Find(bson.D{{"entityid", globalKey}}).Sort("-_id").Limit(size).All(&results)
+	query := statusHistory.Find(filter.query(globalKey)).Sort("-_id")
+	if filter.Size > 0 {
+		query = query.Limit(filter.Size)
+	}
+	err := query.All(&results)
 	if err == mgo.ErrNotFound {
 		return []StatusInfo{}, errors.NotFoundf("statusHistory")
 	}
 	if err != nil {
 		return []StatusInfo{}, errors.Annotatef(err, "cannot get status history for %q", globalKey)
 	}
+	if filter.Since != nil && (filter.Size <= 0 || len(results) < filter.Size) {
+		// The entry in effect at the start of the range was recorded
+		// before it, unless another entry was recorded at exactly that
+		// time, and is included if it matches the other criteria.
+		var doc historicalStatusDoc
+		err := statusHistory.Find(bson.D{
+			{"entityid", globalKey},
+			{"updated", bson.D{{"$lte", *filter.Since}}},
+		}).Sort("-_id").One(&doc)
+		switch {
+		case err == mgo.ErrNotFound:
+		case err != nil:
+			return []StatusInfo{}, errors.Annotatef(err, "cannot get status history for %q", globalKey)
+		case doc.Updated == nil || !doc.Updated.Before(*filter.Since):
+		case filter.Status != "" && doc.Status != filter.Status:
+		case filter.Until != nil && doc.Updated.After(*filter.Until):
+		default:
+			results = append(results, doc)
+		}
+	}
 	for _, s := range results {
 		sInfo = append(sInfo, StatusInfo{
 			Status:  s.Status,
@@ -5,6 +5,7 @@ package state_test
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
@@ -61,6 +62,128 @@ func (s *statusSuite) TestPruneStatusHistory(c *gc.C) {
 	c.Assert(history[99].Message, gc.Equals, "Status change 101")
 }
 
+func (s *statusSuite) TestFilteredStatusHistory(c *gc.C) {
+	globalKey := "BogusKey"
+	begin := state.NowToTheSecond()
+	statuses := []state.Status{
+		state.StatusActive,
+		state.StatusBlocked,
+		state.StatusActive,
+		state.StatusBlocked,
+		state.StatusActive,
+	}
+	for i, status := range statuses {
+		updated := begin.Add(time.Duration(i) * time.Minute)
+		doc := state.NewStatusDoc(state.StatusDoc{
+			Status:     status,
+			StatusInfo: fmt.Sprintf("change %d", i),
+			StatusData: map[string]interface{}{"n": i},
+			Updated:    &updated,
+		})
+		err := state.UpdateStatusHistory(doc, globalKey, s.State)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	at := func(minutes int) *time.Time {
+		t := begin.Add(time.Duration(minutes) * time.Minute)
+		return &t
+	}
+	after := func(minutes int) *time.Time {
+		t := begin.Add(time.Duration(minutes)*time.Minute + 30*time.Second)
+		return &t
+	}
+
+	for i, test := range []struct {
+		about    string
+		filter   state.StatusHistoryFilter
+		expected []string
+	}{{
+		about:    "no filter",
+		expected: []string{"change 4", "change 3", "change 2", "change 1", "change 0"},
+	}, {
+		about:    "size",
+		filter:   state.StatusHistoryFilter{Size: 2},
+		expected: []string{"change 4", "change 3"},
+	}, {
+		about:    "since",
+		filter:   state.StatusHistoryFilter{Since: at(3)},
+		expected: []string{"change 4", "change 3"},
+	}, {
+		about:    "since includes the entry in effect",
+		filter:   state.StatusHistoryFilter{Since: after(2)},
+		expected: []string{"change 4", "change 3", "change 2"},
+	}, {
+		about:    "since and status excludes the entry in effect",
+		filter:   state.StatusHistoryFilter{Since: after(2), Status: state.StatusBlocked},
+		expected: []string{"change 3"},
+	}, {
+		about:    "since and status includes the entry in effect",
+		filter:   state.StatusHistoryFilter{Since: after(2), Status: state.StatusActive},
+		expected: []string{"change 4", "change 2"},
+	}, {
+		about:    "since and size",
+		filter:   state.StatusHistoryFilter{Since: after(2), Size: 2},
+		expected: []string{"change 4", "change 3"},
+	}, {
+		about:    "since before all entries",
+		filter:   state.StatusHistoryFilter{Since: at(-1)},
+		expected: []string{"change 4", "change 3", "change 2", "change 1", "change 0"},
+	}, {
+		about:    "until",
+		filter:   state.StatusHistoryFilter{Until: at(1)},
+		expected: []string{"change 1", "change 0"},
+	}, {
+		about:    "time range",
+		filter:   state.StatusHistoryFilter{Since: at(1), Until: at(3)},
+		expected: []string{"change 3", "change 2", "change 1"},
+	}, {
+		about:    "time range within an entry",
+		filter:   state.StatusHistoryFilter{Since: after(1), Until: after(1)},
+		expected: []string{"change 1"},
+	}, {
+		about:    "status",
+		filter:   state.StatusHistoryFilter{Status: state.StatusBlocked},
+		expected: []string{"change 3", "change 1"},
+	}, {
+		about:    "status and size",
+		filter:   state.StatusHistoryFilter{Status: state.StatusActive, Size: 1},
+		expected: []string{"change 4"},
+	}, {
+		about:  "nothing matches",
+		filter: state.StatusHistoryFilter{Status: state.StatusError},
+	}} {
+		c.Logf("test %d: %s", i, test.about)
+		history, err := state.FilteredStatusHistory(test.filter, globalKey, s.State)
+		c.Assert(err, jc.ErrorIsNil)
+		messages := []string{}
+		for _, info := range history {
+			messages = append(messages, info.Message)
+		}
+		if test.expected == nil {
+			test.expected = []string{}
+		}
+		c.Check(messages, jc.DeepEquals, test.expected)
+	}
+
+	// Status data is included in the history.
+	history, err := state.FilteredStatusHistory(state.StatusHistoryFilter{Size: 1}, globalKey, s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history[0].Data, jc.DeepEquals, map[string]interface{}{"n": 4})
+}
+
+func (s *statusSuite) TestStatusHistoryFilterMatches(c *gc.C) {
+	now := time.Now()
+	earlier := now.Add(-time.Hour)
+	later := now.Add(time.Hour)
+	info := state.StatusInfo{Status: state.StatusActive, Since: &now}
+
+	c.Check(state.StatusHistoryFilter{}.Matches(info), jc.IsTrue)
+	c.Check(state.StatusHistoryFilter{Status: state.StatusActive}.Matches(info), jc.IsTrue)
+	c.Check(state.StatusHistoryFilter{Status: state.StatusBlocked}.Matches(info), jc.IsFalse)
+	c.Check(state.StatusHistoryFilter{Since: &earlier, Until: &later}.Matches(info), jc.IsTrue)
+	c.Check(state.StatusHistoryFilter{Since: &later}.Matches(info), jc.IsFalse)
+	c.Check(state.StatusHistoryFilter{Until: &earlier}.Matches(info), jc.IsFalse)
+}
+
 func (s *statusSuite) TestTranslateLegacyAgentState(c *gc.C) {
 	for i, test := range []struct {
 		agentStatus     state.Status
@@ -296,13 +296,24 @@ func removeStorageInstanceOps(
 	} else if !errors.IsNotFound(err) {
 		return nil, errors.Trace(err)
 	}
+	// The filesystem is of no further use once the storage instance
+	// is gone, so destroy it as well as unassigning it.
 	filesystem, err := st.StorageInstanceFilesystem(tag)
 	if err == nil {
+		filesystemTag := filesystem.FilesystemTag()
+		attachmentOps, life, err := st.destroyFilesystemAttachmentsOps(filesystemTag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, attachmentOps...)
 		ops = append(ops, txn.Op{
 			C:      filesystemsC,
-			Id:     filesystem.Tag().Id(),
-			Assert: bson.D{{"storageid", tag.Id()}},
-			Update: bson.D{{"$set", bson.D{{"storageid", ""}}}},
+			Id:     filesystemTag.Id(),
+			Assert: bson.D{{"storageid", tag.Id()}, {"life", Alive}},
+			Update: bson.D{{"$set", bson.D{
+				{"storageid", ""},
+				{"life", life},
+			}}},
 		})
 	} else if !errors.IsNotFound(err) {
 		return nil, errors.Trace(err)
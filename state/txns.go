@@ -12,6 +12,8 @@ import (
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/monitoring"
 )
 
 const (
@@ -19,6 +21,26 @@ const (
 	txnAssertEnvIsNotAlive = false
 )
 
+var (
+	// txnRetries counts the transactions that were built again
+	// because an earlier attempt was aborted.
+	txnRetries = monitoring.NewCounterVec(
+		"juju_state_txn_retries_total",
+		"Number of times a transaction was retried after being aborted.",
+	)
+
+	// txnAborts counts the transactions that were given up on because
+	// their assertions failed.
+	txnAborts = monitoring.NewCounterVec(
+		"juju_state_txn_aborts_total",
+		"Number of transactions that failed because their assertions were not met.",
+	)
+)
+
+func init() {
+	monitoring.MustRegister(txnRetries, txnAborts)
+}
+
 // txnRunner returns a jujutxn.Runner instance.
 //
 // If st.transactionRunner is non-nil, then that will be
@@ -102,7 +124,11 @@ type multiEnvRunner struct {
 // to ensure correct interaction with these collections.
 func (r *multiEnvRunner) RunTransaction(ops []txn.Op) error {
 	ops = r.updateOps(ops)
-	return r.rawRunner.RunTransaction(ops)
+	err := r.rawRunner.RunTransaction(ops)
+	if err == txn.ErrAborted {
+		txnAborts.With().Inc()
+	}
+	return err
 }
 
 // Run is part of the jujutxn.Runner interface. Operations returned by
@@ -110,7 +136,10 @@ func (r *multiEnvRunner) RunTransaction(ops []txn.Op) error {
 // collections will be modified in-place to ensure correct interaction
 // with these collections.
 func (r *multiEnvRunner) Run(transactions jujutxn.TransactionSource) error {
-	return r.rawRunner.Run(func(attempt int) ([]txn.Op, error) {
+	err := r.rawRunner.Run(func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			txnRetries.With().Inc()
+		}
 		ops, err := transactions(attempt)
 		if err != nil {
 			// Don't use Trace here as jujutxn doens't use juju/errors
@@ -120,6 +149,10 @@ func (r *multiEnvRunner) Run(transactions jujutxn.TransactionSource) error {
 		ops = r.updateOps(ops)
 		return ops, nil
 	})
+	if err == jujutxn.ErrExcessiveContention {
+		txnAborts.With().Inc()
+	}
+	return err
 }
 
 // ResumeTransactions is part of the jujutxn.Runner interface.
@@ -356,6 +356,33 @@ func (s *MultiEnvRunnerSuite) TestRunWithError(c *gc.C) {
 	c.Assert(s.testRunner.seenOps, gc.IsNil)
 }
 
+func (s *MultiEnvRunnerSuite) TestRunCountsRetries(c *gc.C) {
+	retries := txnRetries.With().Value()
+	err := s.multiEnvRunner.Run(func(attempt int) ([]txn.Op, error) {
+		return nil, nil
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(txnRetries.With().Value(), gc.Equals, retries+1)
+}
+
+func (s *MultiEnvRunnerSuite) TestRunCountsExcessiveContention(c *gc.C) {
+	s.testRunner.runErr = jujutxn.ErrExcessiveContention
+	aborts := txnAborts.With().Value()
+	err := s.multiEnvRunner.Run(func(attempt int) ([]txn.Op, error) {
+		return nil, nil
+	})
+	c.Assert(err, gc.Equals, jujutxn.ErrExcessiveContention)
+	c.Assert(txnAborts.With().Value(), gc.Equals, aborts+1)
+}
+
+func (s *MultiEnvRunnerSuite) TestRunTransactionCountsAborts(c *gc.C) {
+	s.testRunner.runTransactionErr = txn.ErrAborted
+	aborts := txnAborts.With().Value()
+	err := s.multiEnvRunner.RunTransaction([]txn.Op{{C: "other", Id: "whatever"}})
+	c.Assert(err, gc.Equals, txn.ErrAborted)
+	c.Assert(txnAborts.With().Value(), gc.Equals, aborts+1)
+}
+
 func (s *MultiEnvRunnerSuite) TestResumeTransactions(c *gc.C) {
 	err := s.multiEnvRunner.ResumeTransactions()
 	c.Assert(err, jc.ErrorIsNil)
@@ -390,6 +417,8 @@ func (s *MultiEnvRunnerSuite) TestMaybePruneTransactionsWithError(c *gc.C) {
 // fresh instance should be created for each test.
 type recordingRunner struct {
 	seenOps                  []txn.Op
+	runTransactionErr        error
+	runErr                   error
 	resumeTransactionsCalled bool
 	resumeTransactionsErr    error
 	pruneTransactionsCalled  bool
@@ -398,11 +427,14 @@ type recordingRunner struct {
 
 func (r *recordingRunner) RunTransaction(ops []txn.Op) error {
 	r.seenOps = ops
-	return nil
+	return r.runTransactionErr
 }
 
 func (r *recordingRunner) Run(transactions jujutxn.TransactionSource) (err error) {
 	r.seenOps, err = transactions(testTxnAttempt)
+	if err == nil {
+		err = r.runErr
+	}
 	return
 }
 
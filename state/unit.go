@@ -61,6 +61,11 @@ const (
 	ResolvedNone       ResolvedMode = ""
 	ResolvedRetryHooks ResolvedMode = "retry-hooks"
 	ResolvedNoHooks    ResolvedMode = "no-hooks"
+
+	// ResolvedCancelRetry cancels the automatic retry of a failed
+	// hook, leaving the unit in an error state until it is resolved
+	// by one of the other modes.
+	ResolvedCancelRetry ResolvedMode = "cancel-retry"
 )
 
 // port identifies a network port number for a particular protocol.
@@ -100,6 +105,10 @@ type unitDoc struct {
 	TxnRevno               int64 `bson:"txn-revno"`
 	PasswordHash           string
 
+	// RemoteUnit is the name of the unit in another environment that
+	// this unit is a proxy for, if the unit belongs to a remote service.
+	RemoteUnit string `bson:"remoteunit,omitempty"`
+
 	// TODO(mue) No longer actively used, only in upgrades.go.
 	// To be removed later.
 	Ports          []port `bson:"ports"`
@@ -775,6 +784,44 @@ func (u *Unit) PrivateAddress() (string, bool) {
 	return privateAddress, privateAddress != ""
 }
 
+// BindingAddress returns the address that the unit should advertise on
+// the given endpoint of its service. If the endpoint is bound to a
+// space, this is the address of the unit's machine that is in one of the
+// space's subnets; otherwise it is the unit's private address.
+func (u *Unit) BindingAddress(endpoint string) (string, error) {
+	service, err := u.Service()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if _, err := service.Endpoint(endpoint); err != nil {
+		return "", errors.NotFoundf("endpoint %q of service %q", endpoint, service)
+	}
+	spaceName, ok := service.EndpointBindings()[endpoint]
+	if !ok {
+		if address, ok := u.PrivateAddress(); ok {
+			return address, nil
+		}
+		return "", errors.NotFoundf("private address of unit %q", u)
+	}
+	space, err := u.st.Space(spaceName)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	subnets, err := space.Subnets()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	m, err := u.machine()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	address, ok := selectSpaceAddress(m.Addresses(), subnets)
+	if !ok {
+		return "", errors.NotFoundf("address of unit %q in space %q", u, spaceName)
+	}
+	return address.Value, nil
+}
+
 // AvailabilityZone returns the name of the availability zone into which
 // the unit's machine instance was provisioned.
 func (u *Unit) AvailabilityZone() (string, error) {
@@ -823,10 +870,10 @@ func (u *Unit) AgentStatus() (StatusInfo, error) {
 	return agent.Status()
 }
 
-// StatusHistory returns a slice of at most <size> StatusInfo items
-// representing past statuses for this unit.
-func (u *Unit) StatusHistory(size int) ([]StatusInfo, error) {
-	return statusHistory(size, u.globalKey(), u.st)
+// StatusHistory returns the past statuses of this unit's workload that
+// match the given filter, most recent first.
+func (u *Unit) StatusHistory(filter StatusHistoryFilter) ([]StatusInfo, error) {
+	return filteredStatusHistory(filter, u.globalKey(), u.st)
 }
 
 // Status returns the status of the unit.
@@ -1915,29 +1962,80 @@ type ActionSpecsByName map[string]charm.ActionSpec
 
 // AddAction adds a new Action of type name and using arguments payload to
 // this Unit, and returns its ID.  Note that the use of spec.InsertDefaults
-// mutates payload.
+// mutates payload.  The Action's timeout is the default for the action
+// in the charm, if any.
 func (u *Unit) AddAction(name string, payload map[string]interface{}) (*Action, error) {
+	return u.AddActionWithTimeout(name, payload, 0)
+}
+
+// AddActionWithTimeout adds a new Action as AddAction does, which will
+// be stopped if it runs for longer than the given timeout.  A zero
+// timeout selects the default for the action in the charm, if any.
+func (u *Unit) AddActionWithTimeout(name string, payload map[string]interface{}, timeout time.Duration) (*Action, error) {
+	payloadWithDefaults, timeout, err := u.actionParams(name, payload, timeout)
+	if err != nil {
+		return nil, err
+	}
+	return u.st.enqueueAction(u.Tag(), name, payloadWithDefaults, timeout)
+}
+
+// actionParams checks the payload of the named action against the
+// unit's charm, and returns the payload with defaults inserted and the
+// timeout to enqueue the action with.
+func (u *Unit) actionParams(name string, payload map[string]interface{}, timeout time.Duration) (map[string]interface{}, time.Duration, error) {
 	if len(name) == 0 {
-		return nil, errors.New("no action name given")
+		return nil, 0, errors.New("no action name given")
 	}
 	specs, err := u.ActionSpecs()
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 	spec, ok := specs[name]
 	if !ok {
-		return nil, errors.Errorf("action %q not defined on unit %q", name, u.Name())
+		return nil, 0, errors.Errorf("action %q not defined on unit %q", name, u.Name())
 	}
 	// Reject bad payloads before attempting to insert defaults.
 	err = spec.ValidateParams(payload)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 	payloadWithDefaults, err := spec.InsertDefaults(payload)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
+	}
+	if timeout == 0 {
+		if timeout, err = actionSpecTimeout(spec); err != nil {
+			return nil, 0, errors.Annotatef(err, "action %q", name)
+		}
+	}
+	return payloadWithDefaults, timeout, nil
+}
+
+// actionSpecTimeout returns the default timeout of an action, which is
+// given by the "timeout" key of the action in the charm's actions.yaml.
+// It may be a duration such as "10m", or a number of seconds; if it is
+// not given, the action may run indefinitely.
+func actionSpecTimeout(spec charm.ActionSpec) (time.Duration, error) {
+	var timeout time.Duration
+	switch value := spec.Params["timeout"].(type) {
+	case nil:
+		return 0, nil
+	case string:
+		var err error
+		if timeout, err = time.ParseDuration(value); err != nil {
+			return 0, errors.Errorf("invalid timeout %q", value)
+		}
+	case int:
+		timeout = time.Duration(value) * time.Second
+	case float64:
+		timeout = time.Duration(value * float64(time.Second))
+	default:
+		return 0, errors.Errorf("invalid timeout %v", value)
+	}
+	if timeout < 0 {
+		return 0, errors.Errorf("invalid timeout %v", timeout)
 	}
-	return u.st.EnqueueAction(u.Tag(), name, payloadWithDefaults)
+	return timeout, nil
 }
 
 // ActionSpecs gets the ActionSpec map for the Unit's charm.
@@ -2022,6 +2120,20 @@ func (u *Unit) Resolve(retryHooks bool) error {
 	return u.SetResolved(mode)
 }
 
+// CancelHookRetry informs the unit that it should not automatically
+// retry the hook that put it in an error state, but should wait for
+// the error to be resolved.
+func (u *Unit) CancelHookRetry() error {
+	statusInfo, err := u.Status()
+	if err != nil {
+		return err
+	}
+	if statusInfo.Status != StatusError {
+		return errors.Errorf("unit %q is not in an error state", u)
+	}
+	return u.SetResolved(ResolvedCancelRetry)
+}
+
 // SetResolved marks the unit as having had any previous state transition
 // problems resolved, and informs the unit that it may attempt to
 // reestablish normal workflow. The resolved mode parameter informs
@@ -2030,7 +2142,7 @@ func (u *Unit) Resolve(retryHooks bool) error {
 func (u *Unit) SetResolved(mode ResolvedMode) (err error) {
 	defer errors.DeferredAnnotatef(&err, "cannot set resolved mode for unit %q", u)
 	switch mode {
-	case ResolvedRetryHooks, ResolvedNoHooks:
+	case ResolvedRetryHooks, ResolvedNoHooks, ResolvedCancelRetry:
 	default:
 		return fmt.Errorf("invalid error resolution mode: %q", mode)
 	}
@@ -694,7 +694,7 @@ func (s *UnitSuite) TestGetUnitStatusHistory(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	globalKey := state.UnitGlobalKey(s.unit)
 	history := func(i int) ([]state.StatusInfo, error) {
-		return s.unit.StatusHistory(i)
+		return s.unit.StatusHistory(state.StatusHistoryFilter{Size: i})
 	}
 	testGetUnitStatusHistory(c, history, s.State, globalKey)
 }
@@ -1182,6 +1182,19 @@ func (s *UnitSuite) TestResolve(c *gc.C) {
 	c.Assert(s.unit.Resolved(), gc.Equals, state.ResolvedRetryHooks)
 }
 
+func (s *UnitSuite) TestCancelHookRetry(c *gc.C) {
+	err := s.unit.CancelHookRetry()
+	c.Assert(err, gc.ErrorMatches, `unit "wordpress/0" is not in an error state`)
+
+	err = s.unit.SetAgentStatus(state.StatusError, "gaaah", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.CancelHookRetry()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.unit.Resolved(), gc.Equals, state.ResolvedCancelRetry)
+	err = s.unit.Resolve(false)
+	c.Assert(err, gc.ErrorMatches, `cannot set resolved mode for unit "wordpress/0": already resolved`)
+}
+
 func (s *UnitSuite) TestGetSetClearResolved(c *gc.C) {
 	mode := s.unit.Resolved()
 	c.Assert(mode, gc.Equals, state.ResolvedNone)
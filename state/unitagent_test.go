@@ -196,7 +196,7 @@ func (s *UnitAgentSuite) TestGetUnitAgentStatusHistory(c *gc.C) {
 	agent := s.unit.Agent().(*state.UnitAgent)
 	globalKey := state.UnitAgentGlobalKey(agent)
 	history := func(i int) ([]state.StatusInfo, error) {
-		return agent.StatusHistory(i)
+		return agent.StatusHistory(state.StatusHistoryFilter{Size: i})
 	}
 	testGetUnitStatusHistory(c, history, s.State, globalKey)
 }
@@ -95,7 +95,9 @@ func AddStateUsersAsEnvironUsers(st *State) error {
 
 		_, err := st.EnvironmentUser(uTag)
 		if err != nil && errors.IsNotFound(err) {
-			_, err = st.AddEnvironmentUser(uTag, uTag, "")
+			// Users had full access to the environment before
+			// access levels were introduced.
+			_, err = st.AddEnvironmentUserWithAccess(uTag, uTag, "", AdminAccess)
 			if err != nil {
 				return errors.Trace(err)
 			}
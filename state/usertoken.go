@@ -0,0 +1,255 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"crypto/rand"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	"github.com/juju/utils"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// tokenCredentialPrefix marks a login credential as an API token
+// rather than a password.
+const tokenCredentialPrefix = "token:"
+
+// UserToken represents an expiring API token that a local user may log
+// in with in place of their password. Only a hash of the token's secret
+// is stored; the secret itself is only available when the token is
+// created.
+type UserToken struct {
+	st  *State
+	doc userTokenDoc
+}
+
+type userTokenDoc struct {
+	DocID      string    `bson:"_id"`
+	User       string    `bson:"user"`
+	SecretHash string    `bson:"secrethash"`
+	SecretSalt string    `bson:"secretsalt"`
+	Access     Access    `bson:"access"`
+	Created    time.Time `bson:"created"`
+	Expires    time.Time `bson:"expires"`
+	// LastUsed is updated by the apiserver whenever the token is used
+	// to log in. As with the user's last login time, this update is not
+	// done using mgo.txn and should NEVER appear in transaction asserts.
+	LastUsed *time.Time `bson:"lastused"`
+}
+
+// ID returns the ID of the token.
+func (t *UserToken) ID() string {
+	return t.doc.DocID
+}
+
+// UserTag returns the tag of the user the token belongs to.
+func (t *UserToken) UserTag() names.UserTag {
+	return names.NewLocalUserTag(t.doc.User)
+}
+
+// Access returns the most access the token grants to any environment.
+// Users logging in with the token are granted the lesser of this and
+// their own level of access.
+func (t *UserToken) Access() Access {
+	return t.doc.Access
+}
+
+// Created returns the time the token was created in UTC.
+func (t *UserToken) Created() time.Time {
+	return t.doc.Created.UTC()
+}
+
+// Expires returns the time the token expires in UTC.
+func (t *UserToken) Expires() time.Time {
+	return t.doc.Expires.UTC()
+}
+
+// Expired returns whether the token had expired at the given time.
+func (t *UserToken) Expired(now time.Time) bool {
+	return !now.Before(t.doc.Expires)
+}
+
+// LastUsed returns when the token was last used to log in, in UTC.
+// The resulting time will be nil if the token has never been used.
+func (t *UserToken) LastUsed() *time.Time {
+	when := t.doc.LastUsed
+	if when == nil {
+		return nil
+	}
+	result := when.UTC()
+	return &result
+}
+
+// UpdateLastUsed sets the time the token was last used to now.
+func (t *UserToken) UpdateLastUsed() error {
+	tokens, closer := t.st.getCollection(userTokensC)
+	defer closer()
+	// Update the safe mode of the underlying session to not require
+	// write majority, nor sync to disk.
+	session := tokens.Underlying().Database.Session
+	session.SetSafe(&mgo.Safe{})
+
+	timestamp := nowToTheSecond()
+	update := bson.D{{"$set", bson.D{{"lastused", timestamp}}}}
+	if err := tokens.UpdateId(t.doc.DocID, update); err != nil {
+		return errors.Annotatef(err, "cannot update last used timestamp for token %q", t.doc.DocID)
+	}
+	t.doc.LastUsed = &timestamp
+	return nil
+}
+
+// TokenCredential returns the credential that a client presents in place
+// of a password to log in with the token with the given ID and secret.
+func TokenCredential(id, secret string) string {
+	return tokenCredentialPrefix + id + ":" + secret
+}
+
+// ParseTokenCredential returns the token ID and secret held in a login
+// credential. It returns false if the credential is not a token.
+func ParseTokenCredential(credential string) (id, secret string, ok bool) {
+	if !strings.HasPrefix(credential, tokenCredentialPrefix) {
+		return "", "", false
+	}
+	parts := strings.SplitN(strings.TrimPrefix(credential, tokenCredentialPrefix), ":", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
+
+// newTokenID returns a new random token ID.
+func newTokenID() (string, error) {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return "", errors.Trace(err)
+	}
+	return fmt.Sprintf("%x", buf), nil
+}
+
+// CreateToken creates an API token for the user that expires after the
+// given duration and that grants at most the given level of access. It
+// returns the token along with the credential to log in with, which
+// cannot be retrieved later.
+func (u *User) CreateToken(expiresIn time.Duration, access Access) (*UserToken, string, error) {
+	if expiresIn <= 0 {
+		return nil, "", errors.NotValidf("token expiry %v", expiresIn)
+	}
+	if err := access.Validate(); err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	id, err := newTokenID()
+	if err != nil {
+		return nil, "", errors.Annotate(err, "cannot create token ID")
+	}
+	secret, err := utils.RandomPassword()
+	if err != nil {
+		return nil, "", errors.Annotate(err, "cannot create token secret")
+	}
+	salt, err := utils.RandomSalt()
+	if err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	now := nowToTheSecond()
+	token := &UserToken{
+		st: u.st,
+		doc: userTokenDoc{
+			DocID:      id,
+			User:       u.doc.DocID,
+			SecretHash: utils.UserPasswordHash(secret, salt),
+			SecretSalt: salt,
+			Access:     access,
+			Created:    now,
+			Expires:    now.Add(expiresIn),
+		},
+	}
+	ops := []txn.Op{{
+		C:      usersC,
+		Id:     u.doc.DocID,
+		Assert: txn.DocExists,
+	}, {
+		C:      userTokensC,
+		Id:     id,
+		Assert: txn.DocMissing,
+		Insert: &token.doc,
+	}}
+	if err := u.st.runTransaction(ops); err != nil {
+		return nil, "", errors.Annotatef(err, "cannot create token for user %q", u.Name())
+	}
+	return token, TokenCredential(id, secret), nil
+}
+
+// Tokens returns the user's API tokens, including any that have expired,
+// ordered by creation time.
+func (u *User) Tokens() ([]*UserToken, error) {
+	tokens, closer := u.st.getCollection(userTokensC)
+	defer closer()
+
+	var docs []userTokenDoc
+	err := tokens.Find(bson.D{{"user", u.doc.DocID}}).Sort("created", "_id").All(&docs)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get tokens for user %q", u.Name())
+	}
+	var result []*UserToken
+	for _, doc := range docs {
+		result = append(result, &UserToken{st: u.st, doc: doc})
+	}
+	return result, nil
+}
+
+// RevokeToken removes the user's API token with the given ID, so that
+// it can no longer be used to log in.
+func (u *User) RevokeToken(id string) error {
+	ops := []txn.Op{{
+		C:      userTokensC,
+		Id:     id,
+		Assert: bson.D{{"user", u.doc.DocID}},
+		Remove: true,
+	}}
+	err := u.st.runTransaction(ops)
+	if err == txn.ErrAborted {
+		err = errors.NotFoundf("token %q for user %q", id, u.Name())
+	}
+	if err != nil {
+		return errors.Annotatef(err, "cannot revoke token")
+	}
+	return nil
+}
+
+// TokenValid returns whether the given token ID and secret identify an
+// unexpired API token belonging to the user.
+func (u *User) TokenValid(id, secret string) bool {
+	if u.IsDisabled() {
+		return false
+	}
+	token, err := u.st.UserToken(id)
+	if err != nil {
+		return false
+	}
+	if token.doc.User != u.doc.DocID || token.Expired(time.Now()) {
+		return false
+	}
+	return utils.UserPasswordHash(secret, token.doc.SecretSalt) == token.doc.SecretHash
+}
+
+// UserToken returns the API token with the given ID.
+func (st *State) UserToken(id string) (*UserToken, error) {
+	tokens, closer := st.getCollection(userTokensC)
+	defer closer()
+
+	token := &UserToken{st: st}
+	err := tokens.FindId(id).One(&token.doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("token %q", id)
+	}
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get token %q", id)
+	}
+	return token, nil
+}
@@ -0,0 +1,150 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type UserTokenSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&UserTokenSuite{})
+
+func (s *UserTokenSuite) TestCreateToken(c *gc.C) {
+	user := s.factory.MakeUser(c, &factory.UserParams{Name: "bob"})
+	now := state.NowToTheSecond()
+
+	token, credential, err := user.CreateToken(24*time.Hour, state.ReadAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(token.ID(), gc.Not(gc.Equals), "")
+	c.Assert(token.UserTag(), gc.Equals, user.UserTag())
+	c.Assert(token.Access(), gc.Equals, state.ReadAccess)
+	c.Assert(token.Created().Before(now), jc.IsFalse)
+	c.Assert(token.Expires(), gc.Equals, token.Created().Add(24*time.Hour))
+	c.Assert(token.LastUsed(), gc.IsNil)
+
+	id, secret, ok := state.ParseTokenCredential(credential)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(id, gc.Equals, token.ID())
+	c.Assert(user.TokenValid(id, secret), jc.IsTrue)
+	c.Assert(user.PasswordValid(secret), jc.IsFalse)
+
+	fetched, err := s.State.UserToken(id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fetched.UserTag(), gc.Equals, user.UserTag())
+	c.Assert(fetched.Access(), gc.Equals, state.ReadAccess)
+}
+
+func (s *UserTokenSuite) TestCreateTokenInvalid(c *gc.C) {
+	user := s.factory.MakeUser(c, nil)
+	_, _, err := user.CreateToken(0, state.ReadAccess)
+	c.Assert(err, gc.ErrorMatches, "token expiry 0 not valid")
+	_, _, err = user.CreateToken(time.Hour, state.Access("root"))
+	c.Assert(err, gc.ErrorMatches, `access level "root" not valid`)
+}
+
+func (s *UserTokenSuite) TestTokenValid(c *gc.C) {
+	bob := s.factory.MakeUser(c, &factory.UserParams{Name: "bob"})
+	alice := s.factory.MakeUser(c, &factory.UserParams{Name: "alice"})
+	token, credential, err := bob.CreateToken(time.Hour, state.WriteAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	id, secret, _ := state.ParseTokenCredential(credential)
+
+	c.Check(bob.TokenValid(id, secret), jc.IsTrue)
+	c.Check(bob.TokenValid(id, "wrong"), jc.IsFalse)
+	c.Check(bob.TokenValid("missing", secret), jc.IsFalse)
+	c.Check(alice.TokenValid(id, secret), jc.IsFalse)
+
+	err = state.RunTransaction(s.State, []txn.Op{{
+		C:      state.UserTokensC,
+		Id:     token.ID(),
+		Update: bson.D{{"$set", bson.D{{"expires", time.Now().Add(-time.Minute)}}}},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(bob.TokenValid(id, secret), jc.IsFalse)
+}
+
+func (s *UserTokenSuite) TestTokenInvalidForDisabledUser(c *gc.C) {
+	user := s.factory.MakeUser(c, nil)
+	_, credential, err := user.CreateToken(time.Hour, state.WriteAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	id, secret, _ := state.ParseTokenCredential(credential)
+
+	err = user.Disable()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(user.TokenValid(id, secret), jc.IsFalse)
+}
+
+func (s *UserTokenSuite) TestTokensAndRevoke(c *gc.C) {
+	bob := s.factory.MakeUser(c, &factory.UserParams{Name: "bob"})
+	alice := s.factory.MakeUser(c, &factory.UserParams{Name: "alice"})
+	token1, _, err := bob.CreateToken(time.Hour, state.ReadAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	token2, _, err := bob.CreateToken(time.Hour, state.AdminAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	_, _, err = alice.CreateToken(time.Hour, state.ReadAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	tokens, err := bob.Tokens()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tokenIDs(tokens), jc.SameContents, []string{token1.ID(), token2.ID()})
+
+	err = alice.RevokeToken(token1.ID())
+	c.Assert(err, gc.ErrorMatches, `cannot revoke token: token ".*" for user "alice" not found`)
+	c.Assert(errors.IsNotFound(errors.Cause(err)), jc.IsTrue)
+
+	err = bob.RevokeToken(token1.ID())
+	c.Assert(err, jc.ErrorIsNil)
+	tokens, err = bob.Tokens()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tokenIDs(tokens), jc.DeepEquals, []string{token2.ID()})
+
+	_, err = s.State.UserToken(token1.ID())
+	c.Assert(errors.IsNotFound(err), jc.IsTrue)
+}
+
+func (s *UserTokenSuite) TestUpdateLastUsed(c *gc.C) {
+	user := s.factory.MakeUser(c, nil)
+	token, _, err := user.CreateToken(time.Hour, state.ReadAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	now := state.NowToTheSecond()
+
+	err = token.UpdateLastUsed()
+	c.Assert(err, jc.ErrorIsNil)
+	fetched, err := s.State.UserToken(token.ID())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fetched.LastUsed(), gc.NotNil)
+	c.Assert(fetched.LastUsed().Before(now), jc.IsFalse)
+}
+
+func (s *UserTokenSuite) TestParseTokenCredential(c *gc.C) {
+	id, secret, ok := state.ParseTokenCredential(state.TokenCredential("abc", "s3:cret"))
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(id, gc.Equals, "abc")
+	c.Assert(secret, gc.Equals, "s3:cret")
+
+	for _, credential := range []string{"password", "token:", "token:abc", "token::secret", "token:abc:"} {
+		_, _, ok := state.ParseTokenCredential(credential)
+		c.Check(ok, jc.IsFalse, gc.Commentf("credential %q", credential))
+	}
+}
+
+func tokenIDs(tokens []*state.UserToken) []string {
+	var ids []string
+	for _, token := range tokens {
+		ids = append(ids, token.ID())
+	}
+	return ids
+}
@@ -0,0 +1,151 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// volumeResizeDoc records a request to grow a provisioned volume. The
+// document shares its ID with the volume being resized, so requests
+// to resize machine-scoped volumes are watched by the machine's storage
+// provisioner, and all others by the environment storage provisioner.
+type volumeResizeDoc struct {
+	DocID   string `bson:"_id"`
+	EnvUUID string `bson:"env-uuid"`
+	Volume  string `bson:"volumeid"`
+	Size    uint64 `bson:"size"`
+}
+
+// ResizeVolume records a request to grow the volume with the specified
+// tag to the specified size, in MiB. The volume must be alive and
+// provisioned, and volumes may not be shrunk; the volume is resized by
+// the storage provisioner responsible for it. Requesting a new size for
+// a volume with a pending resize replaces the pending request.
+func (st *State) ResizeVolume(tag names.VolumeTag, size uint64) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot resize volume %q", tag.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		v, err := st.Volume(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if v.Life() != Alive {
+			return nil, errors.New("volume is not alive")
+		}
+		info, err := v.Info()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if size < info.Size {
+			return nil, errors.Errorf(
+				"cannot shrink volume from %dMiB to %dMiB",
+				info.Size, size,
+			)
+		}
+		pending, err := st.PendingVolumeResize(tag)
+		isPending := err == nil
+		if err != nil && !errors.IsNotFound(err) {
+			return nil, errors.Trace(err)
+		}
+		if isPending && pending == size || !isPending && size == info.Size {
+			return nil, jujutxn.ErrNoOperations
+		}
+		ops := []txn.Op{{
+			C:  volumesC,
+			Id: tag.Id(),
+			Assert: append(isAliveDoc, bson.DocElem{
+				"info.size", bson.D{{"$lte", size}},
+			}),
+		}}
+		if isPending {
+			ops = append(ops, txn.Op{
+				C:      volumeResizesC,
+				Id:     tag.Id(),
+				Assert: bson.D{{"size", pending}},
+				Update: bson.D{{"$set", bson.D{{"size", size}}}},
+			})
+		} else {
+			ops = append(ops, txn.Op{
+				C:      volumeResizesC,
+				Id:     tag.Id(),
+				Assert: txn.DocMissing,
+				Insert: &volumeResizeDoc{Volume: tag.Id(), Size: size},
+			})
+		}
+		return ops, nil
+	}
+	return st.run(buildTxn)
+}
+
+// PendingVolumeResize returns the size, in MiB, that the volume with
+// the specified tag has been requested to grow to, or a NotFound error
+// if there is no pending request to resize the volume.
+func (st *State) PendingVolumeResize(tag names.VolumeTag) (uint64, error) {
+	coll, cleanup := st.getCollection(volumeResizesC)
+	defer cleanup()
+
+	var doc volumeResizeDoc
+	err := coll.FindId(tag.Id()).One(&doc)
+	if err == mgo.ErrNotFound {
+		return 0, errors.NotFoundf("pending resize of volume %q", tag.Id())
+	} else if err != nil {
+		return 0, errors.Annotate(err, "cannot get pending volume resize")
+	}
+	return doc.Size, nil
+}
+
+// SetVolumeResized records that the volume with the specified tag has
+// been grown to the specified size, in MiB. The pending request to
+// resize the volume is removed if it has been satisfied.
+func (st *State) SetVolumeResized(tag names.VolumeTag, size uint64) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set size of volume %q", tag.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		v, err := st.Volume(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		info, err := v.Info()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if size < info.Size {
+			return nil, errors.Errorf(
+				"cannot shrink volume from %dMiB to %dMiB",
+				info.Size, size,
+			)
+		}
+		pending, err := st.PendingVolumeResize(tag)
+		isPending := err == nil
+		if err != nil && !errors.IsNotFound(err) {
+			return nil, errors.Trace(err)
+		}
+		var ops []txn.Op
+		if size != info.Size {
+			ops = append(ops, txn.Op{
+				C:      volumesC,
+				Id:     tag.Id(),
+				Assert: bson.D{{"info.size", info.Size}},
+				Update: bson.D{{"$set", bson.D{{"info.size", size}}}},
+			})
+		}
+		if isPending && pending <= size {
+			ops = append(ops, txn.Op{
+				C:      volumeResizesC,
+				Id:     tag.Id(),
+				Assert: bson.D{{"size", pending}},
+				Remove: true,
+			})
+		}
+		if len(ops) == 0 {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return ops, nil
+	}
+	return st.run(buildTxn)
+}
@@ -0,0 +1,189 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/testing"
+)
+
+type VolumeResizeStateSuite struct {
+	StorageStateSuiteBase
+}
+
+var _ = gc.Suite(&VolumeResizeStateSuite{})
+
+// setupProvisionedVolume adds a unit with a provisioned 1GiB loop
+// volume, assigned to a new machine, and returns the volume's tag.
+func (s *VolumeResizeStateSuite) setupProvisionedVolume(c *gc.C) names.VolumeTag {
+	_, u, storageTag := s.setupSingleStorage(c, "block", "loop-pool")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	volumeTag := s.storageInstanceVolume(c, storageTag).VolumeTag()
+	err = s.State.SetVolumeInfo(volumeTag, state.VolumeInfo{VolumeId: "vol-123", Size: 1024})
+	c.Assert(err, jc.ErrorIsNil)
+	return volumeTag
+}
+
+func (s *VolumeResizeStateSuite) TestResizeVolume(c *gc.C) {
+	volumeTag := s.setupProvisionedVolume(c)
+	_, err := s.State.PendingVolumeResize(volumeTag)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	err = s.State.ResizeVolume(volumeTag, 2048)
+	c.Assert(err, jc.ErrorIsNil)
+	size, err := s.State.PendingVolumeResize(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(size, gc.Equals, uint64(2048))
+
+	// A later request replaces the pending one.
+	err = s.State.ResizeVolume(volumeTag, 4096)
+	c.Assert(err, jc.ErrorIsNil)
+	size, err = s.State.PendingVolumeResize(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(size, gc.Equals, uint64(4096))
+
+	// The volume's recorded size is unchanged until it is resized.
+	volume, err := s.State.Volume(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+	info, err := volume.Info()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.Size, gc.Equals, uint64(1024))
+}
+
+func (s *VolumeResizeStateSuite) TestResizeVolumeSameSize(c *gc.C) {
+	volumeTag := s.setupProvisionedVolume(c)
+	err := s.State.ResizeVolume(volumeTag, 1024)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.PendingVolumeResize(volumeTag)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *VolumeResizeStateSuite) TestResizeVolumeShrink(c *gc.C) {
+	volumeTag := s.setupProvisionedVolume(c)
+	err := s.State.ResizeVolume(volumeTag, 512)
+	c.Assert(err, gc.ErrorMatches, `cannot resize volume "0/0": cannot shrink volume from 1024MiB to 512MiB`)
+}
+
+func (s *VolumeResizeStateSuite) TestResizeVolumeUnprovisioned(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "block", "loop-pool")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	volumeTag := s.storageInstanceVolume(c, storageTag).VolumeTag()
+
+	err = s.State.ResizeVolume(volumeTag, 2048)
+	c.Assert(err, gc.ErrorMatches, `cannot resize volume "0/0": volume "0/0" not provisioned`)
+}
+
+func (s *VolumeResizeStateSuite) TestResizeVolumeNotFound(c *gc.C) {
+	err := s.State.ResizeVolume(names.NewVolumeTag("42"), 2048)
+	c.Assert(err, gc.ErrorMatches, `cannot resize volume "42": volume "42" not found`)
+	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *VolumeResizeStateSuite) TestSetVolumeResized(c *gc.C) {
+	volumeTag := s.setupProvisionedVolume(c)
+	err := s.State.ResizeVolume(volumeTag, 2000)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Providers may grow volumes by more than was requested.
+	err = s.State.SetVolumeResized(volumeTag, 2048)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.PendingVolumeResize(volumeTag)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	volume, err := s.State.Volume(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+	info, err := volume.Info()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.Size, gc.Equals, uint64(2048))
+	c.Assert(info.VolumeId, gc.Equals, "vol-123")
+}
+
+func (s *VolumeResizeStateSuite) TestSetVolumeResizedPartially(c *gc.C) {
+	volumeTag := s.setupProvisionedVolume(c)
+	err := s.State.ResizeVolume(volumeTag, 4096)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// A resize that does not satisfy the request leaves it pending.
+	err = s.State.SetVolumeResized(volumeTag, 2048)
+	c.Assert(err, jc.ErrorIsNil)
+	size, err := s.State.PendingVolumeResize(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(size, gc.Equals, uint64(4096))
+}
+
+func (s *VolumeResizeStateSuite) TestSetVolumeResizedShrink(c *gc.C) {
+	volumeTag := s.setupProvisionedVolume(c)
+	err := s.State.SetVolumeResized(volumeTag, 512)
+	c.Assert(err, gc.ErrorMatches, `cannot set size of volume "0/0": cannot shrink volume from 1024MiB to 512MiB`)
+}
+
+func (s *VolumeResizeStateSuite) TestWatchMachineVolumeResizes(c *gc.C) {
+	volumeTag := s.setupProvisionedVolume(c)
+	w := s.State.WatchMachineVolumeResizes(names.NewMachineTag("0"))
+	defer testing.AssertStop(c, w)
+	wc := testing.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChangeInSingleEvent() // initial
+	wc.AssertNoChange()
+
+	err := s.State.ResizeVolume(volumeTag, 2048)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent("0/0")
+	wc.AssertNoChange()
+
+	// Replacing the size of a pending request is reported.
+	err = s.State.ResizeVolume(volumeTag, 4096)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent("0/0")
+	wc.AssertNoChange()
+
+	err = s.State.SetVolumeResized(volumeTag, 4096)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent("0/0")
+	wc.AssertNoChange()
+
+	// Resizes of other machines' volumes are not reported.
+	other := s.State.WatchMachineVolumeResizes(names.NewMachineTag("1"))
+	defer testing.AssertStop(c, other)
+	otherC := testing.NewStringsWatcherC(c, s.State, other)
+	otherC.AssertChangeInSingleEvent()
+	otherC.AssertNoChange()
+}
+
+func (s *VolumeResizeStateSuite) TestWatchEnvironVolumeResizes(c *gc.C) {
+	w := s.State.WatchEnvironVolumeResizes()
+	defer testing.AssertStop(c, w)
+	wc := testing.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChangeInSingleEvent() // initial
+	wc.AssertNoChange()
+
+	// Resizes of machine-scoped volumes are not reported.
+	volumeTag := s.setupProvisionedVolume(c)
+	err := s.State.ResizeVolume(volumeTag, 2048)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+}
+
+func (s *VolumeResizeStateSuite) TestWatchVolume(c *gc.C) {
+	volumeTag := s.setupProvisionedVolume(c)
+	w := s.State.WatchVolume(volumeTag)
+	defer testing.AssertStop(c, w)
+	wc := testing.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	// Requesting a resize does not change the volume.
+	err := s.State.ResizeVolume(volumeTag, 2048)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+
+	err = s.State.SetVolumeResized(volumeTag, 2048)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
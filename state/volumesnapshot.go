@@ -0,0 +1,361 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// VolumeSnapshot describes a point-in-time snapshot of a volume.
+//
+// Snapshots share the scope of the volume they are taken of: snapshots
+// of machine-scoped volumes have IDs of the form "<machine>/<n>", and
+// are managed by that machine's storage provisioner; all other snapshots
+// have IDs of the form "<n>", and are managed by the environment storage
+// provisioner.
+type VolumeSnapshot interface {
+	// Id returns the unique ID assigned by Juju to the snapshot.
+	Id() string
+
+	// Volume returns the tag of the volume that the snapshot is of.
+	Volume() names.VolumeTag
+
+	// Pool returns the name of the storage pool of the snapshotted volume.
+	Pool() string
+
+	// Life returns the life of the snapshot.
+	Life() Life
+
+	// Created returns the time the snapshot was requested, in UTC.
+	Created() time.Time
+
+	// Info returns the snapshot's VolumeSnapshotInfo, or a NotProvisioned
+	// error if the snapshot has not yet been taken.
+	Info() (VolumeSnapshotInfo, error)
+}
+
+// VolumeSnapshotInfo describes information about a volume snapshot.
+type VolumeSnapshotInfo struct {
+	SnapshotId string `bson:"snapshotid"`
+	Size       uint64 `bson:"size"`
+}
+
+type volumeSnapshot struct {
+	doc volumeSnapshotDoc
+}
+
+// volumeSnapshotDoc records information about a volume snapshot
+// in the environment.
+type volumeSnapshotDoc struct {
+	DocID   string              `bson:"_id"`
+	Name    string              `bson:"name"`
+	EnvUUID string              `bson:"env-uuid"`
+	Life    Life                `bson:"life"`
+	Volume  string              `bson:"volumeid"`
+	Pool    string              `bson:"pool"`
+	Created time.Time           `bson:"created"`
+	Info    *VolumeSnapshotInfo `bson:"info,omitempty"`
+}
+
+// Id is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Id() string {
+	return s.doc.Name
+}
+
+// Volume is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Volume() names.VolumeTag {
+	return names.NewVolumeTag(s.doc.Volume)
+}
+
+// Pool is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Pool() string {
+	return s.doc.Pool
+}
+
+// Life is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Life() Life {
+	return s.doc.Life
+}
+
+// Created is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Created() time.Time {
+	return s.doc.Created.UTC()
+}
+
+// Info is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Info() (VolumeSnapshotInfo, error) {
+	if s.doc.Info == nil {
+		return VolumeSnapshotInfo{}, errors.NotProvisionedf("volume snapshot %q", s.doc.Name)
+	}
+	return *s.doc.Info, nil
+}
+
+// IsValidVolumeSnapshot reports whether the specified string is a valid
+// volume snapshot ID. Snapshot IDs have the same form as volume IDs.
+func IsValidVolumeSnapshot(id string) bool {
+	return names.IsValidVolume(id)
+}
+
+// VolumeSnapshotMachine returns the machine component of the volume
+// snapshot ID, and a boolean indicating whether or not there is a
+// machine component.
+func VolumeSnapshotMachine(id string) (names.MachineTag, bool) {
+	return names.VolumeMachine(names.NewVolumeTag(id))
+}
+
+// newVolumeSnapshotName returns a unique volume snapshot name, with
+// the same machine scope as the volume with the specified name.
+func newVolumeSnapshotName(st *State, volumeName string) (string, error) {
+	seq, err := st.sequence("snapshot")
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	id := fmt.Sprint(seq)
+	if i := strings.LastIndex(volumeName, "/"); i != -1 {
+		id = volumeName[:i] + "/" + id
+	}
+	return id, nil
+}
+
+// AddVolumeSnapshot records a request to snapshot the volume with the
+// specified tag, returning the new snapshot. The volume must be alive
+// and provisioned; the snapshot is taken by the storage provisioner
+// responsible for the volume.
+func (st *State) AddVolumeSnapshot(tag names.VolumeTag) (_ VolumeSnapshot, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot snapshot volume %q", tag.Id())
+	v, err := st.Volume(tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if v.Life() != Alive {
+		return nil, errors.New("volume is not alive")
+	}
+	info, err := v.Info()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	name, err := newVolumeSnapshotName(st, tag.Id())
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot generate snapshot name")
+	}
+	doc := volumeSnapshotDoc{
+		Name:    name,
+		Volume:  tag.Id(),
+		Pool:    info.Pool,
+		Created: nowToTheSecond(),
+	}
+	ops := []txn.Op{{
+		C:      volumesC,
+		Id:     tag.Id(),
+		Assert: append(isAliveDoc, bson.DocElem{"info", bson.D{{"$exists", true}}}),
+	}, {
+		C:      volumeSnapshotsC,
+		Id:     name,
+		Assert: txn.DocMissing,
+		Insert: &doc,
+	}}
+	if err := st.runTransaction(ops); err == txn.ErrAborted {
+		return nil, errors.New("volume is not alive")
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &volumeSnapshot{doc}, nil
+}
+
+// VolumeSnapshot returns the VolumeSnapshot with the specified ID.
+func (st *State) VolumeSnapshot(id string) (VolumeSnapshot, error) {
+	coll, cleanup := st.getCollection(volumeSnapshotsC)
+	defer cleanup()
+
+	var s volumeSnapshot
+	err := coll.FindId(id).One(&s.doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("volume snapshot %q", id)
+	} else if err != nil {
+		return nil, errors.Annotate(err, "cannot get volume snapshot")
+	}
+	return &s, nil
+}
+
+// VolumeSnapshots returns all of the VolumeSnapshots of the specified
+// volume, ordered by creation time.
+func (st *State) VolumeSnapshots(volume names.VolumeTag) ([]VolumeSnapshot, error) {
+	snapshots, err := st.volumeSnapshots(bson.D{{"volumeid", volume.Id()}})
+	if err != nil {
+		return nil, errors.Annotatef(err, "getting snapshots of volume %q", volume.Id())
+	}
+	return snapshots, nil
+}
+
+// AllVolumeSnapshots returns all VolumeSnapshots in the environment,
+// ordered by creation time.
+func (st *State) AllVolumeSnapshots() ([]VolumeSnapshot, error) {
+	snapshots, err := st.volumeSnapshots(nil)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot get volume snapshots")
+	}
+	return snapshots, nil
+}
+
+func (st *State) volumeSnapshots(query bson.D) ([]VolumeSnapshot, error) {
+	coll, cleanup := st.getCollection(volumeSnapshotsC)
+	defer cleanup()
+
+	var docs []volumeSnapshotDoc
+	if err := coll.Find(query).Sort("created", "_id").All(&docs); err != nil {
+		return nil, errors.Trace(err)
+	}
+	snapshots := make([]VolumeSnapshot, len(docs))
+	for i, doc := range docs {
+		snapshots[i] = &volumeSnapshot{doc}
+	}
+	return snapshots, nil
+}
+
+// DestroyVolumeSnapshot ensures that the volume snapshot with the
+// specified ID is Dying, so that the storage provisioner will destroy
+// it and remove it from state.
+func (st *State) DestroyVolumeSnapshot(id string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot destroy volume snapshot %q", id)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		s, err := st.VolumeSnapshot(id)
+		if errors.IsNotFound(err) {
+			return nil, jujutxn.ErrNoOperations
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if s.Life() != Alive {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return []txn.Op{{
+			C:      volumeSnapshotsC,
+			Id:     id,
+			Assert: isAliveDoc,
+			Update: bson.D{{"$set", bson.D{{"life", Dying}}}},
+		}}, nil
+	}
+	return st.run(buildTxn)
+}
+
+// SetVolumeSnapshotInfo sets the VolumeSnapshotInfo for the specified
+// volume snapshot. The info may only be set once.
+func (st *State) SetVolumeSnapshotInfo(id string, info VolumeSnapshotInfo) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set info for volume snapshot %q", id)
+	if info.SnapshotId == "" {
+		return errors.New("snapshot ID not set")
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		s, err := st.VolumeSnapshot(id)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if oldInfo, err := s.Info(); err == nil {
+			if oldInfo == info {
+				return nil, jujutxn.ErrNoOperations
+			}
+			return nil, errors.Errorf(
+				"cannot change snapshot ID from %q to %q",
+				oldInfo.SnapshotId, info.SnapshotId,
+			)
+		}
+		return []txn.Op{{
+			C:      volumeSnapshotsC,
+			Id:     id,
+			Assert: bson.D{{"info", bson.D{{"$exists", false}}}},
+			Update: bson.D{{"$set", bson.D{{"info", &info}}}},
+		}}, nil
+	}
+	return st.run(buildTxn)
+}
+
+// RemoveVolumeSnapshot removes the volume snapshot with the specified
+// ID from state. The snapshot must not be Alive.
+func (st *State) RemoveVolumeSnapshot(id string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot remove volume snapshot %q", id)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		s, err := st.VolumeSnapshot(id)
+		if errors.IsNotFound(err) {
+			return nil, jujutxn.ErrNoOperations
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if s.Life() == Alive {
+			return nil, errors.New("volume snapshot is alive")
+		}
+		return []txn.Op{{
+			C:      volumeSnapshotsC,
+			Id:     id,
+			Assert: bson.D{{"life", bson.D{{"$ne", Alive}}}},
+			Remove: true,
+		}}, nil
+	}
+	return st.run(buildTxn)
+}
+
+// AddVolumeFromSnapshot adds a volume created from the volume snapshot
+// with the specified ID, and attaches it to the specified machine. The
+// new volume is in the same storage pool as the snapshotted volume, and
+// is the size of the snapshot. Snapshots of machine-scoped volumes may
+// only be restored to the same machine.
+func (st *State) AddVolumeFromSnapshot(id string, machine names.MachineTag) (_ names.VolumeTag, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot restore volume snapshot %q", id)
+	s, err := st.VolumeSnapshot(id)
+	if err != nil {
+		return names.VolumeTag{}, errors.Trace(err)
+	}
+	if s.Life() != Alive {
+		return names.VolumeTag{}, errors.New("volume snapshot is not alive")
+	}
+	info, err := s.Info()
+	if err != nil {
+		return names.VolumeTag{}, errors.Trace(err)
+	}
+	if snapshotMachine, ok := VolumeSnapshotMachine(id); ok && snapshotMachine != machine {
+		return names.VolumeTag{}, errors.Errorf(
+			"snapshot of machine-scoped volume cannot be restored to another machine",
+		)
+	}
+	m, err := st.Machine(machine.Id())
+	if err != nil {
+		return names.VolumeTag{}, errors.Trace(err)
+	}
+	if m.Life() != Alive {
+		return names.VolumeTag{}, errors.Errorf("machine %s is not alive", machine.Id())
+	}
+	volumeOp, volumeTag, err := st.addVolumeOp(VolumeParams{
+		Pool:     s.Pool(),
+		Size:     info.Size,
+		Snapshot: info.SnapshotId,
+	}, machine.Id())
+	if err != nil {
+		return names.VolumeTag{}, errors.Trace(err)
+	}
+	ops := []txn.Op{{
+		C:      machinesC,
+		Id:     m.doc.DocID,
+		Assert: isAliveDoc,
+	}, {
+		C:      volumeSnapshotsC,
+		Id:     id,
+		Assert: isAliveDoc,
+	}, volumeOp}
+	ops = append(ops, createMachineVolumeAttachmentsOps(
+		machine.Id(), []volumeAttachmentTemplate{{tag: volumeTag}},
+	)...)
+	if err := st.runTransaction(ops); err == txn.ErrAborted {
+		return names.VolumeTag{}, errors.New("machine or volume snapshot is no longer alive")
+	} else if err != nil {
+		return names.VolumeTag{}, errors.Trace(err)
+	}
+	return volumeTag, nil
+}
@@ -0,0 +1,253 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/testing"
+)
+
+type VolumeSnapshotStateSuite struct {
+	StorageStateSuiteBase
+}
+
+var _ = gc.Suite(&VolumeSnapshotStateSuite{})
+
+// setupProvisionedVolume adds a unit with a provisioned loop volume,
+// assigned to a new machine, and returns the volume's tag.
+func (s *VolumeSnapshotStateSuite) setupProvisionedVolume(c *gc.C) names.VolumeTag {
+	_, u, storageTag := s.setupSingleStorage(c, "block", "loop-pool")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	volumeTag := s.storageInstanceVolume(c, storageTag).VolumeTag()
+	err = s.State.SetVolumeInfo(volumeTag, state.VolumeInfo{VolumeId: "vol-123", Size: 1024})
+	c.Assert(err, jc.ErrorIsNil)
+	return volumeTag
+}
+
+func (s *VolumeSnapshotStateSuite) TestAddVolumeSnapshot(c *gc.C) {
+	volumeTag := s.setupProvisionedVolume(c)
+	snapshot, err := s.State.AddVolumeSnapshot(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+	// Snapshots share the machine scope of the volume.
+	c.Assert(snapshot.Id(), gc.Equals, "0/0")
+	c.Assert(snapshot.Volume(), gc.Equals, volumeTag)
+	c.Assert(snapshot.Pool(), gc.Equals, "loop-pool")
+	c.Assert(snapshot.Life(), gc.Equals, state.Alive)
+	c.Assert(snapshot.Created().IsZero(), jc.IsFalse)
+	_, err = snapshot.Info()
+	c.Assert(err, jc.Satisfies, errors.IsNotProvisioned)
+
+	snapshot, err = s.State.VolumeSnapshot("0/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshot.Volume(), gc.Equals, volumeTag)
+}
+
+func (s *VolumeSnapshotStateSuite) TestAddVolumeSnapshotUnprovisioned(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "block", "loop-pool")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	volumeTag := s.storageInstanceVolume(c, storageTag).VolumeTag()
+
+	_, err = s.State.AddVolumeSnapshot(volumeTag)
+	c.Assert(err, gc.ErrorMatches, `cannot snapshot volume "0/0": volume "0/0" not provisioned`)
+}
+
+func (s *VolumeSnapshotStateSuite) TestAddVolumeSnapshotVolumeNotFound(c *gc.C) {
+	_, err := s.State.AddVolumeSnapshot(names.NewVolumeTag("42"))
+	c.Assert(err, gc.ErrorMatches, `cannot snapshot volume "42": volume "42" not found`)
+	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *VolumeSnapshotStateSuite) TestVolumeSnapshotNotFound(c *gc.C) {
+	_, err := s.State.VolumeSnapshot("0/42")
+	c.Assert(err, gc.ErrorMatches, `volume snapshot "0/42" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *VolumeSnapshotStateSuite) TestVolumeSnapshots(c *gc.C) {
+	volumeTag := s.setupProvisionedVolume(c)
+	for i := 0; i < 2; i++ {
+		_, err := s.State.AddVolumeSnapshot(volumeTag)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	snapshots, err := s.State.VolumeSnapshots(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshots, gc.HasLen, 2)
+	c.Assert(snapshots[0].Id(), gc.Equals, "0/0")
+	c.Assert(snapshots[1].Id(), gc.Equals, "0/1")
+
+	snapshots, err = s.State.VolumeSnapshots(names.NewVolumeTag("42"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshots, gc.HasLen, 0)
+
+	snapshots, err = s.State.AllVolumeSnapshots()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshots, gc.HasLen, 2)
+}
+
+func (s *VolumeSnapshotStateSuite) TestSetVolumeSnapshotInfo(c *gc.C) {
+	volumeTag := s.setupProvisionedVolume(c)
+	snapshot, err := s.State.AddVolumeSnapshot(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	info := state.VolumeSnapshotInfo{SnapshotId: "snap-123", Size: 1024}
+	err = s.State.SetVolumeSnapshotInfo(snapshot.Id(), info)
+	c.Assert(err, jc.ErrorIsNil)
+	snapshot, err = s.State.VolumeSnapshot(snapshot.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	infoGot, err := snapshot.Info()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(infoGot, gc.Equals, info)
+
+	// Setting the same info again is a no-op.
+	err = s.State.SetVolumeSnapshotInfo(snapshot.Id(), info)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.SetVolumeSnapshotInfo(snapshot.Id(), state.VolumeSnapshotInfo{SnapshotId: "snap-456"})
+	c.Assert(err, gc.ErrorMatches, `cannot set info for volume snapshot "0/0": cannot change snapshot ID from "snap-123" to "snap-456"`)
+
+	err = s.State.SetVolumeSnapshotInfo(snapshot.Id(), state.VolumeSnapshotInfo{})
+	c.Assert(err, gc.ErrorMatches, `cannot set info for volume snapshot "0/0": snapshot ID not set`)
+}
+
+func (s *VolumeSnapshotStateSuite) TestDestroyRemoveVolumeSnapshot(c *gc.C) {
+	volumeTag := s.setupProvisionedVolume(c)
+	snapshot, err := s.State.AddVolumeSnapshot(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.RemoveVolumeSnapshot(snapshot.Id())
+	c.Assert(err, gc.ErrorMatches, `cannot remove volume snapshot "0/0": volume snapshot is alive`)
+
+	err = s.State.DestroyVolumeSnapshot(snapshot.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	snapshot, err = s.State.VolumeSnapshot(snapshot.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshot.Life(), gc.Equals, state.Dying)
+
+	// Destroying a Dying snapshot is a no-op.
+	err = s.State.DestroyVolumeSnapshot(snapshot.Id())
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.RemoveVolumeSnapshot(snapshot.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.VolumeSnapshot(snapshot.Id())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	// Destroying or removing a removed snapshot is a no-op.
+	err = s.State.DestroyVolumeSnapshot(snapshot.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.RemoveVolumeSnapshot(snapshot.Id())
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *VolumeSnapshotStateSuite) TestAddVolumeFromSnapshot(c *gc.C) {
+	volumeTag := s.setupProvisionedVolume(c)
+	snapshot, err := s.State.AddVolumeSnapshot(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetVolumeSnapshotInfo(snapshot.Id(), state.VolumeSnapshotInfo{
+		SnapshotId: "snap-123", Size: 2048,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	machineTag := names.NewMachineTag("0")
+	restoredTag, err := s.State.AddVolumeFromSnapshot(snapshot.Id(), machineTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(restoredTag, gc.Equals, names.NewVolumeTag("0/1"))
+
+	volume := s.volume(c, restoredTag)
+	params, ok := volume.Params()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(params, jc.DeepEquals, state.VolumeParams{
+		Pool:     "loop-pool",
+		Size:     2048,
+		Snapshot: "snap-123",
+	})
+	_, err = s.State.VolumeAttachment(machineTag, restoredTag)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *VolumeSnapshotStateSuite) TestAddVolumeFromSnapshotUnprovisioned(c *gc.C) {
+	volumeTag := s.setupProvisionedVolume(c)
+	snapshot, err := s.State.AddVolumeSnapshot(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.AddVolumeFromSnapshot(snapshot.Id(), names.NewMachineTag("0"))
+	c.Assert(err, gc.ErrorMatches, `cannot restore volume snapshot "0/0": volume snapshot "0/0" not provisioned`)
+}
+
+func (s *VolumeSnapshotStateSuite) TestAddVolumeFromSnapshotOtherMachine(c *gc.C) {
+	volumeTag := s.setupProvisionedVolume(c)
+	snapshot, err := s.State.AddVolumeSnapshot(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetVolumeSnapshotInfo(snapshot.Id(), state.VolumeSnapshotInfo{SnapshotId: "snap-123"})
+	c.Assert(err, jc.ErrorIsNil)
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.AddVolumeFromSnapshot(snapshot.Id(), machine.MachineTag())
+	c.Assert(err, gc.ErrorMatches, `cannot restore volume snapshot "0/0": snapshot of machine-scoped volume cannot be restored to another machine`)
+}
+
+func (s *VolumeSnapshotStateSuite) TestAddVolumeFromSnapshotDying(c *gc.C) {
+	volumeTag := s.setupProvisionedVolume(c)
+	snapshot, err := s.State.AddVolumeSnapshot(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetVolumeSnapshotInfo(snapshot.Id(), state.VolumeSnapshotInfo{SnapshotId: "snap-123"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.DestroyVolumeSnapshot(snapshot.Id())
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.AddVolumeFromSnapshot(snapshot.Id(), names.NewMachineTag("0"))
+	c.Assert(err, gc.ErrorMatches, `cannot restore volume snapshot "0/0": volume snapshot is not alive`)
+}
+
+func (s *VolumeSnapshotStateSuite) TestWatchMachineVolumeSnapshots(c *gc.C) {
+	volumeTag := s.setupProvisionedVolume(c)
+	_, err := s.State.AddVolumeSnapshot(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	w := s.State.WatchMachineVolumeSnapshots(names.NewMachineTag("0"))
+	defer testing.AssertStop(c, w)
+	wc := testing.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChangeInSingleEvent("0/0") // initial
+	wc.AssertNoChange()
+
+	_, err = s.State.AddVolumeSnapshot(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent("0/1")
+	wc.AssertNoChange()
+
+	err = s.State.DestroyVolumeSnapshot("0/0")
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent("0/0")
+	wc.AssertNoChange()
+
+	// Snapshots of other machines' volumes are not reported.
+	other := s.State.WatchMachineVolumeSnapshots(names.NewMachineTag("1"))
+	defer testing.AssertStop(c, other)
+	otherC := testing.NewStringsWatcherC(c, s.State, other)
+	otherC.AssertChangeInSingleEvent()
+	otherC.AssertNoChange()
+}
+
+func (s *VolumeSnapshotStateSuite) TestWatchEnvironVolumeSnapshots(c *gc.C) {
+	w := s.State.WatchEnvironVolumeSnapshots()
+	defer testing.AssertStop(c, w)
+	wc := testing.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChangeInSingleEvent() // initial
+	wc.AssertNoChange()
+
+	// Snapshots of machine-scoped volumes are not reported.
+	volumeTag := s.setupProvisionedVolume(c)
+	_, err := s.State.AddVolumeSnapshot(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+}
@@ -200,6 +200,28 @@ func (st *State) watchEnvironMachineStorage(collection string) StringsWatcher {
 	return newLifecycleWatcher(st, collection, members, filter, nil)
 }
 
+// WatchEnvironVolumeSnapshots returns a StringsWatcher that notifies of
+// changes to the lifecycles of all snapshots of environment-scoped volumes.
+func (st *State) WatchEnvironVolumeSnapshots() StringsWatcher {
+	return st.watchEnvironMachineStorage(volumeSnapshotsC)
+}
+
+// WatchEnvironVolumeResizes returns a StringsWatcher that notifies of
+// requests to resize environment-scoped volumes, reporting the IDs of
+// the volumes.
+func (st *State) WatchEnvironVolumeResizes() StringsWatcher {
+	pattern := fmt.Sprintf("^%s$", st.docID(names.NumberSnippet))
+	members := bson.D{{"_id", bson.D{{"$regex", pattern}}}}
+	filter := func(id interface{}) bool {
+		k, err := st.strictLocalID(id.(string))
+		if err != nil {
+			return false
+		}
+		return !strings.Contains(k, "/")
+	}
+	return newCollectionWatcher(st, volumeResizesC, members, filter)
+}
+
 // WatchMachineVolumes returns a StringsWatcher that notifies of changes to
 // the lifecycles of all volumes scoped to the specified machine.
 func (st *State) WatchMachineVolumes(m names.MachineTag) StringsWatcher {
@@ -212,6 +234,30 @@ func (st *State) WatchMachineFilesystems(m names.MachineTag) StringsWatcher {
 	return st.watchMachineStorage(m, filesystemsC)
 }
 
+// WatchMachineVolumeSnapshots returns a StringsWatcher that notifies of
+// changes to the lifecycles of all snapshots of volumes scoped to the
+// specified machine.
+func (st *State) WatchMachineVolumeSnapshots(m names.MachineTag) StringsWatcher {
+	return st.watchMachineStorage(m, volumeSnapshotsC)
+}
+
+// WatchMachineVolumeResizes returns a StringsWatcher that notifies of
+// requests to resize volumes scoped to the specified machine, reporting
+// the IDs of the volumes.
+func (st *State) WatchMachineVolumeResizes(m names.MachineTag) StringsWatcher {
+	pattern := fmt.Sprintf("^%s/%s$", st.docID(m.Id()), names.NumberSnippet)
+	members := bson.D{{"_id", bson.D{{"$regex", pattern}}}}
+	prefix := m.Id() + "/"
+	filter := func(id interface{}) bool {
+		k, err := st.strictLocalID(id.(string))
+		if err != nil {
+			return false
+		}
+		return strings.HasPrefix(k, prefix)
+	}
+	return newCollectionWatcher(st, volumeResizesC, members, filter)
+}
+
 func (st *State) watchMachineStorage(m names.MachineTag, collection string) StringsWatcher {
 	pattern := fmt.Sprintf("^%s/%s$", st.docID(m.Id()), names.NumberSnippet)
 	members := bson.D{{"_id", bson.D{{"$regex", pattern}}}}
@@ -550,6 +596,98 @@ func (w *lifecycleWatcher) loop() error {
 	}
 }
 
+// collectionWatcher notifies of any change to the documents in a
+// collection, reporting their local IDs. Unlike lifecycleWatcher, it
+// reports documents that are updated without changing their life,
+// so it is used for documents that have no lifecycle.
+type collectionWatcher struct {
+	commonWatcher
+	coll     func() (stateCollection, func())
+	collName string
+	members  bson.D
+	filter   func(key interface{}) bool
+	out      chan []string
+}
+
+func newCollectionWatcher(
+	st *State,
+	collName string,
+	members bson.D,
+	filter func(key interface{}) bool,
+) StringsWatcher {
+	w := &collectionWatcher{
+		commonWatcher: commonWatcher{st: st},
+		coll:          collFactory(st, collName),
+		collName:      collName,
+		members:       members,
+		filter:        filter,
+		out:           make(chan []string),
+	}
+	go func() {
+		defer w.tomb.Done()
+		defer close(w.out)
+		w.tomb.Kill(w.loop())
+	}()
+	return w
+}
+
+// Changes returns the event channel for the collectionWatcher.
+func (w *collectionWatcher) Changes() <-chan []string {
+	return w.out
+}
+
+func (w *collectionWatcher) initial() (set.Strings, error) {
+	coll, closer := w.coll()
+	defer closer()
+
+	ids := make(set.Strings)
+	var doc struct {
+		Id string `bson:"_id"`
+	}
+	iter := coll.Find(w.members).Select(bson.D{{"_id", 1}}).Iter()
+	for iter.Next(&doc) {
+		ids.Add(w.st.localID(doc.Id))
+	}
+	return ids, iter.Close()
+}
+
+func (w *collectionWatcher) loop() error {
+	in := make(chan watcher.Change)
+	w.st.watcher.WatchCollectionWithFilter(w.collName, in, w.filter)
+	defer w.st.watcher.UnwatchCollection(w.collName, in)
+	ids, err := w.initial()
+	if err != nil {
+		return err
+	}
+	out := w.out
+	for {
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case <-w.st.watcher.Dead():
+			return stateWatcherDeadError(w.st.watcher.Err())
+		case ch := <-in:
+			updates, ok := collect(ch, in, w.tomb.Dying())
+			if !ok {
+				return tomb.ErrDying
+			}
+			for docID := range updates {
+				id, ok := docID.(string)
+				if !ok {
+					return errors.Errorf("id is not of type string, got %T", docID)
+				}
+				ids.Add(w.st.localID(id))
+			}
+			if !ids.IsEmpty() {
+				out = w.out
+			}
+		case out <- ids.Values():
+			ids = make(set.Strings)
+			out = nil
+		}
+	}
+}
+
 // minUnitsWatcher notifies about MinUnits changes of the services requiring
 // a minimum number of units to be alive. The first event returned by the
 // watcher is the set of service names requiring a minimum number of units.
@@ -1417,6 +1555,12 @@ func (st *State) WatchStorageAttachment(s names.StorageTag, u names.UnitTag) Not
 	return newEntityWatcher(st, storageAttachmentsC, st.docID(id))
 }
 
+// WatchVolume returns a watcher for observing changes to a volume,
+// such as the volume being resized.
+func (st *State) WatchVolume(v names.VolumeTag) NotifyWatcher {
+	return newEntityWatcher(st, volumesC, st.docID(v.Id()))
+}
+
 // WatchVolumeAttachment returns a watcher for observing changes
 // to a volume attachment.
 func (st *State) WatchVolumeAttachment(m names.MachineTag, v names.VolumeTag) NotifyWatcher {
@@ -63,8 +63,9 @@ type Provider interface {
 }
 
 // VolumeSource provides an interface for creating, destroying, describing,
-// attaching and detaching volumes in the environment. A VolumeSource is
-// configured in a particular way, and corresponds to a storage "pool".
+// attaching, detaching, resizing and snapshotting volumes in the
+// environment. A VolumeSource is configured in a particular way, and
+// corresponds to a storage "pool".
 type VolumeSource interface {
 	// CreateVolumes creates volumes with the specified parameters. If the
 	// volumes are initially attached, then CreateVolumes returns
@@ -102,6 +103,30 @@ type VolumeSource interface {
 	// are detachable, and reject attempts to attach/detach on
 	// that basis.
 	DetachVolumes(params []VolumeAttachmentParams) error
+
+	// ResizeVolumes grows the volumes with the specified parameters,
+	// while they remain attached. ResizeVolumes returns the new size
+	// of each volume in MiB, which is at least the requested size, or
+	// an error for each volume that could not be resized.
+	//
+	// Volumes must never be shrunk. If the storage provider does not
+	// support resizing volumes, ResizeVolumes must return errors
+	// satisfying errors.IsNotSupported.
+	ResizeVolumes(params []VolumeResizeParams) ([]uint64, []error)
+
+	// CreateVolumeSnapshots creates point-in-time snapshots of volumes
+	// with the specified parameters. A volume may be created from a
+	// snapshot by specifying the snapshot's provider ID in the volume
+	// creation parameters.
+	CreateVolumeSnapshots(params []VolumeSnapshotParams) ([]VolumeSnapshot, error)
+
+	// ListVolumeSnapshots returns the properties of the snapshots of the
+	// volume with the specified provider volume ID.
+	ListVolumeSnapshots(volumeId string) ([]VolumeSnapshotInfo, error)
+
+	// DestroyVolumeSnapshots destroys the snapshots with the specified
+	// provider snapshot IDs.
+	DestroyVolumeSnapshots(snapshotIds []string) []error
 }
 
 // FilesystemSource provides an interface for creating, destroying and
@@ -115,6 +140,14 @@ type FilesystemSource interface {
 	// CreateFilesystems creates filesystems with the specified size, in MiB.
 	CreateFilesystems(params []FilesystemParams) ([]Filesystem, error)
 
+	// DescribeFilesystems returns the properties of the filesystems with
+	// the specified provider filesystem IDs.
+	DescribeFilesystems(filesystemIds []string) ([]FilesystemInfo, error)
+
+	// DestroyFilesystems destroys the filesystems with the specified
+	// provider filesystem IDs.
+	DestroyFilesystems(filesystemIds []string) []error
+
 	// AttachFilesystems attaches filesystems to machines.
 	//
 	// AttachFilesystems must be idempotent; it may be called even if
@@ -167,6 +200,12 @@ type VolumeParams struct {
 	// once the instance is created there are still unprovisioned volumes,
 	// the dynamic storage provisioner will take care of creating them.
 	Attachment *VolumeAttachmentParams
+
+	// Snapshot is the provider-supplied ID of the snapshot that the
+	// volume should be created from, or empty if the volume should be
+	// created empty. If Snapshot is set, Size will be at least the size
+	// of the snapshot.
+	Snapshot string
 }
 
 // IsPersistent returns true if the params has persistent set to true.
@@ -189,6 +228,47 @@ type VolumeAttachmentParams struct {
 	VolumeId string
 }
 
+// VolumeResizeParams is a set of parameters for resizing a volume.
+type VolumeResizeParams struct {
+	// Volume is the unique tag assigned by Juju for the volume that
+	// should be resized.
+	Volume names.VolumeTag
+
+	// VolumeId is the unique provider-supplied ID for the volume that
+	// should be resized.
+	VolumeId string
+
+	// Provider is the name of the storage provider that is to be used to
+	// resize the volume.
+	Provider ProviderType
+
+	// Size is the minimum size of the volume after resizing, in MiB.
+	// Size is never less than the volume's current size.
+	Size uint64
+}
+
+// VolumeSnapshotParams is a set of parameters for volume snapshot creation.
+type VolumeSnapshotParams struct {
+	// Snapshot is a unique ID assigned by Juju for the requested snapshot.
+	Snapshot string
+
+	// Volume is the unique tag assigned by Juju for the volume that
+	// should be snapshotted.
+	Volume names.VolumeTag
+
+	// VolumeId is the unique provider-supplied ID for the volume that
+	// should be snapshotted.
+	VolumeId string
+
+	// Provider is the name of the storage provider that is to be used to
+	// create the snapshot.
+	Provider ProviderType
+
+	// ResourceTags is a set of tags to set on the created snapshot, if the
+	// storage provider supports tags.
+	ResourceTags map[string]string
+}
+
 // AttachmentParams describes the parameters for attaching a volume or
 // filesystem to a machine.
 type AttachmentParams struct {
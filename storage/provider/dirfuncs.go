@@ -21,6 +21,10 @@ type dirFuncs interface {
 	calculateSize(path string) (sizeInMib uint64, _ error)
 	symlink(oldpath, newpath string) error
 
+	// removeAll removes the path and anything it contains.
+	// Removing a path that does not exist is not an error.
+	removeAll(path string) error
+
 	// bindMount remounts the directory "source" at "target",
 	// so that the source tree is available in both locations.
 	// If "target" already refers to "source" in this manner,
@@ -62,6 +66,10 @@ func (*osDirFuncs) symlink(oldpath, newpath string) error {
 	return os.Symlink(oldpath, newpath)
 }
 
+func (*osDirFuncs) removeAll(path string) error {
+	return os.RemoveAll(path)
+}
+
 func (o *osDirFuncs) calculateSize(path string) (sizeInMib uint64, _ error) {
 	output, err := df(o.run, path, "size")
 	if err != nil {
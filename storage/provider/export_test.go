@@ -56,6 +56,15 @@ func (m *MockDirFuncs) mkDirAll(path string, perm os.FileMode) error {
 	return nil
 }
 
+func (m *MockDirFuncs) removeAll(path string) error {
+	for _, dir := range m.Dirs.Values() {
+		if dir == path || strings.HasPrefix(dir, path+"/") {
+			m.Dirs.Remove(dir)
+		}
+	}
+	return nil
+}
+
 type MockFileInfo struct {
 	isDir bool
 }
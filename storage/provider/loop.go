@@ -120,6 +120,17 @@ func (lvs *loopVolumeSource) createVolume(params storage.VolumeParams) (storage.
 	if err := ensureDir(lvs.dirFuncs, filepath.Dir(loopFilePath)); err != nil {
 		return storage.Volume{}, errors.Trace(err)
 	}
+	if params.Snapshot != "" {
+		// Start with a copy of the snapshot's backing file; the
+		// file is then grown to the requested size if necessary.
+		snapshotFilePath, err := lvs.snapshotFilePath(params.Snapshot)
+		if err != nil {
+			return storage.Volume{}, errors.Trace(err)
+		}
+		if err := copyBlockFile(lvs.run, snapshotFilePath, loopFilePath); err != nil {
+			return storage.Volume{}, errors.Annotate(err, "could not restore snapshot")
+		}
+	}
 	if err := createBlockFile(lvs.run, loopFilePath, params.Size); err != nil {
 		return storage.Volume{}, errors.Annotate(err, "could not create block file")
 	}
@@ -136,6 +147,16 @@ func (lvs *loopVolumeSource) volumeFilePath(volumeId string) string {
 	return filepath.Join(lvs.storageDir, volumeId)
 }
 
+// snapshotFilePath returns the path of the backing file of the loop
+// volume snapshot with the specified ID. Snapshots are kept in their
+// own directory, so they are never mistaken for volumes.
+func (lvs *loopVolumeSource) snapshotFilePath(snapshotId string) (string, error) {
+	if !strings.Contains(snapshotId, loopSnapshotInfix) || strings.ContainsAny(snapshotId, `/\`) {
+		return "", errors.Errorf("invalid loop snapshot ID %q", snapshotId)
+	}
+	return filepath.Join(lvs.storageDir, loopSnapshotsDir, snapshotId), nil
+}
+
 // DescribeVolumes is defined on the VolumeSource interface.
 func (lvs *loopVolumeSource) DescribeVolumes(volumeIds []string) ([]storage.VolumeInfo, error) {
 	// TODO(axw) implement this when we need it.
@@ -220,8 +241,169 @@ func (lvs *loopVolumeSource) DetachVolumes([]storage.VolumeAttachmentParams) err
 	return errors.NotSupportedf("detaching loop devices")
 }
 
+// ResizeVolumes is defined on the VolumeSource interface.
+func (lvs *loopVolumeSource) ResizeVolumes(args []storage.VolumeResizeParams) ([]uint64, []error) {
+	sizes := make([]uint64, len(args))
+	errs := make([]error, len(args))
+	for i, arg := range args {
+		if err := lvs.resizeVolume(arg); err != nil {
+			errs[i] = errors.Annotatef(err, "resizing volume %v", arg.Volume.Id())
+			continue
+		}
+		sizes[i] = arg.Size
+	}
+	return sizes, errs
+}
+
+func (lvs *loopVolumeSource) resizeVolume(arg storage.VolumeResizeParams) error {
+	if _, err := names.ParseVolumeTag(arg.VolumeId); err != nil {
+		return errors.Errorf("invalid loop volume ID %q", arg.VolumeId)
+	}
+	loopFilePath := lvs.volumeFilePath(arg.VolumeId)
+	fi, err := os.Stat(loopFilePath)
+	if err != nil {
+		return errors.Annotate(err, "locating loop backing file")
+	}
+	if size := mibFromBytes(fi.Size()); size > arg.Size {
+		return errors.Errorf("cannot shrink volume from %dMiB to %dMiB", size, arg.Size)
+	}
+	if err := createBlockFile(lvs.run, loopFilePath, arg.Size); err != nil {
+		return errors.Trace(err)
+	}
+	// Any attached loop devices must be told to pick up the
+	// new size of the backing file.
+	deviceNames, err := associatedLoopDevices(lvs.run, loopFilePath)
+	if err != nil {
+		return errors.Annotate(err, "locating loop device")
+	}
+	for _, deviceName := range deviceNames {
+		if err := refreshLoopDevice(lvs.run, deviceName); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// loopSnapshotsDir is the directory, relative to the storage directory,
+// that holds the backing files of loop volume snapshots.
+const loopSnapshotsDir = "snapshots"
+
+// loopSnapshotInfix separates the volume ID from the rest of a loop
+// volume snapshot's ID.
+const loopSnapshotInfix = "-snapshot-"
+
+// CreateVolumeSnapshots is defined on the VolumeSource interface.
+func (lvs *loopVolumeSource) CreateVolumeSnapshots(args []storage.VolumeSnapshotParams) ([]storage.VolumeSnapshot, error) {
+	snapshots := make([]storage.VolumeSnapshot, len(args))
+	for i, arg := range args {
+		snapshot, err := lvs.createVolumeSnapshot(arg)
+		if err != nil {
+			return nil, errors.Annotatef(err, "creating snapshot of volume %v", arg.Volume.Id())
+		}
+		snapshots[i] = snapshot
+	}
+	return snapshots, nil
+}
+
+func (lvs *loopVolumeSource) createVolumeSnapshot(arg storage.VolumeSnapshotParams) (storage.VolumeSnapshot, error) {
+	if _, err := names.ParseVolumeTag(arg.VolumeId); err != nil {
+		return storage.VolumeSnapshot{}, errors.Errorf("invalid loop volume ID %q", arg.VolumeId)
+	}
+	loopFilePath := lvs.volumeFilePath(arg.VolumeId)
+	fi, err := os.Stat(loopFilePath)
+	if err != nil {
+		return storage.VolumeSnapshot{}, errors.Annotate(err, "locating loop backing file")
+	}
+	// Juju snapshot IDs of machine-scoped snapshots contain a "/",
+	// which may not appear in a file name.
+	snapshotId := arg.VolumeId + loopSnapshotInfix + strings.Replace(arg.Snapshot, "/", "-", -1)
+	snapshotFilePath, err := lvs.snapshotFilePath(snapshotId)
+	if err != nil {
+		return storage.VolumeSnapshot{}, errors.Trace(err)
+	}
+	if err := ensureDir(lvs.dirFuncs, filepath.Dir(snapshotFilePath)); err != nil {
+		return storage.VolumeSnapshot{}, errors.Trace(err)
+	}
+	if err := copyBlockFile(lvs.run, loopFilePath, snapshotFilePath); err != nil {
+		return storage.VolumeSnapshot{}, errors.Trace(err)
+	}
+	return storage.VolumeSnapshot{
+		arg.Snapshot,
+		storage.VolumeSnapshotInfo{
+			SnapshotId: snapshotId,
+			VolumeId:   arg.VolumeId,
+			Size:       mibFromBytes(fi.Size()),
+		},
+	}, nil
+}
+
+// ListVolumeSnapshots is defined on the VolumeSource interface.
+func (lvs *loopVolumeSource) ListVolumeSnapshots(volumeId string) ([]storage.VolumeSnapshotInfo, error) {
+	if _, err := names.ParseVolumeTag(volumeId); err != nil {
+		return nil, errors.Errorf("invalid loop volume ID %q", volumeId)
+	}
+	pattern := filepath.Join(lvs.storageDir, loopSnapshotsDir, volumeId+loopSnapshotInfix+"*")
+	paths, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var snapshots []storage.VolumeSnapshotInfo
+	for _, path := range paths {
+		fi, err := os.Stat(path)
+		if err != nil {
+			return nil, errors.Annotate(err, "reading snapshot backing file")
+		}
+		snapshots = append(snapshots, storage.VolumeSnapshotInfo{
+			SnapshotId: filepath.Base(path),
+			VolumeId:   volumeId,
+			Size:       mibFromBytes(fi.Size()),
+		})
+	}
+	return snapshots, nil
+}
+
+// DestroyVolumeSnapshots is defined on the VolumeSource interface.
+func (lvs *loopVolumeSource) DestroyVolumeSnapshots(snapshotIds []string) []error {
+	results := make([]error, len(snapshotIds))
+	for i, snapshotId := range snapshotIds {
+		if err := lvs.destroyVolumeSnapshot(snapshotId); err != nil {
+			results[i] = errors.Annotatef(err, "destroying snapshot %q", snapshotId)
+		}
+	}
+	return results
+}
+
+func (lvs *loopVolumeSource) destroyVolumeSnapshot(snapshotId string) error {
+	snapshotFilePath, err := lvs.snapshotFilePath(snapshotId)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := os.Remove(snapshotFilePath); err != nil && !os.IsNotExist(err) {
+		return errors.Annotate(err, "removing snapshot backing file")
+	}
+	return nil
+}
+
+// mibFromBytes returns the specified size in bytes, rounded up to
+// the nearest mebibyte.
+func mibFromBytes(sizeInBytes int64) uint64 {
+	const mib = 1024 * 1024
+	return uint64((sizeInBytes + mib - 1) / mib)
+}
+
+// copyBlockFile copies the loop backing file at the source path to
+// the target path, preserving any holes in the file.
+func copyBlockFile(run runCommandFunc, source, target string) error {
+	_, err := run("cp", "--sparse=always", source, target)
+	if err != nil {
+		return errors.Annotatef(err, "copying loop backing file %q", source)
+	}
+	return nil
+}
+
 // createBlockFile creates a file at the specified path, with the
-// given size in mebibytes.
+// given size in mebibytes. If the file already exists, it is grown
+// to the given size.
 func createBlockFile(run runCommandFunc, filePath string, sizeInMiB uint64) error {
 	// fallocate will reserve the space without actually writing to it.
 	_, err := run("fallocate", "-l", fmt.Sprintf("%dMiB", sizeInMiB), filePath)
@@ -270,6 +452,16 @@ func detachLoopDevice(run runCommandFunc, deviceName string) error {
 	return err
 }
 
+// refreshLoopDevice updates the loop device with the specified name
+// to reflect the current size of its backing file.
+func refreshLoopDevice(run runCommandFunc, deviceName string) error {
+	_, err := run("losetup", "-c", path.Join("/dev", deviceName))
+	if err != nil {
+		return errors.Annotatef(err, "refreshing capacity of loop device %q", deviceName)
+	}
+	return nil
+}
+
 // associatedLoopDevices returns the device names of the loop devices
 // associated with the specified file path.
 func associatedLoopDevices(run runCommandFunc, filePath string) ([]string, error) {
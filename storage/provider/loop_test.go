@@ -5,6 +5,7 @@ package provider_test
 
 import (
 	"io/ioutil"
+	"os"
 	"path/filepath"
 
 	"github.com/juju/errors"
@@ -229,3 +230,162 @@ func (s *loopSuite) TestDetachVolumes(c *gc.C) {
 	err := source.DetachVolumes(nil)
 	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
 }
+
+func (s *loopSuite) TestResizeVolumes(c *gc.C) {
+	source, _ := s.loopVolumeSource(c)
+	fileName := filepath.Join(s.storageDir, "volume-0")
+	err := ioutil.WriteFile(fileName, make([]byte, 2*1024*1024), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	s.commands.expect("fallocate", "-l", "4MiB", fileName)
+	cmd := s.commands.expect("losetup", "-j", fileName)
+	cmd.respond("/dev/loop0: foo\n", nil)
+	s.commands.expect("losetup", "-c", "/dev/loop0")
+
+	sizes, errs := source.ResizeVolumes([]storage.VolumeResizeParams{{
+		Volume:   names.NewVolumeTag("0"),
+		VolumeId: "volume-0",
+		Size:     4,
+	}})
+	c.Assert(errs, gc.HasLen, 1)
+	c.Assert(errs[0], jc.ErrorIsNil)
+	c.Assert(sizes, jc.DeepEquals, []uint64{4})
+}
+
+func (s *loopSuite) TestResizeVolumesShrink(c *gc.C) {
+	source, _ := s.loopVolumeSource(c)
+	fileName := filepath.Join(s.storageDir, "volume-0")
+	err := ioutil.WriteFile(fileName, make([]byte, 2*1024*1024), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, errs := source.ResizeVolumes([]storage.VolumeResizeParams{{
+		Volume:   names.NewVolumeTag("0"),
+		VolumeId: "volume-0",
+		Size:     1,
+	}, {
+		Volume:   names.NewVolumeTag("1"),
+		VolumeId: "../volume-0",
+		Size:     1,
+	}})
+	c.Assert(errs, gc.HasLen, 2)
+	c.Assert(errs[0], gc.ErrorMatches, "resizing volume 0: cannot shrink volume from 2MiB to 1MiB")
+	c.Assert(errs[1], gc.ErrorMatches, `resizing volume 1: invalid loop volume ID "\.\./volume-0"`)
+}
+
+func (s *loopSuite) TestCreateVolumesFromSnapshot(c *gc.C) {
+	source, _ := s.loopVolumeSource(c)
+	s.commands.expect("cp", "--sparse=always",
+		filepath.Join(s.storageDir, "snapshots", "volume-0-snapshot-0"),
+		filepath.Join(s.storageDir, "volume-1"),
+	)
+	s.commands.expect("fallocate", "-l", "4MiB", filepath.Join(s.storageDir, "volume-1"))
+
+	volumes, _, err := source.CreateVolumes([]storage.VolumeParams{{
+		Tag:      names.NewVolumeTag("1"),
+		Size:     4,
+		Snapshot: "volume-0-snapshot-0",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(volumes, jc.DeepEquals, []storage.Volume{{
+		names.NewVolumeTag("1"),
+		storage.VolumeInfo{
+			VolumeId: "volume-1",
+			Size:     4,
+		},
+	}})
+}
+
+func (s *loopSuite) TestCreateVolumesFromInvalidSnapshot(c *gc.C) {
+	source, _ := s.loopVolumeSource(c)
+	_, _, err := source.CreateVolumes([]storage.VolumeParams{{
+		Tag:      names.NewVolumeTag("1"),
+		Size:     4,
+		Snapshot: "../volume-0",
+	}})
+	c.Assert(err, gc.ErrorMatches, `creating volume: invalid loop snapshot ID "\.\./volume-0"`)
+}
+
+func (s *loopSuite) TestCreateVolumeSnapshots(c *gc.C) {
+	source, dirFuncs := s.loopVolumeSource(c)
+	err := ioutil.WriteFile(filepath.Join(s.storageDir, "volume-0"), make([]byte, 2*1024*1024), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	s.commands.expect("cp", "--sparse=always",
+		filepath.Join(s.storageDir, "volume-0"),
+		filepath.Join(s.storageDir, "snapshots", "volume-0-snapshot-0-1"),
+	)
+
+	snapshots, err := source.CreateVolumeSnapshots([]storage.VolumeSnapshotParams{{
+		Snapshot: "0/1",
+		Volume:   names.NewVolumeTag("0"),
+		VolumeId: "volume-0",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshots, jc.DeepEquals, []storage.VolumeSnapshot{{
+		"0/1",
+		storage.VolumeSnapshotInfo{
+			SnapshotId: "volume-0-snapshot-0-1",
+			VolumeId:   "volume-0",
+			Size:       2,
+		},
+	}})
+	c.Assert(dirFuncs.Dirs.Contains(filepath.Join(s.storageDir, "snapshots")), jc.IsTrue)
+}
+
+func (s *loopSuite) TestCreateVolumeSnapshotsMissingVolume(c *gc.C) {
+	source, _ := s.loopVolumeSource(c)
+	_, err := source.CreateVolumeSnapshots([]storage.VolumeSnapshotParams{{
+		Snapshot: "0",
+		Volume:   names.NewVolumeTag("0"),
+		VolumeId: "volume-0",
+	}})
+	c.Assert(err, gc.ErrorMatches, "creating snapshot of volume 0: locating loop backing file: .*")
+}
+
+func (s *loopSuite) TestListVolumeSnapshots(c *gc.C) {
+	source, _ := s.loopVolumeSource(c)
+	snapshotsDir := filepath.Join(s.storageDir, "snapshots")
+	err := os.MkdirAll(snapshotsDir, 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	for name, size := range map[string]int{
+		"volume-0-snapshot-0": 1024 * 1024,
+		"volume-0-snapshot-1": 1024*1024 + 1,
+		"volume-1-snapshot-2": 1024 * 1024,
+	} {
+		err := ioutil.WriteFile(filepath.Join(snapshotsDir, name), make([]byte, size), 0644)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	snapshots, err := source.ListVolumeSnapshots("volume-0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshots, jc.DeepEquals, []storage.VolumeSnapshotInfo{{
+		SnapshotId: "volume-0-snapshot-0",
+		VolumeId:   "volume-0",
+		Size:       1,
+	}, {
+		SnapshotId: "volume-0-snapshot-1",
+		VolumeId:   "volume-0",
+		Size:       2,
+	}})
+}
+
+func (s *loopSuite) TestDestroyVolumeSnapshots(c *gc.C) {
+	source, _ := s.loopVolumeSource(c)
+	snapshotsDir := filepath.Join(s.storageDir, "snapshots")
+	err := os.MkdirAll(snapshotsDir, 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	fileName := filepath.Join(snapshotsDir, "volume-0-snapshot-0")
+	err = ioutil.WriteFile(fileName, nil, 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	errs := source.DestroyVolumeSnapshots([]string{
+		"volume-0-snapshot-0",
+		"volume-0-snapshot-1",
+		"../volume-0",
+	})
+	c.Assert(errs, gc.HasLen, 3)
+	c.Assert(errs[0], jc.ErrorIsNil)
+	// Destroying a snapshot that does not exist is not an error.
+	c.Assert(errs[1], jc.ErrorIsNil)
+	c.Assert(errs[2], gc.ErrorMatches, `destroying snapshot "\.\./volume-0": invalid loop snapshot ID "\.\./volume-0"`)
+	_, err = os.Stat(fileName)
+	c.Assert(err, jc.Satisfies, os.IsNotExist)
+}
@@ -4,6 +4,7 @@
 package provider
 
 import (
+	"os"
 	"path"
 	"path/filepath"
 
@@ -97,6 +98,58 @@ func (s *managedFilesystemSource) createFilesystem(arg storage.FilesystemParams)
 	}, nil
 }
 
+// DescribeFilesystems is defined on storage.FilesystemSource.
+func (s *managedFilesystemSource) DescribeFilesystems(filesystemIds []string) ([]storage.FilesystemInfo, error) {
+	infos := make([]storage.FilesystemInfo, len(filesystemIds))
+	for i, filesystemId := range filesystemIds {
+		tag, err := names.ParseFilesystemTag(filesystemId)
+		if err != nil {
+			return nil, errors.Annotatef(err, "describing %q", filesystemId)
+		}
+		filesystem, ok := s.filesystems[tag]
+		if !ok {
+			return nil, errors.NotFoundf("filesystem %v", tag.Id())
+		}
+		infos[i] = filesystem.FilesystemInfo
+	}
+	return infos, nil
+}
+
+// DestroyFilesystems is defined on storage.FilesystemSource.
+func (s *managedFilesystemSource) DestroyFilesystems(filesystemIds []string) []error {
+	errs := make([]error, len(filesystemIds))
+	for i, filesystemId := range filesystemIds {
+		if err := s.destroyFilesystem(filesystemId); err != nil {
+			errs[i] = errors.Annotatef(err, "destroying %q", filesystemId)
+		}
+	}
+	return errs
+}
+
+func (s *managedFilesystemSource) destroyFilesystem(filesystemId string) error {
+	tag, err := names.ParseFilesystemTag(filesystemId)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// Managed filesystems live on their backing volumes. The filesystem
+	// was unmounted when it was detached; wipe its signature from the
+	// volume, if the volume is still attached, so that the filesystem
+	// is not mounted again should the volume be reused.
+	filesystem, ok := s.filesystems[tag]
+	if !ok {
+		return nil
+	}
+	blockDevice, ok := s.volumeBlockDevices[filesystem.Volume]
+	if !ok {
+		return nil
+	}
+	devicePath := s.devicePath(blockDevice)
+	if _, err := s.run("wipefs", "-a", devicePath); err != nil {
+		return errors.Annotatef(err, "wiping filesystem on %q", devicePath)
+	}
+	return nil
+}
+
 func (s *managedFilesystemSource) devicePath(dev storage.BlockDevice) string {
 	if dev.DeviceName != "" {
 		return path.Join("/dev", dev.DeviceName)
@@ -142,8 +195,12 @@ func (s *managedFilesystemSource) attachFilesystem(arg storage.FilesystemAttachm
 
 // DetachFilesystems is defined on storage.FilesystemSource.
 func (s *managedFilesystemSource) DetachFilesystems(args []storage.FilesystemAttachmentParams) error {
-	// TODO(axw)
-	return errors.NotImplementedf("DetachFilesystems")
+	for _, arg := range args {
+		if err := unmountFilesystem(s.run, s.dirFuncs, arg.Path); err != nil {
+			return errors.Annotatef(err, "detaching filesystem %s", arg.Filesystem.Id())
+		}
+	}
+	return nil
 }
 
 func createFilesystem(run runCommandFunc, devicePath string) error {
@@ -187,3 +244,34 @@ func mountFilesystem(run runCommandFunc, dirFuncs dirFuncs, devicePath, mountPoi
 	logger.Infof("mounted filesystem on %q at %q", devicePath, mountPoint)
 	return nil
 }
+
+func unmountFilesystem(run runCommandFunc, dirFuncs dirFuncs, mountPoint string) error {
+	if mountPoint == "" {
+		return errNoMountPoint
+	}
+	logger.Debugf("attempting to unmount filesystem at %q", mountPoint)
+	if _, err := dirFuncs.lstat(mountPoint); os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	mountPointParent := filepath.Dir(mountPoint)
+	parentSource, err := dirFuncs.mountPointSource(mountPointParent)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	source, err := dirFuncs.mountPointSource(mountPoint)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if source == parentSource {
+		// Not mounted.
+		logger.Debugf("filesystem at %q is not mounted", mountPoint)
+		return nil
+	}
+	if _, err := run("umount", mountPoint); err != nil {
+		return errors.Annotate(err, "umount failed")
+	}
+	logger.Infof("unmounted filesystem at %q", mountPoint)
+	return nil
+}
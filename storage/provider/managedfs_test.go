@@ -164,7 +164,111 @@ func (s *managedfsSuite) testAttachFilesystems(c *gc.C, readOnly, reattach bool)
 }
 
 func (s *managedfsSuite) TestDetachFilesystems(c *gc.C) {
+	s.testDetachFilesystems(c, true)
+}
+
+func (s *managedfsSuite) TestDetachFilesystemsUnmounted(c *gc.C) {
+	s.testDetachFilesystems(c, false)
+}
+
+func (s *managedfsSuite) testDetachFilesystems(c *gc.C, mounted bool) {
+	const testMountPoint = "/in/the/place"
+
+	source := s.initSource(c)
+	s.dirFuncs.Dirs.Add(testMountPoint)
+	cmd := s.commands.expect("df", "--output=source", filepath.Dir(testMountPoint))
+	cmd.respond("headers\n/same/as/rootfs", nil)
+	cmd = s.commands.expect("df", "--output=source", testMountPoint)
+	if mounted {
+		cmd.respond("headers\n/different/to/rootfs", nil)
+		s.commands.expect("umount", testMountPoint)
+	} else {
+		cmd.respond("headers\n/same/as/rootfs", nil)
+	}
+
+	err := source.DetachFilesystems([]storage.FilesystemAttachmentParams{{
+		Filesystem:   names.NewFilesystemTag("0/0"),
+		FilesystemId: "filesystem-0-0",
+		AttachmentParams: storage.AttachmentParams{
+			Machine:    names.NewMachineTag("0"),
+			InstanceId: "inst-ance",
+		},
+		Path: testMountPoint,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *managedfsSuite) TestDetachFilesystemsMissingMountPoint(c *gc.C) {
 	source := s.initSource(c)
-	err := source.DetachFilesystems(nil)
-	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+	err := source.DetachFilesystems([]storage.FilesystemAttachmentParams{{
+		Filesystem: names.NewFilesystemTag("0/0"),
+		Path:       "/in/the/place",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *managedfsSuite) TestDescribeFilesystems(c *gc.C) {
+	source := s.initSource(c)
+	s.filesystems[names.NewFilesystemTag("0/0")] = storage.Filesystem{
+		Tag:    names.NewFilesystemTag("0/0"),
+		Volume: names.NewVolumeTag("0"),
+		FilesystemInfo: storage.FilesystemInfo{
+			FilesystemId: "filesystem-0-0",
+			Size:         2,
+		},
+	}
+	infos, err := source.DescribeFilesystems([]string{"filesystem-0-0"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(infos, jc.DeepEquals, []storage.FilesystemInfo{{
+		FilesystemId: "filesystem-0-0",
+		Size:         2,
+	}})
+
+	_, err = source.DescribeFilesystems([]string{"filesystem-0-1"})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *managedfsSuite) TestDestroyFilesystems(c *gc.C) {
+	source := s.initSource(c)
+	s.commands.expect("wipefs", "-a", "/dev/sda")
+
+	s.blockDevices[names.NewVolumeTag("0")] = storage.BlockDevice{
+		DeviceName: "sda",
+		HardwareId: "capncrunch",
+		Size:       2,
+	}
+	s.filesystems[names.NewFilesystemTag("0/0")] = storage.Filesystem{
+		Tag:    names.NewFilesystemTag("0/0"),
+		Volume: names.NewVolumeTag("0"),
+	}
+	s.filesystems[names.NewFilesystemTag("0/1")] = storage.Filesystem{
+		Tag:    names.NewFilesystemTag("0/1"),
+		Volume: names.NewVolumeTag("1"),
+	}
+
+	// The backing volume of filesystem 0/1 is not attached,
+	// and filesystem 0/2 is unknown; there is nothing to wipe.
+	errs := source.DestroyFilesystems([]string{
+		"filesystem-0-0", "filesystem-0-1", "filesystem-0-2", "0/0",
+	})
+	c.Assert(errs, gc.HasLen, 4)
+	c.Assert(errs[0], jc.ErrorIsNil)
+	c.Assert(errs[1], jc.ErrorIsNil)
+	c.Assert(errs[2], jc.ErrorIsNil)
+	c.Assert(errs[3], gc.ErrorMatches, `destroying "0/0": "0/0" is not a valid tag`)
+}
+
+func (s *managedfsSuite) TestDestroyFilesystemsWipeFails(c *gc.C) {
+	source := s.initSource(c)
+	cmd := s.commands.expect("wipefs", "-a", "/dev/sda")
+	cmd.respond("", errors.New("device busy"))
+
+	s.blockDevices[names.NewVolumeTag("0")] = storage.BlockDevice{DeviceName: "sda"}
+	s.filesystems[names.NewFilesystemTag("0/0")] = storage.Filesystem{
+		Tag:    names.NewFilesystemTag("0/0"),
+		Volume: names.NewVolumeTag("0"),
+	}
+	errs := source.DestroyFilesystems([]string{"filesystem-0-0"})
+	c.Assert(errs, gc.HasLen, 1)
+	c.Assert(errs[0], gc.ErrorMatches, `destroying "filesystem-0-0": wiping filesystem on "/dev/sda": device busy`)
 }
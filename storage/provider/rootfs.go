@@ -178,6 +178,77 @@ func (s *rootfsFilesystemSource) createFilesystem(params storage.FilesystemParam
 	return filesystem, nil
 }
 
+// DescribeFilesystems is defined on the FilesystemSource interface.
+func (s *rootfsFilesystemSource) DescribeFilesystems(filesystemIds []string) ([]storage.FilesystemInfo, error) {
+	infos := make([]storage.FilesystemInfo, len(filesystemIds))
+	for i, filesystemId := range filesystemIds {
+		info, err := s.describeFilesystem(filesystemId)
+		if err != nil {
+			return nil, errors.Annotatef(err, "describing %q", filesystemId)
+		}
+		infos[i] = info
+	}
+	return infos, nil
+}
+
+func (s *rootfsFilesystemSource) describeFilesystem(filesystemId string) (storage.FilesystemInfo, error) {
+	if !names.IsValidFilesystem(filesystemId) {
+		return storage.FilesystemInfo{}, errors.Errorf("invalid rootfs filesystem ID %q", filesystemId)
+	}
+	path := filepath.Join(s.storageDir, filesystemId)
+	if _, err := s.dirFuncs.lstat(path); os.IsNotExist(err) {
+		return storage.FilesystemInfo{}, errors.NotFoundf("filesystem directory %q", path)
+	} else if err != nil {
+		return storage.FilesystemInfo{}, errors.Trace(err)
+	}
+	sizeInMiB, err := s.dirFuncs.calculateSize(s.storageDir)
+	if err != nil {
+		return storage.FilesystemInfo{}, errors.Annotate(err, "getting size")
+	}
+	return storage.FilesystemInfo{
+		FilesystemId: filesystemId,
+		Size:         sizeInMiB,
+	}, nil
+}
+
+// DestroyFilesystems is defined on the FilesystemSource interface.
+func (s *rootfsFilesystemSource) DestroyFilesystems(filesystemIds []string) []error {
+	results := make([]error, len(filesystemIds))
+	for i, filesystemId := range filesystemIds {
+		if err := s.destroyFilesystem(filesystemId); err != nil {
+			results[i] = errors.Annotatef(err, "destroying %q", filesystemId)
+		}
+	}
+	return results
+}
+
+func (s *rootfsFilesystemSource) destroyFilesystem(filesystemId string) error {
+	if !names.IsValidFilesystem(filesystemId) {
+		return errors.Errorf("invalid rootfs filesystem ID %q", filesystemId)
+	}
+	// The filesystem is a directory in the storage directory. Any
+	// bind mount of the directory should have been removed when the
+	// filesystem was detached, but make sure it is gone before the
+	// directory is removed, or the contents would be removed from
+	// under the mount. Removing a directory that does not exist is
+	// not an error, so that destruction is idempotent.
+	tag := names.NewFilesystemTag(filesystemId)
+	target, err := readMountPoint(s.filesystemInfoFile(tag))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if target != "" {
+		if err := s.unmount(tag, target); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	path := filepath.Join(s.storageDir, filesystemId)
+	if err := s.dirFuncs.removeAll(path); err != nil {
+		return errors.Annotate(err, "removing filesystem directory")
+	}
+	return nil
+}
+
 // AttachFilesystems is defined on the FilesystemSource interface.
 func (s *rootfsFilesystemSource) AttachFilesystems(args []storage.FilesystemAttachmentParams) ([]storage.FilesystemAttachment, error) {
 	attachments := make([]storage.FilesystemAttachment, len(args))
@@ -222,9 +293,14 @@ func (s *rootfsFilesystemSource) mount(tag names.FilesystemTag, target string) e
 	}
 
 	mounted, err := s.tryBindMount(fsPath, target)
-	if mounted || err != nil {
+	if err != nil {
 		return errors.Trace(err)
 	}
+	if mounted {
+		// Record the target, so the bind mount can be removed
+		// if the filesystem is destroyed without being detached.
+		return writeMountPoint(s.filesystemInfoFile(tag), target)
+	}
 	// We couldn't bind-mount over the designated directory;
 	// carry on and check if it's on the same filesystem. If
 	// it is, and it's empty, then claim it as our own.
@@ -290,6 +366,44 @@ func (s *rootfsFilesystemSource) validateSameMountPoints(source, target string)
 
 // DetachFilesystems is defined on the FilesystemSource interface.
 func (s *rootfsFilesystemSource) DetachFilesystems(args []storage.FilesystemAttachmentParams) error {
-	// TODO(axw)
-	return errors.NotImplementedf("DetachFilesystems")
+	for _, arg := range args {
+		if arg.Path == "" {
+			return errors.Annotatef(errNoMountPoint, "detaching %s", names.ReadableString(arg.Filesystem))
+		}
+		if err := s.unmount(arg.Filesystem, arg.Path); err != nil {
+			return errors.Annotatef(err, "detaching %s", names.ReadableString(arg.Filesystem))
+		}
+	}
+	return nil
+}
+
+// unmount removes the bind mount of the filesystem with the specified
+// tag at the specified target, if there is one, and forgets the target.
+func (s *rootfsFilesystemSource) unmount(tag names.FilesystemTag, target string) error {
+	fsPath := filepath.Join(s.storageDir, tag.Id())
+	if target == fsPath {
+		return nil
+	}
+	if _, err := s.dirFuncs.lstat(target); err == nil {
+		source, err := s.dirFuncs.mountPointSource(target)
+		if err != nil {
+			return errors.Annotate(err, "getting target mount-point source")
+		}
+		if source == fsPath {
+			logger.Debugf("unmounting filesystem %q from %q", fsPath, target)
+			if _, err := s.run("umount", target); err != nil {
+				return errors.Annotate(err, "cannot unmount")
+			}
+		}
+	} else if !os.IsNotExist(err) {
+		return errors.Trace(err)
+	}
+	if err := os.Remove(s.filesystemInfoFile(tag)); err != nil && !os.IsNotExist(err) {
+		return errors.Annotate(err, "removing filesystem info")
+	}
+	return nil
+}
+
+func (s *rootfsFilesystemSource) filesystemInfoFile(tag names.FilesystemTag) string {
+	return filepath.Join(s.storageDir, tag.Id()+".info")
 }
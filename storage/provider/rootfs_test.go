@@ -320,3 +320,108 @@ func (s *rootfsSuite) TestAttachFilesystemsBindSameFSNonEmptyDirClaimed(c *gc.C)
 		},
 	}})
 }
+
+func (s *rootfsSuite) TestDescribeFilesystems(c *gc.C) {
+	source := s.rootfsFilesystemSource(c)
+	s.mockDirFuncs.Dirs.Add(filepath.Join(s.storageDir, "6"))
+	cmd := s.commands.expect("df", "--output=size", s.storageDir)
+	cmd.respond("1K-blocks\n2048", nil)
+
+	infos, err := source.DescribeFilesystems([]string{"6"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(infos, jc.DeepEquals, []storage.FilesystemInfo{{
+		FilesystemId: "6",
+		Size:         2,
+	}})
+}
+
+func (s *rootfsSuite) TestDescribeFilesystemsNotFound(c *gc.C) {
+	source := s.rootfsFilesystemSource(c)
+	_, err := source.DescribeFilesystems([]string{"6"})
+	c.Assert(err, gc.ErrorMatches, `describing "6": filesystem directory ".*/6" not found`)
+}
+
+func (s *rootfsSuite) TestDestroyFilesystems(c *gc.C) {
+	source := s.rootfsFilesystemSource(c)
+	fsPath := filepath.Join(s.storageDir, "6")
+	s.mockDirFuncs.Dirs.Add(fsPath)
+	s.mockDirFuncs.Dirs.Add(filepath.Join(fsPath, "data"))
+
+	errs := source.DestroyFilesystems([]string{"6", "7", "invalid"})
+	c.Assert(errs, gc.HasLen, 3)
+	c.Assert(errs[0], jc.ErrorIsNil)
+	c.Assert(errs[1], jc.ErrorIsNil)
+	c.Assert(errs[2], gc.ErrorMatches, `destroying "invalid": invalid rootfs filesystem ID "invalid"`)
+	c.Assert(s.mockDirFuncs.Dirs.IsEmpty(), jc.IsTrue)
+}
+
+func (s *rootfsSuite) TestDestroyFilesystemsBound(c *gc.C) {
+	source := s.rootfsFilesystemSource(c)
+	fsPath := filepath.Join(s.storageDir, "6")
+	s.attachBound(c, source, "/srv")
+
+	// The filesystem was never detached, so the bind mount is
+	// removed before the filesystem directory is.
+	cmd := s.commands.expect("df", "--output=source", "/srv")
+	cmd.respond("headers\n"+fsPath, nil)
+	s.commands.expect("umount", "/srv")
+
+	errs := source.DestroyFilesystems([]string{"6"})
+	c.Assert(errs, jc.DeepEquals, []error{nil})
+	c.Assert(s.mockDirFuncs.Dirs.Contains(fsPath), jc.IsFalse)
+	c.Assert(filepath.Join(s.storageDir, "6.info"), jc.DoesNotExist)
+}
+
+func (s *rootfsSuite) TestDetachFilesystems(c *gc.C) {
+	source := s.rootfsFilesystemSource(c)
+	fsPath := filepath.Join(s.storageDir, "6")
+	s.attachBound(c, source, "/srv")
+
+	cmd := s.commands.expect("df", "--output=source", "/srv")
+	cmd.respond("headers\n"+fsPath, nil)
+	s.commands.expect("umount", "/srv")
+
+	err := source.DetachFilesystems([]storage.FilesystemAttachmentParams{{
+		Filesystem:   names.NewFilesystemTag("6"),
+		FilesystemId: "6",
+		Path:         "/srv",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(filepath.Join(s.storageDir, "6.info"), jc.DoesNotExist)
+
+	// The bind mount was removed when the filesystem was
+	// detached, so there is nothing to unmount when it is
+	// destroyed.
+	errs := source.DestroyFilesystems([]string{"6"})
+	c.Assert(errs, jc.DeepEquals, []error{nil})
+}
+
+func (s *rootfsSuite) TestDetachFilesystemsNotBound(c *gc.C) {
+	source := s.rootfsFilesystemSource(c)
+	s.mockDirFuncs.Dirs.Add("/srv")
+	cmd := s.commands.expect("df", "--output=source", "/srv")
+	cmd.respond("headers\n/src/of/root", nil)
+
+	err := source.DetachFilesystems([]storage.FilesystemAttachmentParams{{
+		Filesystem:   names.NewFilesystemTag("6"),
+		FilesystemId: "6",
+		Path:         "/srv",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+// attachBound attaches filesystem "6" at the specified path by
+// bind mounting the filesystem directory there.
+func (s *rootfsSuite) attachBound(c *gc.C, source storage.FilesystemSource, path string) {
+	cmd := s.commands.expect("df", "--output=source", path)
+	cmd.respond("headers\n/src/of/root", nil)
+	s.commands.expect("mount", "--bind", filepath.Join(s.storageDir, "6"), path)
+
+	_, err := source.AttachFilesystems([]storage.FilesystemAttachmentParams{{
+		Filesystem:   names.NewFilesystemTag("6"),
+		FilesystemId: "6",
+		Path:         path,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(filepath.Join(s.storageDir, "6.info"), jc.IsNonEmptyFile)
+}
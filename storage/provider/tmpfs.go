@@ -128,7 +128,8 @@ func (s *tmpfsFilesystemSource) createFilesystem(params storage.FilesystemParams
 	}
 
 	info := storage.FilesystemInfo{
-		Size: sizeInMiB,
+		FilesystemId: params.Tag.Id(),
+		Size:         sizeInMiB,
 	}
 
 	// Creating the mount is the responsibility of AttachFilesystems.
@@ -142,6 +143,58 @@ func (s *tmpfsFilesystemSource) createFilesystem(params storage.FilesystemParams
 	return storage.Filesystem{params.Tag, params.Volume, info}, nil
 }
 
+// DescribeFilesystems is defined on the FilesystemSource interface.
+func (s *tmpfsFilesystemSource) DescribeFilesystems(filesystemIds []string) ([]storage.FilesystemInfo, error) {
+	infos := make([]storage.FilesystemInfo, len(filesystemIds))
+	for i, filesystemId := range filesystemIds {
+		if !names.IsValidFilesystem(filesystemId) {
+			return nil, errors.Errorf("invalid tmpfs filesystem ID %q", filesystemId)
+		}
+		info, err := s.readFilesystemInfo(names.NewFilesystemTag(filesystemId))
+		if err != nil {
+			return nil, errors.Annotatef(err, "describing %q", filesystemId)
+		}
+		infos[i] = info
+	}
+	return infos, nil
+}
+
+// DestroyFilesystems is defined on the FilesystemSource interface.
+func (s *tmpfsFilesystemSource) DestroyFilesystems(filesystemIds []string) []error {
+	results := make([]error, len(filesystemIds))
+	for i, filesystemId := range filesystemIds {
+		if err := s.destroyFilesystem(filesystemId); err != nil {
+			results[i] = errors.Annotatef(err, "destroying %q", filesystemId)
+		}
+	}
+	return results
+}
+
+func (s *tmpfsFilesystemSource) destroyFilesystem(filesystemId string) error {
+	if !names.IsValidFilesystem(filesystemId) {
+		return errors.Errorf("invalid tmpfs filesystem ID %q", filesystemId)
+	}
+	// The contents of a tmpfs filesystem are discarded when it is
+	// unmounted. That should have happened when the filesystem was
+	// detached, but make sure it is not left mounted; all that then
+	// remains is the filesystem info file.
+	tag := names.NewFilesystemTag(filesystemId)
+	filename := s.filesystemInfoFile(tag)
+	mountPoint, err := readMountPoint(filename)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if mountPoint != "" {
+		if err := s.unmount(tag, mountPoint); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if err := os.Remove(filename); err != nil && !os.IsNotExist(err) {
+		return errors.Annotate(err, "removing filesystem info")
+	}
+	return nil
+}
+
 // AttachFilesystems is defined on the FilesystemSource interface.
 func (s *tmpfsFilesystemSource) AttachFilesystems(args []storage.FilesystemAttachmentParams) ([]storage.FilesystemAttachment, error) {
 	attachments := make([]storage.FilesystemAttachment, len(args))
@@ -188,6 +241,11 @@ func (s *tmpfsFilesystemSource) attachFilesystem(arg storage.FilesystemAttachmen
 			return storage.FilesystemAttachment{}, errors.Annotate(err, "cannot mount tmpfs")
 		}
 	}
+	// Record the mount point, so the filesystem can be unmounted
+	// if it is destroyed without being detached.
+	if err := writeMountPoint(s.filesystemInfoFile(arg.Filesystem), path); err != nil {
+		return storage.FilesystemAttachment{}, errors.Trace(err)
+	}
 
 	return storage.FilesystemAttachment{
 		arg.Filesystem,
@@ -201,8 +259,43 @@ func (s *tmpfsFilesystemSource) attachFilesystem(arg storage.FilesystemAttachmen
 
 // DetachFilesystems is defined on the FilesystemSource interface.
 func (s *tmpfsFilesystemSource) DetachFilesystems(args []storage.FilesystemAttachmentParams) error {
-	// TODO(axw)
-	return errors.NotImplementedf("DetachFilesystems")
+	for _, arg := range args {
+		if err := s.detachFilesystem(arg); err != nil {
+			return errors.Annotatef(err, "detaching %s", names.ReadableString(arg.Filesystem))
+		}
+	}
+	return nil
+}
+
+func (s *tmpfsFilesystemSource) detachFilesystem(arg storage.FilesystemAttachmentParams) error {
+	if arg.Path == "" {
+		return errNoMountPoint
+	}
+	if err := s.unmount(arg.Filesystem, arg.Path); err != nil {
+		return errors.Trace(err)
+	}
+	return writeMountPoint(s.filesystemInfoFile(arg.Filesystem), "")
+}
+
+// unmount unmounts the tmpfs filesystem with the specified tag from
+// the specified path, if it is mounted there.
+func (s *tmpfsFilesystemSource) unmount(tag names.FilesystemTag, path string) error {
+	if _, err := s.dirFuncs.lstat(path); os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	source, err := s.dirFuncs.mountPointSource(path)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if source != tag.String() {
+		return nil
+	}
+	if _, err := s.run("umount", path); err != nil {
+		return errors.Annotate(err, "cannot unmount tmpfs")
+	}
+	return nil
 }
 
 func (s *tmpfsFilesystemSource) writeFilesystemInfo(tag names.FilesystemTag, info storage.FilesystemInfo) error {
@@ -213,7 +306,7 @@ func (s *tmpfsFilesystemSource) writeFilesystemInfo(tag names.FilesystemTag, inf
 	if err := ensureDir(s.dirFuncs, filepath.Dir(filename)); err != nil {
 		return errors.Trace(err)
 	}
-	err := utils.WriteYaml(filename, filesystemInfo{&info.Size})
+	err := utils.WriteYaml(filename, filesystemInfo{Size: &info.Size})
 	if err != nil {
 		return errors.Annotate(err, "writing filesystem info to disk")
 	}
@@ -228,13 +321,50 @@ func (s *tmpfsFilesystemSource) readFilesystemInfo(tag names.FilesystemTag) (sto
 	if info.Size == nil {
 		return storage.FilesystemInfo{}, errors.New("invalid filesystem info: missing size")
 	}
-	return storage.FilesystemInfo{Size: *info.Size}, nil
+	return storage.FilesystemInfo{
+		FilesystemId: tag.Id(),
+		Size:         *info.Size,
+	}, nil
 }
 
 func (s *tmpfsFilesystemSource) filesystemInfoFile(tag names.FilesystemTag) string {
 	return filepath.Join(s.storageDir, tag.Id()+".info")
 }
 
+// filesystemInfo is the information that the tmpfs and rootfs
+// filesystem sources record on disk about their filesystems.
 type filesystemInfo struct {
-	Size *uint64 `yaml:"size,omitempty"`
+	Size       *uint64 `yaml:"size,omitempty"`
+	MountPoint string  `yaml:"mountpoint,omitempty"`
+}
+
+// readMountPoint returns the mount point recorded in the specified
+// filesystem info file, or "" if there is none.
+func readMountPoint(filename string) (string, error) {
+	var info filesystemInfo
+	if err := utils.ReadYaml(filename, &info); os.IsNotExist(err) {
+		return "", nil
+	} else if err != nil {
+		return "", errors.Annotate(err, "reading filesystem info from disk")
+	}
+	return info.MountPoint, nil
+}
+
+// writeMountPoint records the mount point in the specified filesystem
+// info file, preserving the other information in the file. Recording
+// an empty mount point forgets any recorded mount point.
+func writeMountPoint(filename, mountPoint string) error {
+	var info filesystemInfo
+	if err := utils.ReadYaml(filename, &info); os.IsNotExist(err) {
+		if mountPoint == "" {
+			return nil
+		}
+	} else if err != nil {
+		return errors.Annotate(err, "reading filesystem info from disk")
+	}
+	info.MountPoint = mountPoint
+	if err := utils.WriteYaml(filename, info); err != nil {
+		return errors.Annotate(err, "writing filesystem info to disk")
+	}
+	return nil
 }
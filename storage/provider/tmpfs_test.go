@@ -5,6 +5,7 @@ package provider_test
 
 import (
 	"errors"
+	"path/filepath"
 	"runtime"
 
 	"github.com/juju/names"
@@ -98,7 +99,8 @@ func (s *tmpfsSuite) TestCreateFilesystems(c *gc.C) {
 	c.Assert(filesystems, jc.DeepEquals, []storage.Filesystem{{
 		Tag: names.NewFilesystemTag("6"),
 		FilesystemInfo: storage.FilesystemInfo{
-			Size: 2,
+			FilesystemId: "6",
+			Size:         2,
 		},
 	}})
 }
@@ -120,12 +122,14 @@ func (s *tmpfsSuite) TestCreateFilesystemsHugePages(c *gc.C) {
 	c.Assert(filesystems, jc.DeepEquals, []storage.Filesystem{{
 		Tag: names.NewFilesystemTag("1"),
 		FilesystemInfo: storage.FilesystemInfo{
-			Size: 32,
+			FilesystemId: "1",
+			Size:         32,
 		},
 	}, {
 		Tag: names.NewFilesystemTag("2"),
 		FilesystemInfo: storage.FilesystemInfo{
-			Size: 16,
+			FilesystemId: "2",
+			Size:         16,
 		},
 	}})
 }
@@ -249,3 +253,121 @@ func (s *tmpfsSuite) TestAttachFilesystemsNoFilesystem(c *gc.C) {
 	}})
 	c.Assert(err, gc.ErrorMatches, "attaching filesystem 6: reading filesystem info from disk: open .*/6.info: no such file or directory")
 }
+
+func (s *tmpfsSuite) TestDescribeFilesystems(c *gc.C) {
+	source := s.tmpfsFilesystemSource(c)
+	_, err := source.CreateFilesystems([]storage.FilesystemParams{{
+		Tag:  names.NewFilesystemTag("6"),
+		Size: 2,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	infos, err := source.DescribeFilesystems([]string{"6"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(infos, jc.DeepEquals, []storage.FilesystemInfo{{
+		FilesystemId: "6",
+		Size:         2,
+	}})
+}
+
+func (s *tmpfsSuite) TestDescribeFilesystemsNoFilesystem(c *gc.C) {
+	source := s.tmpfsFilesystemSource(c)
+	_, err := source.DescribeFilesystems([]string{"6"})
+	c.Assert(err, gc.ErrorMatches, `describing "6": reading filesystem info from disk: open .*/6.info: no such file or directory`)
+}
+
+func (s *tmpfsSuite) TestDestroyFilesystems(c *gc.C) {
+	source := s.tmpfsFilesystemSource(c)
+	_, err := source.CreateFilesystems([]storage.FilesystemParams{{
+		Tag:  names.NewFilesystemTag("6"),
+		Size: 2,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(filepath.Join(s.storageDir, "6.info"), jc.IsNonEmptyFile)
+
+	errs := source.DestroyFilesystems([]string{"6", "7", "invalid"})
+	c.Assert(errs, gc.HasLen, 3)
+	c.Assert(errs[0], jc.ErrorIsNil)
+	c.Assert(errs[1], jc.ErrorIsNil)
+	c.Assert(errs[2], gc.ErrorMatches, `destroying "invalid": invalid tmpfs filesystem ID "invalid"`)
+	c.Assert(filepath.Join(s.storageDir, "6.info"), jc.DoesNotExist)
+}
+
+func (s *tmpfsSuite) TestDestroyFilesystemsMounted(c *gc.C) {
+	source := s.tmpfsFilesystemSource(c)
+	s.createAndAttach(c, source, "6", "/var/lib/juju/storage/fs/foo")
+
+	// The filesystem was never detached, so it is unmounted
+	// before it is destroyed.
+	cmd := s.commands.expect("df", "--output=source", "/var/lib/juju/storage/fs/foo")
+	cmd.respond("header\nfilesystem-6", nil)
+	s.commands.expect("umount", "/var/lib/juju/storage/fs/foo")
+
+	errs := source.DestroyFilesystems([]string{"6"})
+	c.Assert(errs, jc.DeepEquals, []error{nil})
+	c.Assert(filepath.Join(s.storageDir, "6.info"), jc.DoesNotExist)
+}
+
+func (s *tmpfsSuite) TestDetachFilesystems(c *gc.C) {
+	source := s.tmpfsFilesystemSource(c)
+	s.createAndAttach(c, source, "6", "/var/lib/juju/storage/fs/foo")
+
+	cmd := s.commands.expect("df", "--output=source", "/var/lib/juju/storage/fs/foo")
+	cmd.respond("header\nfilesystem-6", nil)
+	s.commands.expect("umount", "/var/lib/juju/storage/fs/foo")
+
+	err := source.DetachFilesystems([]storage.FilesystemAttachmentParams{{
+		Filesystem: names.NewFilesystemTag("6"),
+		Path:       "/var/lib/juju/storage/fs/foo",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The filesystem was unmounted when it was detached,
+	// so there is nothing to unmount when it is destroyed.
+	errs := source.DestroyFilesystems([]string{"6"})
+	c.Assert(errs, jc.DeepEquals, []error{nil})
+}
+
+func (s *tmpfsSuite) TestDetachFilesystemsNotMounted(c *gc.C) {
+	source := s.tmpfsFilesystemSource(c)
+	err := source.DetachFilesystems([]storage.FilesystemAttachmentParams{{
+		Filesystem: names.NewFilesystemTag("6"),
+		Path:       "/var/lib/juju/storage/fs/foo",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *tmpfsSuite) TestDetachFilesystemsUnmountFails(c *gc.C) {
+	source := s.tmpfsFilesystemSource(c)
+	s.createAndAttach(c, source, "6", "/var/lib/juju/storage/fs/foo")
+
+	cmd := s.commands.expect("df", "--output=source", "/var/lib/juju/storage/fs/foo")
+	cmd.respond("header\nfilesystem-6", nil)
+	cmd = s.commands.expect("umount", "/var/lib/juju/storage/fs/foo")
+	cmd.respond("", errors.New("device is busy"))
+
+	err := source.DetachFilesystems([]storage.FilesystemAttachmentParams{{
+		Filesystem: names.NewFilesystemTag("6"),
+		Path:       "/var/lib/juju/storage/fs/foo",
+	}})
+	c.Assert(err, gc.ErrorMatches, "detaching filesystem 6: cannot unmount tmpfs: device is busy")
+}
+
+// createAndAttach creates a tmpfs filesystem with the specified ID,
+// and mounts it at the specified path.
+func (s *tmpfsSuite) createAndAttach(c *gc.C, source storage.FilesystemSource, id, path string) {
+	_, err := source.CreateFilesystems([]storage.FilesystemParams{{
+		Tag:  names.NewFilesystemTag(id),
+		Size: 2,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	cmd := s.commands.expect("df", "--output=source", path)
+	cmd.respond("header\nvalue", nil)
+	s.commands.expect("mount", "-t", "tmpfs", "filesystem-"+id, path, "-o", "size=2m")
+	_, err = source.AttachFilesystems([]storage.FilesystemAttachmentParams{{
+		Filesystem: names.NewFilesystemTag(id),
+		Path:       path,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+}
@@ -47,4 +47,9 @@ type StorageAttachmentInfo struct {
 	// for a filesystem-kind storage attachment, and the device path
 	// for a block-kind.
 	Location string
+
+	// Size is the size of the volume backing a block-kind storage
+	// attachment, in MiB. Size is not set for filesystem-kind storage
+	// attachments.
+	Size uint64
 }
@@ -59,3 +59,26 @@ type VolumeAttachmentInfo struct {
 	// ReadOnly signifies whether the volume is read only or writable.
 	ReadOnly bool
 }
+
+// VolumeSnapshot identifies and describes a point-in-time snapshot of
+// a volume.
+type VolumeSnapshot struct {
+	// Snapshot is the unique ID assigned by Juju to the snapshot.
+	Snapshot string
+
+	VolumeSnapshotInfo
+}
+
+// VolumeSnapshotInfo describes a point-in-time snapshot of a volume.
+type VolumeSnapshotInfo struct {
+	// SnapshotId is a unique provider-supplied ID for the snapshot.
+	SnapshotId string
+
+	// VolumeId is the provider-supplied ID of the volume that the
+	// snapshot was taken of.
+	VolumeId string
+
+	// Size is the size of the snapshotted volume, in MiB. A volume
+	// created from the snapshot must be at least this size.
+	Size uint64
+}
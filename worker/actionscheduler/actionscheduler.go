@@ -0,0 +1,125 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package actionscheduler provides a worker that queues up the actions
+// of an environment's action schedules when they are due.
+package actionscheduler
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/worker"
+)
+
+var logger = loggo.GetLogger("juju.worker.actionscheduler")
+
+// DefaultCheckInterval is the interval at which the worker checks for
+// schedules that are due.
+const DefaultCheckInterval = time.Minute
+
+// ServiceLeaders finds the leaders of services.
+type ServiceLeaders interface {
+	// ServiceLeader returns the name of the unit that is the leader
+	// of the named service.
+	ServiceLeader(serviceName string) (string, error)
+}
+
+// New returns a worker that checks the environment's action schedules
+// every checkInterval, and queues up the action of each schedule that
+// is due.
+func New(st *state.State, leaders ServiceLeaders, checkInterval time.Duration) worker.Worker {
+	return worker.NewPeriodicWorker(func(stop <-chan struct{}) error {
+		return RunDue(st, leaders, time.Now())
+	}, checkInterval)
+}
+
+// RunDue queues up the action of each schedule that is due at the
+// given time, and records the outcome in the schedule's run history.
+// A run is skipped if the action queued up by the schedule's previous
+// run is still pending. Failures to queue up an action are recorded
+// against the run rather than returned.
+func RunDue(st *state.State, leaders ServiceLeaders, now time.Time) error {
+	schedules, err := st.AllActionSchedules()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, schedule := range schedules {
+		if schedule.NextRun().After(now) {
+			continue
+		}
+		if err := runSchedule(st, leaders, schedule, now); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// runSchedule queues up the action of the given schedule on its
+// receiver and records the run in the same transaction, unless the
+// previous run is still pending or the action cannot be queued up, in
+// which case it records why.
+func runSchedule(st *state.State, leaders ServiceLeaders, schedule *state.ActionSchedule, now time.Time) error {
+	run := state.ActionScheduleRun{Due: schedule.NextRun()}
+	pendingId, err := pendingActionId(st, schedule)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if pendingId != "" {
+		logger.Infof("skipping run of schedule %q: action %s still pending", schedule.Name(), pendingId)
+		run.Skipped = true
+		run.Message = fmt.Sprintf("action %s still pending", pendingId)
+		return schedule.RecordRun(run, now)
+	}
+	action, err := runAction(st, leaders, schedule, now)
+	if err != nil {
+		logger.Warningf("cannot run schedule %q: %v", schedule.Name(), err)
+		run.Message = err.Error()
+		return schedule.RecordRun(run, now)
+	}
+	if action != nil {
+		logger.Debugf("schedule %q queued up action %s on %s", schedule.Name(), action.Id(), action.Receiver())
+	}
+	return nil
+}
+
+// runAction queues up the action of the given schedule on the unit
+// that receives it, recording the run as it does so.
+func runAction(st *state.State, leaders ServiceLeaders, schedule *state.ActionSchedule, now time.Time) (*state.Action, error) {
+	receiver, err := schedule.ReceiverTag(leaders.ServiceLeader)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	unit, err := st.Unit(receiver.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return schedule.RunAction(unit, now)
+}
+
+// pendingActionId returns the id of the action queued up by the most
+// recent run of the schedule that queued one up, if that action is
+// still pending.
+func pendingActionId(st *state.State, schedule *state.ActionSchedule) (string, error) {
+	runs := schedule.Runs()
+	for i := len(runs) - 1; i >= 0; i-- {
+		if runs[i].ActionId == "" {
+			continue
+		}
+		action, err := st.Action(runs[i].ActionId)
+		if errors.IsNotFound(err) {
+			return "", nil
+		} else if err != nil {
+			return "", errors.Trace(err)
+		}
+		if action.Status() == state.ActionPending {
+			return action.Id(), nil
+		}
+		return "", nil
+	}
+	return "", nil
+}
@@ -0,0 +1,129 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/worker/actionscheduler"
+)
+
+type actionSchedulerSuite struct {
+	testing.JujuConnSuite
+	unit    *state.Unit
+	start   time.Time
+	leaders fakeLeaders
+}
+
+var _ = gc.Suite(&actionSchedulerSuite{})
+
+type fakeLeaders map[string]string
+
+func (l fakeLeaders) ServiceLeader(serviceName string) (string, error) {
+	if leader, ok := l[serviceName]; ok {
+		return leader, nil
+	}
+	return "", errors.NotFoundf("leader of service %q", serviceName)
+}
+
+func (s *actionSchedulerSuite) SetUpTest(c *gc.C) {
+	s.JujuConnSuite.SetUpTest(c)
+	service := s.AddTestingService(c, "dummy", s.AddTestingCharm(c, "dummy"))
+	var err error
+	s.unit, err = service.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	s.start = time.Date(2015, 6, 1, 2, 0, 0, 0, time.UTC)
+	s.leaders = fakeLeaders{}
+}
+
+func (s *actionSchedulerSuite) addSchedule(c *gc.C, receiver string) {
+	_, err := s.State.AddActionSchedule(state.ActionScheduleArgs{
+		Name:       "nightly",
+		Receiver:   receiver,
+		ActionName: "snapshot",
+		Start:      s.start,
+		Interval:   24 * time.Hour,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *actionSchedulerSuite) runDue(c *gc.C, now time.Time) []state.ActionScheduleRun {
+	err := actionscheduler.RunDue(s.State, s.leaders, now)
+	c.Assert(err, jc.ErrorIsNil)
+	schedule, err := s.State.ActionSchedule("nightly")
+	c.Assert(err, jc.ErrorIsNil)
+	return schedule.Runs()
+}
+
+func (s *actionSchedulerSuite) TestRunDue(c *gc.C) {
+	s.addSchedule(c, s.unit.Name())
+
+	// Nothing is due before the start time.
+	runs := s.runDue(c, s.start.Add(-time.Minute))
+	c.Assert(runs, gc.HasLen, 0)
+
+	runs = s.runDue(c, s.start.Add(time.Minute))
+	c.Assert(runs, gc.HasLen, 1)
+	c.Assert(runs[0].Due, gc.Equals, s.start)
+	c.Assert(runs[0].Skipped, jc.IsFalse)
+	action, err := s.State.Action(runs[0].ActionId)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(action.Name(), gc.Equals, "snapshot")
+	c.Assert(action.Receiver(), gc.Equals, s.unit.Name())
+
+	// The run is only recorded once.
+	runs = s.runDue(c, s.start.Add(2*time.Minute))
+	c.Assert(runs, gc.HasLen, 1)
+}
+
+func (s *actionSchedulerSuite) TestRunDueSkipsWhilePending(c *gc.C) {
+	s.addSchedule(c, s.unit.Name())
+	runs := s.runDue(c, s.start)
+	c.Assert(runs, gc.HasLen, 1)
+	firstId := runs[0].ActionId
+
+	// The previous action is still pending, so the next run is skipped.
+	runs = s.runDue(c, s.start.Add(24*time.Hour))
+	c.Assert(runs, gc.HasLen, 2)
+	c.Assert(runs[1], jc.DeepEquals, state.ActionScheduleRun{
+		Due:     s.start.Add(24 * time.Hour),
+		Skipped: true,
+		Message: "action " + firstId + " still pending",
+	})
+
+	// Once the action has started, the next run goes ahead.
+	action, err := s.State.Action(firstId)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = action.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	runs = s.runDue(c, s.start.Add(48*time.Hour))
+	c.Assert(runs, gc.HasLen, 3)
+	c.Assert(runs[2].Skipped, jc.IsFalse)
+	c.Assert(runs[2].ActionId, gc.Not(gc.Equals), "")
+	c.Assert(runs[2].ActionId, gc.Not(gc.Equals), firstId)
+}
+
+func (s *actionSchedulerSuite) TestRunDueLeader(c *gc.C) {
+	s.addSchedule(c, "dummy/leader")
+
+	runs := s.runDue(c, s.start)
+	c.Assert(runs, gc.HasLen, 1)
+	c.Assert(runs[0], jc.DeepEquals, state.ActionScheduleRun{
+		Due:     s.start,
+		Message: `leader of service "dummy" not found`,
+	})
+
+	s.leaders["dummy"] = s.unit.Name()
+	runs = s.runDue(c, s.start.Add(24*time.Hour))
+	c.Assert(runs, gc.HasLen, 2)
+	action, err := s.State.Action(runs[1].ActionId)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(action.Receiver(), gc.Equals, s.unit.Name())
+}
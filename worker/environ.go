@@ -59,6 +59,25 @@ func WaitForEnviron(w apiwatcher.NotifyWatcher, st EnvironConfigGetter, dying <-
 	}
 }
 
+// CredentialsLoadedSetter interface defines a way to record which
+// provider credentials a worker is currently using.
+type CredentialsLoadedSetter interface {
+	SetCredentialsLoaded(worker, fingerprint string) error
+}
+
+// SetCredentialsLoaded records that the named worker has opened an
+// environment with the credentials in the given configuration. Failures
+// are logged rather than returned, as they must not stop the worker.
+func SetCredentialsLoaded(st CredentialsLoadedSetter, worker string, config *config.Config) {
+	fingerprint, err := environs.CredentialsFingerprint(config)
+	if err == nil {
+		err = st.SetCredentialsLoaded(worker, fingerprint)
+	}
+	if err != nil {
+		logger.Warningf("cannot record credentials loaded by %s: %v", worker, err)
+	}
+}
+
 // EnvironConfigObserver interface defines a way to read the
 // environment configuration and watch for changes.
 type EnvironConfigObserver interface {
@@ -73,6 +92,7 @@ type EnvironObserver struct {
 	tomb           tomb.Tomb
 	environWatcher apiwatcher.NotifyWatcher
 	st             EnvironConfigObserver
+	loaded         func(*config.Config)
 	mu             sync.Mutex
 	environ        environs.Environ
 }
@@ -81,7 +101,9 @@ type EnvironObserver struct {
 // environment configuration and returns a new environment observer.
 // While waiting for the first environment configuration, it will
 // return with tomb.ErrDying if it receives a value on dying.
-func NewEnvironObserver(st EnvironConfigObserver) (*EnvironObserver, error) {
+// If loaded is not nil, it is called with every configuration
+// that was successfully used to create an environment.
+func NewEnvironObserver(st EnvironConfigObserver, loaded func(*config.Config)) (*EnvironObserver, error) {
 	config, err := st.EnvironConfig()
 	if err != nil {
 		return nil, err
@@ -90,12 +112,16 @@ func NewEnvironObserver(st EnvironConfigObserver) (*EnvironObserver, error) {
 	if err != nil {
 		return nil, errors.Annotate(err, "cannot create an environment")
 	}
+	if loaded != nil {
+		loaded(config)
+	}
 	environWatcher, err := st.WatchForEnvironConfigChanges()
 	if err != nil {
 		return nil, errors.Annotate(err, "cannot watch environment config")
 	}
 	obs := &EnvironObserver{
 		st:             st,
+		loaded:         loaded,
 		environ:        environ,
 		environWatcher: environWatcher,
 	}
@@ -130,6 +156,9 @@ func (obs *EnvironObserver) loop() error {
 		obs.mu.Lock()
 		obs.environ = environ
 		obs.mu.Unlock()
+		if obs.loaded != nil {
+			obs.loaded(config)
+		}
 	}
 }
 
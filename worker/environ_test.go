@@ -108,7 +108,7 @@ func (s *environSuite) TestErrorWhenEnvironIsInvalid(c *gc.C) {
 		"type": "unknown",
 	})
 
-	obs, err := worker.NewEnvironObserver(s.st)
+	obs, err := worker.NewEnvironObserver(s.st, nil)
 	c.Assert(err, gc.ErrorMatches,
 		`cannot create an environment: no registered provider for "unknown"`,
 	)
@@ -123,7 +123,7 @@ func (s *environSuite) TestEnvironmentChanges(c *gc.C) {
 	c.Assert(loggo.RegisterWriter("testing", logc, loggo.WARNING), gc.IsNil)
 	defer loggo.RemoveWriter("testing")
 
-	obs, err := worker.NewEnvironObserver(s.st)
+	obs, err := worker.NewEnvironObserver(s.st, nil)
 	c.Assert(err, jc.ErrorIsNil)
 
 	env := obs.Environ()
@@ -169,6 +169,41 @@ loop:
 	}
 }
 
+func (s *environSuite) TestEnvironObserverReportsLoadedConfig(c *gc.C) {
+	s.st.SetConfig(c, nil)
+
+	loaded := make(chan *config.Config, 10)
+	obs, err := worker.NewEnvironObserver(s.st, func(cfg *config.Config) {
+		loaded <- cfg
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer func() {
+		obs.Kill()
+		c.Assert(obs.Wait(), jc.ErrorIsNil)
+	}()
+
+	select {
+	case cfg := <-loaded:
+		s.st.AssertConfig(c, cfg)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for initial config")
+	}
+
+	s.st.SetConfig(c, coretesting.Attrs{
+		"name": "a-new-name",
+	})
+	for {
+		select {
+		case cfg := <-loaded:
+			if cfg.Name() == "a-new-name" {
+				return
+			}
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for new config")
+		}
+	}
+}
+
 type logChan chan string
 
 func (logc logChan) Write(level loggo.Level, name, filename string, line int, timestamp time.Time, message string) {
@@ -40,6 +40,7 @@ type Firewaller struct {
 	exposedChange   chan *exposedChange
 	globalMode      bool
 	globalPortRef   map[network.PortRange]int
+	globalRuleRef   map[ingressKey]int
 	machinePorts    map[names.MachineTag]machineRanges
 }
 
@@ -89,10 +90,12 @@ func NewFirewaller(st *apifirewaller.State) (_ worker.Worker, err error) {
 	case config.FwGlobal:
 		fw.globalMode = true
 		fw.globalPortRef = make(map[network.PortRange]int)
+		fw.globalRuleRef = make(map[ingressKey]int)
 	case config.FwNone:
 		logger.Warningf("stopping firewaller - firewall-mode is %q", config.FwNone)
 		return nil, errors.Errorf("firewaller is disabled when firewall-mode is %q", config.FwNone)
 	}
+	worker.SetCredentialsLoaded(fw.st, "firewaller", fw.environ.Config())
 
 	go func() {
 		defer fw.tomb.Done()
@@ -124,6 +127,8 @@ func (fw *Firewaller) loop() error {
 			}
 			if err := fw.environ.SetConfig(config); err != nil {
 				logger.Errorf("loaded invalid environment configuration: %v", err)
+			} else {
+				worker.SetCredentialsLoaded(fw.st, "firewaller", config)
 			}
 		case change, ok := <-fw.machinesWatcher.Changes():
 			if !ok {
@@ -163,6 +168,7 @@ func (fw *Firewaller) loop() error {
 			}
 		case change := <-fw.exposedChange:
 			change.serviced.exposed = change.exposed
+			change.serviced.cidrs = change.cidrs
 			unitds := []*unitData{}
 			for _, unitd := range change.serviced.unitds {
 				unitds = append(unitds, unitd)
@@ -268,7 +274,7 @@ func (fw *Firewaller) startUnit(unit *apifirewaller.Unit, machineTag names.Machi
 // startService creates a new data value for tracking details of the
 // service and starts watching the service for exposure changes.
 func (fw *Firewaller) startService(service *apifirewaller.Service) error {
-	exposed, err := service.IsExposed()
+	exposed, cidrs, err := serviceExposure(service)
 	if err != nil {
 		return err
 	}
@@ -276,13 +282,31 @@ func (fw *Firewaller) startService(service *apifirewaller.Service) error {
 		fw:      fw,
 		service: service,
 		exposed: exposed,
+		cidrs:   cidrs,
 		unitds:  make(map[names.UnitTag]*unitData),
 	}
 	fw.serviceds[service.Tag()] = serviced
-	go serviced.watchLoop(serviced.exposed)
+	go serviced.watchLoop(serviced.exposed, serviced.cidrs)
 	return nil
 }
 
+// serviceExposure returns whether the service is exposed, and the
+// source CIDRs it is exposed to. An API server that does not know
+// about source CIDRs exposes services to any address.
+func serviceExposure(service *apifirewaller.Service) (bool, []string, error) {
+	exposed, err := service.IsExposed()
+	if err != nil {
+		return false, nil, err
+	}
+	cidrs, err := service.ExposedCIDRs()
+	if params.IsCodeNotImplemented(err) {
+		return exposed, nil, nil
+	} else if err != nil {
+		return false, nil, err
+	}
+	return exposed, cidrs, nil
+}
+
 // reconcileGlobal compares the initially started watcher for machines,
 // units and services with the opened and closed ports globally and
 // opens and closes the appropriate ports for the whole environment.
@@ -292,6 +316,7 @@ func (fw *Firewaller) reconcileGlobal() error {
 		return err
 	}
 	collector := make(map[network.PortRange]bool)
+	ruleCollector := make(map[ingressKey]bool)
 	for _, machined := range fw.machineds {
 		for portRange, unitTag := range machined.definedPorts {
 			unitd, known := machined.unitds[unitTag]
@@ -299,15 +324,28 @@ func (fw *Firewaller) reconcileGlobal() error {
 				delete(machined.unitds, unitTag)
 				continue
 			}
-			if unitd.serviced.exposed {
+			if !unitd.serviced.exposed {
+				continue
+			}
+			if len(unitd.serviced.cidrs) == 0 {
 				collector[portRange] = true
 			}
+			for _, cidr := range unitd.serviced.cidrs {
+				ruleCollector[ingressKey{portRange, cidr}] = true
+			}
 		}
 	}
 	wantedPorts := []network.PortRange{}
 	for port := range collector {
 		wantedPorts = append(wantedPorts, port)
 	}
+	wantedRules := []ingressKey{}
+	for key := range ruleCollector {
+		wantedRules = append(wantedRules, key)
+	}
+	if err := fw.reconcileGlobalRules(wantedRules); err != nil {
+		return err
+	}
 	// Check which ports to open or to close.
 	toOpen := diffRanges(wantedPorts, initialPortRanges)
 	toClose := diffRanges(initialPortRanges, wantedPorts)
@@ -328,6 +366,41 @@ func (fw *Firewaller) reconcileGlobal() error {
 	return nil
 }
 
+// reconcileGlobalRules opens and closes the ingress rules restricted
+// to source CIDRs for the whole environment, so that only the wanted
+// ones remain.
+func (fw *Firewaller) reconcileGlobalRules(wanted []ingressKey) error {
+	ingress, ok := environs.SupportsIngressFirewall(fw.environ)
+	if !ok {
+		if len(wanted) > 0 {
+			logger.Errorf("cannot open %v: %v", ingressRules(wanted), errNoIngressFirewall)
+		}
+		return nil
+	}
+	initialRules, err := ingress.IngressRules()
+	if err != nil {
+		return err
+	}
+	initial := restrictedKeys(initialRules)
+	toOpen := diffKeys(wanted, initial)
+	toClose := diffKeys(initial, wanted)
+	if len(toOpen) > 0 {
+		rules := ingressRules(toOpen)
+		logger.Infof("opening global ingress rules %v", rules)
+		if err := ingress.OpenIngressRules(rules); err != nil {
+			return err
+		}
+	}
+	if len(toClose) > 0 {
+		rules := ingressRules(toClose)
+		logger.Infof("closing global ingress rules %v", rules)
+		if err := ingress.CloseIngressRules(rules); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // reconcileInstances compares the initially started watcher for machines,
 // units and services with the opened and closed ports of the instances and
 // opens and closes the appropriate ports for each instance.
@@ -383,6 +456,46 @@ func (fw *Firewaller) reconcileInstances() error {
 			}
 			network.SortPortRanges(toClose)
 		}
+		if err := fw.reconcileInstanceRules(machined, instances[0]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reconcileInstanceRules opens and closes the ingress rules restricted
+// to source CIDRs on the machine's instance, so that only the ones
+// wanted by the machine's units remain.
+func (fw *Firewaller) reconcileInstanceRules(machined *machineData, inst instance.Instance) error {
+	ingress, ok := environs.InstanceSupportsIngressFirewall(inst)
+	if !ok {
+		if len(machined.openedRules) > 0 {
+			logger.Errorf("cannot open %v for %q: %v",
+				ingressRules(machined.openedRules), machined.tag, errNoIngressFirewall)
+		}
+		return nil
+	}
+	machineId := machined.tag.Id()
+	initialRules, err := ingress.IngressRules(machineId)
+	if err != nil {
+		return err
+	}
+	initial := restrictedKeys(initialRules)
+	toOpen := diffKeys(machined.openedRules, initial)
+	toClose := diffKeys(initial, machined.openedRules)
+	if len(toOpen) > 0 {
+		rules := ingressRules(toOpen)
+		logger.Infof("opening instance ingress rules %v for %q", rules, machined.tag)
+		if err := ingress.OpenIngressRules(machineId, rules); err != nil {
+			return err
+		}
+	}
+	if len(toClose) > 0 {
+		rules := ingressRules(toClose)
+		logger.Infof("closing instance ingress rules %v for %q", rules, machined.tag)
+		if err := ingress.CloseIngressRules(machineId, rules); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -502,25 +615,40 @@ func (fw *Firewaller) flushUnits(unitds []*unitData) error {
 
 // flushMachine opens and closes ports for the passed machine.
 func (fw *Firewaller) flushMachine(machined *machineData) error {
-	// Gather ports to open and close.
+	// Gather ports and ingress rules to open and close. Ports of
+	// services exposed to any address are opened as such; those of
+	// services exposed to source CIDRs through ingress rules.
 	want := []network.PortRange{}
+	wantRules := []ingressKey{}
 	for portRange, unitTag := range machined.definedPorts {
 		unitd, known := machined.unitds[unitTag]
 		if !known {
 			delete(machined.unitds, unitTag)
 			continue
 		}
-		if unitd.serviced.exposed {
+		if !unitd.serviced.exposed {
+			continue
+		}
+		if len(unitd.serviced.cidrs) == 0 {
 			want = append(want, portRange)
 		}
+		for _, cidr := range unitd.serviced.cidrs {
+			wantRules = append(wantRules, ingressKey{portRange, cidr})
+		}
 	}
 	toOpen := diffRanges(want, machined.openedPorts)
 	toClose := diffRanges(machined.openedPorts, want)
+	rulesToOpen := diffKeys(wantRules, machined.openedRules)
+	rulesToClose := diffKeys(machined.openedRules, wantRules)
 	machined.openedPorts = want
+	machined.openedRules = wantRules
 	if fw.globalMode {
-		return fw.flushGlobalPorts(toOpen, toClose)
+		if err := fw.flushGlobalPorts(toOpen, toClose); err != nil {
+			return err
+		}
+		return fw.flushGlobalRules(rulesToOpen, rulesToClose)
 	}
-	return fw.flushInstancePorts(machined, toOpen, toClose)
+	return fw.flushInstancePorts(machined, toOpen, toClose, rulesToOpen, rulesToClose)
 }
 
 // flushGlobalPorts opens and closes global ports in the environment.
@@ -562,13 +690,66 @@ func (fw *Firewaller) flushGlobalPorts(rawOpen, rawClose []network.PortRange) er
 	return nil
 }
 
-// flushInstancePorts opens and closes ports global on the machine.
-func (fw *Firewaller) flushInstancePorts(machined *machineData, toOpen, toClose []network.PortRange) error {
+// flushGlobalRules opens and closes global ingress rules restricted to
+// source CIDRs in the environment. Like flushGlobalPorts, it keeps a
+// reference count for each port range and source CIDR.
+func (fw *Firewaller) flushGlobalRules(rawOpen, rawClose []ingressKey) error {
+	// Filter which rules are really to open or close.
+	var toOpen, toClose []ingressKey
+	for _, key := range rawOpen {
+		if fw.globalRuleRef[key] == 0 {
+			toOpen = append(toOpen, key)
+		}
+		fw.globalRuleRef[key]++
+	}
+	for _, key := range rawClose {
+		fw.globalRuleRef[key]--
+		if fw.globalRuleRef[key] == 0 {
+			toClose = append(toClose, key)
+			delete(fw.globalRuleRef, key)
+		}
+	}
+	if len(toOpen) == 0 && len(toClose) == 0 {
+		return nil
+	}
+	ingress, ok := environs.SupportsIngressFirewall(fw.environ)
+	if !ok {
+		// The ports stay closed, rather than open to any address.
+		if len(toOpen) > 0 {
+			logger.Errorf("cannot open %v in environment: %v", ingressRules(toOpen), errNoIngressFirewall)
+		}
+		return nil
+	}
+	// Open and close the rules.
+	if len(toOpen) > 0 {
+		rules := ingressRules(toOpen)
+		if err := ingress.OpenIngressRules(rules); err != nil {
+			return err
+		}
+		logger.Infof("opened ingress rules %v in environment", rules)
+	}
+	if len(toClose) > 0 {
+		rules := ingressRules(toClose)
+		if err := ingress.CloseIngressRules(rules); err != nil {
+			return err
+		}
+		logger.Infof("closed ingress rules %v in environment", rules)
+	}
+	return nil
+}
+
+// flushInstancePorts opens and closes ports and ingress rules global on
+// the machine.
+func (fw *Firewaller) flushInstancePorts(
+	machined *machineData,
+	toOpen, toClose []network.PortRange,
+	rulesToOpen, rulesToClose []ingressKey,
+) error {
 	// If there's nothing to do, do nothing.
 	// This is important because when a machine is first created,
 	// it will have no instance id but also no open ports -
 	// InstanceId will fail but we don't care.
-	if len(toOpen) == 0 && len(toClose) == 0 {
+	if len(toOpen) == 0 && len(toClose) == 0 && len(rulesToOpen) == 0 && len(rulesToClose) == 0 {
 		return nil
 	}
 	m, err := machined.machine()
@@ -604,6 +785,31 @@ func (fw *Firewaller) flushInstancePorts(machined *machineData, toOpen, toClose
 		network.SortPortRanges(toClose)
 		logger.Infof("closed port ranges %v on %q", toClose, machined.tag)
 	}
+	if len(rulesToOpen) == 0 && len(rulesToClose) == 0 {
+		return nil
+	}
+	ingress, ok := environs.InstanceSupportsIngressFirewall(instances[0])
+	if !ok {
+		// The ports stay closed, rather than open to any address.
+		if len(rulesToOpen) > 0 {
+			logger.Errorf("cannot open %v on %q: %v", ingressRules(rulesToOpen), machined.tag, errNoIngressFirewall)
+		}
+		return nil
+	}
+	if len(rulesToOpen) > 0 {
+		rules := ingressRules(rulesToOpen)
+		if err := ingress.OpenIngressRules(machineId, rules); err != nil {
+			return err
+		}
+		logger.Infof("opened ingress rules %v on %q", rules, machined.tag)
+	}
+	if len(rulesToClose) > 0 {
+		rules := ingressRules(rulesToClose)
+		if err := ingress.CloseIngressRules(machineId, rules); err != nil {
+			return err
+		}
+		logger.Infof("closed ingress rules %v on %q", rules, machined.tag)
+	}
 	return nil
 }
 
@@ -717,6 +923,8 @@ type machineData struct {
 	tag         names.MachineTag
 	unitds      map[names.UnitTag]*unitData
 	openedPorts []network.PortRange
+	// ingress rules opened for units exposed to source CIDRs
+	openedRules []ingressKey
 	// ports defined by units on this machine
 	definedPorts map[network.PortRange]names.UnitTag
 }
@@ -770,6 +978,7 @@ type unitData struct {
 type exposedChange struct {
 	serviced *serviceData
 	exposed  bool
+	cidrs    []string
 }
 
 // serviceData holds service details and watches exposure changes.
@@ -778,11 +987,13 @@ type serviceData struct {
 	fw      *Firewaller
 	service *apifirewaller.Service
 	exposed bool
+	cidrs   []string
 	unitds  map[names.UnitTag]*unitData
 }
 
-// watchLoop watches the service's exposed flag for changes.
-func (sd *serviceData) watchLoop(exposed bool) {
+// watchLoop watches the service's exposed flag and source CIDRs for
+// changes.
+func (sd *serviceData) watchLoop(exposed bool, cidrs []string) {
 	defer sd.tomb.Done()
 	w, err := sd.service.Watch()
 	if err != nil {
@@ -805,17 +1016,17 @@ func (sd *serviceData) watchLoop(exposed bool) {
 				}
 				return
 			}
-			change, err := sd.service.IsExposed()
+			change, changeCIDRs, err := serviceExposure(sd.service)
 			if err != nil {
 				sd.fw.tomb.Kill(err)
 				return
 			}
-			if change == exposed {
+			if change == exposed && stringsEqual(changeCIDRs, cidrs) {
 				continue
 			}
-			exposed = change
+			exposed, cidrs = change, changeCIDRs
 			select {
-			case sd.fw.exposedChange <- &exposedChange{sd, change}:
+			case sd.fw.exposedChange <- &exposedChange{sd, change, changeCIDRs}:
 			case <-sd.tomb.Dying():
 				return
 			}
@@ -843,6 +1054,70 @@ next:
 	return
 }
 
+// ingressKey identifies a port range opened to a single source CIDR.
+type ingressKey struct {
+	portRange network.PortRange
+	cidr      string
+}
+
+// errNoIngressFirewall is logged when ports must be restricted to
+// source CIDRs, but the provider cannot do so.
+var errNoIngressFirewall = errors.NotSupportedf("restricting ports to source CIDRs")
+
+// diffKeys returns all the ingress keys that exist in A but not B.
+func diffKeys(A, B []ingressKey) (missing []ingressKey) {
+next:
+	for _, a := range A {
+		for _, b := range B {
+			if a == b {
+				continue next
+			}
+		}
+		missing = append(missing, a)
+	}
+	return
+}
+
+// ingressRules returns the sorted ingress rules for the keys.
+func ingressRules(keys []ingressKey) []network.IngressRule {
+	rules := make([]network.IngressRule, len(keys))
+	for i, key := range keys {
+		rules[i] = network.IngressRule{
+			PortRange:   key.portRange,
+			SourceCIDRs: []string{key.cidr},
+		}
+	}
+	network.SortIngressRules(rules)
+	return rules
+}
+
+// restrictedKeys returns the keys of the rules' port ranges and source
+// CIDRs, leaving out those open to any address, which the firewaller
+// handles as plain ports.
+func restrictedKeys(rules []network.IngressRule) []ingressKey {
+	var keys []ingressKey
+	for _, rule := range rules {
+		for _, cidr := range rule.SourceCIDRs {
+			if cidr != network.AnySourceCIDR {
+				keys = append(keys, ingressKey{rule.PortRange, cidr})
+			}
+		}
+	}
+	return keys
+}
+
+func stringsEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
 // parsePortsKey parses a ports document global key coming from the
 // ports watcher (e.g. "42:juju-public") and returns the machine and
 // network tags from its components (in the last example "machine-42"
@@ -81,11 +81,18 @@ func (a *aggregator) loop() error {
 				ids[i] = req.instId
 			}
 			insts, err := a.environ.Instances(ids)
+			var reclaimed map[instance.Id]bool
+			if err == environs.ErrPartialInstances || err == environs.ErrNoInstances {
+				reclaimed = a.reclaimedInstances(ids, insts)
+			}
 			for i, req := range reqs {
 				var reply instanceInfoReply
-				if err != nil && err != environs.ErrPartialInstances {
+				switch {
+				case reclaimed[req.instId]:
+					reply.info = instanceInfo{reclaimed: true}
+				case err != nil && err != environs.ErrPartialInstances:
 					reply.err = err
-				} else {
+				default:
 					reply.info, reply.err = a.instInfo(req.instId, insts[i])
 				}
 				req.reply <- reply
@@ -95,6 +102,53 @@ func (a *aggregator) loop() error {
 	}
 }
 
+// reclaimingEnviron is implemented by environments that may reclaim
+// their instances.
+type reclaimingEnviron interface {
+	environs.InstanceReclaimer
+	StopInstances(ids ...instance.Id) error
+}
+
+// reclaimedInstances returns the set of those of the given ids that
+// were not found in insts because the provider has reclaimed their
+// instances. It returns nil if the environment does not reclaim
+// instances.
+//
+// Reclaimed instances are stopped before they are reported, as the
+// provider may keep them, and their disks, around: their replacements
+// are started under the same names, which would otherwise clash. If
+// they cannot be stopped, none are reported, and they are tried again
+// at the next poll.
+func (a *aggregator) reclaimedInstances(ids []instance.Id, insts []instance.Instance) map[instance.Id]bool {
+	reclaimer, ok := a.environ.(reclaimingEnviron)
+	if !ok {
+		return nil
+	}
+	var missing []instance.Id
+	for i, id := range ids {
+		if insts == nil || insts[i] == nil {
+			missing = append(missing, id)
+		}
+	}
+	reclaimedIds, err := reclaimer.ReclaimedInstances(missing)
+	if err != nil {
+		logger.Warningf("cannot get reclaimed instances: %v", err)
+		return nil
+	}
+	if len(reclaimedIds) == 0 {
+		return nil
+	}
+	if err := reclaimer.StopInstances(reclaimedIds...); err != nil {
+		logger.Warningf("cannot stop reclaimed instances %v: %v", reclaimedIds, err)
+		return nil
+	}
+	reclaimed := make(map[instance.Id]bool)
+	for _, id := range reclaimedIds {
+		reclaimed[id] = true
+	}
+	return reclaimed
+}
+
 // instInfo returns the instance info for the given id
 // and instance. If inst is nil, it returns a not-found error.
 func (*aggregator) instInfo(id instance.Id, inst instance.Instance) (instanceInfo, error) {
@@ -106,8 +160,8 @@ func (*aggregator) instInfo(id instance.Id, inst instance.Instance) (instanceInf
 		return instanceInfo{}, err
 	}
 	return instanceInfo{
-		addr,
-		inst.Status(),
+		addresses: addr,
+		status:    inst.Status(),
 	}, nil
 }
 
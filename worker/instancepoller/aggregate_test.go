@@ -209,6 +209,99 @@ func (s *aggregateSuite) TestPartialErrResponse(c *gc.C) {
 	c.Assert(err, jc.Satisfies, errors.IsNotFound)
 }
 
+type reclaimingInstanceGetter struct {
+	testInstanceGetter
+	// missing is set when the ReclaimedInstances method is called.
+	missing   []instance.Id
+	reclaimed map[instance.Id]bool
+	// stopped is set when the StopInstances method is called, which
+	// then returns stopErr.
+	stopped []instance.Id
+	stopErr error
+}
+
+func (g *reclaimingInstanceGetter) StopInstances(ids ...instance.Id) error {
+	g.stopped = ids
+	return g.stopErr
+}
+
+func (g *reclaimingInstanceGetter) ReclaimedInstances(ids []instance.Id) ([]instance.Id, error) {
+	g.missing = ids
+	var reclaimed []instance.Id
+	for _, id := range ids {
+		if g.reclaimed[id] {
+			reclaimed = append(reclaimed, id)
+		}
+	}
+	return reclaimed, nil
+}
+
+func (s *aggregateSuite) TestReclaimedInstance(c *gc.C) {
+	s.PatchValue(&gatherTime, 30*time.Millisecond)
+	testGetter := &reclaimingInstanceGetter{
+		reclaimed: map[instance.Id]bool{"foo": true},
+	}
+	testGetter.err = environs.ErrNoInstances
+	aggregator := newAggregator(testGetter)
+
+	info, err := aggregator.instanceInfo("foo")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info, gc.DeepEquals, instanceInfo{reclaimed: true})
+	c.Assert(testGetter.missing, gc.DeepEquals, []instance.Id{"foo"})
+	c.Assert(testGetter.stopped, gc.DeepEquals, []instance.Id{"foo"})
+}
+
+func (s *aggregateSuite) TestReclaimedInstanceStopError(c *gc.C) {
+	s.PatchValue(&gatherTime, 30*time.Millisecond)
+	testGetter := &reclaimingInstanceGetter{
+		reclaimed: map[instance.Id]bool{"foo": true},
+		stopErr:   errors.New("stop failed"),
+	}
+	testGetter.err = environs.ErrNoInstances
+	aggregator := newAggregator(testGetter)
+
+	// The instance is not reported reclaimed until it is stopped.
+	_, err := aggregator.instanceInfo("foo")
+	c.Assert(err, gc.Equals, environs.ErrNoInstances)
+	c.Assert(testGetter.stopped, gc.DeepEquals, []instance.Id{"foo"})
+}
+
+func (s *aggregateSuite) TestReclaimedInstancePartial(c *gc.C) {
+	s.PatchValue(&gatherTime, 30*time.Millisecond)
+	testGetter := &reclaimingInstanceGetter{
+		reclaimed: map[instance.Id]bool{"foo2": true},
+	}
+	testGetter.newTestInstance("foo", "foobar", []string{"127.0.0.1"})
+	testGetter.err = environs.ErrPartialInstances
+	aggregator := newAggregator(testGetter)
+
+	// Prime the rate limiter so that the following
+	// requests are aggregated into one batch.
+	_, err := aggregator.instanceInfo("foo")
+	c.Assert(err, jc.ErrorIsNil)
+
+	var wg sync.WaitGroup
+	wg.Add(3)
+	go func() {
+		defer wg.Done()
+		info, err := aggregator.instanceInfo("foo")
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(info.status, gc.Equals, "foobar")
+	}()
+	go func() {
+		defer wg.Done()
+		info, err := aggregator.instanceInfo("foo2")
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(info.reclaimed, jc.IsTrue)
+	}()
+	go func() {
+		defer wg.Done()
+		_, err := aggregator.instanceInfo("foo3")
+		c.Check(err, jc.Satisfies, errors.IsNotFound)
+	}()
+	wg.Wait()
+}
+
 func (s *aggregateSuite) TestAddressesError(c *gc.C) {
 	testGetter := new(testInstanceGetter)
 	instance1 := testGetter.newTestInstance("foo", "foobar", []string{"127.0.0.1", "192.168.1.1"})
@@ -58,6 +58,39 @@ func (s *machineSuite) TestSetsInstanceInfoInitially(c *gc.C) {
 	c.Assert(m.instStatus, gc.Equals, "running")
 }
 
+func (s *machineSuite) TestMarksReclaimedInstance(c *gc.C) {
+	context := &testMachineContext{
+		getInstanceInfo: func(id instance.Id) (instanceInfo, error) {
+			c.Check(id, gc.Equals, instance.Id("i1234"))
+			return instanceInfo{reclaimed: true}, nil
+		},
+		dyingc: make(chan struct{}),
+	}
+	m := &testMachine{
+		tag:        names.NewMachineTag("99"),
+		instanceId: "i1234",
+		instStatus: "running",
+		addresses:  testAddrs,
+		refresh:    func() error { return nil },
+		life:       params.Alive,
+	}
+	died := make(chan machine)
+	s.PatchValue(&ShortPoll, coretesting.ShortWait/10)
+	s.PatchValue(&LongPoll, coretesting.ShortWait/10)
+
+	go runMachine(context, m, nil, died)
+	time.Sleep(coretesting.ShortWait)
+
+	killMachineLoop(c, m, context.dyingc, died)
+	c.Assert(context.killAllErr, gc.Equals, nil)
+	// Once marked reclaimed the machine is no longer provisioned,
+	// so it is not marked again, and its instance info is left alone
+	// for the replacement instance.
+	c.Assert(m.reclaimedCount, gc.Equals, 1)
+	c.Assert(m.setAddressCount, gc.Equals, 0)
+	c.Assert(m.instStatus, gc.Equals, "running")
+}
+
 func (s *machineSuite) TestShortPollIntervalWhenNoAddress(c *gc.C) {
 	s.PatchValue(&ShortPoll, 1*time.Millisecond)
 	s.PatchValue(&LongPoll, coretesting.LongWait)
@@ -121,7 +154,7 @@ func countPolls(c *gc.C, addrs []network.Address, instId, instStatus string, mac
 		if addrs == nil {
 			return instanceInfo{}, fmt.Errorf("no instance addresses available")
 		}
-		return instanceInfo{addrs, instStatus}, nil
+		return instanceInfo{addresses: addrs, status: instStatus}, nil
 	}
 	context := &testMachineContext{
 		getInstanceInfo: getInstanceInfo,
@@ -299,7 +332,7 @@ func instanceInfoGetter(
 
 	return func(id instance.Id) (instanceInfo, error) {
 		c.Check(id, gc.Equals, expectId)
-		return instanceInfo{addrs, status}, err
+		return instanceInfo{addresses: addrs, status: status}, err
 	}
 }
 
@@ -337,6 +370,7 @@ type testMachine struct {
 	life            params.Life
 	addresses       []network.Address
 	setAddressCount int
+	reclaimedCount  int
 }
 
 func (m *testMachine) Tag() names.MachineTag {
@@ -402,6 +436,14 @@ func (m *testMachine) SetProviderAddresses(addrs ...network.Address) error {
 	return nil
 }
 
+func (m *testMachine) MarkReclaimed() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.instanceId = ""
+	m.reclaimedCount++
+	return nil
+}
+
 func (m *testMachine) String() string {
 	return m.tag.Id()
 }
@@ -39,6 +39,7 @@ type machine interface {
 	SetProviderAddresses(...network.Address) error
 	InstanceStatus() (string, error)
 	SetInstanceStatus(status string) error
+	MarkReclaimed() error
 	String() string
 	Refresh() error
 	Life() params.Life
@@ -49,6 +50,9 @@ type machine interface {
 type instanceInfo struct {
 	addresses []network.Address
 	status    string
+	// reclaimed is set when the instance has been taken back by
+	// the provider, in which case the other fields are empty.
+	reclaimed bool
 }
 
 type machineContext interface {
@@ -251,6 +255,13 @@ func pollInstanceInfo(context machineContext, m machine) (instInfo instanceInfo,
 		logger.Warningf("cannot get instance info for instance %q: %v", instId, err)
 		return instInfo, nil
 	}
+	if instInfo.reclaimed {
+		logger.Infof("machine %q instance %q was reclaimed by the provider", m.Id(), instId)
+		if err = m.MarkReclaimed(); err != nil {
+			logger.Errorf("cannot mark machine %q reclaimed: %v", m, err)
+		}
+		return instInfo, nil
+	}
 	currentInstStatus, err := m.InstanceStatus()
 	if err != nil {
 		// This should never occur since the machine is provisioned.
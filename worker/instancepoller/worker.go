@@ -9,6 +9,7 @@ import (
 
 	apiinstancepoller "github.com/juju/juju/api/instancepoller"
 	apiwatcher "github.com/juju/juju/api/watcher"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/worker"
 )
 
@@ -44,7 +45,9 @@ func (u *updaterWorker) Wait() error {
 }
 
 func (u *updaterWorker) loop() (err error) {
-	u.observer, err = worker.NewEnvironObserver(u.st)
+	u.observer, err = worker.NewEnvironObserver(u.st, func(cfg *config.Config) {
+		worker.SetCredentialsLoaded(u.st, "instancepoller", cfg)
+	})
 	if err != nil {
 		return err
 	}
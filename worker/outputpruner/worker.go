@@ -0,0 +1,62 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package outputpruner
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"launchpad.net/tomb"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/worker"
+)
+
+// OutputPruneParams specifies how recorded action and command output
+// should be pruned.
+type OutputPruneParams struct {
+	MaxOutputAge  time.Duration
+	PruneInterval time.Duration
+}
+
+const DefaultMaxOutputAge = 7 * 24 * time.Hour // 7 days
+const DefaultPruneInterval = 5 * time.Minute
+
+// NewOutputPruneParams returns an OutputPruneParams initialised with
+// default values.
+func NewOutputPruneParams() *OutputPruneParams {
+	return &OutputPruneParams{
+		MaxOutputAge:  DefaultMaxOutputAge,
+		PruneInterval: DefaultPruneInterval,
+	}
+}
+
+// New returns a worker which periodically wakes up to remove old
+// chunks of action and command output.
+func New(st *state.State, params *OutputPruneParams) worker.Worker {
+	w := &pruneWorker{
+		st:     st,
+		params: params,
+	}
+	return worker.NewSimpleWorker(w.loop)
+}
+
+type pruneWorker struct {
+	st     *state.State
+	params *OutputPruneParams
+}
+
+func (w *pruneWorker) loop(stopCh <-chan struct{}) error {
+	p := w.params
+	for {
+		select {
+		case <-stopCh:
+			return tomb.ErrDying
+		case <-time.After(p.PruneInterval):
+			if err := state.PruneOutput(w.st, p.MaxOutputAge); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+}
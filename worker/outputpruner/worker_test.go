@@ -0,0 +1,74 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package outputpruner_test
+
+import (
+	stdtesting "testing"
+	"time"
+
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/outputpruner"
+)
+
+func TestPackage(t *stdtesting.T) {
+	testing.MgoTestPackage(t)
+}
+
+var _ = gc.Suite(&suite{})
+
+type suite struct {
+	statetesting.StateSuite
+}
+
+func (s *suite) TestPrunesOldOutput(c *gc.C) {
+	maxOutputAge := 24 * time.Hour
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	unit := names.NewUnitTag("mysql/0")
+	err := s.State.AddOutput("output-id", unit, []state.OutputChunk{{
+		Seq:    1,
+		Stream: state.OutputStdout,
+		Data:   "prune\n",
+		Time:   now.Add(-maxOutputAge - time.Minute),
+	}, {
+		Seq:    2,
+		Stream: state.OutputStdout,
+		Data:   "keep\n",
+		Time:   now,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	pruner := outputpruner.New(s.State, &outputpruner.OutputPruneParams{
+		MaxOutputAge:  maxOutputAge,
+		PruneInterval: time.Millisecond, // Speed up pruning interval for testing
+	})
+	defer func() {
+		pruner.Kill()
+		c.Assert(pruner.Wait(), jc.ErrorIsNil)
+	}()
+
+	for attempt := testing.LongAttempt.Start(); attempt.Next(); {
+		chunks, err := s.State.Output("output-id", nil)
+		c.Assert(err, jc.ErrorIsNil)
+		if len(chunks) == 2 {
+			if !attempt.HasNext() {
+				c.Fatal("timed out waiting for output to be pruned")
+			}
+			continue
+		}
+		c.Assert(chunks, jc.DeepEquals, []state.OutputChunk{{
+			Unit:   "mysql/0",
+			Seq:    2,
+			Stream: state.OutputStdout,
+			Data:   "keep\n",
+			Time:   now,
+		}})
+		break
+	}
+}
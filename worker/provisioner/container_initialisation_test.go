@@ -178,7 +178,7 @@ func (s *ContainerSetupSuite) TestContainerProvisionerStarted(c *gc.C) {
 			Constraints: s.defaultConstraints,
 		})
 		c.Assert(err, jc.ErrorIsNil)
-		err = m.SetSupportedContainers([]instance.ContainerType{instance.LXC, instance.KVM})
+		err = m.SetSupportedContainers(instance.ContainerTypes)
 		c.Assert(err, jc.ErrorIsNil)
 		err = m.SetAgentVersion(version.Current)
 		c.Assert(err, jc.ErrorIsNil)
@@ -200,6 +200,13 @@ func (s *ContainerSetupSuite) TestKvmContainerUsesHostArch(c *gc.C) {
 	s.testContainerConstraintsArch(c, instance.KVM, arch.AMD64)
 }
 
+func (s *ContainerSetupSuite) TestLxdContainerUsesConstraintsArch(c *gc.C) {
+	// LXD should override the architecture in constraints with the
+	// host's architecture.
+	s.PatchValue(&version.Current.Arch, arch.PPC64EL)
+	s.testContainerConstraintsArch(c, instance.LXD, arch.PPC64EL)
+}
+
 func (s *ContainerSetupSuite) testContainerConstraintsArch(c *gc.C, containerType instance.ContainerType, expectArch string) {
 	var called bool
 	s.PatchValue(provisioner.GetToolsFinder, func(*apiprovisioner.State) provisioner.ToolsFinder {
@@ -296,7 +303,7 @@ func (s *ContainerSetupSuite) assertContainerInitialised(c *gc.C, ctype instance
 		Constraints: s.defaultConstraints,
 	})
 	c.Assert(err, jc.ErrorIsNil)
-	err = m.SetSupportedContainers([]instance.ContainerType{instance.LXC, instance.KVM})
+	err = m.SetSupportedContainers(instance.ContainerTypes)
 	c.Assert(err, jc.ErrorIsNil)
 	err = m.SetAgentVersion(version.Current)
 	c.Assert(err, jc.ErrorIsNil)
@@ -341,6 +348,8 @@ func (s *ContainerSetupSuite) TestContainerInitialised(c *gc.C) {
 		{instance.KVM, [][]string{
 			[]string{"uvtool-libvirt"},
 			[]string{"uvtool"}}},
+		{instance.LXD, [][]string{
+			[]string{"lxd"}}},
 	} {
 		s.assertContainerInitialised(c, test.ctype, test.packages, false)
 	}
@@ -189,6 +189,7 @@ func (p *environProvisioner) loop() error {
 		return utils.LoggedErrorStack(errors.Trace(err))
 	}
 	p.broker = p.environ
+	worker.SetCredentialsLoaded(p.st, "environ-provisioner", p.environ.Config())
 
 	harvestMode := p.environ.Config().ProvisionerHarvestMode()
 	task, err := p.getStartTask(harvestMode)
@@ -236,6 +237,7 @@ func (p *environProvisioner) setConfig(environConfig *config.Config) error {
 	if err := p.environ.SetConfig(environConfig); err != nil {
 		return err
 	}
+	worker.SetCredentialsLoaded(p.st, "environ-provisioner", environConfig)
 	p.configObserver.notify(environConfig)
 	return nil
 }
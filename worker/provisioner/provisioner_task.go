@@ -526,9 +526,18 @@ func constructStartInstanceParams(
 				},
 				Volume: volumeTag,
 			},
+			v.Snapshot,
 		}
 	}
 
+	var subnetsToZones map[network.Id][]string
+	for subnetId, zones := range provisioningInfo.SubnetsToZones {
+		if subnetsToZones == nil {
+			subnetsToZones = make(map[network.Id][]string)
+		}
+		subnetsToZones[network.Id(subnetId)] = zones
+	}
+
 	return environs.StartInstanceParams{
 		Constraints:       provisioningInfo.Constraints,
 		Tools:             possibleTools,
@@ -536,6 +545,7 @@ func constructStartInstanceParams(
 		Placement:         provisioningInfo.Placement,
 		DistributionGroup: machine.DistributionGroup,
 		Volumes:           volumes,
+		SubnetsToZones:    subnetsToZones,
 	}, nil
 }
 
@@ -602,6 +612,19 @@ func (task *provisionerTask) setErrorStatus(message string, machine *apiprovisio
 	return nil
 }
 
+// setCredentialsInvalidStatus sets the error status of a machine that
+// could not be started because the provider rejected the credentials.
+// The status data marks it for retrying when the credentials are updated.
+func (task *provisionerTask) setCredentialsInvalidStatus(machine *apiprovisioner.Machine, err error) error {
+	logger.Errorf("cannot start instance for machine %q: credentials invalid: %v", machine, err)
+	message := "credentials invalid: " + err.Error()
+	data := map[string]interface{}{"credentials-invalid": true}
+	if err1 := machine.SetStatus(params.StatusError, message, data); err1 != nil {
+		return errors.Annotatef(err1, "cannot set error status for machine %q", machine)
+	}
+	return nil
+}
+
 func (task *provisionerTask) prepareNetworkAndInterfaces(networkInfo []network.InterfaceInfo) (
 	networks []params.Network, ifaces []params.NetworkInterface, err error) {
 	if len(networkInfo) == 0 {
@@ -641,6 +664,12 @@ func (task *provisionerTask) startMachine(
 
 	result, err := task.broker.StartInstance(startInstanceParams)
 	if err != nil {
+		// Retrying with credentials the provider has rejected cannot
+		// succeed, so report them as invalid; the machine is retried
+		// once they have been updated.
+		if errors.IsUnauthorized(err) {
+			return task.setCredentialsInvalidStatus(machine, err)
+		}
 		// If this is a retryable error, we retry once
 		if instance.IsRetryableCreationError(errors.Cause(err)) {
 			logger.Infof("retryable error received on start instance - retrying instance creation")
@@ -682,6 +682,43 @@ func (s *ProvisionerSuite) TestProvisionerSucceedStartInstanceWithInjectedWrappe
 	s.checkStartInstanceNoSecureConnection(c, m)
 }
 
+func (s *ProvisionerSuite) TestProvisionerFailStartInstanceWithInjectedUnauthorizedError(c *gc.C) {
+	// create the error injection channel
+	errorInjectionChannel := make(chan error, 1)
+	c.Assert(errorInjectionChannel, gc.NotNil)
+
+	p := s.newEnvironProvisioner(c)
+	defer stop(c, p)
+
+	// patch the dummy provider error injection channel
+	cleanup := dummy.PatchTransientErrorInjectionChannel(errorInjectionChannel)
+	defer cleanup()
+
+	// send the error message once
+	// - instance creation should fail without being retried
+	unauthorizedError := errors.NewUnauthorized(nil, "authentication failed")
+	errorInjectionChannel <- unauthorizedError
+
+	m, err := s.addMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	s.checkNoOperations(c)
+
+	t0 := time.Now()
+	for time.Since(t0) < coretesting.LongWait {
+		// And check the machine status is set to error.
+		statusInfo, err := m.Status()
+		c.Assert(err, jc.ErrorIsNil)
+		if statusInfo.Status == state.StatusPending {
+			time.Sleep(coretesting.ShortWait)
+			continue
+		}
+		c.Assert(statusInfo.Status, gc.Equals, state.StatusError)
+		c.Assert(statusInfo.Message, gc.Equals, "credentials invalid: authentication failed")
+		c.Assert(statusInfo.Data, gc.DeepEquals, map[string]interface{}{"credentials-invalid": true})
+		break
+	}
+}
+
 func (s *ProvisionerSuite) TestProvisionerFailStartInstanceWithInjectedNonRetryableCreationError(c *gc.C) {
 	// create the error injection channel
 	errorInjectionChannel := make(chan error, 1)
@@ -1332,6 +1369,30 @@ func (s *ProvisionerSuite) TestProvisionerRetriesTransientErrors(c *gc.C) {
 	c.Assert(err, jc.Satisfies, errors.IsNotProvisioned)
 }
 
+func (s *ProvisionerSuite) TestProvisionerReplacesReclaimedInstance(c *gc.C) {
+	s.PatchValue(&apiserverprovisioner.ErrorRetryWaitDelay, 5*time.Millisecond)
+	task := s.newProvisionerTask(c, config.HarvestDestroyed, s.Environ, s.provisioner, mockToolsFinder{})
+	defer stop(c, task)
+
+	m, err := s.addMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	inst := s.checkStartInstance(c, m)
+
+	// The instance poller marks the machine reclaimed when the
+	// provider takes its instance back; the provisioner then starts
+	// a replacement.
+	err = m.MarkReclaimed()
+	c.Assert(err, jc.ErrorIsNil)
+	replacement := s.checkStartInstance(c, m)
+	c.Assert(replacement.Id(), gc.Not(gc.Equals), inst.Id())
+
+	err = m.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	instId, err := m.InstanceId()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(instId, gc.Equals, replacement.Id())
+}
+
 func (s *ProvisionerSuite) TestProvisionerObservesMachineJobs(c *gc.C) {
 	s.PatchValue(&apiserverprovisioner.ErrorRetryWaitDelay, 5*time.Millisecond)
 	broker := &mockBroker{Environ: s.Environ, retryCount: make(map[string]int)}
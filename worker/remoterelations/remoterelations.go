@@ -0,0 +1,334 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package remoterelations provides a worker that mirrors the relations
+// between an environment's services and the remote services it added
+// by consuming the offers of other environments on the same state
+// server.
+//
+// For each relation with such a remote service, the worker ensures that
+// the offering environment holds a matching relation between the offered
+// service and a remote service standing in for the consuming side. The
+// units in scope on either side, and their relation settings, are then
+// mirrored as the units of the remote service on the other side, so the
+// hooks of the real units run just as they do for local relations.
+package remoterelations
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/names"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/worker"
+)
+
+var logger = loggo.GetLogger("juju.worker.remoterelations")
+
+// DefaultMirrorInterval is the interval at which the worker mirrors
+// relations.
+const DefaultMirrorInterval = 10 * time.Second
+
+// New returns a worker that mirrors the relations of the environment's
+// remote services every mirrorInterval.
+func New(st *state.State, mirrorInterval time.Duration) worker.Worker {
+	return worker.NewPeriodicWorker(func(stop <-chan struct{}) error {
+		return Mirror(st)
+	}, mirrorInterval)
+}
+
+// Mirror mirrors the relations with each of the environment's remote
+// services that were added by consuming an offer. Failures to mirror the
+// relations of a remote service are logged rather than returned, so they
+// do not hold up the others.
+func Mirror(st *state.State) error {
+	services, err := st.AllServices()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	envStates := make(map[string]*state.State)
+	defer func() {
+		for _, envSt := range envStates {
+			envSt.Close()
+		}
+	}()
+	for _, service := range services {
+		info, ok := service.RemoteInfo()
+		if !ok || info.OfferName == "" {
+			continue
+		}
+		offerSt, ok := envStates[info.EnvUUID]
+		if !ok {
+			offerSt, err = st.ForEnviron(names.NewEnvironTag(info.EnvUUID))
+			if err != nil {
+				return errors.Trace(err)
+			}
+			envStates[info.EnvUUID] = offerSt
+		}
+		if err := mirrorService(st, offerSt, service, info); err != nil {
+			logger.Errorf("cannot mirror relations of remote service %q: %v", service.Name(), err)
+		}
+	}
+	return nil
+}
+
+// mirrorService mirrors the relations with the remote service, which
+// was added by consuming the offer described by info, into offerSt.
+func mirrorService(st, offerSt *state.State, remote *state.Service, info state.RemoteServiceInfo) error {
+	offer, err := offerSt.Offer(info.OfferName)
+	if errors.IsNotFound(err) {
+		logger.Warningf("offer %q of remote service %q has been removed", info.OfferName, remote.Name())
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	offered, err := offerSt.Service(offer.ServiceName())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	relations, err := remote.Relations()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, relation := range relations {
+		if err := mirrorRelation(st, offerSt, remote, offered, relation); err != nil {
+			return errors.Annotatef(err, "cannot mirror relation %q", relation)
+		}
+	}
+	return removeDepartedUnits(remote)
+}
+
+// mirrorRelation mirrors the given relation with the remote service into
+// a relation with the offered service in offerSt, and mirrors the units
+// in scope of each relation into the other.
+func mirrorRelation(st, offerSt *state.State, remote, offered *state.Service, relation *state.Relation) error {
+	remoteEp, err := relation.Endpoint(remote.Name())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	relatedEps, err := relation.RelatedEndpoints(remote.Name())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	localEp := relatedEps[0]
+	local, err := st.Service(localEp.ServiceName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	stub, err := ensureRemoteService(offerSt, st.EnvironUUID(), local, offered.GetOwnerTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	offerEps := []state.Endpoint{
+		{ServiceName: offered.Name(), Relation: remoteEp.Relation},
+		{ServiceName: stub.Name(), Relation: localEp.Relation},
+	}
+	offerRelation, err := offerSt.EndpointsRelation(offerEps...)
+	if errors.IsNotFound(err) {
+		if relation.Life() != state.Alive {
+			return leaveScopes(relation, remote)
+		}
+		offerRelation, err = offerSt.AddRelation(offerEps...)
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	if relation.Life() != state.Alive {
+		// The relation is going away, so the mirrored relation must
+		// go too, and the units standing in for the other side must
+		// leave both so that each can be removed.
+		if err := offerRelation.Destroy(); err != nil && !errors.IsNotFound(err) {
+			return errors.Trace(err)
+		}
+		if err := leaveScopes(relation, remote); err != nil {
+			return errors.Trace(err)
+		}
+		if err := leaveScopes(offerRelation, stub); err != nil {
+			return errors.Trace(err)
+		}
+		return removeDepartedUnits(stub)
+	}
+	if offerRelation.Life() != state.Alive {
+		// The previous incarnation of the mirrored relation has not
+		// yet been removed; try again later.
+		return nil
+	}
+	if err := mirrorUnits(relation, local, offerRelation, stub); err != nil {
+		return errors.Trace(err)
+	}
+	if err := mirrorUnits(offerRelation, offered, relation, remote); err != nil {
+		return errors.Trace(err)
+	}
+	return removeDepartedUnits(stub)
+}
+
+// ensureRemoteService returns the remote service in st that stands in
+// for the given service of the environment with the given UUID, adding
+// it if necessary.
+func ensureRemoteService(st *state.State, envUUID string, service *state.Service, owner string) (*state.Service, error) {
+	existing, err := st.Service(service.Name())
+	if err == nil {
+		info, ok := existing.RemoteInfo()
+		if !ok || info.EnvUUID != envUUID || info.ServiceName != service.Name() {
+			return nil, errors.Errorf("service %q already exists in environment %s", service.Name(), st.EnvironUUID())
+		}
+		return existing, nil
+	} else if !errors.IsNotFound(err) {
+		return nil, errors.Trace(err)
+	}
+	ch, _, err := service.Charm()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return st.AddRemoteService(service.Name(), owner, ch, state.RemoteServiceInfo{
+		EnvUUID:     envUUID,
+		ServiceName: service.Name(),
+	})
+}
+
+// mirrorUnits ensures that, for each unit of service in scope of from,
+// a unit of remote standing in for it is in scope of to, with the same
+// relation settings. Units of remote standing in for units no longer in
+// scope of from leave the scope of to.
+func mirrorUnits(from *state.Relation, service *state.Service, to *state.Relation, remote *state.Service) error {
+	units, err := service.AllUnits()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	inScope := make(map[string]bool)
+	for _, unit := range units {
+		ru, err := from.Unit(unit)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if ok, err := ru.InScope(); err != nil {
+			return errors.Trace(err)
+		} else if !ok {
+			continue
+		}
+		inScope[unit.Name()] = true
+		settings, err := ru.Settings()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		stubUnit, err := remote.RemoteUnit(unit.Name())
+		if errors.IsNotFound(err) {
+			logger.Infof("adding unit of %q for %q", remote.Name(), unit.Name())
+			stubUnit, err = remote.AddRemoteUnit(unit.Name())
+		}
+		if err != nil {
+			return errors.Trace(err)
+		}
+		stubRU, err := to.Unit(stubUnit)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if ok, err := stubRU.InScope(); err != nil {
+			return errors.Trace(err)
+		} else if !ok {
+			if err := stubRU.EnterScope(settings.Map()); err != nil {
+				return errors.Trace(err)
+			}
+			continue
+		}
+		stubSettings, err := stubRU.Settings()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := copySettings(settings, stubSettings); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	stubUnits, err := remote.AllUnits()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, stubUnit := range stubUnits {
+		if inScope[stubUnit.RemoteName()] {
+			continue
+		}
+		stubRU, err := to.Unit(stubUnit)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := stubRU.LeaveScope(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// copySettings makes the settings in to the same as those in from.
+func copySettings(from, to *state.Settings) error {
+	fromMap := from.Map()
+	if reflect.DeepEqual(fromMap, to.Map()) {
+		return nil
+	}
+	for _, key := range to.Keys() {
+		if _, ok := fromMap[key]; !ok {
+			to.Delete(key)
+		}
+	}
+	to.Update(fromMap)
+	_, err := to.Write()
+	return errors.Trace(err)
+}
+
+// leaveScopes makes all the units of the remote service leave the scope
+// of the relation.
+func leaveScopes(relation *state.Relation, remote *state.Service) error {
+	units, err := remote.AllUnits()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, unit := range units {
+		ru, err := relation.Unit(unit)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := ru.LeaveScope(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// removeDepartedUnits destroys the units of the remote service that are
+// not in scope of any of its relations.
+func removeDepartedUnits(remote *state.Service) error {
+	units, err := remote.AllUnits()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	relations, err := remote.Relations()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, unit := range units {
+		inScope := false
+		for _, relation := range relations {
+			ru, err := relation.Unit(unit)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if inScope, err = ru.InScope(); err != nil {
+				return errors.Trace(err)
+			} else if inScope {
+				break
+			}
+		}
+		if inScope {
+			continue
+		}
+		logger.Infof("removing unit %q for departed %q", unit.Name(), unit.RemoteName())
+		if err := unit.Destroy(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,188 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package remoterelations_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+	"github.com/juju/juju/worker/remoterelations"
+)
+
+type remoteRelationsSuite struct {
+	testing.JujuConnSuite
+	otherState *state.State
+	mysql      *state.Service
+	wordpress  *state.Service
+	sharedDB   *state.Service
+	relation   *state.Relation
+}
+
+var _ = gc.Suite(&remoteRelationsSuite{})
+
+func (s *remoteRelationsSuite) SetUpTest(c *gc.C) {
+	s.JujuConnSuite.SetUpTest(c)
+	s.otherState = s.Factory.MakeEnvironment(c, nil)
+	s.AddCleanup(func(*gc.C) { s.otherState.Close() })
+
+	otherFactory := factory.NewFactory(s.otherState)
+	s.mysql = otherFactory.MakeService(c, &factory.ServiceParams{
+		Charm:   otherFactory.MakeCharm(c, &factory.CharmParams{Name: "mysql"}),
+		Creator: s.AdminUserTag(c),
+	})
+	_, err := s.otherState.AddOffer("db", "mysql", []string{"server"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	ch, _, err := s.mysql.Charm()
+	c.Assert(err, jc.ErrorIsNil)
+	s.sharedDB, err = s.State.AddRemoteService("shared-db", s.AdminUserTag(c).String(), ch, state.RemoteServiceInfo{
+		EnvUUID:     s.otherState.EnvironUUID(),
+		ServiceName: "mysql",
+		OfferName:   "db",
+		Endpoints:   []string{"server"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.wordpress = s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	eps, err := s.State.InferEndpoints("wordpress", "shared-db")
+	c.Assert(err, jc.ErrorIsNil)
+	s.relation, err = s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *remoteRelationsSuite) mirror(c *gc.C) {
+	err := remoterelations.Mirror(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *remoteRelationsSuite) otherRelation(c *gc.C) *state.Relation {
+	relation, err := s.otherState.EndpointsRelation(
+		state.Endpoint{ServiceName: "mysql", Relation: s.relationEndpoint(c, "shared-db").Relation},
+		state.Endpoint{ServiceName: "wordpress", Relation: s.relationEndpoint(c, "wordpress").Relation},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	return relation
+}
+
+func (s *remoteRelationsSuite) relationEndpoint(c *gc.C, serviceName string) state.Endpoint {
+	ep, err := s.relation.Endpoint(serviceName)
+	c.Assert(err, jc.ErrorIsNil)
+	return ep
+}
+
+func enterScope(c *gc.C, relation *state.Relation, service *state.Service, settings map[string]interface{}) *state.Unit {
+	unit, err := service.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	ru, err := relation.Unit(unit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ru.EnterScope(settings)
+	c.Assert(err, jc.ErrorIsNil)
+	return unit
+}
+
+func assertMirrored(c *gc.C, relation *state.Relation, remote *state.Service, remoteName string, settings map[string]interface{}) {
+	unit, err := remote.RemoteUnit(remoteName)
+	c.Assert(err, jc.ErrorIsNil)
+	ru, err := relation.Unit(unit)
+	c.Assert(err, jc.ErrorIsNil)
+	inScope, err := ru.InScope()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(inScope, jc.IsTrue)
+	actual, err := ru.Settings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(actual.Map(), jc.DeepEquals, settings)
+}
+
+func (s *remoteRelationsSuite) TestMirrorRelation(c *gc.C) {
+	s.mirror(c)
+
+	// The consuming service stands in as a remote service in the
+	// offering environment, related to the offered service.
+	stub, err := s.otherState.Service("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	info, ok := stub.RemoteInfo()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(info, jc.DeepEquals, state.RemoteServiceInfo{
+		EnvUUID:     s.State.EnvironUUID(),
+		ServiceName: "wordpress",
+	})
+	relation := s.otherRelation(c)
+	c.Assert(relation.Life(), gc.Equals, state.Alive)
+
+	// Mirroring again changes nothing.
+	s.mirror(c)
+	c.Assert(s.otherRelation(c).Id(), gc.Equals, relation.Id())
+}
+
+func (s *remoteRelationsSuite) TestMirrorUnits(c *gc.C) {
+	s.mirror(c)
+	otherRelation := s.otherRelation(c)
+	stub, err := s.otherState.Service("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+
+	wordpress0 := enterScope(c, s.relation, s.wordpress, map[string]interface{}{"database": "wp"})
+	mysql0 := enterScope(c, otherRelation, s.mysql, map[string]interface{}{"user": "admin"})
+	s.mirror(c)
+	assertMirrored(c, otherRelation, stub, wordpress0.Name(), map[string]interface{}{"database": "wp"})
+	assertMirrored(c, s.relation, s.sharedDB, mysql0.Name(), map[string]interface{}{"user": "admin"})
+
+	// Changed settings are mirrored.
+	ru, err := otherRelation.Unit(mysql0)
+	c.Assert(err, jc.ErrorIsNil)
+	settings, err := ru.Settings()
+	c.Assert(err, jc.ErrorIsNil)
+	settings.Delete("user")
+	settings.Set("password", "sekrit")
+	_, err = settings.Write()
+	c.Assert(err, jc.ErrorIsNil)
+	s.mirror(c)
+	assertMirrored(c, s.relation, s.sharedDB, mysql0.Name(), map[string]interface{}{"password": "sekrit"})
+
+	// Departed units are removed.
+	ru, err = otherRelation.Unit(mysql0)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ru.LeaveScope()
+	c.Assert(err, jc.ErrorIsNil)
+	s.mirror(c)
+	_, err = s.sharedDB.RemoteUnit(mysql0.Name())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	assertMirrored(c, otherRelation, stub, wordpress0.Name(), map[string]interface{}{"database": "wp"})
+}
+
+func (s *remoteRelationsSuite) TestMirrorDestroyedRelation(c *gc.C) {
+	s.mirror(c)
+	otherRelation := s.otherRelation(c)
+	enterScope(c, s.relation, s.wordpress, nil)
+	mysql0 := enterScope(c, otherRelation, s.mysql, nil)
+	s.mirror(c)
+
+	err := s.relation.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	s.mirror(c)
+
+	err = otherRelation.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(otherRelation.Life(), gc.Equals, state.Dying)
+	_, err = s.sharedDB.RemoteUnit(mysql0.Name())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	stub, err := s.otherState.Service("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	units, err := stub.AllUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.HasLen, 0)
+}
+
+func (s *remoteRelationsSuite) TestMirrorRemovedOffer(c *gc.C) {
+	offer, err := s.otherState.Offer("db")
+	c.Assert(err, jc.ErrorIsNil)
+	err = offer.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.mirror(c)
+	_, err = s.otherState.Service("wordpress")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
@@ -8,12 +8,26 @@ import (
 	"time"
 
 	"launchpad.net/tomb"
+
+	"github.com/juju/juju/monitoring"
 )
 
 // RestartDelay holds the length of time that a worker
 // will wait between exiting and restarting.
 var RestartDelay = 3 * time.Second
 
+// workerRestarts counts the restarts of the workers run by all runners,
+// by worker id.
+var workerRestarts = monitoring.NewCounterVec(
+	"juju_worker_restarts_total",
+	"Number of times a worker has been restarted after exiting.",
+	"worker",
+)
+
+func init() {
+	monitoring.MustRegister(workerRestarts)
+}
+
 // Worker is implemented by a running worker.
 type Worker interface {
 	// Kill asks the worker to stop without necessarily
@@ -225,6 +239,7 @@ func (runner *runner) run() error {
 				delete(workers, info.id)
 				break
 			}
+			workerRestarts.With(info.id).Inc()
 			go runner.runWorker(workerInfo.restartDelay, info.id, workerInfo.start)
 			workerInfo.restartDelay = RestartDelay
 		}
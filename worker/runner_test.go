@@ -83,6 +83,25 @@ func (*runnerSuite) TestOneWorkerRestart(c *gc.C) {
 	starter.assertStarted(c, false)
 }
 
+func (*runnerSuite) TestOneWorkerRestartCounted(c *gc.C) {
+	runner := worker.NewRunner(noneFatal, noImportance)
+	starter := newTestWorkerStarter()
+	err := runner.StartWorker("counted", testWorkerStart(starter))
+	c.Assert(err, jc.ErrorIsNil)
+	starter.assertStarted(c, true)
+	restarts := worker.WorkerRestarts.With("counted").Value()
+
+	for i := 0; i < 2; i++ {
+		starter.die <- fmt.Errorf("an error")
+		starter.assertStarted(c, false)
+		starter.assertStarted(c, true)
+	}
+	c.Assert(worker.WorkerRestarts.With("counted").Value(), gc.Equals, restarts+2)
+
+	c.Assert(worker.Stop(runner), gc.IsNil)
+	starter.assertStarted(c, false)
+}
+
 func (*runnerSuite) TestOneWorkerStartFatalError(c *gc.C) {
 	runner := worker.NewRunner(allFatal, noImportance)
 	starter := newTestWorkerStarter()
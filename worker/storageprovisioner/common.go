@@ -24,7 +24,10 @@ func storageEntityLife(ctx *context, tags []names.Tag) (alive, dying, dead []nam
 		return nil, nil, nil, errors.Annotate(err, "getting storage entity life")
 	}
 	for i, result := range lifeResults {
-		if result.Error != nil {
+		if params.IsCodeNotFound(result.Error) {
+			// The entity has been removed; nothing to do.
+			continue
+		} else if result.Error != nil {
 			return nil, nil, nil, errors.Annotatef(
 				result.Error, "getting life of %s",
 				names.ReadableString(tags[i]),
@@ -52,7 +55,10 @@ func attachmentLife(ctx *context, ids []params.MachineStorageId) (
 		return nil, nil, nil, errors.Annotate(err, "getting machine attachment life")
 	}
 	for i, result := range lifeResults {
-		if result.Error != nil {
+		if params.IsCodeNotFound(result.Error) {
+			// The attachment has been removed; nothing to do.
+			continue
+		} else if result.Error != nil {
 			return nil, nil, nil, errors.Annotatef(
 				result.Error, "getting life of %s attached to %s",
 				ids[i].AttachmentTag, ids[i].MachineTag,
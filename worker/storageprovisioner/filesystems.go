@@ -25,19 +25,12 @@ func filesystemsChanged(ctx *context, changes []string) error {
 	if err != nil {
 		return errors.Trace(err)
 	}
-	// TODO(axw) wait for filesystems to have no attachments first.
-	// We can then have the removal of the last attachment trigger
-	// the filesystem's Life being transitioned to Dead, or watch
-	// the attachments until they're all gone. We need to watch
-	// attachments *anyway*, so we can probably integrate the two
-	// things.
 	logger.Debugf("filesystems alive: %v, dying: %v, dead: %v", alive, dying, dead)
-	if err := ensureDead(ctx, dying); err != nil {
-		return errors.Annotate(err, "ensuring filesystems dead")
-	}
-	// Once the entities are Dead, they can be removed from state
-	// after the corresponding cloud storage resources are removed.
-	dead = append(dead, dying...)
+	// Dying filesystems are left alone: their attachments are Dying
+	// too, and the filesystem is made Dead once the last of them has
+	// been detached and removed from state. Dead filesystems can be
+	// removed from state after the corresponding cloud storage
+	// resources are removed.
 	if len(alive)+len(dead) == 0 {
 		return nil
 	}
@@ -115,31 +108,46 @@ func processDeadFilesystems(ctx *context, tags []names.Tag, filesystemResults []
 	for _, tag := range tags {
 		delete(ctx.pendingFilesystems, tag.(names.FilesystemTag))
 	}
-	filesystems := make([]params.Filesystem, len(filesystemResults))
+	// Filesystems that were never provisioned can be removed
+	// from state immediately; the others must be destroyed first.
+	destroyed := make([]names.Tag, 0, len(tags))
+	provisioned := make([]names.FilesystemTag, 0, len(tags))
 	for i, result := range filesystemResults {
-		if result.Error != nil {
+		if result.Error == nil {
+			provisioned = append(provisioned, tags[i].(names.FilesystemTag))
+			continue
+		}
+		if !params.IsCodeNotProvisioned(result.Error) {
 			return errors.Annotatef(result.Error, "getting filesystem information for filesystem %q", tags[i].Id())
 		}
-		filesystems[i] = result.Result
-	}
-	if len(filesystems) == 0 {
-		return nil
-	}
-	errorResults, err := destroyFilesystems(filesystems)
-	if err != nil {
-		return errors.Annotate(err, "destroying filesystems")
+		destroyed = append(destroyed, tags[i])
 	}
-	destroyed := make([]names.Tag, 0, len(tags))
-	for i, tag := range tags {
-		if err := errorResults[i]; err != nil {
-			logger.Errorf("destroying %s: %v", names.ReadableString(tag), err)
-			continue
+	if len(provisioned) > 0 {
+		destroyParams, err := filesystemDestroyParams(ctx, provisioned)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		errs, err := destroyFilesystems(ctx, destroyParams)
+		if err != nil {
+			return errors.Annotate(err, "destroying filesystems")
+		}
+		for i, tag := range provisioned {
+			if err := errs[i]; err != nil {
+				logger.Errorf("destroying %s: %v", names.ReadableString(tag), err)
+				continue
+			}
+			destroyed = append(destroyed, tag)
 		}
-		destroyed = append(destroyed, tag)
+	}
+	if len(destroyed) == 0 {
+		return nil
 	}
 	if err := removeEntities(ctx, destroyed); err != nil {
 		return errors.Annotate(err, "removing filesystems from state")
 	}
+	for _, tag := range destroyed {
+		delete(ctx.filesystems, tag.(names.FilesystemTag))
+	}
 	return nil
 }
 
@@ -150,31 +158,45 @@ func processDyingFilesystemAttachments(
 	ids []params.MachineStorageId,
 	filesystemAttachmentResults []params.FilesystemAttachmentResult,
 ) error {
-	filesystemAttachments := make([]params.FilesystemAttachment, len(filesystemAttachmentResults))
+	for _, id := range ids {
+		delete(ctx.pendingFilesystemAttachments, id)
+	}
+	// Attachments that were never made can be removed from state
+	// immediately; the others must be detached first.
+	detached := make([]params.MachineStorageId, 0, len(ids))
+	attached := make([]params.MachineStorageId, 0, len(ids))
 	for i, result := range filesystemAttachmentResults {
-		if result.Error != nil {
+		if result.Error == nil {
+			attached = append(attached, ids[i])
+			continue
+		}
+		if !params.IsCodeNotProvisioned(result.Error) {
 			return errors.Annotatef(result.Error, "getting information for filesystem attachment %v", ids[i])
 		}
-		filesystemAttachments[i] = result.Result
+		detached = append(detached, ids[i])
 	}
-	if len(filesystemAttachments) == 0 {
-		return nil
-	}
-	errorResults, err := detachFilesystems(filesystemAttachments)
-	if err != nil {
-		return errors.Annotate(err, "detaching filesystems")
-	}
-	detached := make([]params.MachineStorageId, 0, len(ids))
-	for i, id := range ids {
-		if err := errorResults[i]; err != nil {
-			logger.Errorf("detaching %v from %v: %v", ids[i].AttachmentTag, ids[i].MachineTag, err)
-			continue
+	if len(attached) > 0 {
+		errs, err := detachFilesystems(ctx, attached)
+		if err != nil {
+			return errors.Annotate(err, "detaching filesystems")
+		}
+		for i, id := range attached {
+			if err := errs[i]; err != nil {
+				logger.Errorf("detaching %v from %v: %v", id.AttachmentTag, id.MachineTag, err)
+				continue
+			}
+			detached = append(detached, id)
 		}
-		detached = append(detached, id)
+	}
+	if len(detached) == 0 {
+		return nil
 	}
 	if err := removeAttachments(ctx, detached); err != nil {
 		return errors.Annotate(err, "removing attachments from state")
 	}
+	for _, id := range detached {
+		delete(ctx.filesystemAttachments, id)
+	}
 	return nil
 }
 
@@ -183,6 +205,7 @@ func processDyingFilesystemAttachments(
 func processAliveFilesystems(ctx *context, tags []names.Tag, filesystemResults []params.FilesystemResult) error {
 	// Filter out the already-provisioned filesystems.
 	pending := make([]names.FilesystemTag, 0, len(tags))
+	provisioned := make([]storage.Filesystem, 0, len(tags))
 	for i, result := range filesystemResults {
 		filesystemTag := tags[i].(names.FilesystemTag)
 		if result.Error == nil {
@@ -198,6 +221,8 @@ func processAliveFilesystems(ctx *context, tags []names.Tag, filesystemResults [
 				// devices are present even after creating the
 				// filesystem, so that attachments can be made.
 				maybeAddPendingVolumeBlockDevice(ctx, filesystem.Volume)
+			} else if filesystem.FilesystemId != "" {
+				provisioned = append(provisioned, filesystem)
 			}
 			continue
 		}
@@ -210,6 +235,9 @@ func processAliveFilesystems(ctx *context, tags []names.Tag, filesystemResults [
 		// to enquire about parameters below.
 		pending = append(pending, filesystemTag)
 	}
+	if err := refreshFilesystems(ctx, provisioned); err != nil {
+		return errors.Annotate(err, "refreshing filesystems")
+	}
 	if len(pending) == 0 {
 		return nil
 	}
@@ -240,6 +268,62 @@ func processAliveFilesystems(ctx *context, tags []names.Tag, filesystemResults [
 	return nil
 }
 
+// refreshFilesystems describes the specified provisioned filesystems
+// using the filesystem sources that created them, and records any
+// change in their size in state. Volume-backed filesystems are not
+// refreshed, as they are described from the storage provisioner's
+// own records.
+func refreshFilesystems(ctx *context, filesystems []storage.Filesystem) error {
+	if len(filesystems) == 0 {
+		return nil
+	}
+	// The destroy parameters identify the source
+	// that created each provisioned filesystem.
+	tags := make([]names.FilesystemTag, len(filesystems))
+	for i, filesystem := range filesystems {
+		tags[i] = filesystem.Tag
+	}
+	destroyParams, err := filesystemDestroyParams(ctx, tags)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	indicesBySource := make(map[string][]int)
+	for i, p := range destroyParams {
+		indicesBySource[p.Provider] = append(indicesBySource[p.Provider], i)
+	}
+	var changed []storage.Filesystem
+	for sourceName, indices := range indicesBySource {
+		filesystemSource, err := filesystemSource(
+			ctx.environConfig, ctx.storageDir, sourceName, storage.ProviderType(sourceName),
+		)
+		if err != nil {
+			return errors.Annotate(err, "getting filesystem source")
+		}
+		filesystemIds := make([]string, len(indices))
+		for i, index := range indices {
+			filesystemIds[i] = filesystems[index].FilesystemId
+		}
+		logger.Debugf("describing filesystems: %v", filesystemIds)
+		infos, err := filesystemSource.DescribeFilesystems(filesystemIds)
+		if err != nil {
+			// The filesystems are left as they were recorded
+			// in state; they will be described again the next
+			// time they change.
+			logger.Errorf("describing filesystems from source %q: %v", sourceName, err)
+			continue
+		}
+		for i, index := range indices {
+			filesystem := filesystems[index]
+			if infos[i].Size == filesystem.Size {
+				continue
+			}
+			filesystem.Size = infos[i].Size
+			changed = append(changed, filesystem)
+		}
+	}
+	return setFilesystemInfo(ctx, changed)
+}
+
 func maybeAddPendingVolumeBlockDevice(ctx *context, v names.VolumeTag) {
 	if _, ok := ctx.volumeBlockDevices[v]; !ok {
 		ctx.pendingVolumeBlockDevices.Add(v)
@@ -549,12 +633,137 @@ func createFilesystemAttachments(
 	return allFilesystemAttachments, nil
 }
 
-func destroyFilesystems(filesystems []params.Filesystem) ([]error, error) {
-	panic("not implemented")
+// filesystemDestroyParams returns the parameters for destroying the
+// filesystems with the specified tags.
+func filesystemDestroyParams(ctx *context, tags []names.FilesystemTag) ([]params.FilesystemDestroyParams, error) {
+	paramsResults, err := ctx.filesystemAccessor.FilesystemDestroyParams(tags)
+	if err != nil {
+		return nil, errors.Annotate(err, "getting filesystem destroy params")
+	}
+	destroyParams := make([]params.FilesystemDestroyParams, len(tags))
+	for i, result := range paramsResults {
+		if result.Error != nil {
+			return nil, errors.Annotatef(result.Error, "getting destroy params for filesystem %q", tags[i].Id())
+		}
+		destroyParams[i] = result.Result
+	}
+	return destroyParams, nil
 }
 
-func detachFilesystems(attachments []params.FilesystemAttachment) ([]error, error) {
-	panic("not implemented")
+// destroyFilesystems destroys the filesystems with the specified
+// parameters, returning an error for each filesystem.
+func destroyFilesystems(ctx *context, destroyParams []params.FilesystemDestroyParams) ([]error, error) {
+	// Volume-backed filesystems are managed by the storage provisioner
+	// itself; all others are destroyed by the filesystem source of the
+	// provider that created them.
+	var managedIndices []int
+	indicesBySource := make(map[string][]int)
+	for i, p := range destroyParams {
+		if p.VolumeTag != "" {
+			managedIndices = append(managedIndices, i)
+			continue
+		}
+		indicesBySource[p.Provider] = append(indicesBySource[p.Provider], i)
+	}
+	errs := make([]error, len(destroyParams))
+	destroy := func(filesystemSource storage.FilesystemSource, indices []int) {
+		filesystemIds := make([]string, len(indices))
+		for i, index := range indices {
+			filesystemIds[i] = destroyParams[index].FilesystemId
+		}
+		logger.Debugf("destroying filesystems: %v", filesystemIds)
+		sourceErrs := filesystemSource.DestroyFilesystems(filesystemIds)
+		for i, index := range indices {
+			errs[index] = sourceErrs[i]
+		}
+	}
+	if len(managedIndices) > 0 {
+		destroy(ctx.managedFilesystemSource, managedIndices)
+	}
+	for sourceName, indices := range indicesBySource {
+		filesystemSource, err := filesystemSource(
+			ctx.environConfig, ctx.storageDir, sourceName, storage.ProviderType(sourceName),
+		)
+		if err != nil {
+			return nil, errors.Annotate(err, "getting filesystem source")
+		}
+		destroy(filesystemSource, indices)
+	}
+	return errs, nil
+}
+
+// detachFilesystems detaches the filesystem attachments with the
+// specified IDs, returning an error for each attachment.
+func detachFilesystems(ctx *context, ids []params.MachineStorageId) ([]error, error) {
+	paramsResults, err := ctx.filesystemAccessor.FilesystemAttachmentParams(ids)
+	if err != nil {
+		return nil, errors.Annotate(err, "getting filesystem attachment params")
+	}
+	attachmentParams := make([]storage.FilesystemAttachmentParams, len(ids))
+	filesystemTags := make([]names.FilesystemTag, len(ids))
+	for i, result := range paramsResults {
+		if result.Error != nil {
+			return nil, errors.Annotatef(result.Error, "getting parameters for filesystem attachment %v", ids[i])
+		}
+		params, err := filesystemAttachmentParamsFromParams(result.Result)
+		if err != nil {
+			return nil, errors.Annotate(err, "getting filesystem attachment parameters")
+		}
+		attachmentParams[i] = params
+		filesystemTags[i] = params.Filesystem
+	}
+	filesystemResults, err := ctx.filesystemAccessor.Filesystems(filesystemTags)
+	if err != nil {
+		return nil, errors.Annotate(err, "getting filesystem information")
+	}
+
+	// Volume-backed filesystems are managed by the storage provisioner
+	// itself; all others are detached by the filesystem source of the
+	// provider that created them.
+	var managedIndices []int
+	indicesBySource := make(map[string][]int)
+	for i, result := range filesystemResults {
+		if result.Error != nil {
+			return nil, errors.Annotatef(result.Error, "getting information for filesystem %q", filesystemTags[i].Id())
+		}
+		filesystem, err := filesystemFromParams(result.Result)
+		if err != nil {
+			return nil, errors.Annotate(err, "getting filesystem information")
+		}
+		attachmentParams[i].FilesystemId = filesystem.FilesystemId
+		if filesystem.Volume != (names.VolumeTag{}) {
+			managedIndices = append(managedIndices, i)
+			continue
+		}
+		sourceName := string(attachmentParams[i].Provider)
+		indicesBySource[sourceName] = append(indicesBySource[sourceName], i)
+	}
+	errs := make([]error, len(ids))
+	detach := func(filesystemSource storage.FilesystemSource, indices []int) {
+		sourceParams := make([]storage.FilesystemAttachmentParams, len(indices))
+		for i, index := range indices {
+			sourceParams[i] = attachmentParams[index]
+		}
+		logger.Debugf("detaching filesystems: %v", sourceParams)
+		if err := filesystemSource.DetachFilesystems(sourceParams); err != nil {
+			for _, index := range indices {
+				errs[index] = err
+			}
+		}
+	}
+	if len(managedIndices) > 0 {
+		detach(ctx.managedFilesystemSource, managedIndices)
+	}
+	for sourceName, indices := range indicesBySource {
+		filesystemSource, err := filesystemSource(
+			ctx.environConfig, ctx.storageDir, sourceName, storage.ProviderType(sourceName),
+		)
+		if err != nil {
+			return nil, errors.Annotate(err, "getting filesystem source")
+		}
+		detach(filesystemSource, indices)
+	}
+	return errs, nil
 }
 
 func filesystemsFromStorage(in []storage.Filesystem) []params.Filesystem {
@@ -33,6 +33,16 @@ var missingVolumeAttachmentId = params.MachineStorageId{
 	AttachmentTag: "volume-1",
 }
 
+var dyingFilesystemAttachmentId = params.MachineStorageId{
+	MachineTag:    "machine-0",
+	AttachmentTag: "filesystem-101",
+}
+
+var dyingUnprovisionedFilesystemAttachmentId = params.MachineStorageId{
+	MachineTag:    "machine-1",
+	AttachmentTag: "filesystem-101",
+}
+
 type mockNotifyWatcher struct {
 	changes chan struct{}
 }
@@ -85,6 +95,7 @@ type mockEnvironAccessor struct {
 	watcher *mockNotifyWatcher
 	mu      sync.Mutex
 	cfg     *config.Config
+	loaded  map[string]string
 }
 
 func (e *mockEnvironAccessor) WatchForEnvironConfigChanges() (apiwatcher.NotifyWatcher, error) {
@@ -98,6 +109,13 @@ func (e *mockEnvironAccessor) EnvironConfig() (*config.Config, error) {
 	return cfg, nil
 }
 
+func (e *mockEnvironAccessor) SetCredentialsLoaded(worker, fingerprint string) error {
+	e.mu.Lock()
+	e.loaded[worker] = fingerprint
+	e.mu.Unlock()
+	return nil
+}
+
 func (e *mockEnvironAccessor) setConfig(cfg *config.Config) {
 	e.mu.Lock()
 	e.cfg = cfg
@@ -108,6 +126,7 @@ func newMockEnvironAccessor(c *gc.C) *mockEnvironAccessor {
 	return &mockEnvironAccessor{
 		watcher: &mockNotifyWatcher{make(chan struct{}, 1)},
 		cfg:     testing.EnvironConfig(c),
+		loaded:  make(map[string]string),
 	}
 }
 
@@ -119,9 +138,15 @@ type mockVolumeAccessor struct {
 	provisionedVolumes     map[string]params.Volume
 	provisionedAttachments map[params.MachineStorageId]params.VolumeAttachment
 	blockDevices           map[params.MachineStorageId]storage.BlockDevice
+	snapshotsWatcher       *mockStringsWatcher
+	takenSnapshots         map[string]params.VolumeSnapshot
+	resizesWatcher         *mockStringsWatcher
 
 	setVolumeInfo           func([]params.Volume) ([]params.ErrorResult, error)
 	setVolumeAttachmentInfo func([]params.VolumeAttachment) ([]params.ErrorResult, error)
+	setVolumeSnapshotInfo   func([]params.VolumeSnapshot) ([]params.ErrorResult, error)
+	removeVolumeSnapshots   func([]string) ([]params.ErrorResult, error)
+	setVolumesResized       func([]params.VolumeSize) ([]params.ErrorResult, error)
 }
 
 func (w *mockVolumeAccessor) WatchVolumes() (apiwatcher.StringsWatcher, error) {
@@ -238,6 +263,93 @@ func (v *mockVolumeAccessor) SetVolumeAttachmentInfo(volumeAttachments []params.
 	return nil, nil
 }
 
+func (w *mockVolumeAccessor) WatchVolumeSnapshots() (apiwatcher.StringsWatcher, error) {
+	return w.snapshotsWatcher, nil
+}
+
+func (v *mockVolumeAccessor) VolumeSnapshots(ids []string) ([]params.VolumeSnapshotResult, error) {
+	var result []params.VolumeSnapshotResult
+	for _, id := range ids {
+		if snapshot, ok := v.takenSnapshots[id]; ok {
+			result = append(result, params.VolumeSnapshotResult{Result: snapshot})
+		} else {
+			result = append(result, params.VolumeSnapshotResult{
+				Error: common.ServerError(errors.NotProvisionedf("volume snapshot %q", id)),
+			})
+		}
+	}
+	return result, nil
+}
+
+func (v *mockVolumeAccessor) VolumeSnapshotParams(ids []string) ([]params.VolumeSnapshotParamsResult, error) {
+	var result []params.VolumeSnapshotParamsResult
+	for _, id := range ids {
+		result = append(result, params.VolumeSnapshotParamsResult{Result: params.VolumeSnapshotParams{
+			Id:        id,
+			VolumeTag: "volume-1",
+			VolumeId:  "id-1",
+			Provider:  "dummy",
+		}})
+	}
+	return result, nil
+}
+
+func (v *mockVolumeAccessor) SetVolumeSnapshotInfo(snapshots []params.VolumeSnapshot) ([]params.ErrorResult, error) {
+	return v.setVolumeSnapshotInfo(snapshots)
+}
+
+func (v *mockVolumeAccessor) VolumeSnapshotLife(ids []string) ([]params.LifeResult, error) {
+	var result []params.LifeResult
+	for _, id := range ids {
+		switch id {
+		case "removed":
+			result = append(result, params.LifeResult{
+				Error: common.ServerError(errors.NotFoundf("volume snapshot %q", id)),
+			})
+		case "dying":
+			result = append(result, params.LifeResult{Life: params.Dying})
+		default:
+			result = append(result, params.LifeResult{Life: params.Alive})
+		}
+	}
+	return result, nil
+}
+
+func (v *mockVolumeAccessor) RemoveVolumeSnapshots(ids []string) ([]params.ErrorResult, error) {
+	if v.removeVolumeSnapshots != nil {
+		return v.removeVolumeSnapshots(ids)
+	}
+	return make([]params.ErrorResult, len(ids)), nil
+}
+
+func (w *mockVolumeAccessor) WatchVolumeResizes() (apiwatcher.StringsWatcher, error) {
+	return w.resizesWatcher, nil
+}
+
+func (v *mockVolumeAccessor) VolumeResizeParams(tags []names.VolumeTag) ([]params.VolumeResizeParamsResult, error) {
+	var result []params.VolumeResizeParamsResult
+	for _, tag := range tags {
+		if tag.Id() == "9" {
+			// Volume 9 has already been resized.
+			result = append(result, params.VolumeResizeParamsResult{
+				Error: common.ServerError(errors.NotFoundf("pending resize of volume %q", tag.Id())),
+			})
+			continue
+		}
+		result = append(result, params.VolumeResizeParamsResult{Result: params.VolumeResizeParams{
+			VolumeTag: tag.String(),
+			VolumeId:  "id-" + tag.Id(),
+			Provider:  "dummy",
+			Size:      2048,
+		}})
+	}
+	return result, nil
+}
+
+func (v *mockVolumeAccessor) SetVolumesResized(sizes []params.VolumeSize) ([]params.ErrorResult, error) {
+	return v.setVolumesResized(sizes)
+}
+
 func newMockVolumeAccessor() *mockVolumeAccessor {
 	return &mockVolumeAccessor{
 		volumesWatcher:         &mockStringsWatcher{make(chan []string, 1)},
@@ -247,6 +359,9 @@ func newMockVolumeAccessor() *mockVolumeAccessor {
 		provisionedVolumes:     make(map[string]params.Volume),
 		provisionedAttachments: make(map[params.MachineStorageId]params.VolumeAttachment),
 		blockDevices:           make(map[params.MachineStorageId]storage.BlockDevice),
+		snapshotsWatcher:       &mockStringsWatcher{make(chan []string, 1)},
+		takenSnapshots:         make(map[string]params.VolumeSnapshot),
+		resizesWatcher:         &mockStringsWatcher{make(chan []string, 1)},
 	}
 }
 
@@ -335,12 +450,33 @@ func (f *mockFilesystemAccessor) FilesystemAttachmentParams(ids []params.Machine
 			FilesystemTag: id.AttachmentTag,
 			InstanceId:    string(instanceId),
 			Provider:      "dummy",
+			MountPoint:    f.provisionedAttachments[id].Info.MountPoint,
 			ReadOnly:      true,
 		}})
 	}
 	return result, nil
 }
 
+func (f *mockFilesystemAccessor) FilesystemDestroyParams(tags []names.FilesystemTag) ([]params.FilesystemDestroyParamsResult, error) {
+	var result []params.FilesystemDestroyParamsResult
+	for _, tag := range tags {
+		filesystem, ok := f.provisionedFilesystems[tag.String()]
+		if !ok {
+			result = append(result, params.FilesystemDestroyParamsResult{
+				Error: common.ServerError(errors.NotProvisionedf("filesystem %q", tag.Id())),
+			})
+			continue
+		}
+		result = append(result, params.FilesystemDestroyParamsResult{Result: params.FilesystemDestroyParams{
+			FilesystemTag: tag.String(),
+			FilesystemId:  filesystem.Info.FilesystemId,
+			VolumeTag:     filesystem.VolumeTag,
+			Provider:      "dummy",
+		}})
+	}
+	return result, nil
+}
+
 func (f *mockFilesystemAccessor) SetFilesystemInfo(filesystems []params.Filesystem) ([]params.ErrorResult, error) {
 	return f.setFilesystemInfo(filesystems)
 }
@@ -363,16 +499,21 @@ func newMockFilesystemAccessor() *mockFilesystemAccessor {
 }
 
 type mockLifecycleManager struct {
+	remove            func([]names.Tag) ([]params.ErrorResult, error)
+	removeAttachments func([]params.MachineStorageId) ([]params.ErrorResult, error)
 }
 
 func (m *mockLifecycleManager) Life(volumes []names.Tag) ([]params.LifeResult, error) {
 	var result []params.LifeResult
 	for _, tag := range volumes {
 		id, _ := strconv.Atoi(tag.Id())
-		if id <= 100 {
+		switch {
+		case id <= 100:
 			result = append(result, params.LifeResult{Life: params.Alive})
-		} else {
+		case id <= 200:
 			result = append(result, params.LifeResult{Life: params.Dying})
+		default:
+			result = append(result, params.LifeResult{Life: params.Dead})
 		}
 	}
 	return result, nil
@@ -382,7 +523,7 @@ func (m *mockLifecycleManager) AttachmentLife(ids []params.MachineStorageId) ([]
 	var result []params.LifeResult
 	for _, id := range ids {
 		switch id {
-		case dyingVolumeAttachmentId:
+		case dyingVolumeAttachmentId, dyingFilesystemAttachmentId, dyingUnprovisionedFilesystemAttachmentId:
 			result = append(result, params.LifeResult{Life: params.Dying})
 		case missingVolumeAttachmentId:
 			result = append(result, params.LifeResult{
@@ -399,11 +540,17 @@ func (m *mockLifecycleManager) EnsureDead([]names.Tag) ([]params.ErrorResult, er
 	return nil, nil
 }
 
-func (m *mockLifecycleManager) Remove([]names.Tag) ([]params.ErrorResult, error) {
+func (m *mockLifecycleManager) Remove(tags []names.Tag) ([]params.ErrorResult, error) {
+	if m.remove != nil {
+		return m.remove(tags)
+	}
 	return nil, nil
 }
 
-func (m *mockLifecycleManager) RemoveAttachments([]params.MachineStorageId) ([]params.ErrorResult, error) {
+func (m *mockLifecycleManager) RemoveAttachments(ids []params.MachineStorageId) ([]params.ErrorResult, error) {
+	if m.removeAttachments != nil {
+		return m.removeAttachments(ids)
+	}
 	return nil, nil
 }
 
@@ -418,12 +565,26 @@ type dummyProvider struct {
 
 type dummyVolumeSource struct {
 	storage.VolumeSource
-	createVolumesArgs [][]storage.VolumeParams
+	createVolumesArgs          [][]storage.VolumeParams
+	createVolumeSnapshotsArgs  [][]storage.VolumeSnapshotParams
+	destroyVolumeSnapshotsArgs [][]string
+	resizeVolumesArgs          [][]storage.VolumeResizeParams
+
+	// destroyVolumeSnapshotsFailures is the number of calls to
+	// DestroyVolumeSnapshots that will fail before it succeeds.
+	destroyVolumeSnapshotsFailures int
 }
 
 type dummyFilesystemSource struct {
 	storage.FilesystemSource
-	createFilesystemsArgs [][]storage.FilesystemParams
+	createFilesystemsArgs   [][]storage.FilesystemParams
+	describeFilesystemsArgs [][]string
+	destroyFilesystemsArgs  [][]string
+	detachFilesystemsArgs   [][]storage.FilesystemAttachmentParams
+
+	// filesystemSizes holds the sizes that DescribeFilesystems
+	// reports for filesystems, keyed by filesystem ID.
+	filesystemSizes map[string]uint64
 }
 
 func (p *dummyProvider) VolumeSource(environConfig *config.Config, providerConfig *storage.Config) (storage.VolumeSource, error) {
@@ -493,6 +654,68 @@ func (*dummyVolumeSource) AttachVolumes(params []storage.VolumeAttachmentParams)
 	return volumeAttachments, nil
 }
 
+// CreateVolumeSnapshots makes some volume snapshots that we can check
+// later to ensure things went as expected.
+func (s *dummyVolumeSource) CreateVolumeSnapshots(params []storage.VolumeSnapshotParams) ([]storage.VolumeSnapshot, error) {
+	paramsCopy := make([]storage.VolumeSnapshotParams, len(params))
+	copy(paramsCopy, params)
+	s.createVolumeSnapshotsArgs = append(s.createVolumeSnapshotsArgs, paramsCopy)
+
+	var snapshots []storage.VolumeSnapshot
+	for _, p := range params {
+		if p.VolumeId == "" {
+			panic("CreateVolumeSnapshots called with unprovisioned volume")
+		}
+		snapshots = append(snapshots, storage.VolumeSnapshot{
+			p.Snapshot,
+			storage.VolumeSnapshotInfo{
+				SnapshotId: "snap-" + p.Snapshot,
+				VolumeId:   p.VolumeId,
+				Size:       1024,
+			},
+		})
+	}
+	return snapshots, nil
+}
+
+// DestroyVolumeSnapshots records the snapshots it is asked to destroy,
+// failing the first destroyVolumeSnapshotsFailures calls.
+func (s *dummyVolumeSource) DestroyVolumeSnapshots(snapshotIds []string) []error {
+	s.destroyVolumeSnapshotsArgs = append(s.destroyVolumeSnapshotsArgs, snapshotIds)
+	errs := make([]error, len(snapshotIds))
+	if s.destroyVolumeSnapshotsFailures > 0 {
+		s.destroyVolumeSnapshotsFailures--
+		for i := range errs {
+			errs[i] = errors.New("destroy failed")
+		}
+	}
+	return errs
+}
+
+// ResizeVolumes records the volumes it is asked to resize, failing
+// to resize the volume with the ID "id-2", and failing the first
+// resizeVolumesFailures calls.
+func (s *dummyVolumeSource) ResizeVolumes(params []storage.VolumeResizeParams) ([]uint64, []error) {
+	paramsCopy := make([]storage.VolumeResizeParams, len(params))
+	copy(paramsCopy, params)
+	s.resizeVolumesArgs = append(s.resizeVolumesArgs, paramsCopy)
+
+	fail := s.resizeVolumesFailures > 0
+	if fail {
+		s.resizeVolumesFailures--
+	}
+	sizes := make([]uint64, len(params))
+	errs := make([]error, len(params))
+	for i, p := range params {
+		if fail || p.VolumeId == "id-2" {
+			errs[i] = errors.New("resize failed")
+			continue
+		}
+		sizes[i] = p.Size
+	}
+	return sizes, errs
+}
+
 func (*dummyFilesystemSource) ValidateFilesystemParams(params storage.FilesystemParams) error {
 	return nil
 }
@@ -516,6 +739,34 @@ func (s *dummyFilesystemSource) CreateFilesystems(params []storage.FilesystemPar
 	return filesystems, nil
 }
 
+// DescribeFilesystems records the filesystems it is asked to describe,
+// reporting their sizes from filesystemSizes.
+func (s *dummyFilesystemSource) DescribeFilesystems(filesystemIds []string) ([]storage.FilesystemInfo, error) {
+	s.describeFilesystemsArgs = append(s.describeFilesystemsArgs, filesystemIds)
+	infos := make([]storage.FilesystemInfo, len(filesystemIds))
+	for i, filesystemId := range filesystemIds {
+		infos[i] = storage.FilesystemInfo{
+			FilesystemId: filesystemId,
+			Size:         s.filesystemSizes[filesystemId],
+		}
+	}
+	return infos, nil
+}
+
+// DestroyFilesystems records the filesystems it is asked to destroy.
+func (s *dummyFilesystemSource) DestroyFilesystems(filesystemIds []string) []error {
+	s.destroyFilesystemsArgs = append(s.destroyFilesystemsArgs, filesystemIds)
+	return make([]error, len(filesystemIds))
+}
+
+// DetachFilesystems records the filesystem attachments to detach.
+func (s *dummyFilesystemSource) DetachFilesystems(params []storage.FilesystemAttachmentParams) error {
+	paramsCopy := make([]storage.FilesystemAttachmentParams, len(params))
+	copy(paramsCopy, params)
+	s.detachFilesystemsArgs = append(s.detachFilesystemsArgs, paramsCopy)
+	return nil
+}
+
 // AttachFilesystems attaches filesystems to machines.
 func (*dummyFilesystemSource) AttachFilesystems(params []storage.FilesystemAttachmentParams) ([]storage.FilesystemAttachment, error) {
 	var filesystemAttachments []storage.FilesystemAttachment
@@ -564,6 +815,14 @@ func (s *mockManagedFilesystemSource) CreateFilesystems(args []storage.Filesyste
 	return filesystems, nil
 }
 
+func (s *mockManagedFilesystemSource) DescribeFilesystems(filesystemIds []string) ([]storage.FilesystemInfo, error) {
+	return nil, errors.NotImplementedf("DescribeFilesystems")
+}
+
+func (s *mockManagedFilesystemSource) DestroyFilesystems(filesystemIds []string) []error {
+	return make([]error, len(filesystemIds))
+}
+
 func (s *mockManagedFilesystemSource) AttachFilesystems(args []storage.FilesystemAttachmentParams) ([]storage.FilesystemAttachment, error) {
 	var filesystemAttachments []storage.FilesystemAttachment
 	for _, arg := range args {
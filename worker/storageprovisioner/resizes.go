@@ -0,0 +1,127 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storageprovisioner
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/storage"
+)
+
+// volumeResizesChanged is called when requests to resize the volumes
+// with the provided IDs have been seen to have changed.
+func volumeResizesChanged(ctx *context, ids []string) error {
+	tags := make([]names.VolumeTag, len(ids))
+	for i, id := range ids {
+		tags[i] = names.NewVolumeTag(id)
+	}
+	paramsResults, err := ctx.volumeAccessor.VolumeResizeParams(tags)
+	if err != nil {
+		return errors.Annotate(err, "getting volume resize params")
+	}
+	resizeParams := make([]storage.VolumeResizeParams, 0, len(ids))
+	for i, result := range paramsResults {
+		if params.IsCodeNotFound(result.Error) {
+			// The resize has been completed; nothing to do.
+			continue
+		} else if result.Error != nil {
+			return errors.Annotatef(result.Error, "getting resize params for volume %q", ids[i])
+		}
+		p, err := volumeResizeParamsFromParams(result.Result)
+		if err != nil {
+			return errors.Annotate(err, "getting volume resize params")
+		}
+		resizeParams = append(resizeParams, p)
+	}
+	logger.Debugf("volumes pending resize: %v", resizeParams)
+	if len(resizeParams) == 0 {
+		return nil
+	}
+	sizes, failed := resizeVolumes(ctx.environConfig, ctx.storageDir, resizeParams)
+	for _, tag := range failed {
+		ctx.retryVolumeResizes.Add(tag.Id())
+	}
+	if len(sizes) == 0 {
+		return nil
+	}
+	errorResults, err := ctx.volumeAccessor.SetVolumesResized(sizes)
+	if err != nil {
+		return errors.Annotate(err, "publishing volume sizes to state")
+	}
+	for i, result := range errorResults {
+		if result.Error != nil {
+			return errors.Annotatef(
+				result.Error, "publishing size of %s to state",
+				sizes[i].VolumeTag,
+			)
+		}
+	}
+	return nil
+}
+
+// resizeVolumes resizes the volumes with the specified parameters,
+// returning the new sizes of the volumes that were resized, and the
+// tags of the volumes that should be retried. Failing to resize a
+// volume is not fatal to the storage provisioner: the error is logged,
+// and the request to resize the volume remains pending.
+func resizeVolumes(
+	environConfig *config.Config,
+	baseStorageDir string,
+	resizeParams []storage.VolumeResizeParams,
+) ([]params.VolumeSize, []names.VolumeTag) {
+	paramsBySource := make(map[string][]storage.VolumeResizeParams)
+	for _, p := range resizeParams {
+		sourceName := string(p.Provider)
+		paramsBySource[sourceName] = append(paramsBySource[sourceName], p)
+	}
+	var sizes []params.VolumeSize
+	var failed []names.VolumeTag
+	for sourceName, sourceParams := range paramsBySource {
+		volumeSource, err := volumeSource(
+			environConfig, baseStorageDir, sourceName, sourceParams[0].Provider,
+		)
+		if errors.Cause(err) == errNonDynamic {
+			logger.Errorf("cannot resize volumes from non-dynamic source %q", sourceName)
+			continue
+		} else if err != nil {
+			logger.Errorf("getting volume source %q: %v", sourceName, err)
+			for _, p := range sourceParams {
+				failed = append(failed, p.Volume)
+			}
+			continue
+		}
+		logger.Debugf("resizing volumes: %v", sourceParams)
+		newSizes, errs := volumeSource.ResizeVolumes(sourceParams)
+		for i, err := range errs {
+			if err != nil {
+				logger.Errorf("resizing volume %s: %v", sourceParams[i].Volume.Id(), err)
+				if !errors.IsNotSupported(err) {
+					failed = append(failed, sourceParams[i].Volume)
+				}
+				continue
+			}
+			sizes = append(sizes, params.VolumeSize{
+				VolumeTag: sourceParams[i].Volume.String(),
+				Size:      newSizes[i],
+			})
+		}
+	}
+	return sizes, failed
+}
+
+func volumeResizeParamsFromParams(in params.VolumeResizeParams) (storage.VolumeResizeParams, error) {
+	volumeTag, err := names.ParseVolumeTag(in.VolumeTag)
+	if err != nil {
+		return storage.VolumeResizeParams{}, errors.Trace(err)
+	}
+	return storage.VolumeResizeParams{
+		Volume:   volumeTag,
+		VolumeId: in.VolumeId,
+		Provider: storage.ProviderType(in.Provider),
+		Size:     in.Size,
+	}, nil
+}
@@ -0,0 +1,287 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storageprovisioner
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/storage"
+)
+
+// volumeSnapshotsChanged is called when the lifecycle states of the
+// volume snapshots with the provided IDs have been seen to have changed.
+func volumeSnapshotsChanged(ctx *context, ids []string) error {
+	lifeResults, err := ctx.volumeAccessor.VolumeSnapshotLife(ids)
+	if err != nil {
+		return errors.Annotate(err, "getting volume snapshot life")
+	}
+	var alive, dying []string
+	for i, result := range lifeResults {
+		if params.IsCodeNotFound(result.Error) {
+			// The snapshot has been removed; nothing to do.
+			continue
+		} else if result.Error != nil {
+			return errors.Annotatef(result.Error, "getting life of volume snapshot %q", ids[i])
+		}
+		switch result.Life {
+		case params.Alive:
+			alive = append(alive, ids[i])
+		case params.Dying, params.Dead:
+			dying = append(dying, ids[i])
+		}
+	}
+	logger.Debugf("volume snapshots alive: %v, dying: %v", alive, dying)
+	if len(alive)+len(dying) == 0 {
+		return nil
+	}
+
+	// Get snapshot information for alive and dying snapshots, so
+	// we can take or destroy them.
+	ids = append(alive, dying...)
+	snapshotResults, err := ctx.volumeAccessor.VolumeSnapshots(ids)
+	if err != nil {
+		return errors.Annotate(err, "getting volume snapshot information")
+	}
+
+	// Destroy Dying snapshots, and then remove them from state.
+	if err := processDyingVolumeSnapshots(ctx, dying, snapshotResults[len(alive):]); err != nil {
+		return errors.Annotate(err, "destroying volume snapshots")
+	}
+
+	// Take Alive snapshots.
+	if err := processAliveVolumeSnapshots(ctx, alive, snapshotResults[:len(alive)]); err != nil {
+		return errors.Annotate(err, "taking volume snapshots")
+	}
+	return nil
+}
+
+// processAliveVolumeSnapshots processes the VolumeSnapshotResults for
+// Alive volume snapshots, taking snapshots and setting the info in state
+// as necessary.
+func processAliveVolumeSnapshots(ctx *context, ids []string, snapshotResults []params.VolumeSnapshotResult) error {
+	// Filter out the snapshots that have already been taken.
+	pending := make([]string, 0, len(ids))
+	for i, result := range snapshotResults {
+		if result.Error == nil {
+			logger.Debugf("volume snapshot %q is already taken, nothing to do", ids[i])
+			continue
+		}
+		if !params.IsCodeNotProvisioned(result.Error) {
+			return errors.Annotatef(result.Error, "getting information for volume snapshot %q", ids[i])
+		}
+		pending = append(pending, ids[i])
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+	snapshotParams, err := volumeSnapshotParams(ctx, pending)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	snapshots, err := createVolumeSnapshots(ctx.environConfig, ctx.storageDir, snapshotParams)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(snapshots) == 0 {
+		return nil
+	}
+	errorResults, err := ctx.volumeAccessor.SetVolumeSnapshotInfo(volumeSnapshotsFromStorage(snapshots))
+	if err != nil {
+		return errors.Annotate(err, "publishing volume snapshots to state")
+	}
+	for i, result := range errorResults {
+		if result.Error != nil {
+			return errors.Annotatef(
+				result.Error, "publishing volume snapshot %q to state",
+				snapshots[i].Snapshot,
+			)
+		}
+	}
+	return nil
+}
+
+// processDyingVolumeSnapshots processes the VolumeSnapshotResults for
+// Dying volume snapshots, destroying snapshots and removing them from
+// state as necessary.
+func processDyingVolumeSnapshots(ctx *context, ids []string, snapshotResults []params.VolumeSnapshotResult) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	// Snapshots that were never taken can be removed immediately.
+	taken := make(map[string]string)
+	for i, result := range snapshotResults {
+		if result.Error == nil {
+			taken[ids[i]] = result.Result.Info.SnapshotId
+			continue
+		}
+		if !params.IsCodeNotProvisioned(result.Error) {
+			return errors.Annotatef(result.Error, "getting information for volume snapshot %q", ids[i])
+		}
+	}
+	destroyed := make([]string, 0, len(ids))
+	if len(taken) > 0 {
+		takenIds := make([]string, 0, len(taken))
+		for _, id := range ids {
+			if _, ok := taken[id]; ok {
+				takenIds = append(takenIds, id)
+			}
+		}
+		snapshotParams, err := volumeSnapshotParams(ctx, takenIds)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		errs, err := destroyVolumeSnapshots(ctx.environConfig, ctx.storageDir, snapshotParams, taken)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for i, id := range takenIds {
+			if err := errs[i]; err != nil {
+				logger.Errorf("destroying volume snapshot %q: %v", id, err)
+				ctx.retryVolumeSnapshots.Add(id)
+				continue
+			}
+			destroyed = append(destroyed, id)
+		}
+	}
+	for _, id := range ids {
+		if _, ok := taken[id]; !ok {
+			destroyed = append(destroyed, id)
+		}
+	}
+	if len(destroyed) == 0 {
+		return nil
+	}
+	errorResults, err := ctx.volumeAccessor.RemoveVolumeSnapshots(destroyed)
+	if err != nil {
+		return errors.Annotate(err, "removing volume snapshots from state")
+	}
+	for i, result := range errorResults {
+		if result.Error != nil {
+			return errors.Annotatef(result.Error, "removing volume snapshot %q from state", destroyed[i])
+		}
+	}
+	return nil
+}
+
+// volumeSnapshotParams returns the parameters for the volume snapshots
+// with the specified IDs.
+func volumeSnapshotParams(ctx *context, ids []string) ([]storage.VolumeSnapshotParams, error) {
+	paramsResults, err := ctx.volumeAccessor.VolumeSnapshotParams(ids)
+	if err != nil {
+		return nil, errors.Annotate(err, "getting volume snapshot params")
+	}
+	snapshotParams := make([]storage.VolumeSnapshotParams, len(ids))
+	for i, result := range paramsResults {
+		if result.Error != nil {
+			return nil, errors.Annotatef(result.Error, "getting params for volume snapshot %q", ids[i])
+		}
+		p, err := volumeSnapshotParamsFromParams(result.Result)
+		if err != nil {
+			return nil, errors.Annotate(err, "getting volume snapshot params")
+		}
+		snapshotParams[i] = p
+	}
+	return snapshotParams, nil
+}
+
+// createVolumeSnapshots takes volume snapshots with the specified
+// parameters.
+func createVolumeSnapshots(
+	environConfig *config.Config,
+	baseStorageDir string,
+	params []storage.VolumeSnapshotParams,
+) ([]storage.VolumeSnapshot, error) {
+	paramsBySource := make(map[string][]storage.VolumeSnapshotParams)
+	for _, params := range params {
+		sourceName := string(params.Provider)
+		paramsBySource[sourceName] = append(paramsBySource[sourceName], params)
+	}
+	var allSnapshots []storage.VolumeSnapshot
+	for sourceName, params := range paramsBySource {
+		volumeSource, err := volumeSource(
+			environConfig, baseStorageDir, sourceName, params[0].Provider,
+		)
+		if errors.Cause(err) == errNonDynamic {
+			// TODO(axw) we should set an error status for the
+			// snapshots here.
+			logger.Errorf("cannot snapshot volumes from non-dynamic source %q", sourceName)
+			continue
+		} else if err != nil {
+			return nil, errors.Annotate(err, "getting volume source")
+		}
+		logger.Debugf("creating volume snapshots: %v", params)
+		snapshots, err := volumeSource.CreateVolumeSnapshots(params)
+		if err != nil {
+			return nil, errors.Annotatef(err, "creating volume snapshots from source %q", sourceName)
+		}
+		allSnapshots = append(allSnapshots, snapshots...)
+	}
+	return allSnapshots, nil
+}
+
+// destroyVolumeSnapshots destroys the volume snapshots with the specified
+// parameters, returning an error for each snapshot. The taken map holds
+// the provider snapshot ID for each Juju snapshot ID.
+func destroyVolumeSnapshots(
+	environConfig *config.Config,
+	baseStorageDir string,
+	params []storage.VolumeSnapshotParams,
+	taken map[string]string,
+) ([]error, error) {
+	indicesBySource := make(map[string][]int)
+	for i, params := range params {
+		sourceName := string(params.Provider)
+		indicesBySource[sourceName] = append(indicesBySource[sourceName], i)
+	}
+	errs := make([]error, len(params))
+	for sourceName, indices := range indicesBySource {
+		volumeSource, err := volumeSource(
+			environConfig, baseStorageDir, sourceName, params[indices[0]].Provider,
+		)
+		if err != nil {
+			return nil, errors.Annotate(err, "getting volume source")
+		}
+		snapshotIds := make([]string, len(indices))
+		for i, index := range indices {
+			snapshotIds[i] = taken[params[index].Snapshot]
+		}
+		sourceErrs := volumeSource.DestroyVolumeSnapshots(snapshotIds)
+		for i, index := range indices {
+			errs[index] = sourceErrs[i]
+		}
+	}
+	return errs, nil
+}
+
+func volumeSnapshotsFromStorage(in []storage.VolumeSnapshot) []params.VolumeSnapshot {
+	out := make([]params.VolumeSnapshot, len(in))
+	for i, s := range in {
+		out[i] = params.VolumeSnapshot{
+			s.Snapshot,
+			"", // the volume is known to state
+			params.VolumeSnapshotInfo{
+				s.SnapshotId,
+				s.Size,
+			},
+		}
+	}
+	return out
+}
+
+func volumeSnapshotParamsFromParams(in params.VolumeSnapshotParams) (storage.VolumeSnapshotParams, error) {
+	volumeTag, err := names.ParseVolumeTag(in.VolumeTag)
+	if err != nil {
+		return storage.VolumeSnapshotParams{}, errors.Trace(err)
+	}
+	return storage.VolumeSnapshotParams{
+		Snapshot:     in.Id,
+		Volume:       volumeTag,
+		VolumeId:     in.VolumeId,
+		Provider:     storage.ProviderType(in.Provider),
+		ResourceTags: in.Tags,
+	}, nil
+}
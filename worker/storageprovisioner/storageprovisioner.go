@@ -4,6 +4,8 @@
 package storageprovisioner
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/names"
@@ -23,6 +25,10 @@ var logger = loggo.GetLogger("juju.worker.storageprovisioner")
 
 var newManagedFilesystemSource = provider.NewManagedFilesystemSource
 
+// retryInterval is the time the storage provisioner waits before
+// retrying operations that failed in the provider.
+var retryInterval = 30 * time.Second
+
 // VolumeAccessor defines an interface used to allow a storage provisioner
 // worker to perform volume related operations.
 type VolumeAccessor interface {
@@ -63,6 +69,41 @@ type VolumeAccessor interface {
 	// SetVolumeAttachmentInfo records the details of newly provisioned
 	// volume attachments.
 	SetVolumeAttachmentInfo([]params.VolumeAttachment) ([]params.ErrorResult, error)
+
+	// WatchVolumeSnapshots watches for changes to volume snapshots that
+	// this storage provisioner is responsible for.
+	WatchVolumeSnapshots() (apiwatcher.StringsWatcher, error)
+
+	// VolumeSnapshots returns details of volume snapshots with the
+	// specified IDs.
+	VolumeSnapshots([]string) ([]params.VolumeSnapshotResult, error)
+
+	// VolumeSnapshotParams returns the parameters for taking the volume
+	// snapshots with the specified IDs.
+	VolumeSnapshotParams([]string) ([]params.VolumeSnapshotParamsResult, error)
+
+	// SetVolumeSnapshotInfo records the details of newly taken volume
+	// snapshots.
+	SetVolumeSnapshotInfo([]params.VolumeSnapshot) ([]params.ErrorResult, error)
+
+	// VolumeSnapshotLife returns the lifecycle state of the volume
+	// snapshots with the specified IDs.
+	VolumeSnapshotLife([]string) ([]params.LifeResult, error)
+
+	// RemoveVolumeSnapshots removes the volume snapshots with the
+	// specified IDs from state.
+	RemoveVolumeSnapshots([]string) ([]params.ErrorResult, error)
+
+	// WatchVolumeResizes watches for requests to resize volumes that
+	// this storage provisioner is responsible for.
+	WatchVolumeResizes() (apiwatcher.StringsWatcher, error)
+
+	// VolumeResizeParams returns the parameters for resizing the
+	// volumes with the specified tags.
+	VolumeResizeParams([]names.VolumeTag) ([]params.VolumeResizeParamsResult, error)
+
+	// SetVolumesResized records the new sizes of resized volumes.
+	SetVolumesResized([]params.VolumeSize) ([]params.ErrorResult, error)
 }
 
 // FilesystemAccessor defines an interface used to allow a storage provisioner
@@ -91,6 +132,10 @@ type FilesystemAccessor interface {
 	// filesystem attachments with the specified tags.
 	FilesystemAttachmentParams([]params.MachineStorageId) ([]params.FilesystemAttachmentParamsResult, error)
 
+	// FilesystemDestroyParams returns the parameters for destroying the
+	// filesystems with the specified tags.
+	FilesystemDestroyParams([]names.FilesystemTag) ([]params.FilesystemDestroyParamsResult, error)
+
 	// SetFilesystemInfo records the details of newly provisioned filesystems.
 	SetFilesystemInfo([]params.Filesystem) ([]params.ErrorResult, error)
 
@@ -142,6 +187,10 @@ type EnvironAccessor interface {
 
 	// EnvironConfig returns the current environment config.
 	EnvironConfig() (*config.Config, error)
+
+	// SetCredentialsLoaded records the fingerprint of the provider
+	// credentials the worker is using.
+	SetCredentialsLoaded(worker, fingerprint string) error
 }
 
 // NewStorageProvisioner returns a Worker which manages
@@ -208,6 +257,10 @@ func (w *storageprovisioner) loop() error {
 	var filesystemAttachmentsWatcher apiwatcher.MachineStorageIdsWatcher
 	var volumeAttachmentsChanges <-chan []params.MachineStorageId
 	var filesystemAttachmentsChanges <-chan []params.MachineStorageId
+	var volumeSnapshotsWatcher apiwatcher.StringsWatcher
+	var volumeSnapshotsChanges <-chan []string
+	var volumeResizesWatcher apiwatcher.StringsWatcher
+	var volumeResizesChanges <-chan []string
 	var machineBlockDevicesWatcher apiwatcher.NotifyWatcher
 	var machineBlockDevicesChanges <-chan struct{}
 	machineChanges := make(chan names.MachineTag)
@@ -235,6 +288,8 @@ func (w *storageprovisioner) loop() error {
 	defer w.maybeStopWatcher(volumeAttachmentsWatcher)
 	defer w.maybeStopWatcher(filesystemsWatcher)
 	defer w.maybeStopWatcher(filesystemAttachmentsWatcher)
+	defer w.maybeStopWatcher(volumeSnapshotsWatcher)
+	defer w.maybeStopWatcher(volumeResizesWatcher)
 
 	startWatchers := func() error {
 		var err error
@@ -254,10 +309,20 @@ func (w *storageprovisioner) loop() error {
 		if err != nil {
 			return errors.Annotate(err, "watching filesystem attachments")
 		}
+		volumeSnapshotsWatcher, err = w.volumes.WatchVolumeSnapshots()
+		if err != nil {
+			return errors.Annotate(err, "watching volume snapshots")
+		}
+		volumeResizesWatcher, err = w.volumes.WatchVolumeResizes()
+		if err != nil {
+			return errors.Annotate(err, "watching volume resizes")
+		}
 		volumesChanges = volumesWatcher.Changes()
 		filesystemsChanges = filesystemsWatcher.Changes()
 		volumeAttachmentsChanges = volumeAttachmentsWatcher.Changes()
 		filesystemAttachmentsChanges = filesystemAttachmentsWatcher.Changes()
+		volumeSnapshotsChanges = volumeSnapshotsWatcher.Changes()
+		volumeResizesChanges = volumeResizesWatcher.Changes()
 		return nil
 	}
 
@@ -280,6 +345,8 @@ func (w *storageprovisioner) loop() error {
 		pendingVolumeBlockDevices:    make(set.Tags),
 		pendingFilesystems:           make(map[names.FilesystemTag]storage.FilesystemParams),
 		pendingFilesystemAttachments: make(map[params.MachineStorageId]storage.FilesystemAttachmentParams),
+		retryVolumeSnapshots:         make(set.Strings),
+		retryVolumeResizes:           make(set.Strings),
 	}
 	ctx.managedFilesystemSource = newManagedFilesystemSource(
 		ctx.volumeBlockDevices, ctx.filesystems,
@@ -290,15 +357,24 @@ func (w *storageprovisioner) loop() error {
 		}
 	}()
 
+	var retry <-chan time.Time
 	for {
 		// Check if any pending operations can be fulfilled.
 		if err := processPending(&ctx); err != nil {
 			return errors.Trace(err)
 		}
+		if retry == nil && hasRetries(&ctx) {
+			retry = time.After(retryInterval)
+		}
 
 		select {
 		case <-w.tomb.Dying():
 			return tomb.ErrDying
+		case <-retry:
+			retry = nil
+			if err := processRetries(&ctx); err != nil {
+				return errors.Trace(err)
+			}
 		case _, ok := <-environConfigChanges:
 			if !ok {
 				return watcher.EnsureErr(environConfigWatcher)
@@ -315,6 +391,9 @@ func (w *storageprovisioner) loop() error {
 				}
 			}
 			ctx.environConfig = environConfig
+			if _, ok := w.scope.(names.EnvironTag); ok {
+				worker.SetCredentialsLoaded(w.environ, "environ-storageprovisioner", environConfig)
+			}
 		case changes, ok := <-volumesChanges:
 			if !ok {
 				return watcher.EnsureErr(volumesWatcher)
@@ -343,6 +422,20 @@ func (w *storageprovisioner) loop() error {
 			if err := filesystemAttachmentsChanged(&ctx, changes); err != nil {
 				return errors.Trace(err)
 			}
+		case changes, ok := <-volumeSnapshotsChanges:
+			if !ok {
+				return watcher.EnsureErr(volumeSnapshotsWatcher)
+			}
+			if err := volumeSnapshotsChanged(&ctx, changes); err != nil {
+				return errors.Trace(err)
+			}
+		case changes, ok := <-volumeResizesChanges:
+			if !ok {
+				return watcher.EnsureErr(volumeResizesWatcher)
+			}
+			if err := volumeResizesChanged(&ctx, changes); err != nil {
+				return errors.Trace(err)
+			}
 		case _, ok := <-machineBlockDevicesChanges:
 			if !ok {
 				return watcher.EnsureErr(machineBlockDevicesWatcher)
@@ -379,6 +472,32 @@ func processPending(ctx *context) error {
 	return nil
 }
 
+// hasRetries reports whether there are any failed operations
+// waiting to be retried.
+func hasRetries(ctx *context) bool {
+	return !ctx.retryVolumeSnapshots.IsEmpty() || !ctx.retryVolumeResizes.IsEmpty()
+}
+
+// processRetries retries the operations that previously failed in
+// the provider. Operations that fail again are rescheduled.
+func processRetries(ctx *context) error {
+	if !ctx.retryVolumeSnapshots.IsEmpty() {
+		ids := ctx.retryVolumeSnapshots.SortedValues()
+		ctx.retryVolumeSnapshots = make(set.Strings)
+		if err := volumeSnapshotsChanged(ctx, ids); err != nil {
+			return errors.Annotate(err, "retrying volume snapshots")
+		}
+	}
+	if !ctx.retryVolumeResizes.IsEmpty() {
+		ids := ctx.retryVolumeResizes.SortedValues()
+		ctx.retryVolumeResizes = make(set.Strings)
+		if err := volumeResizesChanged(ctx, ids); err != nil {
+			return errors.Annotate(err, "retrying volume resizes")
+		}
+	}
+	return nil
+}
+
 func (p *storageprovisioner) maybeStopWatcher(w watcher.Stopper) {
 	if w != nil {
 		watcher.Stop(w, &p.tomb)
@@ -438,6 +557,15 @@ type context struct {
 	// that are yet to be created.
 	pendingFilesystemAttachments map[params.MachineStorageId]storage.FilesystemAttachmentParams
 
+	// retryVolumeSnapshots contains the IDs of dying volume snapshots
+	// that could not be destroyed, and will be retried after
+	// retryInterval.
+	retryVolumeSnapshots set.Strings
+
+	// retryVolumeResizes contains the IDs of volumes that could not be
+	// resized, and will be retried after retryInterval.
+	retryVolumeResizes set.Strings
+
 	// managedFilesystemSource is a storage.FilesystemSource that
 	// manages filesystems backed by volumes attached to the host
 	// machine.
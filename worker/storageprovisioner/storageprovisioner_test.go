@@ -196,6 +196,205 @@ func (s *storageProvisionerSuite) TestFilesystemAdded(c *gc.C) {
 	waitChannel(c, filesystemInfoSet, "waiting for filesystem info to be set")
 }
 
+func (s *storageProvisionerSuite) TestFilesystemDead(c *gc.C) {
+	removed := make(chan interface{})
+	filesystemAccessor := newMockFilesystemAccessor()
+	filesystemAccessor.provisionedFilesystems["filesystem-201"] = params.Filesystem{
+		FilesystemTag: "filesystem-201",
+		Info:          params.FilesystemInfo{FilesystemId: "id-201"},
+	}
+	lifecycleManager := &mockLifecycleManager{
+		remove: func(tags []names.Tag) ([]params.ErrorResult, error) {
+			defer close(removed)
+			c.Assert(tags, jc.SameContents, []names.Tag{
+				names.NewFilesystemTag("201"),
+				names.NewFilesystemTag("202"),
+			})
+			return make([]params.ErrorResult, len(tags)), nil
+		},
+	}
+
+	var filesystemSource dummyFilesystemSource
+	s.provider.filesystemSourceFunc = func(envConfig *config.Config, sourceConfig *storage.Config) (storage.FilesystemSource, error) {
+		return &filesystemSource, nil
+	}
+
+	environAccessor := newMockEnvironAccessor(c)
+	worker := storageprovisioner.NewStorageProvisioner(
+		coretesting.EnvironmentTag,
+		"storage-dir",
+		newMockVolumeAccessor(),
+		filesystemAccessor,
+		lifecycleManager,
+		environAccessor,
+		newMockMachineAccessor(c),
+	)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	// Filesystems with IDs greater than 200 are Dead. Filesystem
+	// "201" must be destroyed before it is removed from state, but
+	// "202" was never provisioned and can be removed immediately.
+	filesystemAccessor.filesystemsWatcher.changes <- []string{"201", "202"}
+	environAccessor.watcher.changes <- struct{}{}
+	waitChannel(c, removed, "waiting for filesystems to be removed")
+	c.Assert(filesystemSource.destroyFilesystemsArgs, jc.DeepEquals, [][]string{{"id-201"}})
+}
+
+func (s *storageProvisionerSuite) TestFilesystemDying(c *gc.C) {
+	removed := make(chan interface{})
+	filesystemAccessor := newMockFilesystemAccessor()
+	filesystemAccessor.provisionedFilesystems["filesystem-101"] = params.Filesystem{
+		FilesystemTag: "filesystem-101",
+		Info:          params.FilesystemInfo{FilesystemId: "id-101"},
+	}
+	lifecycleManager := &mockLifecycleManager{
+		remove: func(tags []names.Tag) ([]params.ErrorResult, error) {
+			removed <- tags
+			return make([]params.ErrorResult, len(tags)), nil
+		},
+	}
+
+	var filesystemSource dummyFilesystemSource
+	s.provider.filesystemSourceFunc = func(envConfig *config.Config, sourceConfig *storage.Config) (storage.FilesystemSource, error) {
+		return &filesystemSource, nil
+	}
+
+	environAccessor := newMockEnvironAccessor(c)
+	worker := storageprovisioner.NewStorageProvisioner(
+		coretesting.EnvironmentTag,
+		"storage-dir",
+		newMockVolumeAccessor(),
+		filesystemAccessor,
+		lifecycleManager,
+		environAccessor,
+		newMockMachineAccessor(c),
+	)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	// Filesystems with IDs between 101 and 200 are Dying. They may
+	// still be attached, so they must not be destroyed or removed
+	// until they are made Dead.
+	filesystemAccessor.filesystemsWatcher.changes <- []string{"101"}
+	environAccessor.watcher.changes <- struct{}{}
+	assertNoEvent(c, removed, "filesystem removal")
+	c.Assert(filesystemSource.destroyFilesystemsArgs, gc.HasLen, 0)
+}
+
+func (s *storageProvisionerSuite) TestFilesystemAttachmentDying(c *gc.C) {
+	removed := make(chan interface{})
+	filesystemAccessor := newMockFilesystemAccessor()
+	filesystemAccessor.provisionedFilesystems["filesystem-101"] = params.Filesystem{
+		FilesystemTag: "filesystem-101",
+		Info:          params.FilesystemInfo{FilesystemId: "id-101"},
+	}
+	filesystemAccessor.provisionedMachines["machine-0"] = instance.Id("already-provisioned-0")
+	filesystemAccessor.provisionedAttachments[dyingFilesystemAttachmentId] = params.FilesystemAttachment{
+		MachineTag:    "machine-0",
+		FilesystemTag: "filesystem-101",
+		Info:          params.FilesystemAttachmentInfo{MountPoint: "/srv/fs-101"},
+	}
+	lifecycleManager := &mockLifecycleManager{
+		removeAttachments: func(ids []params.MachineStorageId) ([]params.ErrorResult, error) {
+			defer close(removed)
+			c.Assert(ids, jc.SameContents, []params.MachineStorageId{
+				dyingFilesystemAttachmentId,
+				dyingUnprovisionedFilesystemAttachmentId,
+			})
+			return make([]params.ErrorResult, len(ids)), nil
+		},
+	}
+
+	var filesystemSource dummyFilesystemSource
+	s.provider.filesystemSourceFunc = func(envConfig *config.Config, sourceConfig *storage.Config) (storage.FilesystemSource, error) {
+		return &filesystemSource, nil
+	}
+
+	environAccessor := newMockEnvironAccessor(c)
+	worker := storageprovisioner.NewStorageProvisioner(
+		coretesting.EnvironmentTag,
+		"storage-dir",
+		newMockVolumeAccessor(),
+		filesystemAccessor,
+		lifecycleManager,
+		environAccessor,
+		newMockMachineAccessor(c),
+	)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	// The attachment to machine-0 must be detached before it is
+	// removed from state, but the attachment to machine-1 was never
+	// made and can be removed immediately.
+	filesystemAccessor.attachmentsWatcher.changes <- []params.MachineStorageId{
+		dyingFilesystemAttachmentId,
+		dyingUnprovisionedFilesystemAttachmentId,
+	}
+	environAccessor.watcher.changes <- struct{}{}
+	waitChannel(c, removed, "waiting for filesystem attachments to be removed")
+	c.Assert(filesystemSource.detachFilesystemsArgs, jc.DeepEquals, [][]storage.FilesystemAttachmentParams{{{
+		AttachmentParams: storage.AttachmentParams{
+			Provider:   "dummy",
+			Machine:    names.NewMachineTag("0"),
+			InstanceId: "already-provisioned-0",
+			ReadOnly:   true,
+		},
+		Filesystem:   names.NewFilesystemTag("101"),
+		FilesystemId: "id-101",
+		Path:         "/srv/fs-101",
+	}}})
+}
+
+func (s *storageProvisionerSuite) TestFilesystemRefreshed(c *gc.C) {
+	filesystemInfoSet := make(chan interface{})
+	filesystemAccessor := newMockFilesystemAccessor()
+	filesystemAccessor.provisionedFilesystems["filesystem-1"] = params.Filesystem{
+		FilesystemTag: "filesystem-1",
+		Info:          params.FilesystemInfo{FilesystemId: "id-1", Size: 1024},
+	}
+	filesystemAccessor.provisionedFilesystems["filesystem-2"] = params.Filesystem{
+		FilesystemTag: "filesystem-2",
+		Info:          params.FilesystemInfo{FilesystemId: "id-2", Size: 1024},
+	}
+	filesystemAccessor.setFilesystemInfo = func(filesystems []params.Filesystem) ([]params.ErrorResult, error) {
+		defer close(filesystemInfoSet)
+		// Only filesystem-1 has changed in the provider.
+		c.Assert(filesystems, jc.DeepEquals, []params.Filesystem{{
+			FilesystemTag: "filesystem-1",
+			Info:          params.FilesystemInfo{FilesystemId: "id-1", Size: 2048},
+		}})
+		return make([]params.ErrorResult, len(filesystems)), nil
+	}
+
+	filesystemSource := dummyFilesystemSource{
+		filesystemSizes: map[string]uint64{"id-1": 2048, "id-2": 1024},
+	}
+	s.provider.filesystemSourceFunc = func(envConfig *config.Config, sourceConfig *storage.Config) (storage.FilesystemSource, error) {
+		return &filesystemSource, nil
+	}
+
+	environAccessor := newMockEnvironAccessor(c)
+	worker := storageprovisioner.NewStorageProvisioner(
+		coretesting.EnvironmentTag,
+		"storage-dir",
+		newMockVolumeAccessor(),
+		filesystemAccessor,
+		&mockLifecycleManager{},
+		environAccessor,
+		newMockMachineAccessor(c),
+	)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	filesystemAccessor.filesystemsWatcher.changes <- []string{"1", "2"}
+	environAccessor.watcher.changes <- struct{}{}
+	waitChannel(c, filesystemInfoSet, "waiting for filesystem info to be set")
+	c.Assert(filesystemSource.describeFilesystemsArgs, gc.HasLen, 1)
+	c.Assert(filesystemSource.describeFilesystemsArgs[0], jc.SameContents, []string{"id-1", "id-2"})
+	c.Assert(filesystemSource.createFilesystemsArgs, gc.HasLen, 0)
+}
+
 func (s *storageProvisionerSuite) TestVolumeNeedsInstance(c *gc.C) {
 	volumeInfoSet := make(chan interface{})
 	volumeAccessor := newMockVolumeAccessor()
@@ -726,6 +925,225 @@ func (s *storageProvisionerSuite) TestSetVolumeInfoErrorStopsWorker(c *gc.C) {
 	waitChannel(c, done, "waiting for worker to exit")
 }
 
+func (s *storageProvisionerSuite) TestVolumeSnapshotAdded(c *gc.C) {
+	snapshotInfoSet := make(chan interface{})
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.takenSnapshots["0"] = params.VolumeSnapshot{Id: "0"}
+	volumeAccessor.setVolumeSnapshotInfo = func(snapshots []params.VolumeSnapshot) ([]params.ErrorResult, error) {
+		defer close(snapshotInfoSet)
+		c.Assert(snapshots, jc.DeepEquals, []params.VolumeSnapshot{{
+			Id: "1",
+			Info: params.VolumeSnapshotInfo{
+				SnapshotId: "snap-1",
+				Size:       1024,
+			},
+		}})
+		return make([]params.ErrorResult, len(snapshots)), nil
+	}
+
+	var volumeSource dummyVolumeSource
+	s.provider.volumeSourceFunc = func(envConfig *config.Config, sourceConfig *storage.Config) (storage.VolumeSource, error) {
+		return &volumeSource, nil
+	}
+
+	environAccessor := newMockEnvironAccessor(c)
+	worker := storageprovisioner.NewStorageProvisioner(
+		coretesting.EnvironmentTag,
+		"storage-dir",
+		volumeAccessor,
+		newMockFilesystemAccessor(),
+		&mockLifecycleManager{},
+		environAccessor,
+		newMockMachineAccessor(c),
+	)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	// Snapshot "0" has already been taken, and "removed" no longer
+	// exists; only "1" should be taken.
+	volumeAccessor.snapshotsWatcher.changes <- []string{"0", "1", "removed"}
+	environAccessor.watcher.changes <- struct{}{}
+	waitChannel(c, snapshotInfoSet, "waiting for volume snapshot info to be set")
+	c.Assert(volumeSource.createVolumeSnapshotsArgs, jc.DeepEquals, [][]storage.VolumeSnapshotParams{{{
+		Snapshot: "1",
+		Volume:   names.NewVolumeTag("1"),
+		VolumeId: "id-1",
+		Provider: "dummy",
+	}}})
+}
+
+func (s *storageProvisionerSuite) TestVolumeSnapshotDying(c *gc.C) {
+	removed := make(chan interface{})
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.takenSnapshots["dying"] = params.VolumeSnapshot{
+		Id:        "dying",
+		VolumeTag: "volume-1",
+		Info:      params.VolumeSnapshotInfo{SnapshotId: "snap-dying"},
+	}
+	volumeAccessor.removeVolumeSnapshots = func(ids []string) ([]params.ErrorResult, error) {
+		defer close(removed)
+		c.Assert(ids, jc.DeepEquals, []string{"dying"})
+		return make([]params.ErrorResult, len(ids)), nil
+	}
+
+	var volumeSource dummyVolumeSource
+	s.provider.volumeSourceFunc = func(envConfig *config.Config, sourceConfig *storage.Config) (storage.VolumeSource, error) {
+		return &volumeSource, nil
+	}
+
+	environAccessor := newMockEnvironAccessor(c)
+	worker := storageprovisioner.NewStorageProvisioner(
+		coretesting.EnvironmentTag,
+		"storage-dir",
+		volumeAccessor,
+		newMockFilesystemAccessor(),
+		&mockLifecycleManager{},
+		environAccessor,
+		newMockMachineAccessor(c),
+	)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	volumeAccessor.snapshotsWatcher.changes <- []string{"dying"}
+	environAccessor.watcher.changes <- struct{}{}
+	waitChannel(c, removed, "waiting for volume snapshot to be removed")
+	c.Assert(volumeSource.destroyVolumeSnapshotsArgs, jc.DeepEquals, [][]string{{"snap-dying"}})
+}
+
+func (s *storageProvisionerSuite) TestVolumeSnapshotDyingRetried(c *gc.C) {
+	s.PatchValue(storageprovisioner.RetryInterval, time.Millisecond)
+
+	removed := make(chan interface{})
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.takenSnapshots["dying"] = params.VolumeSnapshot{
+		Id:        "dying",
+		VolumeTag: "volume-1",
+		Info:      params.VolumeSnapshotInfo{SnapshotId: "snap-dying"},
+	}
+	volumeAccessor.removeVolumeSnapshots = func(ids []string) ([]params.ErrorResult, error) {
+		defer close(removed)
+		c.Assert(ids, jc.DeepEquals, []string{"dying"})
+		return make([]params.ErrorResult, len(ids)), nil
+	}
+
+	// The first attempt to destroy the snapshot fails; the snapshot
+	// must not be removed from state until it is destroyed.
+	volumeSource := dummyVolumeSource{destroyVolumeSnapshotsFailures: 1}
+	s.provider.volumeSourceFunc = func(envConfig *config.Config, sourceConfig *storage.Config) (storage.VolumeSource, error) {
+		return &volumeSource, nil
+	}
+
+	environAccessor := newMockEnvironAccessor(c)
+	worker := storageprovisioner.NewStorageProvisioner(
+		coretesting.EnvironmentTag,
+		"storage-dir",
+		volumeAccessor,
+		newMockFilesystemAccessor(),
+		&mockLifecycleManager{},
+		environAccessor,
+		newMockMachineAccessor(c),
+	)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	volumeAccessor.snapshotsWatcher.changes <- []string{"dying"}
+	environAccessor.watcher.changes <- struct{}{}
+	waitChannel(c, removed, "waiting for volume snapshot to be removed")
+	c.Assert(volumeSource.destroyVolumeSnapshotsArgs, jc.DeepEquals, [][]string{
+		{"snap-dying"}, {"snap-dying"},
+	})
+}
+
+func (s *storageProvisionerSuite) TestVolumeResized(c *gc.C) {
+	resized := make(chan interface{})
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.setVolumesResized = func(sizes []params.VolumeSize) ([]params.ErrorResult, error) {
+		defer close(resized)
+		// Volume "2" could not be resized, so it is
+		// left pending.
+		c.Assert(sizes, jc.DeepEquals, []params.VolumeSize{{
+			VolumeTag: "volume-1",
+			Size:      2048,
+		}})
+		return make([]params.ErrorResult, len(sizes)), nil
+	}
+
+	var volumeSource dummyVolumeSource
+	s.provider.volumeSourceFunc = func(envConfig *config.Config, sourceConfig *storage.Config) (storage.VolumeSource, error) {
+		return &volumeSource, nil
+	}
+
+	environAccessor := newMockEnvironAccessor(c)
+	worker := storageprovisioner.NewStorageProvisioner(
+		coretesting.EnvironmentTag,
+		"storage-dir",
+		volumeAccessor,
+		newMockFilesystemAccessor(),
+		&mockLifecycleManager{},
+		environAccessor,
+		newMockMachineAccessor(c),
+	)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	// Volume "9" has already been resized; only "1" and
+	// "2" should be resized.
+	volumeAccessor.resizesWatcher.changes <- []string{"1", "2", "9"}
+	environAccessor.watcher.changes <- struct{}{}
+	waitChannel(c, resized, "waiting for volume sizes to be set")
+	c.Assert(volumeSource.resizeVolumesArgs, jc.DeepEquals, [][]storage.VolumeResizeParams{{{
+		Volume:   names.NewVolumeTag("1"),
+		VolumeId: "id-1",
+		Provider: "dummy",
+		Size:     2048,
+	}, {
+		Volume:   names.NewVolumeTag("2"),
+		VolumeId: "id-2",
+		Provider: "dummy",
+		Size:     2048,
+	}}})
+}
+
+func (s *storageProvisionerSuite) TestVolumeResizeRetried(c *gc.C) {
+	s.PatchValue(storageprovisioner.RetryInterval, time.Millisecond)
+
+	resized := make(chan interface{})
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.setVolumesResized = func(sizes []params.VolumeSize) ([]params.ErrorResult, error) {
+		defer close(resized)
+		c.Assert(sizes, jc.DeepEquals, []params.VolumeSize{{
+			VolumeTag: "volume-1",
+			Size:      2048,
+		}})
+		return make([]params.ErrorResult, len(sizes)), nil
+	}
+
+	// The first attempt to resize the volume fails; the resize
+	// must be retried until it succeeds.
+	volumeSource := dummyVolumeSource{resizeVolumesFailures: 1}
+	s.provider.volumeSourceFunc = func(envConfig *config.Config, sourceConfig *storage.Config) (storage.VolumeSource, error) {
+		return &volumeSource, nil
+	}
+
+	environAccessor := newMockEnvironAccessor(c)
+	worker := storageprovisioner.NewStorageProvisioner(
+		coretesting.EnvironmentTag,
+		"storage-dir",
+		volumeAccessor,
+		newMockFilesystemAccessor(),
+		&mockLifecycleManager{},
+		environAccessor,
+		newMockMachineAccessor(c),
+	)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	volumeAccessor.resizesWatcher.changes <- []string{"1"}
+	environAccessor.watcher.changes <- struct{}{}
+	waitChannel(c, resized, "waiting for volume sizes to be set")
+	c.Assert(volumeSource.resizeVolumesArgs, gc.HasLen, 2)
+}
+
 func waitChannel(c *gc.C, ch <-chan interface{}, activity string) interface{} {
 	select {
 	case v := <-ch:
@@ -0,0 +1,241 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package healthcheck reads the health checks a charm declares in its
+// metadata, and runs them on behalf of the uniter.
+//
+// Health checks are declared under the health-checks key of the charm's
+// metadata.yaml, keyed by name. Each check probes the workload in one of
+// three ways: by fetching a URL, by connecting to a TCP address, or by
+// running a script from the charm directory. For example:
+//
+//	health-checks:
+//	  web:
+//	    http: http://localhost:8080/health
+//	    interval: 30s
+//	  db:
+//	    tcp: localhost:5432
+//	    status: maintenance
+//	  daemon:
+//	    script: scripts/check-daemon
+//	    timeout: 30s
+//
+// The status of a check, which is "blocked" unless given, is the
+// workload status the unit reports while the check fails.
+package healthcheck
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	goyaml "gopkg.in/yaml.v1"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+const (
+	// DefaultInterval is the interval at which a check is run if its
+	// declaration does not give one.
+	DefaultInterval = time.Minute
+
+	// DefaultTimeout is the time a check may take before it fails, if
+	// its declaration does not give one.
+	DefaultTimeout = 10 * time.Second
+)
+
+// Check describes a health check declared by a charm. Exactly one of
+// HTTP, TCP and Script is set.
+type Check struct {
+	// Name is the name of the check.
+	Name string
+
+	// HTTP is the URL fetched by an HTTP check. The check passes if
+	// the response status is below 400.
+	HTTP string
+
+	// TCP is the address connected to by a TCP check. The check passes
+	// if the connection is accepted.
+	TCP string
+
+	// Script is the command run, in the charm directory, by a script
+	// check. The check passes if the command exits successfully.
+	Script string
+
+	// Interval is the interval at which the check is run.
+	Interval time.Duration
+
+	// Timeout is the time the check may take before it fails.
+	Timeout time.Duration
+
+	// Status is the workload status set while the check fails; either
+	// blocked or maintenance.
+	Status params.Status
+}
+
+// checkDoc is the metadata.yaml representation of a Check.
+type checkDoc struct {
+	HTTP     string `yaml:"http"`
+	TCP      string `yaml:"tcp"`
+	Script   string `yaml:"script"`
+	Interval string `yaml:"interval"`
+	Timeout  string `yaml:"timeout"`
+	Status   string `yaml:"status"`
+}
+
+// ReadChecks returns the health checks declared in the metadata of the
+// charm deployed in charmDir, sorted by name.
+func ReadChecks(charmDir string) ([]Check, error) {
+	data, err := ioutil.ReadFile(filepath.Join(charmDir, "metadata.yaml"))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var meta struct {
+		HealthChecks map[string]checkDoc `yaml:"health-checks"`
+	}
+	if err := goyaml.Unmarshal(data, &meta); err != nil {
+		return nil, errors.Annotate(err, "cannot parse charm metadata")
+	}
+	var checks []Check
+	for name, doc := range meta.HealthChecks {
+		check, err := parseCheck(name, doc)
+		if err != nil {
+			return nil, errors.Annotatef(err, "invalid health check %q", name)
+		}
+		checks = append(checks, check)
+	}
+	sort.Sort(byName(checks))
+	return checks, nil
+}
+
+func parseCheck(name string, doc checkDoc) (Check, error) {
+	check := Check{
+		Name:     name,
+		HTTP:     doc.HTTP,
+		TCP:      doc.TCP,
+		Script:   doc.Script,
+		Interval: DefaultInterval,
+		Timeout:  DefaultTimeout,
+		Status:   params.StatusBlocked,
+	}
+	probes := 0
+	for _, probe := range []string{doc.HTTP, doc.TCP, doc.Script} {
+		if probe != "" {
+			probes++
+		}
+	}
+	if probes != 1 {
+		return Check{}, errors.New("expected exactly one of http, tcp and script")
+	}
+	var err error
+	if doc.Interval != "" {
+		if check.Interval, err = parsePositiveDuration(doc.Interval); err != nil {
+			return Check{}, errors.Annotate(err, "invalid interval")
+		}
+	}
+	if doc.Timeout != "" {
+		if check.Timeout, err = parsePositiveDuration(doc.Timeout); err != nil {
+			return Check{}, errors.Annotate(err, "invalid timeout")
+		}
+	}
+	switch status := params.Status(doc.Status); status {
+	case "":
+	case params.StatusBlocked, params.StatusMaintenance:
+		check.Status = status
+	default:
+		return Check{}, errors.Errorf("invalid status %q", status)
+	}
+	return check, nil
+}
+
+func parsePositiveDuration(s string) (time.Duration, error) {
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, err
+	}
+	if d <= 0 {
+		return 0, errors.Errorf("%q is not positive", s)
+	}
+	return d, nil
+}
+
+type byName []Check
+
+func (s byName) Len() int           { return len(s) }
+func (s byName) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s byName) Less(i, j int) bool { return s[i].Name < s[j].Name }
+
+// Probe runs the check once, running script checks in charmDir. It
+// returns an error describing the failure if the check fails.
+func (c Check) Probe(charmDir string) error {
+	switch {
+	case c.HTTP != "":
+		return c.probeHTTP()
+	case c.TCP != "":
+		return c.probeTCP()
+	case c.Script != "":
+		return c.probeScript(charmDir)
+	}
+	return errors.New("nothing to probe")
+}
+
+func (c Check) probeHTTP() error {
+	client := &http.Client{Timeout: c.Timeout}
+	resp, err := client.Get(c.HTTP)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return errors.Errorf("GET %s: %s", c.HTTP, resp.Status)
+	}
+	return nil
+}
+
+func (c Check) probeTCP() error {
+	conn, err := net.DialTimeout("tcp", c.TCP, c.Timeout)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+func (c Check) probeScript(charmDir string) error {
+	var out bytes.Buffer
+	cmd := exec.Command("/bin/sh", "-c", c.Script)
+	cmd.Dir = charmDir
+	cmd.Env = append(os.Environ(), "JUJU_CHARM_DIR="+charmDir)
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- cmd.Wait()
+	}()
+	var err error
+	select {
+	case err = <-done:
+	case <-time.After(c.Timeout):
+		cmd.Process.Kill()
+		<-done
+		return fmt.Errorf("timed out after %v", c.Timeout)
+	}
+	if err == nil {
+		return nil
+	}
+	if message := strings.TrimSpace(out.String()); message != "" {
+		return errors.New(message)
+	}
+	return err
+}
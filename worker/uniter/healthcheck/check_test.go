@@ -0,0 +1,149 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package healthcheck_test
+
+import (
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter/healthcheck"
+)
+
+type CheckSuite struct {
+	testing.BaseSuite
+	charmDir string
+}
+
+var _ = gc.Suite(&CheckSuite{})
+
+func (s *CheckSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.charmDir = c.MkDir()
+}
+
+func (s *CheckSuite) writeMetadata(c *gc.C, metadata string) {
+	err := ioutil.WriteFile(filepath.Join(s.charmDir, "metadata.yaml"), []byte(metadata), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *CheckSuite) TestReadChecks(c *gc.C) {
+	s.writeMetadata(c, `
+name: wordpress
+health-checks:
+  web:
+    http: http://localhost:8080/health
+    interval: 30s
+  db:
+    tcp: localhost:5432
+    status: maintenance
+  daemon:
+    script: scripts/check-daemon
+    timeout: 1m
+`)
+	checks, err := healthcheck.ReadChecks(s.charmDir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(checks, jc.DeepEquals, []healthcheck.Check{{
+		Name:     "daemon",
+		Script:   "scripts/check-daemon",
+		Interval: healthcheck.DefaultInterval,
+		Timeout:  time.Minute,
+		Status:   params.StatusBlocked,
+	}, {
+		Name:     "db",
+		TCP:      "localhost:5432",
+		Interval: healthcheck.DefaultInterval,
+		Timeout:  healthcheck.DefaultTimeout,
+		Status:   params.StatusMaintenance,
+	}, {
+		Name:     "web",
+		HTTP:     "http://localhost:8080/health",
+		Interval: 30 * time.Second,
+		Timeout:  healthcheck.DefaultTimeout,
+		Status:   params.StatusBlocked,
+	}})
+}
+
+func (s *CheckSuite) TestReadChecksNone(c *gc.C) {
+	s.writeMetadata(c, "name: wordpress\n")
+	checks, err := healthcheck.ReadChecks(s.charmDir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(checks, gc.HasLen, 0)
+}
+
+func (s *CheckSuite) TestReadChecksErrors(c *gc.C) {
+	for i, test := range []struct {
+		check string
+		err   string
+	}{{
+		"{}",
+		`invalid health check "web": expected exactly one of http, tcp and script`,
+	}, {
+		"{http: 'http://localhost/', tcp: 'localhost:80'}",
+		`invalid health check "web": expected exactly one of http, tcp and script`,
+	}, {
+		"{tcp: 'localhost:80', interval: soon}",
+		`invalid health check "web": invalid interval: time: invalid duration soon`,
+	}, {
+		"{tcp: 'localhost:80', timeout: -1s}",
+		`invalid health check "web": invalid timeout: "-1s" is not positive`,
+	}, {
+		"{tcp: 'localhost:80', status: active}",
+		`invalid health check "web": invalid status "active"`,
+	}} {
+		c.Logf("test %d: %s", i, test.check)
+		s.writeMetadata(c, "health-checks:\n  web: "+test.check+"\n")
+		_, err := healthcheck.ReadChecks(s.charmDir)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *CheckSuite) TestProbeHTTP(c *gc.C) {
+	healthy := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !healthy {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	check := healthcheck.Check{HTTP: server.URL, Timeout: testing.LongWait}
+	c.Assert(check.Probe(s.charmDir), jc.ErrorIsNil)
+	healthy = false
+	c.Assert(check.Probe(s.charmDir), gc.ErrorMatches, "GET "+server.URL+": 503 Service Unavailable")
+}
+
+func (s *CheckSuite) TestProbeTCP(c *gc.C) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	c.Assert(err, jc.ErrorIsNil)
+	check := healthcheck.Check{TCP: listener.Addr().String(), Timeout: testing.LongWait}
+	c.Assert(check.Probe(s.charmDir), jc.ErrorIsNil)
+
+	listener.Close()
+	c.Assert(check.Probe(s.charmDir), gc.NotNil)
+}
+
+func (s *CheckSuite) TestProbeScript(c *gc.C) {
+	check := healthcheck.Check{Script: "test -f ok", Timeout: testing.LongWait}
+	c.Assert(check.Probe(s.charmDir), gc.ErrorMatches, "exit status 1")
+
+	err := ioutil.WriteFile(filepath.Join(s.charmDir, "ok"), nil, 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(check.Probe(s.charmDir), jc.ErrorIsNil)
+
+	check.Script = "echo daemon not running; exit 1"
+	c.Assert(check.Probe(s.charmDir), gc.ErrorMatches, "daemon not running")
+
+	check.Script = "sleep 10"
+	check.Timeout = 10 * time.Millisecond
+	c.Assert(check.Probe(s.charmDir), gc.ErrorMatches, "timed out after 10ms")
+}
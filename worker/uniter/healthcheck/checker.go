@@ -0,0 +1,141 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package healthcheck
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/loggo"
+	"launchpad.net/tomb"
+)
+
+var logger = loggo.GetLogger("juju.worker.uniter.healthcheck")
+
+// Result holds the outcome of a health check.
+type Result struct {
+	// Name is the name of the check.
+	Name string
+
+	// Healthy reports whether the check passed.
+	Healthy bool
+
+	// Message describes why the check failed. It is empty if the check
+	// passed.
+	Message string
+
+	// Since is the time at which the check last became healthy or
+	// unhealthy.
+	Since time.Time
+}
+
+// Checker runs health checks, each on its own interval, and reports
+// when their outcomes change.
+type Checker struct {
+	tomb     tomb.Tomb
+	charmDir string
+	changes  chan Result
+}
+
+// NewChecker returns a Checker that runs the given checks, running
+// script checks in charmDir.
+func NewChecker(checks []Check, charmDir string) *Checker {
+	c := &Checker{
+		charmDir: charmDir,
+		changes:  make(chan Result),
+	}
+	go func() {
+		defer c.tomb.Done()
+		c.tomb.Kill(c.loop(checks))
+	}()
+	return c
+}
+
+// Changes returns a channel that receives the outcome of each check
+// when it is first run, and again whenever it changes.
+func (c *Checker) Changes() <-chan Result {
+	return c.changes
+}
+
+// Kill is part of the worker.Worker interface.
+func (c *Checker) Kill() {
+	c.tomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (c *Checker) Wait() error {
+	return c.tomb.Wait()
+}
+
+// Stop stops the checker and returns any error it encountered.
+func (c *Checker) Stop() error {
+	c.Kill()
+	return c.Wait()
+}
+
+func (c *Checker) loop(checks []Check) error {
+	results := make(chan Result)
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	for _, check := range checks {
+		wg.Add(1)
+		go func(check Check) {
+			defer wg.Done()
+			c.run(check, results)
+		}(check)
+	}
+
+	last := make(map[string]Result)
+	var pending []Result
+	for {
+		var out chan<- Result
+		var next Result
+		if len(pending) > 0 {
+			out, next = c.changes, pending[0]
+		}
+		select {
+		case <-c.tomb.Dying():
+			return tomb.ErrDying
+		case result := <-results:
+			previous, ok := last[result.Name]
+			if ok && previous.Healthy == result.Healthy && previous.Message == result.Message {
+				continue
+			}
+			if ok && previous.Healthy == result.Healthy {
+				result.Since = previous.Since
+			}
+			last[result.Name] = result
+			pending = append(pending, result)
+		case out <- next:
+			pending = pending[1:]
+		}
+	}
+}
+
+// run runs the check on its interval, sending each outcome to results,
+// until the checker is stopped.
+func (c *Checker) run(check Check, results chan<- Result) {
+	for {
+		result := Result{
+			Name:    check.Name,
+			Healthy: true,
+			Since:   time.Now(),
+		}
+		if err := check.Probe(c.charmDir); err != nil {
+			logger.Debugf("health check %q failed: %v", check.Name, err)
+			result.Healthy = false
+			result.Message = err.Error()
+		}
+		select {
+		case results <- result:
+		case <-c.tomb.Dying():
+			return
+		}
+		select {
+		case <-time.After(check.Interval):
+		case <-c.tomb.Dying():
+			return
+		}
+	}
+}
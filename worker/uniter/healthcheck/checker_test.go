@@ -0,0 +1,96 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package healthcheck_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter/healthcheck"
+)
+
+type CheckerSuite struct {
+	testing.BaseSuite
+	charmDir string
+}
+
+var _ = gc.Suite(&CheckerSuite{})
+
+func (s *CheckerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.charmDir = c.MkDir()
+}
+
+func (s *CheckerSuite) nextResult(c *gc.C, checker *healthcheck.Checker) healthcheck.Result {
+	select {
+	case result := <-checker.Changes():
+		return result
+	case <-time.After(testing.LongWait):
+		c.Fatalf("timed out waiting for health check result")
+	}
+	panic("unreachable")
+}
+
+func (s *CheckerSuite) assertNoResult(c *gc.C, checker *healthcheck.Checker) {
+	select {
+	case result := <-checker.Changes():
+		c.Fatalf("unexpected health check result %+v", result)
+	case <-time.After(testing.ShortWait):
+	}
+}
+
+func (s *CheckerSuite) TestChanges(c *gc.C) {
+	okPath := filepath.Join(s.charmDir, "ok")
+	checker := healthcheck.NewChecker([]healthcheck.Check{{
+		Name:     "daemon",
+		Script:   "test -f ok || (echo not ok; exit 1)",
+		Interval: 10 * time.Millisecond,
+		Timeout:  testing.LongWait,
+	}}, s.charmDir)
+	defer func() {
+		c.Assert(checker.Stop(), jc.ErrorIsNil)
+	}()
+
+	result := s.nextResult(c, checker)
+	c.Assert(result.Name, gc.Equals, "daemon")
+	c.Assert(result.Healthy, jc.IsFalse)
+	c.Assert(result.Message, gc.Equals, "not ok")
+	since := result.Since
+
+	// The outcome is only reported again when it changes.
+	s.assertNoResult(c, checker)
+
+	err := ioutil.WriteFile(okPath, nil, 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	result = s.nextResult(c, checker)
+	c.Assert(result.Healthy, jc.IsTrue)
+	c.Assert(result.Message, gc.Equals, "")
+	c.Assert(result.Since.After(since), jc.IsTrue)
+
+	err = os.Remove(okPath)
+	c.Assert(err, jc.ErrorIsNil)
+	result = s.nextResult(c, checker)
+	c.Assert(result.Healthy, jc.IsFalse)
+}
+
+func (s *CheckerSuite) TestStopWithPendingResults(c *gc.C) {
+	checker := healthcheck.NewChecker([]healthcheck.Check{{
+		Name:     "a",
+		Script:   "true",
+		Interval: time.Hour,
+		Timeout:  testing.LongWait,
+	}, {
+		Name:     "b",
+		Script:   "false",
+		Interval: time.Hour,
+		Timeout:  testing.LongWait,
+	}}, s.charmDir)
+	c.Assert(checker.Stop(), jc.ErrorIsNil)
+}
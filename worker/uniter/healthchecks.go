@@ -0,0 +1,161 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/worker/uniter/healthcheck"
+	"github.com/juju/juju/worker/uniter/hook"
+)
+
+// healthChecks runs the health checks declared by the deployed charm,
+// and tracks how their outcomes are reflected in the workload status.
+type healthChecks struct {
+	checks  []healthcheck.Check
+	checker *healthcheck.Checker
+	results map[string]healthcheck.Result
+
+	// overridden holds the workload status in place before it was
+	// set to reflect a failing check, so that it can be restored when
+	// all checks pass again. It is nil if the workload status does not
+	// reflect a failing check.
+	overridden *params.StatusResult
+
+	// status and message record the workload status set to reflect a
+	// failing check.
+	status  params.Status
+	message string
+}
+
+// initializeHealthChecks starts running the health checks declared by
+// the deployed charm, if they differ from those already running.
+func (u *Uniter) initializeHealthChecks() error {
+	checks, err := healthcheck.ReadChecks(u.paths.State.CharmDir)
+	if err != nil {
+		return errors.Annotate(err, "cannot read health checks")
+	}
+	if reflect.DeepEqual(checks, u.healthChecks.checks) {
+		return nil
+	}
+	if err := u.stopHealthChecks(); err != nil {
+		return errors.Trace(err)
+	}
+	u.healthChecks.checks = checks
+	u.healthChecks.results = make(map[string]healthcheck.Result)
+	if len(checks) > 0 {
+		logger.Infof("running %d health checks", len(checks))
+		u.healthChecks.checker = healthcheck.NewChecker(checks, u.paths.State.CharmDir)
+	}
+	return u.reportHealthChecks()
+}
+
+// stopHealthChecks stops running the health checks, if any are running.
+func (u *Uniter) stopHealthChecks() error {
+	checker := u.healthChecks.checker
+	if checker == nil {
+		return nil
+	}
+	u.healthChecks.checker = nil
+	return checker.Stop()
+}
+
+// healthCheckChanges returns a channel that receives the outcomes of the
+// health checks as they change, or nil if no checks are running.
+func (u *Uniter) healthCheckChanges() <-chan healthcheck.Result {
+	if u.healthChecks.checker == nil {
+		return nil
+	}
+	return u.healthChecks.checker.Changes()
+}
+
+// handleHealthCheck records the outcome of a health check, and updates
+// the workload status to reflect it. It returns the creator of the
+// operation that runs the health-check-failed hook if the check has just
+// started to fail, and nil otherwise.
+func (u *Uniter) handleHealthCheck(result healthcheck.Result) (creator, error) {
+	previous, seen := u.healthChecks.results[result.Name]
+	u.healthChecks.results[result.Name] = result
+	if err := u.reportHealthChecks(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := u.updateHealthStatus(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Healthy || (seen && !previous.Healthy) {
+		return nil, nil
+	}
+	logger.Infof("health check %q failed: %s", result.Name, result.Message)
+	return newRunHookOp(hook.Info{
+		Kind:        hook.HealthCheckFailed,
+		HealthCheck: result.Name,
+	}), nil
+}
+
+// reportHealthChecks records the outcomes of the health checks in state.
+func (u *Uniter) reportHealthChecks() error {
+	var names []string
+	for name := range u.healthChecks.results {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	checks := make([]params.HealthCheckResult, len(names))
+	for i, name := range names {
+		result := u.healthChecks.results[name]
+		checks[i] = params.HealthCheckResult{
+			Name:    result.Name,
+			Healthy: result.Healthy,
+			Message: result.Message,
+			Since:   result.Since,
+		}
+	}
+	err := u.unit.SetHealthChecks(checks)
+	if errors.IsNotImplemented(err) {
+		logger.Debugf("cannot report health checks: %v", err)
+		return nil
+	}
+	return errors.Trace(err)
+}
+
+// updateHealthStatus sets the workload status to reflect the first
+// failing health check, if any. When all checks pass again, the
+// workload status in place before the first failure is restored, unless
+// the charm has since changed it.
+func (u *Uniter) updateHealthStatus() error {
+	var failing *healthcheck.Check
+	for i, check := range u.healthChecks.checks {
+		if result, ok := u.healthChecks.results[check.Name]; ok && !result.Healthy {
+			failing = &u.healthChecks.checks[i]
+			break
+		}
+	}
+	current, err := u.unit.UnitStatus()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ours := u.healthChecks.overridden != nil &&
+		current.Status == u.healthChecks.status &&
+		current.Info == u.healthChecks.message
+	if failing == nil {
+		if !ours {
+			u.healthChecks.overridden = nil
+			return nil
+		}
+		previous := u.healthChecks.overridden
+		u.healthChecks.overridden = nil
+		return u.unit.SetUnitStatus(previous.Status, previous.Info, previous.Data)
+	}
+	if !ours {
+		u.healthChecks.overridden = &current
+	}
+	result := u.healthChecks.results[failing.Name]
+	u.healthChecks.status = failing.Status
+	u.healthChecks.message = fmt.Sprintf("health check %q failed: %s", failing.Name, result.Message)
+	return u.unit.SetUnitStatus(u.healthChecks.status, u.healthChecks.message, nil)
+}
@@ -50,6 +50,10 @@ var validateTests = []struct {
 	{hook.Info{Kind: hooks.StorageAttached}, `invalid storage ID ""`},
 	{hook.Info{Kind: hooks.StorageAttached, StorageId: "data/0"}, ""},
 	{hook.Info{Kind: hooks.StorageDetaching, StorageId: "data/0"}, ""},
+	{hook.Info{Kind: hook.StorageResized}, `invalid storage ID ""`},
+	{hook.Info{Kind: hook.StorageResized, StorageId: "data/0"}, ""},
+	{hook.Info{Kind: hook.HealthCheckFailed}, `"health-check-failed" hook requires a health check`},
+	{hook.Info{Kind: hook.HealthCheckFailed, HealthCheck: "http"}, ""},
 }
 
 func (s *InfoSuite) TestValidate(c *gc.C) {
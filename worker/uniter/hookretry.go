@@ -0,0 +1,98 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// hookRetry tracks the automatic retries of a failed hook.
+type hookRetry struct {
+	policy params.HookRetryPolicy
+
+	// attempt is the number of the next retry, counting from 1.
+	attempt int
+
+	// delay is the time waited before the next retry.
+	delay time.Duration
+
+	// at is the time of the next retry. It is zero if no retry is
+	// pending.
+	at time.Time
+}
+
+// newHookRetry returns a hookRetry that follows the unit's hook retry
+// policy, with its first retry scheduled if the policy allows it.
+func (u *Uniter) newHookRetry() (*hookRetry, error) {
+	policy, err := u.unit.HookRetryPolicy()
+	if errors.IsNotImplemented(err) {
+		logger.Debugf("cannot retry failed hooks automatically: %v", err)
+		policy = params.HookRetryPolicy{}
+	} else if err != nil {
+		return nil, errors.Annotate(err, "cannot get hook retry policy")
+	}
+	retry := &hookRetry{policy: policy}
+	retry.schedule(time.Now())
+	return retry, nil
+}
+
+// schedule schedules the next retry, if any remain, counting the delay
+// from now.
+func (r *hookRetry) schedule(now time.Time) {
+	if r.attempt >= r.policy.Attempts {
+		r.cancel()
+		return
+	}
+	if r.attempt == 0 {
+		r.delay = r.policy.Delay
+	} else {
+		r.delay *= 2
+	}
+	if r.delay > r.policy.MaxDelay || r.delay <= 0 {
+		r.delay = r.policy.MaxDelay
+	}
+	r.attempt++
+	r.at = now.Add(r.delay)
+}
+
+// cancel cancels the pending retry, if any.
+func (r *hookRetry) cancel() {
+	r.at = time.Time{}
+}
+
+// pending reports whether a retry is pending.
+func (r *hookRetry) pending() bool {
+	return !r.at.IsZero()
+}
+
+// ready returns a channel that receives a value when the pending retry
+// is due, or nil if no retry is pending.
+func (r *hookRetry) ready() <-chan time.Time {
+	if !r.pending() {
+		return nil
+	}
+	return time.After(r.at.Sub(time.Now()))
+}
+
+// status returns the agent status message and data reporting the hook
+// failure, amended to describe the pending retry, if any.
+func (r *hookRetry) status(message string, data map[string]interface{}) (string, map[string]interface{}) {
+	if !r.pending() {
+		return message, data
+	}
+	retryData := make(map[string]interface{}, len(data)+3)
+	for key, value := range data {
+		retryData[key] = value
+	}
+	retryData["retry-attempt"] = r.attempt
+	retryData["retry-attempts"] = r.policy.Attempts
+	retryData["retry-at"] = r.at.UTC().Format(time.RFC3339)
+	message = fmt.Sprintf("%s (retrying in %v, attempt %d of %d)", message, r.delay, r.attempt, r.policy.Attempts)
+	return message, retryData
+}
@@ -76,6 +76,9 @@ func ModeContinue(u *Uniter) (next Mode, err error) {
 	if err := u.initializeMetricsCollector(); err != nil {
 		return nil, errors.Trace(err)
 	}
+	if err := u.initializeHealthChecks(); err != nil {
+		return nil, errors.Trace(err)
+	}
 
 	// Check for any leadership change, and enact it if possible.
 	logger.Infof("checking leadership status")
@@ -335,6 +338,13 @@ func modeAbideAliveLoop(u *Uniter) (Mode, error) {
 			creator = newSimpleRunHookOp(hooks.CollectMetrics)
 		case <-updateStatusSignal:
 			creator = newSimpleRunHookOp(hooks.UpdateStatus)
+		case result := <-u.healthCheckChanges():
+			var err error
+			if creator, err = u.handleHealthCheck(result); err != nil {
+				return nil, errors.Trace(err)
+			} else if creator == nil {
+				continue
+			}
 		case hookInfo := <-u.relations.Hooks():
 			creator = newRunHookOp(hookInfo)
 		case hookInfo := <-u.storage.Hooks():
@@ -437,6 +447,7 @@ func waitStorage(u *Uniter) error {
 
 // ModeHookError is responsible for watching and responding to:
 // * user resolution of hook errors
+// * automatic retries of the failed hook
 // * forced charm upgrade requests
 // * loss of service leadership
 func ModeHookError(u *Uniter) (next Mode, err error) {
@@ -464,6 +475,12 @@ func ModeHookError(u *Uniter) (next Mode, err error) {
 	statusData["hook"] = hookName
 	statusMessage := fmt.Sprintf("hook failed: %q", hookName)
 
+	// Schedule the first automatic retry, if the policy allows it.
+	retry, err := u.newHookRetry()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
 	// Run the select loop.
 	u.f.WantResolvedEvent()
 	u.f.WantUpgradeEvent(true)
@@ -476,7 +493,8 @@ func ModeHookError(u *Uniter) (next Mode, err error) {
 		// It's the agent itself that should be in Error state. So we'll ensure the model is
 		// correct and translate before the user sees the data.
 		// ie a charm hook error results in agent error status, but is presented as a workload error.
-		if err = setAgentStatus(u, params.StatusError, statusMessage, statusData); err != nil {
+		message, data := retry.status(statusMessage, statusData)
+		if err = setAgentStatus(u, params.StatusError, message, data); err != nil {
 			return nil, errors.Trace(err)
 		}
 		select {
@@ -491,6 +509,13 @@ func ModeHookError(u *Uniter) (next Mode, err error) {
 				creator = newRetryHookOp(hookInfo)
 			case params.ResolvedNoHooks:
 				creator = newSkipHookOp(hookInfo)
+			case params.ResolvedCancelRetry:
+				logger.Infof("automatic retry of hook %q cancelled", hookName)
+				retry.cancel()
+				if err := u.f.ClearResolved(); err != nil {
+					return nil, errors.Trace(err)
+				}
+				continue
 			default:
 				return nil, errors.Errorf("unknown resolved mode %q", rm)
 			}
@@ -501,6 +526,16 @@ func ModeHookError(u *Uniter) (next Mode, err error) {
 				return nil, errors.Trace(err)
 			}
 			return ModeContinue, nil
+		case <-retry.ready():
+			logger.Infof("retrying hook %q (attempt %d of %d)", hookName, retry.attempt, retry.policy.Attempts)
+			err := u.runOperation(newRunHookOp(hookInfo))
+			if errors.Cause(err) == operation.ErrHookFailed {
+				retry.schedule(time.Now())
+				continue
+			} else if err != nil {
+				return nil, errors.Trace(err)
+			}
+			return ModeContinue, nil
 		case actionId := <-u.f.ActionEvents():
 			if err := u.runOperation(newActionOp(actionId)); err != nil {
 				return nil, errors.Trace(err)
@@ -538,7 +573,15 @@ func ModeConflicted(curl *charm.URL) Mode {
 			return nil, tomb.ErrDying
 		case curl = <-u.f.UpgradeEvents():
 			creator = newRevertUpgradeOp(curl)
-		case <-u.f.ResolvedEvents():
+		case rm := <-u.f.ResolvedEvents():
+			if rm == params.ResolvedCancelRetry {
+				// Upgrade conflicts are never retried automatically,
+				// so there is nothing to cancel.
+				if err := u.f.ClearResolved(); err != nil {
+					return nil, errors.Trace(err)
+				}
+				return ModeConflicted(curl), nil
+			}
 			creator = newResolvedUpgradeOp(curl)
 		}
 		return continueAfter(u, creator)
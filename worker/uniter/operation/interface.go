@@ -123,6 +123,9 @@ type CommandArgs struct {
 	RemoteUnitName string
 	// ForceRemoteUnit skips unit inference and existence validation.
 	ForceRemoteUnit bool
+	// OutputId, if set, is the id under which the output of the
+	// commands is forwarded to the controller as it is produced.
+	OutputId string
 }
 
 // CommandResponseFunc is for marshalling command responses back to the source
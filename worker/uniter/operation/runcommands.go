@@ -41,6 +41,7 @@ func (rc *runCommands) Prepare(state State) (*State, error) {
 		RelationId:      rc.args.RelationId,
 		RemoteUnitName:  rc.args.RemoteUnitName,
 		ForceRemoteUnit: rc.args.ForceRemoteUnit,
+		OutputId:        rc.args.OutputId,
 	})
 	if err != nil {
 		return nil, err
@@ -36,8 +36,10 @@ func (rh *runHook) String() string {
 		} else {
 			suffix = fmt.Sprintf(" (%d; %s)", rh.info.RelationId, rh.info.RemoteUnit)
 		}
-	case rh.info.Kind.IsStorage():
+	case hook.IsStorage(rh.info.Kind):
 		suffix = fmt.Sprintf(" (%s)", rh.info.StorageId)
+	case rh.info.Kind == hook.HealthCheckFailed:
+		suffix = fmt.Sprintf(" (%s)", rh.info.HealthCheck)
 	}
 	return fmt.Sprintf("run %s%s hook", rh.info.Kind, suffix)
 }
@@ -29,6 +29,9 @@ type RunCommandsArgs struct {
 	RemoteUnitName string
 	// ForceRemoteUnit skips relation membership and existence validation.
 	ForceRemoteUnit bool
+	// OutputId, if set, is the id under which the output of the
+	// commands is forwarded to the controller as it is produced.
+	OutputId string
 }
 
 // A CommandRunner is something that will actually execute the commands and
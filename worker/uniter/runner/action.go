@@ -4,6 +4,8 @@
 package runner
 
 import (
+	"time"
+
 	"github.com/juju/names"
 )
 
@@ -12,9 +14,14 @@ type ActionData struct {
 	ActionName     string
 	ActionTag      names.ActionTag
 	ActionParams   map[string]interface{}
+	ActionTimeout  time.Duration
 	ActionFailed   bool
 	ResultsMessage string
 	ResultsMap     map[string]interface{}
+
+	// ActionStopped holds the status of an Action that was stopped
+	// before it finished, because it was aborted or timed out.
+	ActionStopped string
 }
 
 // NewActionData builds a suitable ActionData struct with no nil members.
@@ -81,3 +81,21 @@ func hookCommand(hook string) []string {
 	}
 	return []string{hook}
 }
+
+// commandsCommand returns a command that runs the supplied script the
+// way juju-run does: with bash, or with powershell on windows.
+func commandsCommand(commands string) *exec.Cmd {
+	if version.Current.OS != version.Windows {
+		ps := exec.Command("/bin/bash", "-s")
+		ps.Stdin = strings.NewReader(commands)
+		return ps
+	}
+	return exec.Command(
+		"powershell.exe",
+		"-NonInteractive",
+		"-ExecutionPolicy",
+		"RemoteSigned",
+		"-Command",
+		commands,
+	)
+}
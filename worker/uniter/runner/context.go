@@ -17,6 +17,7 @@ import (
 	"gopkg.in/juju/charm.v5"
 
 	"github.com/juju/juju/api/uniter"
+	"github.com/juju/juju/api/watcher"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
@@ -81,6 +82,11 @@ type HookContext struct {
 	// its tag, its parameters, and its results.
 	actionData *ActionData
 
+	// outputId is the id under which the output of commands run in the
+	// context is forwarded to the controller. It is empty if the output
+	// is not forwarded.
+	outputId string
+
 	// uuid is the universally unique identifier of the environment.
 	uuid string
 
@@ -103,6 +109,10 @@ type HookContext struct {
 	// or if it is running a relation-broken hook.
 	remoteUnitName string
 
+	// healthCheck names the failed health check when the context is
+	// running a health-check-failed hook. It is empty otherwise.
+	healthCheck string
+
 	// relations contains the context for every relation the unit is a member
 	// of, keyed on relation id.
 	relations map[int]*ContextRelation
@@ -208,6 +218,45 @@ func (ctx *HookContext) SetProcess(process *os.Process) {
 	ctx.process = process
 }
 
+// WatchActionAbort returns a watcher that notifies when the running
+// action is asked to stop.
+func (ctx *HookContext) WatchActionAbort() (watcher.NotifyWatcher, error) {
+	if ctx.actionData == nil {
+		return nil, errors.New("not running an action")
+	}
+	return ctx.state.WatchActionAbort(ctx.actionData.ActionTag)
+}
+
+// ActionAborting reports whether the running action has been asked to
+// stop.
+func (ctx *HookContext) ActionAborting() (bool, error) {
+	if ctx.actionData == nil {
+		return false, errors.New("not running an action")
+	}
+	return ctx.state.ActionAborting(ctx.actionData.ActionTag)
+}
+
+// OutputId returns the id under which the output produced in the
+// context is forwarded to the controller: the tag of the running action,
+// or the output id given with the commands being run. It returns an
+// empty string if the output is not forwarded.
+func (ctx *HookContext) OutputId() string {
+	if ctx.actionData != nil {
+		return ctx.actionData.ActionTag.String()
+	}
+	return ctx.outputId
+}
+
+// SendOutput forwards chunks of the output produced in the context to
+// the controller.
+func (ctx *HookContext) SendOutput(chunks []params.OutputChunk) error {
+	outputId := ctx.OutputId()
+	if outputId == "" {
+		return errors.New("output is not forwarded")
+	}
+	return ctx.state.AddOutput(outputId, chunks)
+}
+
 func (ctx *HookContext) Id() string {
 	return ctx.id
 }
@@ -326,6 +375,10 @@ func (ctx *HookContext) PrivateAddress() (string, bool) {
 	return ctx.privateAddress, ctx.privateAddress != ""
 }
 
+func (ctx *HookContext) BindingAddress(endpoint string) (string, error) {
+	return ctx.unit.BindingAddress(endpoint)
+}
+
 func (ctx *HookContext) AvailabilityZone() (string, bool) {
 	return ctx.availabilityzone, ctx.availabilityzone != ""
 }
@@ -520,6 +573,9 @@ func (context *HookContext) HookVars(paths Paths) []string {
 			"JUJU_REMOTE_UNIT="+context.remoteUnitName,
 		)
 	}
+	if context.healthCheck != "" {
+		vars = append(vars, "JUJU_HEALTH_CHECK="+context.healthCheck)
+	}
 	if context.actionData != nil {
 		vars = append(vars,
 			"JUJU_ACTION_NAME="+context.actionData.ActionName,
@@ -722,6 +778,17 @@ func (ctx *HookContext) finalizeAction(err, unhandledErr error) error {
 		status = params.ActionFailed
 	}
 
+	// An action that was stopped reports why, along with any results
+	// it set before it was stopped.
+	switch ctx.actionData.ActionStopped {
+	case params.ActionAborted:
+		status = params.ActionAborted
+		message = "action aborted"
+	case params.ActionTimedOut:
+		status = params.ActionTimedOut
+		message = fmt.Sprintf("action timed out after %v", ctx.actionData.ActionTimeout)
+	}
+
 	callErr := ctx.state.ActionFinish(tag, status, results, message)
 	if callErr != nil {
 		unhandledErr = errors.Wrap(unhandledErr, callErr)
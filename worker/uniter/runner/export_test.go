@@ -24,6 +24,7 @@ var (
 	TryOpenPorts            = tryOpenPorts
 	TryClosePorts           = tryClosePorts
 	LockTimeout             = lockTimeout
+	OutputFlushInterval     = &outputFlushInterval
 )
 
 func RunnerPaths(rnr Runner) Paths {
@@ -27,6 +27,9 @@ type CommandInfo struct {
 	RemoteUnitName string
 	// ForceRemoteUnit skips unit inference and existence validation.
 	ForceRemoteUnit bool
+	// OutputId, if set, is the id under which the output of the
+	// commands is forwarded to the controller as it is produced.
+	OutputId string
 }
 
 // Factory represents a long-lived object that can create execution contexts
@@ -143,6 +146,7 @@ func (f *factory) NewCommandRunner(commandInfo CommandInfo) (Runner, error) {
 	}
 	ctx.relationId = relationId
 	ctx.remoteUnitName = remoteUnitName
+	ctx.outputId = commandInfo.OutputId
 	ctx.id = f.newId("run-commands")
 	runner := NewRunner(ctx, f.paths)
 	return runner, nil
@@ -175,7 +179,7 @@ func (f *factory) NewHookRunner(hookInfo hook.Info) (Runner, error) {
 		}
 		hookName = fmt.Sprintf("%s-%s", relation.Name(), hookInfo.Kind)
 	}
-	if hookInfo.Kind.IsStorage() {
+	if hook.IsStorage(hookInfo.Kind) {
 		ctx.storageTag = names.NewStorageTag(hookInfo.StorageId)
 		if _, found := ctx.storage.Storage(ctx.storageTag); !found {
 			return nil, errors.Errorf("unknown storage id: %v", hookInfo.StorageId)
@@ -186,6 +190,9 @@ func (f *factory) NewHookRunner(hookInfo hook.Info) (Runner, error) {
 		}
 		hookName = fmt.Sprintf("%s-%s", storageName, hookName)
 	}
+	if hookInfo.Kind == hook.HealthCheckFailed {
+		ctx.healthCheck = hookInfo.HealthCheck
+	}
 	// Metrics are only sent from the collect-metrics hook.
 	if hookInfo.Kind == hooks.CollectMetrics {
 		ch, err := f.getCharm()
@@ -254,6 +261,7 @@ func (f *factory) NewActionRunner(actionId string) (Runner, error) {
 		return nil, errors.Trace(err)
 	}
 	ctx.actionData = newActionData(name, &tag, params)
+	ctx.actionData.ActionTimeout = action.Timeout()
 	ctx.id = f.newId(name)
 	runner := NewRunner(ctx, f.paths)
 	return runner, nil
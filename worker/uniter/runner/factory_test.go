@@ -179,6 +179,19 @@ func (s *FactorySuite) TestNewCommandRunnerNoRelation(c *gc.C) {
 	s.AssertNotStorageContext(c, ctx)
 }
 
+func (s *FactorySuite) TestNewCommandRunnerOutputId(c *gc.C) {
+	rnr, err := s.factory.NewCommandRunner(runner.CommandInfo{RelationId: -1})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rnr.Context().OutputId(), gc.Equals, "")
+
+	outputId := "c8a71ff5-4e8c-4ed7-8c45-ad5f3c8f9b4d"
+	rnr, err = s.factory.NewCommandRunner(runner.CommandInfo{
+		RelationId: -1, OutputId: outputId,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rnr.Context().OutputId(), gc.Equals, outputId)
+}
+
 func (s *FactorySuite) TestNewCommandRunnerRelationIdDoesNotExist(c *gc.C) {
 	for _, value := range []bool{true, false} {
 		_, err := s.factory.NewCommandRunner(runner.CommandInfo{
@@ -273,6 +286,20 @@ func (s *FactorySuite) TestNewHookRunner(c *gc.C) {
 	s.AssertNotStorageContext(c, ctx)
 }
 
+func (s *FactorySuite) TestNewHookRunnerWithHealthCheck(c *gc.C) {
+	rnr, err := s.factory.NewHookRunner(hook.Info{
+		Kind:        hook.HealthCheckFailed,
+		HealthCheck: "http",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.AssertPaths(c, rnr)
+	ctx := rnr.Context()
+	s.AssertCoreContext(c, ctx)
+	s.AssertNotRelationContext(c, ctx)
+	combined := strings.Join(ctx.HookVars(s.paths), "|")
+	c.Assert(combined, gc.Matches, `(^|.*\|)JUJU_HEALTH_CHECK=http(\|.*|$)`)
+}
+
 func (s *FactorySuite) TestNewHookRunnerWithBadHook(c *gc.C) {
 	rnr, err := s.factory.NewHookRunner(hook.Info{})
 	c.Assert(rnr, gc.IsNil)
@@ -581,6 +608,7 @@ func (s *FactorySuite) TestNewActionRunnerGood(c *gc.C) {
 	c.Assert(combined, gc.Matches, `(^|.*\|)JUJU_ACTION_NAME=snapshot(\|.*|$)`)
 	c.Assert(combined, gc.Matches, `(^|.*\|)JUJU_ACTION_UUID=`+action.Id()+`(\|.*|$)`)
 	c.Assert(combined, gc.Matches, `(^|.*\|)JUJU_ACTION_TAG=`+action.Tag().String()+`(\|.*|$)`)
+	c.Assert(ctx.OutputId(), gc.Equals, action.Tag().String())
 }
 
 func (s *FactorySuite) TestNewActionRunnerBadCharm(c *gc.C) {
@@ -143,6 +143,12 @@ type ContextNetworking interface {
 	// PrivateAddress returns the executing unit's private address.
 	PrivateAddress() (string, bool)
 
+	// BindingAddress returns the address the executing unit should
+	// advertise on the given endpoint: its address in the network
+	// space the endpoint is bound to, or its private address if the
+	// endpoint is not bound.
+	BindingAddress(endpoint string) (string, error)
+
 	// OpenPorts marks the supplied port range for opening when the
 	// executing unit's service is exposed.
 	OpenPorts(protocol string, fromPort, toPort int) error
@@ -0,0 +1,57 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"launchpad.net/gnuflag"
+)
+
+// NetworkGetCommand implements the network-get command.
+type NetworkGetCommand struct {
+	cmd.CommandBase
+	ctx      Context
+	Endpoint string
+	out      cmd.Output
+}
+
+func NewNetworkGetCommand(ctx Context) cmd.Command {
+	return &NetworkGetCommand{ctx: ctx}
+}
+
+func (c *NetworkGetCommand) Info() *cmd.Info {
+	doc := `
+network-get prints the address the unit should advertise to the units
+it is related to over the given relation endpoint. If the endpoint is
+bound to a network space, that is the unit's address in the space's
+subnets; otherwise it is the unit's private address.
+`
+	return &cmd.Info{
+		Name:    "network-get",
+		Args:    "<relation name>",
+		Purpose: "print the address to advertise on a relation endpoint",
+		Doc:     doc,
+	}
+}
+
+func (c *NetworkGetCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "smart", cmd.DefaultFormatters)
+}
+
+func (c *NetworkGetCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("no relation name specified")
+	}
+	c.Endpoint = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+func (c *NetworkGetCommand) Run(ctx *cmd.Context) error {
+	address, err := c.ctx.BindingAddress(c.Endpoint)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return c.out.Write(ctx, address)
+}
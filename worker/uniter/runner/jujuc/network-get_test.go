@@ -0,0 +1,92 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type NetworkGetSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&NetworkGetSuite{})
+
+func (s *NetworkGetSuite) createCommand(c *gc.C) cmd.Command {
+	hctx := s.GetHookContext(c, -1, "")
+	hctx.info.BindingAddresses = map[string]string{
+		"db":      "10.0.0.4",
+		"website": "192.168.0.99",
+	}
+	com, err := jujuc.NewCommand(hctx, cmdString("network-get"))
+	c.Assert(err, jc.ErrorIsNil)
+	return com
+}
+
+var networkGetTests = []struct {
+	args []string
+	out  string
+}{
+	{[]string{"db"}, "10.0.0.4\n"},
+	{[]string{"db", "--format", "yaml"}, "10.0.0.4\n"},
+	{[]string{"db", "--format", "json"}, `"10.0.0.4"` + "\n"},
+	{[]string{"website"}, "192.168.0.99\n"},
+}
+
+func (s *NetworkGetSuite) TestOutputFormat(c *gc.C) {
+	for i, t := range networkGetTests {
+		c.Logf("test %d: %v", i, t.args)
+		com := s.createCommand(c)
+		ctx := testing.Context(c)
+		code := cmd.Main(com, ctx, t.args)
+		c.Check(code, gc.Equals, 0)
+		c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+		c.Check(bufferString(ctx.Stdout), gc.Equals, t.out)
+	}
+}
+
+func (s *NetworkGetSuite) TestUnknownEndpoint(c *gc.C) {
+	com := s.createCommand(c)
+	ctx := testing.Context(c)
+	code := cmd.Main(com, ctx, []string{"nonsense"})
+	c.Assert(code, gc.Equals, 1)
+	c.Assert(bufferString(ctx.Stderr), gc.Equals, "error: endpoint \"nonsense\" not found\n")
+}
+
+func (s *NetworkGetSuite) TestInitErrors(c *gc.C) {
+	com := s.createCommand(c)
+	err := testing.InitCommand(com, nil)
+	c.Assert(err, gc.ErrorMatches, "no relation name specified")
+
+	com = s.createCommand(c)
+	err = testing.InitCommand(com, []string{"db", "website"})
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["website"\]`)
+}
+
+func (s *NetworkGetSuite) TestHelp(c *gc.C) {
+	com := s.createCommand(c)
+	ctx := testing.Context(c)
+	code := cmd.Main(com, ctx, []string{"--help"})
+	c.Assert(code, gc.Equals, 0)
+	c.Assert(bufferString(ctx.Stdout), gc.Equals, `usage: network-get [options] <relation name>
+purpose: print the address to advertise on a relation endpoint
+
+options:
+--format  (= smart)
+    specify output format (json|smart|yaml)
+-o, --output (= "")
+    specify an output file
+
+network-get prints the address the unit should advertise to the units
+it is related to over the given relation endpoint. If the endpoint is
+bound to a network space, that is the unit's address in the space's
+subnets; otherwise it is the unit's private address.
+`)
+	c.Assert(bufferString(ctx.Stderr), gc.Equals, "")
+}
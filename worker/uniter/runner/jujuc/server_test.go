@@ -241,6 +241,7 @@ var newCommandTests = []struct {
 	{"relation-list", ""},
 	{"relation-set", ""},
 	{"unit-get", ""},
+	{"network-get", ""},
 	{"storage-add", ""},
 	{"storage-get", ""},
 	{"status-get", ""},
@@ -16,6 +16,10 @@ type NetworkInterface struct {
 	PublicAddress  string
 	PrivateAddress string
 	Ports          []network.PortRange
+
+	// BindingAddresses maps endpoint names to the addresses
+	// returned by BindingAddress.
+	BindingAddresses map[string]string
 }
 
 // CheckPorts checks the current ports.
@@ -77,6 +81,20 @@ func (c *ContextNetworking) PrivateAddress() (string, bool) {
 	return c.info.PrivateAddress, true
 }
 
+// BindingAddress implements jujuc.ContextNetworking.
+func (c *ContextNetworking) BindingAddress(endpoint string) (string, error) {
+	c.stub.AddCall("BindingAddress", endpoint)
+	if err := c.stub.NextErr(); err != nil {
+		return "", errors.Trace(err)
+	}
+
+	address, ok := c.info.BindingAddresses[endpoint]
+	if !ok {
+		return "", errors.NotFoundf("endpoint %q", endpoint)
+	}
+	return address, nil
+}
+
 // OpenPorts implements jujuc.ContextNetworking.
 func (c *ContextNetworking) OpenPorts(protocol string, from, to int) error {
 	c.stub.AddCall("OpenPorts", protocol, from, to)
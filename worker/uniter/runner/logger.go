@@ -18,6 +18,9 @@ type hookLogger struct {
 	mu      sync.Mutex
 	stopped bool
 	logger  loggo.Logger
+
+	// out, if not nil, receives a copy of the output.
+	out io.Writer
 }
 
 func (l *hookLogger) run() {
@@ -25,7 +28,7 @@ func (l *hookLogger) run() {
 	defer l.r.Close()
 	br := bufio.NewReaderSize(l.r, 4096)
 	for {
-		line, _, err := br.ReadLine()
+		line, isPrefix, err := br.ReadLine()
 		if err != nil {
 			if err != io.EOF {
 				logger.Errorf("cannot read hook output: %v", err)
@@ -38,6 +41,12 @@ func (l *hookLogger) run() {
 			return
 		}
 		l.logger.Infof("%s", line)
+		if l.out != nil {
+			l.out.Write(line)
+			if !isPrefix {
+				l.out.Write([]byte("\n"))
+			}
+		}
 		l.mu.Unlock()
 	}
 }
@@ -0,0 +1,172 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package runner
+
+import (
+	"io"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// outputFlushInterval is how often output is forwarded to the
+// controller while it is being produced.
+var outputFlushInterval = time.Second
+
+// maxOutputChunkSize is the most output held in a single chunk.
+const maxOutputChunkSize = 16 * 1024
+
+// outputForwarder gathers the output of a running action or commands
+// into chunks, and periodically forwards them to the controller via
+// the context.
+type outputForwarder struct {
+	context Context
+	stop    chan struct{}
+	done    chan struct{}
+
+	mu      sync.Mutex
+	seq     int
+	pending []params.OutputChunk
+	partial map[string][]byte
+
+	// sent is the number of chunks at the start of pending that
+	// failed to send. They are sent again with the next flush,
+	// and must not be extended in the meantime.
+	sent int
+}
+
+// newOutputForwarder returns an outputForwarder that sends output via
+// the given context until it is closed.
+func newOutputForwarder(context Context) *outputForwarder {
+	f := &outputForwarder{
+		context: context,
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+		partial: make(map[string][]byte),
+	}
+	go f.loop()
+	return f
+}
+
+func (f *outputForwarder) loop() {
+	defer close(f.done)
+	for {
+		select {
+		case <-f.stop:
+			return
+		case <-time.After(outputFlushInterval):
+			f.flush()
+		}
+	}
+}
+
+// writer returns an io.Writer that records output written to the given
+// stream.
+func (f *outputForwarder) writer(stream string) io.Writer {
+	return &outputWriter{f, stream}
+}
+
+type outputWriter struct {
+	forwarder *outputForwarder
+	stream    string
+}
+
+// Write is part of the io.Writer interface.
+func (w *outputWriter) Write(data []byte) (int, error) {
+	w.forwarder.write(w.stream, data)
+	return len(data), nil
+}
+
+// write records output written to the given stream. Any incomplete
+// UTF-8 sequence at the end of the data is held back until the rest of
+// it is written, so that chunks always hold whole characters.
+func (f *outputForwarder) write(stream string, data []byte) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if partial := f.partial[stream]; len(partial) > 0 {
+		data = append(partial, data...)
+	}
+	complete := len(data)
+	for i := len(data) - 1; i >= 0 && i >= len(data)-utf8.UTFMax; i-- {
+		if utf8.RuneStart(data[i]) {
+			if !utf8.FullRune(data[i:]) {
+				complete = i
+			}
+			break
+		}
+	}
+	f.partial[stream] = append([]byte(nil), data[complete:]...)
+	f.add(stream, data[:complete])
+}
+
+// add appends the data to the pending chunks, starting a new chunk
+// when the stream changes or the last chunk is full. The caller must
+// hold f.mu.
+func (f *outputForwarder) add(stream string, data []byte) {
+	for len(data) > 0 {
+		n := len(f.pending)
+		if n == 0 || n <= f.sent || f.pending[n-1].Stream != stream || len(f.pending[n-1].Data)+utf8.UTFMax > maxOutputChunkSize {
+			f.seq++
+			f.pending = append(f.pending, params.OutputChunk{
+				Seq:    f.seq,
+				Stream: stream,
+				Time:   time.Now(),
+			})
+			n++
+		}
+		last := &f.pending[n-1]
+		size := maxOutputChunkSize - len(last.Data)
+		if size >= len(data) {
+			size = len(data)
+		} else {
+			// Don't split a character across chunks, unless the
+			// data is not valid UTF-8 anyway.
+			limit := size
+			for size > 0 && !utf8.RuneStart(data[size]) {
+				size--
+			}
+			if size == 0 {
+				size = limit
+			}
+		}
+		last.Data += string(data[:size])
+		data = data[size:]
+	}
+}
+
+// flush sends the pending chunks to the controller. Chunks that fail
+// to send are kept, and sent again with the next flush.
+func (f *outputForwarder) flush() {
+	f.mu.Lock()
+	chunks := f.pending
+	f.pending = nil
+	f.sent = 0
+	f.mu.Unlock()
+	if len(chunks) == 0 {
+		return
+	}
+	if err := f.context.SendOutput(chunks); err != nil {
+		logger.Warningf("cannot forward output: %v", err)
+		f.mu.Lock()
+		f.pending = append(chunks, f.pending...)
+		f.sent = len(chunks)
+		f.mu.Unlock()
+	}
+}
+
+// close stops the periodic forwarding of output, and sends any output
+// that is still pending.
+func (f *outputForwarder) close() {
+	close(f.stop)
+	<-f.done
+	f.mu.Lock()
+	for _, stream := range []string{params.OutputStdout, params.OutputStderr} {
+		f.add(stream, f.partial[stream])
+		delete(f.partial, stream)
+	}
+	f.mu.Unlock()
+	f.flush()
+}
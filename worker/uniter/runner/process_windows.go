@@ -0,0 +1,18 @@
+// Copyright 2015 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package runner
+
+import (
+	"os"
+	"os/exec"
+)
+
+// setProcessGroup does nothing on windows, where the command's process
+// is killed on its own.
+func setProcessGroup(cmd *exec.Cmd) {}
+
+// killProcessGroup kills the given process.
+func killProcessGroup(process *os.Process) error {
+	return process.Kill()
+}
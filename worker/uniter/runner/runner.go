@@ -4,16 +4,22 @@
 package runner
 
 import (
+	"bytes"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"syscall"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	utilexec "github.com/juju/utils/exec"
 
+	"github.com/juju/juju/api/watcher"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/version"
 	"github.com/juju/juju/worker/uniter/runner/debug"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
@@ -41,6 +47,10 @@ type Context interface {
 	Id() string
 	HookVars(paths Paths) []string
 	ActionData() (*ActionData, error)
+	WatchActionAbort() (watcher.NotifyWatcher, error)
+	ActionAborting() (bool, error)
+	OutputId() string
+	SendOutput(chunks []params.OutputChunk) error
 	SetProcess(process *os.Process)
 	FlushContext(badge string, failure error) error
 	HasExecutionSetUnitStatus() bool
@@ -92,6 +102,13 @@ func (runner *runner) RunCommands(commands string) (*utilexec.ExecResponse, erro
 	defer srv.Close()
 
 	env := runner.context.HookVars(runner.paths)
+	if runner.context.OutputId() != "" {
+		result, err := runner.runCommandsForwardingOutput(commands, env)
+		if err != nil {
+			return nil, err
+		}
+		return result, runner.context.FlushContext("run commands", nil)
+	}
 	command := utilexec.RunParams{
 		Commands:    commands,
 		WorkingDir:  runner.paths.GetCharmDir(),
@@ -108,6 +125,56 @@ func (runner *runner) RunCommands(commands string) (*utilexec.ExecResponse, erro
 	return result, runner.context.FlushContext("run commands", err)
 }
 
+// runCommandsForwardingOutput runs the supplied script as RunCommands
+// does, and forwards its output to the controller as it is produced.
+func (runner *runner) runCommandsForwardingOutput(commands string, env []string) (*utilexec.ExecResponse, error) {
+	if version.Current.OS == version.Windows {
+		env = mergeWindowsEnvironment(env, os.Environ())
+	}
+	ps := commandsCommand(commands)
+	ps.Env = env
+	ps.Dir = runner.paths.GetCharmDir()
+
+	forwarder := newOutputForwarder(runner.context)
+	defer forwarder.close()
+	var stdout, stderr bytes.Buffer
+	ps.Stdout = io.MultiWriter(&stdout, forwarder.writer(params.OutputStdout))
+	ps.Stderr = io.MultiWriter(&stderr, forwarder.writer(params.OutputStderr))
+	if err := ps.Start(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	runner.context.SetProcess(ps.Process)
+
+	// Block and wait for process to finish
+	code, err := exitCode(ps.Wait())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &utilexec.ExecResponse{
+		Code:   code,
+		Stdout: stdout.Bytes(),
+		Stderr: stderr.Bytes(),
+	}, nil
+}
+
+// exitCode returns the exit code of a process that exited with the
+// given error from Wait, or the error itself if the process did not
+// exit normally.
+func exitCode(err error) (int, error) {
+	if err == nil {
+		return 0, nil
+	}
+	exitErr, ok := err.(*exec.ExitError)
+	if !ok {
+		return 0, err
+	}
+	status, ok := exitErr.ProcessState.Sys().(syscall.WaitStatus)
+	if !ok {
+		return 0, err
+	}
+	return status.ExitStatus(), nil
+}
+
 // RunAction exists to satisfy the Runner interface.
 func (runner *runner) RunAction(actionName string) error {
 	if _, err := runner.context.ActionData(); err != nil {
@@ -160,30 +227,156 @@ func (runner *runner) runCharmHook(hookName string, env []string, charmLocation
 	ps := exec.Command(hookCmd[0], hookCmd[1:]...)
 	ps.Env = env
 	ps.Dir = charmDir
-	outReader, outWriter, err := os.Pipe()
+	isAction := charmLocation == "actions"
+	if isAction {
+		// Run actions in their own process group, so that everything
+		// they start can be stopped with them.
+		setProcessGroup(ps)
+	}
+	var forwarder *outputForwarder
+	if isAction && runner.context.OutputId() != "" {
+		forwarder = newOutputForwarder(runner.context)
+	}
+	outWriter, outLogger, err := runner.startHookLogger(hookName, forwarder, params.OutputStdout)
 	if err != nil {
-		return errors.Errorf("cannot make logging pipe: %v", err)
+		return err
 	}
-	ps.Stdout = outWriter
-	ps.Stderr = outWriter
-	hookLogger := &hookLogger{
-		r:      outReader,
-		done:   make(chan struct{}),
-		logger: runner.getLogger(hookName),
+	errWriter, errLogger := outWriter, outLogger
+	if forwarder != nil {
+		// Keep stderr apart from stdout, so that the two can be
+		// told apart in the forwarded output.
+		errWriter, errLogger, err = runner.startHookLogger(hookName, forwarder, params.OutputStderr)
+		if err != nil {
+			outWriter.Close()
+			outLogger.stop()
+			forwarder.close()
+			return err
+		}
 	}
-	go hookLogger.run()
+	ps.Stdout = outWriter
+	ps.Stderr = errWriter
 	err = ps.Start()
 	outWriter.Close()
+	if errWriter != outWriter {
+		errWriter.Close()
+	}
 	if err == nil {
 		// Record the *os.Process of the hook
 		runner.context.SetProcess(ps.Process)
+		if isAction {
+			stop := runner.superviseAction(ps.Process)
+			defer stop()
+		}
 		// Block until execution finishes
 		err = ps.Wait()
 	}
-	hookLogger.stop()
+	outLogger.stop()
+	if forwarder != nil {
+		errLogger.stop()
+		forwarder.close()
+	}
 	return errors.Trace(err)
 }
 
+// startHookLogger returns the writing end of a pipe for the output of
+// the named hook, and starts a hookLogger that logs whatever is written
+// to it. If forwarder is not nil, the output is also forwarded to the
+// controller as the given stream.
+func (runner *runner) startHookLogger(hookName string, forwarder *outputForwarder, stream string) (*os.File, *hookLogger, error) {
+	outReader, outWriter, err := os.Pipe()
+	if err != nil {
+		return nil, nil, errors.Errorf("cannot make logging pipe: %v", err)
+	}
+	hookLogger := &hookLogger{
+		r:      outReader,
+		done:   make(chan struct{}),
+		logger: runner.getLogger(hookName),
+	}
+	if forwarder != nil {
+		hookLogger.out = forwarder.writer(stream)
+	}
+	go hookLogger.run()
+	return outWriter, hookLogger, nil
+}
+
+// superviseAction stops the process group of the running action when
+// the action is aborted, or when it runs for longer than its timeout,
+// and records why it was stopped. The returned function must be called
+// once the process has exited.
+func (runner *runner) superviseAction(process *os.Process) func() {
+	data, err := runner.context.ActionData()
+	if err != nil {
+		logger.Errorf("cannot supervise action: %v", err)
+		return func() {}
+	}
+	var changes <-chan struct{}
+	w, err := runner.context.WatchActionAbort()
+	if errors.IsNotImplemented(err) {
+		logger.Warningf("cannot watch for action %q to be aborted: %v", data.ActionTag.Id(), err)
+	} else if err != nil {
+		logger.Errorf("cannot watch for action %q to be aborted: %v", data.ActionTag.Id(), err)
+	} else {
+		changes = w.Changes()
+	}
+	var timer *time.Timer
+	var timeout <-chan time.Time
+	if data.ActionTimeout > 0 {
+		timer = time.NewTimer(data.ActionTimeout)
+		timeout = timer.C
+	}
+	done := make(chan struct{})
+	finished := make(chan struct{})
+	go func() {
+		defer close(finished)
+		for {
+			select {
+			case <-done:
+				return
+			case <-timeout:
+				logger.Infof("action %q timed out after %v", data.ActionTag.Id(), data.ActionTimeout)
+				runner.stopAction(data, process, params.ActionTimedOut)
+				return
+			case _, ok := <-changes:
+				if !ok {
+					changes = nil
+					continue
+				}
+				aborting, err := runner.context.ActionAborting()
+				if err != nil {
+					logger.Errorf("cannot check whether action %q is aborting: %v", data.ActionTag.Id(), err)
+					continue
+				}
+				if aborting {
+					logger.Infof("action %q aborted", data.ActionTag.Id())
+					runner.stopAction(data, process, params.ActionAborted)
+					return
+				}
+			}
+		}
+	}()
+	return func() {
+		close(done)
+		<-finished
+		if timer != nil {
+			timer.Stop()
+		}
+		if w != nil {
+			if err := w.Stop(); err != nil {
+				logger.Errorf("cannot stop watching action %q: %v", data.ActionTag.Id(), err)
+			}
+		}
+	}
+}
+
+// stopAction kills the process group of the running action, and
+// records the status with which the action was stopped.
+func (runner *runner) stopAction(data *ActionData, process *os.Process, status string) {
+	data.ActionStopped = status
+	if err := killProcessGroup(process); err != nil {
+		logger.Errorf("cannot stop action %q: %v", data.ActionTag.Id(), err)
+	}
+}
+
 func (runner *runner) startJujucServer() (*jujuc.Server, error) {
 	// Prepare server.
 	getCmd := func(ctxId, cmdName string) (cmd.Command, error) {
@@ -18,6 +18,8 @@ import (
 	"github.com/juju/utils"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/api/watcher"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/worker/uniter/runner"
 )
 
@@ -147,6 +149,11 @@ func (s *RunHookSuite) TestRunHook(c *gc.C) {
 type MockContext struct {
 	runner.Context
 	actionData   *runner.ActionData
+	abortWatcher *mockNotifyWatcher
+	aborting     bool
+	outputId     string
+	output       []params.OutputChunk
+	outputErrors int
 	expectPid    int
 	flushBadge   string
 	flushFailure error
@@ -168,6 +175,41 @@ func (ctx *MockContext) ActionData() (*runner.ActionData, error) {
 	return ctx.actionData, nil
 }
 
+func (ctx *MockContext) WatchActionAbort() (watcher.NotifyWatcher, error) {
+	if ctx.abortWatcher == nil {
+		return nil, errors.NotImplementedf("WatchActionAbort")
+	}
+	return ctx.abortWatcher, nil
+}
+
+func (ctx *MockContext) ActionAborting() (bool, error) {
+	return ctx.aborting, nil
+}
+
+func (ctx *MockContext) OutputId() string {
+	return ctx.outputId
+}
+
+func (ctx *MockContext) SendOutput(chunks []params.OutputChunk) error {
+	if ctx.outputErrors > 0 {
+		ctx.outputErrors--
+		return errors.New("cannot send output")
+	}
+	ctx.output = append(ctx.output, chunks...)
+	return nil
+}
+
+// forwardedOutput returns the output forwarded via the context to
+// each stream, checking that the chunks were numbered in order.
+func (ctx *MockContext) forwardedOutput(c *gc.C) map[string]string {
+	output := make(map[string]string)
+	for i, chunk := range ctx.output {
+		c.Check(chunk.Seq, gc.Equals, i+1)
+		output[chunk.Stream] += chunk.Data
+	}
+	return output
+}
+
 func (ctx *MockContext) SetProcess(process *os.Process) {
 	ctx.expectPid = process.Pid
 }
@@ -178,6 +220,24 @@ func (ctx *MockContext) FlushContext(badge string, failure error) error {
 	return ctx.flushResult
 }
 
+type mockNotifyWatcher struct {
+	changes chan struct{}
+	stopped bool
+}
+
+func (w *mockNotifyWatcher) Changes() <-chan struct{} {
+	return w.changes
+}
+
+func (w *mockNotifyWatcher) Stop() error {
+	w.stopped = true
+	return nil
+}
+
+func (w *mockNotifyWatcher) Err() error {
+	return nil
+}
+
 type RunMockContextSuite struct {
 	envtesting.IsolationSuite
 	paths RealPaths
@@ -270,6 +330,122 @@ func (s *RunMockContextSuite) TestRunActionFlushFailure(c *gc.C) {
 	s.assertRecordedPid(c, ctx.expectPid)
 }
 
+func (s *RunMockContextSuite) TestRunActionTimeout(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("process groups are not used on windows")
+	}
+	ctx := &MockContext{
+		actionData: &runner.ActionData{ActionTimeout: 100 * time.Millisecond},
+	}
+	makeCharm(c, hookSpec{
+		dir:   "actions",
+		name:  hookName,
+		perm:  0700,
+		sleep: 10,
+	}, s.paths.charm)
+	started := time.Now()
+	err := runner.NewRunner(ctx, s.paths).RunAction("something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(time.Since(started) < 5*time.Second, jc.IsTrue)
+	c.Assert(ctx.actionData.ActionStopped, gc.Equals, "timed-out")
+	c.Assert(ctx.flushFailure, gc.ErrorMatches, "signal: killed")
+}
+
+func (s *RunMockContextSuite) TestRunActionAbort(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("process groups are not used on windows")
+	}
+	w := &mockNotifyWatcher{changes: make(chan struct{})}
+	ctx := &MockContext{
+		actionData:   &runner.ActionData{},
+		abortWatcher: w,
+		aborting:     true,
+	}
+	makeCharm(c, hookSpec{
+		dir:   "actions",
+		name:  hookName,
+		perm:  0700,
+		sleep: 10,
+	}, s.paths.charm)
+	go func() {
+		w.changes <- struct{}{}
+	}()
+	started := time.Now()
+	err := runner.NewRunner(ctx, s.paths).RunAction("something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(time.Since(started) < 5*time.Second, jc.IsTrue)
+	c.Assert(ctx.actionData.ActionStopped, gc.Equals, "aborted")
+	c.Assert(ctx.flushFailure, gc.ErrorMatches, "signal: killed")
+	c.Assert(w.stopped, jc.IsTrue)
+}
+
+func (s *RunMockContextSuite) TestRunActionForwardsOutput(c *gc.C) {
+	ctx := &MockContext{
+		actionData: &runner.ActionData{},
+		outputId:   "action-some-id",
+	}
+	makeCharm(c, hookSpec{
+		dir:    "actions",
+		name:   hookName,
+		perm:   0700,
+		stdout: "hello",
+		stderr: "oops",
+	}, s.paths.charm)
+	err := runner.NewRunner(ctx, s.paths).RunAction("something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.flushFailure, gc.IsNil)
+	output := ctx.forwardedOutput(c)
+	c.Assert(strings.TrimRight(output["stdout"], "\r\n"), gc.Equals, "hello")
+	c.Assert(strings.TrimRight(output["stderr"], "\r\n"), gc.Equals, "oops")
+}
+
+func (s *RunMockContextSuite) TestRunActionRetriesOutput(c *gc.C) {
+	s.PatchValue(runner.OutputFlushInterval, 10*time.Millisecond)
+	ctx := &MockContext{
+		actionData:   &runner.ActionData{},
+		outputId:     "action-some-id",
+		outputErrors: 1,
+	}
+	makeCharm(c, hookSpec{
+		dir:    "actions",
+		name:   hookName,
+		perm:   0700,
+		stdout: "hello",
+		stderr: "oops",
+		sleep:  1,
+	}, s.paths.charm)
+	err := runner.NewRunner(ctx, s.paths).RunAction("something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.outputErrors, gc.Equals, 0)
+
+	// The output that failed to send was sent again later.
+	output := ctx.forwardedOutput(c)
+	c.Assert(strings.TrimRight(output["stdout"], "\r\n"), gc.Equals, "hello")
+	c.Assert(strings.TrimRight(output["stderr"], "\r\n"), gc.Equals, "oops")
+}
+
+func (s *RunMockContextSuite) TestRunCommandsForwardsOutput(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("commands are run with powershell on windows")
+	}
+	ctx := &MockContext{
+		outputId: "c8a71ff5-4e8c-4ed7-8c45-ad5f3c8f9b4d",
+	}
+	result, err := runner.NewRunner(ctx, s.paths).RunCommands(
+		echoPidScript + "; echo hello; echo oops >&2; exit 3",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Code, gc.Equals, 3)
+	c.Assert(string(result.Stdout), gc.Equals, "hello\n")
+	c.Assert(string(result.Stderr), gc.Equals, "oops\n")
+	c.Assert(ctx.flushBadge, gc.Equals, "run commands")
+	c.Assert(ctx.forwardedOutput(c), jc.DeepEquals, map[string]string{
+		"stdout": "hello\n",
+		"stderr": "oops\n",
+	})
+	s.assertRecordedPid(c, ctx.expectPid)
+}
+
 func (s *RunMockContextSuite) TestRunCommandsFlushSuccess(c *gc.C) {
 	expectErr := errors.New("pew pew pew")
 	ctx := &MockContext{
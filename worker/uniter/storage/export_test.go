@@ -28,8 +28,16 @@ func StateAttached(s State) bool {
 	return s.(*stateFile).attached
 }
 
+func StateSize(s State) uint64 {
+	return s.(*stateFile).size
+}
+
+func CommitHookWithSize(s State, hi hook.Info, size uint64) error {
+	return s.(*stateFile).commitHook(hi, size)
+}
+
 func ValidateHook(tag names.StorageTag, attached bool, hi hook.Info) error {
-	st := &state{tag, attached}
+	st := &state{tag, attached, 0}
 	return st.ValidateHook(hi)
 }
 
@@ -54,11 +62,13 @@ func NewStorageHookQueue(
 	unitTag names.UnitTag,
 	storageTag names.StorageTag,
 	attached bool,
+	size uint64,
 ) StorageHookQueue {
 	return &storageHookQueue{
 		unitTag:    unitTag,
 		storageTag: storageTag,
 		attached:   attached,
+		size:       size,
 	}
 }
 
@@ -68,6 +78,6 @@ func NewStorageSource(
 	storageTag names.StorageTag,
 	attached bool,
 ) (hook.Source, error) {
-	source, err := newStorageSource(st, unitTag, storageTag, attached)
+	source, err := newStorageSource(st, unitTag, storageTag, attached, 0)
 	return source, err
 }
@@ -38,6 +38,12 @@ type storageHookQueue struct {
 	// hook has been executed.
 	attached bool
 
+	// size records the size of the storage, in MiB, as of the most
+	// recently queued hook. A size of zero means the size is unknown,
+	// in which case no storage-resized hook will be queued until the
+	// size has been observed.
+	size uint64
+
 	// hookInfo is the next hook.Info to return, if non-nil.
 	hookInfo *hook.Info
 
@@ -52,6 +58,7 @@ func newStorageSource(
 	unitTag names.UnitTag,
 	storageTag names.StorageTag,
 	attached bool,
+	size uint64,
 ) (*storageSource, error) {
 	w, err := st.WatchStorageAttachment(storageTag, unitTag)
 	if err != nil {
@@ -62,6 +69,7 @@ func newStorageSource(
 			unitTag:    unitTag,
 			storageTag: storageTag,
 			attached:   attached,
+			size:       size,
 		},
 		st:      st,
 		watcher: w,
@@ -170,10 +178,10 @@ func (s *storageHookQueue) Update(attachment params.StorageAttachment) error {
 	switch attachment.Life {
 	case params.Alive:
 		if s.attached {
-			// Storage attachments currently do not change
-			// (apart from lifecycle) after being provisioned.
-			// We don't process unprovisioned storage here,
-			// so there's nothing to do.
+			// The only change to an attached storage
+			// attachment that we respond to (apart from
+			// lifecycle) is the backing volume growing.
+			s.updateSize(attachment)
 			return nil
 		}
 	case params.Dying:
@@ -199,7 +207,9 @@ func (s *storageHookQueue) Update(attachment params.StorageAttachment) error {
 			tag:      s.storageTag,
 			kind:     storage.StorageKind(attachment.Kind),
 			location: attachment.Location,
+			size:     attachment.Size,
 		}
+		s.size = attachment.Size
 	}
 
 	if s.hookInfo == nil {
@@ -216,6 +226,45 @@ func (s *storageHookQueue) Update(attachment params.StorageAttachment) error {
 	return nil
 }
 
+// updateSize queues a storage-resized hook if the attached storage has
+// grown since the size was last recorded.
+func (s *storageHookQueue) updateSize(attachment params.StorageAttachment) {
+	if attachment.Size <= s.size {
+		return
+	}
+	if s.size == 0 {
+		// We have no record of the previous size, so we
+		// cannot tell whether the storage has been resized.
+		s.size = attachment.Size
+		return
+	}
+	s.size = attachment.Size
+	// Replace rather than modify the context, as it may be
+	// in use by a running hook.
+	s.context = &contextStorage{
+		tag:      s.storageTag,
+		kind:     storage.StorageKind(attachment.Kind),
+		location: attachment.Location,
+		size:     attachment.Size,
+	}
+	if s.hookInfo == nil {
+		s.hookInfo = &hook.Info{
+			Kind:      hook.StorageResized,
+			StorageId: s.storageTag.Id(),
+		}
+		logger.Debugf("queued hook: %v", s.hookInfo)
+	}
+}
+
+// contextSize returns the size of the storage, in MiB, recorded in the
+// storage context, or zero if there is no context.
+func (s *storageHookQueue) contextSize() uint64 {
+	if s.context != nil {
+		return s.context.size
+	}
+	return 0
+}
+
 // Context returns the ContextStorage for the storage that this hook queue
 // corresponds to, and whether there is any context available yet. There
 // will be context beginning from when the first hook is queued.
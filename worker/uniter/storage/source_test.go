@@ -29,13 +29,28 @@ type storageHookQueueSuite struct {
 var _ = gc.Suite(&storageHookQueueSuite{})
 
 func newHookQueue(attached bool) storage.StorageHookQueue {
+	return newSizedHookQueue(attached, 0)
+}
+
+func newSizedHookQueue(attached bool, size uint64) storage.StorageHookQueue {
 	return storage.NewStorageHookQueue(
 		names.NewUnitTag("mysql/0"),
 		names.NewStorageTag("data/0"),
 		attached,
+		size,
 	)
 }
 
+func resizeHookQueue(c *gc.C, q storage.StorageHookQueue, size uint64) {
+	err := q.Update(params.StorageAttachment{
+		Life:     params.Alive,
+		Kind:     params.StorageKindBlock,
+		Location: "/dev/sdb",
+		Size:     size,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func updateHookQueue(c *gc.C, q storage.StorageHookQueue, life params.Life) {
 	err := q.Update(params.StorageAttachment{
 		Life:     life,
@@ -113,6 +128,62 @@ func (s *storageHookQueueSuite) TestStorageHookQueueDead(c *gc.C) {
 	c.Assert(q.Empty(), jc.IsTrue)
 }
 
+func (s *storageHookQueueSuite) TestStorageHookQueueResized(c *gc.C) {
+	q := newSizedHookQueue(initiallyAttached, 1024)
+	resizeHookQueue(c, q, 1024)
+	c.Assert(q.Empty(), jc.IsTrue)
+
+	resizeHookQueue(c, q, 2048)
+	c.Assert(q.Empty(), jc.IsFalse)
+	c.Assert(q.Next(), gc.Equals, hook.Info{
+		Kind:      hook.StorageResized,
+		StorageId: "data/0",
+	})
+	ctx, ok := q.Context()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(ctx.Location(), gc.Equals, "/dev/sdb")
+	q.Pop()
+
+	// The same size does not queue another hook.
+	resizeHookQueue(c, q, 2048)
+	c.Assert(q.Empty(), jc.IsTrue)
+}
+
+func (s *storageHookQueueSuite) TestStorageHookQueueResizedUnknownSize(c *gc.C) {
+	q := newSizedHookQueue(initiallyAttached, 0)
+	// The previous size is not known, so no hook is queued;
+	// the size is recorded for next time.
+	resizeHookQueue(c, q, 1024)
+	c.Assert(q.Empty(), jc.IsTrue)
+	resizeHookQueue(c, q, 2048)
+	c.Assert(q.Empty(), jc.IsFalse)
+	c.Assert(q.Next().Kind, gc.Equals, hook.StorageResized)
+}
+
+func (s *storageHookQueueSuite) TestStorageHookQueueAttachedRecordsSize(c *gc.C) {
+	q := newHookQueue(initiallyUnattached)
+	resizeHookQueue(c, q, 1024)
+	c.Assert(q.Next().Kind, gc.Equals, hooks.StorageAttached)
+	q.Pop()
+
+	resizeHookQueue(c, q, 2048)
+	c.Assert(q.Empty(), jc.IsFalse)
+	c.Assert(q.Next().Kind, gc.Equals, hook.StorageResized)
+}
+
+func (s *storageHookQueueSuite) TestStorageHookQueueResizedDetach(c *gc.C) {
+	q := newSizedHookQueue(initiallyAttached, 1024)
+	resizeHookQueue(c, q, 2048)
+	c.Assert(q.Next().Kind, gc.Equals, hook.StorageResized)
+	// The unconsumed storage-resized hook is superseded
+	// by storage-detaching.
+	updateHookQueue(c, q, params.Dying)
+	c.Assert(q.Next(), gc.Equals, hook.Info{
+		Kind:      hooks.StorageDetaching,
+		StorageId: "data/0",
+	})
+}
+
 func (s *storageHookQueueSuite) TestStorageHookQueueContext(c *gc.C) {
 	q := newHookQueue(initiallyUnattached)
 	_, ok := q.Context()
@@ -30,7 +30,7 @@ func newStorager(
 	state *stateFile,
 	hooks chan<- hook.Info,
 ) (*storager, error) {
-	source, err := newStorageSource(st, unitTag, storageTag, state.attached)
+	source, err := newStorageSource(st, unitTag, storageTag, state.attached, state.size)
 	if err != nil {
 		return nil, errors.Annotate(err, "creating storage event source")
 	}
@@ -63,5 +63,8 @@ func (s *storager) Context() (jujuc.ContextStorageAttachment, bool) {
 // CommitHook persists the state change encoded in the supplied storage
 // hook, or returns an error if the hook is invalid given current state.
 func (s *storager) CommitHook(hi hook.Info) error {
-	return s.state.CommitHook(hi)
+	// Record the size of the storage that the hook was run
+	// with, so that a restarted agent will notice any resize
+	// that has not yet been handled.
+	return s.state.commitHook(hi, s.source.contextSize())
 }
@@ -90,6 +90,9 @@ type Uniter struct {
 	// updateStatusAt defines a function that will be used to generate signals for
 	// the update-status hook
 	updateStatusAt TimedSignal
+
+	// healthChecks runs the health checks declared by the deployed charm.
+	healthChecks healthChecks
 }
 
 // NewUniter creates a new Uniter which will install, run, and upgrade
@@ -158,6 +161,7 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 		return err
 	}
 	u.addCleanup(u.f.Stop)
+	u.addCleanup(u.stopHealthChecks)
 
 	// Stop the uniter if either of these components fails.
 	go func() { u.tomb.Kill(leadershipTracker.Wait()) }()
@@ -359,6 +363,7 @@ func (u *Uniter) RunCommands(args RunCommandsArgs) (results *exec.ExecResponse,
 		RelationId:      args.RelationId,
 		RemoteUnitName:  args.RemoteUnitName,
 		ForceRemoteUnit: args.ForceRemoteUnit,
+		OutputId:        args.OutputId,
 	}
 	err = u.runOperation(newCommandsOp(commandArgs, sendResponse))
 	if err == nil {
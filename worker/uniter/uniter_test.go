@@ -296,6 +296,57 @@ func (s *UniterSuite) TestUniterMultipleErrors(c *gc.C) {
 	})
 }
 
+func (s *UniterSuite) TestUniterHookRetry(c *gc.C) {
+	s.runUniterTests(c, []uniterTest{
+		ut(
+			"failed hook is retried until attempts are exhausted",
+			setHookRetryPolicy{attempts: 2, delay: "10ms"},
+			createCharm{badHooks: []string{"install"}},
+			serveCharm{},
+			createUniter{},
+			waitHooks{"fail-install", "fail-install", "fail-install"},
+			waitUnitAgent{
+				statusGetter: unitStatusGetter,
+				status:       params.StatusError,
+				info:         `hook failed: "install"`,
+			},
+			verifyWaiting{},
+
+			resolveError{state.ResolvedNoHooks},
+			waitUnitAgent{
+				status: params.StatusIdle,
+			},
+			waitHooks{"leader-elected", "config-changed", "start"},
+		), ut(
+			"pending retry is reported and can be cancelled",
+			setHookRetryPolicy{attempts: 5, delay: "1h"},
+			createCharm{badHooks: []string{"install"}},
+			serveCharm{},
+			createUniter{},
+			waitHooks{"fail-install"},
+			waitUnitAgent{
+				statusGetter: unitStatusGetter,
+				status:       params.StatusError,
+				info:         `hook failed: "install" (retrying in 1h0m0s, attempt 1 of 5)`,
+			},
+
+			resolveError{state.ResolvedCancelRetry},
+			waitUnitAgent{
+				statusGetter: unitStatusGetter,
+				status:       params.StatusError,
+				info:         `hook failed: "install"`,
+			},
+			verifyWaiting{},
+
+			resolveError{state.ResolvedNoHooks},
+			waitUnitAgent{
+				status: params.StatusIdle,
+			},
+			waitHooks{"leader-elected", "config-changed", "start"},
+		),
+	})
+}
+
 func (s *UniterSuite) TestUniterConfigChangedHook(c *gc.C) {
 	s.runUniterTests(c, []uniterTest{
 		ut(
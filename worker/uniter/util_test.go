@@ -1332,6 +1332,21 @@ func (s setProxySettings) step(c *gc.C, ctx *context) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+type setHookRetryPolicy struct {
+	attempts int
+	delay    string
+}
+
+func (s setHookRetryPolicy) step(c *gc.C, ctx *context) {
+	attrs := map[string]interface{}{
+		"hook-retry-attempts":  s.attempts,
+		"hook-retry-delay":     s.delay,
+		"hook-retry-max-delay": s.delay,
+	}
+	err := ctx.st.UpdateEnvironConfig(attrs, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 type relationRunCommands []string
 
 func (cmds relationRunCommands) step(c *gc.C, ctx *context) {